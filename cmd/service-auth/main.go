@@ -1,11 +1,13 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/signal"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/casbin/casbin"
 	"github.com/opentracing/opentracing-go"
@@ -17,8 +19,19 @@ import (
 
 	"github.com/ssup2ket/service-auth/internal/config"
 	"github.com/ssup2ket/service-auth/internal/domain"
+	"github.com/ssup2ket/service-auth/internal/domain/service"
 	"github.com/ssup2ket/service-auth/internal/server/grpc_server"
 	"github.com/ssup2ket/service-auth/internal/server/http_server"
+	"github.com/ssup2ket/service-auth/internal/server/middleware"
+	"github.com/ssup2ket/service-auth/pkg/auth/hashing"
+	"github.com/ssup2ket/service-auth/pkg/auth/token"
+)
+
+const (
+	tokenRevocationSyncPeriod = 10 * time.Second
+	policyVersionSyncPeriod   = 10 * time.Second
+	loginLockCleanupPeriod    = time.Minute
+	rateLimitCleanupPeriod    = time.Minute
 )
 
 func main() {
@@ -39,13 +52,9 @@ func main() {
 	}
 
 	// Print config and starting
-	log.Info().Str("config", fmt.Sprintf("%+v", *cfg)).Send()
+	log.Info().Str("config", fmt.Sprintf("%+v", cfg.GetMasked())).Send()
 	log.Info().Msg("Starting ssup2ket auth service...")
 
-	// Init Casbin for RBAC
-	enforcerHTTP := casbin.NewEnforcer("configs/rbac_http_model.conf", "configs/rbac_http_policy.csv")
-	enforcerGRPC := casbin.NewEnforcer("configs/rbac_grpc_model.conf", "configs/rbac_grpc_policy.csv")
-
 	// Set jeager tracer config
 	jeagerCfg := jaegercfg.Configuration{
 		ServiceName: "service-auth-" + string(cfg.DeployEnv),
@@ -80,8 +89,74 @@ func main() {
 		log.Fatal().Err(err).Msg("Failed to create domain instance")
 	}
 
-	// Init and run HTTP server
-	httpServer, err := http_server.New(d, cfg.ServerURL, enforcerHTTP)
+	// Init token config
+	tokenConfig, err := getTokenConfig(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to init token config")
+	}
+	token.SetConfig(tokenConfig)
+
+	// Init password hashing config
+	hashingConfig, err := getHashingConfig(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to init password hashing config")
+	}
+	hashing.SetConfig(hashingConfig)
+
+	// Init token key provider
+	if d.Keyring != nil {
+		ctx := log.Logger.WithContext(context.Background())
+		if err := d.Key.SyncTokenKeys(ctx); err != nil {
+			log.Fatal().Err(err).Msg("Failed to sync token keys")
+		}
+		token.SetKeyProvider(d.Keyring)
+		go syncTokenKeys(ctx, d)
+	} else {
+		keyProvider, err := getTokenKeyProvider(cfg)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to init token key provider")
+		}
+		token.SetKeyProvider(keyProvider)
+	}
+
+	// Init token revocations
+	ctx := log.Logger.WithContext(context.Background())
+	if err := d.TokenRevocation.SyncTokenRevocations(ctx); err != nil {
+		log.Fatal().Err(err).Msg("Failed to sync token revocations")
+	}
+	go syncTokenRevocations(ctx, d)
+
+	// Clean up login locks whose failures are reset
+	go cleanupLoginLocks(ctx, d)
+
+	// Init default tenant
+	if err := d.Tenant.CreateDefaultTenant(ctx); err != nil {
+		log.Fatal().Err(err).Msg("Failed to create default tenant")
+	}
+
+	// Init roles and Casbin for RBAC
+	if err := d.Role.CreateDefaultRoles(ctx); err != nil {
+		log.Fatal().Err(err).Msg("Failed to create default roles")
+	}
+	enforcer, err := getEnforcer(ctx, d, "configs/rbac_model.conf", "configs/rbac_policy.csv")
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to init enforcer")
+	}
+
+	// Init rate limiter shared by HTTP and GRPC servers
+	rateLimits, err := middleware.ParseRateLimits(cfg.RateLimits)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to parse rate limits")
+	}
+	rateLimiter := middleware.NewRateLimiter(rateLimits)
+	go cleanupRateLimiter(rateLimiter)
+
+	// Init and run HTTP server. Only trusted proxies can forward client IPs.
+	trustedProxies, err := middleware.ParseTrustedProxies(cfg.TrustedProxies)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to parse trusted proxies")
+	}
+	httpServer, err := http_server.New(d, cfg.ServerURL, cfg.TenantDomain, enforcer, rateLimiter, trustedProxies)
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to create HTTP server")
 	}
@@ -89,7 +164,7 @@ func main() {
 	httpServer.ListenAndServe()
 
 	// Init and run GRPC server
-	grpcServer, err := grpc_server.New(d, enforcerGRPC)
+	grpcServer, err := grpc_server.New(d, cfg.TenantDomain, enforcer, rateLimiter)
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to create GRPC server")
 	}
@@ -117,3 +192,117 @@ func main() {
 	}()
 	wg.Wait()
 }
+
+func getTokenConfig(cfg *config.Configs) (token.Config, error) {
+	tokenConfig := token.GetDefaultConfig()
+	tokenConfig.Issuer = cfg.TokenIssuer
+	tokenConfig.Audience = cfg.TokenAudience
+	tokenConfig.Audiences = cfg.TokenAudiences
+
+	var err error
+	if tokenConfig.AccessTokenLifetime, err = time.ParseDuration(cfg.TokenAccessLifetime); err != nil || tokenConfig.AccessTokenLifetime <= 0 {
+		return tokenConfig, fmt.Errorf("wrong access token lifetime")
+	}
+	if tokenConfig.RefreshTokenLifetime, err = time.ParseDuration(cfg.TokenRefreshLifetime); err != nil || tokenConfig.RefreshTokenLifetime <= 0 {
+		return tokenConfig, fmt.Errorf("wrong refresh token lifetime")
+	}
+	if tokenConfig.Leeway, err = time.ParseDuration(cfg.TokenLeeway); err != nil || tokenConfig.Leeway < 0 {
+		return tokenConfig, fmt.Errorf("wrong token leeway")
+	}
+	return tokenConfig, nil
+}
+
+func getHashingConfig(cfg *config.Configs) (hashing.Config, error) {
+	hashingConfig := hashing.GetDefaultConfig()
+	hashingConfig.Alg = hashing.Alg(cfg.PasswdHashAlg)
+	if hashingConfig.Alg != hashing.AlgArgon2id && hashingConfig.Alg != hashing.AlgBcrypt {
+		return hashingConfig, fmt.Errorf("wrong password hash algorithm")
+	}
+	return hashingConfig, nil
+}
+
+func getTokenKeyProvider(cfg *config.Configs) (token.KeyProvider, error) {
+	// Get keys from mounted key files
+	if cfg.TokenAccessKeyFile != "" || cfg.TokenRefreshKeyFile != "" {
+		return token.NewFileKeyProvider(cfg.TokenAccessAlg, cfg.TokenAccessKeyFile, cfg.TokenRefreshKeyFile)
+	}
+
+	// Get keys from env
+	if cfg.TokenAccessKey != "" || cfg.TokenRefreshKey != "" {
+		return token.NewBytesKeyProvider(cfg.TokenAccessAlg, []byte(cfg.TokenAccessKey), []byte(cfg.TokenRefreshKey))
+	}
+
+	// Use random keys only for local env
+	if cfg.DeployEnv == config.DeployEnvLocal {
+		log.Warn().Msg("No token key is configured, so random token keys are used")
+		return token.NewRandomKeyProvider(cfg.TokenAccessAlg)
+	}
+	return nil, fmt.Errorf("no token key is configured")
+}
+
+// Get an enforcer loading permissions from DB. The enforcer is shared by HTTP and GRPC servers. Permissions are
+// initialized with the policy file only at first, and the watcher reloads permissions when they are changed by any replica.
+func getEnforcer(ctx context.Context, d *domain.Domain, modelPath, policyPath string) (*casbin.SyncedEnforcer, error) {
+	// Init permissions with the policy file
+	fileEnforcer := casbin.NewEnforcer(modelPath, policyPath)
+	if err := d.Permission.InitPermissions(ctx, fileEnforcer.GetPolicy()); err != nil {
+		return nil, err
+	}
+
+	// Create watcher before loading permissions not to miss changes
+	watcher, err := d.NewCasbinWatcher(policyVersionSyncPeriod)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create enforcer and load permissions
+	enforcer := casbin.NewSyncedEnforcer(modelPath, d.NewCasbinAdapter())
+	if err := enforcer.LoadPolicy(); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+	enforcer.SetWatcher(watcher)
+	return enforcer, nil
+}
+
+// Sync token keys periodically to get keys rotated by other replicas or to rotate keys by schedule
+func syncTokenKeys(ctx context.Context, d *domain.Domain) {
+	ticker := time.NewTicker(service.TokenKeySyncPeriod)
+	defer ticker.Stop()
+	for range ticker.C {
+		if err := d.Key.SyncTokenKeys(ctx); err != nil {
+			log.Error().Err(err).Msg("Failed to sync token keys")
+		}
+	}
+}
+
+// Sync token revocations periodically to get revocations by other replicas and to delete expired revocations
+func syncTokenRevocations(ctx context.Context, d *domain.Domain) {
+	ticker := time.NewTicker(tokenRevocationSyncPeriod)
+	defer ticker.Stop()
+	for range ticker.C {
+		if err := d.TokenRevocation.SyncTokenRevocations(ctx); err != nil {
+			log.Error().Err(err).Msg("Failed to sync token revocations")
+		}
+	}
+}
+
+// Delete login locks periodically whose failures are reset
+func cleanupLoginLocks(ctx context.Context, d *domain.Domain) {
+	ticker := time.NewTicker(loginLockCleanupPeriod)
+	defer ticker.Stop()
+	for range ticker.C {
+		if err := d.LoginLock.DeleteExpiredLoginLocks(ctx); err != nil {
+			log.Error().Err(err).Msg("Failed to clean up login locks")
+		}
+	}
+}
+
+// Delete full token buckets of the rate limiter periodically not to keep buckets of all clients
+func cleanupRateLimiter(limiter *middleware.RateLimiter) {
+	ticker := time.NewTicker(rateLimitCleanupPeriod)
+	defer ticker.Stop()
+	for range ticker.C {
+		limiter.DeleteFullBuckets()
+	}
+}
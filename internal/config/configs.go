@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"strings"
 )
 
 // Config
@@ -27,6 +28,73 @@ const (
 
 	// Jaeger
 	EnvJaegerCollectorEndpoint = "JAEGER_COLLECTOR_ENDPOINT"
+
+	// Token
+	EnvTokenAccessAlg      = "TOKEN_ACCESS_ALG"
+	EnvTokenAccessKey      = "TOKEN_ACCESS_KEY"
+	EnvTokenRefreshKey     = "TOKEN_REFRESH_KEY"
+	EnvTokenAccessKeyFile  = "TOKEN_ACCESS_KEY_FILE"
+	EnvTokenRefreshKeyFile = "TOKEN_REFRESH_KEY_FILE"
+
+	EnvTokenAccessLifetime  = "TOKEN_ACCESS_LIFETIME"
+	EnvTokenRefreshLifetime = "TOKEN_REFRESH_LIFETIME"
+	EnvTokenIssuer          = "TOKEN_ISSUER"
+	EnvTokenAudience        = "TOKEN_AUDIENCE"
+	EnvTokenAudiences       = "TOKEN_AUDIENCES"
+	EnvTokenLeeway          = "TOKEN_LEEWAY"
+
+	EnvTokenKeyMode             = "TOKEN_KEY_MODE"
+	EnvTokenKeyringSecret       = "TOKEN_KEYRING_SECRET"
+	EnvTokenKeyRotationInterval = "TOKEN_KEY_ROTATION_INTERVAL"
+
+	EnvTokenRevocationStore = "TOKEN_REVOCATION_STORE"
+
+	// Password
+	EnvPasswdHashAlg          = "PASSWD_HASH_ALG"
+	EnvPasswdMinLength        = "PASSWD_MIN_LENGTH"
+	EnvPasswdMaxLength        = "PASSWD_MAX_LENGTH"
+	EnvPasswdMinCharClasses   = "PASSWD_MIN_CHAR_CLASSES"
+	EnvPasswdBreachedListFile = "PASSWD_BREACHED_LIST_FILE"
+	EnvPasswdHistorySize      = "PASSWD_HISTORY_SIZE"
+
+	// Login lock
+	EnvLoginLockStore       = "LOGIN_LOCK_STORE"
+	EnvLoginLockThreshold   = "LOGIN_LOCK_THRESHOLD"
+	EnvLoginLockIPThreshold = "LOGIN_LOCK_IP_THRESHOLD"
+	EnvLoginLockDuration    = "LOGIN_LOCK_DURATION"
+	EnvLoginLockMaxDuration = "LOGIN_LOCK_MAX_DURATION"
+
+	// Rate limit
+	EnvRateLimits     = "RATE_LIMITS"
+	EnvTrustedProxies = "TRUSTED_PROXIES"
+
+	// MFA
+	EnvMFASecret     = "MFA_SECRET"
+	EnvMFATOTPIssuer = "MFA_TOTP_ISSUER"
+
+	// WebAuthn
+	EnvWebAuthnRPID    = "WEBAUTHN_RP_ID"
+	EnvWebAuthnRPName  = "WEBAUTHN_RP_NAME"
+	EnvWebAuthnOrigins = "WEBAUTHN_ORIGINS"
+
+	// Mail
+	EnvMailSender       = "MAIL_SENDER"
+	EnvMailFrom         = "MAIL_FROM"
+	EnvMailSMTPAddr     = "MAIL_SMTP_ADDR"
+	EnvMailSMTPUser     = "MAIL_SMTP_USER"
+	EnvMailSMTPPassword = "MAIL_SMTP_PASSWORD"
+	EnvMailFile         = "MAIL_FILE"
+
+	// Email verification
+	EnvEmailVerificationRequired = "EMAIL_VERIFICATION_REQUIRED"
+	EnvEmailVerificationLifetime = "EMAIL_VERIFICATION_LIFETIME"
+	EnvEmailVerificationURL      = "EMAIL_VERIFICATION_URL"
+
+	// OAuth
+	EnvOAuthConsentURL = "OAUTH_CONSENT_URL"
+
+	// Tenant
+	EnvTenantDomain = "TENANT_DOMAIN"
 )
 
 type Configs struct {
@@ -53,6 +121,73 @@ type Configs struct {
 
 	// Jaeger
 	JaegerCollectorEndpoint string
+
+	// Token
+	TokenAccessAlg      string
+	TokenAccessKey      string
+	TokenRefreshKey     string
+	TokenAccessKeyFile  string
+	TokenRefreshKeyFile string
+
+	TokenAccessLifetime  string
+	TokenRefreshLifetime string
+	TokenIssuer          string
+	TokenAudience        string
+	TokenAudiences       []string
+	TokenLeeway          string
+
+	TokenKeyMode             TokenKeyMode
+	TokenKeyringSecret       string
+	TokenKeyRotationInterval string
+
+	TokenRevocationStore TokenRevocationStore
+
+	// Password
+	PasswdHashAlg          string
+	PasswdMinLength        string
+	PasswdMaxLength        string
+	PasswdMinCharClasses   string
+	PasswdBreachedListFile string
+	PasswdHistorySize      string
+
+	// Login lock
+	LoginLockStore       LoginLockStore
+	LoginLockThreshold   string
+	LoginLockIPThreshold string
+	LoginLockDuration    string
+	LoginLockMaxDuration string
+
+	// Rate limit
+	RateLimits     []string
+	TrustedProxies []string
+
+	// MFA
+	MFASecret     string
+	MFATOTPIssuer string
+
+	// WebAuthn
+	WebAuthnRPID    string
+	WebAuthnRPName  string
+	WebAuthnOrigins []string
+
+	// Mail
+	MailSender       MailSender
+	MailFrom         string
+	MailSMTPAddr     string
+	MailSMTPUser     string
+	MailSMTPPassword string
+	MailFile         string
+
+	// Email verification
+	EmailVerificationRequired string
+	EmailVerificationLifetime string
+	EmailVerificationURL      string
+
+	// OAuth
+	OAuthConsentURL string
+
+	// Tenant
+	TenantDomain string
 }
 
 func GetConfigs() *Configs {
@@ -74,7 +209,119 @@ func GetConfigs() *Configs {
 		MySQLSecondaryPassword: os.Getenv(EnvMySQLSecondaryPassword),
 
 		JaegerCollectorEndpoint: os.Getenv(EnvJaegerCollectorEndpoint),
+
+		TokenAccessAlg:      getEnvOrDefault(EnvTokenAccessAlg, "HS256"),
+		TokenAccessKey:      os.Getenv(EnvTokenAccessKey),
+		TokenRefreshKey:     os.Getenv(EnvTokenRefreshKey),
+		TokenAccessKeyFile:  os.Getenv(EnvTokenAccessKeyFile),
+		TokenRefreshKeyFile: os.Getenv(EnvTokenRefreshKeyFile),
+
+		TokenAccessLifetime:  getEnvOrDefault(EnvTokenAccessLifetime, "1h"),
+		TokenRefreshLifetime: getEnvOrDefault(EnvTokenRefreshLifetime, "336h"),
+		TokenIssuer:          os.Getenv(EnvTokenIssuer),
+		TokenAudience:        os.Getenv(EnvTokenAudience),
+		TokenAudiences:       getEnvList(EnvTokenAudiences),
+		TokenLeeway:          getEnvOrDefault(EnvTokenLeeway, "0s"),
+
+		TokenKeyMode:             TokenKeyMode(getEnvOrDefault(EnvTokenKeyMode, string(TokenKeyModeStatic))),
+		TokenKeyringSecret:       os.Getenv(EnvTokenKeyringSecret),
+		TokenKeyRotationInterval: os.Getenv(EnvTokenKeyRotationInterval),
+
+		TokenRevocationStore: TokenRevocationStore(getEnvOrDefault(EnvTokenRevocationStore, string(TokenRevocationStoreMemory))),
+
+		PasswdHashAlg:          getEnvOrDefault(EnvPasswdHashAlg, "argon2id"),
+		PasswdMinLength:        getEnvOrDefault(EnvPasswdMinLength, "8"),
+		PasswdMaxLength:        getEnvOrDefault(EnvPasswdMaxLength, "128"),
+		PasswdMinCharClasses:   getEnvOrDefault(EnvPasswdMinCharClasses, "2"),
+		PasswdBreachedListFile: getEnvOrDefault(EnvPasswdBreachedListFile, "configs/breached_passwds.txt"),
+		PasswdHistorySize:      getEnvOrDefault(EnvPasswdHistorySize, "5"),
+
+		LoginLockStore:       LoginLockStore(getEnvOrDefault(EnvLoginLockStore, string(LoginLockStoreMySQL))),
+		LoginLockThreshold:   getEnvOrDefault(EnvLoginLockThreshold, "5"),
+		LoginLockIPThreshold: getEnvOrDefault(EnvLoginLockIPThreshold, "20"),
+		LoginLockDuration:    getEnvOrDefault(EnvLoginLockDuration, "1m"),
+		LoginLockMaxDuration: getEnvOrDefault(EnvLoginLockMaxDuration, "1h"),
+
+		RateLimits:     getEnvListOrDefault(EnvRateLimits, []string{"*=100/1s/ip"}),
+		TrustedProxies: getEnvList(EnvTrustedProxies),
+
+		MFASecret:     os.Getenv(EnvMFASecret),
+		MFATOTPIssuer: getEnvOrDefault(EnvMFATOTPIssuer, "ssup2ket"),
+
+		WebAuthnRPID:    os.Getenv(EnvWebAuthnRPID),
+		WebAuthnRPName:  getEnvOrDefault(EnvWebAuthnRPName, "ssup2ket"),
+		WebAuthnOrigins: getEnvList(EnvWebAuthnOrigins),
+
+		MailSender:       MailSender(getEnvOrDefault(EnvMailSender, string(MailSenderLog))),
+		MailFrom:         getEnvOrDefault(EnvMailFrom, "no-reply@localhost"),
+		MailSMTPAddr:     os.Getenv(EnvMailSMTPAddr),
+		MailSMTPUser:     os.Getenv(EnvMailSMTPUser),
+		MailSMTPPassword: os.Getenv(EnvMailSMTPPassword),
+		MailFile:         os.Getenv(EnvMailFile),
+
+		EmailVerificationRequired: getEnvOrDefault(EnvEmailVerificationRequired, "false"),
+		EmailVerificationLifetime: getEnvOrDefault(EnvEmailVerificationLifetime, "24h"),
+		EmailVerificationURL:      os.Getenv(EnvEmailVerificationURL),
+
+		OAuthConsentURL: os.Getenv(EnvOAuthConsentURL),
+
+		TenantDomain: os.Getenv(EnvTenantDomain),
+	}
+}
+
+func getEnvOrDefault(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
 	}
+	return defaultValue
+}
+
+// Get comma separated values
+func getEnvList(key string) []string {
+	values := []string{}
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// Get comma separated values or default values if the env isn't set
+func getEnvListOrDefault(key string, defaultValues []string) []string {
+	if values := getEnvList(key); len(values) > 0 {
+		return values
+	}
+	return defaultValues
+}
+
+// Get configs without secrets for logging
+func (c *Configs) GetMasked() Configs {
+	masked := *c
+	if masked.TokenAccessKey != "" {
+		masked.TokenAccessKey = "*"
+	}
+	if masked.TokenRefreshKey != "" {
+		masked.TokenRefreshKey = "*"
+	}
+	if masked.TokenKeyringSecret != "" {
+		masked.TokenKeyringSecret = "*"
+	}
+	if masked.MFASecret != "" {
+		masked.MFASecret = "*"
+	}
+	if masked.MailSMTPPassword != "" {
+		masked.MailSMTPPassword = "*"
+	}
+	return masked
+}
+
+// Get OpenID Connect issuer. Server URL is the issuer if token issuer isn't set.
+func (c *Configs) GetOIDCIssuer() string {
+	if c.TokenIssuer != "" {
+		return c.TokenIssuer
+	}
+	return c.ServerURL
 }
 
 // Deploy env
@@ -86,3 +333,45 @@ const (
 	DeployEnvStage DeployEnv = "stage"
 	DeployEnvProd  DeployEnv = "prod"
 )
+
+// Token key mode
+type TokenKeyMode string
+
+const (
+	// Keys are given by env or files
+	TokenKeyModeStatic TokenKeyMode = "static"
+	// Keys are stored in DB and rotated periodically
+	TokenKeyModeKeyring TokenKeyMode = "keyring"
+)
+
+// Token revocation store
+type TokenRevocationStore string
+
+const (
+	// Revocations are checked from MySQL for every request
+	TokenRevocationStoreMySQL TokenRevocationStore = "mysql"
+	// Revocations are stored in MySQL and cached in memory
+	TokenRevocationStoreMemory TokenRevocationStore = "memory"
+)
+
+// Login lock store
+type LoginLockStore string
+
+const (
+	// Failed logins are counted in MySQL and shared by all replicas
+	LoginLockStoreMySQL LoginLockStore = "mysql"
+	// Failed logins are counted in memory of each replica
+	LoginLockStoreMemory LoginLockStore = "memory"
+)
+
+// Mail sender
+type MailSender string
+
+const (
+	// Mails are sent by a SMTP server
+	MailSenderSMTP MailSender = "smtp"
+	// Mails are logged for local use
+	MailSenderLog MailSender = "log"
+	// Mails are appended to a file for local use
+	MailSenderFile MailSender = "file"
+)
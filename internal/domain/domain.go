@@ -2,12 +2,19 @@ package domain
 
 import (
 	"fmt"
+	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/rs/zerolog/log"
 
 	"github.com/ssup2ket/service-auth/internal/config"
 	"github.com/ssup2ket/service-auth/internal/domain/repo"
 	"github.com/ssup2ket/service-auth/internal/domain/service"
+	"github.com/ssup2ket/service-auth/pkg/auth/passwd"
+	"github.com/ssup2ket/service-auth/pkg/auth/token"
+	"github.com/ssup2ket/service-auth/pkg/auth/webauthn"
+	"github.com/ssup2ket/service-auth/pkg/mail"
 )
 
 type Domain struct {
@@ -15,8 +22,30 @@ type Domain struct {
 	Configs *config.Configs
 
 	// Service
-	User  service.UserService
-	Token service.TokenService
+	User    service.UserService
+	Token   service.TokenService
+	Key     service.TokenKeyService
+	Session service.SessionService
+	OAuth   service.OAuthService
+
+	OAuthClient service.OAuthClientService
+	Role        service.RoleService
+	Permission  service.PermissionService
+	Tenant      service.TenantService
+	Group       service.GroupService
+
+	TokenRevocation service.TokenRevocationService
+	LoginLock       service.LoginLockService
+	MFA             service.MFAService
+	Passkey         service.PasskeyService
+
+	EmailVerification service.EmailVerificationService
+
+	// Keyring is only set in keyring token key mode
+	Keyring *token.Keyring
+
+	// Permission repo for Casbin enforcers
+	permissionRepo repo.PermissionRepo
 }
 
 func New(c *config.Configs) (*Domain, error) {
@@ -36,14 +65,312 @@ func New(c *config.Configs) (*Domain, error) {
 	userInfoRepoSecondaryMysql := repo.NewUserInfoRepoImp(secondaryMySQL)
 	userSecretRepoPrimaryMysql := repo.NewUserSecretRepoImp(primaryMySQL)
 	userSecretRepoSecondaryMysql := repo.NewUserSecretRepoImp(secondaryMySQL)
+	passwdHistoryRepoPrimaryMysql := repo.NewPasswdHistoryRepoImp(primaryMySQL)
+	mfaRecoveryCodeRepoPrimaryMysql := repo.NewMFARecoveryCodeRepoImp(primaryMySQL)
+	webAuthnCredentialRepoPrimaryMysql := repo.NewWebAuthnCredentialRepoImp(primaryMySQL)
+	webAuthnChallengeRepoPrimaryMysql := repo.NewWebAuthnChallengeRepoImp(primaryMySQL)
+	emailVerificationRepoPrimaryMysql := repo.NewEmailVerificationRepoImp(primaryMySQL)
+	sessionRepoPrimaryMysql := repo.NewSessionRepoImp(primaryMySQL)
+	sessionRepoSecondaryMysql := repo.NewSessionRepoImp(secondaryMySQL)
+	tokenKeyRepoPrimaryMysql := repo.NewTokenKeyRepoImp(primaryMySQL)
+	tokenKeyRepoSecondaryMysql := repo.NewTokenKeyRepoImp(secondaryMySQL)
+	tokenRevocationRepoPrimaryMysql := repo.NewTokenRevocationRepoImp(primaryMySQL)
+	tokenRevocationRepoSecondaryMysql := repo.NewTokenRevocationRepoImp(secondaryMySQL)
+	oauthAuthCodeRepoPrimaryMysql := repo.NewOAuthAuthCodeRepoImp(primaryMySQL)
+	oauthClientRepoPrimaryMysql := repo.NewOAuthClientRepoImp(primaryMySQL)
+	oauthClientRepoSecondaryMysql := repo.NewOAuthClientRepoImp(secondaryMySQL)
+	roleRepoPrimaryMysql := repo.NewRoleRepoImp(primaryMySQL)
+	roleRepoSecondaryMysql := repo.NewRoleRepoImp(secondaryMySQL)
+	permissionRepoPrimaryMysql := repo.NewPermissionRepoImp(primaryMySQL)
+	permissionRepoSecondaryMysql := repo.NewPermissionRepoImp(secondaryMySQL)
+	tenantRepoPrimaryMysql := repo.NewTenantRepoImp(primaryMySQL)
+	tenantRepoSecondaryMysql := repo.NewTenantRepoImp(secondaryMySQL)
+	groupRepoPrimaryMysql := repo.NewGroupRepoImp(primaryMySQL)
+	groupRepoSecondaryMysql := repo.NewGroupRepoImp(secondaryMySQL)
+	groupMemberRepoPrimaryMysql := repo.NewGroupMemberRepoImp(primaryMySQL)
+	groupMemberRepoSecondaryMysql := repo.NewGroupMemberRepoImp(secondaryMySQL)
+
+	// Init keyring
+	var rotationInterval time.Duration
+	if c.TokenKeyMode == config.TokenKeyModeKeyring {
+		if c.TokenKeyringSecret == "" {
+			return nil, fmt.Errorf("no token keyring secret is configured")
+		}
+		if c.TokenKeyRotationInterval != "" {
+			if rotationInterval, err = time.ParseDuration(c.TokenKeyRotationInterval); err != nil {
+				return nil, fmt.Errorf("wrong token key rotation interval")
+			}
+		}
+		domain.Keyring = token.NewKeyring()
+	} else if c.TokenKeyMode != config.TokenKeyModeStatic {
+		return nil, fmt.Errorf("wrong token key mode")
+	}
+
+	// Init revocation list
+	var revocationList *token.RevocationList
+	if c.TokenRevocationStore == config.TokenRevocationStoreMemory {
+		revocationList = token.NewRevocationList()
+	} else if c.TokenRevocationStore != config.TokenRevocationStoreMySQL {
+		return nil, fmt.Errorf("wrong token revocation store")
+	}
+
+	// Init password policy
+	passwdPolicy, err := getPasswdPolicy(c)
+	if err != nil {
+		return nil, err
+	}
+	passwdHistorySize, err := strconv.Atoi(c.PasswdHistorySize)
+	if err != nil || passwdHistorySize < 0 {
+		return nil, fmt.Errorf("wrong password history size")
+	}
+
+	// Init login lock
+	var loginLockRepo repo.LoginLockRepo
+	if c.LoginLockStore == config.LoginLockStoreMySQL {
+		loginLockRepo = repo.NewLoginLockRepoImp(primaryMySQL)
+	} else if c.LoginLockStore == config.LoginLockStoreMemory {
+		loginLockRepo = repo.NewLoginLockRepoMemory()
+	} else {
+		return nil, fmt.Errorf("wrong login lock store")
+	}
+	loginLockPolicy, err := getLoginLockPolicy(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Init WebAuthn relying party
+	relyingParty := getRelyingParty(c)
+
+	// Init mail sender and email verification policy
+	emailVerificationPolicy, err := getEmailVerificationPolicy(c)
+	if err != nil {
+		return nil, err
+	}
+	mailSender, err := getMailSender(c, emailVerificationPolicy.Required)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check OAuth consent page
+	if c.OAuthConsentURL != "" {
+		if consentURL, err := url.Parse(c.OAuthConsentURL); err != nil || consentURL.Scheme == "" || consentURL.Host == "" {
+			return nil, fmt.Errorf("wrong OAuth consent URL")
+		}
+	}
 
 	// Init services
-	userService := service.NewUserServiceImp(txMySQL, outboxRepoPrimaryMysql,
-		userInfoRepoPrimaryMysql, userInfoRepoSecondaryMysql, userSecretRepoPrimaryMysql, userSecretRepoSecondaryMysql)
-	tokenService := service.NewTokenServiceImp(txMySQL, userInfoRepoSecondaryMysql, userSecretRepoPrimaryMysql, userSecretRepoSecondaryMysql)
+	userService := service.NewUserServiceImp(txMySQL, service.UserRepos{
+		OutboxPrimary:        outboxRepoPrimaryMysql,
+		UserInfoPrimary:      userInfoRepoPrimaryMysql,
+		UserInfoSecondary:    userInfoRepoSecondaryMysql,
+		UserSecretPrimary:    userSecretRepoPrimaryMysql,
+		UserSecretSecondary:  userSecretRepoSecondaryMysql,
+		PasswdHistoryPrimary: passwdHistoryRepoPrimaryMysql,
+		RolePrimary:          roleRepoPrimaryMysql,
+		PermissionPrimary:    permissionRepoPrimaryMysql,
+		TenantPrimary:        tenantRepoPrimaryMysql,
+		GroupPrimary:         groupRepoPrimaryMysql,
+		GroupMemberPrimary:   groupMemberRepoPrimaryMysql,
+
+		MFARecoveryCodePrimary:    mfaRecoveryCodeRepoPrimaryMysql,
+		WebAuthnCredentialPrimary: webAuthnCredentialRepoPrimaryMysql,
+
+		SessionPrimary:         sessionRepoPrimaryMysql,
+		TokenRevocationPrimary: tokenRevocationRepoPrimaryMysql,
+
+		EmailVerificationPrimary: emailVerificationRepoPrimaryMysql,
+	}, revocationList, mailSender, service.UserPolicies{
+		Passwd:            passwdPolicy,
+		PasswdHistorySize: passwdHistorySize,
+		EmailVerification: emailVerificationPolicy,
+	})
+	tokenService := service.NewTokenServiceImp(txMySQL, service.TokenRepos{
+		UserInfoSecondary:    userInfoRepoSecondaryMysql,
+		UserSecretSecondary:  userSecretRepoSecondaryMysql,
+		RoleSecondary:        roleRepoSecondaryMysql,
+		GroupSecondary:       groupRepoSecondaryMysql,
+		GroupMemberSecondary: groupMemberRepoSecondaryMysql,
+		UserSecretPrimary:    userSecretRepoPrimaryMysql,
+		SessionPrimary:       sessionRepoPrimaryMysql,
+
+		TokenRevocationPrimary: tokenRevocationRepoPrimaryMysql,
+		LoginLock:              loginLockRepo,
+		MFARecoveryCodePrimary: mfaRecoveryCodeRepoPrimaryMysql,
+
+		WebAuthnCredentialPrimary: webAuthnCredentialRepoPrimaryMysql,
+		WebAuthnChallengePrimary:  webAuthnChallengeRepoPrimaryMysql,
+	}, revocationList, service.TokenPolicies{
+		LoginLock:         loginLockPolicy,
+		EmailVerification: emailVerificationPolicy,
+	}, []byte(c.MFASecret), relyingParty)
+	sessionService := service.NewSessionServiceImp(txMySQL, outboxRepoPrimaryMysql, sessionRepoPrimaryMysql, sessionRepoSecondaryMysql,
+		tokenRevocationRepoPrimaryMysql, revocationList)
+	tokenRevocationService := service.NewTokenRevocationServiceImp(tokenRevocationRepoPrimaryMysql, tokenRevocationRepoSecondaryMysql,
+		revocationList)
+	loginLockService := service.NewLoginLockServiceImp(loginLockRepo, loginLockPolicy)
+	mfaService := service.NewMFAServiceImp(txMySQL, userInfoRepoPrimaryMysql, userSecretRepoPrimaryMysql, mfaRecoveryCodeRepoPrimaryMysql,
+		loginLockRepo, loginLockPolicy, []byte(c.MFASecret), c.MFATOTPIssuer)
+	passkeyService := service.NewPasskeyServiceImp(txMySQL, userInfoRepoPrimaryMysql, userSecretRepoPrimaryMysql,
+		webAuthnCredentialRepoPrimaryMysql, webAuthnChallengeRepoPrimaryMysql, relyingParty)
+	emailVerificationService := service.NewEmailVerificationServiceImp(txMySQL, userInfoRepoPrimaryMysql,
+		emailVerificationRepoPrimaryMysql, mailSender, emailVerificationPolicy)
+	keyService := service.NewTokenKeyServiceImp(txMySQL, outboxRepoPrimaryMysql, tokenKeyRepoPrimaryMysql, tokenKeyRepoSecondaryMysql,
+		domain.Keyring, c.TokenAccessAlg, []byte(c.TokenKeyringSecret), rotationInterval)
+	oauthService := service.NewOAuthServiceImp(txMySQL, oauthAuthCodeRepoPrimaryMysql, oauthClientRepoSecondaryMysql,
+		userInfoRepoSecondaryMysql, tokenService, c.GetOIDCIssuer())
+	oauthClientService := service.NewOAuthClientServiceImp(txMySQL, oauthClientRepoPrimaryMysql, oauthClientRepoSecondaryMysql)
+	roleService := service.NewRoleServiceImp(txMySQL, roleRepoPrimaryMysql, roleRepoSecondaryMysql, permissionRepoPrimaryMysql,
+		userInfoRepoPrimaryMysql, groupRepoPrimaryMysql)
+	permissionService := service.NewPermissionServiceImp(txMySQL, permissionRepoPrimaryMysql, permissionRepoSecondaryMysql,
+		roleRepoPrimaryMysql)
+	tenantService := service.NewTenantServiceImp(txMySQL, tenantRepoPrimaryMysql, tenantRepoSecondaryMysql, userInfoRepoPrimaryMysql,
+		groupRepoPrimaryMysql)
+	groupService := service.NewGroupServiceImp(txMySQL, groupRepoPrimaryMysql, groupRepoSecondaryMysql, groupMemberRepoPrimaryMysql,
+		groupMemberRepoSecondaryMysql, roleRepoPrimaryMysql, permissionRepoPrimaryMysql, userInfoRepoPrimaryMysql, outboxRepoPrimaryMysql)
 
 	domain.User = userService
 	domain.Token = tokenService
+	domain.Key = keyService
+	domain.Session = sessionService
+	domain.OAuth = oauthService
+	domain.OAuthClient = oauthClientService
+	domain.TokenRevocation = tokenRevocationService
+	domain.LoginLock = loginLockService
+	domain.MFA = mfaService
+	domain.Passkey = passkeyService
+	domain.EmailVerification = emailVerificationService
+	domain.Role = roleService
+	domain.Permission = permissionService
+	domain.Tenant = tenantService
+	domain.Group = groupService
+	domain.permissionRepo = permissionRepoPrimaryMysql
 
 	return &domain, nil
 }
+
+// Get a Casbin adapter loading permissions from the primary DB
+func (d *Domain) NewCasbinAdapter() *repo.CasbinAdapter {
+	return repo.NewCasbinAdapter(d.permissionRepo)
+}
+
+// Get a Casbin watcher checking the policy version from the primary DB every period
+func (d *Domain) NewCasbinWatcher(period time.Duration) (*repo.CasbinWatcher, error) {
+	return repo.NewCasbinWatcher(d.permissionRepo, period)
+}
+
+func getPasswdPolicy(c *config.Configs) (passwd.Policy, error) {
+	var err error
+	policyConfig := passwd.GetDefaultPolicyConfig()
+	if policyConfig.MinLength, err = strconv.Atoi(c.PasswdMinLength); err != nil || policyConfig.MinLength < 1 {
+		return nil, fmt.Errorf("wrong password min length")
+	}
+	if policyConfig.MaxLength, err = strconv.Atoi(c.PasswdMaxLength); err != nil || policyConfig.MaxLength < policyConfig.MinLength {
+		return nil, fmt.Errorf("wrong password max length")
+	}
+	if policyConfig.MinCharClasses, err = strconv.Atoi(c.PasswdMinCharClasses); err != nil || policyConfig.MinCharClasses < 0 ||
+		policyConfig.MinCharClasses > 4 {
+		return nil, fmt.Errorf("wrong password min character classes")
+	}
+	if c.PasswdBreachedListFile != "" {
+		if policyConfig.BreachedPasswds, err = passwd.LoadBreachedPasswds(c.PasswdBreachedListFile); err != nil {
+			log.Error().Err(err).Msg("Failed to load breached password list")
+			return nil, fmt.Errorf("failed to load breached password list")
+		}
+	}
+	return passwd.NewDefaultPolicy(policyConfig), nil
+}
+
+func getLoginLockPolicy(c *config.Configs) (*service.LoginLockPolicy, error) {
+	var err error
+	loginLockPolicy := service.LoginLockPolicy{}
+	if loginLockPolicy.LoginIDThreshold, err = strconv.Atoi(c.LoginLockThreshold); err != nil || loginLockPolicy.LoginIDThreshold < 0 {
+		return nil, fmt.Errorf("wrong login lock threshold")
+	}
+	if loginLockPolicy.IPThreshold, err = strconv.Atoi(c.LoginLockIPThreshold); err != nil || loginLockPolicy.IPThreshold < 0 {
+		return nil, fmt.Errorf("wrong login lock IP threshold")
+	}
+	if loginLockPolicy.Duration, err = time.ParseDuration(c.LoginLockDuration); err != nil || loginLockPolicy.Duration <= 0 {
+		return nil, fmt.Errorf("wrong login lock duration")
+	}
+	if loginLockPolicy.MaxDuration, err = time.ParseDuration(c.LoginLockMaxDuration); err != nil ||
+		loginLockPolicy.MaxDuration < loginLockPolicy.Duration {
+		return nil, fmt.Errorf("wrong login lock max duration")
+	}
+	return &loginLockPolicy, nil
+}
+
+// Get the WebAuthn relying party. Passkeys are disabled without the relying party ID, and the origin of the
+// relying party ID is used by default.
+func getRelyingParty(c *config.Configs) *webauthn.RelyingParty {
+	if c.WebAuthnRPID == "" {
+		return nil
+	}
+	origins := c.WebAuthnOrigins
+	if len(origins) == 0 {
+		origins = []string{"https://" + c.WebAuthnRPID}
+	}
+	return &webauthn.RelyingParty{
+		ID:      c.WebAuthnRPID,
+		Name:    c.WebAuthnRPName,
+		Origins: origins,
+	}
+}
+
+// Get the mail sender. Log and file senders don't deliver mails, so they are refused outside the local env
+// if email verification is required, because users couldn't login without verification mails.
+func getMailSender(c *config.Configs, verificationRequired bool) (mail.Sender, error) {
+	switch c.MailSender {
+	case config.MailSenderSMTP:
+		sender, err := mail.NewSMTPSender(c.MailSMTPAddr, c.MailSMTPUser, c.MailSMTPPassword, c.MailFrom)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to init SMTP mail sender")
+			return nil, fmt.Errorf("wrong SMTP mail sender")
+		}
+		return sender, nil
+	case config.MailSenderLog:
+		if err := checkLocalMailSender(c, verificationRequired); err != nil {
+			return nil, err
+		}
+		return mail.NewLogSender(c.MailFrom), nil
+	case config.MailSenderFile:
+		if err := checkLocalMailSender(c, verificationRequired); err != nil {
+			return nil, err
+		}
+		if c.MailFile == "" {
+			return nil, fmt.Errorf("no mail file is configured")
+		}
+		return mail.NewFileSender(c.MailFile, c.MailFrom), nil
+	}
+	return nil, fmt.Errorf("wrong mail sender")
+}
+
+// Check the mail sender for local use can be used in the deploy env
+func checkLocalMailSender(c *config.Configs, verificationRequired bool) error {
+	if c.DeployEnv == config.DeployEnvLocal {
+		return nil
+	}
+	if verificationRequired {
+		return fmt.Errorf("%s mail sender can't be used with required email verification outside local env, set MAIL_SENDER to smtp",
+			c.MailSender)
+	}
+	log.Warn().Str("mail_sender", string(c.MailSender)).Msg("Mails aren't delivered outside local env")
+	return nil
+}
+
+func getEmailVerificationPolicy(c *config.Configs) (*service.EmailVerificationPolicy, error) {
+	var err error
+	emailVerificationPolicy := service.EmailVerificationPolicy{URL: c.EmailVerificationURL}
+	if emailVerificationPolicy.Required, err = strconv.ParseBool(c.EmailVerificationRequired); err != nil {
+		return nil, fmt.Errorf("wrong email verification required")
+	}
+	if emailVerificationPolicy.Lifetime, err = time.ParseDuration(c.EmailVerificationLifetime); err != nil ||
+		emailVerificationPolicy.Lifetime <= 0 {
+		return nil, fmt.Errorf("wrong email verification lifetime")
+	}
+	if c.EmailVerificationURL != "" {
+		if verifyURL, err := url.Parse(c.EmailVerificationURL); err != nil || verifyURL.Scheme == "" || verifyURL.Host == "" {
+			return nil, fmt.Errorf("wrong email verification URL")
+		}
+	}
+	return &emailVerificationPolicy, nil
+}
@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+// EmailVerification is a verification token sent to the email of a user. Only the token's hash is stored,
+// and it can verify only the email it was sent to once before ExpiresAt.
+type EmailVerification struct {
+	ID        string `gorm:"primaryKey;size:64"` // SHA-256 hash of the token
+	CreatedAt time.Time
+
+	UserID    uuid.EntityUUID `gorm:"index;type:binary(16)"`
+	TenantID  string          `gorm:"size:30"`
+	Email     string          `gorm:"size:40"`
+	ExpiresAt time.Time       `gorm:"index"`
+}
@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+// Group of users in a tenant. Groups can be members of other groups, and roles of a group are granted
+// to all direct and nested members of the group.
+type Group struct {
+	ID        uuid.EntityUUID `gorm:"primaryKey;type:binary(16)"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	TenantID    string  `gorm:"uniqueIndex:idx_group_tenant_name;size:30"`
+	Name        string  `gorm:"uniqueIndex:idx_group_tenant_name;size:50"` // Unique key in tenant
+	Description string  `gorm:"size:255"`
+	Roles       StrList `gorm:"size:1024"`
+}
+
+// GroupMemberType is the type of a group member
+type GroupMemberType string
+
+const (
+	GroupMemberTypeUser  GroupMemberType = "user"
+	GroupMemberTypeGroup GroupMemberType = "group"
+)
+
+// Member of a group. A member is a user or a group of the same tenant.
+type GroupMember struct {
+	GroupID   uuid.EntityUUID `gorm:"primaryKey;type:binary(16)"`
+	MemberID  uuid.EntityUUID `gorm:"primaryKey;type:binary(16);index"`
+	CreatedAt time.Time
+
+	MemberType GroupMemberType `gorm:"size:10"`
+}
@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+// LoginLockType is the type of a login lock subject
+type LoginLockType string
+
+const (
+	LoginLockTypeLoginID LoginLockType = "loginId" // Subject is a login ID of the tenant
+	LoginLockTypeIP      LoginLockType = "ip"      // Subject is a client IP, and tenant ID is empty
+)
+
+// LoginLock counts failed logins of the subject. The subject can't login until LockedUntil after failures
+// reach the threshold, and failures are reset if there is no failed login for a while.
+type LoginLock struct {
+	ID        uuid.EntityUUID `gorm:"primaryKey;type:binary(16)"`
+	CreatedAt time.Time
+
+	Type     LoginLockType `gorm:"uniqueIndex:idx_login_lock_subject;size:10"`
+	TenantID string        `gorm:"uniqueIndex:idx_login_lock_subject;size:30"`
+	Subject  string        `gorm:"uniqueIndex:idx_login_lock_subject;size:50"`
+
+	Failures     int
+	LastFailedAt time.Time `gorm:"index"`
+	LockedUntil  time.Time
+}
+
+// Check whether the subject is locked at the time
+func (l *LoginLock) IsLocked(now time.Time) bool {
+	return l.LockedUntil.After(now)
+}
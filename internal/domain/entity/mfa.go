@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+// One-time recovery code of a user to pass MFA without the TOTP device. Only the hash of the code is stored,
+// and the code is deleted when it's used.
+type MFARecoveryCode struct {
+	ID        uuid.EntityUUID `gorm:"primaryKey;type:binary(16)"`
+	CreatedAt time.Time
+
+	UserID   uuid.EntityUUID `gorm:"index;type:binary(16)"`
+	CodeHash []byte          `gorm:"size:32"`
+}
+
+// MFA status of a user
+type MFAInfo struct {
+	TOTPEnabled   bool
+	TOTPEnabledAt *time.Time
+	RecoveryCodes int
+}
+
+// TOTP secret and its otpauth URI for authenticator apps. They are returned only once when TOTP is enrolled.
+type TOTPEnrollment struct {
+	Secret string
+	URI    string
+}
@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+type OAuthGrantType string
+
+const (
+	OAuthGrantTypeAuthorizationCode OAuthGrantType = "authorization_code"
+	OAuthGrantTypeClientCredentials OAuthGrantType = "client_credentials"
+	OAuthGrantTypeRefreshToken      OAuthGrantType = "refresh_token"
+)
+
+func IsValidOAuthGrantType(grantType string) bool {
+	switch OAuthGrantType(grantType) {
+	case OAuthGrantTypeAuthorizationCode, OAuthGrantTypeClientCredentials, OAuthGrantTypeRefreshToken:
+		return true
+	}
+	return false
+}
+
+// OAuthAuthCode is an authorization code issued to a client for a user. Only the code's hash is stored,
+// and it can be exchanged for tokens only once before ExpiresAt.
+type OAuthAuthCode struct {
+	ID        string `gorm:"primaryKey;size:64"` // SHA-256 hash of the code
+	CreatedAt time.Time
+
+	ClientID      string          `gorm:"size:64"`
+	UserID        uuid.EntityUUID `gorm:"type:binary(16)"`
+	TenantID      string          `gorm:"size:30"`
+	RedirectURI   string          `gorm:"size:2048"`
+	Scope         string          `gorm:"size:1024"`
+	Nonce         string          `gorm:"size:255"`
+	CodeChallenge string          `gorm:"size:128"`
+	AuthTime      time.Time
+	ExpiresAt     time.Time `gorm:"index"`
+}
@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+// OAuthClient is an application getting tokens by OAuth2 grants. The client ID is the ID, and only the hash
+// of the secret is stored. Public clients like SPA or mobile apps can't keep a secret, so they don't have
+// a secret and are authenticated only by PKCE.
+type OAuthClient struct {
+	ID        uuid.EntityUUID `gorm:"primaryKey;type:binary(16)"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	Name         string  `gorm:"size:100"`
+	SecretHash   []byte  `gorm:"size:4096"`
+	SecretSalt   []byte  `gorm:"size:20"`
+	RedirectURIs StrList `gorm:"size:4096"`
+	GrantTypes   StrList `gorm:"size:255"`
+	Scopes       StrList `gorm:"size:1024"`
+	Public       bool
+}
+
+func (o *OAuthClient) IsGrantTypeAllowed(grantType OAuthGrantType) bool {
+	for _, g := range o.GrantTypes {
+		if g == string(grantType) {
+			return true
+		}
+	}
+	return false
+}
+
+// StrList is stored as a JSON array
+type StrList []string
+
+func (s StrList) Value() (driver.Value, error) {
+	if s == nil {
+		return "[]", nil
+	}
+	value, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	return string(value), nil
+}
+
+func (s *StrList) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, s)
+	case string:
+		return json.Unmarshal([]byte(v), s)
+	case nil:
+		*s = StrList{}
+		return nil
+	}
+	return fmt.Errorf("wrong string list type")
+}
@@ -0,0 +1,17 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+// Previous password of a user. Password hashes are stored when passwords are changed, so users can't reuse
+// their last passwords.
+type PasswdHistory struct {
+	ID        uint64 `gorm:"primaryKey;autoIncrement"`
+	CreatedAt time.Time
+
+	UserID    uuid.EntityUUID `gorm:"index;type:binary(16)"`
+	PasswdPHC string          `gorm:"column:passwd_phc;size:255"`
+}
@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+// Subject prefix of scope permissions not to be confused with roles
+const PermissionScopePrefix = "scope:"
+
+// Permission is a Casbin policy rule shared by HTTP and GRPC. Subject is a role name or a scope with the
+// scope prefix, and object and action are regular expressions of operations in the operation catalogue.
+type Permission struct {
+	ID        uuid.EntityUUID `gorm:"primaryKey;type:binary(16)"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	Subject string `gorm:"size:100;uniqueIndex:idx_permission_rule"`
+	Object  string `gorm:"size:255;uniqueIndex:idx_permission_rule"`
+	Action  string `gorm:"size:255;uniqueIndex:idx_permission_rule"`
+}
+
+// PolicyVersion is increased whenever permissions are changed, so every replica can reload permissions.
+// There is only one policy version row.
+type PolicyVersion struct {
+	ID        uint `gorm:"primaryKey"`
+	UpdatedAt time.Time
+
+	Version uint64
+}
@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"time"
+)
+
+// Role of users. Roles are stored in DB, and permissions of a role are stored as permissions
+// having the role as subject. Scopes are the permission scopes which can be granted to tokens of the role.
+// Passwords of users having the role expire after the max age, and 0 means that passwords don't expire.
+type Role struct {
+	Name      string `gorm:"primaryKey;size:20"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	Description string  `gorm:"size:255"`
+	Scopes      StrList `gorm:"size:1024"`
+
+	PasswdMaxAgeDays int
+}
+
+func (r *Role) IsScopeAllowed(scope string) bool {
+	for _, s := range r.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Get default roles created when service-auth starts at first
+func GetDefaultRoles() []Role {
+	return []Role{
+		{
+			Name:        string(UserRoleAdmin),
+			Description: "Administrator",
+			Scopes:      GetAllScopes(),
+		},
+		{
+			Name:        string(UserRoleTenantAdmin),
+			Description: "Tenant administrator",
+			Scopes: StrList{ScopeUsersRead, ScopeUsersWrite, ScopeUsersMeRead, ScopeUsersMeWrite, ScopeUsersMePasswd,
+				ScopeUsersMeEmail, ScopeTokensIntrospect, ScopeGroupsRead, ScopeGroupsWrite},
+		},
+		{
+			Name:        string(UserRoleUser),
+			Description: "User",
+			Scopes:      StrList{ScopeUsersMeRead, ScopeUsersMeWrite, ScopeUsersMePasswd, ScopeUsersMeEmail, ScopeTokensIntrospect},
+		},
+	}
+}
@@ -0,0 +1,45 @@
+package entity
+
+// Permission scopes of tokens. A token without scopes has all permissions of its role,
+// and a token with scopes has only the permissions of the scopes within its role.
+// Roles scopes cover both roles and their permissions.
+const (
+	ScopeUsersRead         = "users:read"
+	ScopeUsersWrite        = "users:write"
+	ScopeUsersMeRead       = "users.me:read"
+	ScopeUsersMeWrite      = "users.me:write"
+	ScopeUsersMePasswd     = "users.me:passwd"
+	ScopeUsersMeEmail      = "users.me:email"
+	ScopeTokensIntrospect  = "tokens:introspect"
+	ScopeKeysRead          = "keys:read"
+	ScopeKeysWrite         = "keys:write"
+	ScopeOAuthClientsRead  = "oauth.clients:read"
+	ScopeOAuthClientsWrite = "oauth.clients:write"
+	ScopeRolesRead         = "roles:read"
+	ScopeRolesWrite        = "roles:write"
+	ScopeTenantsRead       = "tenants:read"
+	ScopeTenantsWrite      = "tenants:write"
+	ScopeGroupsRead        = "groups:read"
+	ScopeGroupsWrite       = "groups:write"
+	ScopeLoginLocksRead    = "login.locks:read"
+	ScopeLoginLocksWrite   = "login.locks:write"
+)
+
+// Get all permission scopes
+func GetAllScopes() []string {
+	return []string{
+		ScopeUsersRead, ScopeUsersWrite, ScopeUsersMeRead, ScopeUsersMeWrite, ScopeUsersMePasswd, ScopeUsersMeEmail,
+		ScopeTokensIntrospect, ScopeKeysRead, ScopeKeysWrite, ScopeOAuthClientsRead, ScopeOAuthClientsWrite,
+		ScopeRolesRead, ScopeRolesWrite, ScopeTenantsRead, ScopeTenantsWrite, ScopeGroupsRead, ScopeGroupsWrite,
+		ScopeLoginLocksRead, ScopeLoginLocksWrite,
+	}
+}
+
+func IsValidScope(scope string) bool {
+	for _, s := range GetAllScopes() {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
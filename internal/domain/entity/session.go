@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+// Session is created per login, so a user can keep logged in on several devices
+type Session struct {
+	ID        uuid.EntityUUID `gorm:"primaryKey;type:binary(16)"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	UserID     uuid.EntityUUID `gorm:"index;type:binary(16)"`
+	DeviceName string          `gorm:"size:100"`
+	UserAgent  string          `gorm:"size:255"`
+	IP         string          `gorm:"size:45"`
+	ClientID   string          `gorm:"size:64"`   // OAuth2 client of the session, empty for login
+	Scopes     StrList         `gorm:"size:1024"` // OAuth2 scopes granted to the client
+	LastUsedAt time.Time
+	ExpiresAt  time.Time
+
+	RefreshTokenHash []byte `gorm:"size:4096"`
+	RefreshTokenSalt []byte `gorm:"size:20"`
+}
@@ -0,0 +1,34 @@
+package entity
+
+// Subject is the caller of a request authenticated by an access token. A subject without user is
+// an OAuth2 client of the client credentials grant. Tenant is the tenant of the request, which is
+// the token's tenant or the tenant selected by an admin. Roles are the effective roles of the user
+// including roles granted by the user's groups. Session is the session of the access token, and it's empty
+// for tokens without session.
+type Subject struct {
+	UserID    string
+	UserRoles []UserRole
+	TenantID  string
+	SessionID string
+}
+
+func (s *Subject) IsClient() bool {
+	return s.UserID == ""
+}
+
+func (s *Subject) HasRole(role UserRole) bool {
+	for _, userRole := range s.UserRoles {
+		if userRole == role {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Subject) IsAdmin() bool {
+	return s.HasRole(UserRoleAdmin)
+}
+
+func (s *Subject) IsTenantAdmin() bool {
+	return s.HasRole(UserRoleTenantAdmin)
+}
@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"time"
+)
+
+// Default tenant. Users created before tenants were introduced belong to the default tenant.
+const TenantIDDefault = "default"
+
+// Tenant owns users. Login IDs are unique per tenant, and tenant admins can manage only users of their tenant.
+// The ID is a DNS label, so a tenant can be resolved from the subdomain of a request.
+type Tenant struct {
+	ID        string `gorm:"primaryKey;size:30"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	Description string `gorm:"size:255"`
+}
+
+// Get the default tenant created when service-auth starts at first
+func GetDefaultTenant() Tenant {
+	return Tenant{
+		ID:          TenantIDDefault,
+		Description: "Default tenant",
+	}
+}
+
+// Get the tenant ID or the default tenant ID if it's empty
+func GetTenantIDOrDefault(tenantID string) string {
+	if tenantID == "" {
+		return TenantIDDefault
+	}
+	return tenantID
+}
@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+type TokenKeyType string
+
+const (
+	TokenKeyTypeAccess  TokenKeyType = "access"
+	TokenKeyTypeRefresh TokenKeyType = "refresh"
+)
+
+type TokenKeyStatus string
+
+const (
+	TokenKeyStatusPending TokenKeyStatus = "pending" // Only verifies tokens until it becomes primary
+	TokenKeyStatusPrimary TokenKeyStatus = "primary"
+	TokenKeyStatusRetired TokenKeyStatus = "retired"
+)
+
+type TokenKey struct {
+	ID        uuid.EntityUUID `gorm:"primaryKey;type:binary(16)"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	KeyID     string         `gorm:"unique;size:64"` // Unique key, JWT kid
+	Type      TokenKeyType   `gorm:"size:10"`
+	Alg       string         `gorm:"size:10"`
+	Status    TokenKeyStatus `gorm:"size:10"`
+	Key       []byte         `gorm:"size:4096"` // Encrypted
+	RetiredAt *time.Time
+	ExpiresAt *time.Time
+}
@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+type TokenRevocationType string
+
+const (
+	TokenRevocationTypeToken   TokenRevocationType = "token"   // Subject is a token ID, JWT jti
+	TokenRevocationTypeSession TokenRevocationType = "session" // Subject is a session ID
+	TokenRevocationTypeUser    TokenRevocationType = "user"    // Subject is a user ID
+)
+
+// TokenRevocation revokes tokens of the subject issued until RevokedAt. It can be deleted after ExpiresAt
+// because all revoked tokens are expired.
+type TokenRevocation struct {
+	ID        uuid.EntityUUID `gorm:"primaryKey;type:binary(16)"`
+	CreatedAt time.Time
+
+	Type      TokenRevocationType `gorm:"size:10"`
+	Subject   string              `gorm:"index;size:36"`
+	RevokedAt time.Time
+	ExpiresAt time.Time `gorm:"index"`
+}
@@ -8,30 +8,29 @@ import (
 	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
 )
 
+// UserRole is the name of a role stored in DB. Admin, tenant admin and user roles are created by default.
+// An admin manages all tenants, and a tenant admin manages only users of its tenant.
 type UserRole string
 
 const (
-	UserRoleAdmin UserRole = "admin"
-	UserRoleUser  UserRole = "user"
+	UserRoleAdmin       UserRole = "admin"
+	UserRoleTenantAdmin UserRole = "tenant-admin"
+	UserRoleUser        UserRole = "user"
 )
 
-func IsValidUserRole(role string) bool {
-	if role == string(UserRoleAdmin) {
-		return true
-	} else if role == string(UserRoleUser) {
-		return true
-	}
-	return false
-}
-
 type UserInfo struct {
 	ID        uuid.EntityUUID `gorm:"primaryKey;type:binary(16)"`
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt gorm.DeletedAt `gorm:"index"`
 
-	LoginID string   `gorm:"unique;size:20"` // Unique key
-	Role    UserRole `gorm:"size:20"`
-	Phone   string   `gorm:"size:13"`
-	Email   string   `gorm:"size:40"`
+	TenantID string   `gorm:"uniqueIndex:idx_user_tenant_login_id;size:30"`
+	LoginID  string   `gorm:"uniqueIndex:idx_user_tenant_login_id;size:20"` // Unique key in tenant
+	Role     UserRole `gorm:"size:20"`
+	Phone    string   `gorm:"size:13"`
+	Email    string   `gorm:"size:40"`
+
+	// Email is verified by a one-time token sent to the email, and changing the email resets it
+	EmailVerified   bool
+	EmailVerifiedAt *time.Time
 }
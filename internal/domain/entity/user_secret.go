@@ -5,6 +5,7 @@ import (
 
 	"gorm.io/gorm"
 
+	"github.com/ssup2ket/service-auth/pkg/auth/hashing"
 	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
 )
 
@@ -14,8 +15,47 @@ type UserSecret struct {
 	UpdatedAt time.Time
 	DeletedAt gorm.DeletedAt `gorm:"index"`
 
-	PasswdHash       []byte `gorm:"size:4096"`
-	PasswdSalt       []byte `gorm:"size:20"`
-	RefreshTokenHash []byte `gorm:"size:4096"`
-	RefreshTokenSalt []byte `gorm:"size:20"`
+	TenantID string `gorm:"index;size:30"`
+
+	// Password hash having its algorithm and parameters
+	PasswdPHC string `gorm:"column:passwd_phc;size:255"`
+	// Time when the password is changed. Null means the password isn't changed since the user is created.
+	PasswdChangedAt *time.Time
+
+	// Legacy PBKDF2 password hash and salt. They are replaced by the PHC string at the next login.
+	PasswdHash []byte `gorm:"size:4096"`
+	PasswdSalt []byte `gorm:"size:20"`
+
+	// TOTP secret encrypted with the MFA secret. TOTP is enrolled if the secret is set, and it's enabled after
+	// it's confirmed with a first code.
+	TOTPSecret    []byte     `gorm:"column:totp_secret;size:255"`
+	TOTPEnabledAt *time.Time `gorm:"column:totp_enabled_at"`
+	// Time step of the last used TOTP code. Codes of the time step or before can't be used again.
+	TOTPLastStep int64 `gorm:"column:totp_last_step"`
+}
+
+// Check whether the user has a password. Users without a password login only by passkeys.
+func (u *UserSecret) HasPasswd() bool {
+	return u.PasswdPHC != "" || len(u.PasswdHash) > 0
+}
+
+// Get the password hash. The legacy password hash and salt are converted to a PHC string.
+func (u *UserSecret) GetPasswdHash() string {
+	if u.PasswdPHC != "" {
+		return u.PasswdPHC
+	}
+	return hashing.GetLegacyPasswdHash(u.PasswdHash, u.PasswdSalt)
+}
+
+// Get the time when the password is changed
+func (u *UserSecret) GetPasswdChangedAt() time.Time {
+	if u.PasswdChangedAt != nil {
+		return *u.PasswdChangedAt
+	}
+	return u.CreatedAt
+}
+
+// Check whether TOTP is confirmed and MFA is required for login
+func (u *UserSecret) IsTOTPEnabled() bool {
+	return len(u.TOTPSecret) > 0 && u.TOTPEnabledAt != nil
 }
@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/ssup2ket/service-auth/pkg/auth/webauthn"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+// WebAuthn credential (passkey) of a user. The public key is a COSE key, and the sign count is increased by
+// authenticators supporting it for every login.
+type WebAuthnCredential struct {
+	ID        uuid.EntityUUID `gorm:"primaryKey;type:binary(16)"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	UserID       uuid.EntityUUID `gorm:"index;type:binary(16)"`
+	Name         string          `gorm:"size:100"`
+	CredentialID []byte          `gorm:"uniqueIndex;size:1023"`
+	PublicKey    []byte          `gorm:"size:1024"`
+	SignCount    uint32
+	Transports   StrList `gorm:"size:255"`
+	LastUsedAt   *time.Time
+}
+
+// WebAuthn challenge type
+type WebAuthnChallengeType string
+
+const (
+	WebAuthnChallengeTypeRegistration WebAuthnChallengeType = "registration"
+	WebAuthnChallengeTypeLogin        WebAuthnChallengeType = "login"
+)
+
+// WebAuthnChallenge is the challenge of a registration or login ceremony. It can be used only once before ExpiresAt,
+// and login challenges don't have a user because users are found by discoverable credentials.
+type WebAuthnChallenge struct {
+	ID        uuid.EntityUUID `gorm:"primaryKey;type:binary(16)"`
+	CreatedAt time.Time
+
+	Type      WebAuthnChallengeType `gorm:"size:20"`
+	TenantID  string                `gorm:"size:30"`
+	UserID    uuid.EntityUUID       `gorm:"type:binary(16)"`
+	Challenge []byte                `gorm:"size:64"`
+	ExpiresAt time.Time             `gorm:"index"`
+}
+
+// Options of a passkey registration ceremony
+type PasskeyRegistration struct {
+	ChallengeID uuid.EntityUUID
+	Options     *webauthn.CreationOptions
+}
+
+// Options of a passkey login ceremony
+type PasskeyLogin struct {
+	ChallengeID uuid.EntityUUID
+	Options     *webauthn.RequestOptions
+}
+
+// Response of navigator.credentials.create() to finish a passkey registration
+type PasskeyAttestation struct {
+	CredentialID      []byte
+	ClientDataJSON    []byte
+	AttestationObject []byte
+	Transports        []string
+}
+
+// Response of navigator.credentials.get() to finish a passkey login. User handle is the user ID
+// which authenticators return for discoverable credentials.
+type PasskeyAssertion struct {
+	CredentialID      []byte
+	ClientDataJSON    []byte
+	AuthenticatorData []byte
+	Signature         []byte
+	UserHandle        []byte
+}
@@ -0,0 +1,129 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/casbin/casbin/model"
+	"github.com/rs/zerolog/log"
+)
+
+var errCasbinNotImplemented = fmt.Errorf("not implemented, permissions are changed only by the permission service")
+
+// Casbin adapter loading all permissions. Permissions are changed only by the permission
+// service, so the policy version is increased with permission changes in the same transaction.
+type CasbinAdapter struct {
+	permissionRepo PermissionRepo
+}
+
+func NewCasbinAdapter(permissionRepo PermissionRepo) *CasbinAdapter {
+	return &CasbinAdapter{
+		permissionRepo: permissionRepo,
+	}
+}
+
+func (c *CasbinAdapter) LoadPolicy(m model.Model) error {
+	permissions, err := c.permissionRepo.ListAll(log.Logger.WithContext(context.Background()))
+	if err != nil {
+		return err
+	}
+	for _, permission := range permissions {
+		m.AddPolicy("p", "p", []string{permission.Subject, permission.Object, permission.Action})
+	}
+	return nil
+}
+
+func (c *CasbinAdapter) SavePolicy(m model.Model) error {
+	return errCasbinNotImplemented
+}
+
+func (c *CasbinAdapter) AddPolicy(sec string, ptype string, rule []string) error {
+	return errCasbinNotImplemented
+}
+
+func (c *CasbinAdapter) RemovePolicy(sec string, ptype string, rule []string) error {
+	return errCasbinNotImplemented
+}
+
+func (c *CasbinAdapter) RemoveFilteredPolicy(sec string, ptype string, fieldIndex int, fieldValues ...string) error {
+	return errCasbinNotImplemented
+}
+
+// Casbin watcher polling the policy version. The update callback is called when the policy version
+// is changed by any replica, so permission changes are applied to all replicas without a restart.
+type CasbinWatcher struct {
+	permissionRepo PermissionRepo
+
+	mutex    sync.Mutex
+	version  uint64
+	callback func(string)
+	ticker   *time.Ticker
+	done     chan struct{}
+}
+
+func NewCasbinWatcher(permissionRepo PermissionRepo, period time.Duration) (*CasbinWatcher, error) {
+	ctx := log.Logger.WithContext(context.Background())
+
+	// Get current policy version before loading policies not to miss changes
+	version, err := permissionRepo.GetPolicyVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	watcher := &CasbinWatcher{
+		permissionRepo: permissionRepo,
+		version:        version,
+		ticker:         time.NewTicker(period),
+		done:           make(chan struct{}),
+	}
+	go watcher.watch(ctx)
+	return watcher, nil
+}
+
+func (c *CasbinWatcher) SetUpdateCallback(callback func(string)) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.callback = callback
+	return nil
+}
+
+func (c *CasbinWatcher) Update() error {
+	return c.permissionRepo.IncreasePolicyVersion(log.Logger.WithContext(context.Background()))
+}
+
+func (c *CasbinWatcher) Close() {
+	c.ticker.Stop()
+	close(c.done)
+}
+
+func (c *CasbinWatcher) watch(ctx context.Context) {
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-c.ticker.C:
+			c.checkVersion(ctx)
+		}
+	}
+}
+
+func (c *CasbinWatcher) checkVersion(ctx context.Context) {
+	version, err := c.permissionRepo.GetPolicyVersion(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get policy version")
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if version == c.version {
+		return
+	}
+	log.Ctx(ctx).Info().Uint64("policy_version", version).Msg("Policy version is changed, reload policies")
+	c.version = version
+	if c.callback != nil {
+		c.callback(fmt.Sprintf("%d", version))
+	}
+}
@@ -0,0 +1,96 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/casbin/casbin/model"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	"github.com/ssup2ket/service-auth/internal/test"
+)
+
+func TestCasbin(t *testing.T) {
+	suite.Run(t, new(casbinSuite))
+}
+
+type casbinSuite struct {
+	suite.Suite
+	sqlMock sqlmock.Sqlmock
+
+	repo PermissionRepo
+}
+
+func (c *casbinSuite) SetupTest() {
+	var err error
+	var db *sql.DB
+
+	// Init sqlMock
+	db, c.sqlMock, err = sqlmock.New()
+	require.NoError(c.T(), err)
+
+	// Init DB
+	primaryMySQL, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}))
+	require.NoError(c.T(), err)
+
+	// Init repo
+	c.repo = NewPermissionRepoImp(primaryMySQL)
+}
+
+func (c *casbinSuite) AfterTest(_, _ string) {
+	require.NoError(c.T(), c.sqlMock.ExpectationsWereMet())
+}
+
+func (c *casbinSuite) TestAdapterLoadPolicySuccess() {
+	c.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `permissions`")).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "subject", "object", "action"}).
+				AddRow(test.PermissionIDCorrect, test.RoleNameCorrect, test.PermissionObjectCorrect,
+					test.PermissionActionCorrect),
+		)
+
+	m := model.Model{}
+	m.LoadModelFromText(`
+[request_definition]
+r = sub, obj, act
+[policy_definition]
+p = sub, obj, act
+[policy_effect]
+e = some(where (p.eft == allow))
+[matchers]
+m = r.sub == p.sub && r.obj == p.obj && r.act == p.act
+`)
+	err := NewCasbinAdapter(c.repo).LoadPolicy(m)
+	require.NoError(c.T(), err)
+	require.Equal(c.T(), [][]string{{test.RoleNameCorrect, test.PermissionObjectCorrect, test.PermissionActionCorrect}},
+		m.GetPolicy("p", "p"))
+}
+
+func (c *casbinSuite) TestWatcherCallbackOnVersionChanged() {
+	c.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `policy_versions` WHERE id = ? ORDER BY `policy_versions`.`id` LIMIT 1")).
+		WithArgs(policyVersionID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "version"}).AddRow(policyVersionID, 1))
+	c.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `policy_versions` WHERE id = ? ORDER BY `policy_versions`.`id` LIMIT 1")).
+		WithArgs(policyVersionID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "version"}).AddRow(policyVersionID, 2))
+
+	watcher, err := NewCasbinWatcher(c.repo, time.Hour)
+	require.NoError(c.T(), err)
+	defer watcher.Close()
+
+	updatedVersion := ""
+	require.NoError(c.T(), watcher.SetUpdateCallback(func(version string) { updatedVersion = version }))
+	watcher.checkVersion(log.Logger.WithContext(context.Background()))
+	require.Equal(c.T(), "2", updatedVersion)
+}
@@ -0,0 +1,86 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+// Email verification repo
+type EmailVerificationRepo interface {
+	WithTx(tx DBTx) EmailVerificationRepo
+
+	Create(ctx context.Context, emailVerification *entity.EmailVerification) error
+	GetForUpdate(ctx context.Context, tokenHash string) (*entity.EmailVerification, error)
+	Delete(ctx context.Context, tokenHash string) error
+	DeleteByUser(ctx context.Context, userUUID uuid.EntityUUID) error
+	DeleteExpired(ctx context.Context, now time.Time) error
+}
+
+type EmailVerificationRepoImp struct {
+	db *gorm.DB
+}
+
+func NewEmailVerificationRepoImp(repoDB *gorm.DB) *EmailVerificationRepoImp {
+	return &EmailVerificationRepoImp{
+		db: repoDB,
+	}
+}
+
+func (e *EmailVerificationRepoImp) WithTx(tx DBTx) EmailVerificationRepo {
+	transaction := tx.GetTx()
+	return NewEmailVerificationRepoImp(transaction)
+}
+
+func (e *EmailVerificationRepoImp) Create(ctx context.Context, emailVerification *entity.EmailVerification) error {
+	result := e.db.Create(emailVerification)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to create email verification in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
+
+// Lock the email verification in the transaction not to use the token concurrently
+func (e *EmailVerificationRepoImp) GetForUpdate(ctx context.Context, tokenHash string) (*entity.EmailVerification, error) {
+	emailVerification := entity.EmailVerification{}
+	result := e.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&emailVerification, "id = ?", tokenHash)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to get email verification for update from DB")
+		return nil, getReturnErr(result.Error)
+	}
+	return &emailVerification, nil
+}
+
+func (e *EmailVerificationRepoImp) Delete(ctx context.Context, tokenHash string) error {
+	result := e.db.Delete(&entity.EmailVerification{}, "id = ?", tokenHash)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to delete email verification in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
+
+func (e *EmailVerificationRepoImp) DeleteByUser(ctx context.Context, userUUID uuid.EntityUUID) error {
+	result := e.db.Delete(&entity.EmailVerification{}, "user_id = ?", userUUID)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to delete email verifications of user in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
+
+func (e *EmailVerificationRepoImp) DeleteExpired(ctx context.Context, now time.Time) error {
+	result := e.db.Delete(&entity.EmailVerification{}, "expires_at < ?", now)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to delete expired email verifications in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
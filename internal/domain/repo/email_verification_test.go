@@ -0,0 +1,125 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/test"
+)
+
+func TestEmailVerification(t *testing.T) {
+	suite.Run(t, new(emailVerificationSuite))
+}
+
+type emailVerificationSuite struct {
+	suite.Suite
+	sqlMock sqlmock.Sqlmock
+
+	repo EmailVerificationRepo
+}
+
+func (e *emailVerificationSuite) SetupTest() {
+	var err error
+	var db *sql.DB
+
+	// Init sqlMock
+	db, e.sqlMock, err = sqlmock.New()
+	require.NoError(e.T(), err)
+
+	// Init DB
+	primaryMySQL, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}))
+	require.NoError(e.T(), err)
+
+	// Init repo
+	e.repo = NewEmailVerificationRepoImp(primaryMySQL)
+}
+
+func (e *emailVerificationSuite) AfterTest(_, _ string) {
+	require.NoError(e.T(), e.sqlMock.ExpectationsWereMet())
+}
+
+func (e *emailVerificationSuite) TestCreateSuccess() {
+	e.sqlMock.ExpectBegin()
+	e.sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `email_verifications` (`id`,`created_at`,`user_id`,`tenant_id`,`email`,`expires_at`) VALUES (?,?,?,?,?,?)")).
+		WithArgs(test.EmailVerificationTokenHashCorrect, sqlmock.AnyArg(), test.UserIDCorrect, test.TenantIDCorrect, test.UserEmailCorrect, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	e.sqlMock.ExpectCommit()
+
+	err := e.repo.Create(context.Background(), &entity.EmailVerification{
+		ID:        test.EmailVerificationTokenHashCorrect,
+		UserID:    test.UserIDCorrect,
+		TenantID:  test.TenantIDCorrect,
+		Email:     test.UserEmailCorrect,
+		ExpiresAt: time.Now().Add(time.Hour),
+	})
+	require.NoError(e.T(), err)
+}
+
+func (e *emailVerificationSuite) TestGetForUpdateSuccess() {
+	e.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `email_verifications` WHERE id = ? ORDER BY `email_verifications`.`id` LIMIT 1 FOR UPDATE")).
+		WithArgs(test.EmailVerificationTokenHashCorrect).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "email"}).
+			AddRow(test.EmailVerificationTokenHashCorrect, test.UserIDCorrect, test.UserEmailCorrect))
+
+	emailVerification, err := e.repo.GetForUpdate(context.Background(), test.EmailVerificationTokenHashCorrect)
+	require.NoError(e.T(), err)
+	require.Equal(e.T(), test.UserIDCorrect, emailVerification.UserID)
+	require.Equal(e.T(), test.UserEmailCorrect, emailVerification.Email)
+}
+
+func (e *emailVerificationSuite) TestGetForUpdateNotFound() {
+	e.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `email_verifications` WHERE id = ? ORDER BY `email_verifications`.`id` LIMIT 1 FOR UPDATE")).
+		WithArgs(test.EmailVerificationTokenHashCorrect).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	_, err := e.repo.GetForUpdate(context.Background(), test.EmailVerificationTokenHashCorrect)
+	require.Equal(e.T(), ErrNotFound, err)
+}
+
+func (e *emailVerificationSuite) TestDeleteSuccess() {
+	e.sqlMock.ExpectBegin()
+	e.sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `email_verifications` WHERE id = ?")).
+		WithArgs(test.EmailVerificationTokenHashCorrect).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	e.sqlMock.ExpectCommit()
+
+	err := e.repo.Delete(context.Background(), test.EmailVerificationTokenHashCorrect)
+	require.NoError(e.T(), err)
+}
+
+func (e *emailVerificationSuite) TestDeleteByUserSuccess() {
+	e.sqlMock.ExpectBegin()
+	e.sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `email_verifications` WHERE user_id = ?")).
+		WithArgs(test.UserIDCorrect).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	e.sqlMock.ExpectCommit()
+
+	err := e.repo.DeleteByUser(context.Background(), test.UserIDCorrect)
+	require.NoError(e.T(), err)
+}
+
+func (e *emailVerificationSuite) TestDeleteExpiredSuccess() {
+	now := time.Now()
+
+	e.sqlMock.ExpectBegin()
+	e.sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `email_verifications` WHERE expires_at < ?")).
+		WithArgs(now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	e.sqlMock.ExpectCommit()
+
+	err := e.repo.DeleteExpired(context.Background(), now)
+	require.NoError(e.T(), err)
+}
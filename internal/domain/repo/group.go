@@ -0,0 +1,123 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+// Group repo. Every query is scoped to a tenant like the user info repo, except counting by role
+// because roles are shared by all tenants.
+type GroupRepo interface {
+	WithTx(tx DBTx) GroupRepo
+
+	List(ctx context.Context, tenantID string, offset int, limit int) ([]entity.Group, error)
+	ListByIDs(ctx context.Context, tenantID string, groupUUIDs []uuid.EntityUUID) ([]entity.Group, error)
+	Create(ctx context.Context, group *entity.Group) error
+	Get(ctx context.Context, tenantID string, groupUUID uuid.EntityUUID) (*entity.Group, error)
+	CountByRole(ctx context.Context, role entity.UserRole) (int64, error)
+	CountByTenant(ctx context.Context, tenantID string) (int64, error)
+	Update(ctx context.Context, group *entity.Group) error
+	Delete(ctx context.Context, tenantID string, groupUUID uuid.EntityUUID) error
+}
+
+type GroupRepoImp struct {
+	db *gorm.DB
+}
+
+func NewGroupRepoImp(repoDB *gorm.DB) *GroupRepoImp {
+	return &GroupRepoImp{
+		db: repoDB,
+	}
+}
+
+func (g *GroupRepoImp) WithTx(tx DBTx) GroupRepo {
+	transaction := tx.GetTx()
+	return NewGroupRepoImp(transaction)
+}
+
+func (g *GroupRepoImp) List(ctx context.Context, tenantID string, offset int, limit int) ([]entity.Group, error) {
+	groups := []entity.Group{}
+	result := g.db.Where("tenant_id = ?", tenantID).Offset(offset).Limit(limit).Find(&groups)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to list groups from DB")
+		return nil, getReturnErr(result.Error)
+	}
+	return groups, nil
+}
+
+func (g *GroupRepoImp) ListByIDs(ctx context.Context, tenantID string, groupUUIDs []uuid.EntityUUID) ([]entity.Group, error) {
+	groups := []entity.Group{}
+	if len(groupUUIDs) == 0 {
+		return groups, nil
+	}
+	result := g.db.Where("tenant_id = ? AND id IN ?", tenantID, groupUUIDs).Find(&groups)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to list groups from DB by IDs")
+		return nil, getReturnErr(result.Error)
+	}
+	return groups, nil
+}
+
+func (g *GroupRepoImp) Create(ctx context.Context, group *entity.Group) error {
+	result := g.db.Create(group)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to create group in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
+
+func (g *GroupRepoImp) Get(ctx context.Context, tenantID string, groupUUID uuid.EntityUUID) (*entity.Group, error) {
+	group := entity.Group{}
+	result := g.db.First(&group, "tenant_id = ? AND id = ?", tenantID, groupUUID)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to get group from DB")
+		return nil, getReturnErr(result.Error)
+	}
+	return &group, nil
+}
+
+// Count groups having the role. Roles are stored as a JSON list.
+func (g *GroupRepoImp) CountByRole(ctx context.Context, role entity.UserRole) (int64, error) {
+	var count int64
+	result := g.db.Model(&entity.Group{}).Where("JSON_CONTAINS(roles, JSON_QUOTE(?))", string(role)).Count(&count)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to count groups from DB by role")
+		return 0, getReturnErr(result.Error)
+	}
+	return count, nil
+}
+
+func (g *GroupRepoImp) CountByTenant(ctx context.Context, tenantID string) (int64, error) {
+	var count int64
+	result := g.db.Model(&entity.Group{}).Where("tenant_id = ?", tenantID).Count(&count)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to count groups from DB by tenant")
+		return 0, getReturnErr(result.Error)
+	}
+	return count, nil
+}
+
+// Update the group in its tenant. The tenant of a group can't be changed.
+func (g *GroupRepoImp) Update(ctx context.Context, group *entity.Group) error {
+	result := g.db.Model(group).Select("name", "description", "roles").Where("tenant_id = ?", group.TenantID).Updates(group)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to update group in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
+
+func (g *GroupRepoImp) Delete(ctx context.Context, tenantID string, groupUUID uuid.EntityUUID) error {
+	result := g.db.Delete(&entity.Group{}, "tenant_id = ? AND id = ?", tenantID, groupUUID)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to delete group in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
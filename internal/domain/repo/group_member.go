@@ -0,0 +1,110 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+// Group member repo. Members are found by their group or by themselves. Group and member IDs are UUIDs,
+// so members aren't scoped to a tenant. Tenants are checked by the group repo.
+type GroupMemberRepo interface {
+	WithTx(tx DBTx) GroupMemberRepo
+
+	List(ctx context.Context, groupUUID uuid.EntityUUID, offset int, limit int) ([]entity.GroupMember, error)
+	ListByMemberIDs(ctx context.Context, memberUUIDs []uuid.EntityUUID) ([]entity.GroupMember, error)
+	Create(ctx context.Context, groupMember *entity.GroupMember) error
+	Get(ctx context.Context, groupUUID, memberUUID uuid.EntityUUID) (*entity.GroupMember, error)
+	Delete(ctx context.Context, groupUUID, memberUUID uuid.EntityUUID) error
+	DeleteByGroup(ctx context.Context, groupUUID uuid.EntityUUID) error
+	DeleteByMember(ctx context.Context, memberUUID uuid.EntityUUID) error
+}
+
+type GroupMemberRepoImp struct {
+	db *gorm.DB
+}
+
+func NewGroupMemberRepoImp(repoDB *gorm.DB) *GroupMemberRepoImp {
+	return &GroupMemberRepoImp{
+		db: repoDB,
+	}
+}
+
+func (g *GroupMemberRepoImp) WithTx(tx DBTx) GroupMemberRepo {
+	transaction := tx.GetTx()
+	return NewGroupMemberRepoImp(transaction)
+}
+
+func (g *GroupMemberRepoImp) List(ctx context.Context, groupUUID uuid.EntityUUID, offset int, limit int) ([]entity.GroupMember, error) {
+	groupMembers := []entity.GroupMember{}
+	result := g.db.Where("group_id = ?", groupUUID).Offset(offset).Limit(limit).Find(&groupMembers)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to list group members from DB")
+		return nil, getReturnErr(result.Error)
+	}
+	return groupMembers, nil
+}
+
+// List memberships of the members, which have groups of the members
+func (g *GroupMemberRepoImp) ListByMemberIDs(ctx context.Context, memberUUIDs []uuid.EntityUUID) ([]entity.GroupMember, error) {
+	groupMembers := []entity.GroupMember{}
+	if len(memberUUIDs) == 0 {
+		return groupMembers, nil
+	}
+	result := g.db.Where("member_id IN ?", memberUUIDs).Find(&groupMembers)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to list group members from DB by member IDs")
+		return nil, getReturnErr(result.Error)
+	}
+	return groupMembers, nil
+}
+
+func (g *GroupMemberRepoImp) Create(ctx context.Context, groupMember *entity.GroupMember) error {
+	result := g.db.Create(groupMember)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to create group member in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
+
+func (g *GroupMemberRepoImp) Get(ctx context.Context, groupUUID, memberUUID uuid.EntityUUID) (*entity.GroupMember, error) {
+	groupMember := entity.GroupMember{}
+	result := g.db.First(&groupMember, "group_id = ? AND member_id = ?", groupUUID, memberUUID)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to get group member from DB")
+		return nil, getReturnErr(result.Error)
+	}
+	return &groupMember, nil
+}
+
+func (g *GroupMemberRepoImp) Delete(ctx context.Context, groupUUID, memberUUID uuid.EntityUUID) error {
+	result := g.db.Delete(&entity.GroupMember{}, "group_id = ? AND member_id = ?", groupUUID, memberUUID)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to delete group member in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
+
+func (g *GroupMemberRepoImp) DeleteByGroup(ctx context.Context, groupUUID uuid.EntityUUID) error {
+	result := g.db.Delete(&entity.GroupMember{}, "group_id = ?", groupUUID)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to delete group members in DB by group")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
+
+func (g *GroupMemberRepoImp) DeleteByMember(ctx context.Context, memberUUID uuid.EntityUUID) error {
+	result := g.db.Delete(&entity.GroupMember{}, "member_id = ?", memberUUID)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to delete group members in DB by member")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	gomysql "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/test"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+func TestGroupMember(t *testing.T) {
+	suite.Run(t, new(groupMemberSuite))
+}
+
+type groupMemberSuite struct {
+	suite.Suite
+	sqlMock sqlmock.Sqlmock
+
+	tx   *DBTxImp
+	repo GroupMemberRepo
+}
+
+func (g *groupMemberSuite) SetupTest() {
+	var err error
+	var db *sql.DB
+
+	// Init sqlMock
+	db, g.sqlMock, err = sqlmock.New()
+	require.NoError(g.T(), err)
+
+	// Init DB
+	primaryMySQL, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}))
+	require.NoError(g.T(), err)
+
+	// Init transaction, repo
+	g.tx = NewDBTxImp(primaryMySQL)
+	g.repo = NewGroupMemberRepoImp(primaryMySQL)
+}
+
+func (g *groupMemberSuite) AfterTest(_, _ string) {
+	require.NoError(g.T(), g.sqlMock.ExpectationsWereMet())
+}
+
+func (g *groupMemberSuite) TestListSuccess() {
+	g.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `group_members` WHERE group_id = ? LIMIT 10")).
+		WithArgs(test.GroupIDCorrect).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"group_id", "member_id", "member_type"}).
+				AddRow(test.GroupIDCorrect, test.UserIDCorrect, entity.GroupMemberTypeUser),
+		)
+
+	groupMembers, err := g.repo.List(context.Background(), test.GroupIDCorrect, 0, 10)
+	require.NoError(g.T(), err)
+	require.Equal(g.T(), test.UserIDCorrect, groupMembers[0].MemberID)
+	require.Equal(g.T(), entity.GroupMemberTypeUser, groupMembers[0].MemberType)
+}
+
+func (g *groupMemberSuite) TestListByMemberIDsSuccess() {
+	g.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `group_members` WHERE member_id IN (?,?)")).
+		WithArgs(test.UserIDCorrect, test.GroupIDCorrect).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"group_id", "member_id", "member_type"}).
+				AddRow(test.GroupIDCorrect, test.UserIDCorrect, entity.GroupMemberTypeUser).
+				AddRow(test.GroupIDCorrect2, test.GroupIDCorrect, entity.GroupMemberTypeGroup),
+		)
+
+	groupMembers, err := g.repo.ListByMemberIDs(context.Background(), []uuid.EntityUUID{test.UserIDCorrect, test.GroupIDCorrect})
+	require.NoError(g.T(), err)
+	require.Equal(g.T(), test.GroupIDCorrect, groupMembers[0].GroupID)
+	require.Equal(g.T(), test.GroupIDCorrect2, groupMembers[1].GroupID)
+}
+
+func (g *groupMemberSuite) TestCreateSuccess() {
+	g.sqlMock.ExpectBegin()
+	g.sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `group_members` (`group_id`,`member_id`,`created_at`,`member_type`) VALUES (?,?,?,?)")).
+		WithArgs(test.GroupIDCorrect, test.UserIDCorrect, sqlmock.AnyArg(), entity.GroupMemberTypeUser).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	g.sqlMock.ExpectCommit()
+
+	groupMember := test.GroupMemberUserCorrect
+	err := g.repo.Create(context.Background(), &groupMember)
+	require.NoError(g.T(), err)
+}
+
+func (g *groupMemberSuite) TestCreateConflict() {
+	g.sqlMock.ExpectBegin()
+	g.sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `group_members` (`group_id`,`member_id`,`created_at`,`member_type`) VALUES (?,?,?,?)")).
+		WithArgs(test.GroupIDCorrect, test.UserIDCorrect, sqlmock.AnyArg(), entity.GroupMemberTypeUser).
+		WillReturnError(&gomysql.MySQLError{Number: 1062})
+	g.sqlMock.ExpectRollback()
+
+	groupMember := test.GroupMemberUserCorrect
+	err := g.repo.Create(context.Background(), &groupMember)
+	require.Equal(g.T(), ErrConflict, err)
+}
+
+func (g *groupMemberSuite) TestGetNotFound() {
+	g.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `group_members` WHERE group_id = ? AND member_id = ? ORDER BY `group_members`.`group_id` LIMIT 1")).
+		WithArgs(test.GroupIDCorrect, test.UserIDCorrect).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	_, err := g.repo.Get(context.Background(), test.GroupIDCorrect, test.UserIDCorrect)
+	require.Equal(g.T(), ErrNotFound, err)
+}
+
+func (g *groupMemberSuite) TestDeleteSuccess() {
+	g.sqlMock.ExpectBegin()
+	g.sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `group_members` WHERE group_id = ? AND member_id = ?")).
+		WithArgs(test.GroupIDCorrect, test.UserIDCorrect).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	g.sqlMock.ExpectCommit()
+
+	err := g.repo.Delete(context.Background(), test.GroupIDCorrect, test.UserIDCorrect)
+	require.NoError(g.T(), err)
+}
+
+func (g *groupMemberSuite) TestDeleteByGroupSuccess() {
+	g.sqlMock.ExpectBegin()
+	g.sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `group_members` WHERE group_id = ?")).
+		WithArgs(test.GroupIDCorrect).
+		WillReturnResult(sqlmock.NewResult(1, 2))
+	g.sqlMock.ExpectCommit()
+
+	err := g.repo.DeleteByGroup(context.Background(), test.GroupIDCorrect)
+	require.NoError(g.T(), err)
+}
+
+func (g *groupMemberSuite) TestDeleteByMemberError() {
+	g.sqlMock.ExpectBegin()
+	g.sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `group_members` WHERE member_id = ?")).
+		WithArgs(test.UserIDCorrect).
+		WillReturnError(fmt.Errorf("error"))
+	g.sqlMock.ExpectRollback()
+
+	err := g.repo.DeleteByMember(context.Background(), test.UserIDCorrect)
+	require.Error(g.T(), err)
+}
@@ -0,0 +1,190 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	gomysql "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/test"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+func TestGroup(t *testing.T) {
+	suite.Run(t, new(groupSuite))
+}
+
+type groupSuite struct {
+	suite.Suite
+	sqlMock sqlmock.Sqlmock
+
+	tx   *DBTxImp
+	repo GroupRepo
+}
+
+func (g *groupSuite) SetupTest() {
+	var err error
+	var db *sql.DB
+
+	// Init sqlMock
+	db, g.sqlMock, err = sqlmock.New()
+	require.NoError(g.T(), err)
+
+	// Init DB
+	primaryMySQL, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}))
+	require.NoError(g.T(), err)
+
+	// Init transaction, repo
+	g.tx = NewDBTxImp(primaryMySQL)
+	g.repo = NewGroupRepoImp(primaryMySQL)
+}
+
+func (g *groupSuite) AfterTest(_, _ string) {
+	require.NoError(g.T(), g.sqlMock.ExpectationsWereMet())
+}
+
+func (g *groupSuite) TestListSuccess() {
+	g.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `groups` WHERE tenant_id = ? LIMIT 10")).
+		WithArgs(test.TenantIDCorrect).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "tenant_id", "name", "description", "roles"}).
+				AddRow(test.GroupIDCorrect, test.TenantIDCorrect, test.GroupNameCorrect, test.GroupDescriptionCorrect, `["tester"]`),
+		)
+
+	groups, err := g.repo.List(context.Background(), test.TenantIDCorrect, 0, 10)
+	require.NoError(g.T(), err)
+	require.Equal(g.T(), test.GroupIDCorrect, groups[0].ID)
+	require.Equal(g.T(), test.GroupNameCorrect, groups[0].Name)
+	require.Equal(g.T(), entity.StrList{test.RoleNameCorrect}, groups[0].Roles)
+}
+
+func (g *groupSuite) TestListByIDsSuccess() {
+	g.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `groups` WHERE tenant_id = ? AND id IN (?,?)")).
+		WithArgs(test.TenantIDCorrect, test.GroupIDCorrect, test.GroupIDCorrect2).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "tenant_id", "name", "roles"}).
+				AddRow(test.GroupIDCorrect, test.TenantIDCorrect, test.GroupNameCorrect, `["tester"]`),
+		)
+
+	groups, err := g.repo.ListByIDs(context.Background(), test.TenantIDCorrect, []uuid.EntityUUID{test.GroupIDCorrect, test.GroupIDCorrect2})
+	require.NoError(g.T(), err)
+	require.Len(g.T(), groups, 1)
+	require.Equal(g.T(), test.GroupIDCorrect, groups[0].ID)
+}
+
+func (g *groupSuite) TestListByIDsEmpty() {
+	groups, err := g.repo.ListByIDs(context.Background(), test.TenantIDCorrect, nil)
+	require.NoError(g.T(), err)
+	require.Empty(g.T(), groups)
+}
+
+func (g *groupSuite) TestCreateSuccess() {
+	g.sqlMock.ExpectBegin()
+	g.sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `groups` (`id`,`created_at`,`updated_at`,`tenant_id`,`name`,`description`,`roles`) VALUES (?,?,?,?,?,?,?)")).
+		WithArgs(test.GroupIDCorrect, sqlmock.AnyArg(), sqlmock.AnyArg(), test.TenantIDCorrect, test.GroupNameCorrect, test.GroupDescriptionCorrect, `["tester"]`).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	g.sqlMock.ExpectCommit()
+
+	group := test.GroupCorrect
+	err := g.repo.Create(context.Background(), &group)
+	require.NoError(g.T(), err)
+}
+
+func (g *groupSuite) TestCreateConflict() {
+	g.sqlMock.ExpectBegin()
+	g.sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `groups` (`id`,`created_at`,`updated_at`,`tenant_id`,`name`,`description`,`roles`) VALUES (?,?,?,?,?,?,?)")).
+		WithArgs(test.GroupIDCorrect, sqlmock.AnyArg(), sqlmock.AnyArg(), test.TenantIDCorrect, test.GroupNameCorrect, test.GroupDescriptionCorrect, `["tester"]`).
+		WillReturnError(&gomysql.MySQLError{Number: 1062})
+	g.sqlMock.ExpectRollback()
+
+	group := test.GroupCorrect
+	err := g.repo.Create(context.Background(), &group)
+	require.Equal(g.T(), ErrConflict, err)
+}
+
+func (g *groupSuite) TestGetSuccess() {
+	g.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `groups` WHERE tenant_id = ? AND id = ? ORDER BY `groups`.`id` LIMIT 1")).
+		WithArgs(test.TenantIDCorrect, test.GroupIDCorrect).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "name", "roles"}).
+			AddRow(test.GroupIDCorrect, test.TenantIDCorrect, test.GroupNameCorrect, `["tester"]`))
+
+	group, err := g.repo.Get(context.Background(), test.TenantIDCorrect, test.GroupIDCorrect)
+	require.NoError(g.T(), err)
+	require.Equal(g.T(), test.GroupIDCorrect, group.ID)
+	require.Equal(g.T(), test.GroupNameCorrect, group.Name)
+}
+
+func (g *groupSuite) TestGetNotFound() {
+	g.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `groups` WHERE tenant_id = ? AND id = ? ORDER BY `groups`.`id` LIMIT 1")).
+		WithArgs(test.TenantIDCorrect, test.GroupIDCorrect).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	_, err := g.repo.Get(context.Background(), test.TenantIDCorrect, test.GroupIDCorrect)
+	require.Equal(g.T(), ErrNotFound, err)
+}
+
+func (g *groupSuite) TestCountByRoleSuccess() {
+	g.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `groups` WHERE JSON_CONTAINS(roles, JSON_QUOTE(?))")).
+		WithArgs(test.RoleNameCorrect).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	count, err := g.repo.CountByRole(context.Background(), test.RoleNameCorrect)
+	require.NoError(g.T(), err)
+	require.Equal(g.T(), int64(2), count)
+}
+
+func (g *groupSuite) TestCountByTenantSuccess() {
+	g.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `groups` WHERE tenant_id = ?")).
+		WithArgs(test.TenantIDCorrect).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	count, err := g.repo.CountByTenant(context.Background(), test.TenantIDCorrect)
+	require.NoError(g.T(), err)
+	require.Equal(g.T(), int64(1), count)
+}
+
+func (g *groupSuite) TestUpdateSuccess() {
+	g.sqlMock.ExpectBegin()
+	g.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `groups` SET `updated_at`=?,`name`=?,`description`=?,`roles`=? WHERE tenant_id = ? AND `id` = ?")).
+		WithArgs(sqlmock.AnyArg(), test.GroupNameCorrect, test.GroupDescriptionCorrect, `["tester"]`, test.TenantIDCorrect, test.GroupIDCorrect).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	g.sqlMock.ExpectCommit()
+
+	group := test.GroupCorrect
+	err := g.repo.Update(context.Background(), &group)
+	require.NoError(g.T(), err)
+}
+
+func (g *groupSuite) TestDeleteSuccess() {
+	g.sqlMock.ExpectBegin()
+	g.sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `groups` WHERE tenant_id = ? AND id = ?")).
+		WithArgs(test.TenantIDCorrect, test.GroupIDCorrect).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	g.sqlMock.ExpectCommit()
+
+	err := g.repo.Delete(context.Background(), test.TenantIDCorrect, test.GroupIDCorrect)
+	require.NoError(g.T(), err)
+}
+
+func (g *groupSuite) TestDeleteError() {
+	g.sqlMock.ExpectBegin()
+	g.sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `groups` WHERE tenant_id = ? AND id = ?")).
+		WithArgs(test.TenantIDCorrect, test.GroupIDCorrect).
+		WillReturnError(fmt.Errorf("error"))
+	g.sqlMock.ExpectRollback()
+
+	err := g.repo.Delete(context.Background(), test.TenantIDCorrect, test.GroupIDCorrect)
+	require.Error(g.T(), err)
+}
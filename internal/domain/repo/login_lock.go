@@ -0,0 +1,273 @@
+package repo
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+// Login lock repo. Login locks aren't changed in transactions, because they are also stored in memory.
+type LoginLockRepo interface {
+	List(ctx context.Context, offset int, limit int) ([]entity.LoginLock, error)
+	Get(ctx context.Context, lockUUID uuid.EntityUUID) (*entity.LoginLock, error)
+	GetBySubject(ctx context.Context, lockType entity.LoginLockType, tenantID, subject string) (*entity.LoginLock, error)
+	IncreaseFailures(ctx context.Context, loginLock *entity.LoginLock, resetBefore time.Time) (*entity.LoginLock, error)
+	Lock(ctx context.Context, lockUUID uuid.EntityUUID, lockedUntil time.Time) error
+	Delete(ctx context.Context, lockUUID uuid.EntityUUID) error
+	DeleteBySubject(ctx context.Context, lockType entity.LoginLockType, tenantID, subject string) error
+	DeleteExpired(ctx context.Context, before time.Time) error
+}
+
+// MySQL
+type LoginLockRepoImp struct {
+	db *gorm.DB
+}
+
+func NewLoginLockRepoImp(repoDB *gorm.DB) *LoginLockRepoImp {
+	return &LoginLockRepoImp{
+		db: repoDB,
+	}
+}
+
+// List login locks by their last failed time in descending order
+func (l *LoginLockRepoImp) List(ctx context.Context, offset int, limit int) ([]entity.LoginLock, error) {
+	loginLocks := []entity.LoginLock{}
+	result := l.db.Order("last_failed_at DESC").Offset(offset).Limit(limit).Find(&loginLocks)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to list login locks from DB")
+		return nil, getReturnErr(result.Error)
+	}
+	return loginLocks, nil
+}
+
+func (l *LoginLockRepoImp) Get(ctx context.Context, lockUUID uuid.EntityUUID) (*entity.LoginLock, error) {
+	loginLock := entity.LoginLock{}
+	result := l.db.First(&loginLock, "id = ?", lockUUID)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to get login lock from DB")
+		return nil, getReturnErr(result.Error)
+	}
+	return &loginLock, nil
+}
+
+func (l *LoginLockRepoImp) GetBySubject(ctx context.Context, lockType entity.LoginLockType, tenantID, subject string) (*entity.LoginLock, error) {
+	loginLock := entity.LoginLock{}
+	result := l.db.Where("type = ? AND tenant_id = ? AND subject = ?", lockType, tenantID, subject).First(&loginLock)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to get login lock by subject from DB")
+		return nil, getReturnErr(result.Error)
+	}
+	return &loginLock, nil
+}
+
+// Increase failures of the login lock's subject at its last failed time, and create the login lock if it doesn't exist.
+// Failures are reset if the last failure is before the reset time. It returns the increased login lock.
+func (l *LoginLockRepoImp) IncreaseFailures(ctx context.Context, loginLock *entity.LoginLock, resetBefore time.Time) (*entity.LoginLock, error) {
+	// Failures are updated before the last failed time, because assignments are evaluated in order
+	loginLock.Failures = 1
+	result := l.db.Clauses(clause.OnConflict{
+		DoUpdates: clause.Set{
+			{Column: clause.Column{Name: "failures"}, Value: gorm.Expr("IF(last_failed_at < ?, 1, failures + 1)", resetBefore)},
+			{Column: clause.Column{Name: "last_failed_at"}, Value: loginLock.LastFailedAt},
+		},
+	}).Create(loginLock)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to increase login failures in DB")
+		return nil, getReturnErr(result.Error)
+	}
+	return l.GetBySubject(ctx, loginLock.Type, loginLock.TenantID, loginLock.Subject)
+}
+
+// Lock the subject until the time. A longer lock by a concurrent failure isn't shortened.
+func (l *LoginLockRepoImp) Lock(ctx context.Context, lockUUID uuid.EntityUUID, lockedUntil time.Time) error {
+	result := l.db.Model(&entity.LoginLock{}).Where("id = ?", lockUUID).
+		Update("locked_until", gorm.Expr("GREATEST(locked_until, ?)", lockedUntil))
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to lock login in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
+
+func (l *LoginLockRepoImp) Delete(ctx context.Context, lockUUID uuid.EntityUUID) error {
+	result := l.db.Delete(&entity.LoginLock{}, "id = ?", lockUUID)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to delete login lock in DB")
+		return getReturnErr(result.Error)
+	} else if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (l *LoginLockRepoImp) DeleteBySubject(ctx context.Context, lockType entity.LoginLockType, tenantID, subject string) error {
+	result := l.db.Delete(&entity.LoginLock{}, "type = ? AND tenant_id = ? AND subject = ?", lockType, tenantID, subject)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to delete login lock by subject in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
+
+// Delete login locks which have no failure after the time
+func (l *LoginLockRepoImp) DeleteExpired(ctx context.Context, before time.Time) error {
+	result := l.db.Delete(&entity.LoginLock{}, "last_failed_at < ?", before)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to delete expired login locks in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
+
+// Memory. Login locks are kept only in the replica, so locks of other replicas aren't applied.
+type LoginLockRepoMemory struct {
+	lock sync.Mutex
+
+	loginLocks map[loginLockKey]*entity.LoginLock
+}
+
+type loginLockKey struct {
+	lockType entity.LoginLockType
+	tenantID string
+	subject  string
+}
+
+func NewLoginLockRepoMemory() *LoginLockRepoMemory {
+	return &LoginLockRepoMemory{
+		loginLocks: map[loginLockKey]*entity.LoginLock{},
+	}
+}
+
+// List login locks by their last failed time in descending order
+func (l *LoginLockRepoMemory) List(ctx context.Context, offset int, limit int) ([]entity.LoginLock, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	loginLocks := []entity.LoginLock{}
+	for _, loginLock := range l.loginLocks {
+		loginLocks = append(loginLocks, *loginLock)
+	}
+	sort.Slice(loginLocks, func(i, j int) bool {
+		return loginLocks[i].LastFailedAt.After(loginLocks[j].LastFailedAt)
+	})
+
+	if offset >= len(loginLocks) {
+		return []entity.LoginLock{}, nil
+	}
+	loginLocks = loginLocks[offset:]
+	if limit < len(loginLocks) {
+		loginLocks = loginLocks[:limit]
+	}
+	return loginLocks, nil
+}
+
+func (l *LoginLockRepoMemory) Get(ctx context.Context, lockUUID uuid.EntityUUID) (*entity.LoginLock, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if key, ok := l.getKey(lockUUID); ok {
+		loginLock := *l.loginLocks[key]
+		return &loginLock, nil
+	}
+	return nil, ErrNotFound
+}
+
+func (l *LoginLockRepoMemory) GetBySubject(ctx context.Context, lockType entity.LoginLockType, tenantID, subject string) (*entity.LoginLock, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if loginLock, ok := l.loginLocks[loginLockKey{lockType: lockType, tenantID: tenantID, subject: subject}]; ok {
+		copied := *loginLock
+		return &copied, nil
+	}
+	return nil, ErrNotFound
+}
+
+// Increase failures of the login lock's subject at its last failed time, and create the login lock if it doesn't exist.
+// Failures are reset if the last failure is before the reset time. It returns the increased login lock.
+func (l *LoginLockRepoMemory) IncreaseFailures(ctx context.Context, loginLock *entity.LoginLock, resetBefore time.Time) (*entity.LoginLock, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	key := loginLockKey{lockType: loginLock.Type, tenantID: loginLock.TenantID, subject: loginLock.Subject}
+	stored, ok := l.loginLocks[key]
+	if !ok {
+		created := *loginLock
+		created.CreatedAt = time.Now()
+		created.Failures = 0
+		stored = &created
+		l.loginLocks[key] = stored
+	}
+	if stored.LastFailedAt.Before(resetBefore) {
+		stored.Failures = 0
+	}
+	stored.Failures++
+	stored.LastFailedAt = loginLock.LastFailedAt
+
+	increased := *stored
+	return &increased, nil
+}
+
+// Lock the subject until the time. A longer lock by a concurrent failure isn't shortened.
+func (l *LoginLockRepoMemory) Lock(ctx context.Context, lockUUID uuid.EntityUUID, lockedUntil time.Time) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if key, ok := l.getKey(lockUUID); ok {
+		if loginLock := l.loginLocks[key]; lockedUntil.After(loginLock.LockedUntil) {
+			loginLock.LockedUntil = lockedUntil
+		}
+	}
+	return nil
+}
+
+func (l *LoginLockRepoMemory) Delete(ctx context.Context, lockUUID uuid.EntityUUID) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	key, ok := l.getKey(lockUUID)
+	if !ok {
+		return ErrNotFound
+	}
+	delete(l.loginLocks, key)
+	return nil
+}
+
+func (l *LoginLockRepoMemory) DeleteBySubject(ctx context.Context, lockType entity.LoginLockType, tenantID, subject string) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	delete(l.loginLocks, loginLockKey{lockType: lockType, tenantID: tenantID, subject: subject})
+	return nil
+}
+
+// Delete login locks which have no failure after the time
+func (l *LoginLockRepoMemory) DeleteExpired(ctx context.Context, before time.Time) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	for key, loginLock := range l.loginLocks {
+		if loginLock.LastFailedAt.Before(before) {
+			delete(l.loginLocks, key)
+		}
+	}
+	return nil
+}
+
+// Get the key of the login lock ID. Login locks are looked up by their ID only by admins.
+func (l *LoginLockRepoMemory) getKey(lockUUID uuid.EntityUUID) (loginLockKey, bool) {
+	for key, loginLock := range l.loginLocks {
+		if loginLock.ID == lockUUID {
+			return key, true
+		}
+	}
+	return loginLockKey{}, false
+}
@@ -0,0 +1,199 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/test"
+)
+
+func TestLoginLock(t *testing.T) {
+	suite.Run(t, new(loginLockSuite))
+}
+
+type loginLockSuite struct {
+	suite.Suite
+	sqlMock sqlmock.Sqlmock
+
+	repo       LoginLockRepo
+	repoMemory LoginLockRepo
+}
+
+func (l *loginLockSuite) SetupTest() {
+	var err error
+	var db *sql.DB
+
+	// Init sqlMock
+	db, l.sqlMock, err = sqlmock.New()
+	require.NoError(l.T(), err)
+
+	// Init DB
+	primaryMySQL, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}))
+	require.NoError(l.T(), err)
+
+	// Init repo
+	l.repo = NewLoginLockRepoImp(primaryMySQL)
+	l.repoMemory = NewLoginLockRepoMemory()
+}
+
+func (l *loginLockSuite) AfterTest(_, _ string) {
+	require.NoError(l.T(), l.sqlMock.ExpectationsWereMet())
+}
+
+func (l *loginLockSuite) TestListSuccess() {
+	l.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `login_locks` ORDER BY last_failed_at DESC LIMIT 10")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "type", "tenant_id", "subject", "failures"}).
+			AddRow(test.LoginLockIDCorrect, test.LoginLockTypeCorrect, test.TenantIDCorrect, test.LoginLockSubjectCorrect, 3))
+
+	loginLocks, err := l.repo.List(context.Background(), 0, 10)
+	require.NoError(l.T(), err)
+	require.Len(l.T(), loginLocks, 1)
+	require.Equal(l.T(), test.LoginLockSubjectCorrect, loginLocks[0].Subject)
+	require.Equal(l.T(), 3, loginLocks[0].Failures)
+}
+
+func (l *loginLockSuite) TestGetBySubjectNotFound() {
+	l.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `login_locks` WHERE type = ? AND tenant_id = ? AND subject = ?")).
+		WithArgs(test.LoginLockTypeCorrect, test.TenantIDCorrect, test.LoginLockSubjectCorrect).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, err := l.repo.GetBySubject(context.Background(), test.LoginLockTypeCorrect, test.TenantIDCorrect, test.LoginLockSubjectCorrect)
+	require.Equal(l.T(), ErrNotFound, err)
+}
+
+func (l *loginLockSuite) TestIncreaseFailuresSuccess() {
+	now := time.Now()
+	resetBefore := now.Add(-time.Hour)
+
+	l.sqlMock.ExpectBegin()
+	l.sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `login_locks` (`id`,`created_at`,`type`,`tenant_id`,`subject`,`failures`,`last_failed_at`,`locked_until`) VALUES (?,?,?,?,?,?,?,?) "+
+		"ON DUPLICATE KEY UPDATE `failures`=IF(last_failed_at < ?, 1, failures + 1),`last_failed_at`=?")).
+		WithArgs(test.LoginLockIDCorrect, sqlmock.AnyArg(), test.LoginLockTypeCorrect, test.TenantIDCorrect, test.LoginLockSubjectCorrect, 1, now,
+			sqlmock.AnyArg(), resetBefore, now).
+		WillReturnResult(sqlmock.NewResult(1, 2))
+	l.sqlMock.ExpectCommit()
+	l.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `login_locks` WHERE type = ? AND tenant_id = ? AND subject = ?")).
+		WithArgs(test.LoginLockTypeCorrect, test.TenantIDCorrect, test.LoginLockSubjectCorrect).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "type", "tenant_id", "subject", "failures"}).
+			AddRow(test.LoginLockIDCorrect, test.LoginLockTypeCorrect, test.TenantIDCorrect, test.LoginLockSubjectCorrect, 4))
+
+	loginLock, err := l.repo.IncreaseFailures(context.Background(), &entity.LoginLock{
+		ID:           test.LoginLockIDCorrect,
+		Type:         test.LoginLockTypeCorrect,
+		TenantID:     test.TenantIDCorrect,
+		Subject:      test.LoginLockSubjectCorrect,
+		LastFailedAt: now,
+	}, resetBefore)
+	require.NoError(l.T(), err)
+	require.Equal(l.T(), 4, loginLock.Failures)
+}
+
+func (l *loginLockSuite) TestLockSuccess() {
+	lockedUntil := time.Now().Add(time.Minute)
+
+	l.sqlMock.ExpectBegin()
+	l.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `login_locks` SET `locked_until`=GREATEST(locked_until, ?) WHERE id = ?")).
+		WithArgs(lockedUntil, test.LoginLockIDCorrect).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	l.sqlMock.ExpectCommit()
+
+	err := l.repo.Lock(context.Background(), test.LoginLockIDCorrect, lockedUntil)
+	require.NoError(l.T(), err)
+}
+
+func (l *loginLockSuite) TestDeleteNotFound() {
+	l.sqlMock.ExpectBegin()
+	l.sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `login_locks` WHERE id = ?")).
+		WithArgs(test.LoginLockIDWrong).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	l.sqlMock.ExpectCommit()
+
+	err := l.repo.Delete(context.Background(), test.LoginLockIDWrong)
+	require.Equal(l.T(), ErrNotFound, err)
+}
+
+func (l *loginLockSuite) TestDeleteExpiredError() {
+	before := time.Now()
+
+	l.sqlMock.ExpectBegin()
+	l.sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `login_locks` WHERE last_failed_at < ?")).
+		WithArgs(before).
+		WillReturnError(fmt.Errorf("error"))
+	l.sqlMock.ExpectRollback()
+
+	err := l.repo.DeleteExpired(context.Background(), before)
+	require.Equal(l.T(), ErrServerError, err)
+}
+
+func (l *loginLockSuite) TestMemoryIncreaseFailuresAndLock() {
+	now := time.Now()
+	loginLock := entity.LoginLock{
+		ID:           test.LoginLockIDCorrect,
+		Type:         test.LoginLockTypeCorrect,
+		TenantID:     test.TenantIDCorrect,
+		Subject:      test.LoginLockSubjectCorrect,
+		LastFailedAt: now,
+	}
+
+	// Failures are increased
+	for i := 1; i <= 3; i++ {
+		increased, err := l.repoMemory.IncreaseFailures(context.Background(), &loginLock, now.Add(-time.Hour))
+		require.NoError(l.T(), err)
+		require.Equal(l.T(), i, increased.Failures)
+	}
+
+	// Longer lock isn't shortened
+	require.NoError(l.T(), l.repoMemory.Lock(context.Background(), test.LoginLockIDCorrect, now.Add(time.Hour)))
+	require.NoError(l.T(), l.repoMemory.Lock(context.Background(), test.LoginLockIDCorrect, now.Add(time.Minute)))
+	got, err := l.repoMemory.GetBySubject(context.Background(), test.LoginLockTypeCorrect, test.TenantIDCorrect, test.LoginLockSubjectCorrect)
+	require.NoError(l.T(), err)
+	require.Equal(l.T(), now.Add(time.Hour), got.LockedUntil)
+
+	// Failures are reset after the reset time
+	loginLock.LastFailedAt = now.Add(2 * time.Hour)
+	increased, err := l.repoMemory.IncreaseFailures(context.Background(), &loginLock, now.Add(time.Hour))
+	require.NoError(l.T(), err)
+	require.Equal(l.T(), 1, increased.Failures)
+}
+
+func (l *loginLockSuite) TestMemoryListAndDelete() {
+	now := time.Now()
+	_, err := l.repoMemory.IncreaseFailures(context.Background(), &entity.LoginLock{ID: test.LoginLockIDCorrect, Type: entity.LoginLockTypeIP,
+		Subject: test.LoginLockIPCorrect, LastFailedAt: now.Add(-time.Hour)}, time.Time{})
+	require.NoError(l.T(), err)
+	_, err = l.repoMemory.IncreaseFailures(context.Background(), &entity.LoginLock{ID: test.LoginLockIDWrong, Type: test.LoginLockTypeCorrect,
+		TenantID: test.TenantIDCorrect, Subject: test.LoginLockSubjectCorrect, LastFailedAt: now}, time.Time{})
+	require.NoError(l.T(), err)
+
+	// Locks are listed by the last failed time in descending order
+	loginLocks, err := l.repoMemory.List(context.Background(), 0, 10)
+	require.NoError(l.T(), err)
+	require.Len(l.T(), loginLocks, 2)
+	require.Equal(l.T(), test.LoginLockIDWrong, loginLocks[0].ID)
+	loginLocks, err = l.repoMemory.List(context.Background(), 1, 10)
+	require.NoError(l.T(), err)
+	require.Len(l.T(), loginLocks, 1)
+	require.Equal(l.T(), test.LoginLockIDCorrect, loginLocks[0].ID)
+
+	// Expired locks are deleted
+	require.NoError(l.T(), l.repoMemory.DeleteExpired(context.Background(), now.Add(-time.Minute)))
+	_, err = l.repoMemory.Get(context.Background(), test.LoginLockIDCorrect)
+	require.Equal(l.T(), ErrNotFound, err)
+
+	require.NoError(l.T(), l.repoMemory.Delete(context.Background(), test.LoginLockIDWrong))
+	require.Equal(l.T(), ErrNotFound, l.repoMemory.Delete(context.Background(), test.LoginLockIDWrong))
+}
@@ -0,0 +1,77 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+// MFA recovery code repo
+type MFARecoveryCodeRepo interface {
+	WithTx(tx DBTx) MFARecoveryCodeRepo
+
+	CountByUser(ctx context.Context, userUUID uuid.EntityUUID) (int, error)
+	CreateAll(ctx context.Context, recoveryCodes []entity.MFARecoveryCode) error
+	DeleteByCodeHash(ctx context.Context, userUUID uuid.EntityUUID, codeHash []byte) error
+	DeleteByUser(ctx context.Context, userUUID uuid.EntityUUID) error
+}
+
+type MFARecoveryCodeRepoImp struct {
+	db *gorm.DB
+}
+
+func NewMFARecoveryCodeRepoImp(repoDB *gorm.DB) *MFARecoveryCodeRepoImp {
+	return &MFARecoveryCodeRepoImp{
+		db: repoDB,
+	}
+}
+
+func (m *MFARecoveryCodeRepoImp) WithTx(tx DBTx) MFARecoveryCodeRepo {
+	transaction := tx.GetTx()
+	return NewMFARecoveryCodeRepoImp(transaction)
+}
+
+func (m *MFARecoveryCodeRepoImp) CountByUser(ctx context.Context, userUUID uuid.EntityUUID) (int, error) {
+	var count int64
+	result := m.db.Model(&entity.MFARecoveryCode{}).Where("user_id = ?", userUUID).Count(&count)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to count MFA recovery codes from DB")
+		return 0, getReturnErr(result.Error)
+	}
+	return int(count), nil
+}
+
+func (m *MFARecoveryCodeRepoImp) CreateAll(ctx context.Context, recoveryCodes []entity.MFARecoveryCode) error {
+	result := m.db.Create(&recoveryCodes)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to create MFA recovery codes in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
+
+// Delete the recovery code of the user to use it. It returns not found error if the code doesn't exist or is
+// already used.
+func (m *MFARecoveryCodeRepoImp) DeleteByCodeHash(ctx context.Context, userUUID uuid.EntityUUID, codeHash []byte) error {
+	result := m.db.Delete(&entity.MFARecoveryCode{}, "user_id = ? AND code_hash = ?", userUUID, codeHash)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to delete MFA recovery code in DB")
+		return getReturnErr(result.Error)
+	} else if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (m *MFARecoveryCodeRepoImp) DeleteByUser(ctx context.Context, userUUID uuid.EntityUUID) error {
+	result := m.db.Delete(&entity.MFARecoveryCode{}, "user_id = ?", userUUID)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to delete MFA recovery codes in DB by user")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
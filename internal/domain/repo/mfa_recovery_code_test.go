@@ -0,0 +1,110 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/test"
+)
+
+func TestMFARecoveryCode(t *testing.T) {
+	suite.Run(t, new(mfaRecoveryCodeSuite))
+}
+
+type mfaRecoveryCodeSuite struct {
+	suite.Suite
+	sqlMock sqlmock.Sqlmock
+
+	repo MFARecoveryCodeRepo
+}
+
+func (m *mfaRecoveryCodeSuite) SetupTest() {
+	var err error
+	var db *sql.DB
+
+	// Init sqlMock
+	db, m.sqlMock, err = sqlmock.New()
+	require.NoError(m.T(), err)
+
+	// Init DB
+	primaryMySQL, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}))
+	require.NoError(m.T(), err)
+
+	// Init repo
+	m.repo = NewMFARecoveryCodeRepoImp(primaryMySQL)
+}
+
+func (m *mfaRecoveryCodeSuite) AfterTest(_, _ string) {
+	require.NoError(m.T(), m.sqlMock.ExpectationsWereMet())
+}
+
+func (m *mfaRecoveryCodeSuite) TestCountByUserSuccess() {
+	m.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `mfa_recovery_codes` WHERE user_id = ?")).
+		WithArgs(test.UserIDCorrect).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(10))
+
+	count, err := m.repo.CountByUser(context.Background(), test.UserIDCorrect)
+	require.NoError(m.T(), err)
+	require.Equal(m.T(), 10, count)
+}
+
+func (m *mfaRecoveryCodeSuite) TestCreateAllSuccess() {
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `mfa_recovery_codes` (`id`,`created_at`,`user_id`,`code_hash`) VALUES (?,?,?,?)")).
+		WithArgs(test.MFARecoveryCodeIDCorrect, sqlmock.AnyArg(), test.UserIDCorrect, []byte(test.MFARecoveryCodeCorrect)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	m.sqlMock.ExpectCommit()
+
+	err := m.repo.CreateAll(context.Background(), []entity.MFARecoveryCode{{
+		ID:       test.MFARecoveryCodeIDCorrect,
+		UserID:   test.UserIDCorrect,
+		CodeHash: []byte(test.MFARecoveryCodeCorrect),
+	}})
+	require.NoError(m.T(), err)
+}
+
+func (m *mfaRecoveryCodeSuite) TestDeleteByCodeHashSuccess() {
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `mfa_recovery_codes` WHERE user_id = ? AND code_hash = ?")).
+		WithArgs(test.UserIDCorrect, []byte(test.MFARecoveryCodeCorrect)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	m.sqlMock.ExpectCommit()
+
+	err := m.repo.DeleteByCodeHash(context.Background(), test.UserIDCorrect, []byte(test.MFARecoveryCodeCorrect))
+	require.NoError(m.T(), err)
+}
+
+func (m *mfaRecoveryCodeSuite) TestDeleteByCodeHashUsed() {
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `mfa_recovery_codes` WHERE user_id = ? AND code_hash = ?")).
+		WithArgs(test.UserIDCorrect, []byte(test.MFARecoveryCodeCorrect)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	m.sqlMock.ExpectCommit()
+
+	err := m.repo.DeleteByCodeHash(context.Background(), test.UserIDCorrect, []byte(test.MFARecoveryCodeCorrect))
+	require.Equal(m.T(), ErrNotFound, err)
+}
+
+func (m *mfaRecoveryCodeSuite) TestDeleteByUserError() {
+	m.sqlMock.ExpectBegin()
+	m.sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `mfa_recovery_codes` WHERE user_id = ?")).
+		WithArgs(test.UserIDCorrect).
+		WillReturnError(fmt.Errorf("error"))
+	m.sqlMock.ExpectRollback()
+
+	err := m.repo.DeleteByUser(context.Background(), test.UserIDCorrect)
+	require.Error(m.T(), err)
+}
@@ -0,0 +1,128 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	time "time"
+
+	entity "github.com/ssup2ket/service-auth/internal/domain/entity"
+	repo "github.com/ssup2ket/service-auth/internal/domain/repo"
+	uuid "github.com/ssup2ket/service-auth/pkg/entity/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// EmailVerificationRepo is an autogenerated mock type for the EmailVerificationRepo type
+type EmailVerificationRepo struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, emailVerification
+func (_m *EmailVerificationRepo) Create(ctx context.Context, emailVerification *entity.EmailVerification) error {
+	ret := _m.Called(ctx, emailVerification)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.EmailVerification) error); ok {
+		r0 = rf(ctx, emailVerification)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, tokenHash
+func (_m *EmailVerificationRepo) Delete(ctx context.Context, tokenHash string) error {
+	ret := _m.Called(ctx, tokenHash)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteByUser provides a mock function with given fields: ctx, userUUID
+func (_m *EmailVerificationRepo) DeleteByUser(ctx context.Context, userUUID uuid.EntityUUID) error {
+	ret := _m.Called(ctx, userUUID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID) error); ok {
+		r0 = rf(ctx, userUUID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteExpired provides a mock function with given fields: ctx, now
+func (_m *EmailVerificationRepo) DeleteExpired(ctx context.Context, now time.Time) error {
+	ret := _m.Called(ctx, now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetForUpdate provides a mock function with given fields: ctx, tokenHash
+func (_m *EmailVerificationRepo) GetForUpdate(ctx context.Context, tokenHash string) (*entity.EmailVerification, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	var r0 *entity.EmailVerification
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.EmailVerification); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.EmailVerification)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WithTx provides a mock function with given fields: tx
+func (_m *EmailVerificationRepo) WithTx(tx repo.DBTx) repo.EmailVerificationRepo {
+	ret := _m.Called(tx)
+
+	var r0 repo.EmailVerificationRepo
+	if rf, ok := ret.Get(0).(func(repo.DBTx) repo.EmailVerificationRepo); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repo.EmailVerificationRepo)
+		}
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewEmailVerificationRepo interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewEmailVerificationRepo creates a new instance of EmailVerificationRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewEmailVerificationRepo(t mockConstructorTestingTNewEmailVerificationRepo) *EmailVerificationRepo {
+	mock := &EmailVerificationRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
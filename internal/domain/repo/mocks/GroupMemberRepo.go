@@ -0,0 +1,173 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/ssup2ket/service-auth/internal/domain/entity"
+	repo "github.com/ssup2ket/service-auth/internal/domain/repo"
+	uuid "github.com/ssup2ket/service-auth/pkg/entity/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// GroupMemberRepo is an autogenerated mock type for the GroupMemberRepo type
+type GroupMemberRepo struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, groupMember
+func (_m *GroupMemberRepo) Create(ctx context.Context, groupMember *entity.GroupMember) error {
+	ret := _m.Called(ctx, groupMember)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.GroupMember) error); ok {
+		r0 = rf(ctx, groupMember)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, groupUUID, memberUUID
+func (_m *GroupMemberRepo) Delete(ctx context.Context, groupUUID uuid.EntityUUID, memberUUID uuid.EntityUUID) error {
+	ret := _m.Called(ctx, groupUUID, memberUUID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID, uuid.EntityUUID) error); ok {
+		r0 = rf(ctx, groupUUID, memberUUID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteByGroup provides a mock function with given fields: ctx, groupUUID
+func (_m *GroupMemberRepo) DeleteByGroup(ctx context.Context, groupUUID uuid.EntityUUID) error {
+	ret := _m.Called(ctx, groupUUID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID) error); ok {
+		r0 = rf(ctx, groupUUID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteByMember provides a mock function with given fields: ctx, memberUUID
+func (_m *GroupMemberRepo) DeleteByMember(ctx context.Context, memberUUID uuid.EntityUUID) error {
+	ret := _m.Called(ctx, memberUUID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID) error); ok {
+		r0 = rf(ctx, memberUUID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, groupUUID, memberUUID
+func (_m *GroupMemberRepo) Get(ctx context.Context, groupUUID uuid.EntityUUID, memberUUID uuid.EntityUUID) (*entity.GroupMember, error) {
+	ret := _m.Called(ctx, groupUUID, memberUUID)
+
+	var r0 *entity.GroupMember
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID, uuid.EntityUUID) *entity.GroupMember); ok {
+		r0 = rf(ctx, groupUUID, memberUUID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.GroupMember)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.EntityUUID, uuid.EntityUUID) error); ok {
+		r1 = rf(ctx, groupUUID, memberUUID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, groupUUID, offset, limit
+func (_m *GroupMemberRepo) List(ctx context.Context, groupUUID uuid.EntityUUID, offset int, limit int) ([]entity.GroupMember, error) {
+	ret := _m.Called(ctx, groupUUID, offset, limit)
+
+	var r0 []entity.GroupMember
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID, int, int) []entity.GroupMember); ok {
+		r0 = rf(ctx, groupUUID, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.GroupMember)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.EntityUUID, int, int) error); ok {
+		r1 = rf(ctx, groupUUID, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListByMemberIDs provides a mock function with given fields: ctx, memberUUIDs
+func (_m *GroupMemberRepo) ListByMemberIDs(ctx context.Context, memberUUIDs []uuid.EntityUUID) ([]entity.GroupMember, error) {
+	ret := _m.Called(ctx, memberUUIDs)
+
+	var r0 []entity.GroupMember
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.EntityUUID) []entity.GroupMember); ok {
+		r0 = rf(ctx, memberUUIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.GroupMember)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.EntityUUID) error); ok {
+		r1 = rf(ctx, memberUUIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WithTx provides a mock function with given fields: tx
+func (_m *GroupMemberRepo) WithTx(tx repo.DBTx) repo.GroupMemberRepo {
+	ret := _m.Called(tx)
+
+	var r0 repo.GroupMemberRepo
+	if rf, ok := ret.Get(0).(func(repo.DBTx) repo.GroupMemberRepo); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repo.GroupMemberRepo)
+		}
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewGroupMemberRepo interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewGroupMemberRepo creates a new instance of GroupMemberRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewGroupMemberRepo(t mockConstructorTestingTNewGroupMemberRepo) *GroupMemberRepo {
+	mock := &GroupMemberRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,201 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/ssup2ket/service-auth/internal/domain/entity"
+	repo "github.com/ssup2ket/service-auth/internal/domain/repo"
+	uuid "github.com/ssup2ket/service-auth/pkg/entity/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// GroupRepo is an autogenerated mock type for the GroupRepo type
+type GroupRepo struct {
+	mock.Mock
+}
+
+// CountByRole provides a mock function with given fields: ctx, role
+func (_m *GroupRepo) CountByRole(ctx context.Context, role entity.UserRole) (int64, error) {
+	ret := _m.Called(ctx, role)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserRole) int64); ok {
+		r0 = rf(ctx, role)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.UserRole) error); ok {
+		r1 = rf(ctx, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountByTenant provides a mock function with given fields: ctx, tenantID
+func (_m *GroupRepo) CountByTenant(ctx context.Context, tenantID string) (int64, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, group
+func (_m *GroupRepo) Create(ctx context.Context, group *entity.Group) error {
+	ret := _m.Called(ctx, group)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Group) error); ok {
+		r0 = rf(ctx, group)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, tenantID, groupUUID
+func (_m *GroupRepo) Delete(ctx context.Context, tenantID string, groupUUID uuid.EntityUUID) error {
+	ret := _m.Called(ctx, tenantID, groupUUID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.EntityUUID) error); ok {
+		r0 = rf(ctx, tenantID, groupUUID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, tenantID, groupUUID
+func (_m *GroupRepo) Get(ctx context.Context, tenantID string, groupUUID uuid.EntityUUID) (*entity.Group, error) {
+	ret := _m.Called(ctx, tenantID, groupUUID)
+
+	var r0 *entity.Group
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.EntityUUID) *entity.Group); ok {
+		r0 = rf(ctx, tenantID, groupUUID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Group)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.EntityUUID) error); ok {
+		r1 = rf(ctx, tenantID, groupUUID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, tenantID, offset, limit
+func (_m *GroupRepo) List(ctx context.Context, tenantID string, offset int, limit int) ([]entity.Group, error) {
+	ret := _m.Called(ctx, tenantID, offset, limit)
+
+	var r0 []entity.Group
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []entity.Group); ok {
+		r0 = rf(ctx, tenantID, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Group)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(ctx, tenantID, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListByIDs provides a mock function with given fields: ctx, tenantID, groupUUIDs
+func (_m *GroupRepo) ListByIDs(ctx context.Context, tenantID string, groupUUIDs []uuid.EntityUUID) ([]entity.Group, error) {
+	ret := _m.Called(ctx, tenantID, groupUUIDs)
+
+	var r0 []entity.Group
+	if rf, ok := ret.Get(0).(func(context.Context, string, []uuid.EntityUUID) []entity.Group); ok {
+		r0 = rf(ctx, tenantID, groupUUIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Group)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, []uuid.EntityUUID) error); ok {
+		r1 = rf(ctx, tenantID, groupUUIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, group
+func (_m *GroupRepo) Update(ctx context.Context, group *entity.Group) error {
+	ret := _m.Called(ctx, group)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Group) error); ok {
+		r0 = rf(ctx, group)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WithTx provides a mock function with given fields: tx
+func (_m *GroupRepo) WithTx(tx repo.DBTx) repo.GroupRepo {
+	ret := _m.Called(tx)
+
+	var r0 repo.GroupRepo
+	if rf, ok := ret.Get(0).(func(repo.DBTx) repo.GroupRepo); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repo.GroupRepo)
+		}
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewGroupRepo interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewGroupRepo creates a new instance of GroupRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewGroupRepo(t mockConstructorTestingTNewGroupRepo) *GroupRepo {
+	mock := &GroupRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,182 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/ssup2ket/service-auth/internal/domain/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+// LoginLockRepo is an autogenerated mock type for the LoginLockRepo type
+type LoginLockRepo struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: ctx, lockUUID
+func (_m *LoginLockRepo) Delete(ctx context.Context, lockUUID uuid.EntityUUID) error {
+	ret := _m.Called(ctx, lockUUID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID) error); ok {
+		r0 = rf(ctx, lockUUID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteBySubject provides a mock function with given fields: ctx, lockType, tenantID, subject
+func (_m *LoginLockRepo) DeleteBySubject(ctx context.Context, lockType entity.LoginLockType, tenantID string, subject string) error {
+	ret := _m.Called(ctx, lockType, tenantID, subject)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.LoginLockType, string, string) error); ok {
+		r0 = rf(ctx, lockType, tenantID, subject)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteExpired provides a mock function with given fields: ctx, before
+func (_m *LoginLockRepo) DeleteExpired(ctx context.Context, before time.Time) error {
+	ret := _m.Called(ctx, before)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, lockUUID
+func (_m *LoginLockRepo) Get(ctx context.Context, lockUUID uuid.EntityUUID) (*entity.LoginLock, error) {
+	ret := _m.Called(ctx, lockUUID)
+
+	var r0 *entity.LoginLock
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID) *entity.LoginLock); ok {
+		r0 = rf(ctx, lockUUID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.LoginLock)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.EntityUUID) error); ok {
+		r1 = rf(ctx, lockUUID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBySubject provides a mock function with given fields: ctx, lockType, tenantID, subject
+func (_m *LoginLockRepo) GetBySubject(ctx context.Context, lockType entity.LoginLockType, tenantID string, subject string) (*entity.LoginLock, error) {
+	ret := _m.Called(ctx, lockType, tenantID, subject)
+
+	var r0 *entity.LoginLock
+	if rf, ok := ret.Get(0).(func(context.Context, entity.LoginLockType, string, string) *entity.LoginLock); ok {
+		r0 = rf(ctx, lockType, tenantID, subject)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.LoginLock)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.LoginLockType, string, string) error); ok {
+		r1 = rf(ctx, lockType, tenantID, subject)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IncreaseFailures provides a mock function with given fields: ctx, loginLock, resetBefore
+func (_m *LoginLockRepo) IncreaseFailures(ctx context.Context, loginLock *entity.LoginLock, resetBefore time.Time) (*entity.LoginLock, error) {
+	ret := _m.Called(ctx, loginLock, resetBefore)
+
+	var r0 *entity.LoginLock
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.LoginLock, time.Time) *entity.LoginLock); ok {
+		r0 = rf(ctx, loginLock, resetBefore)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.LoginLock)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.LoginLock, time.Time) error); ok {
+		r1 = rf(ctx, loginLock, resetBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, offset, limit
+func (_m *LoginLockRepo) List(ctx context.Context, offset int, limit int) ([]entity.LoginLock, error) {
+	ret := _m.Called(ctx, offset, limit)
+
+	var r0 []entity.LoginLock
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []entity.LoginLock); ok {
+		r0 = rf(ctx, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.LoginLock)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Lock provides a mock function with given fields: ctx, lockUUID, lockedUntil
+func (_m *LoginLockRepo) Lock(ctx context.Context, lockUUID uuid.EntityUUID, lockedUntil time.Time) error {
+	ret := _m.Called(ctx, lockUUID, lockedUntil)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID, time.Time) error); ok {
+		r0 = rf(ctx, lockUUID, lockedUntil)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewLoginLockRepo interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewLoginLockRepo creates a new instance of LoginLockRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewLoginLockRepo(t mockConstructorTestingTNewLoginLockRepo) *LoginLockRepo {
+	mock := &LoginLockRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,111 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/ssup2ket/service-auth/internal/domain/entity"
+	repo "github.com/ssup2ket/service-auth/internal/domain/repo"
+	uuid "github.com/ssup2ket/service-auth/pkg/entity/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MFARecoveryCodeRepo is an autogenerated mock type for the MFARecoveryCodeRepo type
+type MFARecoveryCodeRepo struct {
+	mock.Mock
+}
+
+// CountByUser provides a mock function with given fields: ctx, userUUID
+func (_m *MFARecoveryCodeRepo) CountByUser(ctx context.Context, userUUID uuid.EntityUUID) (int, error) {
+	ret := _m.Called(ctx, userUUID)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID) int); ok {
+		r0 = rf(ctx, userUUID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.EntityUUID) error); ok {
+		r1 = rf(ctx, userUUID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateAll provides a mock function with given fields: ctx, recoveryCodes
+func (_m *MFARecoveryCodeRepo) CreateAll(ctx context.Context, recoveryCodes []entity.MFARecoveryCode) error {
+	ret := _m.Called(ctx, recoveryCodes)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.MFARecoveryCode) error); ok {
+		r0 = rf(ctx, recoveryCodes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteByCodeHash provides a mock function with given fields: ctx, userUUID, codeHash
+func (_m *MFARecoveryCodeRepo) DeleteByCodeHash(ctx context.Context, userUUID uuid.EntityUUID, codeHash []byte) error {
+	ret := _m.Called(ctx, userUUID, codeHash)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID, []byte) error); ok {
+		r0 = rf(ctx, userUUID, codeHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteByUser provides a mock function with given fields: ctx, userUUID
+func (_m *MFARecoveryCodeRepo) DeleteByUser(ctx context.Context, userUUID uuid.EntityUUID) error {
+	ret := _m.Called(ctx, userUUID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID) error); ok {
+		r0 = rf(ctx, userUUID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WithTx provides a mock function with given fields: tx
+func (_m *MFARecoveryCodeRepo) WithTx(tx repo.DBTx) repo.MFARecoveryCodeRepo {
+	ret := _m.Called(tx)
+
+	var r0 repo.MFARecoveryCodeRepo
+	if rf, ok := ret.Get(0).(func(repo.DBTx) repo.MFARecoveryCodeRepo); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repo.MFARecoveryCodeRepo)
+		}
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewMFARecoveryCodeRepo interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMFARecoveryCodeRepo creates a new instance of MFARecoveryCodeRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMFARecoveryCodeRepo(t mockConstructorTestingTNewMFARecoveryCodeRepo) *MFARecoveryCodeRepo {
+	mock := &MFARecoveryCodeRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
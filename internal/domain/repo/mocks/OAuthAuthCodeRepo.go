@@ -0,0 +1,113 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	time "time"
+
+	entity "github.com/ssup2ket/service-auth/internal/domain/entity"
+	repo "github.com/ssup2ket/service-auth/internal/domain/repo"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OAuthAuthCodeRepo is an autogenerated mock type for the OAuthAuthCodeRepo type
+type OAuthAuthCodeRepo struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, authCode
+func (_m *OAuthAuthCodeRepo) Create(ctx context.Context, authCode *entity.OAuthAuthCode) error {
+	ret := _m.Called(ctx, authCode)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.OAuthAuthCode) error); ok {
+		r0 = rf(ctx, authCode)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, codeHash
+func (_m *OAuthAuthCodeRepo) Delete(ctx context.Context, codeHash string) error {
+	ret := _m.Called(ctx, codeHash)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, codeHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteExpired provides a mock function with given fields: ctx, now
+func (_m *OAuthAuthCodeRepo) DeleteExpired(ctx context.Context, now time.Time) error {
+	ret := _m.Called(ctx, now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetForUpdate provides a mock function with given fields: ctx, codeHash
+func (_m *OAuthAuthCodeRepo) GetForUpdate(ctx context.Context, codeHash string) (*entity.OAuthAuthCode, error) {
+	ret := _m.Called(ctx, codeHash)
+
+	var r0 *entity.OAuthAuthCode
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.OAuthAuthCode); ok {
+		r0 = rf(ctx, codeHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.OAuthAuthCode)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, codeHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WithTx provides a mock function with given fields: tx
+func (_m *OAuthAuthCodeRepo) WithTx(tx repo.DBTx) repo.OAuthAuthCodeRepo {
+	ret := _m.Called(tx)
+
+	var r0 repo.OAuthAuthCodeRepo
+	if rf, ok := ret.Get(0).(func(repo.DBTx) repo.OAuthAuthCodeRepo); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repo.OAuthAuthCodeRepo)
+		}
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewOAuthAuthCodeRepo interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewOAuthAuthCodeRepo creates a new instance of OAuthAuthCodeRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewOAuthAuthCodeRepo(t mockConstructorTestingTNewOAuthAuthCodeRepo) *OAuthAuthCodeRepo {
+	mock := &OAuthAuthCodeRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
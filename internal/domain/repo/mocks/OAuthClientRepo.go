@@ -0,0 +1,136 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/ssup2ket/service-auth/internal/domain/entity"
+	repo "github.com/ssup2ket/service-auth/internal/domain/repo"
+	uuid "github.com/ssup2ket/service-auth/pkg/entity/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OAuthClientRepo is an autogenerated mock type for the OAuthClientRepo type
+type OAuthClientRepo struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, oauthClient
+func (_m *OAuthClientRepo) Create(ctx context.Context, oauthClient *entity.OAuthClient) error {
+	ret := _m.Called(ctx, oauthClient)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.OAuthClient) error); ok {
+		r0 = rf(ctx, oauthClient)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, clientUUID
+func (_m *OAuthClientRepo) Delete(ctx context.Context, clientUUID uuid.EntityUUID) error {
+	ret := _m.Called(ctx, clientUUID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID) error); ok {
+		r0 = rf(ctx, clientUUID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, clientUUID
+func (_m *OAuthClientRepo) Get(ctx context.Context, clientUUID uuid.EntityUUID) (*entity.OAuthClient, error) {
+	ret := _m.Called(ctx, clientUUID)
+
+	var r0 *entity.OAuthClient
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID) *entity.OAuthClient); ok {
+		r0 = rf(ctx, clientUUID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.OAuthClient)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.EntityUUID) error); ok {
+		r1 = rf(ctx, clientUUID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, offset, limit
+func (_m *OAuthClientRepo) List(ctx context.Context, offset int, limit int) ([]entity.OAuthClient, error) {
+	ret := _m.Called(ctx, offset, limit)
+
+	var r0 []entity.OAuthClient
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []entity.OAuthClient); ok {
+		r0 = rf(ctx, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.OAuthClient)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, oauthClient
+func (_m *OAuthClientRepo) Update(ctx context.Context, oauthClient *entity.OAuthClient) error {
+	ret := _m.Called(ctx, oauthClient)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.OAuthClient) error); ok {
+		r0 = rf(ctx, oauthClient)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WithTx provides a mock function with given fields: tx
+func (_m *OAuthClientRepo) WithTx(tx repo.DBTx) repo.OAuthClientRepo {
+	ret := _m.Called(tx)
+
+	var r0 repo.OAuthClientRepo
+	if rf, ok := ret.Get(0).(func(repo.DBTx) repo.OAuthClientRepo); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repo.OAuthClientRepo)
+		}
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewOAuthClientRepo interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewOAuthClientRepo creates a new instance of OAuthClientRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewOAuthClientRepo(t mockConstructorTestingTNewOAuthClientRepo) *OAuthClientRepo {
+	mock := &OAuthClientRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,113 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/ssup2ket/service-auth/internal/domain/entity"
+	repo "github.com/ssup2ket/service-auth/internal/domain/repo"
+	uuid "github.com/ssup2ket/service-auth/pkg/entity/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PasswdHistoryRepo is an autogenerated mock type for the PasswdHistoryRepo type
+type PasswdHistoryRepo struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, passwdHistory
+func (_m *PasswdHistoryRepo) Create(ctx context.Context, passwdHistory *entity.PasswdHistory) error {
+	ret := _m.Called(ctx, passwdHistory)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.PasswdHistory) error); ok {
+		r0 = rf(ctx, passwdHistory)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteByUser provides a mock function with given fields: ctx, userUUID
+func (_m *PasswdHistoryRepo) DeleteByUser(ctx context.Context, userUUID uuid.EntityUUID) error {
+	ret := _m.Called(ctx, userUUID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID) error); ok {
+		r0 = rf(ctx, userUUID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteByUserBefore provides a mock function with given fields: ctx, userUUID, id
+func (_m *PasswdHistoryRepo) DeleteByUserBefore(ctx context.Context, userUUID uuid.EntityUUID, id uint64) error {
+	ret := _m.Called(ctx, userUUID, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID, uint64) error); ok {
+		r0 = rf(ctx, userUUID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListByUser provides a mock function with given fields: ctx, userUUID, limit
+func (_m *PasswdHistoryRepo) ListByUser(ctx context.Context, userUUID uuid.EntityUUID, limit int) ([]entity.PasswdHistory, error) {
+	ret := _m.Called(ctx, userUUID, limit)
+
+	var r0 []entity.PasswdHistory
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID, int) []entity.PasswdHistory); ok {
+		r0 = rf(ctx, userUUID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.PasswdHistory)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.EntityUUID, int) error); ok {
+		r1 = rf(ctx, userUUID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WithTx provides a mock function with given fields: tx
+func (_m *PasswdHistoryRepo) WithTx(tx repo.DBTx) repo.PasswdHistoryRepo {
+	ret := _m.Called(tx)
+
+	var r0 repo.PasswdHistoryRepo
+	if rf, ok := ret.Get(0).(func(repo.DBTx) repo.PasswdHistoryRepo); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repo.PasswdHistoryRepo)
+		}
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewPasswdHistoryRepo interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPasswdHistoryRepo creates a new instance of PasswdHistoryRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPasswdHistoryRepo(t mockConstructorTestingTNewPasswdHistoryRepo) *PasswdHistoryRepo {
+	mock := &PasswdHistoryRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,229 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/ssup2ket/service-auth/internal/domain/entity"
+	repo "github.com/ssup2ket/service-auth/internal/domain/repo"
+	uuid "github.com/ssup2ket/service-auth/pkg/entity/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PermissionRepo is an autogenerated mock type for the PermissionRepo type
+type PermissionRepo struct {
+	mock.Mock
+}
+
+// Count provides a mock function with given fields: ctx
+func (_m *PermissionRepo) Count(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, permission
+func (_m *PermissionRepo) Create(ctx context.Context, permission *entity.Permission) error {
+	ret := _m.Called(ctx, permission)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Permission) error); ok {
+		r0 = rf(ctx, permission)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, permissionUUID
+func (_m *PermissionRepo) Delete(ctx context.Context, permissionUUID uuid.EntityUUID) error {
+	ret := _m.Called(ctx, permissionUUID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID) error); ok {
+		r0 = rf(ctx, permissionUUID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteBySubject provides a mock function with given fields: ctx, subject
+func (_m *PermissionRepo) DeleteBySubject(ctx context.Context, subject string) error {
+	ret := _m.Called(ctx, subject)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, subject)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, permissionUUID
+func (_m *PermissionRepo) Get(ctx context.Context, permissionUUID uuid.EntityUUID) (*entity.Permission, error) {
+	ret := _m.Called(ctx, permissionUUID)
+
+	var r0 *entity.Permission
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID) *entity.Permission); ok {
+		r0 = rf(ctx, permissionUUID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Permission)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.EntityUUID) error); ok {
+		r1 = rf(ctx, permissionUUID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPolicyVersion provides a mock function with given fields: ctx
+func (_m *PermissionRepo) GetPolicyVersion(ctx context.Context) (uint64, error) {
+	ret := _m.Called(ctx)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(context.Context) uint64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IncreasePolicyVersion provides a mock function with given fields: ctx
+func (_m *PermissionRepo) IncreasePolicyVersion(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// List provides a mock function with given fields: ctx, offset, limit
+func (_m *PermissionRepo) List(ctx context.Context, offset int, limit int) ([]entity.Permission, error) {
+	ret := _m.Called(ctx, offset, limit)
+
+	var r0 []entity.Permission
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []entity.Permission); ok {
+		r0 = rf(ctx, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Permission)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAll provides a mock function with given fields: ctx
+func (_m *PermissionRepo) ListAll(ctx context.Context) ([]entity.Permission, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []entity.Permission
+	if rf, ok := ret.Get(0).(func(context.Context) []entity.Permission); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Permission)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, permission
+func (_m *PermissionRepo) Update(ctx context.Context, permission *entity.Permission) error {
+	ret := _m.Called(ctx, permission)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Permission) error); ok {
+		r0 = rf(ctx, permission)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WithTx provides a mock function with given fields: tx
+func (_m *PermissionRepo) WithTx(tx repo.DBTx) repo.PermissionRepo {
+	ret := _m.Called(tx)
+
+	var r0 repo.PermissionRepo
+	if rf, ok := ret.Get(0).(func(repo.DBTx) repo.PermissionRepo); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repo.PermissionRepo)
+		}
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewPermissionRepo interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPermissionRepo creates a new instance of PermissionRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPermissionRepo(t mockConstructorTestingTNewPermissionRepo) *PermissionRepo {
+	mock := &PermissionRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,135 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/ssup2ket/service-auth/internal/domain/entity"
+	repo "github.com/ssup2ket/service-auth/internal/domain/repo"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RoleRepo is an autogenerated mock type for the RoleRepo type
+type RoleRepo struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, role
+func (_m *RoleRepo) Create(ctx context.Context, role *entity.Role) error {
+	ret := _m.Called(ctx, role)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Role) error); ok {
+		r0 = rf(ctx, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, name
+func (_m *RoleRepo) Delete(ctx context.Context, name string) error {
+	ret := _m.Called(ctx, name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, name
+func (_m *RoleRepo) Get(ctx context.Context, name string) (*entity.Role, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *entity.Role
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Role); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Role)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, offset, limit
+func (_m *RoleRepo) List(ctx context.Context, offset int, limit int) ([]entity.Role, error) {
+	ret := _m.Called(ctx, offset, limit)
+
+	var r0 []entity.Role
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []entity.Role); ok {
+		r0 = rf(ctx, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Role)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, role
+func (_m *RoleRepo) Update(ctx context.Context, role *entity.Role) error {
+	ret := _m.Called(ctx, role)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Role) error); ok {
+		r0 = rf(ctx, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WithTx provides a mock function with given fields: tx
+func (_m *RoleRepo) WithTx(tx repo.DBTx) repo.RoleRepo {
+	ret := _m.Called(tx)
+
+	var r0 repo.RoleRepo
+	if rf, ok := ret.Get(0).(func(repo.DBTx) repo.RoleRepo); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repo.RoleRepo)
+		}
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewRoleRepo interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewRoleRepo creates a new instance of RoleRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewRoleRepo(t mockConstructorTestingTNewRoleRepo) *RoleRepo {
+	mock := &RoleRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
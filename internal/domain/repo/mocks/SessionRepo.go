@@ -0,0 +1,174 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	time "time"
+
+	entity "github.com/ssup2ket/service-auth/internal/domain/entity"
+	repo "github.com/ssup2ket/service-auth/internal/domain/repo"
+	uuid "github.com/ssup2ket/service-auth/pkg/entity/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SessionRepo is an autogenerated mock type for the SessionRepo type
+type SessionRepo struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, session
+func (_m *SessionRepo) Create(ctx context.Context, session *entity.Session) error {
+	ret := _m.Called(ctx, session)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Session) error); ok {
+		r0 = rf(ctx, session)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, sessionUUID
+func (_m *SessionRepo) Delete(ctx context.Context, sessionUUID uuid.EntityUUID) error {
+	ret := _m.Called(ctx, sessionUUID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID) error); ok {
+		r0 = rf(ctx, sessionUUID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteByUserID provides a mock function with given fields: ctx, userUUID
+func (_m *SessionRepo) DeleteByUserID(ctx context.Context, userUUID uuid.EntityUUID) error {
+	ret := _m.Called(ctx, userUUID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID) error); ok {
+		r0 = rf(ctx, userUUID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, sessionUUID
+func (_m *SessionRepo) Get(ctx context.Context, sessionUUID uuid.EntityUUID) (*entity.Session, error) {
+	ret := _m.Called(ctx, sessionUUID)
+
+	var r0 *entity.Session
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID) *entity.Session); ok {
+		r0 = rf(ctx, sessionUUID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Session)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.EntityUUID) error); ok {
+		r1 = rf(ctx, sessionUUID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetForUpdate provides a mock function with given fields: ctx, sessionUUID
+func (_m *SessionRepo) GetForUpdate(ctx context.Context, sessionUUID uuid.EntityUUID) (*entity.Session, error) {
+	ret := _m.Called(ctx, sessionUUID)
+
+	var r0 *entity.Session
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID) *entity.Session); ok {
+		r0 = rf(ctx, sessionUUID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Session)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.EntityUUID) error); ok {
+		r1 = rf(ctx, sessionUUID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListByUserID provides a mock function with given fields: ctx, userUUID, now
+func (_m *SessionRepo) ListByUserID(ctx context.Context, userUUID uuid.EntityUUID, now time.Time) ([]entity.Session, error) {
+	ret := _m.Called(ctx, userUUID, now)
+
+	var r0 []entity.Session
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID, time.Time) []entity.Session); ok {
+		r0 = rf(ctx, userUUID, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Session)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.EntityUUID, time.Time) error); ok {
+		r1 = rf(ctx, userUUID, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, session
+func (_m *SessionRepo) Update(ctx context.Context, session *entity.Session) error {
+	ret := _m.Called(ctx, session)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Session) error); ok {
+		r0 = rf(ctx, session)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WithTx provides a mock function with given fields: tx
+func (_m *SessionRepo) WithTx(tx repo.DBTx) repo.SessionRepo {
+	ret := _m.Called(tx)
+
+	var r0 repo.SessionRepo
+	if rf, ok := ret.Get(0).(func(repo.DBTx) repo.SessionRepo); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repo.SessionRepo)
+		}
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewSessionRepo interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewSessionRepo creates a new instance of SessionRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewSessionRepo(t mockConstructorTestingTNewSessionRepo) *SessionRepo {
+	mock := &SessionRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,135 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/ssup2ket/service-auth/internal/domain/entity"
+	repo "github.com/ssup2ket/service-auth/internal/domain/repo"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TenantRepo is an autogenerated mock type for the TenantRepo type
+type TenantRepo struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, tenant
+func (_m *TenantRepo) Create(ctx context.Context, tenant *entity.Tenant) error {
+	ret := _m.Called(ctx, tenant)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Tenant) error); ok {
+		r0 = rf(ctx, tenant)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, tenantID
+func (_m *TenantRepo) Delete(ctx context.Context, tenantID string) error {
+	ret := _m.Called(ctx, tenantID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, tenantID
+func (_m *TenantRepo) Get(ctx context.Context, tenantID string) (*entity.Tenant, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	var r0 *entity.Tenant
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Tenant); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Tenant)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, offset, limit
+func (_m *TenantRepo) List(ctx context.Context, offset int, limit int) ([]entity.Tenant, error) {
+	ret := _m.Called(ctx, offset, limit)
+
+	var r0 []entity.Tenant
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []entity.Tenant); ok {
+		r0 = rf(ctx, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Tenant)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, tenant
+func (_m *TenantRepo) Update(ctx context.Context, tenant *entity.Tenant) error {
+	ret := _m.Called(ctx, tenant)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Tenant) error); ok {
+		r0 = rf(ctx, tenant)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WithTx provides a mock function with given fields: tx
+func (_m *TenantRepo) WithTx(tx repo.DBTx) repo.TenantRepo {
+	ret := _m.Called(tx)
+
+	var r0 repo.TenantRepo
+	if rf, ok := ret.Get(0).(func(repo.DBTx) repo.TenantRepo); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repo.TenantRepo)
+		}
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewTenantRepo interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewTenantRepo creates a new instance of TenantRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewTenantRepo(t mockConstructorTestingTNewTenantRepo) *TenantRepo {
+	mock := &TenantRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,151 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	time "time"
+
+	entity "github.com/ssup2ket/service-auth/internal/domain/entity"
+	repo "github.com/ssup2ket/service-auth/internal/domain/repo"
+	uuid "github.com/ssup2ket/service-auth/pkg/entity/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TokenKeyRepo is an autogenerated mock type for the TokenKeyRepo type
+type TokenKeyRepo struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, tokenKey
+func (_m *TokenKeyRepo) Create(ctx context.Context, tokenKey *entity.TokenKey) error {
+	ret := _m.Called(ctx, tokenKey)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.TokenKey) error); ok {
+		r0 = rf(ctx, tokenKey)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteExpired provides a mock function with given fields: ctx, now
+func (_m *TokenKeyRepo) DeleteExpired(ctx context.Context, now time.Time) error {
+	ret := _m.Called(ctx, now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// List provides a mock function with given fields: ctx
+func (_m *TokenKeyRepo) List(ctx context.Context) ([]entity.TokenKey, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []entity.TokenKey
+	if rf, ok := ret.Get(0).(func(context.Context) []entity.TokenKey); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.TokenKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListForUpdate provides a mock function with given fields: ctx
+func (_m *TokenKeyRepo) ListForUpdate(ctx context.Context) ([]entity.TokenKey, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []entity.TokenKey
+	if rf, ok := ret.Get(0).(func(context.Context) []entity.TokenKey); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.TokenKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Promote provides a mock function with given fields: ctx, tokenKeyUUID
+func (_m *TokenKeyRepo) Promote(ctx context.Context, tokenKeyUUID uuid.EntityUUID) error {
+	ret := _m.Called(ctx, tokenKeyUUID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID) error); ok {
+		r0 = rf(ctx, tokenKeyUUID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Retire provides a mock function with given fields: ctx, tokenKeyUUID, retiredAt, expiresAt
+func (_m *TokenKeyRepo) Retire(ctx context.Context, tokenKeyUUID uuid.EntityUUID, retiredAt time.Time, expiresAt time.Time) error {
+	ret := _m.Called(ctx, tokenKeyUUID, retiredAt, expiresAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID, time.Time, time.Time) error); ok {
+		r0 = rf(ctx, tokenKeyUUID, retiredAt, expiresAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WithTx provides a mock function with given fields: tx
+func (_m *TokenKeyRepo) WithTx(tx repo.DBTx) repo.TokenKeyRepo {
+	ret := _m.Called(tx)
+
+	var r0 repo.TokenKeyRepo
+	if rf, ok := ret.Get(0).(func(repo.DBTx) repo.TokenKeyRepo); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repo.TokenKeyRepo)
+		}
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewTokenKeyRepo interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewTokenKeyRepo creates a new instance of TokenKeyRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewTokenKeyRepo(t mockConstructorTestingTNewTokenKeyRepo) *TokenKeyRepo {
+	mock := &TokenKeyRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
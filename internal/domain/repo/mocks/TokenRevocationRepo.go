@@ -0,0 +1,120 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	time "time"
+
+	entity "github.com/ssup2ket/service-auth/internal/domain/entity"
+	repo "github.com/ssup2ket/service-auth/internal/domain/repo"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TokenRevocationRepo is an autogenerated mock type for the TokenRevocationRepo type
+type TokenRevocationRepo struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, tokenRevocation
+func (_m *TokenRevocationRepo) Create(ctx context.Context, tokenRevocation *entity.TokenRevocation) error {
+	ret := _m.Called(ctx, tokenRevocation)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.TokenRevocation) error); ok {
+		r0 = rf(ctx, tokenRevocation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteExpired provides a mock function with given fields: ctx, now
+func (_m *TokenRevocationRepo) DeleteExpired(ctx context.Context, now time.Time) error {
+	ret := _m.Called(ctx, now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IsRevoked provides a mock function with given fields: ctx, subjects, issuedAt
+func (_m *TokenRevocationRepo) IsRevoked(ctx context.Context, subjects []string, issuedAt time.Time) (bool, error) {
+	ret := _m.Called(ctx, subjects, issuedAt)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, []string, time.Time) bool); ok {
+		r0 = rf(ctx, subjects, issuedAt)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []string, time.Time) error); ok {
+		r1 = rf(ctx, subjects, issuedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListActive provides a mock function with given fields: ctx, now
+func (_m *TokenRevocationRepo) ListActive(ctx context.Context, now time.Time) ([]entity.TokenRevocation, error) {
+	ret := _m.Called(ctx, now)
+
+	var r0 []entity.TokenRevocation
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []entity.TokenRevocation); ok {
+		r0 = rf(ctx, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.TokenRevocation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WithTx provides a mock function with given fields: tx
+func (_m *TokenRevocationRepo) WithTx(tx repo.DBTx) repo.TokenRevocationRepo {
+	ret := _m.Called(tx)
+
+	var r0 repo.TokenRevocationRepo
+	if rf, ok := ret.Get(0).(func(repo.DBTx) repo.TokenRevocationRepo); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repo.TokenRevocationRepo)
+		}
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewTokenRevocationRepo interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewTokenRevocationRepo creates a new instance of TokenRevocationRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewTokenRevocationRepo(t mockConstructorTestingTNewTokenRevocationRepo) *TokenRevocationRepo {
+	mock := &TokenRevocationRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
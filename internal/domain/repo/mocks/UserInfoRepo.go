@@ -4,13 +4,12 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
 	entity "github.com/ssup2ket/service-auth/internal/domain/entity"
-	mock "github.com/stretchr/testify/mock"
-
 	repo "github.com/ssup2ket/service-auth/internal/domain/repo"
-
 	uuid "github.com/ssup2ket/service-auth/pkg/entity/uuid"
+	mock "github.com/stretchr/testify/mock"
 )
 
 // UserInfoRepo is an autogenerated mock type for the UserInfoRepo type
@@ -18,6 +17,48 @@ type UserInfoRepo struct {
 	mock.Mock
 }
 
+// CountByRole provides a mock function with given fields: ctx, role
+func (_m *UserInfoRepo) CountByRole(ctx context.Context, role entity.UserRole) (int64, error) {
+	ret := _m.Called(ctx, role)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserRole) int64); ok {
+		r0 = rf(ctx, role)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.UserRole) error); ok {
+		r1 = rf(ctx, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountByTenant provides a mock function with given fields: ctx, tenantID
+func (_m *UserInfoRepo) CountByTenant(ctx context.Context, tenantID string) (int64, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: ctx, userInfo
 func (_m *UserInfoRepo) Create(ctx context.Context, userInfo *entity.UserInfo) error {
 	ret := _m.Called(ctx, userInfo)
@@ -32,13 +73,13 @@ func (_m *UserInfoRepo) Create(ctx context.Context, userInfo *entity.UserInfo) e
 	return r0
 }
 
-// Delete provides a mock function with given fields: ctx, userUUID
-func (_m *UserInfoRepo) Delete(ctx context.Context, userUUID uuid.EntityUUID) error {
-	ret := _m.Called(ctx, userUUID)
+// Delete provides a mock function with given fields: ctx, tenantID, userUUID
+func (_m *UserInfoRepo) Delete(ctx context.Context, tenantID string, userUUID uuid.EntityUUID) error {
+	ret := _m.Called(ctx, tenantID, userUUID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID) error); ok {
-		r0 = rf(ctx, userUUID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.EntityUUID) error); ok {
+		r0 = rf(ctx, tenantID, userUUID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -46,13 +87,13 @@ func (_m *UserInfoRepo) Delete(ctx context.Context, userUUID uuid.EntityUUID) er
 	return r0
 }
 
-// Get provides a mock function with given fields: ctx, userUUID
-func (_m *UserInfoRepo) Get(ctx context.Context, userUUID uuid.EntityUUID) (*entity.UserInfo, error) {
-	ret := _m.Called(ctx, userUUID)
+// Get provides a mock function with given fields: ctx, tenantID, userUUID
+func (_m *UserInfoRepo) Get(ctx context.Context, tenantID string, userUUID uuid.EntityUUID) (*entity.UserInfo, error) {
+	ret := _m.Called(ctx, tenantID, userUUID)
 
 	var r0 *entity.UserInfo
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID) *entity.UserInfo); ok {
-		r0 = rf(ctx, userUUID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.EntityUUID) *entity.UserInfo); ok {
+		r0 = rf(ctx, tenantID, userUUID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.UserInfo)
@@ -60,8 +101,8 @@ func (_m *UserInfoRepo) Get(ctx context.Context, userUUID uuid.EntityUUID) (*ent
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.EntityUUID) error); ok {
-		r1 = rf(ctx, userUUID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.EntityUUID) error); ok {
+		r1 = rf(ctx, tenantID, userUUID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -69,13 +110,13 @@ func (_m *UserInfoRepo) Get(ctx context.Context, userUUID uuid.EntityUUID) (*ent
 	return r0, r1
 }
 
-// GetByLoginID provides a mock function with given fields: ctx, userLoginID
-func (_m *UserInfoRepo) GetByLoginID(ctx context.Context, userLoginID string) (*entity.UserInfo, error) {
-	ret := _m.Called(ctx, userLoginID)
+// GetByLoginID provides a mock function with given fields: ctx, tenantID, userLoginID
+func (_m *UserInfoRepo) GetByLoginID(ctx context.Context, tenantID string, userLoginID string) (*entity.UserInfo, error) {
+	ret := _m.Called(ctx, tenantID, userLoginID)
 
 	var r0 *entity.UserInfo
-	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.UserInfo); ok {
-		r0 = rf(ctx, userLoginID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *entity.UserInfo); ok {
+		r0 = rf(ctx, tenantID, userLoginID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.UserInfo)
@@ -83,8 +124,8 @@ func (_m *UserInfoRepo) GetByLoginID(ctx context.Context, userLoginID string) (*
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, userLoginID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenantID, userLoginID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -92,13 +133,13 @@ func (_m *UserInfoRepo) GetByLoginID(ctx context.Context, userLoginID string) (*
 	return r0, r1
 }
 
-// List provides a mock function with given fields: ctx, offset, limit
-func (_m *UserInfoRepo) List(ctx context.Context, offset int, limit int) ([]entity.UserInfo, error) {
-	ret := _m.Called(ctx, offset, limit)
+// List provides a mock function with given fields: ctx, tenantID, offset, limit
+func (_m *UserInfoRepo) List(ctx context.Context, tenantID string, offset int, limit int) ([]entity.UserInfo, error) {
+	ret := _m.Called(ctx, tenantID, offset, limit)
 
 	var r0 []entity.UserInfo
-	if rf, ok := ret.Get(0).(func(context.Context, int, int) []entity.UserInfo); ok {
-		r0 = rf(ctx, offset, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []entity.UserInfo); ok {
+		r0 = rf(ctx, tenantID, offset, limit)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.UserInfo)
@@ -106,8 +147,8 @@ func (_m *UserInfoRepo) List(ctx context.Context, offset int, limit int) ([]enti
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
-		r1 = rf(ctx, offset, limit)
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(ctx, tenantID, offset, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -129,6 +170,20 @@ func (_m *UserInfoRepo) Update(ctx context.Context, userInfo *entity.UserInfo) e
 	return r0
 }
 
+// UpdateEmailVerified provides a mock function with given fields: ctx, tenantID, userUUID, emailVerifiedAt
+func (_m *UserInfoRepo) UpdateEmailVerified(ctx context.Context, tenantID string, userUUID uuid.EntityUUID, emailVerifiedAt *time.Time) error {
+	ret := _m.Called(ctx, tenantID, userUUID, emailVerifiedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.EntityUUID, *time.Time) error); ok {
+		r0 = rf(ctx, tenantID, userUUID, emailVerifiedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // WithTx provides a mock function with given fields: tx
 func (_m *UserInfoRepo) WithTx(tx repo.DBTx) repo.UserInfoRepo {
 	ret := _m.Called(tx)
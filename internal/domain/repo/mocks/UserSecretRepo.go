@@ -4,13 +4,12 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
 	entity "github.com/ssup2ket/service-auth/internal/domain/entity"
-	mock "github.com/stretchr/testify/mock"
-
 	repo "github.com/ssup2ket/service-auth/internal/domain/repo"
-
 	uuid "github.com/ssup2ket/service-auth/pkg/entity/uuid"
+	mock "github.com/stretchr/testify/mock"
 )
 
 // UserSecretRepo is an autogenerated mock type for the UserSecretRepo type
@@ -46,6 +45,20 @@ func (_m *UserSecretRepo) Delete(ctx context.Context, userUUID uuid.EntityUUID)
 	return r0
 }
 
+// DeletePasswd provides a mock function with given fields: ctx, userUUID, passwdChangedAt
+func (_m *UserSecretRepo) DeletePasswd(ctx context.Context, userUUID uuid.EntityUUID, passwdChangedAt time.Time) error {
+	ret := _m.Called(ctx, userUUID, passwdChangedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID, time.Time) error); ok {
+		r0 = rf(ctx, userUUID, passwdChangedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Get provides a mock function with given fields: ctx, userUUID
 func (_m *UserSecretRepo) Get(ctx context.Context, userUUID uuid.EntityUUID) (*entity.UserSecret, error) {
 	ret := _m.Called(ctx, userUUID)
@@ -83,6 +96,34 @@ func (_m *UserSecretRepo) Update(ctx context.Context, userSecret *entity.UserSec
 	return r0
 }
 
+// UpdateTOTP provides a mock function with given fields: ctx, userSecret
+func (_m *UserSecretRepo) UpdateTOTP(ctx context.Context, userSecret *entity.UserSecret) error {
+	ret := _m.Called(ctx, userSecret)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.UserSecret) error); ok {
+		r0 = rf(ctx, userSecret)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateTOTPLastStep provides a mock function with given fields: ctx, userUUID, step
+func (_m *UserSecretRepo) UpdateTOTPLastStep(ctx context.Context, userUUID uuid.EntityUUID, step int64) error {
+	ret := _m.Called(ctx, userUUID, step)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID, int64) error); ok {
+		r0 = rf(ctx, userUUID, step)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // WithTx provides a mock function with given fields: tx
 func (_m *UserSecretRepo) WithTx(tx repo.DBTx) repo.UserSecretRepo {
 	ret := _m.Called(tx)
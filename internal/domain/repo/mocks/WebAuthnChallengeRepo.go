@@ -0,0 +1,99 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/ssup2ket/service-auth/internal/domain/entity"
+	repo "github.com/ssup2ket/service-auth/internal/domain/repo"
+	uuid "github.com/ssup2ket/service-auth/pkg/entity/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// WebAuthnChallengeRepo is an autogenerated mock type for the WebAuthnChallengeRepo type
+type WebAuthnChallengeRepo struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, challenge
+func (_m *WebAuthnChallengeRepo) Create(ctx context.Context, challenge *entity.WebAuthnChallenge) error {
+	ret := _m.Called(ctx, challenge)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.WebAuthnChallenge) error); ok {
+		r0 = rf(ctx, challenge)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, challengeUUID
+func (_m *WebAuthnChallengeRepo) Delete(ctx context.Context, challengeUUID uuid.EntityUUID) error {
+	ret := _m.Called(ctx, challengeUUID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID) error); ok {
+		r0 = rf(ctx, challengeUUID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetForUpdate provides a mock function with given fields: ctx, challengeUUID
+func (_m *WebAuthnChallengeRepo) GetForUpdate(ctx context.Context, challengeUUID uuid.EntityUUID) (*entity.WebAuthnChallenge, error) {
+	ret := _m.Called(ctx, challengeUUID)
+
+	var r0 *entity.WebAuthnChallenge
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID) *entity.WebAuthnChallenge); ok {
+		r0 = rf(ctx, challengeUUID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.WebAuthnChallenge)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.EntityUUID) error); ok {
+		r1 = rf(ctx, challengeUUID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WithTx provides a mock function with given fields: tx
+func (_m *WebAuthnChallengeRepo) WithTx(tx repo.DBTx) repo.WebAuthnChallengeRepo {
+	ret := _m.Called(tx)
+
+	var r0 repo.WebAuthnChallengeRepo
+	if rf, ok := ret.Get(0).(func(repo.DBTx) repo.WebAuthnChallengeRepo); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repo.WebAuthnChallengeRepo)
+		}
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewWebAuthnChallengeRepo interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewWebAuthnChallengeRepo creates a new instance of WebAuthnChallengeRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewWebAuthnChallengeRepo(t mockConstructorTestingTNewWebAuthnChallengeRepo) *WebAuthnChallengeRepo {
+	mock := &WebAuthnChallengeRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,195 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	time "time"
+
+	entity "github.com/ssup2ket/service-auth/internal/domain/entity"
+	repo "github.com/ssup2ket/service-auth/internal/domain/repo"
+	uuid "github.com/ssup2ket/service-auth/pkg/entity/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// WebAuthnCredentialRepo is an autogenerated mock type for the WebAuthnCredentialRepo type
+type WebAuthnCredentialRepo struct {
+	mock.Mock
+}
+
+// CountByUser provides a mock function with given fields: ctx, userUUID
+func (_m *WebAuthnCredentialRepo) CountByUser(ctx context.Context, userUUID uuid.EntityUUID) (int, error) {
+	ret := _m.Called(ctx, userUUID)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID) int); ok {
+		r0 = rf(ctx, userUUID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.EntityUUID) error); ok {
+		r1 = rf(ctx, userUUID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, credential
+func (_m *WebAuthnCredentialRepo) Create(ctx context.Context, credential *entity.WebAuthnCredential) error {
+	ret := _m.Called(ctx, credential)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.WebAuthnCredential) error); ok {
+		r0 = rf(ctx, credential)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, userUUID, credentialUUID
+func (_m *WebAuthnCredentialRepo) Delete(ctx context.Context, userUUID uuid.EntityUUID, credentialUUID uuid.EntityUUID) error {
+	ret := _m.Called(ctx, userUUID, credentialUUID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID, uuid.EntityUUID) error); ok {
+		r0 = rf(ctx, userUUID, credentialUUID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteByUser provides a mock function with given fields: ctx, userUUID
+func (_m *WebAuthnCredentialRepo) DeleteByUser(ctx context.Context, userUUID uuid.EntityUUID) error {
+	ret := _m.Called(ctx, userUUID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID) error); ok {
+		r0 = rf(ctx, userUUID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, userUUID, credentialUUID
+func (_m *WebAuthnCredentialRepo) Get(ctx context.Context, userUUID uuid.EntityUUID, credentialUUID uuid.EntityUUID) (*entity.WebAuthnCredential, error) {
+	ret := _m.Called(ctx, userUUID, credentialUUID)
+
+	var r0 *entity.WebAuthnCredential
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID, uuid.EntityUUID) *entity.WebAuthnCredential); ok {
+		r0 = rf(ctx, userUUID, credentialUUID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.WebAuthnCredential)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.EntityUUID, uuid.EntityUUID) error); ok {
+		r1 = rf(ctx, userUUID, credentialUUID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByCredentialID provides a mock function with given fields: ctx, credentialID
+func (_m *WebAuthnCredentialRepo) GetByCredentialID(ctx context.Context, credentialID []byte) (*entity.WebAuthnCredential, error) {
+	ret := _m.Called(ctx, credentialID)
+
+	var r0 *entity.WebAuthnCredential
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) *entity.WebAuthnCredential); ok {
+		r0 = rf(ctx, credentialID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.WebAuthnCredential)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []byte) error); ok {
+		r1 = rf(ctx, credentialID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListByUser provides a mock function with given fields: ctx, userUUID
+func (_m *WebAuthnCredentialRepo) ListByUser(ctx context.Context, userUUID uuid.EntityUUID) ([]entity.WebAuthnCredential, error) {
+	ret := _m.Called(ctx, userUUID)
+
+	var r0 []entity.WebAuthnCredential
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID) []entity.WebAuthnCredential); ok {
+		r0 = rf(ctx, userUUID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.WebAuthnCredential)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.EntityUUID) error); ok {
+		r1 = rf(ctx, userUUID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateSignCount provides a mock function with given fields: ctx, credentialUUID, signCount, lastUsedAt
+func (_m *WebAuthnCredentialRepo) UpdateSignCount(ctx context.Context, credentialUUID uuid.EntityUUID, signCount uint32, lastUsedAt time.Time) error {
+	ret := _m.Called(ctx, credentialUUID, signCount, lastUsedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID, uint32, time.Time) error); ok {
+		r0 = rf(ctx, credentialUUID, signCount, lastUsedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WithTx provides a mock function with given fields: tx
+func (_m *WebAuthnCredentialRepo) WithTx(tx repo.DBTx) repo.WebAuthnCredentialRepo {
+	ret := _m.Called(tx)
+
+	var r0 repo.WebAuthnCredentialRepo
+	if rf, ok := ret.Get(0).(func(repo.DBTx) repo.WebAuthnCredentialRepo); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repo.WebAuthnCredentialRepo)
+		}
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewWebAuthnCredentialRepo interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewWebAuthnCredentialRepo creates a new instance of WebAuthnCredentialRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewWebAuthnCredentialRepo(t mockConstructorTestingTNewWebAuthnCredentialRepo) *WebAuthnCredentialRepo {
+	mock := &WebAuthnCredentialRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,75 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+)
+
+// OAuth authorization code repo
+type OAuthAuthCodeRepo interface {
+	WithTx(tx DBTx) OAuthAuthCodeRepo
+
+	Create(ctx context.Context, authCode *entity.OAuthAuthCode) error
+	GetForUpdate(ctx context.Context, codeHash string) (*entity.OAuthAuthCode, error)
+	Delete(ctx context.Context, codeHash string) error
+	DeleteExpired(ctx context.Context, now time.Time) error
+}
+
+type OAuthAuthCodeRepoImp struct {
+	db *gorm.DB
+}
+
+func NewOAuthAuthCodeRepoImp(repoDB *gorm.DB) *OAuthAuthCodeRepoImp {
+	return &OAuthAuthCodeRepoImp{
+		db: repoDB,
+	}
+}
+
+func (o *OAuthAuthCodeRepoImp) WithTx(tx DBTx) OAuthAuthCodeRepo {
+	transaction := tx.GetTx()
+	return NewOAuthAuthCodeRepoImp(transaction)
+}
+
+func (o *OAuthAuthCodeRepoImp) Create(ctx context.Context, authCode *entity.OAuthAuthCode) error {
+	result := o.db.Create(authCode)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to create OAuth authorization code in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
+
+// Lock the authorization code in the transaction not to exchange the code concurrently
+func (o *OAuthAuthCodeRepoImp) GetForUpdate(ctx context.Context, codeHash string) (*entity.OAuthAuthCode, error) {
+	authCode := entity.OAuthAuthCode{}
+	result := o.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&authCode, "id = ?", codeHash)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to get OAuth authorization code for update from DB")
+		return nil, getReturnErr(result.Error)
+	}
+	return &authCode, nil
+}
+
+func (o *OAuthAuthCodeRepoImp) Delete(ctx context.Context, codeHash string) error {
+	result := o.db.Delete(&entity.OAuthAuthCode{}, "id = ?", codeHash)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to delete OAuth authorization code in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
+
+func (o *OAuthAuthCodeRepoImp) DeleteExpired(ctx context.Context, now time.Time) error {
+	result := o.db.Delete(&entity.OAuthAuthCode{}, "expires_at < ?", now)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to delete expired OAuth authorization codes in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/test"
+)
+
+func TestOAuthAuthCode(t *testing.T) {
+	suite.Run(t, new(oauthAuthCodeSuite))
+}
+
+type oauthAuthCodeSuite struct {
+	suite.Suite
+	sqlMock sqlmock.Sqlmock
+
+	repo OAuthAuthCodeRepo
+}
+
+func (o *oauthAuthCodeSuite) SetupTest() {
+	var err error
+	var db *sql.DB
+
+	// Init sqlMock
+	db, o.sqlMock, err = sqlmock.New()
+	require.NoError(o.T(), err)
+
+	// Init DB
+	primaryMySQL, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}))
+	require.NoError(o.T(), err)
+
+	// Init repo
+	o.repo = NewOAuthAuthCodeRepoImp(primaryMySQL)
+}
+
+func (o *oauthAuthCodeSuite) AfterTest(_, _ string) {
+	require.NoError(o.T(), o.sqlMock.ExpectationsWereMet())
+}
+
+func (o *oauthAuthCodeSuite) TestCreateSuccess() {
+	o.sqlMock.ExpectBegin()
+	o.sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `o_auth_auth_codes` (`id`,`created_at`,`client_id`,`user_id`,`tenant_id`,`redirect_uri`,`scope`,`nonce`,`code_challenge`,`auth_time`,`expires_at`) VALUES (?,?,?,?,?,?,?,?,?,?,?)")).
+		WithArgs(test.OAuthAuthCodeHashCorrect, sqlmock.AnyArg(), test.OAuthClientIDCorrect.String(), test.UserIDCorrect, test.TenantIDCorrect, test.OAuthClientRedirectURICorrect,
+			test.OAuthScopeCorrect, test.OAuthNonceCorrect, test.OAuthCodeChallengeCorrect, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	o.sqlMock.ExpectCommit()
+
+	err := o.repo.Create(context.Background(), &entity.OAuthAuthCode{
+		ID:            test.OAuthAuthCodeHashCorrect,
+		ClientID:      test.OAuthClientIDCorrect.String(),
+		UserID:        test.UserIDCorrect,
+		TenantID:      test.TenantIDCorrect,
+		RedirectURI:   test.OAuthClientRedirectURICorrect,
+		Scope:         test.OAuthScopeCorrect,
+		Nonce:         test.OAuthNonceCorrect,
+		CodeChallenge: test.OAuthCodeChallengeCorrect,
+	})
+	require.NoError(o.T(), err)
+}
+
+func (o *oauthAuthCodeSuite) TestGetForUpdateSuccess() {
+	o.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `o_auth_auth_codes` WHERE id = ? ORDER BY `o_auth_auth_codes`.`id` LIMIT 1 FOR UPDATE")).
+		WithArgs(test.OAuthAuthCodeHashCorrect).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "client_id", "user_id"}).
+			AddRow(test.OAuthAuthCodeHashCorrect, test.OAuthClientIDCorrect.String(), test.UserIDCorrect))
+
+	authCode, err := o.repo.GetForUpdate(context.Background(), test.OAuthAuthCodeHashCorrect)
+	require.NoError(o.T(), err)
+	require.Equal(o.T(), test.OAuthClientIDCorrect.String(), authCode.ClientID)
+	require.Equal(o.T(), test.UserIDCorrect, authCode.UserID)
+}
+
+func (o *oauthAuthCodeSuite) TestGetForUpdateNotFound() {
+	o.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `o_auth_auth_codes` WHERE id = ? ORDER BY `o_auth_auth_codes`.`id` LIMIT 1 FOR UPDATE")).
+		WithArgs(test.OAuthAuthCodeHashCorrect).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	_, err := o.repo.GetForUpdate(context.Background(), test.OAuthAuthCodeHashCorrect)
+	require.Equal(o.T(), ErrNotFound, err)
+}
+
+func (o *oauthAuthCodeSuite) TestDeleteSuccess() {
+	o.sqlMock.ExpectBegin()
+	o.sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `o_auth_auth_codes` WHERE id = ?")).
+		WithArgs(test.OAuthAuthCodeHashCorrect).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	o.sqlMock.ExpectCommit()
+
+	err := o.repo.Delete(context.Background(), test.OAuthAuthCodeHashCorrect)
+	require.NoError(o.T(), err)
+}
+
+func (o *oauthAuthCodeSuite) TestDeleteExpiredSuccess() {
+	now := time.Now()
+
+	o.sqlMock.ExpectBegin()
+	o.sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `o_auth_auth_codes` WHERE expires_at < ?")).
+		WithArgs(now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	o.sqlMock.ExpectCommit()
+
+	err := o.repo.DeleteExpired(context.Background(), now)
+	require.NoError(o.T(), err)
+}
@@ -0,0 +1,85 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+// OAuth client repo
+type OAuthClientRepo interface {
+	WithTx(tx DBTx) OAuthClientRepo
+
+	List(ctx context.Context, offset int, limit int) ([]entity.OAuthClient, error)
+	Create(ctx context.Context, oauthClient *entity.OAuthClient) error
+	Get(ctx context.Context, clientUUID uuid.EntityUUID) (*entity.OAuthClient, error)
+	Update(ctx context.Context, oauthClient *entity.OAuthClient) error
+	Delete(ctx context.Context, clientUUID uuid.EntityUUID) error
+}
+
+type OAuthClientRepoImp struct {
+	db *gorm.DB
+}
+
+func NewOAuthClientRepoImp(repoDB *gorm.DB) *OAuthClientRepoImp {
+	return &OAuthClientRepoImp{
+		db: repoDB,
+	}
+}
+
+func (o *OAuthClientRepoImp) WithTx(tx DBTx) OAuthClientRepo {
+	transaction := tx.GetTx()
+	return NewOAuthClientRepoImp(transaction)
+}
+
+func (o *OAuthClientRepoImp) List(ctx context.Context, offset int, limit int) ([]entity.OAuthClient, error) {
+	oauthClients := []entity.OAuthClient{}
+	result := o.db.Offset(offset).Limit(limit).Find(&oauthClients)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to list OAuth clients from DB")
+		return nil, getReturnErr(result.Error)
+	}
+	return oauthClients, nil
+}
+
+func (o *OAuthClientRepoImp) Create(ctx context.Context, oauthClient *entity.OAuthClient) error {
+	result := o.db.Create(oauthClient)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to create OAuth client in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
+
+func (o *OAuthClientRepoImp) Get(ctx context.Context, clientUUID uuid.EntityUUID) (*entity.OAuthClient, error) {
+	oauthClient := entity.OAuthClient{}
+	result := o.db.First(&oauthClient, "id = ?", clientUUID)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to get OAuth client from DB")
+		return nil, getReturnErr(result.Error)
+	}
+	return &oauthClient, nil
+}
+
+// Update settings of the client. The secret and the public flag aren't updated.
+func (o *OAuthClientRepoImp) Update(ctx context.Context, oauthClient *entity.OAuthClient) error {
+	result := o.db.Model(oauthClient).Select("name", "redirect_uris", "grant_types", "scopes").Updates(oauthClient)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to update OAuth client in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
+
+func (o *OAuthClientRepoImp) Delete(ctx context.Context, clientUUID uuid.EntityUUID) error {
+	result := o.db.Delete(&entity.OAuthClient{}, "id = ?", clientUUID)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to delete OAuth client in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/test"
+)
+
+func TestOAuthClient(t *testing.T) {
+	suite.Run(t, new(oauthClientSuite))
+}
+
+type oauthClientSuite struct {
+	suite.Suite
+	sqlMock sqlmock.Sqlmock
+
+	tx   *DBTxImp
+	repo OAuthClientRepo
+}
+
+func (o *oauthClientSuite) SetupTest() {
+	var err error
+	var db *sql.DB
+
+	// Init sqlMock
+	db, o.sqlMock, err = sqlmock.New()
+	require.NoError(o.T(), err)
+
+	// Init DB
+	primaryMySQL, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}))
+	require.NoError(o.T(), err)
+
+	// Init transaction, repo
+	o.tx = NewDBTxImp(primaryMySQL)
+	o.repo = NewOAuthClientRepoImp(primaryMySQL)
+}
+
+func (o *oauthClientSuite) AfterTest(_, _ string) {
+	require.NoError(o.T(), o.sqlMock.ExpectationsWereMet())
+}
+
+func (o *oauthClientSuite) TestListSuccess() {
+	o.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `o_auth_clients` LIMIT 10")).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name", "redirect_uris", "grant_types", "scopes", "public"}).
+				AddRow(test.OAuthClientIDCorrect, test.OAuthClientNameCorrect, `["`+test.OAuthClientRedirectURICorrect+`"]`,
+					`["authorization_code"]`, `["openid"]`, false),
+		)
+
+	clients, err := o.repo.List(context.Background(), 0, 10)
+	require.NoError(o.T(), err)
+	require.Equal(o.T(), test.OAuthClientIDCorrect, clients[0].ID)
+	require.Equal(o.T(), test.OAuthClientNameCorrect, clients[0].Name)
+	require.Equal(o.T(), entity.StrList{test.OAuthClientRedirectURICorrect}, clients[0].RedirectURIs)
+	require.True(o.T(), clients[0].IsGrantTypeAllowed(entity.OAuthGrantTypeAuthorizationCode))
+	require.Equal(o.T(), entity.StrList{"openid"}, clients[0].Scopes)
+}
+
+func (o *oauthClientSuite) TestCreateSuccess() {
+	o.sqlMock.ExpectBegin()
+	o.sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `o_auth_clients` (`id`,`created_at`,`updated_at`,`name`,`secret_hash`,`secret_salt`,`redirect_uris`,`grant_types`,`scopes`,`public`) VALUES (?,?,?,?,?,?,?,?,?,?)")).
+		WithArgs(test.OAuthClientIDCorrect, sqlmock.AnyArg(), sqlmock.AnyArg(), test.OAuthClientNameCorrect, sqlmock.AnyArg(), sqlmock.AnyArg(),
+			`["`+test.OAuthClientRedirectURICorrect+`"]`, `["authorization_code"]`, "[]", true).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	o.sqlMock.ExpectCommit()
+
+	err := o.repo.Create(context.Background(), &entity.OAuthClient{
+		ID:           test.OAuthClientIDCorrect,
+		Name:         test.OAuthClientNameCorrect,
+		RedirectURIs: entity.StrList{test.OAuthClientRedirectURICorrect},
+		GrantTypes:   entity.StrList{string(entity.OAuthGrantTypeAuthorizationCode)},
+		Public:       true,
+	})
+	require.NoError(o.T(), err)
+}
+
+func (o *oauthClientSuite) TestGetSuccess() {
+	o.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `o_auth_clients` WHERE id = ? ORDER BY `o_auth_clients`.`id` LIMIT 1")).
+		WithArgs(test.OAuthClientIDCorrect).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "redirect_uris", "grant_types", "scopes"}).
+			AddRow(test.OAuthClientIDCorrect, test.OAuthClientNameCorrect, nil, nil, nil))
+
+	client, err := o.repo.Get(context.Background(), test.OAuthClientIDCorrect)
+	require.NoError(o.T(), err)
+	require.Equal(o.T(), test.OAuthClientIDCorrect, client.ID)
+	require.Equal(o.T(), test.OAuthClientNameCorrect, client.Name)
+	require.Empty(o.T(), client.RedirectURIs)
+}
+
+func (o *oauthClientSuite) TestGetNotFound() {
+	o.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `o_auth_clients` WHERE id = ? ORDER BY `o_auth_clients`.`id` LIMIT 1")).
+		WithArgs(test.OAuthClientIDCorrect).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	_, err := o.repo.Get(context.Background(), test.OAuthClientIDCorrect)
+	require.Equal(o.T(), ErrNotFound, err)
+}
+
+func (o *oauthClientSuite) TestUpdateSuccess() {
+	o.sqlMock.ExpectBegin()
+	o.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `o_auth_clients` SET `updated_at`=?,`name`=?,`redirect_uris`=?,`grant_types`=?,`scopes`=? WHERE `id` = ?")).
+		WithArgs(sqlmock.AnyArg(), test.OAuthClientNameCorrect, `["`+test.OAuthClientRedirectURICorrect+`"]`, `["authorization_code"]`,
+			`["openid"]`, test.OAuthClientIDCorrect).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	o.sqlMock.ExpectCommit()
+
+	err := o.repo.Update(context.Background(), &entity.OAuthClient{
+		ID:           test.OAuthClientIDCorrect,
+		Name:         test.OAuthClientNameCorrect,
+		RedirectURIs: entity.StrList{test.OAuthClientRedirectURICorrect},
+		GrantTypes:   entity.StrList{string(entity.OAuthGrantTypeAuthorizationCode)},
+		Scopes:       entity.StrList{"openid"},
+		Public:       true,
+	})
+	require.NoError(o.T(), err)
+}
+
+func (o *oauthClientSuite) TestDeleteSuccess() {
+	o.sqlMock.ExpectBegin()
+	o.sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `o_auth_clients` WHERE id = ?")).
+		WithArgs(test.OAuthClientIDCorrect).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	o.sqlMock.ExpectCommit()
+
+	err := o.repo.Delete(context.Background(), test.OAuthClientIDCorrect)
+	require.NoError(o.T(), err)
+}
+
+func (o *oauthClientSuite) TestDeleteError() {
+	o.sqlMock.ExpectBegin()
+	o.sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `o_auth_clients` WHERE id = ?")).
+		WithArgs(test.OAuthClientIDCorrect).
+		WillReturnError(fmt.Errorf("error"))
+	o.sqlMock.ExpectRollback()
+
+	err := o.repo.Delete(context.Background(), test.OAuthClientIDCorrect)
+	require.Error(o.T(), err)
+}
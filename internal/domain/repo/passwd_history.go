@@ -0,0 +1,74 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+// Password history repo. Histories of a user are listed from the latest one.
+type PasswdHistoryRepo interface {
+	WithTx(tx DBTx) PasswdHistoryRepo
+
+	ListByUser(ctx context.Context, userUUID uuid.EntityUUID, limit int) ([]entity.PasswdHistory, error)
+	Create(ctx context.Context, passwdHistory *entity.PasswdHistory) error
+	DeleteByUser(ctx context.Context, userUUID uuid.EntityUUID) error
+	DeleteByUserBefore(ctx context.Context, userUUID uuid.EntityUUID, id uint64) error
+}
+
+type PasswdHistoryRepoImp struct {
+	db *gorm.DB
+}
+
+func NewPasswdHistoryRepoImp(repoDB *gorm.DB) *PasswdHistoryRepoImp {
+	return &PasswdHistoryRepoImp{
+		db: repoDB,
+	}
+}
+
+func (p *PasswdHistoryRepoImp) WithTx(tx DBTx) PasswdHistoryRepo {
+	transaction := tx.GetTx()
+	return NewPasswdHistoryRepoImp(transaction)
+}
+
+func (p *PasswdHistoryRepoImp) ListByUser(ctx context.Context, userUUID uuid.EntityUUID, limit int) ([]entity.PasswdHistory, error) {
+	passwdHistories := []entity.PasswdHistory{}
+	result := p.db.Where("user_id = ?", userUUID).Order("id DESC").Limit(limit).Find(&passwdHistories)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to list password histories from DB")
+		return nil, getReturnErr(result.Error)
+	}
+	return passwdHistories, nil
+}
+
+func (p *PasswdHistoryRepoImp) Create(ctx context.Context, passwdHistory *entity.PasswdHistory) error {
+	result := p.db.Create(passwdHistory)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to create password history in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
+
+func (p *PasswdHistoryRepoImp) DeleteByUser(ctx context.Context, userUUID uuid.EntityUUID) error {
+	result := p.db.Delete(&entity.PasswdHistory{}, "user_id = ?", userUUID)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to delete password histories in DB by user")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
+
+// Delete histories of the user older than the history of the ID
+func (p *PasswdHistoryRepoImp) DeleteByUserBefore(ctx context.Context, userUUID uuid.EntityUUID, id uint64) error {
+	result := p.db.Delete(&entity.PasswdHistory{}, "user_id = ? AND id < ?", userUUID, id)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to delete old password histories in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/test"
+	"github.com/ssup2ket/service-auth/pkg/auth/hashing"
+)
+
+func TestPasswdHistory(t *testing.T) {
+	suite.Run(t, new(passwdHistorySuite))
+}
+
+type passwdHistorySuite struct {
+	suite.Suite
+	sqlMock sqlmock.Sqlmock
+
+	tx   *DBTxImp
+	repo PasswdHistoryRepo
+
+	passwdPHC string
+}
+
+func (p *passwdHistorySuite) SetupTest() {
+	var err error
+	var db *sql.DB
+
+	// Init sqlMock
+	db, p.sqlMock, err = sqlmock.New()
+	require.NoError(p.T(), err)
+
+	// Init DB
+	primaryMySQL, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}))
+	require.NoError(p.T(), err)
+
+	// Init transaction, repo
+	p.tx = NewDBTxImp(primaryMySQL)
+	p.repo = NewPasswdHistoryRepoImp(primaryMySQL)
+
+	// Get password's hash
+	p.passwdPHC, _ = hashing.GetPasswdHash(test.UserPasswdCorrect)
+}
+
+func (p *passwdHistorySuite) AfterTest(_, _ string) {
+	require.NoError(p.T(), p.sqlMock.ExpectationsWereMet())
+}
+
+func (p *passwdHistorySuite) TestListByUserSuccess() {
+	p.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `passwd_histories` WHERE user_id = ? ORDER BY id DESC LIMIT 4")).
+		WithArgs(test.UserIDCorrect).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "user_id", "passwd_phc"}).
+				AddRow(2, test.UserIDCorrect, p.passwdPHC).
+				AddRow(1, test.UserIDCorrect, p.passwdPHC),
+		)
+
+	passwdHistories, err := p.repo.ListByUser(context.Background(), test.UserIDCorrect, 4)
+	require.NoError(p.T(), err)
+	require.Equal(p.T(), uint64(2), passwdHistories[0].ID)
+	require.Equal(p.T(), p.passwdPHC, passwdHistories[1].PasswdPHC)
+}
+
+func (p *passwdHistorySuite) TestCreateSuccess() {
+	p.sqlMock.ExpectBegin()
+	p.sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `passwd_histories` (`created_at`,`user_id`,`passwd_phc`) VALUES (?,?,?)")).
+		WithArgs(sqlmock.AnyArg(), test.UserIDCorrect, p.passwdPHC).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+	p.sqlMock.ExpectCommit()
+
+	passwdHistory := entity.PasswdHistory{UserID: test.UserIDCorrect, PasswdPHC: p.passwdPHC}
+	err := p.repo.Create(context.Background(), &passwdHistory)
+	require.NoError(p.T(), err)
+	require.Equal(p.T(), uint64(3), passwdHistory.ID)
+}
+
+func (p *passwdHistorySuite) TestDeleteByUserSuccess() {
+	p.sqlMock.ExpectBegin()
+	p.sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `passwd_histories` WHERE user_id = ?")).
+		WithArgs(test.UserIDCorrect).
+		WillReturnResult(sqlmock.NewResult(1, 2))
+	p.sqlMock.ExpectCommit()
+
+	err := p.repo.DeleteByUser(context.Background(), test.UserIDCorrect)
+	require.NoError(p.T(), err)
+}
+
+func (p *passwdHistorySuite) TestDeleteByUserBeforeError() {
+	p.sqlMock.ExpectBegin()
+	p.sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `passwd_histories` WHERE user_id = ? AND id < ?")).
+		WithArgs(test.UserIDCorrect, 2).
+		WillReturnError(fmt.Errorf("error"))
+	p.sqlMock.ExpectRollback()
+
+	err := p.repo.DeleteByUserBefore(context.Background(), test.UserIDCorrect, 2)
+	require.Error(p.T(), err)
+}
@@ -0,0 +1,149 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+const (
+	policyVersionID = 1
+)
+
+// Permission repo
+type PermissionRepo interface {
+	WithTx(tx DBTx) PermissionRepo
+
+	List(ctx context.Context, offset int, limit int) ([]entity.Permission, error)
+	ListAll(ctx context.Context) ([]entity.Permission, error)
+	Count(ctx context.Context) (int64, error)
+	Create(ctx context.Context, permission *entity.Permission) error
+	Get(ctx context.Context, permissionUUID uuid.EntityUUID) (*entity.Permission, error)
+	Update(ctx context.Context, permission *entity.Permission) error
+	Delete(ctx context.Context, permissionUUID uuid.EntityUUID) error
+	DeleteBySubject(ctx context.Context, subject string) error
+
+	GetPolicyVersion(ctx context.Context) (uint64, error)
+	IncreasePolicyVersion(ctx context.Context) error
+}
+
+type PermissionRepoImp struct {
+	db *gorm.DB
+}
+
+func NewPermissionRepoImp(repoDB *gorm.DB) *PermissionRepoImp {
+	return &PermissionRepoImp{
+		db: repoDB,
+	}
+}
+
+func (p *PermissionRepoImp) WithTx(tx DBTx) PermissionRepo {
+	transaction := tx.GetTx()
+	return NewPermissionRepoImp(transaction)
+}
+
+func (p *PermissionRepoImp) List(ctx context.Context, offset int, limit int) ([]entity.Permission, error) {
+	permissions := []entity.Permission{}
+	result := p.db.Offset(offset).Limit(limit).Find(&permissions)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to list permissions from DB")
+		return nil, getReturnErr(result.Error)
+	}
+	return permissions, nil
+}
+
+func (p *PermissionRepoImp) ListAll(ctx context.Context) ([]entity.Permission, error) {
+	permissions := []entity.Permission{}
+	result := p.db.Find(&permissions)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to list all permissions from DB")
+		return nil, getReturnErr(result.Error)
+	}
+	return permissions, nil
+}
+
+func (p *PermissionRepoImp) Count(ctx context.Context) (int64, error) {
+	var count int64
+	result := p.db.Model(&entity.Permission{}).Count(&count)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to count permissions from DB")
+		return 0, getReturnErr(result.Error)
+	}
+	return count, nil
+}
+
+func (p *PermissionRepoImp) Create(ctx context.Context, permission *entity.Permission) error {
+	result := p.db.Create(permission)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to create permission in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
+
+func (p *PermissionRepoImp) Get(ctx context.Context, permissionUUID uuid.EntityUUID) (*entity.Permission, error) {
+	permission := entity.Permission{}
+	result := p.db.First(&permission, "id = ?", permissionUUID)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to get permission from DB")
+		return nil, getReturnErr(result.Error)
+	}
+	return &permission, nil
+}
+
+func (p *PermissionRepoImp) Update(ctx context.Context, permission *entity.Permission) error {
+	result := p.db.Model(permission).Select("subject", "object", "action").Updates(permission)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to update permission in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
+
+func (p *PermissionRepoImp) Delete(ctx context.Context, permissionUUID uuid.EntityUUID) error {
+	result := p.db.Delete(&entity.Permission{}, "id = ?", permissionUUID)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to delete permission in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
+
+func (p *PermissionRepoImp) DeleteBySubject(ctx context.Context, subject string) error {
+	result := p.db.Delete(&entity.Permission{}, "subject = ?", subject)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to delete permissions of subject in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
+
+// Get the policy version. The version is 0 if permissions have never been changed.
+func (p *PermissionRepoImp) GetPolicyVersion(ctx context.Context) (uint64, error) {
+	policyVersion := entity.PolicyVersion{}
+	result := p.db.First(&policyVersion, "id = ?", policyVersionID)
+	if result.Error == gorm.ErrRecordNotFound {
+		return 0, nil
+	} else if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to get policy version from DB")
+		return 0, getReturnErr(result.Error)
+	}
+	return policyVersion.Version, nil
+}
+
+// Increase the policy version to notify every replica that permissions are changed
+func (p *PermissionRepoImp) IncreasePolicyVersion(ctx context.Context) error {
+	result := p.db.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]interface{}{"version": gorm.Expr("version + 1")}),
+	}).Create(&entity.PolicyVersion{ID: policyVersionID, Version: 1})
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to increase policy version in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	"github.com/ssup2ket/service-auth/internal/test"
+)
+
+func TestPermission(t *testing.T) {
+	suite.Run(t, new(permissionSuite))
+}
+
+type permissionSuite struct {
+	suite.Suite
+	sqlMock sqlmock.Sqlmock
+
+	tx   *DBTxImp
+	repo PermissionRepo
+}
+
+func (p *permissionSuite) SetupTest() {
+	var err error
+	var db *sql.DB
+
+	// Init sqlMock
+	db, p.sqlMock, err = sqlmock.New()
+	require.NoError(p.T(), err)
+
+	// Init DB
+	primaryMySQL, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}))
+	require.NoError(p.T(), err)
+
+	// Init transaction, repo
+	p.tx = NewDBTxImp(primaryMySQL)
+	p.repo = NewPermissionRepoImp(primaryMySQL)
+}
+
+func (p *permissionSuite) AfterTest(_, _ string) {
+	require.NoError(p.T(), p.sqlMock.ExpectationsWereMet())
+}
+
+func (p *permissionSuite) TestListSuccess() {
+	p.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `permissions` LIMIT 10")).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "subject", "object", "action"}).
+				AddRow(test.PermissionIDCorrect, test.RoleNameCorrect, test.PermissionObjectCorrect,
+					test.PermissionActionCorrect),
+		)
+
+	permissions, err := p.repo.List(context.Background(), 0, 10)
+	require.NoError(p.T(), err)
+	require.Equal(p.T(), test.PermissionCorrect, permissions[0])
+}
+
+func (p *permissionSuite) TestListError() {
+	p.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `permissions` LIMIT 10")).
+		WillReturnError(fmt.Errorf("error"))
+
+	_, err := p.repo.List(context.Background(), 0, 10)
+	require.Error(p.T(), err)
+}
+
+func (p *permissionSuite) TestCountSuccess() {
+	p.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `permissions`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(3))
+
+	count, err := p.repo.Count(context.Background())
+	require.NoError(p.T(), err)
+	require.Equal(p.T(), int64(3), count)
+}
+
+func (p *permissionSuite) TestCreateSuccess() {
+	p.sqlMock.ExpectBegin()
+	p.sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `permissions` (`id`,`created_at`,`updated_at`,`subject`,`object`,`action`) VALUES (?,?,?,?,?,?)")).
+		WithArgs(test.PermissionIDCorrect, sqlmock.AnyArg(), sqlmock.AnyArg(), test.RoleNameCorrect,
+			test.PermissionObjectCorrect, test.PermissionActionCorrect).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	p.sqlMock.ExpectCommit()
+
+	permission := test.PermissionCorrect
+	err := p.repo.Create(context.Background(), &permission)
+	require.NoError(p.T(), err)
+}
+
+func (p *permissionSuite) TestGetSuccess() {
+	p.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `permissions` WHERE id = ? ORDER BY `permissions`.`id` LIMIT 1")).
+		WithArgs(test.PermissionIDCorrect).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "subject", "object", "action"}).
+			AddRow(test.PermissionIDCorrect, test.RoleNameCorrect, test.PermissionObjectCorrect,
+				test.PermissionActionCorrect))
+
+	permission, err := p.repo.Get(context.Background(), test.PermissionIDCorrect)
+	require.NoError(p.T(), err)
+	require.Equal(p.T(), test.PermissionCorrect, *permission)
+}
+
+func (p *permissionSuite) TestGetNotFound() {
+	p.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `permissions` WHERE id = ? ORDER BY `permissions`.`id` LIMIT 1")).
+		WithArgs(test.PermissionIDCorrect).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	_, err := p.repo.Get(context.Background(), test.PermissionIDCorrect)
+	require.Equal(p.T(), ErrNotFound, err)
+}
+
+func (p *permissionSuite) TestUpdateSuccess() {
+	p.sqlMock.ExpectBegin()
+	p.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `permissions` SET `updated_at`=?,`subject`=?,`object`=?,`action`=? WHERE `id` = ?")).
+		WithArgs(sqlmock.AnyArg(), test.RoleNameCorrect, test.PermissionObjectCorrect, test.PermissionActionCorrect, test.PermissionIDCorrect).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	p.sqlMock.ExpectCommit()
+
+	permission := test.PermissionCorrect
+	err := p.repo.Update(context.Background(), &permission)
+	require.NoError(p.T(), err)
+}
+
+func (p *permissionSuite) TestDeleteSuccess() {
+	p.sqlMock.ExpectBegin()
+	p.sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `permissions` WHERE id = ?")).
+		WithArgs(test.PermissionIDCorrect).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	p.sqlMock.ExpectCommit()
+
+	err := p.repo.Delete(context.Background(), test.PermissionIDCorrect)
+	require.NoError(p.T(), err)
+}
+
+func (p *permissionSuite) TestDeleteBySubjectSuccess() {
+	p.sqlMock.ExpectBegin()
+	p.sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `permissions` WHERE subject = ?")).
+		WithArgs(test.RoleNameCorrect).
+		WillReturnResult(sqlmock.NewResult(1, 2))
+	p.sqlMock.ExpectCommit()
+
+	err := p.repo.DeleteBySubject(context.Background(), test.RoleNameCorrect)
+	require.NoError(p.T(), err)
+}
+
+func (p *permissionSuite) TestGetPolicyVersionSuccess() {
+	p.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `policy_versions` WHERE id = ? ORDER BY `policy_versions`.`id` LIMIT 1")).
+		WithArgs(policyVersionID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "version"}).AddRow(policyVersionID, 5))
+
+	version, err := p.repo.GetPolicyVersion(context.Background())
+	require.NoError(p.T(), err)
+	require.Equal(p.T(), uint64(5), version)
+}
+
+func (p *permissionSuite) TestGetPolicyVersionNotExist() {
+	p.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `policy_versions` WHERE id = ? ORDER BY `policy_versions`.`id` LIMIT 1")).
+		WithArgs(policyVersionID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "version"}))
+
+	version, err := p.repo.GetPolicyVersion(context.Background())
+	require.NoError(p.T(), err)
+	require.Equal(p.T(), uint64(0), version)
+}
+
+func (p *permissionSuite) TestIncreasePolicyVersionSuccess() {
+	p.sqlMock.ExpectBegin()
+	p.sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `policy_versions` (`updated_at`,`version`,`id`) VALUES (?,?,?) ON DUPLICATE KEY UPDATE `version`=version + 1")).
+		WithArgs(sqlmock.AnyArg(), 1, policyVersionID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	p.sqlMock.ExpectCommit()
+
+	err := p.repo.IncreasePolicyVersion(context.Background())
+	require.NoError(p.T(), err)
+}
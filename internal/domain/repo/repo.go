@@ -46,15 +46,52 @@ func New(c *config.Configs) (DBTx, *gorm.DB, *gorm.DB, error) {
 	if err = primaryMySQL.AutoMigrate(
 		&entity.UserInfo{},
 		&entity.UserSecret{},
+		&entity.EmailVerification{},
+		&entity.PasswdHistory{},
+		&entity.MFARecoveryCode{},
+		&entity.WebAuthnCredential{},
+		&entity.WebAuthnChallenge{},
 		&entity.Outbox{},
+		&entity.TokenKey{},
+		&entity.Session{},
+		&entity.OAuthClient{},
+		&entity.OAuthAuthCode{},
+		&entity.TokenRevocation{},
+		&entity.LoginLock{},
+		&entity.Role{},
+		&entity.Permission{},
+		&entity.PolicyVersion{},
+		&entity.Tenant{},
+		&entity.Group{},
+		&entity.GroupMember{},
 	); err != nil {
 		log.Error().Err(err).Msg("Failed to init schemas")
 		return nil, nil, nil, err
 	}
+	if err = migrateTenant(primaryMySQL); err != nil {
+		log.Error().Err(err).Msg("Failed to migrate users to tenants")
+		return nil, nil, nil, err
+	}
 
 	return NewDBTxImp(primaryMySQL), primaryMySQL, secondaryMySQL, nil
 }
 
+// Migrate users created before tenants were introduced. Login IDs were unique in all tenants,
+// and the users belong to the default tenant.
+func migrateTenant(db *gorm.DB) error {
+	if db.Migrator().HasIndex(&entity.UserInfo{}, "login_id") {
+		if err := db.Migrator().DropIndex(&entity.UserInfo{}, "login_id"); err != nil {
+			return err
+		}
+	}
+	if err := db.Model(&entity.UserInfo{}).Unscoped().Where("tenant_id = ?", "").
+		Update("tenant_id", entity.TenantIDDefault).Error; err != nil {
+		return err
+	}
+	return db.Model(&entity.UserSecret{}).Unscoped().Where("tenant_id = ?", "").
+		Update("tenant_id", entity.TenantIDDefault).Error
+}
+
 // DB transaction
 type DBTx interface {
 	GetTx() *gorm.DB
@@ -0,0 +1,83 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+)
+
+// Role repo
+type RoleRepo interface {
+	WithTx(tx DBTx) RoleRepo
+
+	List(ctx context.Context, offset int, limit int) ([]entity.Role, error)
+	Create(ctx context.Context, role *entity.Role) error
+	Get(ctx context.Context, name string) (*entity.Role, error)
+	Update(ctx context.Context, role *entity.Role) error
+	Delete(ctx context.Context, name string) error
+}
+
+type RoleRepoImp struct {
+	db *gorm.DB
+}
+
+func NewRoleRepoImp(repoDB *gorm.DB) *RoleRepoImp {
+	return &RoleRepoImp{
+		db: repoDB,
+	}
+}
+
+func (r *RoleRepoImp) WithTx(tx DBTx) RoleRepo {
+	transaction := tx.GetTx()
+	return NewRoleRepoImp(transaction)
+}
+
+func (r *RoleRepoImp) List(ctx context.Context, offset int, limit int) ([]entity.Role, error) {
+	roles := []entity.Role{}
+	result := r.db.Offset(offset).Limit(limit).Find(&roles)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to list roles from DB")
+		return nil, getReturnErr(result.Error)
+	}
+	return roles, nil
+}
+
+func (r *RoleRepoImp) Create(ctx context.Context, role *entity.Role) error {
+	result := r.db.Create(role)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to create role in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
+
+func (r *RoleRepoImp) Get(ctx context.Context, name string) (*entity.Role, error) {
+	role := entity.Role{}
+	result := r.db.First(&role, "name = ?", name)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to get role from DB")
+		return nil, getReturnErr(result.Error)
+	}
+	return &role, nil
+}
+
+func (r *RoleRepoImp) Update(ctx context.Context, role *entity.Role) error {
+	result := r.db.Model(role).Select("description", "scopes", "passwd_max_age_days").Updates(role)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to update role in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
+
+func (r *RoleRepoImp) Delete(ctx context.Context, name string) error {
+	result := r.db.Delete(&entity.Role{}, "name = ?", name)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to delete role in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
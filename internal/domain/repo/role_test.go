@@ -0,0 +1,150 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	gomysql "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/test"
+)
+
+func TestRole(t *testing.T) {
+	suite.Run(t, new(roleSuite))
+}
+
+type roleSuite struct {
+	suite.Suite
+	sqlMock sqlmock.Sqlmock
+
+	tx   *DBTxImp
+	repo RoleRepo
+}
+
+func (r *roleSuite) SetupTest() {
+	var err error
+	var db *sql.DB
+
+	// Init sqlMock
+	db, r.sqlMock, err = sqlmock.New()
+	require.NoError(r.T(), err)
+
+	// Init DB
+	primaryMySQL, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}))
+	require.NoError(r.T(), err)
+
+	// Init transaction, repo
+	r.tx = NewDBTxImp(primaryMySQL)
+	r.repo = NewRoleRepoImp(primaryMySQL)
+}
+
+func (r *roleSuite) AfterTest(_, _ string) {
+	require.NoError(r.T(), r.sqlMock.ExpectationsWereMet())
+}
+
+func (r *roleSuite) TestListSuccess() {
+	r.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `roles` LIMIT 10")).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"name", "description", "scopes", "passwd_max_age_days"}).
+				AddRow(test.RoleNameCorrect, test.RoleDescriptionCorrect, `["`+entity.ScopeUsersMeRead+`"]`, test.RolePasswdMaxAgeDaysCorrect),
+		)
+
+	roles, err := r.repo.List(context.Background(), 0, 10)
+	require.NoError(r.T(), err)
+	require.Equal(r.T(), test.RoleNameCorrect, roles[0].Name)
+	require.Equal(r.T(), test.RoleDescriptionCorrect, roles[0].Description)
+	require.True(r.T(), roles[0].IsScopeAllowed(entity.ScopeUsersMeRead))
+	require.False(r.T(), roles[0].IsScopeAllowed(entity.ScopeUsersMeWrite))
+	require.Equal(r.T(), test.RolePasswdMaxAgeDaysCorrect, roles[0].PasswdMaxAgeDays)
+}
+
+func (r *roleSuite) TestCreateSuccess() {
+	r.sqlMock.ExpectBegin()
+	r.sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `roles` (`name`,`created_at`,`updated_at`,`description`,`scopes`,`passwd_max_age_days`) VALUES (?,?,?,?,?,?)")).
+		WithArgs(test.RoleNameCorrect, sqlmock.AnyArg(), sqlmock.AnyArg(), test.RoleDescriptionCorrect, `["`+entity.ScopeUsersMeRead+`"]`, test.RolePasswdMaxAgeDaysCorrect).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	r.sqlMock.ExpectCommit()
+
+	role := test.RoleCorrect
+	err := r.repo.Create(context.Background(), &role)
+	require.NoError(r.T(), err)
+}
+
+func (r *roleSuite) TestCreateConflict() {
+	r.sqlMock.ExpectBegin()
+	r.sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `roles` (`name`,`created_at`,`updated_at`,`description`,`scopes`,`passwd_max_age_days`) VALUES (?,?,?,?,?,?)")).
+		WithArgs(test.RoleNameCorrect, sqlmock.AnyArg(), sqlmock.AnyArg(), test.RoleDescriptionCorrect, `["`+entity.ScopeUsersMeRead+`"]`, test.RolePasswdMaxAgeDaysCorrect).
+		WillReturnError(&gomysql.MySQLError{Number: 1062})
+	r.sqlMock.ExpectRollback()
+
+	role := test.RoleCorrect
+	err := r.repo.Create(context.Background(), &role)
+	require.Equal(r.T(), ErrConflict, err)
+}
+
+func (r *roleSuite) TestGetSuccess() {
+	r.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `roles` WHERE name = ? ORDER BY `roles`.`name` LIMIT 1")).
+		WithArgs(test.RoleNameCorrect).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "description", "scopes"}).
+			AddRow(test.RoleNameCorrect, test.RoleDescriptionCorrect, nil))
+
+	role, err := r.repo.Get(context.Background(), test.RoleNameCorrect)
+	require.NoError(r.T(), err)
+	require.Equal(r.T(), test.RoleNameCorrect, role.Name)
+	require.Empty(r.T(), role.Scopes)
+}
+
+func (r *roleSuite) TestGetNotFound() {
+	r.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `roles` WHERE name = ? ORDER BY `roles`.`name` LIMIT 1")).
+		WithArgs(test.RoleNameCorrect).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	_, err := r.repo.Get(context.Background(), test.RoleNameCorrect)
+	require.Equal(r.T(), ErrNotFound, err)
+}
+
+func (r *roleSuite) TestUpdateSuccess() {
+	r.sqlMock.ExpectBegin()
+	r.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `roles` SET `updated_at`=?,`description`=?,`scopes`=?,`passwd_max_age_days`=? WHERE `name` = ?")).
+		WithArgs(sqlmock.AnyArg(), test.RoleDescriptionCorrect, `["`+entity.ScopeUsersMeRead+`"]`, test.RolePasswdMaxAgeDaysCorrect, test.RoleNameCorrect).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	r.sqlMock.ExpectCommit()
+
+	role := test.RoleCorrect
+	err := r.repo.Update(context.Background(), &role)
+	require.NoError(r.T(), err)
+}
+
+func (r *roleSuite) TestDeleteSuccess() {
+	r.sqlMock.ExpectBegin()
+	r.sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `roles` WHERE name = ?")).
+		WithArgs(test.RoleNameCorrect).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	r.sqlMock.ExpectCommit()
+
+	err := r.repo.Delete(context.Background(), test.RoleNameCorrect)
+	require.NoError(r.T(), err)
+}
+
+func (r *roleSuite) TestDeleteError() {
+	r.sqlMock.ExpectBegin()
+	r.sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `roles` WHERE name = ?")).
+		WithArgs(test.RoleNameCorrect).
+		WillReturnError(fmt.Errorf("error"))
+	r.sqlMock.ExpectRollback()
+
+	err := r.repo.Delete(context.Background(), test.RoleNameCorrect)
+	require.Error(r.T(), err)
+}
@@ -0,0 +1,109 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+// Session repo
+type SessionRepo interface {
+	WithTx(tx DBTx) SessionRepo
+
+	ListByUserID(ctx context.Context, userUUID uuid.EntityUUID, now time.Time) ([]entity.Session, error)
+	Create(ctx context.Context, session *entity.Session) error
+	Get(ctx context.Context, sessionUUID uuid.EntityUUID) (*entity.Session, error)
+	GetForUpdate(ctx context.Context, sessionUUID uuid.EntityUUID) (*entity.Session, error)
+	Update(ctx context.Context, session *entity.Session) error
+	Delete(ctx context.Context, sessionUUID uuid.EntityUUID) error
+	DeleteByUserID(ctx context.Context, userUUID uuid.EntityUUID) error
+}
+
+type SessionRepoImp struct {
+	db *gorm.DB
+}
+
+func NewSessionRepoImp(repoDB *gorm.DB) *SessionRepoImp {
+	return &SessionRepoImp{
+		db: repoDB,
+	}
+}
+
+func (s *SessionRepoImp) WithTx(tx DBTx) SessionRepo {
+	transaction := tx.GetTx()
+	return NewSessionRepoImp(transaction)
+}
+
+// List sessions which aren't expired
+func (s *SessionRepoImp) ListByUserID(ctx context.Context, userUUID uuid.EntityUUID, now time.Time) ([]entity.Session, error) {
+	sessions := []entity.Session{}
+	result := s.db.Where("user_id = ? AND expires_at > ?", userUUID, now).Order("last_used_at desc").Find(&sessions)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to list sessions from DB")
+		return nil, getReturnErr(result.Error)
+	}
+	return sessions, nil
+}
+
+func (s *SessionRepoImp) Create(ctx context.Context, session *entity.Session) error {
+	result := s.db.Create(session)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to create session in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
+
+func (s *SessionRepoImp) Get(ctx context.Context, sessionUUID uuid.EntityUUID) (*entity.Session, error) {
+	session := entity.Session{}
+	result := s.db.First(&session, "id = ?", sessionUUID)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to get session from DB")
+		return nil, getReturnErr(result.Error)
+	}
+	return &session, nil
+}
+
+// Lock the session in the transaction not to use a refresh token concurrently
+func (s *SessionRepoImp) GetForUpdate(ctx context.Context, sessionUUID uuid.EntityUUID) (*entity.Session, error) {
+	session := entity.Session{}
+	result := s.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&session, "id = ?", sessionUUID)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to get session for update from DB")
+		return nil, getReturnErr(result.Error)
+	}
+	return &session, nil
+}
+
+func (s *SessionRepoImp) Update(ctx context.Context, session *entity.Session) error {
+	result := s.db.Updates(session)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to update session in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
+
+func (s *SessionRepoImp) Delete(ctx context.Context, sessionUUID uuid.EntityUUID) error {
+	result := s.db.Delete(&entity.Session{}, "id = ?", sessionUUID)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to delete session in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
+
+func (s *SessionRepoImp) DeleteByUserID(ctx context.Context, userUUID uuid.EntityUUID) error {
+	result := s.db.Delete(&entity.Session{}, "user_id = ?", userUUID)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to delete sessions of user in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/test"
+)
+
+func TestSession(t *testing.T) {
+	suite.Run(t, new(sessionSuite))
+}
+
+type sessionSuite struct {
+	suite.Suite
+	sqlMock sqlmock.Sqlmock
+
+	tx   *DBTxImp
+	repo SessionRepo
+}
+
+func (s *sessionSuite) SetupTest() {
+	var err error
+	var db *sql.DB
+
+	// Init sqlMock
+	db, s.sqlMock, err = sqlmock.New()
+	require.NoError(s.T(), err)
+
+	// Init DB
+	primaryMySQL, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}))
+	require.NoError(s.T(), err)
+
+	// Init transaction, repo
+	s.tx = NewDBTxImp(primaryMySQL)
+	s.repo = NewSessionRepoImp(primaryMySQL)
+}
+
+func (s *sessionSuite) AfterTest(_, _ string) {
+	require.NoError(s.T(), s.sqlMock.ExpectationsWereMet())
+}
+
+func (s *sessionSuite) TestListByUserIDSuccess() {
+	now := time.Now()
+	s.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `sessions` WHERE user_id = ? AND expires_at > ? ORDER BY last_used_at desc")).
+		WithArgs(test.UserIDCorrect, now).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "device_name", "user_agent", "ip"}).
+			AddRow(test.SessionIDCorrect, test.UserIDCorrect, test.SessionDeviceNameCorrect, test.SessionUserAgentCorrect, test.SessionIPCorrect))
+
+	sessions, err := s.repo.ListByUserID(context.Background(), test.UserIDCorrect, now)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), sessions, 1)
+	require.Equal(s.T(), test.SessionIDCorrect, sessions[0].ID)
+	require.Equal(s.T(), test.UserIDCorrect, sessions[0].UserID)
+	require.Equal(s.T(), test.SessionDeviceNameCorrect, sessions[0].DeviceName)
+	require.Equal(s.T(), test.SessionUserAgentCorrect, sessions[0].UserAgent)
+	require.Equal(s.T(), test.SessionIPCorrect, sessions[0].IP)
+}
+
+func (s *sessionSuite) TestListByUserIDError() {
+	now := time.Now()
+	s.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `sessions` WHERE user_id = ? AND expires_at > ? ORDER BY last_used_at desc")).
+		WithArgs(test.UserIDCorrect, now).
+		WillReturnError(fmt.Errorf("error"))
+
+	_, err := s.repo.ListByUserID(context.Background(), test.UserIDCorrect, now)
+	require.Error(s.T(), err)
+}
+
+func (s *sessionSuite) TestCreateSuccess() {
+	s.sqlMock.ExpectBegin()
+	s.sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `sessions` (`id`,`created_at`,`updated_at`,`user_id`,`device_name`,`user_agent`,`ip`,`client_id`,`scopes`,`last_used_at`,`expires_at`,`refresh_token_hash`,`refresh_token_salt`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?)")).
+		WithArgs(test.SessionIDCorrect, sqlmock.AnyArg(), sqlmock.AnyArg(), test.UserIDCorrect, test.SessionDeviceNameCorrect,
+			test.SessionUserAgentCorrect, test.SessionIPCorrect, "", "[]", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	s.sqlMock.ExpectCommit()
+
+	err := s.repo.Create(context.Background(), &entity.Session{
+		ID:         test.SessionIDCorrect,
+		UserID:     test.UserIDCorrect,
+		DeviceName: test.SessionDeviceNameCorrect,
+		UserAgent:  test.SessionUserAgentCorrect,
+		IP:         test.SessionIPCorrect,
+	})
+	require.NoError(s.T(), err)
+}
+
+func (s *sessionSuite) TestGetNotFound() {
+	s.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `sessions` WHERE id = ? ORDER BY `sessions`.`id` LIMIT 1")).
+		WithArgs(test.SessionIDCorrect).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	_, err := s.repo.Get(context.Background(), test.SessionIDCorrect)
+	require.Equal(s.T(), ErrNotFound, err)
+}
+
+func (s *sessionSuite) TestGetForUpdateSuccess() {
+	s.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `sessions` WHERE id = ? ORDER BY `sessions`.`id` LIMIT 1 FOR UPDATE")).
+		WithArgs(test.SessionIDCorrect).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).
+			AddRow(test.SessionIDCorrect, test.UserIDCorrect))
+
+	session, err := s.repo.GetForUpdate(context.Background(), test.SessionIDCorrect)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), test.SessionIDCorrect, session.ID)
+	require.Equal(s.T(), test.UserIDCorrect, session.UserID)
+}
+
+func (s *sessionSuite) TestDeleteSuccess() {
+	s.sqlMock.ExpectBegin()
+	s.sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `sessions` WHERE id = ?")).
+		WithArgs(test.SessionIDCorrect).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	s.sqlMock.ExpectCommit()
+
+	err := s.repo.Delete(context.Background(), test.SessionIDCorrect)
+	require.NoError(s.T(), err)
+}
+
+func (s *sessionSuite) TestDeleteByUserIDSuccess() {
+	s.sqlMock.ExpectBegin()
+	s.sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `sessions` WHERE user_id = ?")).
+		WithArgs(test.UserIDCorrect).
+		WillReturnResult(sqlmock.NewResult(1, 2))
+	s.sqlMock.ExpectCommit()
+
+	err := s.repo.DeleteByUserID(context.Background(), test.UserIDCorrect)
+	require.NoError(s.T(), err)
+}
@@ -0,0 +1,83 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+)
+
+// Tenant repo
+type TenantRepo interface {
+	WithTx(tx DBTx) TenantRepo
+
+	List(ctx context.Context, offset int, limit int) ([]entity.Tenant, error)
+	Create(ctx context.Context, tenant *entity.Tenant) error
+	Get(ctx context.Context, tenantID string) (*entity.Tenant, error)
+	Update(ctx context.Context, tenant *entity.Tenant) error
+	Delete(ctx context.Context, tenantID string) error
+}
+
+type TenantRepoImp struct {
+	db *gorm.DB
+}
+
+func NewTenantRepoImp(repoDB *gorm.DB) *TenantRepoImp {
+	return &TenantRepoImp{
+		db: repoDB,
+	}
+}
+
+func (t *TenantRepoImp) WithTx(tx DBTx) TenantRepo {
+	transaction := tx.GetTx()
+	return NewTenantRepoImp(transaction)
+}
+
+func (t *TenantRepoImp) List(ctx context.Context, offset int, limit int) ([]entity.Tenant, error) {
+	tenants := []entity.Tenant{}
+	result := t.db.Offset(offset).Limit(limit).Find(&tenants)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to list tenants from DB")
+		return nil, getReturnErr(result.Error)
+	}
+	return tenants, nil
+}
+
+func (t *TenantRepoImp) Create(ctx context.Context, tenant *entity.Tenant) error {
+	result := t.db.Create(tenant)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to create tenant in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
+
+func (t *TenantRepoImp) Get(ctx context.Context, tenantID string) (*entity.Tenant, error) {
+	tenant := entity.Tenant{}
+	result := t.db.First(&tenant, "id = ?", tenantID)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to get tenant from DB")
+		return nil, getReturnErr(result.Error)
+	}
+	return &tenant, nil
+}
+
+func (t *TenantRepoImp) Update(ctx context.Context, tenant *entity.Tenant) error {
+	result := t.db.Model(tenant).Select("description").Updates(tenant)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to update tenant in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
+
+func (t *TenantRepoImp) Delete(ctx context.Context, tenantID string) error {
+	result := t.db.Delete(&entity.Tenant{}, "id = ?", tenantID)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to delete tenant in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	gomysql "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	"github.com/ssup2ket/service-auth/internal/test"
+)
+
+func TestTenant(t *testing.T) {
+	suite.Run(t, new(tenantSuite))
+}
+
+type tenantSuite struct {
+	suite.Suite
+	sqlMock sqlmock.Sqlmock
+
+	tx   *DBTxImp
+	repo TenantRepo
+}
+
+func (t *tenantSuite) SetupTest() {
+	var err error
+	var db *sql.DB
+
+	// Init sqlMock
+	db, t.sqlMock, err = sqlmock.New()
+	require.NoError(t.T(), err)
+
+	// Init DB
+	primaryMySQL, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}))
+	require.NoError(t.T(), err)
+
+	// Init transaction, repo
+	t.tx = NewDBTxImp(primaryMySQL)
+	t.repo = NewTenantRepoImp(primaryMySQL)
+}
+
+func (t *tenantSuite) AfterTest(_, _ string) {
+	require.NoError(t.T(), t.sqlMock.ExpectationsWereMet())
+}
+
+func (t *tenantSuite) TestListSuccess() {
+	t.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `tenants` LIMIT 10")).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "description"}).
+				AddRow(test.TenantIDCorrect, test.TenantDescriptionCorrect),
+		)
+
+	tenants, err := t.repo.List(context.Background(), 0, 10)
+	require.NoError(t.T(), err)
+	require.Equal(t.T(), test.TenantIDCorrect, tenants[0].ID)
+	require.Equal(t.T(), test.TenantDescriptionCorrect, tenants[0].Description)
+}
+
+func (t *tenantSuite) TestCreateSuccess() {
+	t.sqlMock.ExpectBegin()
+	t.sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `tenants` (`id`,`created_at`,`updated_at`,`description`) VALUES (?,?,?,?)")).
+		WithArgs(test.TenantIDCorrect, sqlmock.AnyArg(), sqlmock.AnyArg(), test.TenantDescriptionCorrect).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	t.sqlMock.ExpectCommit()
+
+	tenant := test.TenantCorrect
+	err := t.repo.Create(context.Background(), &tenant)
+	require.NoError(t.T(), err)
+}
+
+func (t *tenantSuite) TestCreateConflict() {
+	t.sqlMock.ExpectBegin()
+	t.sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `tenants` (`id`,`created_at`,`updated_at`,`description`) VALUES (?,?,?,?)")).
+		WithArgs(test.TenantIDCorrect, sqlmock.AnyArg(), sqlmock.AnyArg(), test.TenantDescriptionCorrect).
+		WillReturnError(&gomysql.MySQLError{Number: 1062})
+	t.sqlMock.ExpectRollback()
+
+	tenant := test.TenantCorrect
+	err := t.repo.Create(context.Background(), &tenant)
+	require.Equal(t.T(), ErrConflict, err)
+}
+
+func (t *tenantSuite) TestGetSuccess() {
+	t.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `tenants` WHERE id = ? ORDER BY `tenants`.`id` LIMIT 1")).
+		WithArgs(test.TenantIDCorrect).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "description"}).
+			AddRow(test.TenantIDCorrect, test.TenantDescriptionCorrect))
+
+	tenant, err := t.repo.Get(context.Background(), test.TenantIDCorrect)
+	require.NoError(t.T(), err)
+	require.Equal(t.T(), test.TenantIDCorrect, tenant.ID)
+	require.Equal(t.T(), test.TenantDescriptionCorrect, tenant.Description)
+}
+
+func (t *tenantSuite) TestGetNotFound() {
+	t.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `tenants` WHERE id = ? ORDER BY `tenants`.`id` LIMIT 1")).
+		WithArgs(test.TenantIDCorrect).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	_, err := t.repo.Get(context.Background(), test.TenantIDCorrect)
+	require.Equal(t.T(), ErrNotFound, err)
+}
+
+func (t *tenantSuite) TestUpdateSuccess() {
+	t.sqlMock.ExpectBegin()
+	t.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `tenants` SET `updated_at`=?,`description`=? WHERE `id` = ?")).
+		WithArgs(sqlmock.AnyArg(), test.TenantDescriptionCorrect, test.TenantIDCorrect).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	t.sqlMock.ExpectCommit()
+
+	tenant := test.TenantCorrect
+	err := t.repo.Update(context.Background(), &tenant)
+	require.NoError(t.T(), err)
+}
+
+func (t *tenantSuite) TestDeleteSuccess() {
+	t.sqlMock.ExpectBegin()
+	t.sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `tenants` WHERE id = ?")).
+		WithArgs(test.TenantIDCorrect).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	t.sqlMock.ExpectCommit()
+
+	err := t.repo.Delete(context.Background(), test.TenantIDCorrect)
+	require.NoError(t.T(), err)
+}
+
+func (t *tenantSuite) TestDeleteError() {
+	t.sqlMock.ExpectBegin()
+	t.sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `tenants` WHERE id = ?")).
+		WithArgs(test.TenantIDCorrect).
+		WillReturnError(fmt.Errorf("error"))
+	t.sqlMock.ExpectRollback()
+
+	err := t.repo.Delete(context.Background(), test.TenantIDCorrect)
+	require.Error(t.T(), err)
+}
@@ -0,0 +1,103 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+// Token key repo
+type TokenKeyRepo interface {
+	WithTx(tx DBTx) TokenKeyRepo
+
+	List(ctx context.Context) ([]entity.TokenKey, error)
+	ListForUpdate(ctx context.Context) ([]entity.TokenKey, error)
+	Create(ctx context.Context, tokenKey *entity.TokenKey) error
+	Promote(ctx context.Context, tokenKeyUUID uuid.EntityUUID) error
+	Retire(ctx context.Context, tokenKeyUUID uuid.EntityUUID, retiredAt, expiresAt time.Time) error
+	DeleteExpired(ctx context.Context, now time.Time) error
+}
+
+type TokenKeyRepoImp struct {
+	db *gorm.DB
+}
+
+func NewTokenKeyRepoImp(repoDB *gorm.DB) *TokenKeyRepoImp {
+	return &TokenKeyRepoImp{
+		db: repoDB,
+	}
+}
+
+func (t *TokenKeyRepoImp) WithTx(tx DBTx) TokenKeyRepo {
+	transaction := tx.GetTx()
+	return NewTokenKeyRepoImp(transaction)
+}
+
+func (t *TokenKeyRepoImp) List(ctx context.Context) ([]entity.TokenKey, error) {
+	tokenKeys := []entity.TokenKey{}
+	result := t.db.Order("created_at").Find(&tokenKeys)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to list token keys from DB")
+		return nil, getReturnErr(result.Error)
+	}
+	return tokenKeys, nil
+}
+
+// Lock token keys in the transaction not to rotate keys concurrently
+func (t *TokenKeyRepoImp) ListForUpdate(ctx context.Context) ([]entity.TokenKey, error) {
+	tokenKeys := []entity.TokenKey{}
+	result := t.db.Clauses(clause.Locking{Strength: "UPDATE"}).Order("created_at").Find(&tokenKeys)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to list token keys for update from DB")
+		return nil, getReturnErr(result.Error)
+	}
+	return tokenKeys, nil
+}
+
+func (t *TokenKeyRepoImp) Create(ctx context.Context, tokenKey *entity.TokenKey) error {
+	result := t.db.Create(tokenKey)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to create token key in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
+
+func (t *TokenKeyRepoImp) Promote(ctx context.Context, tokenKeyUUID uuid.EntityUUID) error {
+	result := t.db.Model(&entity.TokenKey{}).Where("id = ?", tokenKeyUUID).Updates(entity.TokenKey{
+		Status: entity.TokenKeyStatusPrimary,
+	})
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to promote token key in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
+
+func (t *TokenKeyRepoImp) Retire(ctx context.Context, tokenKeyUUID uuid.EntityUUID, retiredAt, expiresAt time.Time) error {
+	result := t.db.Model(&entity.TokenKey{}).Where("id = ?", tokenKeyUUID).Updates(entity.TokenKey{
+		Status:    entity.TokenKeyStatusRetired,
+		RetiredAt: &retiredAt,
+		ExpiresAt: &expiresAt,
+	})
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to retire token key in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
+
+func (t *TokenKeyRepoImp) DeleteExpired(ctx context.Context, now time.Time) error {
+	result := t.db.Delete(&entity.TokenKey{}, "expires_at < ?", now)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to delete expired token keys in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/test"
+)
+
+func TestTokenKey(t *testing.T) {
+	suite.Run(t, new(tokenKeySuite))
+}
+
+type tokenKeySuite struct {
+	suite.Suite
+	sqlMock sqlmock.Sqlmock
+
+	tx   *DBTxImp
+	repo TokenKeyRepo
+}
+
+func (t *tokenKeySuite) SetupTest() {
+	var err error
+	var db *sql.DB
+
+	// Init sqlMock
+	db, t.sqlMock, err = sqlmock.New()
+	require.NoError(t.T(), err)
+
+	// Init DB
+	primaryMySQL, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}))
+	require.NoError(t.T(), err)
+
+	// Init transaction, repo
+	t.tx = NewDBTxImp(primaryMySQL)
+	t.repo = NewTokenKeyRepoImp(primaryMySQL)
+}
+
+func (t *tokenKeySuite) AfterTest(_, _ string) {
+	require.NoError(t.T(), t.sqlMock.ExpectationsWereMet())
+}
+
+func (t *tokenKeySuite) TestListSuccess() {
+	t.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `token_keys` ORDER BY created_at")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "key_id", "type", "alg", "status", "key"}).
+			AddRow(test.TokenKeyIDCorrect, test.TokenKeyKeyIDCorrect, test.TokenKeyTypeCorrect, test.TokenKeyAlgCorrect, test.TokenKeyStatusCorrect, []byte(test.TokenKeyKeyCorrect)))
+
+	tokenKeys, err := t.repo.List(context.Background())
+	require.NoError(t.T(), err)
+	require.Len(t.T(), tokenKeys, 1)
+	require.Equal(t.T(), test.TokenKeyIDCorrect, tokenKeys[0].ID)
+	require.Equal(t.T(), test.TokenKeyKeyIDCorrect, tokenKeys[0].KeyID)
+	require.Equal(t.T(), test.TokenKeyTypeCorrect, tokenKeys[0].Type)
+	require.Equal(t.T(), test.TokenKeyStatusCorrect, tokenKeys[0].Status)
+}
+
+func (t *tokenKeySuite) TestListForUpdateSuccess() {
+	t.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `token_keys` ORDER BY created_at FOR UPDATE")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "key_id"}).
+			AddRow(test.TokenKeyIDCorrect, test.TokenKeyKeyIDCorrect))
+
+	tokenKeys, err := t.repo.ListForUpdate(context.Background())
+	require.NoError(t.T(), err)
+	require.Len(t.T(), tokenKeys, 1)
+}
+
+func (t *tokenKeySuite) TestCreateSuccess() {
+	t.sqlMock.ExpectBegin()
+	t.sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `token_keys` (`id`,`created_at`,`updated_at`,`key_id`,`type`,`alg`,`status`,`key`,`retired_at`,`expires_at`) VALUES (?,?,?,?,?,?,?,?,?,?)")).
+		WithArgs(test.TokenKeyIDCorrect, sqlmock.AnyArg(), sqlmock.AnyArg(), test.TokenKeyKeyIDCorrect, test.TokenKeyTypeCorrect, test.TokenKeyAlgCorrect, test.TokenKeyStatusCorrect, []byte(test.TokenKeyKeyCorrect), nil, nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	t.sqlMock.ExpectCommit()
+
+	err := t.repo.Create(context.Background(), &entity.TokenKey{
+		ID:     test.TokenKeyIDCorrect,
+		KeyID:  test.TokenKeyKeyIDCorrect,
+		Type:   test.TokenKeyTypeCorrect,
+		Alg:    test.TokenKeyAlgCorrect,
+		Status: test.TokenKeyStatusCorrect,
+		Key:    []byte(test.TokenKeyKeyCorrect),
+	})
+	require.NoError(t.T(), err)
+}
+
+func (t *tokenKeySuite) TestCreateError() {
+	t.sqlMock.ExpectBegin()
+	t.sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `token_keys`")).
+		WillReturnError(fmt.Errorf("error"))
+	t.sqlMock.ExpectRollback()
+
+	err := t.repo.Create(context.Background(), &entity.TokenKey{
+		ID:    test.TokenKeyIDCorrect,
+		KeyID: test.TokenKeyKeyIDCorrect,
+	})
+	require.Equal(t.T(), ErrServerError, err)
+}
+
+func (t *tokenKeySuite) TestPromoteSuccess() {
+	t.sqlMock.ExpectBegin()
+	t.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `token_keys` SET `updated_at`=?,`status`=? WHERE id = ?")).
+		WithArgs(sqlmock.AnyArg(), entity.TokenKeyStatusPrimary, test.TokenKeyIDCorrect).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	t.sqlMock.ExpectCommit()
+
+	err := t.repo.Promote(context.Background(), test.TokenKeyIDCorrect)
+	require.NoError(t.T(), err)
+}
+
+func (t *tokenKeySuite) TestRetireSuccess() {
+	now := time.Now()
+	expiresAt := now.Add(time.Hour)
+
+	t.sqlMock.ExpectBegin()
+	t.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `token_keys` SET `updated_at`=?,`status`=?,`retired_at`=?,`expires_at`=? WHERE id = ?")).
+		WithArgs(sqlmock.AnyArg(), entity.TokenKeyStatusRetired, now, expiresAt, test.TokenKeyIDCorrect).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	t.sqlMock.ExpectCommit()
+
+	err := t.repo.Retire(context.Background(), test.TokenKeyIDCorrect, now, expiresAt)
+	require.NoError(t.T(), err)
+}
+
+func (t *tokenKeySuite) TestDeleteExpiredSuccess() {
+	now := time.Now()
+
+	t.sqlMock.ExpectBegin()
+	t.sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `token_keys` WHERE expires_at < ?")).
+		WithArgs(now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	t.sqlMock.ExpectCommit()
+
+	err := t.repo.DeleteExpired(context.Background(), now)
+	require.NoError(t.T(), err)
+}
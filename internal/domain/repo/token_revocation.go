@@ -0,0 +1,76 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+)
+
+// Token revocation repo
+type TokenRevocationRepo interface {
+	WithTx(tx DBTx) TokenRevocationRepo
+
+	ListActive(ctx context.Context, now time.Time) ([]entity.TokenRevocation, error)
+	Create(ctx context.Context, tokenRevocation *entity.TokenRevocation) error
+	IsRevoked(ctx context.Context, subjects []string, issuedAt time.Time) (bool, error)
+	DeleteExpired(ctx context.Context, now time.Time) error
+}
+
+type TokenRevocationRepoImp struct {
+	db *gorm.DB
+}
+
+func NewTokenRevocationRepoImp(repoDB *gorm.DB) *TokenRevocationRepoImp {
+	return &TokenRevocationRepoImp{
+		db: repoDB,
+	}
+}
+
+func (t *TokenRevocationRepoImp) WithTx(tx DBTx) TokenRevocationRepo {
+	transaction := tx.GetTx()
+	return NewTokenRevocationRepoImp(transaction)
+}
+
+// List revocations which aren't expired
+func (t *TokenRevocationRepoImp) ListActive(ctx context.Context, now time.Time) ([]entity.TokenRevocation, error) {
+	tokenRevocations := []entity.TokenRevocation{}
+	result := t.db.Where("expires_at > ?", now).Find(&tokenRevocations)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to list token revocations from DB")
+		return nil, getReturnErr(result.Error)
+	}
+	return tokenRevocations, nil
+}
+
+func (t *TokenRevocationRepoImp) Create(ctx context.Context, tokenRevocation *entity.TokenRevocation) error {
+	result := t.db.Create(tokenRevocation)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to create token revocation in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
+
+// Check whether tokens of the subjects issued at the time are revoked
+func (t *TokenRevocationRepoImp) IsRevoked(ctx context.Context, subjects []string, issuedAt time.Time) (bool, error) {
+	var count int64
+	result := t.db.Model(&entity.TokenRevocation{}).Where("subject IN ? AND revoked_at >= ?", subjects, issuedAt).Count(&count)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to count token revocations from DB")
+		return false, getReturnErr(result.Error)
+	}
+	return count > 0, nil
+}
+
+func (t *TokenRevocationRepoImp) DeleteExpired(ctx context.Context, now time.Time) error {
+	result := t.db.Delete(&entity.TokenRevocation{}, "expires_at < ?", now)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to delete expired token revocations in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/test"
+)
+
+func TestTokenRevocation(t *testing.T) {
+	suite.Run(t, new(tokenRevocationSuite))
+}
+
+type tokenRevocationSuite struct {
+	suite.Suite
+	sqlMock sqlmock.Sqlmock
+
+	repo TokenRevocationRepo
+}
+
+func (t *tokenRevocationSuite) SetupTest() {
+	var err error
+	var db *sql.DB
+
+	// Init sqlMock
+	db, t.sqlMock, err = sqlmock.New()
+	require.NoError(t.T(), err)
+
+	// Init DB
+	primaryMySQL, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}))
+	require.NoError(t.T(), err)
+
+	// Init repo
+	t.repo = NewTokenRevocationRepoImp(primaryMySQL)
+}
+
+func (t *tokenRevocationSuite) AfterTest(_, _ string) {
+	require.NoError(t.T(), t.sqlMock.ExpectationsWereMet())
+}
+
+func (t *tokenRevocationSuite) TestListActiveSuccess() {
+	now := time.Now()
+
+	t.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `token_revocations` WHERE expires_at > ?")).
+		WithArgs(now).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "type", "subject"}).
+			AddRow(test.TokenRevocationIDCorrect, test.TokenRevocationTypeCorrect, test.TokenRevocationSubjectCorrect))
+
+	tokenRevocations, err := t.repo.ListActive(context.Background(), now)
+	require.NoError(t.T(), err)
+	require.Len(t.T(), tokenRevocations, 1)
+	require.Equal(t.T(), test.TokenRevocationSubjectCorrect, tokenRevocations[0].Subject)
+}
+
+func (t *tokenRevocationSuite) TestCreateSuccess() {
+	now := time.Now()
+	expiresAt := now.Add(time.Hour)
+
+	t.sqlMock.ExpectBegin()
+	t.sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `token_revocations` (`id`,`created_at`,`type`,`subject`,`revoked_at`,`expires_at`) VALUES (?,?,?,?,?,?)")).
+		WithArgs(test.TokenRevocationIDCorrect, sqlmock.AnyArg(), test.TokenRevocationTypeCorrect, test.TokenRevocationSubjectCorrect, now, expiresAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	t.sqlMock.ExpectCommit()
+
+	err := t.repo.Create(context.Background(), &entity.TokenRevocation{
+		ID:        test.TokenRevocationIDCorrect,
+		Type:      test.TokenRevocationTypeCorrect,
+		Subject:   test.TokenRevocationSubjectCorrect,
+		RevokedAt: now,
+		ExpiresAt: expiresAt,
+	})
+	require.NoError(t.T(), err)
+}
+
+func (t *tokenRevocationSuite) TestIsRevokedTrue() {
+	issuedAt := time.Now()
+
+	t.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `token_revocations` WHERE subject IN (?) AND revoked_at >= ?")).
+		WithArgs(test.TokenRevocationSubjectCorrect, issuedAt).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	revoked, err := t.repo.IsRevoked(context.Background(), []string{test.TokenRevocationSubjectCorrect}, issuedAt)
+	require.NoError(t.T(), err)
+	require.True(t.T(), revoked)
+}
+
+func (t *tokenRevocationSuite) TestIsRevokedError() {
+	issuedAt := time.Now()
+
+	t.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `token_revocations`")).
+		WillReturnError(fmt.Errorf("error"))
+
+	_, err := t.repo.IsRevoked(context.Background(), []string{test.TokenRevocationSubjectCorrect}, issuedAt)
+	require.Equal(t.T(), ErrServerError, err)
+}
+
+func (t *tokenRevocationSuite) TestDeleteExpiredSuccess() {
+	now := time.Now()
+
+	t.sqlMock.ExpectBegin()
+	t.sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `token_revocations` WHERE expires_at < ?")).
+		WithArgs(now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	t.sqlMock.ExpectCommit()
+
+	err := t.repo.DeleteExpired(context.Background(), now)
+	require.NoError(t.T(), err)
+}
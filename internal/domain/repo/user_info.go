@@ -2,6 +2,7 @@ package repo
 
 import (
 	"context"
+	"time"
 
 	"github.com/rs/zerolog/log"
 	"gorm.io/gorm"
@@ -10,16 +11,20 @@ import (
 	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
 )
 
-// User info repo
+// User info repo. Every query is scoped to a tenant, so users of other tenants aren't found.
+// Counting by role isn't scoped because roles are shared by all tenants.
 type UserInfoRepo interface {
 	WithTx(tx DBTx) UserInfoRepo
 
-	List(ctx context.Context, offset int, limit int) ([]entity.UserInfo, error)
+	List(ctx context.Context, tenantID string, offset int, limit int) ([]entity.UserInfo, error)
 	Create(ctx context.Context, userInfo *entity.UserInfo) error
-	Get(ctx context.Context, userUUID uuid.EntityUUID) (*entity.UserInfo, error)
-	GetByLoginID(ctx context.Context, userLoginID string) (*entity.UserInfo, error)
+	Get(ctx context.Context, tenantID string, userUUID uuid.EntityUUID) (*entity.UserInfo, error)
+	GetByLoginID(ctx context.Context, tenantID string, userLoginID string) (*entity.UserInfo, error)
+	CountByRole(ctx context.Context, role entity.UserRole) (int64, error)
+	CountByTenant(ctx context.Context, tenantID string) (int64, error)
 	Update(ctx context.Context, userInfo *entity.UserInfo) error
-	Delete(ctx context.Context, userUUID uuid.EntityUUID) error
+	UpdateEmailVerified(ctx context.Context, tenantID string, userUUID uuid.EntityUUID, emailVerifiedAt *time.Time) error
+	Delete(ctx context.Context, tenantID string, userUUID uuid.EntityUUID) error
 }
 
 type UserInfoRepoImp struct {
@@ -37,9 +42,9 @@ func (u *UserInfoRepoImp) WithTx(tx DBTx) UserInfoRepo {
 	return NewUserInfoRepoImp(transaction)
 }
 
-func (u *UserInfoRepoImp) List(ctx context.Context, offset int, limit int) ([]entity.UserInfo, error) {
+func (u *UserInfoRepoImp) List(ctx context.Context, tenantID string, offset int, limit int) ([]entity.UserInfo, error) {
 	userInfos := []entity.UserInfo{}
-	result := u.db.Offset(offset).Limit(limit).Find(&userInfos)
+	result := u.db.Where("tenant_id = ?", tenantID).Offset(offset).Limit(limit).Find(&userInfos)
 	if result.Error != nil {
 		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to list user info from DB")
 		return nil, getReturnErr(result.Error)
@@ -56,9 +61,9 @@ func (u *UserInfoRepoImp) Create(ctx context.Context, userInfo *entity.UserInfo)
 	return nil
 }
 
-func (u *UserInfoRepoImp) Get(ctx context.Context, userUUID uuid.EntityUUID) (*entity.UserInfo, error) {
+func (u *UserInfoRepoImp) Get(ctx context.Context, tenantID string, userUUID uuid.EntityUUID) (*entity.UserInfo, error) {
 	userInfo := entity.UserInfo{}
-	result := u.db.First(&userInfo, "id = ?", userUUID)
+	result := u.db.First(&userInfo, "tenant_id = ? AND id = ?", tenantID, userUUID)
 	if result.Error != nil {
 		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to get user info from DB")
 		return nil, getReturnErr(result.Error)
@@ -66,9 +71,9 @@ func (u *UserInfoRepoImp) Get(ctx context.Context, userUUID uuid.EntityUUID) (*e
 	return &userInfo, nil
 }
 
-func (u *UserInfoRepoImp) GetByLoginID(ctx context.Context, userLoginID string) (*entity.UserInfo, error) {
+func (u *UserInfoRepoImp) GetByLoginID(ctx context.Context, tenantID string, userLoginID string) (*entity.UserInfo, error) {
 	userInfo := entity.UserInfo{}
-	result := u.db.First(&userInfo, "login_id = ?", userLoginID)
+	result := u.db.First(&userInfo, "tenant_id = ? AND login_id = ?", tenantID, userLoginID)
 	if result.Error != nil {
 		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to get user info from DB by user login ID")
 		return nil, getReturnErr(result.Error)
@@ -76,8 +81,30 @@ func (u *UserInfoRepoImp) GetByLoginID(ctx context.Context, userLoginID string)
 	return &userInfo, nil
 }
 
+func (u *UserInfoRepoImp) CountByRole(ctx context.Context, role entity.UserRole) (int64, error) {
+	var count int64
+	result := u.db.Model(&entity.UserInfo{}).Where("role = ?", role).Count(&count)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to count user info from DB by role")
+		return 0, getReturnErr(result.Error)
+	}
+	return count, nil
+}
+
+func (u *UserInfoRepoImp) CountByTenant(ctx context.Context, tenantID string) (int64, error) {
+	var count int64
+	result := u.db.Model(&entity.UserInfo{}).Where("tenant_id = ?", tenantID).Count(&count)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to count user info from DB by tenant")
+		return 0, getReturnErr(result.Error)
+	}
+	return count, nil
+}
+
+// Update the user info in its tenant. The tenant of a user can't be changed.
+// Email verified is updated only by UpdateEmailVerified()
 func (u *UserInfoRepoImp) Update(ctx context.Context, userInfo *entity.UserInfo) error {
-	result := u.db.Updates(userInfo)
+	result := u.db.Omit("tenant_id", "email_verified", "email_verified_at").Where("tenant_id = ?", userInfo.TenantID).Updates(userInfo)
 	if result.Error != nil {
 		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to update user info in DB")
 		return getReturnErr(result.Error)
@@ -85,8 +112,22 @@ func (u *UserInfoRepoImp) Update(ctx context.Context, userInfo *entity.UserInfo)
 	return nil
 }
 
-func (u *UserInfoRepoImp) Delete(ctx context.Context, userUUID uuid.EntityUUID) error {
-	result := u.db.Delete(&entity.UserInfo{}, "id = ?", userUUID)
+// Set the email verified time of the user, and nil time means that the email isn't verified
+func (u *UserInfoRepoImp) UpdateEmailVerified(ctx context.Context, tenantID string, userUUID uuid.EntityUUID,
+	emailVerifiedAt *time.Time) error {
+	result := u.db.Model(&entity.UserInfo{}).Where("tenant_id = ? AND id = ?", tenantID, userUUID).Updates(map[string]interface{}{
+		"email_verified":    emailVerifiedAt != nil,
+		"email_verified_at": emailVerifiedAt,
+	})
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to update user info's email verified in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
+
+func (u *UserInfoRepoImp) Delete(ctx context.Context, tenantID string, userUUID uuid.EntityUUID) error {
+	result := u.db.Delete(&entity.UserInfo{}, "tenant_id = ? AND id = ?", tenantID, userUUID)
 	if result.Error != nil {
 		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to delete user info in DB")
 		return getReturnErr(result.Error)
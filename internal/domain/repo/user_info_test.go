@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/require"
@@ -54,14 +55,15 @@ func (u *userInfoSuite) AfterTest(_, _ string) {
 }
 
 func (u *userInfoSuite) TestListSuccess() {
-	u.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `user_infos` WHERE `user_infos`.`deleted_at` IS NULL LIMIT 10")).
+	u.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `user_infos` WHERE tenant_id = ? AND `user_infos`.`deleted_at` IS NULL LIMIT 10")).
+		WithArgs(test.TenantIDCorrect).
 		WillReturnRows(
 			sqlmock.NewRows([]string{"id", "login_id", "role", "phone", "email"}).
 				AddRow(test.UserIDCorrect, test.UserLoginIDCorrect, test.UserRoleCorrect, test.UserPhoneCorrect, test.UserEmailCorrect).
 				AddRow(test.UserIDCorrect2, test.UserLoginIDCorrect2, test.UserRoleCorrect, test.UserPhoneCorrect, test.UserEmailCorrect),
 		)
 
-	userInfos, err := u.repo.List(context.Background(), 0, 10)
+	userInfos, err := u.repo.List(context.Background(), test.TenantIDCorrect, 0, 10)
 	require.NoError(u.T(), err)
 	require.Equal(u.T(), test.UserIDCorrect, userInfos[0].ID)
 	require.Equal(u.T(), test.UserLoginIDCorrect, userInfos[0].LoginID)
@@ -76,54 +78,57 @@ func (u *userInfoSuite) TestListSuccess() {
 }
 
 func (u *userInfoSuite) TestListError() {
-	u.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `user_infos` WHERE `user_infos`.`deleted_at` IS NULL LIMIT 10")).
+	u.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `user_infos` WHERE tenant_id = ? AND `user_infos`.`deleted_at` IS NULL LIMIT 10")).
+		WithArgs(test.TenantIDCorrect).
 		WillReturnError(fmt.Errorf("error"))
 
-	_, err := u.repo.List(context.Background(), 0, 10)
+	_, err := u.repo.List(context.Background(), test.TenantIDCorrect, 0, 10)
 	require.Error(u.T(), err)
 }
 
 func (u *userInfoSuite) TestCreateSuccess() {
 	u.sqlMock.ExpectBegin()
-	u.sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `user_infos` (`id`,`created_at`,`updated_at`,`deleted_at`,`login_id`,`role`,`phone`,`email`) VALUES (?,?,?,?,?,?,?,?)")).
-		WithArgs(test.UserIDCorrect, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), test.UserLoginIDCorrect, test.UserRoleCorrect, test.UserPhoneCorrect, test.UserEmailCorrect).
+	u.sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `user_infos` (`id`,`created_at`,`updated_at`,`deleted_at`,`tenant_id`,`login_id`,`role`,`phone`,`email`,`email_verified`,`email_verified_at`) VALUES (?,?,?,?,?,?,?,?,?,?,?)")).
+		WithArgs(test.UserIDCorrect, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), test.TenantIDCorrect, test.UserLoginIDCorrect, test.UserRoleCorrect, test.UserPhoneCorrect, test.UserEmailCorrect, false, nil).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	u.sqlMock.ExpectCommit()
 
 	err := u.repo.Create(context.Background(), &entity.UserInfo{
-		ID:      test.UserIDCorrect,
-		LoginID: test.UserLoginIDCorrect,
-		Role:    test.UserRoleCorrect,
-		Phone:   test.UserPhoneCorrect,
-		Email:   test.UserEmailCorrect,
+		ID:       test.UserIDCorrect,
+		TenantID: test.TenantIDCorrect,
+		LoginID:  test.UserLoginIDCorrect,
+		Role:     test.UserRoleCorrect,
+		Phone:    test.UserPhoneCorrect,
+		Email:    test.UserEmailCorrect,
 	})
 	require.NoError(u.T(), err)
 }
 
 func (u *userInfoSuite) TestCreateError() {
 	u.sqlMock.ExpectBegin()
-	u.sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `user_infos` (`id`,`created_at`,`updated_at`,`deleted_at`,`login_id`,`role`,`phone`,`email`) VALUES (?,?,?,?,?,?,?,?)")).
-		WithArgs(test.UserIDCorrect, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), test.UserLoginIDCorrect, test.UserRoleCorrect, test.UserPhoneCorrect, test.UserEmailCorrect).
+	u.sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `user_infos` (`id`,`created_at`,`updated_at`,`deleted_at`,`tenant_id`,`login_id`,`role`,`phone`,`email`,`email_verified`,`email_verified_at`) VALUES (?,?,?,?,?,?,?,?,?,?,?)")).
+		WithArgs(test.UserIDCorrect, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), test.TenantIDCorrect, test.UserLoginIDCorrect, test.UserRoleCorrect, test.UserPhoneCorrect, test.UserEmailCorrect, false, nil).
 		WillReturnError(fmt.Errorf("error"))
 	u.sqlMock.ExpectRollback()
 
 	err := u.repo.Create(context.Background(), &entity.UserInfo{
-		ID:      test.UserIDCorrect,
-		LoginID: test.UserLoginIDCorrect,
-		Role:    test.UserRoleCorrect,
-		Phone:   test.UserPhoneCorrect,
-		Email:   test.UserEmailCorrect,
+		ID:       test.UserIDCorrect,
+		TenantID: test.TenantIDCorrect,
+		LoginID:  test.UserLoginIDCorrect,
+		Role:     test.UserRoleCorrect,
+		Phone:    test.UserPhoneCorrect,
+		Email:    test.UserEmailCorrect,
 	})
 	require.Error(u.T(), err)
 }
 
 func (u *userInfoSuite) TestGetSuccess() {
-	u.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `user_infos` WHERE id = ? AND `user_infos`.`deleted_at` IS NULL ORDER BY `user_infos`.`id` LIMIT 1")).
-		WithArgs(test.UserIDCorrect).
+	u.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `user_infos` WHERE (tenant_id = ? AND id = ?) AND `user_infos`.`deleted_at` IS NULL ORDER BY `user_infos`.`id` LIMIT 1")).
+		WithArgs(test.TenantIDCorrect, test.UserIDCorrect).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "login_id", "role", "phone", "email"}).
 			AddRow(test.UserIDCorrect, test.UserLoginIDCorrect, test.UserRoleCorrect, test.UserPhoneCorrect, test.UserEmailCorrect))
 
-	userInfo, err := u.repo.Get(context.Background(), test.UserIDCorrect)
+	userInfo, err := u.repo.Get(context.Background(), test.TenantIDCorrect, test.UserIDCorrect)
 	require.NoError(u.T(), err)
 	require.Equal(u.T(), test.UserIDCorrect, userInfo.ID)
 	require.Equal(u.T(), test.UserLoginIDCorrect, userInfo.LoginID)
@@ -133,21 +138,21 @@ func (u *userInfoSuite) TestGetSuccess() {
 }
 
 func (u *userInfoSuite) TestGetError() {
-	u.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `user_infos` WHERE id = ? AND `user_infos`.`deleted_at` IS NULL ORDER BY `user_infos`.`id` LIMIT 1")).
-		WithArgs(test.UserIDCorrect).
+	u.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `user_infos` WHERE (tenant_id = ? AND id = ?) AND `user_infos`.`deleted_at` IS NULL ORDER BY `user_infos`.`id` LIMIT 1")).
+		WithArgs(test.TenantIDCorrect, test.UserIDCorrect).
 		WillReturnError(fmt.Errorf("error"))
 
-	_, err := u.repo.Get(context.Background(), test.UserIDCorrect)
+	_, err := u.repo.Get(context.Background(), test.TenantIDCorrect, test.UserIDCorrect)
 	require.Error(u.T(), err)
 }
 
 func (u *userInfoSuite) TestGetByLoginIDSuccess() {
-	u.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `user_infos` WHERE login_id = ? AND `user_infos`.`deleted_at` IS NULL ORDER BY `user_infos`.`id` LIMIT 1")).
-		WithArgs(test.UserLoginIDCorrect).
+	u.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `user_infos` WHERE (tenant_id = ? AND login_id = ?) AND `user_infos`.`deleted_at` IS NULL ORDER BY `user_infos`.`id` LIMIT 1")).
+		WithArgs(test.TenantIDCorrect, test.UserLoginIDCorrect).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "login_id", "role", "phone", "email"}).
 			AddRow(test.UserIDCorrect, test.UserLoginIDCorrect, test.UserRoleCorrect, test.UserPhoneCorrect, test.UserEmailCorrect))
 
-	userInfo, err := u.repo.GetByLoginID(context.Background(), test.UserLoginIDCorrect)
+	userInfo, err := u.repo.GetByLoginID(context.Background(), test.TenantIDCorrect, test.UserLoginIDCorrect)
 	require.NoError(u.T(), err)
 	require.Equal(u.T(), test.UserIDCorrect, userInfo.ID)
 	require.Equal(u.T(), test.UserLoginIDCorrect, userInfo.LoginID)
@@ -157,36 +162,75 @@ func (u *userInfoSuite) TestGetByLoginIDSuccess() {
 }
 
 func (u *userInfoSuite) TestGetByLoginIDError() {
-	u.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `user_infos` WHERE login_id = ? AND `user_infos`.`deleted_at` IS NULL ORDER BY `user_infos`.`id` LIMIT 1")).
-		WithArgs(test.UserLoginIDCorrect).
+	u.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `user_infos` WHERE (tenant_id = ? AND login_id = ?) AND `user_infos`.`deleted_at` IS NULL ORDER BY `user_infos`.`id` LIMIT 1")).
+		WithArgs(test.TenantIDCorrect, test.UserLoginIDCorrect).
 		WillReturnError(fmt.Errorf("error"))
 
-	_, err := u.repo.GetByLoginID(context.Background(), test.UserLoginIDCorrect)
+	_, err := u.repo.GetByLoginID(context.Background(), test.TenantIDCorrect, test.UserLoginIDCorrect)
+	require.Error(u.T(), err)
+}
+
+func (u *userInfoSuite) TestCountByRoleSuccess() {
+	u.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `user_infos` WHERE role = ? AND `user_infos`.`deleted_at` IS NULL")).
+		WithArgs(test.UserRoleCorrect).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(2))
+
+	count, err := u.repo.CountByRole(context.Background(), test.UserRoleCorrect)
+	require.NoError(u.T(), err)
+	require.Equal(u.T(), int64(2), count)
+}
+
+func (u *userInfoSuite) TestCountByRoleError() {
+	u.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `user_infos` WHERE role = ? AND `user_infos`.`deleted_at` IS NULL")).
+		WithArgs(test.UserRoleCorrect).
+		WillReturnError(fmt.Errorf("error"))
+
+	_, err := u.repo.CountByRole(context.Background(), test.UserRoleCorrect)
+	require.Error(u.T(), err)
+}
+
+func (u *userInfoSuite) TestCountByTenantSuccess() {
+	u.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `user_infos` WHERE tenant_id = ? AND `user_infos`.`deleted_at` IS NULL")).
+		WithArgs(test.TenantIDCorrect).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(2))
+
+	count, err := u.repo.CountByTenant(context.Background(), test.TenantIDCorrect)
+	require.NoError(u.T(), err)
+	require.Equal(u.T(), int64(2), count)
+}
+
+func (u *userInfoSuite) TestCountByTenantError() {
+	u.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `user_infos` WHERE tenant_id = ? AND `user_infos`.`deleted_at` IS NULL")).
+		WithArgs(test.TenantIDCorrect).
+		WillReturnError(fmt.Errorf("error"))
+
+	_, err := u.repo.CountByTenant(context.Background(), test.TenantIDCorrect)
 	require.Error(u.T(), err)
 }
 
 func (u *userInfoSuite) TestCreateAndGetWithTxSuccess() {
 	u.sqlMock.ExpectBegin()
-	u.sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `user_infos` (`id`,`created_at`,`updated_at`,`deleted_at`,`login_id`,`role`,`phone`,`email`) VALUES (?,?,?,?,?,?,?,?)")).
-		WithArgs(test.UserIDCorrect, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), test.UserLoginIDCorrect, test.UserRoleCorrect, test.UserPhoneCorrect, test.UserEmailCorrect).
+	u.sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `user_infos` (`id`,`created_at`,`updated_at`,`deleted_at`,`tenant_id`,`login_id`,`role`,`phone`,`email`,`email_verified`,`email_verified_at`) VALUES (?,?,?,?,?,?,?,?,?,?,?)")).
+		WithArgs(test.UserIDCorrect, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), test.TenantIDCorrect, test.UserLoginIDCorrect, test.UserRoleCorrect, test.UserPhoneCorrect, test.UserEmailCorrect, false, nil).
 		WillReturnResult(sqlmock.NewResult(1, 1))
-	u.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `user_infos` WHERE id = ? AND `user_infos`.`deleted_at` IS NULL ORDER BY `user_infos`.`id` LIMIT 1")).
-		WithArgs(test.UserIDCorrect).
+	u.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `user_infos` WHERE (tenant_id = ? AND id = ?) AND `user_infos`.`deleted_at` IS NULL ORDER BY `user_infos`.`id` LIMIT 1")).
+		WithArgs(test.TenantIDCorrect, test.UserIDCorrect).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "login_id", "role", "phone", "email"}).
 			AddRow(test.UserIDCorrect, test.UserLoginIDCorrect, test.UserRoleCorrect, test.UserPhoneCorrect, test.UserEmailCorrect))
 	u.sqlMock.ExpectCommit()
 
 	tx, _ := u.tx.Begin()
 	err := u.repo.WithTx(tx).Create(context.Background(), &entity.UserInfo{
-		ID:      test.UserIDCorrect,
-		LoginID: test.UserLoginIDCorrect,
-		Role:    test.UserRoleCorrect,
-		Phone:   test.UserPhoneCorrect,
-		Email:   test.UserEmailCorrect,
+		ID:       test.UserIDCorrect,
+		TenantID: test.TenantIDCorrect,
+		LoginID:  test.UserLoginIDCorrect,
+		Role:     test.UserRoleCorrect,
+		Phone:    test.UserPhoneCorrect,
+		Email:    test.UserEmailCorrect,
 	})
 	require.NoError(u.T(), err)
 
-	userInfo, err := u.repo.WithTx(tx).Get(context.Background(), test.UserIDCorrect)
+	userInfo, err := u.repo.WithTx(tx).Get(context.Background(), test.TenantIDCorrect, test.UserIDCorrect)
 	require.NoError(u.T(), err)
 	require.Equal(u.T(), test.UserIDCorrect, userInfo.ID)
 	require.Equal(u.T(), test.UserLoginIDCorrect, userInfo.LoginID)
@@ -198,56 +242,82 @@ func (u *userInfoSuite) TestCreateAndGetWithTxSuccess() {
 
 func (u *userInfoSuite) TestUpdateSuccess() {
 	u.sqlMock.ExpectBegin()
-	u.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `user_infos` SET `updated_at`=?,`login_id`=?,`role`=?,`phone`=?,`email`=? WHERE `id` = ?")).
-		WithArgs(sqlmock.AnyArg(), test.UserLoginIDCorrect, test.UserRoleCorrect, test.UserPhoneCorrect, test.UserEmailCorrect, test.UserIDCorrect).
+	u.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `user_infos` SET `updated_at`=?,`login_id`=?,`role`=?,`phone`=?,`email`=? WHERE tenant_id = ? AND `user_infos`.`deleted_at` IS NULL AND `id` = ?")).
+		WithArgs(sqlmock.AnyArg(), test.UserLoginIDCorrect, test.UserRoleCorrect, test.UserPhoneCorrect, test.UserEmailCorrect, test.TenantIDCorrect, test.UserIDCorrect).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	u.sqlMock.ExpectCommit()
 
 	err := u.repo.Update(context.Background(), &entity.UserInfo{
-		ID:      test.UserIDCorrect,
-		LoginID: test.UserLoginIDCorrect,
-		Role:    test.UserRoleCorrect,
-		Phone:   test.UserPhoneCorrect,
-		Email:   test.UserEmailCorrect,
+		ID:       test.UserIDCorrect,
+		TenantID: test.TenantIDCorrect,
+		LoginID:  test.UserLoginIDCorrect,
+		Role:     test.UserRoleCorrect,
+		Phone:    test.UserPhoneCorrect,
+		Email:    test.UserEmailCorrect,
 	})
 	require.NoError(u.T(), err)
 }
 
 func (u *userInfoSuite) TestUpdateError() {
 	u.sqlMock.ExpectBegin()
-	u.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `user_infos` SET `updated_at`=?,`login_id`=?,`role`=?,`phone`=?,`email`=? WHERE `id` = ?")).
-		WithArgs(sqlmock.AnyArg(), test.UserLoginIDCorrect, test.UserRoleCorrect, test.UserPhoneCorrect, test.UserEmailCorrect, test.UserIDCorrect).
+	u.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `user_infos` SET `updated_at`=?,`login_id`=?,`role`=?,`phone`=?,`email`=? WHERE tenant_id = ? AND `user_infos`.`deleted_at` IS NULL AND `id` = ?")).
+		WithArgs(sqlmock.AnyArg(), test.UserLoginIDCorrect, test.UserRoleCorrect, test.UserPhoneCorrect, test.UserEmailCorrect, test.TenantIDCorrect, test.UserIDCorrect).
 		WillReturnError(fmt.Errorf("error"))
 	u.sqlMock.ExpectRollback()
 
 	err := u.repo.Update(context.Background(), &entity.UserInfo{
-		ID:      test.UserIDCorrect,
-		LoginID: test.UserLoginIDCorrect,
-		Role:    test.UserRoleCorrect,
-		Phone:   test.UserPhoneCorrect,
-		Email:   test.UserEmailCorrect,
+		ID:       test.UserIDCorrect,
+		TenantID: test.TenantIDCorrect,
+		LoginID:  test.UserLoginIDCorrect,
+		Role:     test.UserRoleCorrect,
+		Phone:    test.UserPhoneCorrect,
+		Email:    test.UserEmailCorrect,
 	})
 	require.Error(u.T(), err)
 }
 
+func (u *userInfoSuite) TestUpdateEmailVerifiedSuccess() {
+	now := time.Now()
+
+	u.sqlMock.ExpectBegin()
+	u.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `user_infos` SET `email_verified`=?,`email_verified_at`=?,`updated_at`=? WHERE (tenant_id = ? AND id = ?) AND `user_infos`.`deleted_at` IS NULL")).
+		WithArgs(true, now, sqlmock.AnyArg(), test.TenantIDCorrect, test.UserIDCorrect).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	u.sqlMock.ExpectCommit()
+
+	err := u.repo.UpdateEmailVerified(context.Background(), test.TenantIDCorrect, test.UserIDCorrect, &now)
+	require.NoError(u.T(), err)
+}
+
+func (u *userInfoSuite) TestUpdateEmailVerifiedError() {
+	u.sqlMock.ExpectBegin()
+	u.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `user_infos` SET `email_verified`=?,`email_verified_at`=?,`updated_at`=? WHERE (tenant_id = ? AND id = ?) AND `user_infos`.`deleted_at` IS NULL")).
+		WithArgs(false, nil, sqlmock.AnyArg(), test.TenantIDCorrect, test.UserIDCorrect).
+		WillReturnError(fmt.Errorf("error"))
+	u.sqlMock.ExpectRollback()
+
+	err := u.repo.UpdateEmailVerified(context.Background(), test.TenantIDCorrect, test.UserIDCorrect, nil)
+	require.Error(u.T(), err)
+}
+
 func (u *userInfoSuite) TestDeleteSuccess() {
 	u.sqlMock.ExpectBegin()
-	u.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `user_infos` SET `deleted_at`=? WHERE id = ? AND `user_infos`.`deleted_at` IS NULL")).
-		WithArgs(sqlmock.AnyArg(), test.UserIDCorrect).
+	u.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `user_infos` SET `deleted_at`=? WHERE (tenant_id = ? AND id = ?) AND `user_infos`.`deleted_at` IS NULL")).
+		WithArgs(sqlmock.AnyArg(), test.TenantIDCorrect, test.UserIDCorrect).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	u.sqlMock.ExpectCommit()
 
-	err := u.repo.Delete(context.Background(), test.UserIDCorrect)
+	err := u.repo.Delete(context.Background(), test.TenantIDCorrect, test.UserIDCorrect)
 	require.NoError(u.T(), err)
 }
 
 func (u *userInfoSuite) TestDeleteError() {
 	u.sqlMock.ExpectBegin()
-	u.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `user_infos` SET `deleted_at`=? WHERE id = ? AND `user_infos`.`deleted_at` IS NULL")).
-		WithArgs(sqlmock.AnyArg(), test.UserIDCorrect).
+	u.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `user_infos` SET `deleted_at`=? WHERE (tenant_id = ? AND id = ?) AND `user_infos`.`deleted_at` IS NULL")).
+		WithArgs(sqlmock.AnyArg(), test.TenantIDCorrect, test.UserIDCorrect).
 		WillReturnError(fmt.Errorf("error"))
 	u.sqlMock.ExpectRollback()
 
-	err := u.repo.Delete(context.Background(), test.UserIDCorrect)
+	err := u.repo.Delete(context.Background(), test.TenantIDCorrect, test.UserIDCorrect)
 	require.Error(u.T(), err)
 }
@@ -2,6 +2,7 @@ package repo
 
 import (
 	"context"
+	"time"
 
 	"github.com/rs/zerolog/log"
 	"gorm.io/gorm"
@@ -17,6 +18,9 @@ type UserSecretRepo interface {
 	Create(ctx context.Context, userSecret *entity.UserSecret) error
 	Get(ctx context.Context, userUUID uuid.EntityUUID) (*entity.UserSecret, error)
 	Update(ctx context.Context, userSecret *entity.UserSecret) error
+	UpdateTOTP(ctx context.Context, userSecret *entity.UserSecret) error
+	UpdateTOTPLastStep(ctx context.Context, userUUID uuid.EntityUUID, step int64) error
+	DeletePasswd(ctx context.Context, userUUID uuid.EntityUUID, passwdChangedAt time.Time) error
 	Delete(ctx context.Context, userUUID uuid.EntityUUID) error
 }
 
@@ -54,8 +58,10 @@ func (u *UserSecretRepoImp) Get(ctx context.Context, userUUID uuid.EntityUUID) (
 	return &user, nil
 }
 
+// Update the password of the user secret. The legacy password hash and salt are cleared if they aren't set,
+// and the password changed time is also updated.
 func (u *UserSecretRepoImp) Update(ctx context.Context, userSecret *entity.UserSecret) error {
-	result := u.db.Updates(userSecret)
+	result := u.db.Select("passwd_phc", "passwd_changed_at", "passwd_hash", "passwd_salt").Updates(userSecret)
 	if result.Error != nil {
 		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to update user secret in DB")
 		return ErrServerError
@@ -63,6 +69,45 @@ func (u *UserSecretRepoImp) Update(ctx context.Context, userSecret *entity.UserS
 	return nil
 }
 
+// Update the TOTP secret, enabled time and last used time step of the user secret. TOTP is cleared if they aren't set.
+func (u *UserSecretRepoImp) UpdateTOTP(ctx context.Context, userSecret *entity.UserSecret) error {
+	result := u.db.Select("totp_secret", "totp_enabled_at", "totp_last_step").Updates(userSecret)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to update user secret's TOTP in DB")
+		return ErrServerError
+	}
+	return nil
+}
+
+// Update the last used TOTP time step only if it's after the current one not to reuse a code concurrently.
+// It returns not found error if the time step is already used.
+func (u *UserSecretRepoImp) UpdateTOTPLastStep(ctx context.Context, userUUID uuid.EntityUUID, step int64) error {
+	result := u.db.Model(&entity.UserSecret{}).Where("id = ? AND totp_last_step < ?", userUUID, step).
+		Update("totp_last_step", step)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to update user secret's TOTP last step in DB")
+		return ErrServerError
+	} else if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Delete the password hashes of the user secret, so the user can login only by passkeys
+func (u *UserSecretRepoImp) DeletePasswd(ctx context.Context, userUUID uuid.EntityUUID, passwdChangedAt time.Time) error {
+	result := u.db.Model(&entity.UserSecret{}).Where("id = ?", userUUID).Updates(map[string]interface{}{
+		"passwd_phc":        "",
+		"passwd_hash":       nil,
+		"passwd_salt":       nil,
+		"passwd_changed_at": passwdChangedAt,
+	})
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to delete user secret's password in DB")
+		return ErrServerError
+	}
+	return nil
+}
+
 func (u *UserSecretRepoImp) Delete(ctx context.Context, userUUID uuid.EntityUUID) error {
 	result := u.db.Delete(&entity.UserSecret{}, "id = ?", userUUID)
 	if result.Error != nil {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/require"
@@ -16,7 +17,6 @@ import (
 	"github.com/ssup2ket/service-auth/internal/domain/entity"
 	"github.com/ssup2ket/service-auth/internal/test"
 	"github.com/ssup2ket/service-auth/pkg/auth/hashing"
-	"github.com/ssup2ket/service-auth/pkg/auth/token"
 )
 
 func TestUserSecret(t *testing.T) {
@@ -30,10 +30,7 @@ type userSecretSuite struct {
 	tx   *DBTxImp
 	repo UserSecretRepo
 
-	passwdHash       []byte
-	passwdSalt       []byte
-	refreshTokenHash []byte
-	refreshTokenSalt []byte
+	passwdPHC string
 }
 
 func (u *userSecretSuite) SetupTest() {
@@ -55,10 +52,8 @@ func (u *userSecretSuite) SetupTest() {
 	u.tx = NewDBTxImp(primaryMySQL)
 	u.repo = NewUserSecretRepoImp(primaryMySQL)
 
-	// Get password and refresh token's hash and salt
-	tokenInfo, _ := token.CreateRefreshToken(&token.AuthClaims{UserID: test.UserLoginIDCorrect, UserLoginID: test.UserLoginIDCorrect})
-	u.passwdHash, u.passwdSalt, _ = hashing.GetStrHashAndSalt(test.UserPasswdCorrect)
-	u.refreshTokenHash, u.refreshTokenSalt, _ = hashing.GetStrHashAndSalt(tokenInfo.Token)
+	// Get password's hash
+	u.passwdPHC, _ = hashing.GetPasswdHash(test.UserPasswdCorrect)
 }
 
 func (u *userSecretSuite) AfterTest(_, _ string) {
@@ -67,34 +62,32 @@ func (u *userSecretSuite) AfterTest(_, _ string) {
 
 func (u *userSecretSuite) TestCreateSuccess() {
 	u.sqlMock.ExpectBegin()
-	u.sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `user_secrets` (`id`,`created_at`,`updated_at`,`deleted_at`,`passwd_hash`,`passwd_salt`,`refresh_token_hash`,`refresh_token_salt`) VALUES (?,?,?,?,?,?,?,?)")).
-		WithArgs(test.UserIDCorrect, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), u.passwdHash, u.passwdSalt, u.refreshTokenHash, u.refreshTokenSalt).
+	u.sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `user_secrets` (`id`,`created_at`,`updated_at`,`deleted_at`,`tenant_id`,`passwd_phc`,`passwd_changed_at`,`passwd_hash`,`passwd_salt`,`totp_secret`,`totp_enabled_at`,`totp_last_step`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?)")).
+		WithArgs(test.UserIDCorrect, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), test.TenantIDCorrect, u.passwdPHC, sqlmock.AnyArg(), []byte(nil), []byte(nil),
+			[]byte(nil), nil, 0).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	u.sqlMock.ExpectCommit()
 
 	err := u.repo.Create(context.Background(), &entity.UserSecret{
-		ID:               test.UserIDCorrect,
-		PasswdHash:       u.passwdHash,
-		PasswdSalt:       u.passwdSalt,
-		RefreshTokenHash: u.refreshTokenHash,
-		RefreshTokenSalt: u.refreshTokenSalt,
+		ID:        test.UserIDCorrect,
+		TenantID:  test.TenantIDCorrect,
+		PasswdPHC: u.passwdPHC,
 	})
 	require.NoError(u.T(), err)
 }
 
 func (u *userSecretSuite) TestCreateError() {
 	u.sqlMock.ExpectBegin()
-	u.sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `user_secrets` (`id`,`created_at`,`updated_at`,`deleted_at`,`passwd_hash`,`passwd_salt`,`refresh_token_hash`,`refresh_token_salt`) VALUES (?,?,?,?,?,?,?,?)")).
-		WithArgs(test.UserIDCorrect, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), u.passwdHash, u.passwdSalt, u.refreshTokenHash, u.refreshTokenSalt).
+	u.sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `user_secrets` (`id`,`created_at`,`updated_at`,`deleted_at`,`tenant_id`,`passwd_phc`,`passwd_changed_at`,`passwd_hash`,`passwd_salt`,`totp_secret`,`totp_enabled_at`,`totp_last_step`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?)")).
+		WithArgs(test.UserIDCorrect, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), test.TenantIDCorrect, u.passwdPHC, sqlmock.AnyArg(), []byte(nil), []byte(nil),
+			[]byte(nil), nil, 0).
 		WillReturnError(fmt.Errorf("error"))
 	u.sqlMock.ExpectRollback()
 
 	err := u.repo.Create(context.Background(), &entity.UserSecret{
-		ID:               test.UserIDCorrect,
-		PasswdHash:       u.passwdHash,
-		PasswdSalt:       u.passwdSalt,
-		RefreshTokenHash: u.refreshTokenHash,
-		RefreshTokenSalt: u.refreshTokenSalt,
+		ID:        test.UserIDCorrect,
+		TenantID:  test.TenantIDCorrect,
+		PasswdPHC: u.passwdPHC,
 	})
 	require.Error(u.T(), err)
 }
@@ -102,16 +95,13 @@ func (u *userSecretSuite) TestCreateError() {
 func (u *userSecretSuite) TestGetSuccess() {
 	u.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `user_secrets` WHERE id = ? AND `user_secrets`.`deleted_at` IS NULL ORDER BY `user_secrets`.`id` LIMIT 1")).
 		WithArgs(test.UserIDCorrect).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "passwd_hash", "passwd_salt", "refresh_token_hash", "refresh_token_salt"}).
-			AddRow(test.UserIDCorrect, u.passwdHash, u.passwdSalt, u.refreshTokenHash, u.refreshTokenSalt))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "passwd_phc"}).
+			AddRow(test.UserIDCorrect, u.passwdPHC))
 
 	userSecret, err := u.repo.Get(context.Background(), test.UserIDCorrect)
 	require.NoError(u.T(), err)
 	require.Equal(u.T(), test.UserIDCorrect, userSecret.ID)
-	require.Equal(u.T(), u.passwdHash, userSecret.PasswdHash)
-	require.Equal(u.T(), u.passwdSalt, userSecret.PasswdSalt)
-	require.Equal(u.T(), u.refreshTokenHash, userSecret.RefreshTokenHash)
-	require.Equal(u.T(), u.refreshTokenSalt, userSecret.RefreshTokenSalt)
+	require.Equal(u.T(), u.passwdPHC, userSecret.PasswdPHC)
 }
 
 func (u *userSecretSuite) TestGetError() {
@@ -125,69 +115,107 @@ func (u *userSecretSuite) TestGetError() {
 
 func (u *userSecretSuite) TestCreateAndGetWithTxSuccess() {
 	u.sqlMock.ExpectBegin()
-	u.sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `user_secrets` (`id`,`created_at`,`updated_at`,`deleted_at`,`passwd_hash`,`passwd_salt`,`refresh_token_hash`,`refresh_token_salt`) VALUES (?,?,?,?,?,?,?,?)")).
-		WithArgs(test.UserIDCorrect, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), u.passwdHash, u.passwdSalt, u.refreshTokenHash, u.refreshTokenSalt).
+	u.sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `user_secrets` (`id`,`created_at`,`updated_at`,`deleted_at`,`tenant_id`,`passwd_phc`,`passwd_changed_at`,`passwd_hash`,`passwd_salt`,`totp_secret`,`totp_enabled_at`,`totp_last_step`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?)")).
+		WithArgs(test.UserIDCorrect, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), test.TenantIDCorrect, u.passwdPHC, sqlmock.AnyArg(), []byte(nil), []byte(nil),
+			[]byte(nil), nil, 0).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	u.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `user_secrets` WHERE id = ? AND `user_secrets`.`deleted_at` IS NULL ORDER BY `user_secrets`.`id` LIMIT 1")).
 		WithArgs(test.UserIDCorrect).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "passwd_hash", "passwd_salt", "refresh_token_hash", "refresh_token_salt"}).
-			AddRow(test.UserIDCorrect, u.passwdHash, u.passwdSalt, u.refreshTokenHash, u.refreshTokenSalt))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "passwd_phc"}).
+			AddRow(test.UserIDCorrect, u.passwdPHC))
 	u.sqlMock.ExpectCommit()
 
 	tx, _ := u.tx.Begin()
 	err := u.repo.WithTx(tx).Create(context.Background(), &entity.UserSecret{
-		ID:               test.UserIDCorrect,
-		PasswdHash:       u.passwdHash,
-		PasswdSalt:       u.passwdSalt,
-		RefreshTokenHash: u.refreshTokenHash,
-		RefreshTokenSalt: u.refreshTokenSalt,
+		ID:        test.UserIDCorrect,
+		TenantID:  test.TenantIDCorrect,
+		PasswdPHC: u.passwdPHC,
 	})
 	require.NoError(u.T(), err)
 
 	userSecret, err := u.repo.WithTx(tx).Get(context.Background(), test.UserIDCorrect)
 	require.NoError(u.T(), err)
 	require.Equal(u.T(), test.UserIDCorrect, userSecret.ID)
-	require.Equal(u.T(), u.passwdHash, userSecret.PasswdHash)
-	require.Equal(u.T(), u.passwdSalt, userSecret.PasswdSalt)
-	require.Equal(u.T(), u.refreshTokenHash, userSecret.RefreshTokenHash)
-	require.Equal(u.T(), u.refreshTokenSalt, userSecret.RefreshTokenSalt)
+	require.Equal(u.T(), u.passwdPHC, userSecret.PasswdPHC)
 	tx.Commit()
 }
 
 func (u *userSecretSuite) TestUpdateSuccess() {
 	u.sqlMock.ExpectBegin()
-	u.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `user_secrets` SET `updated_at`=?,`passwd_hash`=?,`passwd_salt`=?,`refresh_token_hash`=?,`refresh_token_salt`=? WHERE `id` = ?")).
-		WithArgs(sqlmock.AnyArg(), u.passwdHash, u.passwdSalt, u.refreshTokenHash, u.refreshTokenSalt, test.UserIDCorrect).
+	u.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `user_secrets` SET `updated_at`=?,`passwd_phc`=?,`passwd_changed_at`=?,`passwd_hash`=?,`passwd_salt`=? WHERE `id` = ?")).
+		WithArgs(sqlmock.AnyArg(), u.passwdPHC, sqlmock.AnyArg(), []byte(nil), []byte(nil), test.UserIDCorrect).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	u.sqlMock.ExpectCommit()
 
 	err := u.repo.Update(context.Background(), &entity.UserSecret{
-		ID:               test.UserIDCorrect,
-		PasswdHash:       u.passwdHash,
-		PasswdSalt:       u.passwdSalt,
-		RefreshTokenHash: u.refreshTokenHash,
-		RefreshTokenSalt: u.refreshTokenSalt,
+		ID:        test.UserIDCorrect,
+		PasswdPHC: u.passwdPHC,
 	})
 	require.NoError(u.T(), err)
 }
 
 func (u *userSecretSuite) TestUpdateError() {
 	u.sqlMock.ExpectBegin()
-	u.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `user_secrets` SET `updated_at`=?,`passwd_hash`=?,`passwd_salt`=?,`refresh_token_hash`=?,`refresh_token_salt`=? WHERE `id` = ?")).
-		WithArgs(sqlmock.AnyArg(), u.passwdHash, u.passwdSalt, u.refreshTokenHash, u.refreshTokenSalt, test.UserIDCorrect).
+	u.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `user_secrets` SET `updated_at`=?,`passwd_phc`=?,`passwd_changed_at`=?,`passwd_hash`=?,`passwd_salt`=? WHERE `id` = ?")).
+		WithArgs(sqlmock.AnyArg(), u.passwdPHC, sqlmock.AnyArg(), []byte(nil), []byte(nil), test.UserIDCorrect).
 		WillReturnError(fmt.Errorf("error"))
 	u.sqlMock.ExpectRollback()
 
 	err := u.repo.Update(context.Background(), &entity.UserSecret{
-		ID:               test.UserIDCorrect,
-		PasswdHash:       u.passwdHash,
-		PasswdSalt:       u.passwdSalt,
-		RefreshTokenHash: u.refreshTokenHash,
-		RefreshTokenSalt: u.refreshTokenSalt,
+		ID:        test.UserIDCorrect,
+		PasswdPHC: u.passwdPHC,
 	})
 	require.Error(u.T(), err)
 }
 
+func (u *userSecretSuite) TestDeletePasswdSuccess() {
+	passwdChangedAt := time.Now()
+	u.sqlMock.ExpectBegin()
+	u.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `user_secrets` SET `passwd_changed_at`=?,`passwd_hash`=?,`passwd_phc`=?,`passwd_salt`=?,`updated_at`=? WHERE id = ? AND `user_secrets`.`deleted_at` IS NULL")).
+		WithArgs(passwdChangedAt, nil, "", nil, sqlmock.AnyArg(), test.UserIDCorrect).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	u.sqlMock.ExpectCommit()
+
+	err := u.repo.DeletePasswd(context.Background(), test.UserIDCorrect, passwdChangedAt)
+	require.NoError(u.T(), err)
+}
+
+func (u *userSecretSuite) TestUpdateTOTPSuccess() {
+	u.sqlMock.ExpectBegin()
+	u.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `user_secrets` SET `updated_at`=?,`totp_secret`=?,`totp_enabled_at`=?,`totp_last_step`=? WHERE `id` = ?")).
+		WithArgs(sqlmock.AnyArg(), test.MFATOTPSecretCorrect, nil, 0, test.UserIDCorrect).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	u.sqlMock.ExpectCommit()
+
+	err := u.repo.UpdateTOTP(context.Background(), &entity.UserSecret{
+		ID:         test.UserIDCorrect,
+		TOTPSecret: test.MFATOTPSecretCorrect,
+	})
+	require.NoError(u.T(), err)
+}
+
+func (u *userSecretSuite) TestUpdateTOTPLastStepSuccess() {
+	u.sqlMock.ExpectBegin()
+	u.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `user_secrets` SET `totp_last_step`=?,`updated_at`=? WHERE (id = ? AND totp_last_step < ?) AND `user_secrets`.`deleted_at` IS NULL")).
+		WithArgs(10, sqlmock.AnyArg(), test.UserIDCorrect, 10).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	u.sqlMock.ExpectCommit()
+
+	err := u.repo.UpdateTOTPLastStep(context.Background(), test.UserIDCorrect, 10)
+	require.NoError(u.T(), err)
+}
+
+func (u *userSecretSuite) TestUpdateTOTPLastStepUsed() {
+	u.sqlMock.ExpectBegin()
+	u.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `user_secrets` SET `totp_last_step`=?,`updated_at`=? WHERE (id = ? AND totp_last_step < ?) AND `user_secrets`.`deleted_at` IS NULL")).
+		WithArgs(10, sqlmock.AnyArg(), test.UserIDCorrect, 10).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	u.sqlMock.ExpectCommit()
+
+	err := u.repo.UpdateTOTPLastStep(context.Background(), test.UserIDCorrect, 10)
+	require.Equal(u.T(), ErrNotFound, err)
+}
+
 func (u *userSecretSuite) TestDeleteSuccess() {
 	u.sqlMock.ExpectBegin()
 	u.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `user_secrets` SET `deleted_at`=? WHERE id = ? AND `user_secrets`.`deleted_at` IS NULL")).
@@ -0,0 +1,65 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+// WebAuthn challenge repo
+type WebAuthnChallengeRepo interface {
+	WithTx(tx DBTx) WebAuthnChallengeRepo
+
+	Create(ctx context.Context, challenge *entity.WebAuthnChallenge) error
+	GetForUpdate(ctx context.Context, challengeUUID uuid.EntityUUID) (*entity.WebAuthnChallenge, error)
+	Delete(ctx context.Context, challengeUUID uuid.EntityUUID) error
+}
+
+type WebAuthnChallengeRepoImp struct {
+	db *gorm.DB
+}
+
+func NewWebAuthnChallengeRepoImp(repoDB *gorm.DB) *WebAuthnChallengeRepoImp {
+	return &WebAuthnChallengeRepoImp{
+		db: repoDB,
+	}
+}
+
+func (w *WebAuthnChallengeRepoImp) WithTx(tx DBTx) WebAuthnChallengeRepo {
+	transaction := tx.GetTx()
+	return NewWebAuthnChallengeRepoImp(transaction)
+}
+
+func (w *WebAuthnChallengeRepoImp) Create(ctx context.Context, challenge *entity.WebAuthnChallenge) error {
+	result := w.db.Create(challenge)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to create WebAuthn challenge in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
+
+// Lock the challenge in the transaction not to use the challenge concurrently
+func (w *WebAuthnChallengeRepoImp) GetForUpdate(ctx context.Context, challengeUUID uuid.EntityUUID) (*entity.WebAuthnChallenge, error) {
+	challenge := entity.WebAuthnChallenge{}
+	result := w.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&challenge, "id = ?", challengeUUID)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to get WebAuthn challenge for update from DB")
+		return nil, getReturnErr(result.Error)
+	}
+	return &challenge, nil
+}
+
+func (w *WebAuthnChallengeRepoImp) Delete(ctx context.Context, challengeUUID uuid.EntityUUID) error {
+	result := w.db.Delete(&entity.WebAuthnChallenge{}, "id = ?", challengeUUID)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to delete WebAuthn challenge in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
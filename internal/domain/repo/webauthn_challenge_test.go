@@ -0,0 +1,104 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/test"
+)
+
+func TestWebAuthnChallenge(t *testing.T) {
+	suite.Run(t, new(webAuthnChallengeSuite))
+}
+
+type webAuthnChallengeSuite struct {
+	suite.Suite
+	sqlMock sqlmock.Sqlmock
+
+	repo WebAuthnChallengeRepo
+}
+
+func (w *webAuthnChallengeSuite) SetupTest() {
+	var err error
+	var db *sql.DB
+
+	// Init sqlMock
+	db, w.sqlMock, err = sqlmock.New()
+	require.NoError(w.T(), err)
+
+	// Init DB
+	primaryMySQL, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}))
+	require.NoError(w.T(), err)
+
+	// Init repo
+	w.repo = NewWebAuthnChallengeRepoImp(primaryMySQL)
+}
+
+func (w *webAuthnChallengeSuite) AfterTest(_, _ string) {
+	require.NoError(w.T(), w.sqlMock.ExpectationsWereMet())
+}
+
+func (w *webAuthnChallengeSuite) TestCreateSuccess() {
+	expiresAt := time.Now().Add(time.Minute)
+	w.sqlMock.ExpectBegin()
+	w.sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `web_authn_challenges` (`id`,`created_at`,`type`,`tenant_id`,`user_id`,`challenge`,`expires_at`) VALUES (?,?,?,?,?,?,?)")).
+		WithArgs(test.PasskeyChallengeIDCorrect, sqlmock.AnyArg(), entity.WebAuthnChallengeTypeRegistration, test.TenantIDCorrect,
+			test.UserIDCorrect, test.PasskeyChallengeCorrect, expiresAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	w.sqlMock.ExpectCommit()
+
+	err := w.repo.Create(context.Background(), &entity.WebAuthnChallenge{
+		ID:        test.PasskeyChallengeIDCorrect,
+		Type:      entity.WebAuthnChallengeTypeRegistration,
+		TenantID:  test.TenantIDCorrect,
+		UserID:    test.UserIDCorrect,
+		Challenge: test.PasskeyChallengeCorrect,
+		ExpiresAt: expiresAt,
+	})
+	require.NoError(w.T(), err)
+}
+
+func (w *webAuthnChallengeSuite) TestGetForUpdateSuccess() {
+	w.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `web_authn_challenges` WHERE id = ? ORDER BY `web_authn_challenges`.`id` LIMIT 1 FOR UPDATE")).
+		WithArgs(test.PasskeyChallengeIDCorrect).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "type", "tenant_id", "challenge"}).
+			AddRow(test.PasskeyChallengeIDCorrect, entity.WebAuthnChallengeTypeLogin, test.TenantIDCorrect, test.PasskeyChallengeCorrect))
+
+	challenge, err := w.repo.GetForUpdate(context.Background(), test.PasskeyChallengeIDCorrect)
+	require.NoError(w.T(), err)
+	require.Equal(w.T(), entity.WebAuthnChallengeTypeLogin, challenge.Type)
+	require.Equal(w.T(), test.PasskeyChallengeCorrect, challenge.Challenge)
+}
+
+func (w *webAuthnChallengeSuite) TestGetForUpdateNotFound() {
+	w.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `web_authn_challenges` WHERE id = ? ORDER BY `web_authn_challenges`.`id` LIMIT 1 FOR UPDATE")).
+		WithArgs(test.PasskeyChallengeIDCorrect).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	_, err := w.repo.GetForUpdate(context.Background(), test.PasskeyChallengeIDCorrect)
+	require.Equal(w.T(), ErrNotFound, err)
+}
+
+func (w *webAuthnChallengeSuite) TestDeleteSuccess() {
+	w.sqlMock.ExpectBegin()
+	w.sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `web_authn_challenges` WHERE id = ?")).
+		WithArgs(test.PasskeyChallengeIDCorrect).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	w.sqlMock.ExpectCommit()
+
+	err := w.repo.Delete(context.Background(), test.PasskeyChallengeIDCorrect)
+	require.NoError(w.T(), err)
+}
@@ -0,0 +1,123 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+// WebAuthn credential repo
+type WebAuthnCredentialRepo interface {
+	WithTx(tx DBTx) WebAuthnCredentialRepo
+
+	ListByUser(ctx context.Context, userUUID uuid.EntityUUID) ([]entity.WebAuthnCredential, error)
+	CountByUser(ctx context.Context, userUUID uuid.EntityUUID) (int, error)
+	Create(ctx context.Context, credential *entity.WebAuthnCredential) error
+	Get(ctx context.Context, userUUID, credentialUUID uuid.EntityUUID) (*entity.WebAuthnCredential, error)
+	GetByCredentialID(ctx context.Context, credentialID []byte) (*entity.WebAuthnCredential, error)
+	UpdateSignCount(ctx context.Context, credentialUUID uuid.EntityUUID, signCount uint32, lastUsedAt time.Time) error
+	Delete(ctx context.Context, userUUID, credentialUUID uuid.EntityUUID) error
+	DeleteByUser(ctx context.Context, userUUID uuid.EntityUUID) error
+}
+
+type WebAuthnCredentialRepoImp struct {
+	db *gorm.DB
+}
+
+func NewWebAuthnCredentialRepoImp(repoDB *gorm.DB) *WebAuthnCredentialRepoImp {
+	return &WebAuthnCredentialRepoImp{
+		db: repoDB,
+	}
+}
+
+func (w *WebAuthnCredentialRepoImp) WithTx(tx DBTx) WebAuthnCredentialRepo {
+	transaction := tx.GetTx()
+	return NewWebAuthnCredentialRepoImp(transaction)
+}
+
+func (w *WebAuthnCredentialRepoImp) ListByUser(ctx context.Context, userUUID uuid.EntityUUID) ([]entity.WebAuthnCredential, error) {
+	credentials := []entity.WebAuthnCredential{}
+	result := w.db.Where("user_id = ?", userUUID).Order("created_at").Find(&credentials)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to list WebAuthn credentials from DB")
+		return nil, getReturnErr(result.Error)
+	}
+	return credentials, nil
+}
+
+func (w *WebAuthnCredentialRepoImp) CountByUser(ctx context.Context, userUUID uuid.EntityUUID) (int, error) {
+	var count int64
+	result := w.db.Model(&entity.WebAuthnCredential{}).Where("user_id = ?", userUUID).Count(&count)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to count WebAuthn credentials from DB")
+		return 0, getReturnErr(result.Error)
+	}
+	return int(count), nil
+}
+
+func (w *WebAuthnCredentialRepoImp) Create(ctx context.Context, credential *entity.WebAuthnCredential) error {
+	result := w.db.Create(credential)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to create WebAuthn credential in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
+
+// Get the credential of the user
+func (w *WebAuthnCredentialRepoImp) Get(ctx context.Context, userUUID, credentialUUID uuid.EntityUUID) (*entity.WebAuthnCredential, error) {
+	credential := entity.WebAuthnCredential{}
+	result := w.db.First(&credential, "user_id = ? AND id = ?", userUUID, credentialUUID)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to get WebAuthn credential from DB")
+		return nil, getReturnErr(result.Error)
+	}
+	return &credential, nil
+}
+
+func (w *WebAuthnCredentialRepoImp) GetByCredentialID(ctx context.Context, credentialID []byte) (*entity.WebAuthnCredential, error) {
+	credential := entity.WebAuthnCredential{}
+	result := w.db.First(&credential, "credential_id = ?", credentialID)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to get WebAuthn credential by credential ID from DB")
+		return nil, getReturnErr(result.Error)
+	}
+	return &credential, nil
+}
+
+func (w *WebAuthnCredentialRepoImp) UpdateSignCount(ctx context.Context, credentialUUID uuid.EntityUUID, signCount uint32,
+	lastUsedAt time.Time) error {
+	result := w.db.Model(&entity.WebAuthnCredential{}).Where("id = ?", credentialUUID).
+		Updates(map[string]interface{}{"sign_count": signCount, "last_used_at": lastUsedAt})
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to update WebAuthn credential's sign count in DB")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
+
+// Delete the credential of the user
+func (w *WebAuthnCredentialRepoImp) Delete(ctx context.Context, userUUID, credentialUUID uuid.EntityUUID) error {
+	result := w.db.Delete(&entity.WebAuthnCredential{}, "user_id = ? AND id = ?", userUUID, credentialUUID)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to delete WebAuthn credential in DB")
+		return getReturnErr(result.Error)
+	} else if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (w *WebAuthnCredentialRepoImp) DeleteByUser(ctx context.Context, userUUID uuid.EntityUUID) error {
+	result := w.db.Delete(&entity.WebAuthnCredential{}, "user_id = ?", userUUID)
+	if result.Error != nil {
+		log.Ctx(ctx).Error().Err(result.Error).Msg("Failed to delete WebAuthn credentials in DB by user")
+		return getReturnErr(result.Error)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/test"
+)
+
+func TestWebAuthnCredential(t *testing.T) {
+	suite.Run(t, new(webAuthnCredentialSuite))
+}
+
+type webAuthnCredentialSuite struct {
+	suite.Suite
+	sqlMock sqlmock.Sqlmock
+
+	repo WebAuthnCredentialRepo
+}
+
+func (w *webAuthnCredentialSuite) SetupTest() {
+	var err error
+	var db *sql.DB
+
+	// Init sqlMock
+	db, w.sqlMock, err = sqlmock.New()
+	require.NoError(w.T(), err)
+
+	// Init DB
+	primaryMySQL, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}))
+	require.NoError(w.T(), err)
+
+	// Init repo
+	w.repo = NewWebAuthnCredentialRepoImp(primaryMySQL)
+}
+
+func (w *webAuthnCredentialSuite) AfterTest(_, _ string) {
+	require.NoError(w.T(), w.sqlMock.ExpectationsWereMet())
+}
+
+func (w *webAuthnCredentialSuite) TestListByUserSuccess() {
+	w.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `web_authn_credentials` WHERE user_id = ? ORDER BY created_at")).
+		WithArgs(test.UserIDCorrect).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "credential_id", "transports"}).
+			AddRow(test.PasskeyIDCorrect, test.UserIDCorrect, test.PasskeyNameCorrect, test.PasskeyCredentialIDCorrect,
+				`["`+test.PasskeyTransportCorrect+`"]`))
+
+	credentials, err := w.repo.ListByUser(context.Background(), test.UserIDCorrect)
+	require.NoError(w.T(), err)
+	require.Len(w.T(), credentials, 1)
+	require.Equal(w.T(), test.PasskeyIDCorrect, credentials[0].ID)
+	require.Equal(w.T(), test.PasskeyCredentialIDCorrect, credentials[0].CredentialID)
+	require.Equal(w.T(), entity.StrList{test.PasskeyTransportCorrect}, credentials[0].Transports)
+}
+
+func (w *webAuthnCredentialSuite) TestCountByUserSuccess() {
+	w.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `web_authn_credentials` WHERE user_id = ?")).
+		WithArgs(test.UserIDCorrect).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(2))
+
+	count, err := w.repo.CountByUser(context.Background(), test.UserIDCorrect)
+	require.NoError(w.T(), err)
+	require.Equal(w.T(), 2, count)
+}
+
+func (w *webAuthnCredentialSuite) TestCreateSuccess() {
+	w.sqlMock.ExpectBegin()
+	w.sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `web_authn_credentials` (`id`,`created_at`,`updated_at`,`user_id`,`name`,`credential_id`,`public_key`,`sign_count`,`transports`,`last_used_at`) VALUES (?,?,?,?,?,?,?,?,?,?)")).
+		WithArgs(test.PasskeyIDCorrect, sqlmock.AnyArg(), sqlmock.AnyArg(), test.UserIDCorrect, test.PasskeyNameCorrect,
+			test.PasskeyCredentialIDCorrect, []byte("public-key"), 0, `["`+test.PasskeyTransportCorrect+`"]`, nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	w.sqlMock.ExpectCommit()
+
+	err := w.repo.Create(context.Background(), &entity.WebAuthnCredential{
+		ID:           test.PasskeyIDCorrect,
+		UserID:       test.UserIDCorrect,
+		Name:         test.PasskeyNameCorrect,
+		CredentialID: test.PasskeyCredentialIDCorrect,
+		PublicKey:    []byte("public-key"),
+		Transports:   entity.StrList{test.PasskeyTransportCorrect},
+	})
+	require.NoError(w.T(), err)
+}
+
+func (w *webAuthnCredentialSuite) TestGetByCredentialIDSuccess() {
+	w.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `web_authn_credentials` WHERE credential_id = ? ORDER BY `web_authn_credentials`.`id` LIMIT 1")).
+		WithArgs(test.PasskeyCredentialIDCorrect).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "credential_id", "sign_count"}).
+			AddRow(test.PasskeyIDCorrect, test.UserIDCorrect, test.PasskeyCredentialIDCorrect, 3))
+
+	credential, err := w.repo.GetByCredentialID(context.Background(), test.PasskeyCredentialIDCorrect)
+	require.NoError(w.T(), err)
+	require.Equal(w.T(), test.PasskeyIDCorrect, credential.ID)
+	require.Equal(w.T(), test.UserIDCorrect, credential.UserID)
+	require.Equal(w.T(), uint32(3), credential.SignCount)
+}
+
+func (w *webAuthnCredentialSuite) TestGetByCredentialIDNotFound() {
+	w.sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `web_authn_credentials` WHERE credential_id = ? ORDER BY `web_authn_credentials`.`id` LIMIT 1")).
+		WithArgs(test.PasskeyCredentialIDCorrect).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	_, err := w.repo.GetByCredentialID(context.Background(), test.PasskeyCredentialIDCorrect)
+	require.Equal(w.T(), ErrNotFound, err)
+}
+
+func (w *webAuthnCredentialSuite) TestUpdateSignCountSuccess() {
+	w.sqlMock.ExpectBegin()
+	w.sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `web_authn_credentials` SET `last_used_at`=?,`sign_count`=?,`updated_at`=? WHERE id = ?")).
+		WithArgs(sqlmock.AnyArg(), 4, sqlmock.AnyArg(), test.PasskeyIDCorrect).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	w.sqlMock.ExpectCommit()
+
+	err := w.repo.UpdateSignCount(context.Background(), test.PasskeyIDCorrect, 4, time.Now())
+	require.NoError(w.T(), err)
+}
+
+func (w *webAuthnCredentialSuite) TestDeleteSuccess() {
+	w.sqlMock.ExpectBegin()
+	w.sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `web_authn_credentials` WHERE user_id = ? AND id = ?")).
+		WithArgs(test.UserIDCorrect, test.PasskeyIDCorrect).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	w.sqlMock.ExpectCommit()
+
+	err := w.repo.Delete(context.Background(), test.UserIDCorrect, test.PasskeyIDCorrect)
+	require.NoError(w.T(), err)
+}
+
+func (w *webAuthnCredentialSuite) TestDeleteNotFound() {
+	w.sqlMock.ExpectBegin()
+	w.sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `web_authn_credentials` WHERE user_id = ? AND id = ?")).
+		WithArgs(test.UserIDCorrect, test.PasskeyIDCorrect).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	w.sqlMock.ExpectCommit()
+
+	err := w.repo.Delete(context.Background(), test.UserIDCorrect, test.PasskeyIDCorrect)
+	require.Equal(w.T(), ErrNotFound, err)
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/repo"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+// Attribute based access checks of resources. RBAC decides which operations a subject can call,
+// and these checks decide which resources the subject can access by the operations. Users are
+// also scoped to the subject's tenant by the user info repo, so no one can access users of other tenants.
+
+// Check the subject can access the user. A user can access only itself, and admins and tenant admins can
+// access all users of the tenant. An OAuth2 client is allowed only by its scopes, so it can access all users.
+func checkUserAccess(ctx context.Context, subject *entity.Subject, userUUID uuid.EntityUUID) error {
+	if subject.IsClient() || subject.IsAdmin() || subject.IsTenantAdmin() || subject.UserID == userUUID.String() {
+		return nil
+	}
+	log.Ctx(ctx).Error().Str("subject_user_id", subject.UserID).Msg("Subject isn't allowed to access the user")
+	return ErrUnauthorized
+}
+
+// Check the subject can access the tenant. Only clients and admins can access other tenants.
+func checkTenantAccess(ctx context.Context, subject *entity.Subject, tenantID string) error {
+	if subject.IsClient() || subject.IsAdmin() || subject.TenantID == tenantID {
+		return nil
+	}
+	log.Ctx(ctx).Error().Str("subject_tenant_id", subject.TenantID).Msg("Subject isn't allowed to access the tenant")
+	return ErrUnauthorized
+}
+
+// Check the subject can change the user. Only admins can change admins, including users who are admins by
+// their groups.
+func checkUserChange(ctx context.Context, groupRepo repo.GroupRepo, groupMemberRepo repo.GroupMemberRepo, subject *entity.Subject,
+	userInfo *entity.UserInfo) error {
+	if subject.IsClient() || subject.IsAdmin() {
+		return nil
+	}
+	roles, err := getUserRoles(ctx, groupRepo, groupMemberRepo, userInfo)
+	if err != nil {
+		return getReturnErr(err)
+	}
+	for _, role := range roles {
+		if role == entity.UserRoleAdmin {
+			log.Ctx(ctx).Error().Str("subject_user_id", subject.UserID).Msg("Subject isn't allowed to change the admin")
+			return ErrUnauthorized
+		}
+	}
+	return nil
+}
+
+// Check the subject can grant the roles to a user or a group. Users can't grant roles, and tenant admins can grant
+// only roles whose permissions are covered by the permissions of the tenant admin's roles. So tenant admins can't
+// escalate by granting the admin role or custom roles having wider permissions.
+func checkRoleGrant(ctx context.Context, permissionRepo repo.PermissionRepo, subject *entity.Subject, roles ...entity.UserRole) error {
+	if len(roles) == 0 || subject.IsClient() || subject.IsAdmin() {
+		return nil
+	}
+	if !subject.IsTenantAdmin() {
+		log.Ctx(ctx).Error().Str("subject_user_id", subject.UserID).Msg("Subject isn't allowed to grant roles")
+		return ErrUnauthorized
+	}
+
+	permissions, err := permissionRepo.ListAll(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to list permissions from DB")
+		return getReturnErr(err)
+	}
+	for _, role := range roles {
+		if role == entity.UserRoleAdmin || !isRoleCovered(permissions, role, subject.UserRoles) {
+			log.Ctx(ctx).Error().Str("subject_user_id", subject.UserID).Str("role", string(role)).Msg("Subject isn't allowed to grant the role")
+			return ErrUnauthorized
+		}
+	}
+	return nil
+}
+
+// Check the subject can change the groups. Changing a group or its members changes the roles granted by
+// the group and its parent groups, so the subject must be able to grant all of their roles.
+func checkGroupChange(ctx context.Context, permissionRepo repo.PermissionRepo, subject *entity.Subject, groups []entity.Group) error {
+	return checkRoleGrant(ctx, permissionRepo, subject, getGroupRoles(groups)...)
+}
+
+// Permission operands like "^(list|get)$" are alternations of operation names, and they are covered if every
+// name is matched. Other regular expressions are covered only by the same expression or a match-all expression.
+var permissionOperandNamesRegex = regexp.MustCompile(`^\^\(?([a-z0-9.]+(\|[a-z0-9.]+)*)\)?\$$`)
+
+// Operand of a permission. Name is an operation name of the operand, or empty if the operand isn't an alternation of names.
+type permissionOperand struct {
+	expr string
+	name string
+}
+
+// Check all permissions of the role are covered by the permissions of the granter's roles
+func isRoleCovered(permissions []entity.Permission, role entity.UserRole, granterRoles []entity.UserRole) bool {
+	granterPermissions := []entity.Permission{}
+	for _, permission := range permissions {
+		for _, granterRole := range granterRoles {
+			if permission.Subject == string(granterRole) {
+				granterPermissions = append(granterPermissions, permission)
+				break
+			}
+		}
+	}
+
+	for _, permission := range permissions {
+		if permission.Subject != string(role) {
+			continue
+		}
+		for _, object := range getPermissionOperands(permission.Object) {
+			for _, action := range getPermissionOperands(permission.Action) {
+				if !isOperationCovered(granterPermissions, object, action) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+func getPermissionOperands(expr string) []permissionOperand {
+	matches := permissionOperandNamesRegex.FindStringSubmatch(expr)
+	if matches == nil {
+		return []permissionOperand{{expr: expr}}
+	}
+	operands := []permissionOperand{}
+	for _, name := range strings.Split(matches[1], "|") {
+		operands = append(operands, permissionOperand{expr: expr, name: name})
+	}
+	return operands
+}
+
+func isOperationCovered(granterPermissions []entity.Permission, object, action permissionOperand) bool {
+	for _, permission := range granterPermissions {
+		if isPermissionOperandCovered(permission.Object, object) && isPermissionOperandCovered(permission.Action, action) {
+			return true
+		}
+	}
+	return false
+}
+
+// Check the granter's operand covers the operand. Names are matched like the regexMatch of Casbin.
+func isPermissionOperandCovered(granterExpr string, operand permissionOperand) bool {
+	if granterExpr == operand.expr || granterExpr == ".*" || granterExpr == "^.*$" {
+		return true
+	}
+	if operand.name == "" {
+		return false
+	}
+	matched, err := regexp.MatchString(granterExpr, operand.name)
+	return err == nil && matched
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/test"
+)
+
+func TestIsRoleCovered(t *testing.T) {
+	tenantAdminRoles := []entity.UserRole{entity.UserRoleTenantAdmin}
+	require.True(t, isRoleCovered(test.PermissionsCorrect, entity.UserRole(test.RoleNameCorrect), tenantAdminRoles))
+	require.True(t, isRoleCovered(test.PermissionsCorrect, entity.UserRoleTenantAdmin, tenantAdminRoles))
+	require.False(t, isRoleCovered(test.PermissionsCorrect, entity.UserRole(test.RoleNameWideCorrect), tenantAdminRoles))
+
+	// Names of an alternation must be all covered
+	permissions := append([]entity.Permission{
+		{Subject: test.RoleNameCorrect, Object: "^token$", Action: "^(logout|revokeuser)$"},
+	}, test.PermissionsCorrect...)
+	require.False(t, isRoleCovered(permissions, entity.UserRole(test.RoleNameCorrect), tenantAdminRoles))
+
+	// Roles without permissions are covered
+	require.True(t, isRoleCovered(test.PermissionsCorrect, entity.UserRoleUser, tenantAdminRoles))
+}
+
+func TestIsPermissionOperandCovered(t *testing.T) {
+	// Names are matched by the regex of the granter like Casbin, so unanchored regexes match longer names
+	require.True(t, isPermissionOperandCovered("^(list|get)$", permissionOperand{expr: "^get$", name: "get"}))
+	require.True(t, isPermissionOperandCovered("user", permissionOperand{expr: "^userme$", name: "userme"}))
+	require.False(t, isPermissionOperandCovered("^user$", permissionOperand{expr: "^userme$", name: "userme"}))
+
+	// Other regexes are covered only by the same regex or a match-all regex
+	require.True(t, isPermissionOperandCovered("^user.*$", permissionOperand{expr: "^user.*$"}))
+	require.True(t, isPermissionOperandCovered(".*", permissionOperand{expr: "^user.*$"}))
+	require.False(t, isPermissionOperandCovered("^user", permissionOperand{expr: "^user.*$"}))
+}
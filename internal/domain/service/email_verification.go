@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/repo"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+	"github.com/ssup2ket/service-auth/pkg/mail"
+)
+
+const (
+	emailVerificationTokenLen = 32
+	emailVerificationSubject  = "Verify your email"
+)
+
+// Email verification policy. Users who didn't verify their emails can't login if verification is required.
+// The token is appended to the URL as the token query parameter if the URL is set, so users can open the link.
+type EmailVerificationPolicy struct {
+	Required bool
+	Lifetime time.Duration
+	URL      string
+}
+
+// Email verification service. A one-time token is sent to the email of the subject user, and only the hash of the token
+// is stored. Sending a new token invalidates the previous tokens of the user, and a token can't verify the email
+// if the user changed the email after the token was sent.
+type EmailVerificationService interface {
+	SendEmailVerification(ctx context.Context, subject *entity.Subject) error
+	VerifyEmail(ctx context.Context, tenantID, token string) error
+}
+
+type EmailVerificationServiceImp struct {
+	repoDBTx repo.DBTx
+
+	userInfoRepoPrimary          repo.UserInfoRepo
+	emailVerificationRepoPrimary repo.EmailVerificationRepo
+
+	mailSender mail.Sender
+	policy     *EmailVerificationPolicy
+}
+
+func NewEmailVerificationServiceImp(dbTx repo.DBTx, userInfoPrimary repo.UserInfoRepo, emailVerificationPrimary repo.EmailVerificationRepo,
+	mailSender mail.Sender, policy *EmailVerificationPolicy) *EmailVerificationServiceImp {
+	return &EmailVerificationServiceImp{
+		repoDBTx: dbTx,
+
+		userInfoRepoPrimary:          userInfoPrimary,
+		emailVerificationRepoPrimary: emailVerificationPrimary,
+
+		mailSender: mailSender,
+		policy:     policy,
+	}
+}
+
+// Send a verification token to the email of the subject user. ErrEmailAlreadyVerified is returned if the email is verified.
+func (e *EmailVerificationServiceImp) SendEmailVerification(ctx context.Context, subject *entity.Subject) error {
+	if subject.IsClient() {
+		log.Ctx(ctx).Error().Msg("Client doesn't have email")
+		return ErrUnauthorized
+	}
+
+	// Get user info
+	userInfo, err := e.userInfoRepoPrimary.Get(ctx, subject.TenantID, uuid.FromStringOrNil(subject.UserID))
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get user info from DB")
+		return getReturnErr(err)
+	}
+	if userInfo.EmailVerified {
+		log.Ctx(ctx).Error().Msg("Email is already verified")
+		return ErrEmailAlreadyVerified
+	}
+
+	return sendEmailVerification(ctx, e.emailVerificationRepoPrimary, e.mailSender, e.policy, userInfo)
+}
+
+// Verify the email of the token's user in the tenant. Empty tenant means the default tenant. The token is used up even if
+// it's expired, and ErrUnauthorized is returned for wrong, expired or used tokens.
+func (e *EmailVerificationServiceImp) VerifyEmail(ctx context.Context, tenantID, token string) error {
+	tenantID = entity.GetTenantIDOrDefault(tenantID)
+
+	// Use token
+	emailVerification, err := e.useEmailVerification(ctx, getEmailVerificationHash(token))
+	if err != nil {
+		if err == repo.ErrNotFound {
+			log.Ctx(ctx).Error().Msg("Email verification token doesn't exist")
+			return ErrUnauthorized
+		}
+		return getReturnErr(err)
+	}
+	if emailVerification.TenantID != tenantID {
+		log.Ctx(ctx).Error().Str("tenant_id", tenantID).Msg("Email verification token is for another tenant")
+		return ErrUnauthorized
+	}
+	now := time.Now()
+	if now.After(emailVerification.ExpiresAt) {
+		log.Ctx(ctx).Error().Msg("Email verification token is expired")
+		return ErrUnauthorized
+	}
+
+	// Check the email isn't changed after the token was sent
+	userInfo, err := e.userInfoRepoPrimary.Get(ctx, tenantID, emailVerification.UserID)
+	if err != nil {
+		if err == repo.ErrNotFound {
+			log.Ctx(ctx).Error().Msg("User of email verification token doesn't exist")
+			return ErrUnauthorized
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get user info from DB")
+		return getReturnErr(err)
+	}
+	if userInfo.Email != emailVerification.Email {
+		log.Ctx(ctx).Error().Msg("Email is changed after email verification token was sent")
+		return ErrUnauthorized
+	}
+
+	// Verify email
+	if err = e.userInfoRepoPrimary.UpdateEmailVerified(ctx, tenantID, userInfo.ID, &now); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to update email verified to DB")
+		return getReturnErr(err)
+	}
+	return nil
+}
+
+// Get and delete the email verification in a transaction, so a token is used only once
+func (e *EmailVerificationServiceImp) useEmailVerification(ctx context.Context, tokenHash string) (*entity.EmailVerification, error) {
+	var err error
+
+	// Begin transaction
+	tx, _ := e.repoDBTx.Begin()
+	defer func() {
+		if err != nil {
+			if err = tx.Rollback(); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Rollback transaction error for using email verification")
+				return
+			}
+			log.Ctx(ctx).Error().Err(err).Msg("Using email verification is canceled")
+			return
+		}
+	}()
+
+	emailVerification, err := e.emailVerificationRepoPrimary.WithTx(tx).GetForUpdate(ctx, tokenHash)
+	if err != nil {
+		return nil, err
+	}
+	if err = e.emailVerificationRepoPrimary.WithTx(tx).Delete(ctx, tokenHash); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete email verification")
+		return nil, err
+	}
+
+	// Commit transaction
+	if err = tx.Commit(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Commit transaction error for using email verification")
+		return nil, err
+	}
+	return emailVerification, nil
+}
+
+// Send a new verification token to the email of the user after deleting the previous tokens of the user
+func sendEmailVerification(ctx context.Context, emailVerificationRepo repo.EmailVerificationRepo, mailSender mail.Sender,
+	policy *EmailVerificationPolicy, userInfo *entity.UserInfo) error {
+	// Create token
+	tokenBytes := make([]byte, emailVerificationTokenLen)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to create email verification token")
+		return ErrServerErr
+	}
+	token := base64.RawURLEncoding.EncodeToString(tokenBytes)
+
+	// Replace the previous tokens of the user
+	if err := emailVerificationRepo.DeleteByUser(ctx, userInfo.ID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete email verifications of user from DB")
+		return getReturnErr(err)
+	}
+	now := time.Now()
+	emailVerification := entity.EmailVerification{
+		ID:        getEmailVerificationHash(token),
+		UserID:    userInfo.ID,
+		TenantID:  userInfo.TenantID,
+		Email:     userInfo.Email,
+		ExpiresAt: now.Add(policy.Lifetime),
+	}
+	if err := emailVerificationRepo.Create(ctx, &emailVerification); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to create email verification to DB")
+		return getReturnErr(err)
+	}
+
+	// Clean up expired tokens. Failure doesn't affect the new token.
+	if err := emailVerificationRepo.DeleteExpired(ctx, now); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("Failed to delete expired email verifications")
+	}
+
+	// Send mail
+	body, err := getEmailVerificationBody(policy, token)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get email verification mail body")
+		return ErrServerErr
+	}
+	if err := mailSender.Send(ctx, &mail.Message{To: userInfo.Email, Subject: emailVerificationSubject, Body: body}); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to send email verification mail")
+		return ErrServerErr
+	}
+	return nil
+}
+
+func getEmailVerificationBody(policy *EmailVerificationPolicy, token string) (string, error) {
+	if policy.URL == "" {
+		return fmt.Sprintf("Please verify your email with the following token. It expires in %s.\n\n%s\n", policy.Lifetime, token), nil
+	}
+	verifyURL, err := url.Parse(policy.URL)
+	if err != nil {
+		return "", err
+	}
+	query := verifyURL.Query()
+	query.Set("token", token)
+	verifyURL.RawQuery = query.Encode()
+	return fmt.Sprintf("Please verify your email by opening the following link. It expires in %s.\n\n%s\n", policy.Lifetime, verifyURL), nil
+}
+
+// Get the hash of an email verification token. Tokens are stored as hashes not to be used if the DB is leaked.
+func getEmailVerificationHash(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
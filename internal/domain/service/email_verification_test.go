@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/repo"
+	"github.com/ssup2ket/service-auth/internal/domain/repo/mocks"
+	"github.com/ssup2ket/service-auth/internal/test"
+	"github.com/ssup2ket/service-auth/pkg/mail"
+	mailmocks "github.com/ssup2ket/service-auth/pkg/mail/mocks"
+)
+
+func TestEmailVerification(t *testing.T) {
+	suite.Run(t, new(emailVerificationSuite))
+}
+
+type emailVerificationSuite struct {
+	suite.Suite
+
+	dbTx                  mocks.DBTx
+	userInfoRepo          mocks.UserInfoRepo
+	emailVerificationRepo mocks.EmailVerificationRepo
+	mailSender            mailmocks.Sender
+
+	userInfo *entity.UserInfo
+
+	emailVerificationService EmailVerificationService
+}
+
+func (e *emailVerificationSuite) SetupTest() {
+	// Init transaction, repo, mail sender
+	e.dbTx = mocks.DBTx{}
+	e.userInfoRepo = mocks.UserInfoRepo{}
+	e.emailVerificationRepo = mocks.EmailVerificationRepo{}
+	e.mailSender = mailmocks.Sender{}
+
+	e.userInfo = &entity.UserInfo{
+		ID:       test.UserIDCorrect,
+		TenantID: test.TenantIDCorrect,
+		LoginID:  test.UserLoginIDCorrect,
+		Email:    test.UserEmailCorrect,
+	}
+
+	// Init service
+	e.emailVerificationService = NewEmailVerificationServiceImp(&e.dbTx, &e.userInfoRepo, &e.emailVerificationRepo, &e.mailSender,
+		&EmailVerificationPolicy{Lifetime: time.Hour, URL: test.EmailVerificationURLCorrect})
+}
+
+// Mock using the token stored for the email
+func (e *emailVerificationSuite) mockUseEmailVerification(emailVerification *entity.EmailVerification) {
+	e.dbTx.On("Begin").Return(&e.dbTx, nil)
+	e.dbTx.On("Commit").Return(nil)
+	e.emailVerificationRepo.On("WithTx", mock.Anything).Return(&e.emailVerificationRepo)
+	e.emailVerificationRepo.On("GetForUpdate", context.Background(), test.EmailVerificationTokenHashCorrect).Return(emailVerification, nil)
+	e.emailVerificationRepo.On("Delete", context.Background(), test.EmailVerificationTokenHashCorrect).Return(nil)
+}
+
+func (e *emailVerificationSuite) TestSendEmailVerificationSuccess() {
+	var createdEmailVerification *entity.EmailVerification
+	var sentMsg *mail.Message
+	e.userInfoRepo.On("Get", context.Background(), test.TenantIDCorrect, test.UserIDCorrect).Return(e.userInfo, nil)
+	e.emailVerificationRepo.On("DeleteByUser", context.Background(), test.UserIDCorrect).Return(nil)
+	e.emailVerificationRepo.On("Create", context.Background(), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		createdEmailVerification = args.Get(1).(*entity.EmailVerification)
+	})
+	e.emailVerificationRepo.On("DeleteExpired", context.Background(), mock.Anything).Return(nil)
+	e.mailSender.On("Send", context.Background(), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		sentMsg = args.Get(1).(*mail.Message)
+	})
+
+	err := e.emailVerificationService.SendEmailVerification(context.Background(), &test.SubjectUserCorrect)
+	require.NoError(e.T(), err)
+	require.Equal(e.T(), test.UserEmailCorrect, sentMsg.To)
+
+	// The mail has the link with the token, and only the token's hash is stored
+	lines := strings.Split(strings.TrimSpace(sentMsg.Body), "\n")
+	verifyURL, err := url.Parse(lines[len(lines)-1])
+	require.NoError(e.T(), err)
+	require.True(e.T(), strings.HasPrefix(verifyURL.String(), test.EmailVerificationURLCorrect+"?"))
+	token := verifyURL.Query().Get("token")
+	require.NotEmpty(e.T(), token)
+	require.Equal(e.T(), getEmailVerificationHash(token), createdEmailVerification.ID)
+	require.Equal(e.T(), test.UserIDCorrect, createdEmailVerification.UserID)
+	require.Equal(e.T(), test.UserEmailCorrect, createdEmailVerification.Email)
+	require.WithinDuration(e.T(), time.Now().Add(time.Hour), createdEmailVerification.ExpiresAt, time.Minute)
+}
+
+func (e *emailVerificationSuite) TestSendEmailVerificationAlreadyVerified() {
+	e.userInfo.EmailVerified = true
+	e.userInfoRepo.On("Get", context.Background(), test.TenantIDCorrect, test.UserIDCorrect).Return(e.userInfo, nil)
+
+	err := e.emailVerificationService.SendEmailVerification(context.Background(), &test.SubjectUserCorrect)
+	require.Equal(e.T(), ErrEmailAlreadyVerified, err)
+	e.mailSender.AssertNotCalled(e.T(), "Send", mock.Anything, mock.Anything)
+}
+
+func (e *emailVerificationSuite) TestSendEmailVerificationClient() {
+	err := e.emailVerificationService.SendEmailVerification(context.Background(), &entity.Subject{TenantID: test.TenantIDCorrect})
+	require.Equal(e.T(), ErrUnauthorized, err)
+}
+
+func (e *emailVerificationSuite) TestSendEmailVerificationMailError() {
+	e.userInfoRepo.On("Get", context.Background(), test.TenantIDCorrect, test.UserIDCorrect).Return(e.userInfo, nil)
+	e.emailVerificationRepo.On("DeleteByUser", context.Background(), test.UserIDCorrect).Return(nil)
+	e.emailVerificationRepo.On("Create", context.Background(), mock.Anything).Return(nil)
+	e.emailVerificationRepo.On("DeleteExpired", context.Background(), mock.Anything).Return(nil)
+	e.mailSender.On("Send", context.Background(), mock.Anything).Return(fmt.Errorf("error"))
+
+	err := e.emailVerificationService.SendEmailVerification(context.Background(), &test.SubjectUserCorrect)
+	require.Equal(e.T(), ErrServerErr, err)
+}
+
+func (e *emailVerificationSuite) TestVerifyEmailSuccess() {
+	e.mockUseEmailVerification(&entity.EmailVerification{
+		ID:        test.EmailVerificationTokenHashCorrect,
+		UserID:    test.UserIDCorrect,
+		TenantID:  test.TenantIDCorrect,
+		Email:     test.UserEmailCorrect,
+		ExpiresAt: time.Now().Add(time.Hour),
+	})
+	e.userInfoRepo.On("Get", context.Background(), test.TenantIDCorrect, test.UserIDCorrect).Return(e.userInfo, nil)
+	e.userInfoRepo.On("UpdateEmailVerified", context.Background(), test.TenantIDCorrect, test.UserIDCorrect, mock.Anything).Return(nil)
+
+	err := e.emailVerificationService.VerifyEmail(context.Background(), test.TenantIDCorrect, test.EmailVerificationTokenCorrect)
+	require.NoError(e.T(), err)
+	e.userInfoRepo.AssertCalled(e.T(), "UpdateEmailVerified", context.Background(), test.TenantIDCorrect, test.UserIDCorrect,
+		mock.MatchedBy(func(emailVerifiedAt *time.Time) bool { return emailVerifiedAt != nil }))
+}
+
+func (e *emailVerificationSuite) TestVerifyEmailNotFound() {
+	e.dbTx.On("Begin").Return(&e.dbTx, nil)
+	e.dbTx.On("Rollback").Return(nil)
+	e.emailVerificationRepo.On("WithTx", mock.Anything).Return(&e.emailVerificationRepo)
+	e.emailVerificationRepo.On("GetForUpdate", context.Background(), test.EmailVerificationTokenHashCorrect).Return(nil, repo.ErrNotFound)
+
+	err := e.emailVerificationService.VerifyEmail(context.Background(), test.TenantIDCorrect, test.EmailVerificationTokenCorrect)
+	require.Equal(e.T(), ErrUnauthorized, err)
+	e.userInfoRepo.AssertNotCalled(e.T(), "UpdateEmailVerified", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (e *emailVerificationSuite) TestVerifyEmailExpired() {
+	e.mockUseEmailVerification(&entity.EmailVerification{
+		ID:        test.EmailVerificationTokenHashCorrect,
+		UserID:    test.UserIDCorrect,
+		TenantID:  test.TenantIDCorrect,
+		Email:     test.UserEmailCorrect,
+		ExpiresAt: time.Now().Add(-time.Minute),
+	})
+
+	// Expired token is used up
+	err := e.emailVerificationService.VerifyEmail(context.Background(), test.TenantIDCorrect, test.EmailVerificationTokenCorrect)
+	require.Equal(e.T(), ErrUnauthorized, err)
+	e.emailVerificationRepo.AssertCalled(e.T(), "Delete", context.Background(), test.EmailVerificationTokenHashCorrect)
+	e.userInfoRepo.AssertNotCalled(e.T(), "UpdateEmailVerified", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (e *emailVerificationSuite) TestVerifyEmailOtherTenant() {
+	e.mockUseEmailVerification(&entity.EmailVerification{
+		ID:        test.EmailVerificationTokenHashCorrect,
+		UserID:    test.UserIDCorrect,
+		TenantID:  test.TenantIDCorrect2,
+		Email:     test.UserEmailCorrect,
+		ExpiresAt: time.Now().Add(time.Hour),
+	})
+
+	err := e.emailVerificationService.VerifyEmail(context.Background(), test.TenantIDCorrect, test.EmailVerificationTokenCorrect)
+	require.Equal(e.T(), ErrUnauthorized, err)
+	e.userInfoRepo.AssertNotCalled(e.T(), "UpdateEmailVerified", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (e *emailVerificationSuite) TestVerifyEmailChanged() {
+	e.mockUseEmailVerification(&entity.EmailVerification{
+		ID:        test.EmailVerificationTokenHashCorrect,
+		UserID:    test.UserIDCorrect,
+		TenantID:  test.TenantIDCorrect,
+		Email:     test.UserEmailCorrect2,
+		ExpiresAt: time.Now().Add(time.Hour),
+	})
+	e.userInfoRepo.On("Get", context.Background(), test.TenantIDCorrect, test.UserIDCorrect).Return(e.userInfo, nil)
+
+	err := e.emailVerificationService.VerifyEmail(context.Background(), test.TenantIDCorrect, test.EmailVerificationTokenCorrect)
+	require.Equal(e.T(), ErrUnauthorized, err)
+	e.userInfoRepo.AssertNotCalled(e.T(), "UpdateEmailVerified", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetEmailVerificationBody(t *testing.T) {
+	// Token is in the body without the URL
+	body, err := getEmailVerificationBody(&EmailVerificationPolicy{Lifetime: time.Hour}, test.EmailVerificationTokenCorrect)
+	require.NoError(t, err)
+	require.Contains(t, body, "\n"+test.EmailVerificationTokenCorrect+"\n")
+
+	// Token is added to the query of the URL
+	body, err = getEmailVerificationBody(&EmailVerificationPolicy{Lifetime: time.Hour, URL: test.EmailVerificationURLCorrect + "?lang=en"},
+		test.EmailVerificationTokenCorrect)
+	require.NoError(t, err)
+	require.Contains(t, body, test.EmailVerificationURLCorrect+"?lang=en&token="+test.EmailVerificationTokenCorrect)
+}
+
+func TestGetEmailVerificationHash(t *testing.T) {
+	require.Equal(t, test.EmailVerificationTokenHashCorrect, getEmailVerificationHash(test.EmailVerificationTokenCorrect))
+}
@@ -0,0 +1,461 @@
+package service
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/repo"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+const (
+	AggregateTypeGroup          = "Group"
+	EventTypeGroupMemberAdded   = "GroupMemberAdded"
+	EventTypeGroupMemberRemoved = "GroupMemberRemoved"
+	EventTypeGroupDeleted       = "GroupDeleted"
+)
+
+// Max depth of nested groups to get the groups of a member. Groups nested deeper don't grant their roles.
+const groupMaxDepth = 10
+
+type groupOutboxPayload struct {
+	GroupID    string `json:"groupId"`
+	TenantID   string `json:"tenantId"`
+	MemberID   string `json:"memberId,omitempty"`
+	MemberType string `json:"memberType,omitempty"`
+}
+
+// Group service
+type GroupService interface {
+	ListGroup(ctx context.Context, tenantID string, offset int, limit int) ([]entity.Group, error)
+	CreateGroup(ctx context.Context, subject *entity.Subject, group *entity.Group) (*entity.Group, error)
+	GetGroup(ctx context.Context, tenantID string, groupUUID uuid.EntityUUID) (*entity.Group, error)
+	UpdateGroup(ctx context.Context, subject *entity.Subject, group *entity.Group) error
+	DeleteGroup(ctx context.Context, subject *entity.Subject, groupUUID uuid.EntityUUID) error
+
+	ListGroupMember(ctx context.Context, tenantID string, groupUUID uuid.EntityUUID, offset int, limit int) ([]entity.GroupMember, error)
+	AddGroupMember(ctx context.Context, subject *entity.Subject, groupMember *entity.GroupMember) (*entity.GroupMember, error)
+	RemoveGroupMember(ctx context.Context, subject *entity.Subject, groupUUID, memberUUID uuid.EntityUUID) error
+}
+
+type GroupServiceImp struct {
+	repoDBTx repo.DBTx
+
+	groupRepoPrimary         repo.GroupRepo
+	groupRepoSecondary       repo.GroupRepo
+	groupMemberRepoPrimary   repo.GroupMemberRepo
+	groupMemberRepoSecondary repo.GroupMemberRepo
+	roleRepoPrimary          repo.RoleRepo
+	permissionRepoPrimary    repo.PermissionRepo
+	userInfoRepoPrimary      repo.UserInfoRepo
+	outboxRepoPrimary        repo.OutboxRepo
+}
+
+func NewGroupServiceImp(dbTx repo.DBTx, groupPrimary, groupSecondary repo.GroupRepo, groupMemberPrimary, groupMemberSecondary repo.GroupMemberRepo,
+	rolePrimary repo.RoleRepo, permissionPrimary repo.PermissionRepo, userInfoPrimary repo.UserInfoRepo, outboxPrimary repo.OutboxRepo) *GroupServiceImp {
+	return &GroupServiceImp{
+		repoDBTx: dbTx,
+
+		groupRepoPrimary:         groupPrimary,
+		groupRepoSecondary:       groupSecondary,
+		groupMemberRepoPrimary:   groupMemberPrimary,
+		groupMemberRepoSecondary: groupMemberSecondary,
+		roleRepoPrimary:          rolePrimary,
+		permissionRepoPrimary:    permissionPrimary,
+		userInfoRepoPrimary:      userInfoPrimary,
+		outboxRepoPrimary:        outboxPrimary,
+	}
+}
+
+func (g *GroupServiceImp) ListGroup(ctx context.Context, tenantID string, offset int, limit int) ([]entity.Group, error) {
+	// Set default limit
+	if limit == 0 {
+		limit = 50
+	}
+
+	// List groups
+	groups, err := g.groupRepoSecondary.List(ctx, tenantID, offset, limit)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to list groups from DB")
+		return nil, getReturnErr(err)
+	}
+	return groups, nil
+}
+
+func (g *GroupServiceImp) CreateGroup(ctx context.Context, subject *entity.Subject, group *entity.Group) (*entity.Group, error) {
+	var err error
+
+	// Check roles can be granted
+	if err = checkGroupChange(ctx, g.permissionRepoPrimary, subject, []entity.Group{*group}); err != nil {
+		return nil, err
+	}
+
+	// Begin transaction
+	tx, _ := g.repoDBTx.Begin()
+	defer func() {
+		if err != nil {
+			if err = tx.Rollback(); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Rollback transaction error for creating group")
+				return
+			}
+			log.Ctx(ctx).Error().Err(err).Msg("Create group request is canceled")
+			return
+		}
+	}()
+
+	// Check roles exist
+	for _, role := range group.Roles {
+		if err = checkRoleExist(ctx, g.roleRepoPrimary, tx, role); err != nil {
+			return nil, err
+		}
+	}
+
+	// Create group in the subject's tenant
+	group.ID = uuid.NewV4()
+	group.TenantID = subject.TenantID
+	if err = g.groupRepoPrimary.WithTx(tx).Create(ctx, group); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to create group to DB")
+		return nil, getReturnErr(err)
+	}
+
+	// Commit transaction
+	if err = tx.Commit(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Commit transaction error for creating group")
+		return nil, getReturnErr(err)
+	}
+	return group, nil
+}
+
+func (g *GroupServiceImp) GetGroup(ctx context.Context, tenantID string, groupUUID uuid.EntityUUID) (*entity.Group, error) {
+	group, err := g.groupRepoSecondary.Get(ctx, tenantID, groupUUID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get group from DB")
+		return nil, getReturnErr(err)
+	}
+	return group, nil
+}
+
+// Update a group. Changed roles of the group take effect when members log in or refresh tokens.
+func (g *GroupServiceImp) UpdateGroup(ctx context.Context, subject *entity.Subject, group *entity.Group) error {
+	var err error
+
+	// Begin transaction
+	tx, _ := g.repoDBTx.Begin()
+	defer func() {
+		if err != nil {
+			if err = tx.Rollback(); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Rollback transaction error for updating group")
+				return
+			}
+			log.Ctx(ctx).Error().Err(err).Msg("Update group request is canceled")
+			return
+		}
+	}()
+
+	// Check the subject can change the group and grant the new roles
+	group.TenantID = subject.TenantID
+	if _, err = g.getGroupToChange(ctx, tx, subject, group.ID); err != nil {
+		return err
+	}
+	if err = checkGroupChange(ctx, g.permissionRepoPrimary, subject, []entity.Group{*group}); err != nil {
+		return err
+	}
+
+	// Check roles exist
+	for _, role := range group.Roles {
+		if err = checkRoleExist(ctx, g.roleRepoPrimary, tx, role); err != nil {
+			return err
+		}
+	}
+
+	// Update group
+	if err = g.groupRepoPrimary.WithTx(tx).Update(ctx, group); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to update group from DB")
+		return getReturnErr(err)
+	}
+
+	// Commit transaction
+	if err = tx.Commit(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Commit transaction error for updating group")
+		return getReturnErr(err)
+	}
+	return nil
+}
+
+// Delete a group with its members and its memberships of other groups
+func (g *GroupServiceImp) DeleteGroup(ctx context.Context, subject *entity.Subject, groupUUID uuid.EntityUUID) error {
+	var err error
+
+	// Begin transaction
+	tx, _ := g.repoDBTx.Begin()
+	defer func() {
+		if err != nil {
+			if err = tx.Rollback(); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Rollback transaction error for deleting group")
+				return
+			}
+			log.Ctx(ctx).Error().Err(err).Msg("Delete group request is canceled")
+			return
+		}
+	}()
+
+	// Check the subject can change the group
+	if _, err = g.getGroupToChange(ctx, tx, subject, groupUUID); err != nil {
+		return err
+	}
+
+	// Delete members and memberships of group
+	if err = g.groupMemberRepoPrimary.WithTx(tx).DeleteByGroup(ctx, groupUUID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete members of group from DB")
+		return getReturnErr(err)
+	}
+	if err = g.groupMemberRepoPrimary.WithTx(tx).DeleteByMember(ctx, groupUUID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete memberships of group from DB")
+		return getReturnErr(err)
+	}
+
+	// Delete group
+	if err = g.groupRepoPrimary.WithTx(tx).Delete(ctx, subject.TenantID, groupUUID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete group from DB")
+		return getReturnErr(err)
+	}
+
+	// Publish a group deleted event
+	if err = createOutbox(ctx, g.outboxRepoPrimary, tx, "DeleteGroup", AggregateTypeGroup, groupUUID.String(),
+		EventTypeGroupDeleted, groupOutboxPayload{GroupID: groupUUID.String(), TenantID: subject.TenantID}); err != nil {
+		return getReturnErr(err)
+	}
+
+	// Commit transaction
+	if err = tx.Commit(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Commit transaction error for deleting group")
+		return getReturnErr(err)
+	}
+	return nil
+}
+
+func (g *GroupServiceImp) ListGroupMember(ctx context.Context, tenantID string, groupUUID uuid.EntityUUID, offset int, limit int) ([]entity.GroupMember, error) {
+	// Set default limit
+	if limit == 0 {
+		limit = 50
+	}
+
+	// Check group exists in the tenant
+	if _, err := g.groupRepoSecondary.Get(ctx, tenantID, groupUUID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get group from DB")
+		return nil, getReturnErr(err)
+	}
+
+	// List group members
+	groupMembers, err := g.groupMemberRepoSecondary.List(ctx, groupUUID, offset, limit)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to list group members from DB")
+		return nil, getReturnErr(err)
+	}
+	return groupMembers, nil
+}
+
+// Add a user or a group to a group. A group can't be added to itself or its nested members.
+func (g *GroupServiceImp) AddGroupMember(ctx context.Context, subject *entity.Subject, groupMember *entity.GroupMember) (*entity.GroupMember, error) {
+	var err error
+
+	// Begin transaction
+	tx, _ := g.repoDBTx.Begin()
+	defer func() {
+		if err != nil {
+			if err = tx.Rollback(); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Rollback transaction error for adding group member")
+				return
+			}
+			log.Ctx(ctx).Error().Err(err).Msg("Add group member request is canceled")
+			return
+		}
+	}()
+
+	// Check the subject can change the group
+	groups, err := g.getGroupToChange(ctx, tx, subject, groupMember.GroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check member exists in the subject's tenant and doesn't make a cycle
+	switch groupMember.MemberType {
+	case entity.GroupMemberTypeUser:
+		_, err = g.userInfoRepoPrimary.WithTx(tx).Get(ctx, subject.TenantID, groupMember.MemberID)
+	case entity.GroupMemberTypeGroup:
+		_, err = g.groupRepoPrimary.WithTx(tx).Get(ctx, subject.TenantID, groupMember.MemberID)
+	default:
+		err = repo.ErrNotFound
+	}
+	if err != nil {
+		if err == repo.ErrNotFound {
+			log.Ctx(ctx).Error().Str("member_id", groupMember.MemberID.String()).Msg("Group member doesn't exist")
+			err = ErrGroupMemberNotExist
+			return nil, err
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get group member from DB")
+		return nil, getReturnErr(err)
+	}
+	if groupMember.MemberType == entity.GroupMemberTypeGroup {
+		for _, group := range groups {
+			if group.ID == groupMember.MemberID {
+				log.Ctx(ctx).Error().Str("member_id", groupMember.MemberID.String()).Msg("Group member makes a cycle")
+				err = ErrGroupMemberCycle
+				return nil, err
+			}
+		}
+	}
+
+	// Create group member
+	if err = g.groupMemberRepoPrimary.WithTx(tx).Create(ctx, groupMember); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to create group member to DB")
+		return nil, getReturnErr(err)
+	}
+
+	// Publish a group member added event
+	if err = createOutbox(ctx, g.outboxRepoPrimary, tx, "AddGroupMember", AggregateTypeGroup, groupMember.GroupID.String(),
+		EventTypeGroupMemberAdded, groupOutboxPayload{GroupID: groupMember.GroupID.String(), TenantID: subject.TenantID,
+			MemberID: groupMember.MemberID.String(), MemberType: string(groupMember.MemberType)}); err != nil {
+		return nil, getReturnErr(err)
+	}
+
+	// Commit transaction
+	if err = tx.Commit(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Commit transaction error for adding group member")
+		return nil, getReturnErr(err)
+	}
+	return groupMember, nil
+}
+
+func (g *GroupServiceImp) RemoveGroupMember(ctx context.Context, subject *entity.Subject, groupUUID, memberUUID uuid.EntityUUID) error {
+	var err error
+
+	// Begin transaction
+	tx, _ := g.repoDBTx.Begin()
+	defer func() {
+		if err != nil {
+			if err = tx.Rollback(); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Rollback transaction error for removing group member")
+				return
+			}
+			log.Ctx(ctx).Error().Err(err).Msg("Remove group member request is canceled")
+			return
+		}
+	}()
+
+	// Check the subject can change the group
+	if _, err = g.getGroupToChange(ctx, tx, subject, groupUUID); err != nil {
+		return err
+	}
+
+	// Get group member
+	groupMember, err := g.groupMemberRepoPrimary.WithTx(tx).Get(ctx, groupUUID, memberUUID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get group member from DB")
+		return getReturnErr(err)
+	}
+
+	// Delete group member
+	if err = g.groupMemberRepoPrimary.WithTx(tx).Delete(ctx, groupUUID, memberUUID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete group member from DB")
+		return getReturnErr(err)
+	}
+
+	// Publish a group member removed event
+	if err = createOutbox(ctx, g.outboxRepoPrimary, tx, "RemoveGroupMember", AggregateTypeGroup, groupUUID.String(),
+		EventTypeGroupMemberRemoved, groupOutboxPayload{GroupID: groupUUID.String(), TenantID: subject.TenantID,
+			MemberID: memberUUID.String(), MemberType: string(groupMember.MemberType)}); err != nil {
+		return getReturnErr(err)
+	}
+
+	// Commit transaction
+	if err = tx.Commit(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Commit transaction error for removing group member")
+		return getReturnErr(err)
+	}
+	return nil
+}
+
+// Get the group in the subject's tenant with its parent groups, and check the subject can change them.
+// The group is the first of the returned groups.
+func (g *GroupServiceImp) getGroupToChange(ctx context.Context, tx repo.DBTx, subject *entity.Subject, groupUUID uuid.EntityUUID) ([]entity.Group, error) {
+	group, err := g.groupRepoPrimary.WithTx(tx).Get(ctx, subject.TenantID, groupUUID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get group from DB")
+		return nil, getReturnErr(err)
+	}
+	parentGroups, err := getMemberGroups(ctx, g.groupRepoPrimary.WithTx(tx), g.groupMemberRepoPrimary.WithTx(tx), subject.TenantID, groupUUID)
+	if err != nil {
+		return nil, getReturnErr(err)
+	}
+
+	groups := append([]entity.Group{*group}, parentGroups...)
+	if err := checkGroupChange(ctx, g.permissionRepoPrimary, subject, groups); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+// Get the groups which have the member as a direct or nested member in the tenant
+func getMemberGroups(ctx context.Context, groupRepo repo.GroupRepo, groupMemberRepo repo.GroupMemberRepo, tenantID string,
+	memberUUID uuid.EntityUUID) ([]entity.Group, error) {
+	groupUUIDs := []uuid.EntityUUID{}
+	visited := map[uuid.EntityUUID]bool{memberUUID: true}
+	memberUUIDs := []uuid.EntityUUID{memberUUID}
+	for depth := 0; depth < groupMaxDepth && len(memberUUIDs) > 0; depth++ {
+		groupMembers, err := groupMemberRepo.ListByMemberIDs(ctx, memberUUIDs)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to list group memberships from DB")
+			return nil, err
+		}
+
+		memberUUIDs = []uuid.EntityUUID{}
+		for _, groupMember := range groupMembers {
+			if visited[groupMember.GroupID] {
+				continue
+			}
+			visited[groupMember.GroupID] = true
+			groupUUIDs = append(groupUUIDs, groupMember.GroupID)
+			memberUUIDs = append(memberUUIDs, groupMember.GroupID)
+		}
+	}
+
+	groups, err := groupRepo.ListByIDs(ctx, tenantID, groupUUIDs)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to list groups from DB")
+		return nil, err
+	}
+	return groups, nil
+}
+
+// Get the effective roles of the user, which are the user's role and the roles of the user's groups
+func getUserRoles(ctx context.Context, groupRepo repo.GroupRepo, groupMemberRepo repo.GroupMemberRepo, userInfo *entity.UserInfo) ([]entity.UserRole, error) {
+	groups, err := getMemberGroups(ctx, groupRepo, groupMemberRepo, userInfo.TenantID, userInfo.ID)
+	if err != nil {
+		return nil, err
+	}
+	roles := []entity.UserRole{userInfo.Role}
+	for _, role := range getGroupRoles(groups) {
+		if role != userInfo.Role {
+			roles = append(roles, role)
+		}
+	}
+	return roles, nil
+}
+
+// Get the roles of the groups without duplicates
+func getGroupRoles(groups []entity.Group) []entity.UserRole {
+	roles := []entity.UserRole{}
+	added := map[string]bool{}
+	for _, group := range groups {
+		for _, role := range group.Roles {
+			if !added[role] {
+				added[role] = true
+				roles = append(roles, entity.UserRole(role))
+			}
+		}
+	}
+	return roles
+}
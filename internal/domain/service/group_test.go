@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/repo"
+	"github.com/ssup2ket/service-auth/internal/domain/repo/mocks"
+	"github.com/ssup2ket/service-auth/internal/test"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+func TestGroup(t *testing.T) {
+	suite.Run(t, new(groupSuite))
+}
+
+type groupSuite struct {
+	suite.Suite
+
+	dbTx            mocks.DBTx
+	groupRepo       mocks.GroupRepo
+	groupMemberRepo mocks.GroupMemberRepo
+	roleRepo        mocks.RoleRepo
+	permissionRepo  mocks.PermissionRepo
+	userInfoRepo    mocks.UserInfoRepo
+	outboxRepo      mocks.OutboxRepo
+
+	groupService GroupService
+}
+
+func (g *groupSuite) SetupTest() {
+	// Init transaction, repo
+	g.dbTx = mocks.DBTx{}
+	g.groupRepo = mocks.GroupRepo{}
+	g.groupMemberRepo = mocks.GroupMemberRepo{}
+	g.roleRepo = mocks.RoleRepo{}
+	g.permissionRepo = mocks.PermissionRepo{}
+	g.userInfoRepo = mocks.UserInfoRepo{}
+	g.outboxRepo = mocks.OutboxRepo{}
+
+	// Set nooptracer
+	opentracing.SetGlobalTracer(opentracing.NoopTracer{})
+
+	// Init service
+	g.groupService = NewGroupServiceImp(&g.dbTx, &g.groupRepo, &g.groupRepo, &g.groupMemberRepo, &g.groupMemberRepo,
+		&g.roleRepo, &g.permissionRepo, &g.userInfoRepo, &g.outboxRepo)
+
+	// Roles granted by tenant admins are checked with permissions
+	g.permissionRepo.On("ListAll", context.Background()).Return(test.PermissionsCorrect, nil)
+}
+
+func (g *groupSuite) mockGroupWithoutParents() {
+	g.groupRepo.On("WithTx", mock.Anything).Return(&g.groupRepo)
+	g.groupRepo.On("Get", context.Background(), test.TenantIDCorrect, test.GroupIDCorrect).Return(&test.GroupCorrect, nil)
+	g.groupMemberRepo.On("WithTx", mock.Anything).Return(&g.groupMemberRepo)
+	g.groupMemberRepo.On("ListByMemberIDs", context.Background(), []uuid.EntityUUID{test.GroupIDCorrect}).Return([]entity.GroupMember{}, nil)
+	g.groupRepo.On("ListByIDs", context.Background(), test.TenantIDCorrect, []uuid.EntityUUID{}).Return([]entity.Group{}, nil)
+}
+
+func (g *groupSuite) TestListGroupSuccess() {
+	g.groupRepo.On("List", context.Background(), test.TenantIDCorrect, 0, 50).Return([]entity.Group{test.GroupCorrect}, nil)
+
+	groups, err := g.groupService.ListGroup(context.Background(), test.TenantIDCorrect, 0, 0)
+	require.NoError(g.T(), err)
+	require.Equal(g.T(), test.GroupIDCorrect, groups[0].ID)
+}
+
+func (g *groupSuite) TestCreateGroupSuccess() {
+	group := entity.Group{Name: test.GroupNameCorrect, Roles: entity.StrList{test.RoleNameCorrect}}
+
+	g.dbTx.On("Begin").Return(&g.dbTx, nil)
+	g.roleRepo.On("WithTx", mock.Anything).Return(&g.roleRepo)
+	g.roleRepo.On("Get", context.Background(), test.RoleNameCorrect).Return(&test.RoleCorrect, nil)
+	g.groupRepo.On("WithTx", mock.Anything).Return(&g.groupRepo)
+	g.groupRepo.On("Create", context.Background(), mock.Anything).Return(nil)
+	g.dbTx.On("Commit").Return(nil)
+
+	created, err := g.groupService.CreateGroup(context.Background(), &test.SubjectTenantAdminCorrect, &group)
+	require.NoError(g.T(), err)
+	require.Equal(g.T(), test.TenantIDCorrect, created.TenantID)
+	require.NotEqual(g.T(), uuid.EntityUUID{}, created.ID)
+}
+
+func (g *groupSuite) TestCreateGroupRoleNotExist() {
+	group := entity.Group{Name: test.GroupNameCorrect, Roles: entity.StrList{test.RoleNameCorrect}}
+
+	g.dbTx.On("Begin").Return(&g.dbTx, nil)
+	g.roleRepo.On("WithTx", mock.Anything).Return(&g.roleRepo)
+	g.roleRepo.On("Get", context.Background(), test.RoleNameCorrect).Return(nil, repo.ErrNotFound)
+	g.dbTx.On("Rollback").Return(nil)
+
+	_, err := g.groupService.CreateGroup(context.Background(), &test.SubjectTenantAdminCorrect, &group)
+	require.Equal(g.T(), ErrRoleNotExist, err)
+	g.groupRepo.AssertNotCalled(g.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (g *groupSuite) TestCreateGroupTenantAdminAdminUnauthorized() {
+	group := entity.Group{Name: test.GroupNameCorrect, Roles: entity.StrList{string(entity.UserRoleAdmin)}}
+
+	_, err := g.groupService.CreateGroup(context.Background(), &test.SubjectTenantAdminCorrect, &group)
+	require.Equal(g.T(), ErrUnauthorized, err)
+	g.dbTx.AssertNotCalled(g.T(), "Begin")
+}
+
+func (g *groupSuite) TestCreateGroupTenantAdminWideRoleUnauthorized() {
+	// Tenant admins can't grant roles having wider permissions than theirs
+	group := entity.Group{Name: test.GroupNameCorrect, Roles: entity.StrList{test.RoleNameWideCorrect}}
+
+	_, err := g.groupService.CreateGroup(context.Background(), &test.SubjectTenantAdminCorrect, &group)
+	require.Equal(g.T(), ErrUnauthorized, err)
+	g.dbTx.AssertNotCalled(g.T(), "Begin")
+}
+
+func (g *groupSuite) TestDeleteGroupSuccess() {
+	g.dbTx.On("Begin").Return(&g.dbTx, nil)
+	g.mockGroupWithoutParents()
+	g.groupMemberRepo.On("DeleteByGroup", context.Background(), test.GroupIDCorrect).Return(nil)
+	g.groupMemberRepo.On("DeleteByMember", context.Background(), test.GroupIDCorrect).Return(nil)
+	g.groupRepo.On("Delete", context.Background(), test.TenantIDCorrect, test.GroupIDCorrect).Return(nil)
+	g.outboxRepo.On("WithTx", mock.Anything).Return(&g.outboxRepo)
+	g.outboxRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	g.dbTx.On("Commit").Return(nil)
+
+	err := g.groupService.DeleteGroup(context.Background(), &test.SubjectTenantAdminCorrect, test.GroupIDCorrect)
+	require.NoError(g.T(), err)
+	g.groupRepo.AssertCalled(g.T(), "Delete", context.Background(), test.TenantIDCorrect, test.GroupIDCorrect)
+	g.outboxRepo.AssertNumberOfCalls(g.T(), "Create", 1)
+}
+
+func (g *groupSuite) TestAddGroupMemberSuccess() {
+	groupMember := test.GroupMemberUserCorrect
+
+	g.dbTx.On("Begin").Return(&g.dbTx, nil)
+	g.mockGroupWithoutParents()
+	g.userInfoRepo.On("WithTx", mock.Anything).Return(&g.userInfoRepo)
+	g.userInfoRepo.On("Get", context.Background(), test.TenantIDCorrect, test.UserIDCorrect).Return(&entity.UserInfo{ID: test.UserIDCorrect}, nil)
+	g.groupMemberRepo.On("Create", context.Background(), &groupMember).Return(nil)
+	g.outboxRepo.On("WithTx", mock.Anything).Return(&g.outboxRepo)
+	g.outboxRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	g.dbTx.On("Commit").Return(nil)
+
+	_, err := g.groupService.AddGroupMember(context.Background(), &test.SubjectTenantAdminCorrect, &groupMember)
+	require.NoError(g.T(), err)
+	g.groupMemberRepo.AssertCalled(g.T(), "Create", context.Background(), &groupMember)
+	g.outboxRepo.AssertNumberOfCalls(g.T(), "Create", 1)
+}
+
+func (g *groupSuite) TestAddGroupMemberNotExist() {
+	groupMember := test.GroupMemberUserCorrect
+
+	g.dbTx.On("Begin").Return(&g.dbTx, nil)
+	g.mockGroupWithoutParents()
+	g.userInfoRepo.On("WithTx", mock.Anything).Return(&g.userInfoRepo)
+	g.userInfoRepo.On("Get", context.Background(), test.TenantIDCorrect, test.UserIDCorrect).Return(nil, repo.ErrNotFound)
+	g.dbTx.On("Rollback").Return(nil)
+
+	_, err := g.groupService.AddGroupMember(context.Background(), &test.SubjectTenantAdminCorrect, &groupMember)
+	require.Equal(g.T(), ErrGroupMemberNotExist, err)
+	g.groupMemberRepo.AssertNotCalled(g.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (g *groupSuite) TestAddGroupMemberCycle() {
+	// Group 1 is a member of group 2, so adding group 2 to group 1 makes a cycle
+	groupMember := entity.GroupMember{GroupID: test.GroupIDCorrect, MemberID: test.GroupIDCorrect2, MemberType: entity.GroupMemberTypeGroup}
+	group2 := entity.Group{ID: test.GroupIDCorrect2, TenantID: test.TenantIDCorrect, Name: test.GroupNameCorrect}
+
+	g.dbTx.On("Begin").Return(&g.dbTx, nil)
+	g.groupRepo.On("WithTx", mock.Anything).Return(&g.groupRepo)
+	g.groupRepo.On("Get", context.Background(), test.TenantIDCorrect, test.GroupIDCorrect).Return(&test.GroupCorrect, nil)
+	g.groupRepo.On("Get", context.Background(), test.TenantIDCorrect, test.GroupIDCorrect2).Return(&group2, nil)
+	g.groupMemberRepo.On("WithTx", mock.Anything).Return(&g.groupMemberRepo)
+	g.groupMemberRepo.On("ListByMemberIDs", context.Background(), []uuid.EntityUUID{test.GroupIDCorrect}).Return([]entity.GroupMember{test.GroupMemberGroupCorrect}, nil)
+	g.groupMemberRepo.On("ListByMemberIDs", context.Background(), []uuid.EntityUUID{test.GroupIDCorrect2}).Return([]entity.GroupMember{}, nil)
+	g.groupRepo.On("ListByIDs", context.Background(), test.TenantIDCorrect, []uuid.EntityUUID{test.GroupIDCorrect2}).Return([]entity.Group{group2}, nil)
+	g.dbTx.On("Rollback").Return(nil)
+
+	_, err := g.groupService.AddGroupMember(context.Background(), &test.SubjectTenantAdminCorrect, &groupMember)
+	require.Equal(g.T(), ErrGroupMemberCycle, err)
+	g.groupMemberRepo.AssertNotCalled(g.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (g *groupSuite) TestAddGroupMemberParentAdminUnauthorized() {
+	// Changing a group changes the roles granted by its parent groups
+	groupMember := test.GroupMemberUserCorrect
+	adminGroup := entity.Group{ID: test.GroupIDCorrect2, TenantID: test.TenantIDCorrect, Roles: entity.StrList{string(entity.UserRoleAdmin)}}
+
+	g.dbTx.On("Begin").Return(&g.dbTx, nil)
+	g.groupRepo.On("WithTx", mock.Anything).Return(&g.groupRepo)
+	g.groupRepo.On("Get", context.Background(), test.TenantIDCorrect, test.GroupIDCorrect).Return(&test.GroupCorrect, nil)
+	g.groupMemberRepo.On("WithTx", mock.Anything).Return(&g.groupMemberRepo)
+	g.groupMemberRepo.On("ListByMemberIDs", context.Background(), []uuid.EntityUUID{test.GroupIDCorrect}).Return([]entity.GroupMember{test.GroupMemberGroupCorrect}, nil)
+	g.groupMemberRepo.On("ListByMemberIDs", context.Background(), []uuid.EntityUUID{test.GroupIDCorrect2}).Return([]entity.GroupMember{}, nil)
+	g.groupRepo.On("ListByIDs", context.Background(), test.TenantIDCorrect, []uuid.EntityUUID{test.GroupIDCorrect2}).Return([]entity.Group{adminGroup}, nil)
+	g.dbTx.On("Rollback").Return(nil)
+
+	_, err := g.groupService.AddGroupMember(context.Background(), &test.SubjectTenantAdminCorrect, &groupMember)
+	require.Equal(g.T(), ErrUnauthorized, err)
+	g.groupMemberRepo.AssertNotCalled(g.T(), "Create", mock.Anything, mock.Anything)
+}
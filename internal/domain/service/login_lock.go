@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/repo"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+// Login lock policy. A login ID or a client IP is locked for the duration when its failed logins reach the threshold,
+// and the lock duration doubles for every failed login after that up to the max duration. Failures are reset if there
+// is no failed login for the max duration. Zero threshold disables locks of the subject type.
+type LoginLockPolicy struct {
+	LoginIDThreshold int
+	IPThreshold      int
+	Duration         time.Duration
+	MaxDuration      time.Duration
+}
+
+// Login lock service
+type LoginLockService interface {
+	ListLoginLock(ctx context.Context, offset int, limit int) ([]entity.LoginLock, error)
+	GetLoginLock(ctx context.Context, lockUUID uuid.EntityUUID) (*entity.LoginLock, error)
+	DeleteLoginLock(ctx context.Context, lockUUID uuid.EntityUUID) error
+	DeleteExpiredLoginLocks(ctx context.Context) error
+}
+
+type LoginLockServiceImp struct {
+	loginLockRepo   repo.LoginLockRepo
+	loginLockPolicy *LoginLockPolicy
+}
+
+func NewLoginLockServiceImp(loginLock repo.LoginLockRepo, loginLockPolicy *LoginLockPolicy) *LoginLockServiceImp {
+	return &LoginLockServiceImp{
+		loginLockRepo:   loginLock,
+		loginLockPolicy: loginLockPolicy,
+	}
+}
+
+// List login locks having failed logins. Locked ones have the locked time after now.
+func (l *LoginLockServiceImp) ListLoginLock(ctx context.Context, offset int, limit int) ([]entity.LoginLock, error) {
+	// Set default limit
+	if limit == 0 {
+		limit = 50
+	}
+
+	// List login locks
+	loginLocks, err := l.loginLockRepo.List(ctx, offset, limit)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to list login locks from repo")
+		return nil, getReturnErr(err)
+	}
+	return loginLocks, nil
+}
+
+func (l *LoginLockServiceImp) GetLoginLock(ctx context.Context, lockUUID uuid.EntityUUID) (*entity.LoginLock, error) {
+	loginLock, err := l.loginLockRepo.Get(ctx, lockUUID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get login lock from repo")
+		return nil, getReturnErr(err)
+	}
+	return loginLock, nil
+}
+
+// Delete a login lock to unlock its subject and reset its failures
+func (l *LoginLockServiceImp) DeleteLoginLock(ctx context.Context, lockUUID uuid.EntityUUID) error {
+	if err := l.loginLockRepo.Delete(ctx, lockUUID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete login lock from repo")
+		return getReturnErr(err)
+	}
+	return nil
+}
+
+// Delete login locks whose failures are reset
+func (l *LoginLockServiceImp) DeleteExpiredLoginLocks(ctx context.Context) error {
+	if err := l.loginLockRepo.DeleteExpired(ctx, time.Now().Add(-l.loginLockPolicy.MaxDuration)); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete expired login locks from repo")
+		return getReturnErr(err)
+	}
+	return nil
+}
+
+// Check whether the login ID of the tenant or the client IP is locked. Empty login ID or IP isn't checked.
+func checkLoginLocks(ctx context.Context, loginLockRepo repo.LoginLockRepo, loginLockPolicy *LoginLockPolicy,
+	tenantID, loginID, ip string) error {
+	now := time.Now()
+	for _, subject := range getLoginLockSubjects(loginLockPolicy, tenantID, loginID, ip) {
+		loginLock, err := loginLockRepo.GetBySubject(ctx, subject.Type, subject.TenantID, subject.Subject)
+		if err == repo.ErrNotFound {
+			continue
+		} else if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to get login lock from repo")
+			return getReturnErr(err)
+		}
+		if loginLock.IsLocked(now) {
+			log.Ctx(ctx).Warn().Str("type", string(subject.Type)).Str("subject", subject.Subject).
+				Time("locked_until", loginLock.LockedUntil).Msg("Login is locked")
+			return ErrLoginLocked
+		}
+	}
+	return nil
+}
+
+// Increase failures of the login ID of the tenant and the client IP, and lock them if their failures reach the threshold.
+// Login isn't failed by errors of login locks, because the login is already failed.
+func addLoginFailures(ctx context.Context, loginLockRepo repo.LoginLockRepo, loginLockPolicy *LoginLockPolicy,
+	tenantID, loginID, ip string) {
+	now := time.Now()
+	for _, subject := range getLoginLockSubjects(loginLockPolicy, tenantID, loginID, ip) {
+		subject.ID = uuid.NewV4()
+		subject.LastFailedAt = now
+		loginLock, err := loginLockRepo.IncreaseFailures(ctx, &subject, now.Add(-loginLockPolicy.MaxDuration))
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to increase login failures in repo")
+			continue
+		}
+
+		threshold := loginLockPolicy.LoginIDThreshold
+		if subject.Type == entity.LoginLockTypeIP {
+			threshold = loginLockPolicy.IPThreshold
+		}
+		if loginLock.Failures < threshold {
+			continue
+		}
+		lockedUntil := now.Add(getLoginLockDuration(loginLockPolicy, loginLock.Failures-threshold))
+		if err := loginLockRepo.Lock(ctx, loginLock.ID, lockedUntil); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to lock login in repo")
+			continue
+		}
+		log.Ctx(ctx).Warn().Str("type", string(subject.Type)).Str("subject", subject.Subject).
+			Int("failures", loginLock.Failures).Time("locked_until", lockedUntil).Msg("Login is locked")
+	}
+}
+
+// Reset failures of the login ID of the tenant after a successful login. Failures of the client IP aren't reset,
+// because an attacker can login with its own account to reset them.
+func resetLoginFailures(ctx context.Context, loginLockRepo repo.LoginLockRepo, loginLockPolicy *LoginLockPolicy,
+	tenantID, loginID string) {
+	if loginLockPolicy.LoginIDThreshold == 0 {
+		return
+	}
+	if err := loginLockRepo.DeleteBySubject(ctx, entity.LoginLockTypeLoginID, tenantID, loginID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to reset login failures in repo")
+	}
+}
+
+// Get subjects of enabled login lock types
+func getLoginLockSubjects(loginLockPolicy *LoginLockPolicy, tenantID, loginID, ip string) []entity.LoginLock {
+	subjects := []entity.LoginLock{}
+	if loginLockPolicy.LoginIDThreshold > 0 && loginID != "" {
+		subjects = append(subjects, entity.LoginLock{Type: entity.LoginLockTypeLoginID, TenantID: tenantID, Subject: loginID})
+	}
+	if loginLockPolicy.IPThreshold > 0 && ip != "" {
+		subjects = append(subjects, entity.LoginLock{Type: entity.LoginLockTypeIP, Subject: ip})
+	}
+	return subjects
+}
+
+// Get the lock duration doubled for every failure after the threshold
+func getLoginLockDuration(loginLockPolicy *LoginLockPolicy, failuresAfterThreshold int) time.Duration {
+	duration := loginLockPolicy.Duration
+	for i := 0; i < failuresAfterThreshold && duration < loginLockPolicy.MaxDuration; i++ {
+		duration *= 2
+	}
+	if duration > loginLockPolicy.MaxDuration {
+		duration = loginLockPolicy.MaxDuration
+	}
+	return duration
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/repo"
+	"github.com/ssup2ket/service-auth/internal/domain/repo/mocks"
+	"github.com/ssup2ket/service-auth/internal/test"
+)
+
+var testLoginLockPolicy = LoginLockPolicy{
+	LoginIDThreshold: 3,
+	IPThreshold:      10,
+	Duration:         time.Minute,
+	MaxDuration:      time.Hour,
+}
+
+func TestLoginLock(t *testing.T) {
+	suite.Run(t, new(loginLockSuite))
+}
+
+type loginLockSuite struct {
+	suite.Suite
+
+	loginLockRepo mocks.LoginLockRepo
+
+	loginLockService LoginLockService
+}
+
+func (l *loginLockSuite) SetupTest() {
+	// Init repo
+	l.loginLockRepo = mocks.LoginLockRepo{}
+
+	// Init service
+	l.loginLockService = NewLoginLockServiceImp(&l.loginLockRepo, &testLoginLockPolicy)
+}
+
+func (l *loginLockSuite) TestListLoginLockSuccess() {
+	l.loginLockRepo.On("List", context.Background(), 0, 50).Return([]entity.LoginLock{{ID: test.LoginLockIDCorrect}}, nil)
+
+	loginLocks, err := l.loginLockService.ListLoginLock(context.Background(), 0, 0)
+	require.NoError(l.T(), err)
+	require.Equal(l.T(), test.LoginLockIDCorrect, loginLocks[0].ID)
+}
+
+func (l *loginLockSuite) TestDeleteLoginLockNotFound() {
+	l.loginLockRepo.On("Delete", context.Background(), test.LoginLockIDWrong).Return(repo.ErrNotFound)
+
+	err := l.loginLockService.DeleteLoginLock(context.Background(), test.LoginLockIDWrong)
+	require.Equal(l.T(), ErrRepoNotFound, err)
+}
+
+func (l *loginLockSuite) TestDeleteExpiredLoginLocksSuccess() {
+	var before time.Time
+	l.loginLockRepo.On("DeleteExpired", context.Background(), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		before = args.Get(1).(time.Time)
+	})
+
+	err := l.loginLockService.DeleteExpiredLoginLocks(context.Background())
+	require.NoError(l.T(), err)
+	require.WithinDuration(l.T(), time.Now().Add(-testLoginLockPolicy.MaxDuration), before, time.Second)
+}
+
+func (l *loginLockSuite) TestGetLoginLockDuration() {
+	require.Equal(l.T(), time.Minute, getLoginLockDuration(&testLoginLockPolicy, 0))
+	require.Equal(l.T(), 8*time.Minute, getLoginLockDuration(&testLoginLockPolicy, 3))
+	require.Equal(l.T(), time.Hour, getLoginLockDuration(&testLoginLockPolicy, 100))
+}
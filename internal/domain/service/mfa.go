@@ -0,0 +1,420 @@
+package service
+
+import (
+	"context"
+	"encoding/base32"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/repo"
+	"github.com/ssup2ket/service-auth/pkg/auth/cipher"
+	"github.com/ssup2ket/service-auth/pkg/auth/hashing"
+	"github.com/ssup2ket/service-auth/pkg/auth/totp"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+const (
+	mfaRecoveryCodeCount = 10
+	mfaRecoveryCodeSize  = 10 // Characters except the separator
+)
+
+var mfaRecoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// MFA service of the subject user. TOTP is enrolled with a new secret and enabled after it's confirmed with
+// a first code, and recovery codes are issued when TOTP is enabled. Disabling TOTP and regenerating recovery codes
+// need a TOTP code or a recovery code, and wrong codes increase failed logins of the login ID and the client IP like
+// the MFA login. ErrMFADisabled is returned if no MFA secret is configured.
+type MFAService interface {
+	GetMFA(ctx context.Context, subject *entity.Subject) (*entity.MFAInfo, error)
+	EnrollTOTP(ctx context.Context, subject *entity.Subject) (*entity.TOTPEnrollment, error)
+	ConfirmTOTP(ctx context.Context, subject *entity.Subject, code string) ([]string, error)
+	DisableTOTP(ctx context.Context, subject *entity.Subject, code, ip string) error
+	RegenerateMFARecoveryCodes(ctx context.Context, subject *entity.Subject, code, ip string) ([]string, error)
+}
+
+type MFAServiceImp struct {
+	repoDBTx repo.DBTx
+
+	userInfoRepoPrimary        repo.UserInfoRepo
+	userSecretRepoPrimary      repo.UserSecretRepo
+	mfaRecoveryCodeRepoPrimary repo.MFARecoveryCodeRepo
+
+	loginLockRepo   repo.LoginLockRepo
+	loginLockPolicy *LoginLockPolicy
+
+	mfaSecret  []byte
+	totpIssuer string
+}
+
+func NewMFAServiceImp(dbTx repo.DBTx, userInfoPrimary repo.UserInfoRepo, userSecretPrimary repo.UserSecretRepo,
+	mfaRecoveryCodePrimary repo.MFARecoveryCodeRepo, loginLock repo.LoginLockRepo, loginLockPolicy *LoginLockPolicy,
+	mfaSecret []byte, totpIssuer string) *MFAServiceImp {
+	return &MFAServiceImp{
+		repoDBTx: dbTx,
+
+		userInfoRepoPrimary:        userInfoPrimary,
+		userSecretRepoPrimary:      userSecretPrimary,
+		mfaRecoveryCodeRepoPrimary: mfaRecoveryCodePrimary,
+
+		loginLockRepo:   loginLock,
+		loginLockPolicy: loginLockPolicy,
+
+		mfaSecret:  mfaSecret,
+		totpIssuer: totpIssuer,
+	}
+}
+
+func (m *MFAServiceImp) GetMFA(ctx context.Context, subject *entity.Subject) (*entity.MFAInfo, error) {
+	userSecret, err := m.getUserSecret(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+	recoveryCodes, err := m.mfaRecoveryCodeRepoPrimary.CountByUser(ctx, userSecret.ID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to count MFA recovery codes from DB")
+		return nil, getReturnErr(err)
+	}
+	return &entity.MFAInfo{
+		TOTPEnabled:   userSecret.IsTOTPEnabled(),
+		TOTPEnabledAt: userSecret.TOTPEnabledAt,
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
+
+// Enroll TOTP with a new secret. Enrolling again before the confirmation replaces the secret.
+func (m *MFAServiceImp) EnrollTOTP(ctx context.Context, subject *entity.Subject) (*entity.TOTPEnrollment, error) {
+	if len(m.mfaSecret) == 0 {
+		log.Ctx(ctx).Error().Msg("MFA secret isn't configured")
+		return nil, ErrMFADisabled
+	}
+
+	// Get user info and user secret
+	userSecret, err := m.getUserSecret(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+	if userSecret.IsTOTPEnabled() {
+		log.Ctx(ctx).Error().Msg("TOTP is already enabled")
+		return nil, ErrMFAAlreadyEnabled
+	}
+	userInfo, err := m.userInfoRepoPrimary.Get(ctx, subject.TenantID, userSecret.ID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get user info from DB")
+		return nil, getReturnErr(err)
+	}
+
+	// Create and store encrypted TOTP secret
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to generate TOTP secret")
+		return nil, ErrServerErr
+	}
+	encryptedSecret, err := cipher.Encrypt(m.mfaSecret, secret)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to encrypt TOTP secret")
+		return nil, ErrServerErr
+	}
+	if err := m.userSecretRepoPrimary.UpdateTOTP(ctx, &entity.UserSecret{ID: userSecret.ID, TOTPSecret: encryptedSecret}); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to update TOTP secret in DB")
+		return nil, getReturnErr(err)
+	}
+
+	return &entity.TOTPEnrollment{
+		Secret: totp.EncodeSecret(secret),
+		URI:    totp.GetURI(m.totpIssuer, userInfo.LoginID, secret),
+	}, nil
+}
+
+// Confirm the enrolled TOTP with a first code to enable it, and issue new recovery codes
+func (m *MFAServiceImp) ConfirmTOTP(ctx context.Context, subject *entity.Subject, code string) ([]string, error) {
+	var err error
+
+	if len(m.mfaSecret) == 0 {
+		log.Ctx(ctx).Error().Msg("MFA secret isn't configured")
+		return nil, ErrMFADisabled
+	}
+
+	// Get user secret
+	userSecret, err := m.getUserSecret(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+	if userSecret.IsTOTPEnabled() {
+		log.Ctx(ctx).Error().Msg("TOTP is already enabled")
+		return nil, ErrMFAAlreadyEnabled
+	} else if len(userSecret.TOTPSecret) == 0 {
+		log.Ctx(ctx).Error().Msg("TOTP isn't enrolled")
+		return nil, ErrMFANotEnabled
+	}
+
+	// Validate code
+	secret, err := cipher.Decrypt(m.mfaSecret, userSecret.TOTPSecret)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to decrypt TOTP secret")
+		return nil, ErrServerErr
+	}
+	step, ok := totp.ValidateCode(secret, code, time.Now())
+	if !ok {
+		log.Ctx(ctx).Error().Msg("TOTP code is wrong")
+		return nil, ErrMFACodeWrong
+	}
+
+	// Begin transaction
+	tx, _ := m.repoDBTx.Begin()
+	defer func() {
+		if err != nil {
+			if err = tx.Rollback(); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Rollback transaction error for confirming TOTP")
+				return
+			}
+			log.Ctx(ctx).Error().Err(err).Msg("Confirm TOTP request is canceled")
+			return
+		}
+	}()
+
+	// Enable TOTP and issue recovery codes
+	now := time.Now()
+	if err = m.userSecretRepoPrimary.WithTx(tx).UpdateTOTP(ctx, &entity.UserSecret{ID: userSecret.ID,
+		TOTPSecret: userSecret.TOTPSecret, TOTPEnabledAt: &now, TOTPLastStep: step}); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to enable TOTP in DB")
+		return nil, getReturnErr(err)
+	}
+	recoveryCodes, err := m.createRecoveryCodes(ctx, tx, userSecret.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Commit transaction
+	if err = tx.Commit(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Commit transaction error for confirming TOTP")
+		return nil, getReturnErr(err)
+	}
+	return recoveryCodes, nil
+}
+
+// Disable TOTP and delete recovery codes. A code is required, so a stolen access token can't disable MFA.
+func (m *MFAServiceImp) DisableTOTP(ctx context.Context, subject *entity.Subject, code, ip string) error {
+	var err error
+
+	// Get user secret and verify code
+	userSecret, err := m.getUserSecret(ctx, subject)
+	if err != nil {
+		return err
+	}
+	if !userSecret.IsTOTPEnabled() {
+		log.Ctx(ctx).Error().Msg("TOTP isn't enabled")
+		return ErrMFANotEnabled
+	}
+	if err = m.verifyCode(ctx, subject, userSecret, code, ip); err != nil {
+		return err
+	}
+
+	// Begin transaction
+	tx, _ := m.repoDBTx.Begin()
+	defer func() {
+		if err != nil {
+			if err = tx.Rollback(); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Rollback transaction error for disabling TOTP")
+				return
+			}
+			log.Ctx(ctx).Error().Err(err).Msg("Disable TOTP request is canceled")
+			return
+		}
+	}()
+
+	// Clear TOTP and delete recovery codes
+	if err = m.userSecretRepoPrimary.WithTx(tx).UpdateTOTP(ctx, &entity.UserSecret{ID: userSecret.ID}); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to disable TOTP in DB")
+		return getReturnErr(err)
+	}
+	if err = m.mfaRecoveryCodeRepoPrimary.WithTx(tx).DeleteByUser(ctx, userSecret.ID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete MFA recovery codes from DB")
+		return getReturnErr(err)
+	}
+
+	// Commit transaction
+	if err = tx.Commit(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Commit transaction error for disabling TOTP")
+		return getReturnErr(err)
+	}
+	return nil
+}
+
+// Replace recovery codes with new ones
+func (m *MFAServiceImp) RegenerateMFARecoveryCodes(ctx context.Context, subject *entity.Subject, code, ip string) ([]string, error) {
+	var err error
+
+	// Get user secret and verify code
+	userSecret, err := m.getUserSecret(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+	if !userSecret.IsTOTPEnabled() {
+		log.Ctx(ctx).Error().Msg("TOTP isn't enabled")
+		return nil, ErrMFANotEnabled
+	}
+	if err = m.verifyCode(ctx, subject, userSecret, code, ip); err != nil {
+		return nil, err
+	}
+
+	// Begin transaction
+	tx, _ := m.repoDBTx.Begin()
+	defer func() {
+		if err != nil {
+			if err = tx.Rollback(); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Rollback transaction error for regenerating MFA recovery codes")
+				return
+			}
+			log.Ctx(ctx).Error().Err(err).Msg("Regenerate MFA recovery codes request is canceled")
+			return
+		}
+	}()
+
+	recoveryCodes, err := m.createRecoveryCodes(ctx, tx, userSecret.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Commit transaction
+	if err = tx.Commit(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Commit transaction error for regenerating MFA recovery codes")
+		return nil, getReturnErr(err)
+	}
+	return recoveryCodes, nil
+}
+
+// Get the user secret of the subject user. Clients don't have MFA.
+func (m *MFAServiceImp) getUserSecret(ctx context.Context, subject *entity.Subject) (*entity.UserSecret, error) {
+	if subject.IsClient() {
+		log.Ctx(ctx).Error().Msg("Client doesn't have MFA")
+		return nil, ErrUnauthorized
+	}
+	userSecret, err := m.userSecretRepoPrimary.Get(ctx, uuid.FromStringOrNil(subject.UserID))
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get user secret from DB")
+		return nil, getReturnErr(err)
+	}
+	return userSecret, nil
+}
+
+// Verify a TOTP code or a recovery code of the subject user with login locks of the user's login ID and the client IP.
+// Wrong codes increase failed logins, so codes can't be guessed with a stolen access token.
+func (m *MFAServiceImp) verifyCode(ctx context.Context, subject *entity.Subject, userSecret *entity.UserSecret, code, ip string) error {
+	userInfo, err := m.userInfoRepoPrimary.Get(ctx, subject.TenantID, userSecret.ID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get user info from DB")
+		return getReturnErr(err)
+	}
+	if err = checkLoginLocks(ctx, m.loginLockRepo, m.loginLockPolicy, subject.TenantID, userInfo.LoginID, ip); err != nil {
+		return err
+	}
+
+	err = verifyMFACode(ctx, m.userSecretRepoPrimary, m.mfaRecoveryCodeRepoPrimary, m.mfaSecret, userSecret, code)
+	if err == ErrMFACodeWrong {
+		addLoginFailures(ctx, m.loginLockRepo, m.loginLockPolicy, subject.TenantID, userInfo.LoginID, ip)
+		return err
+	} else if err != nil {
+		return err
+	}
+	resetLoginFailures(ctx, m.loginLockRepo, m.loginLockPolicy, subject.TenantID, userInfo.LoginID)
+	return nil
+}
+
+// Delete recovery codes of the user and create new ones. Only hashes of codes are stored.
+func (m *MFAServiceImp) createRecoveryCodes(ctx context.Context, tx repo.DBTx, userUUID uuid.EntityUUID) ([]string, error) {
+	if err := m.mfaRecoveryCodeRepoPrimary.WithTx(tx).DeleteByUser(ctx, userUUID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete MFA recovery codes from DB")
+		return nil, getReturnErr(err)
+	}
+
+	codes := []string{}
+	recoveryCodes := []entity.MFARecoveryCode{}
+	for i := 0; i < mfaRecoveryCodeCount; i++ {
+		code, err := generateMFARecoveryCode()
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to generate MFA recovery code")
+			return nil, ErrServerErr
+		}
+		codes = append(codes, code)
+		recoveryCodes = append(recoveryCodes, entity.MFARecoveryCode{
+			ID:       uuid.NewV4(),
+			UserID:   userUUID,
+			CodeHash: getMFARecoveryCodeHash(userUUID, code),
+		})
+	}
+	if err := m.mfaRecoveryCodeRepoPrimary.WithTx(tx).CreateAll(ctx, recoveryCodes); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to create MFA recovery codes in DB")
+		return nil, getReturnErr(err)
+	}
+	return codes, nil
+}
+
+// Verify a TOTP code or a recovery code of the user. TOTP codes of the last used time step or before are rejected,
+// and recovery codes are deleted when they are used.
+func verifyMFACode(ctx context.Context, userSecretRepo repo.UserSecretRepo, mfaRecoveryCodeRepo repo.MFARecoveryCodeRepo,
+	mfaSecret []byte, userSecret *entity.UserSecret, code string) error {
+	if !isTOTPCode(code) {
+		err := mfaRecoveryCodeRepo.DeleteByCodeHash(ctx, userSecret.ID, getMFARecoveryCodeHash(userSecret.ID, code))
+		if err == repo.ErrNotFound {
+			log.Ctx(ctx).Error().Msg("MFA recovery code is wrong")
+			return ErrMFACodeWrong
+		} else if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to delete MFA recovery code from DB")
+			return getReturnErr(err)
+		}
+		log.Ctx(ctx).Warn().Str("user_id", userSecret.ID.String()).Msg("MFA recovery code is used")
+		return nil
+	}
+
+	secret, err := cipher.Decrypt(mfaSecret, userSecret.TOTPSecret)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to decrypt TOTP secret")
+		return ErrServerErr
+	}
+	step, ok := totp.ValidateCode(secret, code, time.Now())
+	if !ok || step <= userSecret.TOTPLastStep {
+		log.Ctx(ctx).Error().Msg("TOTP code is wrong or already used")
+		return ErrMFACodeWrong
+	}
+	err = userSecretRepo.UpdateTOTPLastStep(ctx, userSecret.ID, step)
+	if err == repo.ErrNotFound {
+		log.Ctx(ctx).Error().Msg("TOTP code is already used")
+		return ErrMFACodeWrong
+	} else if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to update TOTP last step in DB")
+		return getReturnErr(err)
+	}
+	return nil
+}
+
+func isTOTPCode(code string) bool {
+	if len(code) != totp.Digits {
+		return false
+	}
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Generate a recovery code like "abcde-fghij"
+func generateMFARecoveryCode() (string, error) {
+	random, err := hashing.GetSalt(mfaRecoveryCodeSize * 5 / 8)
+	if err != nil {
+		return "", err
+	}
+	code := strings.ToLower(mfaRecoveryCodeEncoding.EncodeToString(random))
+	return code[:mfaRecoveryCodeSize/2] + "-" + code[mfaRecoveryCodeSize/2:], nil
+}
+
+// Get the hash of the recovery code salted with the user ID. Recovery codes are case insensitive,
+// and separators and spaces are ignored.
+func getMFARecoveryCodeHash(userUUID uuid.EntityUUID, code string) []byte {
+	code = strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	return hashing.GetStrHash(code, userUUID.Bytes())
+}
@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/repo"
+	"github.com/ssup2ket/service-auth/internal/domain/repo/mocks"
+	"github.com/ssup2ket/service-auth/internal/test"
+	"github.com/ssup2ket/service-auth/pkg/auth/cipher"
+	"github.com/ssup2ket/service-auth/pkg/auth/totp"
+)
+
+func TestMFA(t *testing.T) {
+	suite.Run(t, new(mfaSuite))
+}
+
+type mfaSuite struct {
+	suite.Suite
+
+	dbTx                mocks.DBTx
+	userInfoRepo        mocks.UserInfoRepo
+	userSecretRepo      mocks.UserSecretRepo
+	mfaRecoveryCodeRepo mocks.MFARecoveryCodeRepo
+	loginLockRepo       mocks.LoginLockRepo
+
+	subject    *entity.Subject
+	totpSecret []byte
+
+	mfaService MFAService
+}
+
+func (m *mfaSuite) SetupTest() {
+	var err error
+
+	// Init transaction, repo
+	m.dbTx = mocks.DBTx{}
+	m.userInfoRepo = mocks.UserInfoRepo{}
+	m.userSecretRepo = mocks.UserSecretRepo{}
+	m.mfaRecoveryCodeRepo = mocks.MFARecoveryCodeRepo{}
+	m.loginLockRepo = mocks.LoginLockRepo{}
+
+	// Set nooptracer
+	opentracing.SetGlobalTracer(opentracing.NoopTracer{})
+
+	// Init subject and encrypted TOTP secret
+	m.subject = &entity.Subject{UserID: test.UserIDCorrect.String(), TenantID: test.TenantIDCorrect}
+	m.totpSecret, err = cipher.Encrypt([]byte(test.MFASecretCorrect), test.MFATOTPSecretCorrect)
+	require.NoError(m.T(), err)
+
+	// Init service. Login locks are disabled except login lock tests.
+	m.mfaService = NewMFAServiceImp(&m.dbTx, &m.userInfoRepo, &m.userSecretRepo, &m.mfaRecoveryCodeRepo,
+		&m.loginLockRepo, &LoginLockPolicy{}, []byte(test.MFASecretCorrect), test.MFATOTPIssuerCorrect)
+	m.userInfoRepo.On("Get", context.Background(), test.TenantIDCorrect, test.UserIDCorrect).
+		Return(&entity.UserInfo{ID: test.UserIDCorrect, LoginID: test.UserLoginIDCorrect}, nil)
+}
+
+// Mock the user secret with the TOTP secret
+func (m *mfaSuite) mockUserSecret(enabled bool) {
+	userSecret := &entity.UserSecret{ID: test.UserIDCorrect, TOTPSecret: m.totpSecret}
+	if enabled {
+		enabledAt := time.Now()
+		userSecret.TOTPEnabledAt = &enabledAt
+	}
+	m.userSecretRepo.On("Get", context.Background(), test.UserIDCorrect).Return(userSecret, nil)
+}
+
+// Mock the transaction creating recovery codes
+func (m *mfaSuite) mockCreateRecoveryCodes() {
+	m.dbTx.On("Begin").Return(&m.dbTx, nil)
+	m.dbTx.On("Commit").Return(nil)
+	m.userSecretRepo.On("WithTx", mock.Anything).Return(&m.userSecretRepo)
+	m.mfaRecoveryCodeRepo.On("WithTx", mock.Anything).Return(&m.mfaRecoveryCodeRepo)
+	m.mfaRecoveryCodeRepo.On("DeleteByUser", context.Background(), test.UserIDCorrect).Return(nil)
+	m.mfaRecoveryCodeRepo.On("CreateAll", context.Background(), mock.Anything).Return(nil)
+}
+
+func (m *mfaSuite) TestGetMFASuccess() {
+	m.mockUserSecret(true)
+	m.mfaRecoveryCodeRepo.On("CountByUser", context.Background(), test.UserIDCorrect).Return(10, nil)
+
+	mfaInfo, err := m.mfaService.GetMFA(context.Background(), m.subject)
+	require.NoError(m.T(), err)
+	require.True(m.T(), mfaInfo.TOTPEnabled)
+	require.Equal(m.T(), 10, mfaInfo.RecoveryCodes)
+}
+
+func (m *mfaSuite) TestGetMFAClient() {
+	_, err := m.mfaService.GetMFA(context.Background(), &entity.Subject{TenantID: test.TenantIDCorrect})
+	require.Equal(m.T(), ErrUnauthorized, err)
+}
+
+func (m *mfaSuite) TestEnrollTOTPSuccess() {
+	m.userSecretRepo.On("Get", context.Background(), test.UserIDCorrect).Return(&entity.UserSecret{ID: test.UserIDCorrect}, nil)
+	m.userInfoRepo.On("Get", context.Background(), test.TenantIDCorrect, test.UserIDCorrect).
+		Return(&entity.UserInfo{ID: test.UserIDCorrect, LoginID: test.UserLoginIDCorrect}, nil)
+	var updatedSecret *entity.UserSecret
+	m.userSecretRepo.On("UpdateTOTP", context.Background(), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		updatedSecret = args.Get(1).(*entity.UserSecret)
+	})
+
+	enrollment, err := m.mfaService.EnrollTOTP(context.Background(), m.subject)
+	require.NoError(m.T(), err)
+	uri, err := url.Parse(enrollment.URI)
+	require.NoError(m.T(), err)
+	require.Equal(m.T(), "/"+test.MFATOTPIssuerCorrect+":"+test.UserLoginIDCorrect, uri.Path)
+
+	// Secret is stored encrypted and TOTP isn't enabled yet
+	require.Nil(m.T(), updatedSecret.TOTPEnabledAt)
+	secret, err := cipher.Decrypt([]byte(test.MFASecretCorrect), updatedSecret.TOTPSecret)
+	require.NoError(m.T(), err)
+	require.Equal(m.T(), totp.EncodeSecret(secret), enrollment.Secret)
+}
+
+func (m *mfaSuite) TestEnrollTOTPAlreadyEnabled() {
+	m.mockUserSecret(true)
+
+	_, err := m.mfaService.EnrollTOTP(context.Background(), m.subject)
+	require.Equal(m.T(), ErrMFAAlreadyEnabled, err)
+}
+
+func (m *mfaSuite) TestEnrollTOTPDisabled() {
+	mfaService := NewMFAServiceImp(&m.dbTx, &m.userInfoRepo, &m.userSecretRepo, &m.mfaRecoveryCodeRepo,
+		&m.loginLockRepo, &LoginLockPolicy{}, nil, test.MFATOTPIssuerCorrect)
+
+	_, err := mfaService.EnrollTOTP(context.Background(), m.subject)
+	require.Equal(m.T(), ErrMFADisabled, err)
+}
+
+func (m *mfaSuite) TestConfirmTOTPSuccess() {
+	m.mockUserSecret(false)
+	m.mockCreateRecoveryCodes()
+	var updatedSecret *entity.UserSecret
+	m.userSecretRepo.On("UpdateTOTP", context.Background(), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		updatedSecret = args.Get(1).(*entity.UserSecret)
+	})
+
+	now := time.Now()
+	recoveryCodes, err := m.mfaService.ConfirmTOTP(context.Background(), m.subject, totp.GetCode(test.MFATOTPSecretCorrect, now))
+	require.NoError(m.T(), err)
+	require.Len(m.T(), recoveryCodes, mfaRecoveryCodeCount)
+	require.True(m.T(), updatedSecret.IsTOTPEnabled())
+	require.Equal(m.T(), totp.GetStep(now), updatedSecret.TOTPLastStep)
+
+	// Only hashes of recovery codes are stored
+	m.mfaRecoveryCodeRepo.AssertCalled(m.T(), "CreateAll", context.Background(), mock.MatchedBy(func(codes []entity.MFARecoveryCode) bool {
+		return len(codes) == mfaRecoveryCodeCount &&
+			string(codes[0].CodeHash) == string(getMFARecoveryCodeHash(test.UserIDCorrect, recoveryCodes[0]))
+	}))
+}
+
+func (m *mfaSuite) TestConfirmTOTPCodeWrong() {
+	m.mockUserSecret(false)
+
+	_, err := m.mfaService.ConfirmTOTP(context.Background(), m.subject, totp.GetCode(test.MFATOTPSecretCorrect, time.Now().Add(-time.Hour)))
+	require.Equal(m.T(), ErrMFACodeWrong, err)
+}
+
+func (m *mfaSuite) TestConfirmTOTPNotEnrolled() {
+	m.userSecretRepo.On("Get", context.Background(), test.UserIDCorrect).Return(&entity.UserSecret{ID: test.UserIDCorrect}, nil)
+
+	_, err := m.mfaService.ConfirmTOTP(context.Background(), m.subject, totp.GetCode(test.MFATOTPSecretCorrect, time.Now()))
+	require.Equal(m.T(), ErrMFANotEnabled, err)
+}
+
+func (m *mfaSuite) TestDisableTOTPRecoveryCode() {
+	m.mockUserSecret(true)
+	m.mfaRecoveryCodeRepo.On("DeleteByCodeHash", context.Background(), test.UserIDCorrect,
+		getMFARecoveryCodeHash(test.UserIDCorrect, test.MFARecoveryCodeCorrect)).Return(nil)
+	m.dbTx.On("Begin").Return(&m.dbTx, nil)
+	m.dbTx.On("Commit").Return(nil)
+	m.userSecretRepo.On("WithTx", mock.Anything).Return(&m.userSecretRepo)
+	m.userSecretRepo.On("UpdateTOTP", context.Background(), &entity.UserSecret{ID: test.UserIDCorrect}).Return(nil)
+	m.mfaRecoveryCodeRepo.On("WithTx", mock.Anything).Return(&m.mfaRecoveryCodeRepo)
+	m.mfaRecoveryCodeRepo.On("DeleteByUser", context.Background(), test.UserIDCorrect).Return(nil)
+
+	err := m.mfaService.DisableTOTP(context.Background(), m.subject, test.MFARecoveryCodeCorrect, test.LoginLockIPCorrect)
+	require.NoError(m.T(), err)
+}
+
+func (m *mfaSuite) TestDisableTOTPNotEnabled() {
+	m.mockUserSecret(false)
+
+	err := m.mfaService.DisableTOTP(context.Background(), m.subject, test.MFARecoveryCodeCorrect, test.LoginLockIPCorrect)
+	require.Equal(m.T(), ErrMFANotEnabled, err)
+}
+
+func (m *mfaSuite) TestRegenerateMFARecoveryCodesSuccess() {
+	m.mockUserSecret(true)
+	m.mockCreateRecoveryCodes()
+	m.userSecretRepo.On("UpdateTOTPLastStep", context.Background(), test.UserIDCorrect, mock.Anything).Return(nil)
+
+	recoveryCodes, err := m.mfaService.RegenerateMFARecoveryCodes(context.Background(), m.subject,
+		totp.GetCode(test.MFATOTPSecretCorrect, time.Now()), test.LoginLockIPCorrect)
+	require.NoError(m.T(), err)
+	require.Len(m.T(), recoveryCodes, mfaRecoveryCodeCount)
+}
+
+func (m *mfaSuite) TestRegenerateMFARecoveryCodesCodeUsed() {
+	// Another request used the time step concurrently
+	m.mockUserSecret(true)
+	m.userSecretRepo.On("UpdateTOTPLastStep", context.Background(), test.UserIDCorrect, mock.Anything).Return(repo.ErrNotFound)
+
+	_, err := m.mfaService.RegenerateMFARecoveryCodes(context.Background(), m.subject,
+		totp.GetCode(test.MFATOTPSecretCorrect, time.Now()), test.LoginLockIPCorrect)
+	require.Equal(m.T(), ErrMFACodeWrong, err)
+	m.dbTx.AssertNotCalled(m.T(), "Begin")
+}
+
+func (m *mfaSuite) TestDisableTOTPWrongCodeLoginFailures() {
+	m.mfaService = NewMFAServiceImp(&m.dbTx, &m.userInfoRepo, &m.userSecretRepo, &m.mfaRecoveryCodeRepo,
+		&m.loginLockRepo, &LoginLockPolicy{LoginIDThreshold: 5, IPThreshold: 20, Duration: time.Minute, MaxDuration: time.Hour},
+		[]byte(test.MFASecretCorrect), test.MFATOTPIssuerCorrect)
+	m.mockUserSecret(true)
+	m.mfaRecoveryCodeRepo.On("DeleteByCodeHash", context.Background(), test.UserIDCorrect, mock.Anything).Return(repo.ErrNotFound)
+	m.loginLockRepo.On("GetBySubject", context.Background(), mock.Anything, mock.Anything, mock.Anything).Return(nil, repo.ErrNotFound)
+	m.loginLockRepo.On("IncreaseFailures", context.Background(), mock.MatchedBy(func(l *entity.LoginLock) bool {
+		return l.Type == entity.LoginLockTypeLoginID && l.TenantID == test.TenantIDCorrect && l.Subject == test.UserLoginIDCorrect
+	}), mock.Anything).Return(&entity.LoginLock{ID: test.LoginLockIDCorrect, Failures: 1}, nil)
+	m.loginLockRepo.On("IncreaseFailures", context.Background(), mock.MatchedBy(func(l *entity.LoginLock) bool {
+		return l.Type == entity.LoginLockTypeIP && l.Subject == test.LoginLockIPCorrect
+	}), mock.Anything).Return(&entity.LoginLock{ID: test.LoginLockIDCorrect, Failures: 1}, nil)
+
+	// Wrong codes increase failed logins of the login ID and the client IP
+	err := m.mfaService.DisableTOTP(context.Background(), m.subject, test.MFARecoveryCodeCorrect, test.LoginLockIPCorrect)
+	require.Equal(m.T(), ErrMFACodeWrong, err)
+	m.loginLockRepo.AssertNumberOfCalls(m.T(), "IncreaseFailures", 2)
+	m.dbTx.AssertNotCalled(m.T(), "Begin")
+}
+
+func (m *mfaSuite) TestRegenerateMFARecoveryCodesLoginLocked() {
+	m.mfaService = NewMFAServiceImp(&m.dbTx, &m.userInfoRepo, &m.userSecretRepo, &m.mfaRecoveryCodeRepo,
+		&m.loginLockRepo, &LoginLockPolicy{LoginIDThreshold: 5, IPThreshold: 20, Duration: time.Minute, MaxDuration: time.Hour},
+		[]byte(test.MFASecretCorrect), test.MFATOTPIssuerCorrect)
+	m.mockUserSecret(true)
+	m.loginLockRepo.On("GetBySubject", context.Background(), entity.LoginLockTypeLoginID, test.TenantIDCorrect, test.UserLoginIDCorrect).
+		Return(&entity.LoginLock{LockedUntil: time.Now().Add(time.Minute)}, nil)
+
+	// Even the correct code isn't verified while the login is locked
+	_, err := m.mfaService.RegenerateMFARecoveryCodes(context.Background(), m.subject,
+		totp.GetCode(test.MFATOTPSecretCorrect, time.Now()), test.LoginLockIPCorrect)
+	require.Equal(m.T(), ErrLoginLocked, err)
+	m.userSecretRepo.AssertNotCalled(m.T(), "UpdateTOTPLastStep", mock.Anything, mock.Anything, mock.Anything)
+}
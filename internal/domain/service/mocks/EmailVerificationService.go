@@ -0,0 +1,58 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/ssup2ket/service-auth/internal/domain/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// EmailVerificationService is an autogenerated mock type for the EmailVerificationService type
+type EmailVerificationService struct {
+	mock.Mock
+}
+
+// SendEmailVerification provides a mock function with given fields: ctx, subject
+func (_m *EmailVerificationService) SendEmailVerification(ctx context.Context, subject *entity.Subject) error {
+	ret := _m.Called(ctx, subject)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Subject) error); ok {
+		r0 = rf(ctx, subject)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VerifyEmail provides a mock function with given fields: ctx, tenantID, token
+func (_m *EmailVerificationService) VerifyEmail(ctx context.Context, tenantID string, token string) error {
+	ret := _m.Called(ctx, tenantID, token)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenantID, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewEmailVerificationService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewEmailVerificationService creates a new instance of EmailVerificationService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewEmailVerificationService(t mockConstructorTestingTNewEmailVerificationService) *EmailVerificationService {
+	mock := &EmailVerificationService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,189 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/ssup2ket/service-auth/internal/domain/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+// GroupService is an autogenerated mock type for the GroupService type
+type GroupService struct {
+	mock.Mock
+}
+
+// AddGroupMember provides a mock function with given fields: ctx, subject, groupMember
+func (_m *GroupService) AddGroupMember(ctx context.Context, subject *entity.Subject, groupMember *entity.GroupMember) (*entity.GroupMember, error) {
+	ret := _m.Called(ctx, subject, groupMember)
+
+	var r0 *entity.GroupMember
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Subject, *entity.GroupMember) *entity.GroupMember); ok {
+		r0 = rf(ctx, subject, groupMember)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.GroupMember)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.Subject, *entity.GroupMember) error); ok {
+		r1 = rf(ctx, subject, groupMember)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateGroup provides a mock function with given fields: ctx, subject, group
+func (_m *GroupService) CreateGroup(ctx context.Context, subject *entity.Subject, group *entity.Group) (*entity.Group, error) {
+	ret := _m.Called(ctx, subject, group)
+
+	var r0 *entity.Group
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Subject, *entity.Group) *entity.Group); ok {
+		r0 = rf(ctx, subject, group)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Group)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.Subject, *entity.Group) error); ok {
+		r1 = rf(ctx, subject, group)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteGroup provides a mock function with given fields: ctx, subject, groupUUID
+func (_m *GroupService) DeleteGroup(ctx context.Context, subject *entity.Subject, groupUUID uuid.EntityUUID) error {
+	ret := _m.Called(ctx, subject, groupUUID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Subject, uuid.EntityUUID) error); ok {
+		r0 = rf(ctx, subject, groupUUID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetGroup provides a mock function with given fields: ctx, tenantID, groupUUID
+func (_m *GroupService) GetGroup(ctx context.Context, tenantID string, groupUUID uuid.EntityUUID) (*entity.Group, error) {
+	ret := _m.Called(ctx, tenantID, groupUUID)
+
+	var r0 *entity.Group
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.EntityUUID) *entity.Group); ok {
+		r0 = rf(ctx, tenantID, groupUUID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Group)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.EntityUUID) error); ok {
+		r1 = rf(ctx, tenantID, groupUUID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListGroup provides a mock function with given fields: ctx, tenantID, offset, limit
+func (_m *GroupService) ListGroup(ctx context.Context, tenantID string, offset int, limit int) ([]entity.Group, error) {
+	ret := _m.Called(ctx, tenantID, offset, limit)
+
+	var r0 []entity.Group
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []entity.Group); ok {
+		r0 = rf(ctx, tenantID, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Group)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(ctx, tenantID, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListGroupMember provides a mock function with given fields: ctx, tenantID, groupUUID, offset, limit
+func (_m *GroupService) ListGroupMember(ctx context.Context, tenantID string, groupUUID uuid.EntityUUID, offset int, limit int) ([]entity.GroupMember, error) {
+	ret := _m.Called(ctx, tenantID, groupUUID, offset, limit)
+
+	var r0 []entity.GroupMember
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.EntityUUID, int, int) []entity.GroupMember); ok {
+		r0 = rf(ctx, tenantID, groupUUID, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.GroupMember)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.EntityUUID, int, int) error); ok {
+		r1 = rf(ctx, tenantID, groupUUID, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveGroupMember provides a mock function with given fields: ctx, subject, groupUUID, memberUUID
+func (_m *GroupService) RemoveGroupMember(ctx context.Context, subject *entity.Subject, groupUUID uuid.EntityUUID, memberUUID uuid.EntityUUID) error {
+	ret := _m.Called(ctx, subject, groupUUID, memberUUID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Subject, uuid.EntityUUID, uuid.EntityUUID) error); ok {
+		r0 = rf(ctx, subject, groupUUID, memberUUID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateGroup provides a mock function with given fields: ctx, subject, group
+func (_m *GroupService) UpdateGroup(ctx context.Context, subject *entity.Subject, group *entity.Group) error {
+	ret := _m.Called(ctx, subject, group)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Subject, *entity.Group) error); ok {
+		r0 = rf(ctx, subject, group)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewGroupService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewGroupService creates a new instance of GroupService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewGroupService(t mockConstructorTestingTNewGroupService) *GroupService {
+	mock := &GroupService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
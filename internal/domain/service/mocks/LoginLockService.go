@@ -0,0 +1,106 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/ssup2ket/service-auth/internal/domain/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+// LoginLockService is an autogenerated mock type for the LoginLockService type
+type LoginLockService struct {
+	mock.Mock
+}
+
+// DeleteExpiredLoginLocks provides a mock function with given fields: ctx
+func (_m *LoginLockService) DeleteExpiredLoginLocks(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteLoginLock provides a mock function with given fields: ctx, lockUUID
+func (_m *LoginLockService) DeleteLoginLock(ctx context.Context, lockUUID uuid.EntityUUID) error {
+	ret := _m.Called(ctx, lockUUID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID) error); ok {
+		r0 = rf(ctx, lockUUID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetLoginLock provides a mock function with given fields: ctx, lockUUID
+func (_m *LoginLockService) GetLoginLock(ctx context.Context, lockUUID uuid.EntityUUID) (*entity.LoginLock, error) {
+	ret := _m.Called(ctx, lockUUID)
+
+	var r0 *entity.LoginLock
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID) *entity.LoginLock); ok {
+		r0 = rf(ctx, lockUUID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.LoginLock)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.EntityUUID) error); ok {
+		r1 = rf(ctx, lockUUID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListLoginLock provides a mock function with given fields: ctx, offset, limit
+func (_m *LoginLockService) ListLoginLock(ctx context.Context, offset int, limit int) ([]entity.LoginLock, error) {
+	ret := _m.Called(ctx, offset, limit)
+
+	var r0 []entity.LoginLock
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []entity.LoginLock); ok {
+		r0 = rf(ctx, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.LoginLock)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewLoginLockService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewLoginLockService creates a new instance of LoginLockService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewLoginLockService(t mockConstructorTestingTNewLoginLockService) *LoginLockService {
+	mock := &LoginLockService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,136 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/ssup2ket/service-auth/internal/domain/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MFAService is an autogenerated mock type for the MFAService type
+type MFAService struct {
+	mock.Mock
+}
+
+// ConfirmTOTP provides a mock function with given fields: ctx, subject, code
+func (_m *MFAService) ConfirmTOTP(ctx context.Context, subject *entity.Subject, code string) ([]string, error) {
+	ret := _m.Called(ctx, subject, code)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Subject, string) []string); ok {
+		r0 = rf(ctx, subject, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.Subject, string) error); ok {
+		r1 = rf(ctx, subject, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DisableTOTP provides a mock function with given fields: ctx, subject, code, ip
+func (_m *MFAService) DisableTOTP(ctx context.Context, subject *entity.Subject, code string, ip string) error {
+	ret := _m.Called(ctx, subject, code, ip)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Subject, string, string) error); ok {
+		r0 = rf(ctx, subject, code, ip)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnrollTOTP provides a mock function with given fields: ctx, subject
+func (_m *MFAService) EnrollTOTP(ctx context.Context, subject *entity.Subject) (*entity.TOTPEnrollment, error) {
+	ret := _m.Called(ctx, subject)
+
+	var r0 *entity.TOTPEnrollment
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Subject) *entity.TOTPEnrollment); ok {
+		r0 = rf(ctx, subject)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.TOTPEnrollment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.Subject) error); ok {
+		r1 = rf(ctx, subject)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMFA provides a mock function with given fields: ctx, subject
+func (_m *MFAService) GetMFA(ctx context.Context, subject *entity.Subject) (*entity.MFAInfo, error) {
+	ret := _m.Called(ctx, subject)
+
+	var r0 *entity.MFAInfo
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Subject) *entity.MFAInfo); ok {
+		r0 = rf(ctx, subject)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.MFAInfo)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.Subject) error); ok {
+		r1 = rf(ctx, subject)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RegenerateMFARecoveryCodes provides a mock function with given fields: ctx, subject, code, ip
+func (_m *MFAService) RegenerateMFARecoveryCodes(ctx context.Context, subject *entity.Subject, code string, ip string) ([]string, error) {
+	ret := _m.Called(ctx, subject, code, ip)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Subject, string, string) []string); ok {
+		r0 = rf(ctx, subject, code, ip)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.Subject, string, string) error); ok {
+		r1 = rf(ctx, subject, code, ip)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewMFAService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMFAService creates a new instance of MFAService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMFAService(t mockConstructorTestingTNewMFAService) *MFAService {
+	mock := &MFAService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,136 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/ssup2ket/service-auth/internal/domain/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+// OAuthClientService is an autogenerated mock type for the OAuthClientService type
+type OAuthClientService struct {
+	mock.Mock
+}
+
+// CreateOAuthClient provides a mock function with given fields: ctx, client
+func (_m *OAuthClientService) CreateOAuthClient(ctx context.Context, client *entity.OAuthClient) (*entity.OAuthClient, string, error) {
+	ret := _m.Called(ctx, client)
+
+	var r0 *entity.OAuthClient
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.OAuthClient) *entity.OAuthClient); ok {
+		r0 = rf(ctx, client)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.OAuthClient)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.OAuthClient) string); ok {
+		r1 = rf(ctx, client)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *entity.OAuthClient) error); ok {
+		r2 = rf(ctx, client)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// DeleteOAuthClient provides a mock function with given fields: ctx, clientUUID
+func (_m *OAuthClientService) DeleteOAuthClient(ctx context.Context, clientUUID uuid.EntityUUID) error {
+	ret := _m.Called(ctx, clientUUID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID) error); ok {
+		r0 = rf(ctx, clientUUID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetOAuthClient provides a mock function with given fields: ctx, clientUUID
+func (_m *OAuthClientService) GetOAuthClient(ctx context.Context, clientUUID uuid.EntityUUID) (*entity.OAuthClient, error) {
+	ret := _m.Called(ctx, clientUUID)
+
+	var r0 *entity.OAuthClient
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID) *entity.OAuthClient); ok {
+		r0 = rf(ctx, clientUUID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.OAuthClient)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.EntityUUID) error); ok {
+		r1 = rf(ctx, clientUUID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListOAuthClient provides a mock function with given fields: ctx, offset, limit
+func (_m *OAuthClientService) ListOAuthClient(ctx context.Context, offset int, limit int) ([]entity.OAuthClient, error) {
+	ret := _m.Called(ctx, offset, limit)
+
+	var r0 []entity.OAuthClient
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []entity.OAuthClient); ok {
+		r0 = rf(ctx, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.OAuthClient)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateOAuthClient provides a mock function with given fields: ctx, client
+func (_m *OAuthClientService) UpdateOAuthClient(ctx context.Context, client *entity.OAuthClient) error {
+	ret := _m.Called(ctx, client)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.OAuthClient) error); ok {
+		r0 = rf(ctx, client)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewOAuthClientService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewOAuthClientService creates a new instance of OAuthClientService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewOAuthClientService(t mockConstructorTestingTNewOAuthClientService) *OAuthClientService {
+	mock := &OAuthClientService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,167 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/ssup2ket/service-auth/internal/domain/entity"
+	service "github.com/ssup2ket/service-auth/internal/domain/service"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OAuthService is an autogenerated mock type for the OAuthService type
+type OAuthService struct {
+	mock.Mock
+}
+
+// AuthenticateClient provides a mock function with given fields: ctx, clientID, clientSecret
+func (_m *OAuthService) AuthenticateClient(ctx context.Context, clientID string, clientSecret string) (*entity.OAuthClient, error) {
+	ret := _m.Called(ctx, clientID, clientSecret)
+
+	var r0 *entity.OAuthClient
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *entity.OAuthClient); ok {
+		r0 = rf(ctx, clientID, clientSecret)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.OAuthClient)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, clientID, clientSecret)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateAuthCode provides a mock function with given fields: ctx, req, userInfo
+func (_m *OAuthService) CreateAuthCode(ctx context.Context, req *service.OAuthAuthorizeRequest, userInfo *entity.UserInfo) (string, error) {
+	ret := _m.Called(ctx, req, userInfo)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, *service.OAuthAuthorizeRequest, *entity.UserInfo) string); ok {
+		r0 = rf(ctx, req, userInfo)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *service.OAuthAuthorizeRequest, *entity.UserInfo) error); ok {
+		r1 = rf(ctx, req, userInfo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateClientToken provides a mock function with given fields: ctx, client, scope
+func (_m *OAuthService) CreateClientToken(ctx context.Context, client *entity.OAuthClient, scope string) (*service.OAuthTokens, error) {
+	ret := _m.Called(ctx, client, scope)
+
+	var r0 *service.OAuthTokens
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.OAuthClient, string) *service.OAuthTokens); ok {
+		r0 = rf(ctx, client, scope)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.OAuthTokens)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.OAuthClient, string) error); ok {
+		r1 = rf(ctx, client, scope)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExchangeAuthCode provides a mock function with given fields: ctx, client, code, redirectURI, codeVerifier, session
+func (_m *OAuthService) ExchangeAuthCode(ctx context.Context, client *entity.OAuthClient, code string, redirectURI string, codeVerifier string, session *entity.Session) (*service.OAuthTokens, error) {
+	ret := _m.Called(ctx, client, code, redirectURI, codeVerifier, session)
+
+	var r0 *service.OAuthTokens
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.OAuthClient, string, string, string, *entity.Session) *service.OAuthTokens); ok {
+		r0 = rf(ctx, client, code, redirectURI, codeVerifier, session)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.OAuthTokens)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.OAuthClient, string, string, string, *entity.Session) error); ok {
+		r1 = rf(ctx, client, code, redirectURI, codeVerifier, session)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefreshToken provides a mock function with given fields: ctx, client, refreshToken
+func (_m *OAuthService) RefreshToken(ctx context.Context, client *entity.OAuthClient, refreshToken string) (*service.OAuthTokens, error) {
+	ret := _m.Called(ctx, client, refreshToken)
+
+	var r0 *service.OAuthTokens
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.OAuthClient, string) *service.OAuthTokens); ok {
+		r0 = rf(ctx, client, refreshToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.OAuthTokens)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.OAuthClient, string) error); ok {
+		r1 = rf(ctx, client, refreshToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ValidateAuthorizeRequest provides a mock function with given fields: ctx, req
+func (_m *OAuthService) ValidateAuthorizeRequest(ctx context.Context, req *service.OAuthAuthorizeRequest) (*entity.OAuthClient, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *entity.OAuthClient
+	if rf, ok := ret.Get(0).(func(context.Context, *service.OAuthAuthorizeRequest) *entity.OAuthClient); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.OAuthClient)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *service.OAuthAuthorizeRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewOAuthService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewOAuthService creates a new instance of OAuthService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewOAuthService(t mockConstructorTestingTNewOAuthService) *OAuthService {
+	mock := &OAuthService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,115 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/ssup2ket/service-auth/internal/domain/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+// PasskeyService is an autogenerated mock type for the PasskeyService type
+type PasskeyService struct {
+	mock.Mock
+}
+
+// BeginPasskeyRegistration provides a mock function with given fields: ctx, subject
+func (_m *PasskeyService) BeginPasskeyRegistration(ctx context.Context, subject *entity.Subject) (*entity.PasskeyRegistration, error) {
+	ret := _m.Called(ctx, subject)
+
+	var r0 *entity.PasskeyRegistration
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Subject) *entity.PasskeyRegistration); ok {
+		r0 = rf(ctx, subject)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PasskeyRegistration)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.Subject) error); ok {
+		r1 = rf(ctx, subject)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeletePasskey provides a mock function with given fields: ctx, subject, credentialUUID
+func (_m *PasskeyService) DeletePasskey(ctx context.Context, subject *entity.Subject, credentialUUID uuid.EntityUUID) error {
+	ret := _m.Called(ctx, subject, credentialUUID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Subject, uuid.EntityUUID) error); ok {
+		r0 = rf(ctx, subject, credentialUUID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FinishPasskeyRegistration provides a mock function with given fields: ctx, subject, challengeUUID, name, attestation
+func (_m *PasskeyService) FinishPasskeyRegistration(ctx context.Context, subject *entity.Subject, challengeUUID uuid.EntityUUID, name string, attestation *entity.PasskeyAttestation) (*entity.WebAuthnCredential, error) {
+	ret := _m.Called(ctx, subject, challengeUUID, name, attestation)
+
+	var r0 *entity.WebAuthnCredential
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Subject, uuid.EntityUUID, string, *entity.PasskeyAttestation) *entity.WebAuthnCredential); ok {
+		r0 = rf(ctx, subject, challengeUUID, name, attestation)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.WebAuthnCredential)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.Subject, uuid.EntityUUID, string, *entity.PasskeyAttestation) error); ok {
+		r1 = rf(ctx, subject, challengeUUID, name, attestation)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPasskey provides a mock function with given fields: ctx, subject
+func (_m *PasskeyService) ListPasskey(ctx context.Context, subject *entity.Subject) ([]entity.WebAuthnCredential, error) {
+	ret := _m.Called(ctx, subject)
+
+	var r0 []entity.WebAuthnCredential
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Subject) []entity.WebAuthnCredential); ok {
+		r0 = rf(ctx, subject)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.WebAuthnCredential)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.Subject) error); ok {
+		r1 = rf(ctx, subject)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewPasskeyService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPasskeyService creates a new instance of PasskeyService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPasskeyService(t mockConstructorTestingTNewPasskeyService) *PasskeyService {
+	mock := &PasskeyService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,143 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/ssup2ket/service-auth/internal/domain/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+// PermissionService is an autogenerated mock type for the PermissionService type
+type PermissionService struct {
+	mock.Mock
+}
+
+// CreatePermission provides a mock function with given fields: ctx, permission
+func (_m *PermissionService) CreatePermission(ctx context.Context, permission *entity.Permission) (*entity.Permission, error) {
+	ret := _m.Called(ctx, permission)
+
+	var r0 *entity.Permission
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Permission) *entity.Permission); ok {
+		r0 = rf(ctx, permission)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Permission)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.Permission) error); ok {
+		r1 = rf(ctx, permission)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeletePermission provides a mock function with given fields: ctx, permissionUUID
+func (_m *PermissionService) DeletePermission(ctx context.Context, permissionUUID uuid.EntityUUID) error {
+	ret := _m.Called(ctx, permissionUUID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID) error); ok {
+		r0 = rf(ctx, permissionUUID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetPermission provides a mock function with given fields: ctx, permissionUUID
+func (_m *PermissionService) GetPermission(ctx context.Context, permissionUUID uuid.EntityUUID) (*entity.Permission, error) {
+	ret := _m.Called(ctx, permissionUUID)
+
+	var r0 *entity.Permission
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID) *entity.Permission); ok {
+		r0 = rf(ctx, permissionUUID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Permission)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.EntityUUID) error); ok {
+		r1 = rf(ctx, permissionUUID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InitPermissions provides a mock function with given fields: ctx, policies
+func (_m *PermissionService) InitPermissions(ctx context.Context, policies [][]string) error {
+	ret := _m.Called(ctx, policies)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, [][]string) error); ok {
+		r0 = rf(ctx, policies)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListPermission provides a mock function with given fields: ctx, offset, limit
+func (_m *PermissionService) ListPermission(ctx context.Context, offset int, limit int) ([]entity.Permission, error) {
+	ret := _m.Called(ctx, offset, limit)
+
+	var r0 []entity.Permission
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []entity.Permission); ok {
+		r0 = rf(ctx, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Permission)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdatePermission provides a mock function with given fields: ctx, permission
+func (_m *PermissionService) UpdatePermission(ctx context.Context, permission *entity.Permission) error {
+	ret := _m.Called(ctx, permission)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Permission) error); ok {
+		r0 = rf(ctx, permission)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewPermissionService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPermissionService creates a new instance of PermissionService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPermissionService(t mockConstructorTestingTNewPermissionService) *PermissionService {
+	mock := &PermissionService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
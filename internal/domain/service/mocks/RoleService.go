@@ -0,0 +1,141 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/ssup2ket/service-auth/internal/domain/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RoleService is an autogenerated mock type for the RoleService type
+type RoleService struct {
+	mock.Mock
+}
+
+// CreateDefaultRoles provides a mock function with given fields: ctx
+func (_m *RoleService) CreateDefaultRoles(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateRole provides a mock function with given fields: ctx, role
+func (_m *RoleService) CreateRole(ctx context.Context, role *entity.Role) (*entity.Role, error) {
+	ret := _m.Called(ctx, role)
+
+	var r0 *entity.Role
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Role) *entity.Role); ok {
+		r0 = rf(ctx, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Role)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.Role) error); ok {
+		r1 = rf(ctx, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteRole provides a mock function with given fields: ctx, name
+func (_m *RoleService) DeleteRole(ctx context.Context, name string) error {
+	ret := _m.Called(ctx, name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetRole provides a mock function with given fields: ctx, name
+func (_m *RoleService) GetRole(ctx context.Context, name string) (*entity.Role, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *entity.Role
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Role); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Role)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListRole provides a mock function with given fields: ctx, offset, limit
+func (_m *RoleService) ListRole(ctx context.Context, offset int, limit int) ([]entity.Role, error) {
+	ret := _m.Called(ctx, offset, limit)
+
+	var r0 []entity.Role
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []entity.Role); ok {
+		r0 = rf(ctx, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Role)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateRole provides a mock function with given fields: ctx, role
+func (_m *RoleService) UpdateRole(ctx context.Context, role *entity.Role) error {
+	ret := _m.Called(ctx, role)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Role) error); ok {
+		r0 = rf(ctx, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewRoleService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewRoleService creates a new instance of RoleService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewRoleService(t mockConstructorTestingTNewRoleService) *RoleService {
+	mock := &RoleService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,83 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/ssup2ket/service-auth/internal/domain/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+// SessionService is an autogenerated mock type for the SessionService type
+type SessionService struct {
+	mock.Mock
+}
+
+// ListSession provides a mock function with given fields: ctx, userUUID
+func (_m *SessionService) ListSession(ctx context.Context, userUUID uuid.EntityUUID) ([]entity.Session, error) {
+	ret := _m.Called(ctx, userUUID)
+
+	var r0 []entity.Session
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID) []entity.Session); ok {
+		r0 = rf(ctx, userUUID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Session)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.EntityUUID) error); ok {
+		r1 = rf(ctx, userUUID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeSession provides a mock function with given fields: ctx, userUUID, sessionUUID
+func (_m *SessionService) RevokeSession(ctx context.Context, userUUID uuid.EntityUUID, sessionUUID uuid.EntityUUID) error {
+	ret := _m.Called(ctx, userUUID, sessionUUID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID, uuid.EntityUUID) error); ok {
+		r0 = rf(ctx, userUUID, sessionUUID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeUserSessions provides a mock function with given fields: ctx, userUUID
+func (_m *SessionService) RevokeUserSessions(ctx context.Context, userUUID uuid.EntityUUID) error {
+	ret := _m.Called(ctx, userUUID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID) error); ok {
+		r0 = rf(ctx, userUUID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewSessionService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewSessionService creates a new instance of SessionService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewSessionService(t mockConstructorTestingTNewSessionService) *SessionService {
+	mock := &SessionService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
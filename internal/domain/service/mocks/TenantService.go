@@ -0,0 +1,141 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/ssup2ket/service-auth/internal/domain/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TenantService is an autogenerated mock type for the TenantService type
+type TenantService struct {
+	mock.Mock
+}
+
+// CreateDefaultTenant provides a mock function with given fields: ctx
+func (_m *TenantService) CreateDefaultTenant(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateTenant provides a mock function with given fields: ctx, tenant
+func (_m *TenantService) CreateTenant(ctx context.Context, tenant *entity.Tenant) (*entity.Tenant, error) {
+	ret := _m.Called(ctx, tenant)
+
+	var r0 *entity.Tenant
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Tenant) *entity.Tenant); ok {
+		r0 = rf(ctx, tenant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Tenant)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.Tenant) error); ok {
+		r1 = rf(ctx, tenant)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteTenant provides a mock function with given fields: ctx, tenantID
+func (_m *TenantService) DeleteTenant(ctx context.Context, tenantID string) error {
+	ret := _m.Called(ctx, tenantID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetTenant provides a mock function with given fields: ctx, tenantID
+func (_m *TenantService) GetTenant(ctx context.Context, tenantID string) (*entity.Tenant, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	var r0 *entity.Tenant
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Tenant); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Tenant)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTenant provides a mock function with given fields: ctx, offset, limit
+func (_m *TenantService) ListTenant(ctx context.Context, offset int, limit int) ([]entity.Tenant, error) {
+	ret := _m.Called(ctx, offset, limit)
+
+	var r0 []entity.Tenant
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []entity.Tenant); ok {
+		r0 = rf(ctx, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Tenant)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateTenant provides a mock function with given fields: ctx, tenant
+func (_m *TenantService) UpdateTenant(ctx context.Context, tenant *entity.Tenant) error {
+	ret := _m.Called(ctx, tenant)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Tenant) error); ok {
+		r0 = rf(ctx, tenant)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewTenantService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewTenantService creates a new instance of TenantService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewTenantService(t mockConstructorTestingTNewTenantService) *TenantService {
+	mock := &TenantService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
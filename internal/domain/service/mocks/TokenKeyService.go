@@ -0,0 +1,81 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/ssup2ket/service-auth/internal/domain/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TokenKeyService is an autogenerated mock type for the TokenKeyService type
+type TokenKeyService struct {
+	mock.Mock
+}
+
+// ListTokenKeys provides a mock function with given fields: ctx
+func (_m *TokenKeyService) ListTokenKeys(ctx context.Context) ([]entity.TokenKey, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []entity.TokenKey
+	if rf, ok := ret.Get(0).(func(context.Context) []entity.TokenKey); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.TokenKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RotateTokenKeys provides a mock function with given fields: ctx
+func (_m *TokenKeyService) RotateTokenKeys(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SyncTokenKeys provides a mock function with given fields: ctx
+func (_m *TokenKeyService) SyncTokenKeys(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewTokenKeyService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewTokenKeyService creates a new instance of TokenKeyService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewTokenKeyService(t mockConstructorTestingTNewTokenKeyService) *TokenKeyService {
+	mock := &TokenKeyService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
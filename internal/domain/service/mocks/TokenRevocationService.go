@@ -0,0 +1,110 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	token "github.com/ssup2ket/service-auth/pkg/auth/token"
+)
+
+// TokenRevocationService is an autogenerated mock type for the TokenRevocationService type
+type TokenRevocationService struct {
+	mock.Mock
+}
+
+// IntrospectToken provides a mock function with given fields: ctx, accessToken
+func (_m *TokenRevocationService) IntrospectToken(ctx context.Context, accessToken string) (*token.TokenClaims, bool, error) {
+	ret := _m.Called(ctx, accessToken)
+
+	var r0 *token.TokenClaims
+	if rf, ok := ret.Get(0).(func(context.Context, string) *token.TokenClaims); ok {
+		r0 = rf(ctx, accessToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*token.TokenClaims)
+		}
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(context.Context, string) bool); ok {
+		r1 = rf(ctx, accessToken)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, accessToken)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// IsTokenRevoked provides a mock function with given fields: ctx, claims
+func (_m *TokenRevocationService) IsTokenRevoked(ctx context.Context, claims *token.TokenClaims) (bool, error) {
+	ret := _m.Called(ctx, claims)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, *token.TokenClaims) bool); ok {
+		r0 = rf(ctx, claims)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *token.TokenClaims) error); ok {
+		r1 = rf(ctx, claims)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeToken provides a mock function with given fields: ctx, tokenID
+func (_m *TokenRevocationService) RevokeToken(ctx context.Context, tokenID string) error {
+	ret := _m.Called(ctx, tokenID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, tokenID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SyncTokenRevocations provides a mock function with given fields: ctx
+func (_m *TokenRevocationService) SyncTokenRevocations(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewTokenRevocationService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewTokenRevocationService creates a new instance of TokenRevocationService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewTokenRevocationService(t mockConstructorTestingTNewTokenRevocationService) *TokenRevocationService {
+	mock := &TokenRevocationService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
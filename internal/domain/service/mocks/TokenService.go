@@ -5,9 +5,11 @@ package mocks
 import (
 	context "context"
 
+	entity "github.com/ssup2ket/service-auth/internal/domain/entity"
 	mock "github.com/stretchr/testify/mock"
 
 	token "github.com/ssup2ket/service-auth/pkg/auth/token"
+	uuid "github.com/ssup2ket/service-auth/pkg/entity/uuid"
 )
 
 // TokenService is an autogenerated mock type for the TokenService type
@@ -15,13 +17,187 @@ type TokenService struct {
 	mock.Mock
 }
 
-// CreateTokens provides a mock function with given fields: ctx, loginID, passwd
-func (_m *TokenService) CreateTokens(ctx context.Context, loginID string, passwd string) (*token.TokenInfo, *token.TokenInfo, error) {
-	ret := _m.Called(ctx, loginID, passwd)
+// BeginPasskeyLogin provides a mock function with given fields: ctx, tenantID
+func (_m *TokenService) BeginPasskeyLogin(ctx context.Context, tenantID string) (*entity.PasskeyLogin, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	var r0 *entity.PasskeyLogin
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.PasskeyLogin); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PasskeyLogin)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateMFATokens provides a mock function with given fields: ctx, mfaToken, code, session
+func (_m *TokenService) CreateMFATokens(ctx context.Context, mfaToken string, code string, session *entity.Session) (*token.TokenInfo, *token.TokenInfo, error) {
+	ret := _m.Called(ctx, mfaToken, code, session)
+
+	var r0 *token.TokenInfo
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *entity.Session) *token.TokenInfo); ok {
+		r0 = rf(ctx, mfaToken, code, session)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*token.TokenInfo)
+		}
+	}
+
+	var r1 *token.TokenInfo
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *entity.Session) *token.TokenInfo); ok {
+		r1 = rf(ctx, mfaToken, code, session)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*token.TokenInfo)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, *entity.Session) error); ok {
+		r2 = rf(ctx, mfaToken, code, session)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// CreatePasskeyTokens provides a mock function with given fields: ctx, tenantID, challengeUUID, assertion, session, audience
+func (_m *TokenService) CreatePasskeyTokens(ctx context.Context, tenantID string, challengeUUID uuid.EntityUUID, assertion *entity.PasskeyAssertion, session *entity.Session, audience string) (*token.TokenInfo, *token.TokenInfo, error) {
+	ret := _m.Called(ctx, tenantID, challengeUUID, assertion, session, audience)
+
+	var r0 *token.TokenInfo
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.EntityUUID, *entity.PasskeyAssertion, *entity.Session, string) *token.TokenInfo); ok {
+		r0 = rf(ctx, tenantID, challengeUUID, assertion, session, audience)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*token.TokenInfo)
+		}
+	}
+
+	var r1 *token.TokenInfo
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.EntityUUID, *entity.PasskeyAssertion, *entity.Session, string) *token.TokenInfo); ok {
+		r1 = rf(ctx, tenantID, challengeUUID, assertion, session, audience)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*token.TokenInfo)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, uuid.EntityUUID, *entity.PasskeyAssertion, *entity.Session, string) error); ok {
+		r2 = rf(ctx, tenantID, challengeUUID, assertion, session, audience)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// CreateTokens provides a mock function with given fields: ctx, tenantID, loginID, passwd, session, audience
+func (_m *TokenService) CreateTokens(ctx context.Context, tenantID string, loginID string, passwd string, session *entity.Session, audience string) (*token.TokenInfo, *token.TokenInfo, error) {
+	ret := _m.Called(ctx, tenantID, loginID, passwd, session, audience)
+
+	var r0 *token.TokenInfo
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *entity.Session, string) *token.TokenInfo); ok {
+		r0 = rf(ctx, tenantID, loginID, passwd, session, audience)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*token.TokenInfo)
+		}
+	}
+
+	var r1 *token.TokenInfo
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, *entity.Session, string) *token.TokenInfo); ok {
+		r1 = rf(ctx, tenantID, loginID, passwd, session, audience)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*token.TokenInfo)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, string, *entity.Session, string) error); ok {
+		r2 = rf(ctx, tenantID, loginID, passwd, session, audience)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// CreateUserAccessToken provides a mock function with given fields: ctx, userInfo, clientID, scopes
+func (_m *TokenService) CreateUserAccessToken(ctx context.Context, userInfo *entity.UserInfo, clientID string, scopes []string) (*token.TokenInfo, error) {
+	ret := _m.Called(ctx, userInfo, clientID, scopes)
+
+	var r0 *token.TokenInfo
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.UserInfo, string, []string) *token.TokenInfo); ok {
+		r0 = rf(ctx, userInfo, clientID, scopes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*token.TokenInfo)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.UserInfo, string, []string) error); ok {
+		r1 = rf(ctx, userInfo, clientID, scopes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateUserTokens provides a mock function with given fields: ctx, userInfo, session, audience
+func (_m *TokenService) CreateUserTokens(ctx context.Context, userInfo *entity.UserInfo, session *entity.Session, audience string) (*token.TokenInfo, *token.TokenInfo, error) {
+	ret := _m.Called(ctx, userInfo, session, audience)
+
+	var r0 *token.TokenInfo
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.UserInfo, *entity.Session, string) *token.TokenInfo); ok {
+		r0 = rf(ctx, userInfo, session, audience)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*token.TokenInfo)
+		}
+	}
+
+	var r1 *token.TokenInfo
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.UserInfo, *entity.Session, string) *token.TokenInfo); ok {
+		r1 = rf(ctx, userInfo, session, audience)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*token.TokenInfo)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *entity.UserInfo, *entity.Session, string) error); ok {
+		r2 = rf(ctx, userInfo, session, audience)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// RefreshClientToken provides a mock function with given fields: ctx, refreshToken, clientID
+func (_m *TokenService) RefreshClientToken(ctx context.Context, refreshToken string, clientID string) (*token.TokenInfo, *token.TokenInfo, error) {
+	ret := _m.Called(ctx, refreshToken, clientID)
 
 	var r0 *token.TokenInfo
 	if rf, ok := ret.Get(0).(func(context.Context, string, string) *token.TokenInfo); ok {
-		r0 = rf(ctx, loginID, passwd)
+		r0 = rf(ctx, refreshToken, clientID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*token.TokenInfo)
@@ -30,7 +206,7 @@ func (_m *TokenService) CreateTokens(ctx context.Context, loginID string, passwd
 
 	var r1 *token.TokenInfo
 	if rf, ok := ret.Get(1).(func(context.Context, string, string) *token.TokenInfo); ok {
-		r1 = rf(ctx, loginID, passwd)
+		r1 = rf(ctx, refreshToken, clientID)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*token.TokenInfo)
@@ -39,7 +215,7 @@ func (_m *TokenService) CreateTokens(ctx context.Context, loginID string, passwd
 
 	var r2 error
 	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
-		r2 = rf(ctx, loginID, passwd)
+		r2 = rf(ctx, refreshToken, clientID)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -48,7 +224,7 @@ func (_m *TokenService) CreateTokens(ctx context.Context, loginID string, passwd
 }
 
 // RefreshToken provides a mock function with given fields: ctx, refreshToken
-func (_m *TokenService) RefreshToken(ctx context.Context, refreshToken string) (*token.TokenInfo, error) {
+func (_m *TokenService) RefreshToken(ctx context.Context, refreshToken string) (*token.TokenInfo, *token.TokenInfo, error) {
 	ret := _m.Called(ctx, refreshToken)
 
 	var r0 *token.TokenInfo
@@ -60,14 +236,23 @@ func (_m *TokenService) RefreshToken(ctx context.Context, refreshToken string) (
 		}
 	}
 
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+	var r1 *token.TokenInfo
+	if rf, ok := ret.Get(1).(func(context.Context, string) *token.TokenInfo); ok {
 		r1 = rf(ctx, refreshToken)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*token.TokenInfo)
+		}
 	}
 
-	return r0, r1
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, refreshToken)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
 type mockConstructorTestingTNewTokenService interface {
@@ -16,13 +16,13 @@ type UserService struct {
 	mock.Mock
 }
 
-// CreateUser provides a mock function with given fields: ctx, userInfo, passwd
-func (_m *UserService) CreateUser(ctx context.Context, userInfo *entity.UserInfo, passwd string) (*entity.UserInfo, error) {
-	ret := _m.Called(ctx, userInfo, passwd)
+// CreateUser provides a mock function with given fields: ctx, subject, userInfo, passwd
+func (_m *UserService) CreateUser(ctx context.Context, subject *entity.Subject, userInfo *entity.UserInfo, passwd string) (*entity.UserInfo, error) {
+	ret := _m.Called(ctx, subject, userInfo, passwd)
 
 	var r0 *entity.UserInfo
-	if rf, ok := ret.Get(0).(func(context.Context, *entity.UserInfo, string) *entity.UserInfo); ok {
-		r0 = rf(ctx, userInfo, passwd)
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Subject, *entity.UserInfo, string) *entity.UserInfo); ok {
+		r0 = rf(ctx, subject, userInfo, passwd)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.UserInfo)
@@ -30,8 +30,8 @@ func (_m *UserService) CreateUser(ctx context.Context, userInfo *entity.UserInfo
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *entity.UserInfo, string) error); ok {
-		r1 = rf(ctx, userInfo, passwd)
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.Subject, *entity.UserInfo, string) error); ok {
+		r1 = rf(ctx, subject, userInfo, passwd)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -39,13 +39,13 @@ func (_m *UserService) CreateUser(ctx context.Context, userInfo *entity.UserInfo
 	return r0, r1
 }
 
-// DeleteUser provides a mock function with given fields: ctx, userUUID
-func (_m *UserService) DeleteUser(ctx context.Context, userUUID uuid.EntityUUID) error {
-	ret := _m.Called(ctx, userUUID)
+// DeleteUser provides a mock function with given fields: ctx, subject, userUUID
+func (_m *UserService) DeleteUser(ctx context.Context, subject *entity.Subject, userUUID uuid.EntityUUID) error {
+	ret := _m.Called(ctx, subject, userUUID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID) error); ok {
-		r0 = rf(ctx, userUUID)
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Subject, uuid.EntityUUID) error); ok {
+		r0 = rf(ctx, subject, userUUID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -53,13 +53,13 @@ func (_m *UserService) DeleteUser(ctx context.Context, userUUID uuid.EntityUUID)
 	return r0
 }
 
-// GetUser provides a mock function with given fields: ctx, userUUID
-func (_m *UserService) GetUser(ctx context.Context, userUUID uuid.EntityUUID) (*entity.UserInfo, error) {
-	ret := _m.Called(ctx, userUUID)
+// GetUser provides a mock function with given fields: ctx, subject, userUUID
+func (_m *UserService) GetUser(ctx context.Context, subject *entity.Subject, userUUID uuid.EntityUUID) (*entity.UserInfo, error) {
+	ret := _m.Called(ctx, subject, userUUID)
 
 	var r0 *entity.UserInfo
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.EntityUUID) *entity.UserInfo); ok {
-		r0 = rf(ctx, userUUID)
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Subject, uuid.EntityUUID) *entity.UserInfo); ok {
+		r0 = rf(ctx, subject, userUUID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.UserInfo)
@@ -67,8 +67,8 @@ func (_m *UserService) GetUser(ctx context.Context, userUUID uuid.EntityUUID) (*
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.EntityUUID) error); ok {
-		r1 = rf(ctx, userUUID)
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.Subject, uuid.EntityUUID) error); ok {
+		r1 = rf(ctx, subject, userUUID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -76,13 +76,13 @@ func (_m *UserService) GetUser(ctx context.Context, userUUID uuid.EntityUUID) (*
 	return r0, r1
 }
 
-// ListUser provides a mock function with given fields: ctx, offset, limit
-func (_m *UserService) ListUser(ctx context.Context, offset int, limit int) ([]entity.UserInfo, error) {
-	ret := _m.Called(ctx, offset, limit)
+// ListUser provides a mock function with given fields: ctx, tenantID, offset, limit
+func (_m *UserService) ListUser(ctx context.Context, tenantID string, offset int, limit int) ([]entity.UserInfo, error) {
+	ret := _m.Called(ctx, tenantID, offset, limit)
 
 	var r0 []entity.UserInfo
-	if rf, ok := ret.Get(0).(func(context.Context, int, int) []entity.UserInfo); ok {
-		r0 = rf(ctx, offset, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []entity.UserInfo); ok {
+		r0 = rf(ctx, tenantID, offset, limit)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.UserInfo)
@@ -90,8 +90,8 @@ func (_m *UserService) ListUser(ctx context.Context, offset int, limit int) ([]e
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
-		r1 = rf(ctx, offset, limit)
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(ctx, tenantID, offset, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -99,13 +99,41 @@ func (_m *UserService) ListUser(ctx context.Context, offset int, limit int) ([]e
 	return r0, r1
 }
 
-// UpdateUser provides a mock function with given fields: ctx, userInfo, passwd
-func (_m *UserService) UpdateUser(ctx context.Context, userInfo *entity.UserInfo, passwd string) error {
-	ret := _m.Called(ctx, userInfo, passwd)
+// RemoveUserPasswd provides a mock function with given fields: ctx, subject, userUUID, passwd
+func (_m *UserService) RemoveUserPasswd(ctx context.Context, subject *entity.Subject, userUUID uuid.EntityUUID, passwd string) error {
+	ret := _m.Called(ctx, subject, userUUID, passwd)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *entity.UserInfo, string) error); ok {
-		r0 = rf(ctx, userInfo, passwd)
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Subject, uuid.EntityUUID, string) error); ok {
+		r0 = rf(ctx, subject, userUUID, passwd)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUser provides a mock function with given fields: ctx, subject, userInfo, passwd
+func (_m *UserService) UpdateUser(ctx context.Context, subject *entity.Subject, userInfo *entity.UserInfo, passwd string) error {
+	ret := _m.Called(ctx, subject, userInfo, passwd)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Subject, *entity.UserInfo, string) error); ok {
+		r0 = rf(ctx, subject, userInfo, passwd)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUserPasswd provides a mock function with given fields: ctx, subject, userUUID, passwd, newPasswd
+func (_m *UserService) UpdateUserPasswd(ctx context.Context, subject *entity.Subject, userUUID uuid.EntityUUID, passwd string, newPasswd string) error {
+	ret := _m.Called(ctx, subject, userUUID, passwd, newPasswd)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Subject, uuid.EntityUUID, string, string) error); ok {
+		r0 = rf(ctx, subject, userUUID, passwd, newPasswd)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -0,0 +1,401 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/rs/zerolog/log"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/repo"
+	"github.com/ssup2ket/service-auth/pkg/auth/hashing"
+	"github.com/ssup2ket/service-auth/pkg/auth/token"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+const (
+	oauthAuthCodeSize       = 32
+	oauthAuthCodeLifetime   = 5 * time.Minute
+	oauthCodeVerifierMinLen = 43
+	oauthCodeVerifierMaxLen = 128
+
+	OAuthResponseTypeCode        = "code"
+	OAuthCodeChallengeMethodS256 = "S256"
+)
+
+// OpenID Connect scopes
+const (
+	OAuthScopeOpenID  = "openid"
+	OAuthScopeProfile = "profile"
+	OAuthScopeEmail   = "email"
+	OAuthScopePhone   = "phone"
+)
+
+// Authorization request of authorization code grant
+type OAuthAuthorizeRequest struct {
+	ResponseType        string
+	ClientID            string
+	RedirectURI         string
+	Scope               string
+	State               string
+	Nonce               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+}
+
+// Tokens issued by OAuth2 grants. Refresh token and ID token can be nil.
+type OAuthTokens struct {
+	AccessToken  *token.TokenInfo
+	RefreshToken *token.TokenInfo
+	IDToken      *token.TokenInfo
+	Scope        string
+}
+
+// OAuth service
+type OAuthService interface {
+	AuthenticateClient(ctx context.Context, clientID, clientSecret string) (*entity.OAuthClient, error)
+	ValidateAuthorizeRequest(ctx context.Context, req *OAuthAuthorizeRequest) (*entity.OAuthClient, error)
+	CreateAuthCode(ctx context.Context, req *OAuthAuthorizeRequest, userInfo *entity.UserInfo) (string, error)
+	ExchangeAuthCode(ctx context.Context, client *entity.OAuthClient, code, redirectURI, codeVerifier string,
+		session *entity.Session) (*OAuthTokens, error)
+	RefreshToken(ctx context.Context, client *entity.OAuthClient, refreshToken string) (*OAuthTokens, error)
+	CreateClientToken(ctx context.Context, client *entity.OAuthClient, scope string) (*OAuthTokens, error)
+}
+
+type OAuthServiceImp struct {
+	repoDBTx repo.DBTx
+
+	authCodeRepoPrimary      repo.OAuthAuthCodeRepo
+	oauthClientRepoSecondary repo.OAuthClientRepo
+	userInfoRepoSecondary    repo.UserInfoRepo
+
+	tokenService TokenService
+
+	issuer string
+}
+
+func NewOAuthServiceImp(dbTx repo.DBTx, authCodePrimary repo.OAuthAuthCodeRepo, oauthClientSecondary repo.OAuthClientRepo,
+	userInfoSecondary repo.UserInfoRepo, tokenService TokenService, issuer string) *OAuthServiceImp {
+	return &OAuthServiceImp{
+		repoDBTx: dbTx,
+
+		authCodeRepoPrimary:      authCodePrimary,
+		oauthClientRepoSecondary: oauthClientSecondary,
+		userInfoRepoSecondary:    userInfoSecondary,
+
+		tokenService: tokenService,
+
+		issuer: issuer,
+	}
+}
+
+// Authenticate a client by its secret. Public clients don't have a secret.
+func (o *OAuthServiceImp) AuthenticateClient(ctx context.Context, clientID, clientSecret string) (*entity.OAuthClient, error) {
+	client, err := o.getClient(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	if client.Public {
+		if clientSecret != "" {
+			log.Ctx(ctx).Error().Str("client_id", clientID).Msg("Public OAuth client has a secret")
+			return nil, ErrOAuthInvalidClient
+		}
+	} else if !hashing.ValidateStr(clientSecret, client.SecretHash, client.SecretSalt) {
+		log.Ctx(ctx).Error().Str("client_id", clientID).Msg("Wrong OAuth client secret")
+		return nil, ErrOAuthInvalidClient
+	}
+	return client, nil
+}
+
+// Validate an authorization request and normalize its scope. Empty redirect URI is set to the redirect URI
+// of the client if the client has only one redirect URI. If the client or the redirect URI isn't valid, the error
+// must not be redirected to the redirect URI.
+func (o *OAuthServiceImp) ValidateAuthorizeRequest(ctx context.Context, req *OAuthAuthorizeRequest) (*entity.OAuthClient, error) {
+	// Check client and redirect URI
+	client, err := o.getClient(ctx, req.ClientID)
+	if err != nil {
+		return nil, err
+	}
+	req.ClientID = client.ID.String()
+	if req.RedirectURI == "" && len(client.RedirectURIs) == 1 {
+		req.RedirectURI = client.RedirectURIs[0]
+	}
+	if !containsStr(client.RedirectURIs, req.RedirectURI) {
+		log.Ctx(ctx).Error().Str("redirect_uri", req.RedirectURI).Msg("OAuth redirect URI isn't registered")
+		return nil, ErrOAuthInvalidRedirectURI
+	}
+
+	// Check request
+	if req.ResponseType != OAuthResponseTypeCode {
+		return client, ErrOAuthUnsupportedResponseType
+	}
+	if !client.IsGrantTypeAllowed(entity.OAuthGrantTypeAuthorizationCode) {
+		return client, ErrOAuthUnauthorizedClient
+	}
+	if req.CodeChallenge == "" || req.CodeChallengeMethod != OAuthCodeChallengeMethodS256 {
+		log.Ctx(ctx).Error().Msg("OAuth authorization request doesn't have S256 code challenge")
+		return client, ErrOAuthInvalidRequest
+	}
+	scope, err := getOAuthScope(client, req.Scope)
+	if err != nil {
+		return client, err
+	}
+	req.Scope = scope
+	return client, nil
+}
+
+// Create an authorization code for the user. The request must be validated before.
+func (o *OAuthServiceImp) CreateAuthCode(ctx context.Context, req *OAuthAuthorizeRequest, userInfo *entity.UserInfo) (string, error) {
+	// Create code
+	codeBytes := make([]byte, oauthAuthCodeSize)
+	if _, err := rand.Read(codeBytes); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to create OAuth authorization code")
+		return "", ErrServerErr
+	}
+	code := base64.RawURLEncoding.EncodeToString(codeBytes)
+
+	// Store code's hash
+	now := time.Now()
+	if err := o.authCodeRepoPrimary.Create(ctx, &entity.OAuthAuthCode{
+		ID:            getOAuthAuthCodeHash(code),
+		ClientID:      req.ClientID,
+		UserID:        userInfo.ID,
+		TenantID:      userInfo.TenantID,
+		RedirectURI:   req.RedirectURI,
+		Scope:         req.Scope,
+		Nonce:         req.Nonce,
+		CodeChallenge: req.CodeChallenge,
+		AuthTime:      now,
+		ExpiresAt:     now.Add(oauthAuthCodeLifetime),
+	}); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to create OAuth authorization code")
+		return "", getReturnErr(err)
+	}
+
+	// Clean up expired codes. Failure doesn't affect the new code.
+	if err := o.authCodeRepoPrimary.DeleteExpired(ctx, now); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("Failed to delete expired OAuth authorization codes")
+	}
+	return code, nil
+}
+
+// Exchange an authorization code for tokens. A code can be used only once, even if the exchange fails.
+func (o *OAuthServiceImp) ExchangeAuthCode(ctx context.Context, client *entity.OAuthClient, code, redirectURI, codeVerifier string,
+	session *entity.Session) (*OAuthTokens, error) {
+	if !client.IsGrantTypeAllowed(entity.OAuthGrantTypeAuthorizationCode) {
+		return nil, ErrOAuthUnauthorizedClient
+	}
+
+	// Get and delete code
+	authCode, err := o.useAuthCode(ctx, getOAuthAuthCodeHash(code))
+	if err == repo.ErrNotFound {
+		log.Ctx(ctx).Error().Msg("OAuth authorization code doesn't exist")
+		return nil, ErrOAuthInvalidGrant
+	} else if err != nil {
+		return nil, getReturnErr(err)
+	}
+
+	// Check code
+	if authCode.ClientID != client.ID.String() || authCode.RedirectURI != redirectURI || time.Now().After(authCode.ExpiresAt) {
+		log.Ctx(ctx).Error().Msg("OAuth authorization code isn't valid for the request")
+		return nil, ErrOAuthInvalidGrant
+	}
+	if !validateOAuthCodeVerifier(codeVerifier, authCode.CodeChallenge) {
+		log.Ctx(ctx).Error().Msg("Wrong OAuth code verifier")
+		return nil, ErrOAuthInvalidGrant
+	}
+
+	// Get user info
+	userInfo, err := o.userInfoRepoSecondary.Get(ctx, entity.GetTenantIDOrDefault(authCode.TenantID), authCode.UserID)
+	if err == repo.ErrNotFound {
+		log.Ctx(ctx).Error().Msg("User of OAuth authorization code doesn't exist")
+		return nil, ErrOAuthInvalidGrant
+	} else if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get user info")
+		return nil, getReturnErr(err)
+	}
+
+	// Create tokens bound to the client and the granted scopes. A refresh token and its session are created
+	// only for clients allowed the refresh token grant.
+	tokens := OAuthTokens{Scope: authCode.Scope}
+	if client.IsGrantTypeAllowed(entity.OAuthGrantTypeRefreshToken) {
+		session.ClientID = client.ID.String()
+		session.Scopes = strings.Fields(authCode.Scope)
+		if tokens.AccessToken, tokens.RefreshToken, err = o.tokenService.CreateUserTokens(ctx, userInfo, session, ""); err != nil {
+			return nil, err
+		}
+	} else {
+		if tokens.AccessToken, err = o.tokenService.CreateUserAccessToken(ctx, userInfo, client.ID.String(),
+			strings.Fields(authCode.Scope)); err != nil {
+			return nil, err
+		}
+	}
+
+	// Create ID token for OpenID Connect
+	if containsStr(strings.Fields(authCode.Scope), OAuthScopeOpenID) {
+		if tokens.IDToken, err = o.createIDToken(userInfo, client, authCode); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to create ID token")
+			return nil, getReturnErr(err)
+		}
+	}
+	return &tokens, nil
+}
+
+// Rotate the refresh token issued to the client
+func (o *OAuthServiceImp) RefreshToken(ctx context.Context, client *entity.OAuthClient, refreshToken string) (*OAuthTokens, error) {
+	if !client.IsGrantTypeAllowed(entity.OAuthGrantTypeRefreshToken) {
+		return nil, ErrOAuthUnauthorizedClient
+	}
+
+	// Limited access tokens for expired passwords and unverified emails are only for service-auth, so clients can't get them
+	accTokenInfo, refTokenInfo, err := o.tokenService.RefreshClientToken(ctx, refreshToken, client.ID.String())
+	if err == ErrUnauthorized || err == ErrPasswdExpired || err == ErrEmailNotVerified {
+		return nil, ErrOAuthInvalidGrant
+	} else if err != nil {
+		return nil, err
+	}
+	return &OAuthTokens{
+		AccessToken:  accTokenInfo,
+		RefreshToken: refTokenInfo,
+	}, nil
+}
+
+// Create an access token for the client itself. Only confidential clients can get it,
+// and the access token doesn't have a user.
+func (o *OAuthServiceImp) CreateClientToken(ctx context.Context, client *entity.OAuthClient, scope string) (*OAuthTokens, error) {
+	if client.Public || !client.IsGrantTypeAllowed(entity.OAuthGrantTypeClientCredentials) {
+		return nil, ErrOAuthUnauthorizedClient
+	}
+	scope, err := getOAuthScope(client, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	accTokenInfo, err := token.CreateAccessToken(&token.AuthClaims{ClientID: client.ID.String(), Scopes: strings.Fields(scope)}, "")
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to create access token")
+		return nil, getReturnErr(err)
+	}
+	return &OAuthTokens{
+		AccessToken: accTokenInfo,
+		Scope:       scope,
+	}, nil
+}
+
+func (o *OAuthServiceImp) getClient(ctx context.Context, clientID string) (*entity.OAuthClient, error) {
+	client, err := o.oauthClientRepoSecondary.Get(ctx, uuid.FromStringOrNil(clientID))
+	if err == repo.ErrNotFound {
+		log.Ctx(ctx).Error().Str("client_id", clientID).Msg("OAuth client doesn't exist")
+		return nil, ErrOAuthInvalidClient
+	} else if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get OAuth client")
+		return nil, getReturnErr(err)
+	}
+	return client, nil
+}
+
+// Get and delete the authorization code with lock not to use the code concurrently
+func (o *OAuthServiceImp) useAuthCode(ctx context.Context, codeHash string) (*entity.OAuthAuthCode, error) {
+	var err error
+
+	// Begin transaction
+	tx, _ := o.repoDBTx.Begin()
+	defer func() {
+		if err != nil {
+			if err = tx.Rollback(); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Rollback transaction error for using OAuth authorization code")
+				return
+			}
+			log.Ctx(ctx).Error().Err(err).Msg("Using OAuth authorization code is canceled")
+			return
+		}
+	}()
+
+	authCode, err := o.authCodeRepoPrimary.WithTx(tx).GetForUpdate(ctx, codeHash)
+	if err != nil {
+		return nil, err
+	}
+	if err = o.authCodeRepoPrimary.WithTx(tx).Delete(ctx, codeHash); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete OAuth authorization code")
+		return nil, err
+	}
+
+	// Commit transaction
+	if err = tx.Commit(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Commit transaction error for using OAuth authorization code")
+		return nil, err
+	}
+	return authCode, nil
+}
+
+// Create an ID token with user claims of the granted scopes
+func (o *OAuthServiceImp) createIDToken(userInfo *entity.UserInfo, client *entity.OAuthClient,
+	authCode *entity.OAuthAuthCode) (*token.TokenInfo, error) {
+	idClaims := token.IDTokenClaims{
+		StandardClaims: jwt.StandardClaims{Subject: userInfo.ID.String()},
+		AuthTime:       authCode.AuthTime.Unix(),
+		Nonce:          authCode.Nonce,
+	}
+	scopes := strings.Fields(authCode.Scope)
+	if containsStr(scopes, OAuthScopeProfile) {
+		idClaims.PreferredUsername = userInfo.LoginID
+	}
+	if containsStr(scopes, OAuthScopeEmail) {
+		idClaims.Email = userInfo.Email
+	}
+	if containsStr(scopes, OAuthScopePhone) {
+		idClaims.PhoneNumber = userInfo.Phone
+	}
+	return token.CreateIDToken(&idClaims, o.issuer, client.ID.String())
+}
+
+// Get normalized scope. All scopes must be allowed for the client, and empty scope isn't allowed, because tokens
+// of clients without scopes aren't authorized.
+func getOAuthScope(client *entity.OAuthClient, scope string) (string, error) {
+	if len(strings.Fields(scope)) == 0 {
+		return "", ErrOAuthInvalidScope
+	}
+	scopes := []string{}
+	for _, s := range strings.Fields(scope) {
+		if !containsStr(client.Scopes, s) {
+			return "", ErrOAuthInvalidScope
+		}
+		if !containsStr(scopes, s) {
+			scopes = append(scopes, s)
+		}
+	}
+	return strings.Join(scopes, " "), nil
+}
+
+// Only the code's hash is stored not to use codes leaked from DB
+func getOAuthAuthCodeHash(code string) string {
+	hash := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(hash[:])
+}
+
+// Validate PKCE code verifier by S256 method (RFC 7636)
+func validateOAuthCodeVerifier(codeVerifier, codeChallenge string) bool {
+	if len(codeVerifier) < oauthCodeVerifierMinLen || len(codeVerifier) > oauthCodeVerifierMaxLen {
+		return false
+	}
+	hash := sha256.Sum256([]byte(codeVerifier))
+	challenge := base64.RawURLEncoding.EncodeToString(hash[:])
+	return subtle.ConstantTimeCompare([]byte(challenge), []byte(codeChallenge)) == 1
+}
+
+func containsStr(strs []string, str string) bool {
+	for _, s := range strs {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
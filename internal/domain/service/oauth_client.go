@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/repo"
+	"github.com/ssup2ket/service-auth/pkg/auth/hashing"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+const (
+	oauthClientSecretSize = 32
+)
+
+// OAuth client service
+type OAuthClientService interface {
+	ListOAuthClient(ctx context.Context, offset int, limit int) ([]entity.OAuthClient, error)
+	CreateOAuthClient(ctx context.Context, client *entity.OAuthClient) (*entity.OAuthClient, string, error)
+	GetOAuthClient(ctx context.Context, clientUUID uuid.EntityUUID) (*entity.OAuthClient, error)
+	UpdateOAuthClient(ctx context.Context, client *entity.OAuthClient) error
+	DeleteOAuthClient(ctx context.Context, clientUUID uuid.EntityUUID) error
+}
+
+type OAuthClientServiceImp struct {
+	repoDBTx repo.DBTx
+
+	oauthClientRepoPrimary   repo.OAuthClientRepo
+	oauthClientRepoSecondary repo.OAuthClientRepo
+}
+
+func NewOAuthClientServiceImp(dbTx repo.DBTx, oauthClientPrimary, oauthClientSecondary repo.OAuthClientRepo) *OAuthClientServiceImp {
+	return &OAuthClientServiceImp{
+		repoDBTx: dbTx,
+
+		oauthClientRepoPrimary:   oauthClientPrimary,
+		oauthClientRepoSecondary: oauthClientSecondary,
+	}
+}
+
+func (o *OAuthClientServiceImp) ListOAuthClient(ctx context.Context, offset int, limit int) ([]entity.OAuthClient, error) {
+	// Set default limit
+	if limit == 0 {
+		limit = 50
+	}
+
+	// List clients
+	clients, err := o.oauthClientRepoSecondary.List(ctx, offset, limit)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to list OAuth clients from DB")
+		return nil, getReturnErr(err)
+	}
+	return clients, nil
+}
+
+// Create a client. The secret of a confidential client is generated and returned only once.
+func (o *OAuthClientServiceImp) CreateOAuthClient(ctx context.Context, client *entity.OAuthClient) (*entity.OAuthClient, string, error) {
+	if err := validateOAuthClientGrantTypes(ctx, client); err != nil {
+		return nil, "", err
+	}
+
+	// Create secret
+	secret := ""
+	if !client.Public {
+		secretBytes := make([]byte, oauthClientSecretSize)
+		if _, err := rand.Read(secretBytes); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to create OAuth client secret")
+			return nil, "", ErrServerErr
+		}
+		secret = base64.RawURLEncoding.EncodeToString(secretBytes)
+
+		hash, salt, err := hashing.GetStrHashAndSalt(secret)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to create OAuth client secret hash and salt")
+			return nil, "", ErrServerErr
+		}
+		client.SecretHash = hash
+		client.SecretSalt = salt
+	}
+
+	// Create client
+	client.ID = uuid.NewV4()
+	if err := o.oauthClientRepoPrimary.Create(ctx, client); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to create OAuth client to DB")
+		return nil, "", getReturnErr(err)
+	}
+	return client, secret, nil
+}
+
+func (o *OAuthClientServiceImp) GetOAuthClient(ctx context.Context, clientUUID uuid.EntityUUID) (*entity.OAuthClient, error) {
+	client, err := o.oauthClientRepoSecondary.Get(ctx, clientUUID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get OAuth client from DB")
+		return nil, getReturnErr(err)
+	}
+	return client, nil
+}
+
+// Update a client. The public flag and the secret aren't changed.
+func (o *OAuthClientServiceImp) UpdateOAuthClient(ctx context.Context, client *entity.OAuthClient) error {
+	var err error
+
+	// Begin transaction
+	tx, _ := o.repoDBTx.Begin()
+	defer func() {
+		if err != nil {
+			if err = tx.Rollback(); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Rollback transaction error for updating OAuth client")
+				return
+			}
+			log.Ctx(ctx).Error().Err(err).Msg("Update OAuth client request is canceled")
+			return
+		}
+	}()
+
+	// Get client to check grant types with the public flag
+	oldClient, err := o.oauthClientRepoPrimary.WithTx(tx).Get(ctx, client.ID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get OAuth client from DB")
+		return getReturnErr(err)
+	}
+	client.Public = oldClient.Public
+	if err = validateOAuthClientGrantTypes(ctx, client); err != nil {
+		return err
+	}
+
+	// Update client
+	if err = o.oauthClientRepoPrimary.WithTx(tx).Update(ctx, client); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to update OAuth client from DB")
+		return getReturnErr(err)
+	}
+
+	// Commit transaction
+	if err = tx.Commit(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Commit transaction error for updating OAuth client")
+		return getReturnErr(err)
+	}
+	return nil
+}
+
+func (o *OAuthClientServiceImp) DeleteOAuthClient(ctx context.Context, clientUUID uuid.EntityUUID) error {
+	var err error
+
+	// Begin transaction
+	tx, _ := o.repoDBTx.Begin()
+	defer func() {
+		if err != nil {
+			if err = tx.Rollback(); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Rollback transaction error for deleting OAuth client")
+				return
+			}
+			log.Ctx(ctx).Error().Err(err).Msg("Delete OAuth client request is canceled")
+			return
+		}
+	}()
+
+	// Check client exists
+	if _, err = o.oauthClientRepoPrimary.WithTx(tx).Get(ctx, clientUUID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get OAuth client from DB")
+		return getReturnErr(err)
+	}
+
+	// Delete client
+	if err = o.oauthClientRepoPrimary.WithTx(tx).Delete(ctx, clientUUID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete OAuth client from DB")
+		return getReturnErr(err)
+	}
+
+	// Commit transaction
+	if err = tx.Commit(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Commit transaction error for deleting OAuth client")
+		return getReturnErr(err)
+	}
+	return nil
+}
+
+// Public clients can't keep a secret, so they can't use the client credentials grant
+func validateOAuthClientGrantTypes(ctx context.Context, client *entity.OAuthClient) error {
+	if client.Public && client.IsGrantTypeAllowed(entity.OAuthGrantTypeClientCredentials) {
+		log.Ctx(ctx).Error().Str("client_id", client.ID.String()).Msg("Public OAuth client can't use client credentials grant")
+		return ErrOAuthClientInvalidGrantType
+	}
+	return nil
+}
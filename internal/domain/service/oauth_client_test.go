@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/repo"
+	"github.com/ssup2ket/service-auth/internal/domain/repo/mocks"
+	"github.com/ssup2ket/service-auth/internal/test"
+	"github.com/ssup2ket/service-auth/pkg/auth/hashing"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+func TestOAuthClient(t *testing.T) {
+	suite.Run(t, new(oauthClientSuite))
+}
+
+type oauthClientSuite struct {
+	suite.Suite
+
+	dbTx       mocks.DBTx
+	clientRepo mocks.OAuthClientRepo
+
+	oauthClientService OAuthClientService
+}
+
+func (o *oauthClientSuite) SetupTest() {
+	// Init transaction, repo
+	o.dbTx = mocks.DBTx{}
+	o.clientRepo = mocks.OAuthClientRepo{}
+
+	// Init service
+	o.oauthClientService = NewOAuthClientServiceImp(&o.dbTx, &o.clientRepo, &o.clientRepo)
+}
+
+func (o *oauthClientSuite) TestListOAuthClientSuccess() {
+	o.clientRepo.On("List", context.Background(), 0, 50).Return([]entity.OAuthClient{test.OAuthClientCorrect}, nil)
+
+	clients, err := o.oauthClientService.ListOAuthClient(context.Background(), 0, 0)
+	require.NoError(o.T(), err)
+	require.Equal(o.T(), test.OAuthClientIDCorrect, clients[0].ID)
+}
+
+func (o *oauthClientSuite) TestCreateOAuthClientConfidential() {
+	o.clientRepo.On("Create", context.Background(), mock.Anything).Return(nil)
+
+	client, secret, err := o.oauthClientService.CreateOAuthClient(context.Background(), &entity.OAuthClient{
+		Name:       test.OAuthClientNameCorrect,
+		GrantTypes: entity.StrList{string(entity.OAuthGrantTypeClientCredentials)},
+	})
+	require.NoError(o.T(), err)
+	require.NotEmpty(o.T(), secret)
+	require.NotEqual(o.T(), uuid.EntityUUID{}, client.ID)
+	require.True(o.T(), hashing.ValidateStr(secret, client.SecretHash, client.SecretSalt))
+}
+
+func (o *oauthClientSuite) TestCreateOAuthClientPublic() {
+	o.clientRepo.On("Create", context.Background(), mock.Anything).Return(nil)
+
+	client, secret, err := o.oauthClientService.CreateOAuthClient(context.Background(), &entity.OAuthClient{
+		Name:       test.OAuthClientNameCorrect,
+		GrantTypes: entity.StrList{string(entity.OAuthGrantTypeAuthorizationCode)},
+		Public:     true,
+	})
+	require.NoError(o.T(), err)
+	require.Empty(o.T(), secret)
+	require.Empty(o.T(), client.SecretHash)
+}
+
+func (o *oauthClientSuite) TestCreateOAuthClientPublicClientCredentials() {
+	_, _, err := o.oauthClientService.CreateOAuthClient(context.Background(), &entity.OAuthClient{
+		Name:       test.OAuthClientNameCorrect,
+		GrantTypes: entity.StrList{string(entity.OAuthGrantTypeClientCredentials)},
+		Public:     true,
+	})
+	require.Equal(o.T(), ErrOAuthClientInvalidGrantType, err)
+	o.clientRepo.AssertNotCalled(o.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (o *oauthClientSuite) TestGetOAuthClientNotFound() {
+	o.clientRepo.On("Get", context.Background(), test.OAuthClientIDCorrect).Return(nil, repo.ErrNotFound)
+
+	_, err := o.oauthClientService.GetOAuthClient(context.Background(), test.OAuthClientIDCorrect)
+	require.Equal(o.T(), ErrRepoNotFound, err)
+}
+
+func (o *oauthClientSuite) TestUpdateOAuthClientSuccess() {
+	o.dbTx.On("Begin").Return(&o.dbTx, nil)
+	o.clientRepo.On("WithTx", mock.Anything).Return(&o.clientRepo)
+	o.clientRepo.On("Get", context.Background(), test.OAuthClientIDCorrect).Return(&entity.OAuthClient{ID: test.OAuthClientIDCorrect}, nil)
+	o.clientRepo.On("Update", context.Background(), mock.Anything).Return(nil)
+	o.dbTx.On("Commit").Return(nil)
+
+	err := o.oauthClientService.UpdateOAuthClient(context.Background(), &entity.OAuthClient{
+		ID:         test.OAuthClientIDCorrect,
+		Name:       test.OAuthClientNameCorrect,
+		GrantTypes: entity.StrList{string(entity.OAuthGrantTypeClientCredentials)},
+	})
+	require.NoError(o.T(), err)
+}
+
+func (o *oauthClientSuite) TestUpdateOAuthClientPublicClientCredentials() {
+	o.dbTx.On("Begin").Return(&o.dbTx, nil)
+	o.clientRepo.On("WithTx", mock.Anything).Return(&o.clientRepo)
+	o.clientRepo.On("Get", context.Background(), test.OAuthClientIDCorrect).Return(&entity.OAuthClient{ID: test.OAuthClientIDCorrect, Public: true}, nil)
+	o.dbTx.On("Rollback").Return(nil)
+
+	// Public flag isn't changed by update
+	err := o.oauthClientService.UpdateOAuthClient(context.Background(), &entity.OAuthClient{
+		ID:         test.OAuthClientIDCorrect,
+		Name:       test.OAuthClientNameCorrect,
+		GrantTypes: entity.StrList{string(entity.OAuthGrantTypeClientCredentials)},
+	})
+	require.Equal(o.T(), ErrOAuthClientInvalidGrantType, err)
+	o.clientRepo.AssertNotCalled(o.T(), "Update", mock.Anything, mock.Anything)
+}
+
+func (o *oauthClientSuite) TestDeleteOAuthClientSuccess() {
+	o.dbTx.On("Begin").Return(&o.dbTx, nil)
+	o.clientRepo.On("WithTx", mock.Anything).Return(&o.clientRepo)
+	o.clientRepo.On("Get", context.Background(), test.OAuthClientIDCorrect).Return(&test.OAuthClientCorrect, nil)
+	o.clientRepo.On("Delete", context.Background(), test.OAuthClientIDCorrect).Return(nil)
+	o.dbTx.On("Commit").Return(nil)
+
+	err := o.oauthClientService.DeleteOAuthClient(context.Background(), test.OAuthClientIDCorrect)
+	require.NoError(o.T(), err)
+}
+
+func (o *oauthClientSuite) TestDeleteOAuthClientNotFound() {
+	o.dbTx.On("Begin").Return(&o.dbTx, nil)
+	o.clientRepo.On("WithTx", mock.Anything).Return(&o.clientRepo)
+	o.clientRepo.On("Get", context.Background(), test.OAuthClientIDCorrect).Return(nil, repo.ErrNotFound)
+	o.dbTx.On("Rollback").Return(nil)
+
+	err := o.oauthClientService.DeleteOAuthClient(context.Background(), test.OAuthClientIDCorrect)
+	require.Equal(o.T(), ErrRepoNotFound, err)
+}
@@ -0,0 +1,309 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/repo"
+	"github.com/ssup2ket/service-auth/internal/domain/repo/mocks"
+	"github.com/ssup2ket/service-auth/internal/test"
+	"github.com/ssup2ket/service-auth/pkg/auth/token"
+)
+
+func TestOAuth(t *testing.T) {
+	suite.Run(t, new(oauthSuite))
+}
+
+type oauthSuite struct {
+	suite.Suite
+
+	dbTx           mocks.DBTx
+	authCodeRepo   mocks.OAuthAuthCodeRepo
+	clientRepo     mocks.OAuthClientRepo
+	userInfoRepo   mocks.UserInfoRepo
+	userSecretRepo mocks.UserSecretRepo
+	sessionRepo    mocks.SessionRepo
+
+	tokenRevocationRepo mocks.TokenRevocationRepo
+
+	oauthService OAuthService
+
+	client   entity.OAuthClient
+	userInfo *entity.UserInfo
+}
+
+func (o *oauthSuite) SetupTest() {
+	// Init transaction, repo
+	o.dbTx = mocks.DBTx{}
+	o.authCodeRepo = mocks.OAuthAuthCodeRepo{}
+	o.clientRepo = mocks.OAuthClientRepo{}
+	o.userInfoRepo = mocks.UserInfoRepo{}
+	o.userSecretRepo = mocks.UserSecretRepo{}
+	o.sessionRepo = mocks.SessionRepo{}
+	o.tokenRevocationRepo = mocks.TokenRevocationRepo{}
+
+	// Init token key provider
+	keyProvider, err := token.NewRandomKeyProvider(token.AlgHS256)
+	require.NoError(o.T(), err)
+	token.SetKeyProvider(keyProvider)
+
+	// Init service
+	o.client = test.OAuthClientCorrect
+	groupRepo := mocks.GroupRepo{}
+	groupRepo.On("ListByIDs", mock.Anything, mock.Anything, mock.Anything).Return([]entity.Group{}, nil)
+	groupMemberRepo := mocks.GroupMemberRepo{}
+	groupMemberRepo.On("ListByMemberIDs", mock.Anything, mock.Anything).Return([]entity.GroupMember{}, nil)
+	tokenService := NewTokenServiceImp(&o.dbTx, TokenRepos{
+		UserInfoSecondary:    &o.userInfoRepo,
+		UserSecretSecondary:  &o.userSecretRepo,
+		RoleSecondary:        &mocks.RoleRepo{},
+		GroupSecondary:       &groupRepo,
+		GroupMemberSecondary: &groupMemberRepo,
+		UserSecretPrimary:    &o.userSecretRepo,
+		SessionPrimary:       &o.sessionRepo,
+
+		TokenRevocationPrimary: &o.tokenRevocationRepo,
+		LoginLock:              &mocks.LoginLockRepo{},
+		MFARecoveryCodePrimary: &mocks.MFARecoveryCodeRepo{},
+
+		WebAuthnCredentialPrimary: &mocks.WebAuthnCredentialRepo{},
+		WebAuthnChallengePrimary:  &mocks.WebAuthnChallengeRepo{},
+	}, nil, TokenPolicies{
+		LoginLock:         &LoginLockPolicy{},
+		EmailVerification: &EmailVerificationPolicy{},
+	}, nil, nil)
+	o.oauthService = NewOAuthServiceImp(&o.dbTx, &o.authCodeRepo, &o.clientRepo, &o.userInfoRepo, tokenService, "issuer")
+
+	o.userInfo = &entity.UserInfo{
+		ID:       test.UserIDCorrect,
+		TenantID: test.TenantIDCorrect,
+		LoginID:  test.UserLoginIDCorrect,
+		Role:     test.UserRoleCorrect,
+		Email:    test.UserEmailCorrect,
+	}
+}
+
+func (o *oauthSuite) getAuthorizeRequest() *OAuthAuthorizeRequest {
+	o.clientRepo.On("Get", context.Background(), test.OAuthClientIDCorrect).Return(&o.client, nil)
+	return &OAuthAuthorizeRequest{
+		ResponseType:        OAuthResponseTypeCode,
+		ClientID:            test.OAuthClientIDCorrect.String(),
+		Scope:               test.OAuthScopeCorrect,
+		Nonce:               test.OAuthNonceCorrect,
+		CodeChallenge:       test.OAuthCodeChallengeCorrect,
+		CodeChallengeMethod: OAuthCodeChallengeMethodS256,
+	}
+}
+
+func (o *oauthSuite) getAuthCode() *entity.OAuthAuthCode {
+	return &entity.OAuthAuthCode{
+		ClientID:      test.OAuthClientIDCorrect.String(),
+		UserID:        test.UserIDCorrect,
+		TenantID:      test.TenantIDCorrect,
+		RedirectURI:   test.OAuthClientRedirectURICorrect,
+		Scope:         test.OAuthScopeCorrect,
+		Nonce:         test.OAuthNonceCorrect,
+		CodeChallenge: test.OAuthCodeChallengeCorrect,
+		AuthTime:      time.Now(),
+		ExpiresAt:     time.Now().Add(time.Minute),
+	}
+}
+
+func (o *oauthSuite) TestAuthenticateClientSuccess() {
+	o.clientRepo.On("Get", context.Background(), test.OAuthClientIDCorrect).Return(&o.client, nil)
+
+	client, err := o.oauthService.AuthenticateClient(context.Background(), test.OAuthClientIDCorrect.String(), test.OAuthClientSecretCorrect)
+	require.NoError(o.T(), err)
+	require.Equal(o.T(), test.OAuthClientIDCorrect, client.ID)
+}
+
+func (o *oauthSuite) TestAuthenticateClientWrongSecret() {
+	o.clientRepo.On("Get", context.Background(), test.OAuthClientIDCorrect).Return(&o.client, nil)
+
+	_, err := o.oauthService.AuthenticateClient(context.Background(), test.OAuthClientIDCorrect.String(), test.OAuthClientSecretWrong)
+	require.Equal(o.T(), ErrOAuthInvalidClient, err)
+	_, err = o.oauthService.AuthenticateClient(context.Background(), test.OAuthClientIDCorrect.String(), "")
+	require.Equal(o.T(), ErrOAuthInvalidClient, err)
+}
+
+func (o *oauthSuite) TestAuthenticateClientNotFound() {
+	o.clientRepo.On("Get", context.Background(), test.OAuthClientIDCorrect).Return(nil, repo.ErrNotFound)
+
+	_, err := o.oauthService.AuthenticateClient(context.Background(), test.OAuthClientIDCorrect.String(), test.OAuthClientSecretCorrect)
+	require.Equal(o.T(), ErrOAuthInvalidClient, err)
+}
+
+func (o *oauthSuite) TestValidateAuthorizeRequestSuccess() {
+	req := o.getAuthorizeRequest()
+	client, err := o.oauthService.ValidateAuthorizeRequest(context.Background(), req)
+	require.NoError(o.T(), err)
+	require.Equal(o.T(), test.OAuthClientIDCorrect, client.ID)
+	require.Equal(o.T(), test.OAuthClientRedirectURICorrect, req.RedirectURI)
+}
+
+func (o *oauthSuite) TestValidateAuthorizeRequestWrongRedirectURI() {
+	req := o.getAuthorizeRequest()
+	req.RedirectURI = test.OAuthClientRedirectURIWrong
+	_, err := o.oauthService.ValidateAuthorizeRequest(context.Background(), req)
+	require.Equal(o.T(), ErrOAuthInvalidRedirectURI, err)
+}
+
+func (o *oauthSuite) TestValidateAuthorizeRequestNoPKCE() {
+	req := o.getAuthorizeRequest()
+	req.CodeChallengeMethod = "plain"
+	_, err := o.oauthService.ValidateAuthorizeRequest(context.Background(), req)
+	require.Equal(o.T(), ErrOAuthInvalidRequest, err)
+}
+
+func (o *oauthSuite) TestValidateAuthorizeRequestWrongScope() {
+	req := o.getAuthorizeRequest()
+	req.Scope = test.OAuthScopeWrong
+	_, err := o.oauthService.ValidateAuthorizeRequest(context.Background(), req)
+	require.Equal(o.T(), ErrOAuthInvalidScope, err)
+}
+
+func (o *oauthSuite) TestValidateAuthorizeRequestEmptyScope() {
+	req := o.getAuthorizeRequest()
+	req.Scope = " "
+	_, err := o.oauthService.ValidateAuthorizeRequest(context.Background(), req)
+	require.Equal(o.T(), ErrOAuthInvalidScope, err)
+}
+
+func (o *oauthSuite) TestCreateAuthCodeSuccess() {
+	var createdAuthCode *entity.OAuthAuthCode
+	o.authCodeRepo.On("Create", context.Background(), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		createdAuthCode = args.Get(1).(*entity.OAuthAuthCode)
+	})
+	o.authCodeRepo.On("DeleteExpired", context.Background(), mock.Anything).Return(nil)
+
+	req := o.getAuthorizeRequest()
+	req.RedirectURI = test.OAuthClientRedirectURICorrect
+	code, err := o.oauthService.CreateAuthCode(context.Background(), req, o.userInfo)
+	require.NoError(o.T(), err)
+	require.Equal(o.T(), getOAuthAuthCodeHash(code), createdAuthCode.ID)
+	require.Equal(o.T(), test.UserIDCorrect, createdAuthCode.UserID)
+	require.Equal(o.T(), test.TenantIDCorrect, createdAuthCode.TenantID)
+	require.Equal(o.T(), test.OAuthCodeChallengeCorrect, createdAuthCode.CodeChallenge)
+}
+
+func (o *oauthSuite) TestExchangeAuthCodeSuccess() {
+	o.dbTx.On("Begin").Return(&o.dbTx, nil)
+	o.authCodeRepo.On("WithTx", mock.Anything).Return(&o.authCodeRepo)
+	o.authCodeRepo.On("GetForUpdate", context.Background(), getOAuthAuthCodeHash("code")).Return(o.getAuthCode(), nil)
+	o.authCodeRepo.On("Delete", context.Background(), getOAuthAuthCodeHash("code")).Return(nil)
+	o.dbTx.On("Commit").Return(nil)
+	o.userInfoRepo.On("Get", context.Background(), test.TenantIDCorrect, test.UserIDCorrect).Return(o.userInfo, nil)
+	o.sessionRepo.On("Create", context.Background(), mock.Anything).Return(nil)
+
+	tokens, err := o.oauthService.ExchangeAuthCode(context.Background(), &o.client, "code", test.OAuthClientRedirectURICorrect,
+		test.OAuthCodeVerifierCorrect, &entity.Session{})
+	require.NoError(o.T(), err)
+	require.NotNil(o.T(), tokens.RefreshToken)
+	require.Equal(o.T(), test.OAuthScopeCorrect, tokens.Scope)
+
+	// Tokens are bound to the client
+	refClaims, err := token.ValidateRefreshToken(tokens.RefreshToken.Token)
+	require.NoError(o.T(), err)
+	require.Equal(o.T(), test.OAuthClientIDCorrect.String(), refClaims.ClientID)
+	require.Equal(o.T(), []string{"openid", "profile"}, refClaims.Scopes)
+
+	// ID token has claims of the granted scopes
+	idClaims := token.IDTokenClaims{}
+	_, _, err = new(jwt.Parser).ParseUnverified(tokens.IDToken.Token, &idClaims)
+	require.NoError(o.T(), err)
+	require.Equal(o.T(), test.OAuthClientIDCorrect.String(), idClaims.Audience)
+	require.Equal(o.T(), test.OAuthNonceCorrect, idClaims.Nonce)
+	require.Equal(o.T(), test.UserLoginIDCorrect, idClaims.PreferredUsername)
+	require.Empty(o.T(), idClaims.Email)
+}
+
+func (o *oauthSuite) TestExchangeAuthCodeWithoutRefreshTokenGrant() {
+	o.client.GrantTypes = []string{string(entity.OAuthGrantTypeAuthorizationCode)}
+	o.dbTx.On("Begin").Return(&o.dbTx, nil)
+	o.authCodeRepo.On("WithTx", mock.Anything).Return(&o.authCodeRepo)
+	o.authCodeRepo.On("GetForUpdate", context.Background(), getOAuthAuthCodeHash("code")).Return(o.getAuthCode(), nil)
+	o.authCodeRepo.On("Delete", context.Background(), getOAuthAuthCodeHash("code")).Return(nil)
+	o.dbTx.On("Commit").Return(nil)
+	o.userInfoRepo.On("Get", context.Background(), test.TenantIDCorrect, test.UserIDCorrect).Return(o.userInfo, nil)
+
+	// Only an access token without session is issued
+	tokens, err := o.oauthService.ExchangeAuthCode(context.Background(), &o.client, "code", test.OAuthClientRedirectURICorrect,
+		test.OAuthCodeVerifierCorrect, &entity.Session{})
+	require.NoError(o.T(), err)
+	require.Nil(o.T(), tokens.RefreshToken)
+	o.sessionRepo.AssertNotCalled(o.T(), "Create", mock.Anything, mock.Anything)
+
+	accClaims, err := token.ValidateAccessToken(tokens.AccessToken.Token)
+	require.NoError(o.T(), err)
+	require.Empty(o.T(), accClaims.SessionID)
+	require.Equal(o.T(), test.OAuthClientIDCorrect.String(), accClaims.ClientID)
+	require.Equal(o.T(), []string{"openid", "profile"}, accClaims.Scopes)
+}
+
+func (o *oauthSuite) TestExchangeAuthCodeWrongVerifier() {
+	o.dbTx.On("Begin").Return(&o.dbTx, nil)
+	o.authCodeRepo.On("WithTx", mock.Anything).Return(&o.authCodeRepo)
+	o.authCodeRepo.On("GetForUpdate", context.Background(), mock.Anything).Return(o.getAuthCode(), nil)
+	o.authCodeRepo.On("Delete", context.Background(), mock.Anything).Return(nil)
+	o.dbTx.On("Commit").Return(nil)
+
+	_, err := o.oauthService.ExchangeAuthCode(context.Background(), &o.client, "code", test.OAuthClientRedirectURICorrect,
+		test.OAuthCodeVerifierWrong, &entity.Session{})
+	require.Equal(o.T(), ErrOAuthInvalidGrant, err)
+	o.authCodeRepo.AssertCalled(o.T(), "Delete", context.Background(), mock.Anything)
+	o.sessionRepo.AssertNotCalled(o.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (o *oauthSuite) TestExchangeAuthCodeWrongRedirectURI() {
+	o.dbTx.On("Begin").Return(&o.dbTx, nil)
+	o.authCodeRepo.On("WithTx", mock.Anything).Return(&o.authCodeRepo)
+	o.authCodeRepo.On("GetForUpdate", context.Background(), mock.Anything).Return(o.getAuthCode(), nil)
+	o.authCodeRepo.On("Delete", context.Background(), mock.Anything).Return(nil)
+	o.dbTx.On("Commit").Return(nil)
+
+	_, err := o.oauthService.ExchangeAuthCode(context.Background(), &o.client, "code", test.OAuthClientRedirectURIWrong,
+		test.OAuthCodeVerifierCorrect, &entity.Session{})
+	require.Equal(o.T(), ErrOAuthInvalidGrant, err)
+}
+
+func (o *oauthSuite) TestExchangeAuthCodeUsed() {
+	o.dbTx.On("Begin").Return(&o.dbTx, nil)
+	o.authCodeRepo.On("WithTx", mock.Anything).Return(&o.authCodeRepo)
+	o.authCodeRepo.On("GetForUpdate", context.Background(), mock.Anything).Return(nil, repo.ErrNotFound)
+	o.dbTx.On("Rollback").Return(nil)
+
+	_, err := o.oauthService.ExchangeAuthCode(context.Background(), &o.client, "code", test.OAuthClientRedirectURICorrect,
+		test.OAuthCodeVerifierCorrect, &entity.Session{})
+	require.Equal(o.T(), ErrOAuthInvalidGrant, err)
+}
+
+func (o *oauthSuite) TestCreateClientTokenSuccess() {
+	tokens, err := o.oauthService.CreateClientToken(context.Background(), &o.client, "profile profile")
+	require.NoError(o.T(), err)
+	require.Nil(o.T(), tokens.RefreshToken)
+	require.Equal(o.T(), "profile", tokens.Scope)
+
+	claims, err := token.ValidateAccessToken(tokens.AccessToken.Token)
+	require.NoError(o.T(), err)
+	require.Equal(o.T(), test.OAuthClientIDCorrect.String(), claims.Subject)
+	require.Empty(o.T(), claims.UserID)
+	require.Equal(o.T(), []string{"profile"}, claims.Scopes)
+}
+
+func (o *oauthSuite) TestCreateClientTokenEmptyScope() {
+	_, err := o.oauthService.CreateClientToken(context.Background(), &o.client, "")
+	require.Equal(o.T(), ErrOAuthInvalidScope, err)
+}
+
+func (o *oauthSuite) TestCreateClientTokenPublicClient() {
+	o.client.Public = true
+	_, err := o.oauthService.CreateClientToken(context.Background(), &o.client, "")
+	require.Equal(o.T(), ErrOAuthUnauthorizedClient, err)
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/rs/zerolog/log"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/repo"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+	"github.com/ssup2ket/service-auth/pkg/tracing"
+)
+
+// Insert an event to outbox table in the transaction to publish the event
+func createOutbox(ctx context.Context, outboxRepo repo.OutboxRepo, tx repo.DBTx, spanName, aggregateType, aggregateID, eventType string, payload interface{}) error {
+	// Get outbox payload
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to marshal outbox payload")
+		return err
+	}
+
+	// Get span context as JSON
+	tracer := opentracing.GlobalTracer()
+	span, ctx := opentracing.StartSpanFromContext(ctx, spanName)
+	defer span.Finish()
+	spanContext, err := tracing.GetSpanContextAsJSON(tracer, span)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get outbox spancontext")
+		return err
+	}
+
+	// Insert outbox
+	outbox := entity.Outbox{
+		ID:            uuid.NewV4(),
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		EventType:     eventType,
+		Payload:       string(payloadJSON),
+		SpanContext:   spanContext,
+	}
+	if err = outboxRepo.WithTx(tx).Create(ctx, &outbox); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to insert event to outbox table")
+		return err
+	}
+	return nil
+}
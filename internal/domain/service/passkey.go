@@ -0,0 +1,285 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/repo"
+	"github.com/ssup2ket/service-auth/pkg/auth/webauthn"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+const passkeyDefaultName = "Passkey"
+
+// Passkey service of the subject user. Passkeys are registered by WebAuthn ceremonies started by a begin request
+// and finished with the authenticator's response to the challenge. The last passkey of a user without password
+// can't be deleted. ErrPasskeyDisabled is returned if no relying party is configured.
+type PasskeyService interface {
+	ListPasskey(ctx context.Context, subject *entity.Subject) ([]entity.WebAuthnCredential, error)
+	BeginPasskeyRegistration(ctx context.Context, subject *entity.Subject) (*entity.PasskeyRegistration, error)
+	FinishPasskeyRegistration(ctx context.Context, subject *entity.Subject, challengeUUID uuid.EntityUUID, name string,
+		attestation *entity.PasskeyAttestation) (*entity.WebAuthnCredential, error)
+	DeletePasskey(ctx context.Context, subject *entity.Subject, credentialUUID uuid.EntityUUID) error
+}
+
+type PasskeyServiceImp struct {
+	repoDBTx repo.DBTx
+
+	userInfoRepoPrimary           repo.UserInfoRepo
+	userSecretRepoPrimary         repo.UserSecretRepo
+	webAuthnCredentialRepoPrimary repo.WebAuthnCredentialRepo
+	webAuthnChallengeRepoPrimary  repo.WebAuthnChallengeRepo
+
+	relyingParty *webauthn.RelyingParty
+}
+
+func NewPasskeyServiceImp(dbTx repo.DBTx, userInfoPrimary repo.UserInfoRepo, userSecretPrimary repo.UserSecretRepo,
+	webAuthnCredentialPrimary repo.WebAuthnCredentialRepo, webAuthnChallengePrimary repo.WebAuthnChallengeRepo,
+	relyingParty *webauthn.RelyingParty) *PasskeyServiceImp {
+	return &PasskeyServiceImp{
+		repoDBTx: dbTx,
+
+		userInfoRepoPrimary:           userInfoPrimary,
+		userSecretRepoPrimary:         userSecretPrimary,
+		webAuthnCredentialRepoPrimary: webAuthnCredentialPrimary,
+		webAuthnChallengeRepoPrimary:  webAuthnChallengePrimary,
+
+		relyingParty: relyingParty,
+	}
+}
+
+func (p *PasskeyServiceImp) ListPasskey(ctx context.Context, subject *entity.Subject) ([]entity.WebAuthnCredential, error) {
+	if subject.IsClient() {
+		log.Ctx(ctx).Error().Msg("Client doesn't have passkeys")
+		return nil, ErrUnauthorized
+	}
+	credentials, err := p.webAuthnCredentialRepoPrimary.ListByUser(ctx, uuid.FromStringOrNil(subject.UserID))
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to list WebAuthn credentials from DB")
+		return nil, getReturnErr(err)
+	}
+	return credentials, nil
+}
+
+// Begin a registration excluding passkeys already registered, so an authenticator doesn't register a passkey twice
+func (p *PasskeyServiceImp) BeginPasskeyRegistration(ctx context.Context, subject *entity.Subject) (*entity.PasskeyRegistration, error) {
+	if p.relyingParty == nil {
+		log.Ctx(ctx).Error().Msg("WebAuthn relying party isn't configured")
+		return nil, ErrPasskeyDisabled
+	}
+	if subject.IsClient() {
+		log.Ctx(ctx).Error().Msg("Client doesn't have passkeys")
+		return nil, ErrUnauthorized
+	}
+
+	// Get user info and registered passkeys
+	userInfo, err := p.userInfoRepoPrimary.Get(ctx, subject.TenantID, uuid.FromStringOrNil(subject.UserID))
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get user info from DB")
+		return nil, getReturnErr(err)
+	}
+	credentials, err := p.webAuthnCredentialRepoPrimary.ListByUser(ctx, userInfo.ID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to list WebAuthn credentials from DB")
+		return nil, getReturnErr(err)
+	}
+	excludeCredentials := []webauthn.CredentialDescriptor{}
+	for _, credential := range credentials {
+		excludeCredentials = append(excludeCredentials, webauthn.NewCredentialDescriptor(credential.CredentialID, credential.Transports))
+	}
+
+	// Create challenge
+	challenge, err := createWebAuthnChallenge(ctx, p.webAuthnChallengeRepoPrimary, entity.WebAuthnChallengeTypeRegistration,
+		subject.TenantID, userInfo.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.PasskeyRegistration{
+		ChallengeID: challenge.ID,
+		Options: p.relyingParty.GetCreationOptions(challenge.Challenge, userInfo.ID.Bytes(), userInfo.LoginID, userInfo.LoginID,
+			excludeCredentials),
+	}, nil
+}
+
+// Finish a registration by the authenticator's attestation and store the new passkey
+func (p *PasskeyServiceImp) FinishPasskeyRegistration(ctx context.Context, subject *entity.Subject, challengeUUID uuid.EntityUUID,
+	name string, attestation *entity.PasskeyAttestation) (*entity.WebAuthnCredential, error) {
+	if p.relyingParty == nil {
+		log.Ctx(ctx).Error().Msg("WebAuthn relying party isn't configured")
+		return nil, ErrPasskeyDisabled
+	}
+	if subject.IsClient() {
+		log.Ctx(ctx).Error().Msg("Client doesn't have passkeys")
+		return nil, ErrUnauthorized
+	}
+	userUUID := uuid.FromStringOrNil(subject.UserID)
+
+	// Use challenge of the user
+	challenge, err := useWebAuthnChallenge(ctx, p.repoDBTx, p.webAuthnChallengeRepoPrimary, challengeUUID,
+		entity.WebAuthnChallengeTypeRegistration, subject.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if challenge.UserID != userUUID {
+		log.Ctx(ctx).Error().Msg("WebAuthn challenge is for other user")
+		return nil, ErrPasskeyVerificationFailed
+	}
+
+	// Verify attestation
+	credential, err := p.relyingParty.VerifyRegistration(challenge.Challenge, attestation.ClientDataJSON, attestation.AttestationObject)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to verify WebAuthn registration")
+		return nil, ErrPasskeyVerificationFailed
+	}
+	if !bytes.Equal(credential.ID, attestation.CredentialID) {
+		log.Ctx(ctx).Error().Msg("WebAuthn credential ID doesn't match")
+		return nil, ErrPasskeyVerificationFailed
+	}
+
+	// Create passkey
+	if name == "" {
+		name = passkeyDefaultName
+	}
+	webAuthnCredential := entity.WebAuthnCredential{
+		ID:           uuid.NewV4(),
+		UserID:       userUUID,
+		Name:         name,
+		CredentialID: credential.ID,
+		PublicKey:    credential.PublicKey,
+		SignCount:    credential.SignCount,
+		Transports:   attestation.Transports,
+	}
+	if err := p.webAuthnCredentialRepoPrimary.Create(ctx, &webAuthnCredential); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to create WebAuthn credential in DB")
+		return nil, getReturnErr(err)
+	}
+	return &webAuthnCredential, nil
+}
+
+// Delete a passkey. The last passkey of a user without password can't be deleted not to lock out the user.
+func (p *PasskeyServiceImp) DeletePasskey(ctx context.Context, subject *entity.Subject, credentialUUID uuid.EntityUUID) error {
+	var err error
+
+	if subject.IsClient() {
+		log.Ctx(ctx).Error().Msg("Client doesn't have passkeys")
+		return ErrUnauthorized
+	}
+	userUUID := uuid.FromStringOrNil(subject.UserID)
+
+	// Begin transaction
+	tx, _ := p.repoDBTx.Begin()
+	defer func() {
+		if err != nil {
+			if err = tx.Rollback(); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Rollback transaction error for deleting passkey")
+				return
+			}
+			log.Ctx(ctx).Error().Err(err).Msg("Delete passkey request is canceled")
+			return
+		}
+	}()
+
+	// Delete passkey and check remaining passkeys
+	if err = p.webAuthnCredentialRepoPrimary.WithTx(tx).Delete(ctx, userUUID, credentialUUID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete WebAuthn credential from DB")
+		return getReturnErr(err)
+	}
+	userSecret, err := p.userSecretRepoPrimary.WithTx(tx).Get(ctx, userUUID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get user secret from DB")
+		return getReturnErr(err)
+	}
+	if !userSecret.HasPasswd() {
+		var count int
+		count, err = p.webAuthnCredentialRepoPrimary.WithTx(tx).CountByUser(ctx, userUUID)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to count WebAuthn credentials from DB")
+			return getReturnErr(err)
+		}
+		if count == 0 {
+			log.Ctx(ctx).Error().Msg("Last passkey of user without password can't be deleted")
+			err = ErrPasskeyRequired
+			return err
+		}
+	}
+
+	// Commit transaction
+	if err = tx.Commit(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Commit transaction error for deleting passkey")
+		return getReturnErr(err)
+	}
+	return nil
+}
+
+// Create a WebAuthn challenge of the ceremony. Empty user UUID means a login challenge for discoverable credentials.
+func createWebAuthnChallenge(ctx context.Context, webAuthnChallengeRepo repo.WebAuthnChallengeRepo, challengeType entity.WebAuthnChallengeType,
+	tenantID string, userUUID uuid.EntityUUID) (*entity.WebAuthnChallenge, error) {
+	random, err := webauthn.GenerateChallenge()
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to generate WebAuthn challenge")
+		return nil, ErrServerErr
+	}
+	challenge := entity.WebAuthnChallenge{
+		ID:        uuid.NewV4(),
+		Type:      challengeType,
+		TenantID:  tenantID,
+		UserID:    userUUID,
+		Challenge: random,
+		ExpiresAt: time.Now().Add(webauthn.Timeout),
+	}
+	if err := webAuthnChallengeRepo.Create(ctx, &challenge); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to create WebAuthn challenge in DB")
+		return nil, getReturnErr(err)
+	}
+	return &challenge, nil
+}
+
+// Get and delete the WebAuthn challenge with lock not to use the challenge concurrently. ErrPasskeyVerificationFailed
+// is returned if the challenge doesn't exist, is expired, or is for another ceremony or tenant.
+func useWebAuthnChallenge(ctx context.Context, dbTx repo.DBTx, webAuthnChallengeRepo repo.WebAuthnChallengeRepo,
+	challengeUUID uuid.EntityUUID, challengeType entity.WebAuthnChallengeType, tenantID string) (*entity.WebAuthnChallenge, error) {
+	var err error
+
+	// Begin transaction
+	tx, _ := dbTx.Begin()
+	defer func() {
+		if err != nil {
+			if err = tx.Rollback(); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Rollback transaction error for using WebAuthn challenge")
+				return
+			}
+			log.Ctx(ctx).Error().Err(err).Msg("Using WebAuthn challenge is canceled")
+			return
+		}
+	}()
+
+	challenge, err := webAuthnChallengeRepo.WithTx(tx).GetForUpdate(ctx, challengeUUID)
+	if err == repo.ErrNotFound {
+		log.Ctx(ctx).Error().Msg("WebAuthn challenge doesn't exist")
+		return nil, ErrPasskeyVerificationFailed
+	} else if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get WebAuthn challenge")
+		return nil, getReturnErr(err)
+	}
+	if err = webAuthnChallengeRepo.WithTx(tx).Delete(ctx, challengeUUID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete WebAuthn challenge")
+		return nil, getReturnErr(err)
+	}
+
+	// Commit transaction
+	if err = tx.Commit(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Commit transaction error for using WebAuthn challenge")
+		return nil, getReturnErr(err)
+	}
+
+	if challenge.Type != challengeType || challenge.TenantID != tenantID || time.Now().After(challenge.ExpiresAt) {
+		log.Ctx(ctx).Error().Str("type", string(challenge.Type)).Msg("WebAuthn challenge is wrong or expired")
+		return nil, ErrPasskeyVerificationFailed
+	}
+	return challenge, nil
+}
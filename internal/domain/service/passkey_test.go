@@ -0,0 +1,306 @@
+package service
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/repo"
+	"github.com/ssup2ket/service-auth/internal/domain/repo/mocks"
+	"github.com/ssup2ket/service-auth/internal/test"
+	"github.com/ssup2ket/service-auth/pkg/auth/webauthn"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+var testRelyingParty = webauthn.RelyingParty{
+	ID:      test.PasskeyRPIDCorrect,
+	Name:    test.PasskeyRPIDCorrect,
+	Origins: []string{test.PasskeyOriginCorrect},
+}
+
+// Authenticator having an ES256 passkey to make responses of WebAuthn ceremonies like a browser
+type testPasskeyAuthenticator struct {
+	key *ecdsa.PrivateKey
+}
+
+func newTestPasskeyAuthenticator(t *testing.T) *testPasskeyAuthenticator {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return &testPasskeyAuthenticator{key: key}
+}
+
+// Get the public key as a COSE key {1: 2, 3: -7, -1: 1, -2: x, -3: y}
+func (a *testPasskeyAuthenticator) getPublicKey() []byte {
+	x, y := make([]byte, 32), make([]byte, 32)
+	a.key.X.FillBytes(x)
+	a.key.Y.FillBytes(y)
+	publicKey := []byte{0xa5, 0x01, 0x02, 0x03, 0x26, 0x20, 0x01, 0x21, 0x58, 0x20}
+	publicKey = append(publicKey, x...)
+	publicKey = append(publicKey, 0x22, 0x58, 0x20)
+	return append(publicKey, y...)
+}
+
+func (a *testPasskeyAuthenticator) getAuthData(signCount uint32, attested bool) []byte {
+	rpIDHash := sha256.Sum256([]byte(test.PasskeyRPIDCorrect))
+	flags := byte(0x05) // User present and verified
+	if attested {
+		flags |= 0x40
+	}
+	authData := append(rpIDHash[:], flags, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(authData[33:], signCount)
+	if attested {
+		authData = append(authData, make([]byte, 16)...)
+		authData = append(authData, 0, byte(len(test.PasskeyCredentialIDCorrect)))
+		authData = append(authData, test.PasskeyCredentialIDCorrect...)
+		authData = append(authData, a.getPublicKey()...)
+	}
+	return authData
+}
+
+// Get the attestation object {"fmt": "none", "attStmt": {}, "authData": authData}
+func (a *testPasskeyAuthenticator) getAttestation(t *testing.T, challenge []byte) *entity.PasskeyAttestation {
+	authData := a.getAuthData(0, true)
+	attestationObject := []byte{0xa3, 0x63, 'f', 'm', 't', 0x64, 'n', 'o', 'n', 'e', 0x67, 'a', 't', 't', 'S', 't', 'm', 't', 0xa0,
+		0x68, 'a', 'u', 't', 'h', 'D', 'a', 't', 'a', 0x58, byte(len(authData))}
+	return &entity.PasskeyAttestation{
+		CredentialID:      test.PasskeyCredentialIDCorrect,
+		ClientDataJSON:    getTestClientDataJSON(t, "webauthn.create", challenge),
+		AttestationObject: append(attestationObject, authData...),
+		Transports:        []string{test.PasskeyTransportCorrect},
+	}
+}
+
+func (a *testPasskeyAuthenticator) getAssertion(t *testing.T, challenge []byte, signCount uint32) *entity.PasskeyAssertion {
+	clientDataJSON := getTestClientDataJSON(t, "webauthn.get", challenge)
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	authData := a.getAuthData(signCount, false)
+	digest := sha256.Sum256(append(append([]byte{}, authData...), clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	require.NoError(t, err)
+	return &entity.PasskeyAssertion{
+		CredentialID:      test.PasskeyCredentialIDCorrect,
+		ClientDataJSON:    clientDataJSON,
+		AuthenticatorData: authData,
+		Signature:         signature,
+		UserHandle:        test.UserIDCorrect.Bytes(),
+	}
+}
+
+func getTestClientDataJSON(t *testing.T, clientDataType string, challenge []byte) []byte {
+	clientDataJSON, err := json.Marshal(map[string]string{
+		"type":      clientDataType,
+		"challenge": webauthn.EncodeBase64URL(challenge),
+		"origin":    test.PasskeyOriginCorrect,
+	})
+	require.NoError(t, err)
+	return clientDataJSON
+}
+
+// Mock the transaction using the challenge
+func mockUseWebAuthnChallenge(dbTx *mocks.DBTx, challengeRepo *mocks.WebAuthnChallengeRepo, challenge *entity.WebAuthnChallenge) {
+	dbTx.On("Begin").Return(dbTx, nil)
+	dbTx.On("Commit").Return(nil)
+	challengeRepo.On("WithTx", mock.Anything).Return(challengeRepo)
+	challengeRepo.On("GetForUpdate", context.Background(), test.PasskeyChallengeIDCorrect).Return(challenge, nil)
+	challengeRepo.On("Delete", context.Background(), test.PasskeyChallengeIDCorrect).Return(nil)
+}
+
+func TestPasskey(t *testing.T) {
+	suite.Run(t, new(passkeySuite))
+}
+
+type passkeySuite struct {
+	suite.Suite
+
+	dbTx                   mocks.DBTx
+	userInfoRepo           mocks.UserInfoRepo
+	userSecretRepo         mocks.UserSecretRepo
+	webAuthnCredentialRepo mocks.WebAuthnCredentialRepo
+	webAuthnChallengeRepo  mocks.WebAuthnChallengeRepo
+
+	authenticator *testPasskeyAuthenticator
+
+	passkeyService PasskeyService
+}
+
+func (p *passkeySuite) SetupTest() {
+	// Init transaction, repo
+	p.dbTx = mocks.DBTx{}
+	p.userInfoRepo = mocks.UserInfoRepo{}
+	p.userSecretRepo = mocks.UserSecretRepo{}
+	p.webAuthnCredentialRepo = mocks.WebAuthnCredentialRepo{}
+	p.webAuthnChallengeRepo = mocks.WebAuthnChallengeRepo{}
+
+	// Set nooptracer
+	opentracing.SetGlobalTracer(opentracing.NoopTracer{})
+
+	// Init service
+	p.authenticator = newTestPasskeyAuthenticator(p.T())
+	p.passkeyService = NewPasskeyServiceImp(&p.dbTx, &p.userInfoRepo, &p.userSecretRepo, &p.webAuthnCredentialRepo,
+		&p.webAuthnChallengeRepo, &testRelyingParty)
+}
+
+func (p *passkeySuite) getRegistrationChallenge(userUUID uuid.EntityUUID) *entity.WebAuthnChallenge {
+	return &entity.WebAuthnChallenge{
+		ID:        test.PasskeyChallengeIDCorrect,
+		Type:      entity.WebAuthnChallengeTypeRegistration,
+		TenantID:  test.TenantIDCorrect,
+		UserID:    userUUID,
+		Challenge: test.PasskeyChallengeCorrect,
+		ExpiresAt: time.Now().Add(time.Minute),
+	}
+}
+
+func (p *passkeySuite) TestListPasskeyClient() {
+	_, err := p.passkeyService.ListPasskey(context.Background(), &entity.Subject{TenantID: test.TenantIDCorrect})
+	require.Equal(p.T(), ErrUnauthorized, err)
+}
+
+func (p *passkeySuite) TestBeginPasskeyRegistrationSuccess() {
+	var createdChallenge *entity.WebAuthnChallenge
+	p.userInfoRepo.On("Get", context.Background(), test.TenantIDCorrect, test.UserIDCorrect).Return(&entity.UserInfo{
+		ID: test.UserIDCorrect, TenantID: test.TenantIDCorrect, LoginID: test.UserLoginIDCorrect}, nil)
+	p.webAuthnCredentialRepo.On("ListByUser", context.Background(), test.UserIDCorrect).Return([]entity.WebAuthnCredential{
+		{ID: test.PasskeyIDCorrect, CredentialID: test.PasskeyCredentialIDCorrect}}, nil)
+	p.webAuthnChallengeRepo.On("Create", context.Background(), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		createdChallenge = args.Get(1).(*entity.WebAuthnChallenge)
+	})
+
+	registration, err := p.passkeyService.BeginPasskeyRegistration(context.Background(), &test.SubjectUserCorrect)
+	require.NoError(p.T(), err)
+	require.Equal(p.T(), createdChallenge.ID, registration.ChallengeID)
+	require.Equal(p.T(), entity.WebAuthnChallengeTypeRegistration, createdChallenge.Type)
+	require.Equal(p.T(), test.UserIDCorrect, createdChallenge.UserID)
+	require.Equal(p.T(), webauthn.EncodeBase64URL(createdChallenge.Challenge), registration.Options.Challenge)
+	require.Equal(p.T(), webauthn.EncodeBase64URL(test.UserIDCorrect.Bytes()), registration.Options.User.ID)
+	require.Equal(p.T(), test.PasskeyRPIDCorrect, registration.Options.RP.ID)
+	require.Len(p.T(), registration.Options.ExcludeCredentials, 1)
+	require.Equal(p.T(), webauthn.EncodeBase64URL(test.PasskeyCredentialIDCorrect), registration.Options.ExcludeCredentials[0].ID)
+}
+
+func (p *passkeySuite) TestBeginPasskeyRegistrationDisabled() {
+	passkeyService := NewPasskeyServiceImp(&p.dbTx, &p.userInfoRepo, &p.userSecretRepo, &p.webAuthnCredentialRepo,
+		&p.webAuthnChallengeRepo, nil)
+
+	_, err := passkeyService.BeginPasskeyRegistration(context.Background(), &test.SubjectUserCorrect)
+	require.Equal(p.T(), ErrPasskeyDisabled, err)
+}
+
+func (p *passkeySuite) TestFinishPasskeyRegistrationSuccess() {
+	var createdCredential *entity.WebAuthnCredential
+	mockUseWebAuthnChallenge(&p.dbTx, &p.webAuthnChallengeRepo, p.getRegistrationChallenge(test.UserIDCorrect))
+	p.webAuthnCredentialRepo.On("Create", context.Background(), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		createdCredential = args.Get(1).(*entity.WebAuthnCredential)
+	})
+
+	credential, err := p.passkeyService.FinishPasskeyRegistration(context.Background(), &test.SubjectUserCorrect,
+		test.PasskeyChallengeIDCorrect, "", p.authenticator.getAttestation(p.T(), test.PasskeyChallengeCorrect))
+	require.NoError(p.T(), err)
+	require.Equal(p.T(), createdCredential, credential)
+	require.Equal(p.T(), test.UserIDCorrect, credential.UserID)
+	require.Equal(p.T(), passkeyDefaultName, credential.Name)
+	require.Equal(p.T(), test.PasskeyCredentialIDCorrect, credential.CredentialID)
+	require.Equal(p.T(), p.authenticator.getPublicKey(), credential.PublicKey)
+	require.Equal(p.T(), entity.StrList{test.PasskeyTransportCorrect}, credential.Transports)
+}
+
+func (p *passkeySuite) TestFinishPasskeyRegistrationOtherUser() {
+	mockUseWebAuthnChallenge(&p.dbTx, &p.webAuthnChallengeRepo, p.getRegistrationChallenge(test.UserIDCorrect2))
+
+	_, err := p.passkeyService.FinishPasskeyRegistration(context.Background(), &test.SubjectUserCorrect,
+		test.PasskeyChallengeIDCorrect, test.PasskeyNameCorrect, p.authenticator.getAttestation(p.T(), test.PasskeyChallengeCorrect))
+	require.Equal(p.T(), ErrPasskeyVerificationFailed, err)
+	p.webAuthnCredentialRepo.AssertNotCalled(p.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (p *passkeySuite) TestFinishPasskeyRegistrationExpired() {
+	challenge := p.getRegistrationChallenge(test.UserIDCorrect)
+	challenge.ExpiresAt = time.Now().Add(-time.Second)
+	mockUseWebAuthnChallenge(&p.dbTx, &p.webAuthnChallengeRepo, challenge)
+
+	_, err := p.passkeyService.FinishPasskeyRegistration(context.Background(), &test.SubjectUserCorrect,
+		test.PasskeyChallengeIDCorrect, test.PasskeyNameCorrect, p.authenticator.getAttestation(p.T(), test.PasskeyChallengeCorrect))
+	require.Equal(p.T(), ErrPasskeyVerificationFailed, err)
+	p.webAuthnChallengeRepo.AssertCalled(p.T(), "Delete", context.Background(), test.PasskeyChallengeIDCorrect)
+}
+
+func (p *passkeySuite) TestFinishPasskeyRegistrationUsedChallenge() {
+	p.dbTx.On("Begin").Return(&p.dbTx, nil)
+	p.dbTx.On("Rollback").Return(nil)
+	p.webAuthnChallengeRepo.On("WithTx", mock.Anything).Return(&p.webAuthnChallengeRepo)
+	p.webAuthnChallengeRepo.On("GetForUpdate", context.Background(), test.PasskeyChallengeIDCorrect).Return(nil, repo.ErrNotFound)
+
+	_, err := p.passkeyService.FinishPasskeyRegistration(context.Background(), &test.SubjectUserCorrect,
+		test.PasskeyChallengeIDCorrect, test.PasskeyNameCorrect, p.authenticator.getAttestation(p.T(), test.PasskeyChallengeCorrect))
+	require.Equal(p.T(), ErrPasskeyVerificationFailed, err)
+	p.dbTx.AssertCalled(p.T(), "Rollback")
+}
+
+func (p *passkeySuite) TestFinishPasskeyRegistrationWrongChallenge() {
+	mockUseWebAuthnChallenge(&p.dbTx, &p.webAuthnChallengeRepo, p.getRegistrationChallenge(test.UserIDCorrect))
+
+	_, err := p.passkeyService.FinishPasskeyRegistration(context.Background(), &test.SubjectUserCorrect,
+		test.PasskeyChallengeIDCorrect, test.PasskeyNameCorrect, p.authenticator.getAttestation(p.T(), []byte("wrong-challenge")))
+	require.Equal(p.T(), ErrPasskeyVerificationFailed, err)
+	p.webAuthnCredentialRepo.AssertNotCalled(p.T(), "Create", mock.Anything, mock.Anything)
+}
+
+// Mock the transaction deleting the passkey of the user with or without password
+func (p *passkeySuite) mockDeletePasskey(userSecret *entity.UserSecret) {
+	p.dbTx.On("Begin").Return(&p.dbTx, nil)
+	p.webAuthnCredentialRepo.On("WithTx", mock.Anything).Return(&p.webAuthnCredentialRepo)
+	p.webAuthnCredentialRepo.On("Delete", context.Background(), test.UserIDCorrect, test.PasskeyIDCorrect).Return(nil)
+	p.userSecretRepo.On("WithTx", mock.Anything).Return(&p.userSecretRepo)
+	p.userSecretRepo.On("Get", context.Background(), test.UserIDCorrect).Return(userSecret, nil)
+}
+
+func (p *passkeySuite) TestDeletePasskeySuccess() {
+	p.mockDeletePasskey(&entity.UserSecret{ID: test.UserIDCorrect, PasswdPHC: "$argon2id$test"})
+	p.dbTx.On("Commit").Return(nil)
+
+	err := p.passkeyService.DeletePasskey(context.Background(), &test.SubjectUserCorrect, test.PasskeyIDCorrect)
+	require.NoError(p.T(), err)
+	p.webAuthnCredentialRepo.AssertNotCalled(p.T(), "CountByUser", mock.Anything, mock.Anything)
+}
+
+func (p *passkeySuite) TestDeletePasskeyPasswdlessRemaining() {
+	p.mockDeletePasskey(&entity.UserSecret{ID: test.UserIDCorrect})
+	p.webAuthnCredentialRepo.On("CountByUser", context.Background(), test.UserIDCorrect).Return(1, nil)
+	p.dbTx.On("Commit").Return(nil)
+
+	err := p.passkeyService.DeletePasskey(context.Background(), &test.SubjectUserCorrect, test.PasskeyIDCorrect)
+	require.NoError(p.T(), err)
+}
+
+func (p *passkeySuite) TestDeletePasskeyPasswdlessLast() {
+	p.mockDeletePasskey(&entity.UserSecret{ID: test.UserIDCorrect})
+	p.webAuthnCredentialRepo.On("CountByUser", context.Background(), test.UserIDCorrect).Return(0, nil)
+	p.dbTx.On("Rollback").Return(nil)
+
+	err := p.passkeyService.DeletePasskey(context.Background(), &test.SubjectUserCorrect, test.PasskeyIDCorrect)
+	require.Equal(p.T(), ErrPasskeyRequired, err)
+	p.dbTx.AssertCalled(p.T(), "Rollback")
+	p.dbTx.AssertNotCalled(p.T(), "Commit")
+}
+
+func (p *passkeySuite) TestDeletePasskeyNotFound() {
+	p.dbTx.On("Begin").Return(&p.dbTx, nil)
+	p.dbTx.On("Rollback").Return(nil)
+	p.webAuthnCredentialRepo.On("WithTx", mock.Anything).Return(&p.webAuthnCredentialRepo)
+	p.webAuthnCredentialRepo.On("Delete", context.Background(), test.UserIDCorrect, test.PasskeyIDCorrect).Return(repo.ErrNotFound)
+
+	err := p.passkeyService.DeletePasskey(context.Background(), &test.SubjectUserCorrect, test.PasskeyIDCorrect)
+	require.Equal(p.T(), ErrRepoNotFound, err)
+}
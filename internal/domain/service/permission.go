@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/repo"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+// Permission service. Every change of permissions increases the policy version,
+// so every replica reloads permissions to its enforcer.
+type PermissionService interface {
+	ListPermission(ctx context.Context, offset int, limit int) ([]entity.Permission, error)
+	CreatePermission(ctx context.Context, permission *entity.Permission) (*entity.Permission, error)
+	GetPermission(ctx context.Context, permissionUUID uuid.EntityUUID) (*entity.Permission, error)
+	UpdatePermission(ctx context.Context, permission *entity.Permission) error
+	DeletePermission(ctx context.Context, permissionUUID uuid.EntityUUID) error
+
+	InitPermissions(ctx context.Context, policies [][]string) error
+}
+
+type PermissionServiceImp struct {
+	repoDBTx repo.DBTx
+
+	permissionRepoPrimary   repo.PermissionRepo
+	permissionRepoSecondary repo.PermissionRepo
+	roleRepoPrimary         repo.RoleRepo
+}
+
+func NewPermissionServiceImp(dbTx repo.DBTx, permissionPrimary, permissionSecondary repo.PermissionRepo,
+	rolePrimary repo.RoleRepo) *PermissionServiceImp {
+	return &PermissionServiceImp{
+		repoDBTx: dbTx,
+
+		permissionRepoPrimary:   permissionPrimary,
+		permissionRepoSecondary: permissionSecondary,
+		roleRepoPrimary:         rolePrimary,
+	}
+}
+
+func (p *PermissionServiceImp) ListPermission(ctx context.Context, offset int, limit int) ([]entity.Permission, error) {
+	// Set default limit
+	if limit == 0 {
+		limit = 50
+	}
+
+	// List permissions
+	permissions, err := p.permissionRepoSecondary.List(ctx, offset, limit)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to list permissions from DB")
+		return nil, getReturnErr(err)
+	}
+	return permissions, nil
+}
+
+func (p *PermissionServiceImp) CreatePermission(ctx context.Context, permission *entity.Permission) (*entity.Permission, error) {
+	var err error
+
+	// Begin transaction
+	tx, _ := p.repoDBTx.Begin()
+	defer func() {
+		if err != nil {
+			if err = tx.Rollback(); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Rollback transaction error for creating permission")
+				return
+			}
+			log.Ctx(ctx).Error().Err(err).Msg("Create permission request is canceled")
+			return
+		}
+	}()
+
+	// Check role of subject exists
+	if err = p.checkSubjectRoleExist(ctx, tx, permission.Subject); err != nil {
+		return nil, err
+	}
+
+	// Create permission
+	permission.ID = uuid.NewV4()
+	if err = p.permissionRepoPrimary.WithTx(tx).Create(ctx, permission); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to create permission to DB")
+		return nil, getReturnErr(err)
+	}
+	if err = p.permissionRepoPrimary.WithTx(tx).IncreasePolicyVersion(ctx); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to increase policy version from DB")
+		return nil, getReturnErr(err)
+	}
+
+	// Commit transaction
+	if err = tx.Commit(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Commit transaction error for creating permission")
+		return nil, getReturnErr(err)
+	}
+	return permission, nil
+}
+
+func (p *PermissionServiceImp) GetPermission(ctx context.Context, permissionUUID uuid.EntityUUID) (*entity.Permission, error) {
+	permission, err := p.permissionRepoSecondary.Get(ctx, permissionUUID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get permission from DB")
+		return nil, getReturnErr(err)
+	}
+	return permission, nil
+}
+
+// Update the subject, the object and the action of a permission
+func (p *PermissionServiceImp) UpdatePermission(ctx context.Context, permission *entity.Permission) error {
+	var err error
+
+	// Begin transaction
+	tx, _ := p.repoDBTx.Begin()
+	defer func() {
+		if err != nil {
+			if err = tx.Rollback(); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Rollback transaction error for updating permission")
+				return
+			}
+			log.Ctx(ctx).Error().Err(err).Msg("Update permission request is canceled")
+			return
+		}
+	}()
+
+	// Check permission exists
+	if _, err = p.permissionRepoPrimary.WithTx(tx).Get(ctx, permission.ID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get permission from DB")
+		return getReturnErr(err)
+	}
+
+	// Check role of subject exists
+	if err = p.checkSubjectRoleExist(ctx, tx, permission.Subject); err != nil {
+		return err
+	}
+
+	// Update permission
+	if err = p.permissionRepoPrimary.WithTx(tx).Update(ctx, permission); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to update permission from DB")
+		return getReturnErr(err)
+	}
+	if err = p.permissionRepoPrimary.WithTx(tx).IncreasePolicyVersion(ctx); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to increase policy version from DB")
+		return getReturnErr(err)
+	}
+
+	// Commit transaction
+	if err = tx.Commit(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Commit transaction error for updating permission")
+		return getReturnErr(err)
+	}
+	return nil
+}
+
+func (p *PermissionServiceImp) DeletePermission(ctx context.Context, permissionUUID uuid.EntityUUID) error {
+	var err error
+
+	// Begin transaction
+	tx, _ := p.repoDBTx.Begin()
+	defer func() {
+		if err != nil {
+			if err = tx.Rollback(); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Rollback transaction error for deleting permission")
+				return
+			}
+			log.Ctx(ctx).Error().Err(err).Msg("Delete permission request is canceled")
+			return
+		}
+	}()
+
+	// Check permission exists
+	if _, err = p.permissionRepoPrimary.WithTx(tx).Get(ctx, permissionUUID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get permission from DB")
+		return getReturnErr(err)
+	}
+
+	// Delete permission
+	if err = p.permissionRepoPrimary.WithTx(tx).Delete(ctx, permissionUUID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete permission from DB")
+		return getReturnErr(err)
+	}
+	if err = p.permissionRepoPrimary.WithTx(tx).IncreasePolicyVersion(ctx); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to increase policy version from DB")
+		return getReturnErr(err)
+	}
+
+	// Commit transaction
+	if err = tx.Commit(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Commit transaction error for deleting permission")
+		return getReturnErr(err)
+	}
+	return nil
+}
+
+// Init permissions with the policies of the policy file only if there is no permission.
+// Other replicas may init them at the same time.
+func (p *PermissionServiceImp) InitPermissions(ctx context.Context, policies [][]string) error {
+	// Check permissions exist
+	count, err := p.permissionRepoPrimary.Count(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to count permissions from DB")
+		return getReturnErr(err)
+	}
+	if count > 0 {
+		return nil
+	}
+
+	// Create permissions
+	for _, policy := range policies {
+		if len(policy) != 3 {
+			log.Ctx(ctx).Error().Strs("policy", policy).Msg("Wrong policy")
+			return ErrServerErr
+		}
+
+		permission := entity.Permission{
+			ID:      uuid.NewV4(),
+			Subject: policy[0],
+			Object:  policy[1],
+			Action:  policy[2],
+		}
+		if err := p.permissionRepoPrimary.Create(ctx, &permission); err != nil && err != repo.ErrConflict {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to create permission to DB")
+			return getReturnErr(err)
+		}
+	}
+	if err := p.permissionRepoPrimary.IncreasePolicyVersion(ctx); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to increase policy version from DB")
+		return getReturnErr(err)
+	}
+	return nil
+}
+
+// Check the role of the subject exists. A scope subject doesn't need a role.
+func (p *PermissionServiceImp) checkSubjectRoleExist(ctx context.Context, tx repo.DBTx, subject string) error {
+	if strings.HasPrefix(subject, entity.PermissionScopePrefix) {
+		return nil
+	}
+	return checkRoleExist(ctx, p.roleRepoPrimary, tx, subject)
+}
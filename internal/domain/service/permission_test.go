@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/repo"
+	"github.com/ssup2ket/service-auth/internal/domain/repo/mocks"
+	"github.com/ssup2ket/service-auth/internal/test"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+func TestPermission(t *testing.T) {
+	suite.Run(t, new(permissionSuite))
+}
+
+type permissionSuite struct {
+	suite.Suite
+
+	dbTx           mocks.DBTx
+	permissionRepo mocks.PermissionRepo
+	roleRepo       mocks.RoleRepo
+
+	permissionService PermissionService
+}
+
+func (p *permissionSuite) SetupTest() {
+	// Init transaction, repo
+	p.dbTx = mocks.DBTx{}
+	p.permissionRepo = mocks.PermissionRepo{}
+	p.roleRepo = mocks.RoleRepo{}
+
+	// Init service
+	p.permissionService = NewPermissionServiceImp(&p.dbTx, &p.permissionRepo, &p.permissionRepo, &p.roleRepo)
+}
+
+func (p *permissionSuite) TestListPermissionSuccess() {
+	p.permissionRepo.On("List", context.Background(), 0, 50).
+		Return([]entity.Permission{test.PermissionCorrect}, nil)
+
+	permissions, err := p.permissionService.ListPermission(context.Background(), 0, 0)
+	require.NoError(p.T(), err)
+	require.Equal(p.T(), test.PermissionIDCorrect, permissions[0].ID)
+}
+
+func (p *permissionSuite) TestCreatePermissionSuccess() {
+	permission := test.PermissionCorrect
+
+	p.dbTx.On("Begin").Return(&p.dbTx, nil)
+	p.roleRepo.On("WithTx", mock.Anything).Return(&p.roleRepo)
+	p.roleRepo.On("Get", context.Background(), test.RoleNameCorrect).Return(&test.RoleCorrect, nil)
+	p.permissionRepo.On("WithTx", mock.Anything).Return(&p.permissionRepo)
+	p.permissionRepo.On("Create", context.Background(), &permission).Return(nil)
+	p.permissionRepo.On("IncreasePolicyVersion", context.Background()).Return(nil)
+	p.dbTx.On("Commit").Return(nil)
+
+	created, err := p.permissionService.CreatePermission(context.Background(), &permission)
+	require.NoError(p.T(), err)
+	require.NotEqual(p.T(), uuid.EntityUUID{}, created.ID)
+	p.permissionRepo.AssertCalled(p.T(), "IncreasePolicyVersion", context.Background())
+}
+
+func (p *permissionSuite) TestCreatePermissionScopeSubject() {
+	permission := test.PermissionCorrect
+	permission.Subject = entity.PermissionScopePrefix + entity.ScopeUsersMeRead
+
+	p.dbTx.On("Begin").Return(&p.dbTx, nil)
+	p.permissionRepo.On("WithTx", mock.Anything).Return(&p.permissionRepo)
+	p.permissionRepo.On("Create", context.Background(), &permission).Return(nil)
+	p.permissionRepo.On("IncreasePolicyVersion", context.Background()).Return(nil)
+	p.dbTx.On("Commit").Return(nil)
+
+	_, err := p.permissionService.CreatePermission(context.Background(), &permission)
+	require.NoError(p.T(), err)
+	p.roleRepo.AssertNotCalled(p.T(), "Get", mock.Anything, mock.Anything)
+}
+
+func (p *permissionSuite) TestCreatePermissionRoleNotExist() {
+	permission := test.PermissionCorrect
+
+	p.dbTx.On("Begin").Return(&p.dbTx, nil)
+	p.roleRepo.On("WithTx", mock.Anything).Return(&p.roleRepo)
+	p.roleRepo.On("Get", context.Background(), test.RoleNameCorrect).Return(nil, repo.ErrNotFound)
+	p.dbTx.On("Rollback").Return(nil)
+
+	_, err := p.permissionService.CreatePermission(context.Background(), &permission)
+	require.Equal(p.T(), ErrRoleNotExist, err)
+	p.permissionRepo.AssertNotCalled(p.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (p *permissionSuite) TestUpdatePermissionSuccess() {
+	permission := test.PermissionCorrect
+
+	p.dbTx.On("Begin").Return(&p.dbTx, nil)
+	p.permissionRepo.On("WithTx", mock.Anything).Return(&p.permissionRepo)
+	p.permissionRepo.On("Get", context.Background(), test.PermissionIDCorrect).Return(&test.PermissionCorrect, nil)
+	p.roleRepo.On("WithTx", mock.Anything).Return(&p.roleRepo)
+	p.roleRepo.On("Get", context.Background(), test.RoleNameCorrect).Return(&test.RoleCorrect, nil)
+	p.permissionRepo.On("Update", context.Background(), &permission).Return(nil)
+	p.permissionRepo.On("IncreasePolicyVersion", context.Background()).Return(nil)
+	p.dbTx.On("Commit").Return(nil)
+
+	err := p.permissionService.UpdatePermission(context.Background(), &permission)
+	require.NoError(p.T(), err)
+}
+
+func (p *permissionSuite) TestDeletePermissionNotFound() {
+	p.dbTx.On("Begin").Return(&p.dbTx, nil)
+	p.permissionRepo.On("WithTx", mock.Anything).Return(&p.permissionRepo)
+	p.permissionRepo.On("Get", context.Background(), test.PermissionIDCorrect).Return(nil, repo.ErrNotFound)
+	p.dbTx.On("Rollback").Return(nil)
+
+	err := p.permissionService.DeletePermission(context.Background(), test.PermissionIDCorrect)
+	require.Equal(p.T(), ErrRepoNotFound, err)
+	p.permissionRepo.AssertNotCalled(p.T(), "IncreasePolicyVersion", mock.Anything)
+}
+
+func (p *permissionSuite) TestInitPermissionsSuccess() {
+	p.permissionRepo.On("Count", context.Background()).Return(int64(0), nil)
+	p.permissionRepo.On("Create", context.Background(), mock.Anything).Return(nil)
+	p.permissionRepo.On("IncreasePolicyVersion", context.Background()).Return(nil)
+
+	err := p.permissionService.InitPermissions(context.Background(), [][]string{
+		{test.RoleNameCorrect, test.PermissionObjectCorrect, test.PermissionActionCorrect},
+		{string(entity.UserRoleAdmin), test.PermissionObjectCorrect, test.PermissionActionCorrect},
+	})
+	require.NoError(p.T(), err)
+	p.permissionRepo.AssertNumberOfCalls(p.T(), "Create", 2)
+}
+
+func (p *permissionSuite) TestInitPermissionsExist() {
+	p.permissionRepo.On("Count", context.Background()).Return(int64(1), nil)
+
+	err := p.permissionService.InitPermissions(context.Background(), [][]string{
+		{test.RoleNameCorrect, test.PermissionObjectCorrect, test.PermissionActionCorrect},
+	})
+	require.NoError(p.T(), err)
+	p.permissionRepo.AssertNotCalled(p.T(), "Create", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/repo"
+)
+
+// Role service
+type RoleService interface {
+	ListRole(ctx context.Context, offset int, limit int) ([]entity.Role, error)
+	CreateRole(ctx context.Context, role *entity.Role) (*entity.Role, error)
+	GetRole(ctx context.Context, name string) (*entity.Role, error)
+	UpdateRole(ctx context.Context, role *entity.Role) error
+	DeleteRole(ctx context.Context, name string) error
+
+	CreateDefaultRoles(ctx context.Context) error
+}
+
+type RoleServiceImp struct {
+	repoDBTx repo.DBTx
+
+	roleRepoPrimary       repo.RoleRepo
+	roleRepoSecondary     repo.RoleRepo
+	permissionRepoPrimary repo.PermissionRepo
+	userInfoRepoPrimary   repo.UserInfoRepo
+	groupRepoPrimary      repo.GroupRepo
+}
+
+func NewRoleServiceImp(dbTx repo.DBTx, rolePrimary, roleSecondary repo.RoleRepo, permissionPrimary repo.PermissionRepo,
+	userInfoPrimary repo.UserInfoRepo, groupPrimary repo.GroupRepo) *RoleServiceImp {
+	return &RoleServiceImp{
+		repoDBTx: dbTx,
+
+		roleRepoPrimary:       rolePrimary,
+		roleRepoSecondary:     roleSecondary,
+		permissionRepoPrimary: permissionPrimary,
+		userInfoRepoPrimary:   userInfoPrimary,
+		groupRepoPrimary:      groupPrimary,
+	}
+}
+
+func (r *RoleServiceImp) ListRole(ctx context.Context, offset int, limit int) ([]entity.Role, error) {
+	// Set default limit
+	if limit == 0 {
+		limit = 50
+	}
+
+	// List roles
+	roles, err := r.roleRepoSecondary.List(ctx, offset, limit)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to list roles from DB")
+		return nil, getReturnErr(err)
+	}
+	return roles, nil
+}
+
+func (r *RoleServiceImp) CreateRole(ctx context.Context, role *entity.Role) (*entity.Role, error) {
+	if err := r.roleRepoPrimary.Create(ctx, role); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to create role to DB")
+		return nil, getReturnErr(err)
+	}
+	return role, nil
+}
+
+func (r *RoleServiceImp) GetRole(ctx context.Context, name string) (*entity.Role, error) {
+	role, err := r.roleRepoSecondary.Get(ctx, name)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get role from DB")
+		return nil, getReturnErr(err)
+	}
+	return role, nil
+}
+
+// Update the description and the scopes of a role. Tokens issued already keep their scopes until they expire.
+func (r *RoleServiceImp) UpdateRole(ctx context.Context, role *entity.Role) error {
+	var err error
+
+	// Begin transaction
+	tx, _ := r.repoDBTx.Begin()
+	defer func() {
+		if err != nil {
+			if err = tx.Rollback(); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Rollback transaction error for updating role")
+				return
+			}
+			log.Ctx(ctx).Error().Err(err).Msg("Update role request is canceled")
+			return
+		}
+	}()
+
+	// Check role exists
+	if _, err = r.roleRepoPrimary.WithTx(tx).Get(ctx, role.Name); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get role from DB")
+		return getReturnErr(err)
+	}
+
+	// Update role
+	if err = r.roleRepoPrimary.WithTx(tx).Update(ctx, role); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to update role from DB")
+		return getReturnErr(err)
+	}
+
+	// Commit transaction
+	if err = tx.Commit(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Commit transaction error for updating role")
+		return getReturnErr(err)
+	}
+	return nil
+}
+
+// Delete a role with its permissions. A role which users have can't be deleted.
+func (r *RoleServiceImp) DeleteRole(ctx context.Context, name string) error {
+	var err error
+
+	// Begin transaction
+	tx, _ := r.repoDBTx.Begin()
+	defer func() {
+		if err != nil {
+			if err = tx.Rollback(); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Rollback transaction error for deleting role")
+				return
+			}
+			log.Ctx(ctx).Error().Err(err).Msg("Delete role request is canceled")
+			return
+		}
+	}()
+
+	// Check role exists
+	if _, err = r.roleRepoPrimary.WithTx(tx).Get(ctx, name); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get role from DB")
+		return getReturnErr(err)
+	}
+
+	// Check role isn't in use
+	count, err := r.userInfoRepoPrimary.WithTx(tx).CountByRole(ctx, entity.UserRole(name))
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to count users of role from DB")
+		return getReturnErr(err)
+	}
+	if count > 0 {
+		log.Ctx(ctx).Error().Int64("user_count", count).Msg("Role is in use")
+		err = ErrRoleInUse
+		return err
+	}
+	count, err = r.groupRepoPrimary.WithTx(tx).CountByRole(ctx, entity.UserRole(name))
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to count groups of role from DB")
+		return getReturnErr(err)
+	}
+	if count > 0 {
+		log.Ctx(ctx).Error().Int64("group_count", count).Msg("Role is in use")
+		err = ErrRoleInUse
+		return err
+	}
+
+	// Delete role
+	if err = r.roleRepoPrimary.WithTx(tx).Delete(ctx, name); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete role from DB")
+		return getReturnErr(err)
+	}
+
+	// Delete permissions of role
+	if err = r.permissionRepoPrimary.WithTx(tx).DeleteBySubject(ctx, name); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete permissions of role from DB")
+		return getReturnErr(err)
+	}
+	if err = r.permissionRepoPrimary.WithTx(tx).IncreasePolicyVersion(ctx); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to increase policy version from DB")
+		return getReturnErr(err)
+	}
+
+	// Commit transaction
+	if err = tx.Commit(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Commit transaction error for deleting role")
+		return getReturnErr(err)
+	}
+	return nil
+}
+
+// Create default roles if they don't exist. Other replicas may create them at the same time.
+func (r *RoleServiceImp) CreateDefaultRoles(ctx context.Context) error {
+	for _, role := range entity.GetDefaultRoles() {
+		role := role
+
+		_, err := r.roleRepoPrimary.Get(ctx, role.Name)
+		if err == nil {
+			continue
+		} else if err != repo.ErrNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to get default role from DB")
+			return getReturnErr(err)
+		}
+
+		if err := r.roleRepoPrimary.Create(ctx, &role); err != nil && err != repo.ErrConflict {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to create default role to DB")
+			return getReturnErr(err)
+		}
+	}
+	return nil
+}
+
+// Check the role exists in the transaction
+func checkRoleExist(ctx context.Context, roleRepo repo.RoleRepo, tx repo.DBTx, name string) error {
+	if _, err := roleRepo.WithTx(tx).Get(ctx, name); err != nil {
+		if err == repo.ErrNotFound {
+			log.Ctx(ctx).Error().Str("role", name).Msg("Role doesn't exist")
+			return ErrRoleNotExist
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get role from DB")
+		return getReturnErr(err)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/repo"
+	"github.com/ssup2ket/service-auth/internal/domain/repo/mocks"
+	"github.com/ssup2ket/service-auth/internal/test"
+)
+
+func TestRole(t *testing.T) {
+	suite.Run(t, new(roleSuite))
+}
+
+type roleSuite struct {
+	suite.Suite
+
+	dbTx           mocks.DBTx
+	roleRepo       mocks.RoleRepo
+	permissionRepo mocks.PermissionRepo
+	userInfoRepo   mocks.UserInfoRepo
+	groupRepo      mocks.GroupRepo
+
+	roleService RoleService
+}
+
+func (r *roleSuite) SetupTest() {
+	// Init transaction, repo
+	r.dbTx = mocks.DBTx{}
+	r.roleRepo = mocks.RoleRepo{}
+	r.permissionRepo = mocks.PermissionRepo{}
+	r.userInfoRepo = mocks.UserInfoRepo{}
+	r.groupRepo = mocks.GroupRepo{}
+
+	// Init service
+	r.roleService = NewRoleServiceImp(&r.dbTx, &r.roleRepo, &r.roleRepo, &r.permissionRepo, &r.userInfoRepo, &r.groupRepo)
+}
+
+func (r *roleSuite) TestListRoleSuccess() {
+	r.roleRepo.On("List", context.Background(), 0, 50).Return([]entity.Role{test.RoleCorrect}, nil)
+
+	roles, err := r.roleService.ListRole(context.Background(), 0, 0)
+	require.NoError(r.T(), err)
+	require.Equal(r.T(), test.RoleNameCorrect, roles[0].Name)
+}
+
+func (r *roleSuite) TestCreateRoleConflict() {
+	role := test.RoleCorrect
+	r.roleRepo.On("Create", context.Background(), &role).Return(repo.ErrConflict)
+
+	_, err := r.roleService.CreateRole(context.Background(), &role)
+	require.Equal(r.T(), ErrRepoConflict, err)
+}
+
+func (r *roleSuite) TestUpdateRoleSuccess() {
+	role := test.RoleCorrect
+
+	r.dbTx.On("Begin").Return(&r.dbTx, nil)
+	r.roleRepo.On("WithTx", mock.Anything).Return(&r.roleRepo)
+	r.roleRepo.On("Get", context.Background(), test.RoleNameCorrect).Return(&test.RoleCorrect, nil)
+	r.roleRepo.On("Update", context.Background(), &role).Return(nil)
+	r.dbTx.On("Commit").Return(nil)
+
+	err := r.roleService.UpdateRole(context.Background(), &role)
+	require.NoError(r.T(), err)
+}
+
+func (r *roleSuite) TestDeleteRoleSuccess() {
+	r.dbTx.On("Begin").Return(&r.dbTx, nil)
+	r.roleRepo.On("WithTx", mock.Anything).Return(&r.roleRepo)
+	r.roleRepo.On("Get", context.Background(), test.RoleNameCorrect).Return(&test.RoleCorrect, nil)
+	r.userInfoRepo.On("WithTx", mock.Anything).Return(&r.userInfoRepo)
+	r.userInfoRepo.On("CountByRole", context.Background(), entity.UserRole(test.RoleNameCorrect)).Return(int64(0), nil)
+	r.groupRepo.On("WithTx", mock.Anything).Return(&r.groupRepo)
+	r.groupRepo.On("CountByRole", context.Background(), entity.UserRole(test.RoleNameCorrect)).Return(int64(0), nil)
+	r.roleRepo.On("Delete", context.Background(), test.RoleNameCorrect).Return(nil)
+	r.permissionRepo.On("WithTx", mock.Anything).Return(&r.permissionRepo)
+	r.permissionRepo.On("DeleteBySubject", context.Background(), test.RoleNameCorrect).Return(nil)
+	r.permissionRepo.On("IncreasePolicyVersion", context.Background()).Return(nil)
+	r.dbTx.On("Commit").Return(nil)
+
+	err := r.roleService.DeleteRole(context.Background(), test.RoleNameCorrect)
+	require.NoError(r.T(), err)
+	r.permissionRepo.AssertCalled(r.T(), "IncreasePolicyVersion", context.Background())
+}
+
+func (r *roleSuite) TestDeleteRoleInUse() {
+	r.dbTx.On("Begin").Return(&r.dbTx, nil)
+	r.roleRepo.On("WithTx", mock.Anything).Return(&r.roleRepo)
+	r.roleRepo.On("Get", context.Background(), test.RoleNameCorrect).Return(&test.RoleCorrect, nil)
+	r.userInfoRepo.On("WithTx", mock.Anything).Return(&r.userInfoRepo)
+	r.userInfoRepo.On("CountByRole", context.Background(), entity.UserRole(test.RoleNameCorrect)).Return(int64(1), nil)
+	r.dbTx.On("Rollback").Return(nil)
+
+	err := r.roleService.DeleteRole(context.Background(), test.RoleNameCorrect)
+	require.Equal(r.T(), ErrRoleInUse, err)
+	r.roleRepo.AssertNotCalled(r.T(), "Delete", mock.Anything, mock.Anything)
+}
+
+func (r *roleSuite) TestDeleteRoleInUseByGroup() {
+	r.dbTx.On("Begin").Return(&r.dbTx, nil)
+	r.roleRepo.On("WithTx", mock.Anything).Return(&r.roleRepo)
+	r.roleRepo.On("Get", context.Background(), test.RoleNameCorrect).Return(&test.RoleCorrect, nil)
+	r.userInfoRepo.On("WithTx", mock.Anything).Return(&r.userInfoRepo)
+	r.userInfoRepo.On("CountByRole", context.Background(), entity.UserRole(test.RoleNameCorrect)).Return(int64(0), nil)
+	r.groupRepo.On("WithTx", mock.Anything).Return(&r.groupRepo)
+	r.groupRepo.On("CountByRole", context.Background(), entity.UserRole(test.RoleNameCorrect)).Return(int64(1), nil)
+	r.dbTx.On("Rollback").Return(nil)
+
+	err := r.roleService.DeleteRole(context.Background(), test.RoleNameCorrect)
+	require.Equal(r.T(), ErrRoleInUse, err)
+	r.roleRepo.AssertNotCalled(r.T(), "Delete", mock.Anything, mock.Anything)
+}
+
+func (r *roleSuite) TestCreateDefaultRolesSuccess() {
+	r.roleRepo.On("Get", context.Background(), string(entity.UserRoleAdmin)).Return(&test.RoleAdminCorrect, nil)
+	r.roleRepo.On("Get", context.Background(), string(entity.UserRoleTenantAdmin)).Return(nil, repo.ErrNotFound)
+	r.roleRepo.On("Get", context.Background(), string(entity.UserRoleUser)).Return(nil, repo.ErrNotFound)
+	r.roleRepo.On("Create", context.Background(), mock.Anything).Return(nil)
+
+	err := r.roleService.CreateDefaultRoles(context.Background())
+	require.NoError(r.T(), err)
+	r.roleRepo.AssertNumberOfCalls(r.T(), "Create", 2)
+}
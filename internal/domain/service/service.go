@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/ssup2ket/service-auth/internal/domain/repo"
+	"github.com/ssup2ket/service-auth/pkg/auth/passwd"
 )
 
 // Error
@@ -14,12 +15,80 @@ var (
 	// Auth
 	ErrUnauthorized error = fmt.Errorf("unauthorized")
 
+	// Token key
+	ErrTokenKeyRotationDisabled error = fmt.Errorf("token key rotation is disabled")
+	ErrTokenKeyNotFound         error = fmt.Errorf("token key not found")
+
+	// Token
+	ErrTokenAudienceNotAllowed error = fmt.Errorf("token audience isn't allowed")
+	ErrTokenScopeNotAllowed    error = fmt.Errorf("token scope isn't allowed")
+
+	// OAuth
+	ErrOAuthInvalidRequest          error = fmt.Errorf("invalid OAuth request")
+	ErrOAuthInvalidClient           error = fmt.Errorf("invalid OAuth client")
+	ErrOAuthInvalidRedirectURI      error = fmt.Errorf("invalid OAuth redirect URI")
+	ErrOAuthInvalidGrant            error = fmt.Errorf("invalid OAuth grant")
+	ErrOAuthInvalidScope            error = fmt.Errorf("invalid OAuth scope")
+	ErrOAuthUnauthorizedClient      error = fmt.Errorf("OAuth client isn't allowed for the grant")
+	ErrOAuthUnsupportedResponseType error = fmt.Errorf("unsupported OAuth response type")
+	ErrOAuthUnsupportedGrantType    error = fmt.Errorf("unsupported OAuth grant type")
+
+	// OAuth client
+	ErrOAuthClientInvalidGrantType error = fmt.Errorf("OAuth client grant type isn't allowed for the client type")
+
+	// Role
+	ErrRoleNotExist error = fmt.Errorf("role doesn't exist")
+	ErrRoleInUse    error = fmt.Errorf("role is in use by users or groups")
+
+	// Tenant
+	ErrTenantNotExist error = fmt.Errorf("tenant doesn't exist")
+	ErrTenantInUse    error = fmt.Errorf("tenant is in use by users or groups")
+
+	// Group
+	ErrGroupMemberNotExist error = fmt.Errorf("group member doesn't exist")
+	ErrGroupMemberCycle    error = fmt.Errorf("group member makes a cycle")
+
+	// Password policy
+	ErrPasswdTooShort    error = passwd.ErrTooShort
+	ErrPasswdTooLong     error = passwd.ErrTooLong
+	ErrPasswdCharClasses error = passwd.ErrCharClasses
+	ErrPasswdBreached    error = passwd.ErrBreached
+	ErrPasswdUserInput   error = passwd.ErrUserInput
+	ErrPasswdReused      error = fmt.Errorf("password is one of the last passwords")
+
+	// Password expiration
+	ErrPasswdExpired error = fmt.Errorf("password is expired")
+
+	// Login lock
+	ErrLoginLocked error = fmt.Errorf("login is locked by failed logins")
+
+	// MFA
+	ErrMFADisabled       error = fmt.Errorf("MFA is disabled")
+	ErrMFARequired       error = fmt.Errorf("MFA code is required")
+	ErrMFACodeWrong      error = fmt.Errorf("MFA code is wrong")
+	ErrMFAAlreadyEnabled error = fmt.Errorf("MFA is already enabled")
+	ErrMFANotEnabled     error = fmt.Errorf("MFA isn't enabled")
+
+	// Passkey
+	ErrPasskeyDisabled           error = fmt.Errorf("passkey is disabled")
+	ErrPasskeyVerificationFailed error = fmt.Errorf("passkey verification failed")
+	ErrPasskeyRequired           error = fmt.Errorf("passkey is required for a user without password")
+
+	// Email verification
+	ErrEmailNotVerified     error = fmt.Errorf("email isn't verified")
+	ErrEmailAlreadyVerified error = fmt.Errorf("email is already verified")
+
 	// Repository
 	ErrRepoNotFound    error = fmt.Errorf("repo resource not found")
 	ErrRepoConflict    error = fmt.Errorf("repo conflict")
 	ErrRepoServerError error = fmt.Errorf("repo server error")
 )
 
+// Check whether the error is a violation of the password policy or the password history
+func IsPasswdPolicyErr(err error) bool {
+	return passwd.IsPolicyErr(err) || err == ErrPasswdReused
+}
+
 func getReturnErr(err error) error {
 	switch err {
 	case repo.ErrNotFound:
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/repo"
+	"github.com/ssup2ket/service-auth/pkg/auth/token"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+const (
+	AggregateTypeSession    = "Session"
+	EventTypeSessionRevoked = "SessionRevoked"
+)
+
+type sessionOutboxPayload struct {
+	UserID     string   `json:"userId"`
+	SessionIDs []string `json:"sessionIds"`
+}
+
+// Session service
+type SessionService interface {
+	ListSession(ctx context.Context, userUUID uuid.EntityUUID) ([]entity.Session, error)
+	RevokeSession(ctx context.Context, userUUID, sessionUUID uuid.EntityUUID) error
+	RevokeUserSessions(ctx context.Context, userUUID uuid.EntityUUID) error
+}
+
+type SessionServiceImp struct {
+	repoDBTx repo.DBTx
+
+	outboxRepoPrimary    repo.OutboxRepo
+	sessionRepoPrimary   repo.SessionRepo
+	sessionRepoSecondary repo.SessionRepo
+
+	tokenRevocationRepoPrimary repo.TokenRevocationRepo
+	revocationList             *token.RevocationList
+}
+
+func NewSessionServiceImp(dbTx repo.DBTx, outboxPrimary repo.OutboxRepo, sessionPrimary, sessionSecondary repo.SessionRepo,
+	tokenRevocationPrimary repo.TokenRevocationRepo, revocationList *token.RevocationList) *SessionServiceImp {
+	return &SessionServiceImp{
+		repoDBTx: dbTx,
+
+		outboxRepoPrimary:    outboxPrimary,
+		sessionRepoPrimary:   sessionPrimary,
+		sessionRepoSecondary: sessionSecondary,
+
+		tokenRevocationRepoPrimary: tokenRevocationPrimary,
+		revocationList:             revocationList,
+	}
+}
+
+// List active sessions of the user
+func (s *SessionServiceImp) ListSession(ctx context.Context, userUUID uuid.EntityUUID) ([]entity.Session, error) {
+	sessions, err := s.sessionRepoSecondary.ListByUserID(ctx, userUUID, time.Now())
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to list session from DB")
+		return nil, getReturnErr(err)
+	}
+	return sessions, nil
+}
+
+// Revoke a session of the user. The refresh token and access tokens of the session aren't valid anymore.
+func (s *SessionServiceImp) RevokeSession(ctx context.Context, userUUID, sessionUUID uuid.EntityUUID) error {
+	var err error
+
+	// Begin transaction
+	tx, _ := s.repoDBTx.Begin()
+	defer func() {
+		if err != nil {
+			if err = tx.Rollback(); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Rollback transaction error for revoking session")
+				return
+			}
+			log.Ctx(ctx).Error().Err(err).Msg("Revoke session request is canceled")
+			return
+		}
+	}()
+
+	// Get session and check owner
+	session, err := s.sessionRepoPrimary.WithTx(tx).GetForUpdate(ctx, sessionUUID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get session from DB")
+		return getReturnErr(err)
+	}
+	if session.UserID != userUUID {
+		log.Ctx(ctx).Error().Msg("Session isn't owned by the user")
+		err = ErrRepoNotFound
+		return err
+	}
+
+	// Delete session
+	if err = s.sessionRepoPrimary.WithTx(tx).Delete(ctx, sessionUUID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete session from DB")
+		return getReturnErr(err)
+	}
+
+	// Revoke access tokens of the session
+	tokenRevocation, err := createTokenRevocation(ctx, s.tokenRevocationRepoPrimary, tx, entity.TokenRevocationTypeSession, sessionUUID.String())
+	if err != nil {
+		return getReturnErr(err)
+	}
+
+	// Publish a session revoked event
+	if err = createOutbox(ctx, s.outboxRepoPrimary, tx, "RevokeSession", AggregateTypeSession, userUUID.String(),
+		EventTypeSessionRevoked, sessionOutboxPayload{UserID: userUUID.String(), SessionIDs: []string{sessionUUID.String()}}); err != nil {
+		return getReturnErr(err)
+	}
+
+	// Commit transaction
+	if err = tx.Commit(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Commit transaction error for revoking session")
+		return getReturnErr(err)
+	}
+	addTokenRevocationToList(s.revocationList, tokenRevocation)
+	return nil
+}
+
+// Revoke all sessions of the user to logout everywhere
+func (s *SessionServiceImp) RevokeUserSessions(ctx context.Context, userUUID uuid.EntityUUID) error {
+	var err error
+
+	// Begin transaction
+	tx, _ := s.repoDBTx.Begin()
+	defer func() {
+		if err != nil {
+			if err = tx.Rollback(); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Rollback transaction error for revoking user sessions")
+				return
+			}
+			log.Ctx(ctx).Error().Err(err).Msg("Revoke user sessions request is canceled")
+			return
+		}
+	}()
+
+	// List sessions to publish revoked session IDs
+	sessions, err := s.sessionRepoPrimary.WithTx(tx).ListByUserID(ctx, userUUID, time.Time{})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to list session from DB")
+		return getReturnErr(err)
+	}
+	sessionIDs := []string{}
+	for _, session := range sessions {
+		sessionIDs = append(sessionIDs, session.ID.String())
+	}
+
+	// Delete sessions
+	if err = s.sessionRepoPrimary.WithTx(tx).DeleteByUserID(ctx, userUUID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete sessions from DB")
+		return getReturnErr(err)
+	}
+
+	// Revoke all access tokens of the user
+	tokenRevocation, err := createTokenRevocation(ctx, s.tokenRevocationRepoPrimary, tx, entity.TokenRevocationTypeUser, userUUID.String())
+	if err != nil {
+		return getReturnErr(err)
+	}
+
+	// Publish a session revoked event
+	if err = createOutbox(ctx, s.outboxRepoPrimary, tx, "RevokeUserSessions", AggregateTypeSession, userUUID.String(),
+		EventTypeSessionRevoked, sessionOutboxPayload{UserID: userUUID.String(), SessionIDs: sessionIDs}); err != nil {
+		return getReturnErr(err)
+	}
+
+	// Commit transaction
+	if err = tx.Commit(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Commit transaction error for revoking user sessions")
+		return getReturnErr(err)
+	}
+	addTokenRevocationToList(s.revocationList, tokenRevocation)
+	return nil
+}
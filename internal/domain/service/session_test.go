@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/repo"
+	"github.com/ssup2ket/service-auth/internal/domain/repo/mocks"
+	"github.com/ssup2ket/service-auth/internal/test"
+	"github.com/ssup2ket/service-auth/pkg/auth/token"
+)
+
+func TestSession(t *testing.T) {
+	suite.Run(t, new(sessionSuite))
+}
+
+type sessionSuite struct {
+	suite.Suite
+
+	dbTx        mocks.DBTx
+	outboxRepo  mocks.OutboxRepo
+	sessionRepo mocks.SessionRepo
+
+	tokenRevocationRepo mocks.TokenRevocationRepo
+	revocationList      *token.RevocationList
+
+	sessionService SessionService
+}
+
+func (s *sessionSuite) SetupTest() {
+	// Init transaction, repo
+	s.dbTx = mocks.DBTx{}
+	s.outboxRepo = mocks.OutboxRepo{}
+	s.sessionRepo = mocks.SessionRepo{}
+	s.tokenRevocationRepo = mocks.TokenRevocationRepo{}
+	s.revocationList = token.NewRevocationList()
+
+	// Set nooptracer
+	opentracing.SetGlobalTracer(opentracing.NoopTracer{})
+
+	// Init service
+	s.sessionService = NewSessionServiceImp(&s.dbTx, &s.outboxRepo, &s.sessionRepo, &s.sessionRepo,
+		&s.tokenRevocationRepo, s.revocationList)
+}
+
+func (s *sessionSuite) TestRevokeSessionSuccess() {
+	s.dbTx.On("Begin").Return(&s.dbTx, nil)
+	s.sessionRepo.On("WithTx", mock.Anything).Return(&s.sessionRepo)
+	s.sessionRepo.On("GetForUpdate", context.Background(), test.SessionIDCorrect).
+		Return(&entity.Session{ID: test.SessionIDCorrect, UserID: test.UserIDCorrect}, nil)
+	s.sessionRepo.On("Delete", context.Background(), test.SessionIDCorrect).Return(nil)
+	s.tokenRevocationRepo.On("WithTx", mock.Anything).Return(&s.tokenRevocationRepo)
+	s.tokenRevocationRepo.On("Create", context.Background(), mock.Anything).Return(nil)
+	s.outboxRepo.On("WithTx", mock.Anything).Return(&s.outboxRepo)
+	s.outboxRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	s.dbTx.On("Commit").Return(nil)
+
+	issuedAt := time.Now().Add(-time.Minute)
+	err := s.sessionService.RevokeSession(context.Background(), test.UserIDCorrect, test.SessionIDCorrect)
+	require.NoError(s.T(), err)
+	s.sessionRepo.AssertCalled(s.T(), "Delete", context.Background(), test.SessionIDCorrect)
+	require.True(s.T(), s.revocationList.IsRevoked(&token.TokenClaims{
+		StandardClaims: jwt.StandardClaims{IssuedAt: issuedAt.Unix()},
+		AuthClaims:     token.AuthClaims{UserID: test.UserIDCorrect.String(), SessionID: test.SessionIDCorrect.String()},
+	}))
+}
+
+func (s *sessionSuite) TestRevokeSessionOtherUser() {
+	s.dbTx.On("Begin").Return(&s.dbTx, nil)
+	s.sessionRepo.On("WithTx", mock.Anything).Return(&s.sessionRepo)
+	s.sessionRepo.On("GetForUpdate", context.Background(), test.SessionIDCorrect).
+		Return(&entity.Session{ID: test.SessionIDCorrect, UserID: test.UserIDCorrect2}, nil)
+	s.dbTx.On("Rollback").Return(nil)
+
+	err := s.sessionService.RevokeSession(context.Background(), test.UserIDCorrect, test.SessionIDCorrect)
+	require.Equal(s.T(), ErrRepoNotFound, err)
+	s.sessionRepo.AssertNotCalled(s.T(), "Delete", mock.Anything, mock.Anything)
+}
+
+func (s *sessionSuite) TestRevokeSessionNotFound() {
+	s.dbTx.On("Begin").Return(&s.dbTx, nil)
+	s.sessionRepo.On("WithTx", mock.Anything).Return(&s.sessionRepo)
+	s.sessionRepo.On("GetForUpdate", context.Background(), test.SessionIDCorrect).Return(nil, repo.ErrNotFound)
+	s.dbTx.On("Rollback").Return(nil)
+
+	err := s.sessionService.RevokeSession(context.Background(), test.UserIDCorrect, test.SessionIDCorrect)
+	require.Equal(s.T(), ErrRepoNotFound, err)
+}
+
+func (s *sessionSuite) TestRevokeUserSessionsSuccess() {
+	s.dbTx.On("Begin").Return(&s.dbTx, nil)
+	s.sessionRepo.On("WithTx", mock.Anything).Return(&s.sessionRepo)
+	s.sessionRepo.On("ListByUserID", context.Background(), test.UserIDCorrect, mock.Anything).
+		Return([]entity.Session{{ID: test.SessionIDCorrect, UserID: test.UserIDCorrect}}, nil)
+	s.sessionRepo.On("DeleteByUserID", context.Background(), test.UserIDCorrect).Return(nil)
+	s.tokenRevocationRepo.On("WithTx", mock.Anything).Return(&s.tokenRevocationRepo)
+	s.tokenRevocationRepo.On("Create", context.Background(), mock.Anything).Return(nil)
+	s.outboxRepo.On("WithTx", mock.Anything).Return(&s.outboxRepo)
+	s.outboxRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	s.dbTx.On("Commit").Return(nil)
+
+	err := s.sessionService.RevokeUserSessions(context.Background(), test.UserIDCorrect)
+	require.NoError(s.T(), err)
+	s.sessionRepo.AssertCalled(s.T(), "DeleteByUserID", context.Background(), test.UserIDCorrect)
+	s.tokenRevocationRepo.AssertCalled(s.T(), "Create", context.Background(), mock.Anything)
+}
+
+func (s *sessionSuite) TestRevokeUserSessionsError() {
+	s.dbTx.On("Begin").Return(&s.dbTx, nil)
+	s.sessionRepo.On("WithTx", mock.Anything).Return(&s.sessionRepo)
+	s.sessionRepo.On("ListByUserID", context.Background(), test.UserIDCorrect, mock.Anything).
+		Return([]entity.Session{}, nil)
+	s.sessionRepo.On("DeleteByUserID", context.Background(), test.UserIDCorrect).Return(repo.ErrServerError)
+	s.dbTx.On("Rollback").Return(nil)
+
+	err := s.sessionService.RevokeUserSessions(context.Background(), test.UserIDCorrect)
+	require.Equal(s.T(), ErrRepoServerError, err)
+}
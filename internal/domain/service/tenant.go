@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/repo"
+)
+
+// Tenant service
+type TenantService interface {
+	ListTenant(ctx context.Context, offset int, limit int) ([]entity.Tenant, error)
+	CreateTenant(ctx context.Context, tenant *entity.Tenant) (*entity.Tenant, error)
+	GetTenant(ctx context.Context, tenantID string) (*entity.Tenant, error)
+	UpdateTenant(ctx context.Context, tenant *entity.Tenant) error
+	DeleteTenant(ctx context.Context, tenantID string) error
+
+	CreateDefaultTenant(ctx context.Context) error
+}
+
+type TenantServiceImp struct {
+	repoDBTx repo.DBTx
+
+	tenantRepoPrimary   repo.TenantRepo
+	tenantRepoSecondary repo.TenantRepo
+	userInfoRepoPrimary repo.UserInfoRepo
+	groupRepoPrimary    repo.GroupRepo
+}
+
+func NewTenantServiceImp(dbTx repo.DBTx, tenantPrimary, tenantSecondary repo.TenantRepo, userInfoPrimary repo.UserInfoRepo,
+	groupPrimary repo.GroupRepo) *TenantServiceImp {
+	return &TenantServiceImp{
+		repoDBTx: dbTx,
+
+		tenantRepoPrimary:   tenantPrimary,
+		tenantRepoSecondary: tenantSecondary,
+		userInfoRepoPrimary: userInfoPrimary,
+		groupRepoPrimary:    groupPrimary,
+	}
+}
+
+func (t *TenantServiceImp) ListTenant(ctx context.Context, offset int, limit int) ([]entity.Tenant, error) {
+	// Set default limit
+	if limit == 0 {
+		limit = 50
+	}
+
+	// List tenants
+	tenants, err := t.tenantRepoSecondary.List(ctx, offset, limit)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to list tenants from DB")
+		return nil, getReturnErr(err)
+	}
+	return tenants, nil
+}
+
+func (t *TenantServiceImp) CreateTenant(ctx context.Context, tenant *entity.Tenant) (*entity.Tenant, error) {
+	if err := t.tenantRepoPrimary.Create(ctx, tenant); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to create tenant to DB")
+		return nil, getReturnErr(err)
+	}
+	return tenant, nil
+}
+
+func (t *TenantServiceImp) GetTenant(ctx context.Context, tenantID string) (*entity.Tenant, error) {
+	tenant, err := t.tenantRepoSecondary.Get(ctx, tenantID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get tenant from DB")
+		return nil, getReturnErr(err)
+	}
+	return tenant, nil
+}
+
+func (t *TenantServiceImp) UpdateTenant(ctx context.Context, tenant *entity.Tenant) error {
+	var err error
+
+	// Begin transaction
+	tx, _ := t.repoDBTx.Begin()
+	defer func() {
+		if err != nil {
+			if err = tx.Rollback(); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Rollback transaction error for updating tenant")
+				return
+			}
+			log.Ctx(ctx).Error().Err(err).Msg("Update tenant request is canceled")
+			return
+		}
+	}()
+
+	// Check tenant exists
+	if _, err = t.tenantRepoPrimary.WithTx(tx).Get(ctx, tenant.ID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get tenant from DB")
+		return getReturnErr(err)
+	}
+
+	// Update tenant
+	if err = t.tenantRepoPrimary.WithTx(tx).Update(ctx, tenant); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to update tenant from DB")
+		return getReturnErr(err)
+	}
+
+	// Commit transaction
+	if err = tx.Commit(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Commit transaction error for updating tenant")
+		return getReturnErr(err)
+	}
+	return nil
+}
+
+// Delete a tenant. A tenant which has users or groups can't be deleted, and the default tenant can't be deleted.
+func (t *TenantServiceImp) DeleteTenant(ctx context.Context, tenantID string) error {
+	var err error
+
+	// Check default tenant
+	if tenantID == entity.TenantIDDefault {
+		log.Ctx(ctx).Error().Msg("Default tenant can't be deleted")
+		return ErrTenantInUse
+	}
+
+	// Begin transaction
+	tx, _ := t.repoDBTx.Begin()
+	defer func() {
+		if err != nil {
+			if err = tx.Rollback(); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Rollback transaction error for deleting tenant")
+				return
+			}
+			log.Ctx(ctx).Error().Err(err).Msg("Delete tenant request is canceled")
+			return
+		}
+	}()
+
+	// Check tenant exists
+	if _, err = t.tenantRepoPrimary.WithTx(tx).Get(ctx, tenantID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get tenant from DB")
+		return getReturnErr(err)
+	}
+
+	// Check tenant doesn't have users
+	count, err := t.userInfoRepoPrimary.WithTx(tx).CountByTenant(ctx, tenantID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to count users of tenant from DB")
+		return getReturnErr(err)
+	}
+	if count > 0 {
+		log.Ctx(ctx).Error().Int64("user_count", count).Msg("Tenant is in use")
+		err = ErrTenantInUse
+		return err
+	}
+
+	// Check tenant doesn't have groups
+	count, err = t.groupRepoPrimary.WithTx(tx).CountByTenant(ctx, tenantID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to count groups of tenant from DB")
+		return getReturnErr(err)
+	}
+	if count > 0 {
+		log.Ctx(ctx).Error().Int64("group_count", count).Msg("Tenant is in use")
+		err = ErrTenantInUse
+		return err
+	}
+
+	// Delete tenant
+	if err = t.tenantRepoPrimary.WithTx(tx).Delete(ctx, tenantID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete tenant from DB")
+		return getReturnErr(err)
+	}
+
+	// Commit transaction
+	if err = tx.Commit(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Commit transaction error for deleting tenant")
+		return getReturnErr(err)
+	}
+	return nil
+}
+
+// Create the default tenant if it doesn't exist. Other replicas may create it at the same time.
+func (t *TenantServiceImp) CreateDefaultTenant(ctx context.Context) error {
+	tenant := entity.GetDefaultTenant()
+
+	_, err := t.tenantRepoPrimary.Get(ctx, tenant.ID)
+	if err == nil {
+		return nil
+	} else if err != repo.ErrNotFound {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get default tenant from DB")
+		return getReturnErr(err)
+	}
+
+	if err := t.tenantRepoPrimary.Create(ctx, &tenant); err != nil && err != repo.ErrConflict {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to create default tenant to DB")
+		return getReturnErr(err)
+	}
+	return nil
+}
+
+// Check the tenant exists in the transaction
+func checkTenantExist(ctx context.Context, tenantRepo repo.TenantRepo, tx repo.DBTx, tenantID string) error {
+	if _, err := tenantRepo.WithTx(tx).Get(ctx, tenantID); err != nil {
+		if err == repo.ErrNotFound {
+			log.Ctx(ctx).Error().Str("tenant", tenantID).Msg("Tenant doesn't exist")
+			return ErrTenantNotExist
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get tenant from DB")
+		return getReturnErr(err)
+	}
+	return nil
+}
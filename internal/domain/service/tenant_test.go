@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/repo"
+	"github.com/ssup2ket/service-auth/internal/domain/repo/mocks"
+	"github.com/ssup2ket/service-auth/internal/test"
+)
+
+func TestTenant(t *testing.T) {
+	suite.Run(t, new(tenantSuite))
+}
+
+type tenantSuite struct {
+	suite.Suite
+
+	dbTx         mocks.DBTx
+	tenantRepo   mocks.TenantRepo
+	userInfoRepo mocks.UserInfoRepo
+	groupRepo    mocks.GroupRepo
+
+	tenantService TenantService
+}
+
+func (t *tenantSuite) SetupTest() {
+	// Init transaction, repo
+	t.dbTx = mocks.DBTx{}
+	t.tenantRepo = mocks.TenantRepo{}
+	t.userInfoRepo = mocks.UserInfoRepo{}
+	t.groupRepo = mocks.GroupRepo{}
+
+	// Init service
+	t.tenantService = NewTenantServiceImp(&t.dbTx, &t.tenantRepo, &t.tenantRepo, &t.userInfoRepo, &t.groupRepo)
+}
+
+func (t *tenantSuite) TestListTenantSuccess() {
+	t.tenantRepo.On("List", context.Background(), 0, 50).Return([]entity.Tenant{test.TenantCorrect}, nil)
+
+	tenants, err := t.tenantService.ListTenant(context.Background(), 0, 0)
+	require.NoError(t.T(), err)
+	require.Equal(t.T(), test.TenantIDCorrect, tenants[0].ID)
+}
+
+func (t *tenantSuite) TestCreateTenantConflict() {
+	tenant := test.TenantCorrect
+	t.tenantRepo.On("Create", context.Background(), &tenant).Return(repo.ErrConflict)
+
+	_, err := t.tenantService.CreateTenant(context.Background(), &tenant)
+	require.Equal(t.T(), ErrRepoConflict, err)
+}
+
+func (t *tenantSuite) TestUpdateTenantNotFound() {
+	tenant := test.TenantCorrect
+
+	t.dbTx.On("Begin").Return(&t.dbTx, nil)
+	t.tenantRepo.On("WithTx", mock.Anything).Return(&t.tenantRepo)
+	t.tenantRepo.On("Get", context.Background(), test.TenantIDCorrect).Return(nil, repo.ErrNotFound)
+	t.dbTx.On("Rollback").Return(nil)
+
+	err := t.tenantService.UpdateTenant(context.Background(), &tenant)
+	require.Equal(t.T(), ErrRepoNotFound, err)
+	t.tenantRepo.AssertNotCalled(t.T(), "Update", mock.Anything, mock.Anything)
+}
+
+func (t *tenantSuite) TestDeleteTenantSuccess() {
+	t.dbTx.On("Begin").Return(&t.dbTx, nil)
+	t.tenantRepo.On("WithTx", mock.Anything).Return(&t.tenantRepo)
+	t.tenantRepo.On("Get", context.Background(), test.TenantIDCorrect).Return(&test.TenantCorrect, nil)
+	t.userInfoRepo.On("WithTx", mock.Anything).Return(&t.userInfoRepo)
+	t.userInfoRepo.On("CountByTenant", context.Background(), test.TenantIDCorrect).Return(int64(0), nil)
+	t.groupRepo.On("WithTx", mock.Anything).Return(&t.groupRepo)
+	t.groupRepo.On("CountByTenant", context.Background(), test.TenantIDCorrect).Return(int64(0), nil)
+	t.tenantRepo.On("Delete", context.Background(), test.TenantIDCorrect).Return(nil)
+	t.dbTx.On("Commit").Return(nil)
+
+	err := t.tenantService.DeleteTenant(context.Background(), test.TenantIDCorrect)
+	require.NoError(t.T(), err)
+	t.tenantRepo.AssertCalled(t.T(), "Delete", context.Background(), test.TenantIDCorrect)
+}
+
+func (t *tenantSuite) TestDeleteTenantInUse() {
+	t.dbTx.On("Begin").Return(&t.dbTx, nil)
+	t.tenantRepo.On("WithTx", mock.Anything).Return(&t.tenantRepo)
+	t.tenantRepo.On("Get", context.Background(), test.TenantIDCorrect).Return(&test.TenantCorrect, nil)
+	t.userInfoRepo.On("WithTx", mock.Anything).Return(&t.userInfoRepo)
+	t.userInfoRepo.On("CountByTenant", context.Background(), test.TenantIDCorrect).Return(int64(1), nil)
+	t.dbTx.On("Rollback").Return(nil)
+
+	err := t.tenantService.DeleteTenant(context.Background(), test.TenantIDCorrect)
+	require.Equal(t.T(), ErrTenantInUse, err)
+	t.tenantRepo.AssertNotCalled(t.T(), "Delete", mock.Anything, mock.Anything)
+}
+
+func (t *tenantSuite) TestDeleteDefaultTenantInUse() {
+	err := t.tenantService.DeleteTenant(context.Background(), entity.TenantIDDefault)
+	require.Equal(t.T(), ErrTenantInUse, err)
+	t.dbTx.AssertNotCalled(t.T(), "Begin")
+}
+
+func (t *tenantSuite) TestCreateDefaultTenantSuccess() {
+	t.tenantRepo.On("Get", context.Background(), entity.TenantIDDefault).Return(nil, repo.ErrNotFound)
+	t.tenantRepo.On("Create", context.Background(), mock.Anything).Return(nil)
+
+	err := t.tenantService.CreateDefaultTenant(context.Background())
+	require.NoError(t.T(), err)
+	t.tenantRepo.AssertNumberOfCalls(t.T(), "Create", 1)
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/rs/zerolog/log"
 
@@ -9,36 +10,371 @@ import (
 	"github.com/ssup2ket/service-auth/internal/domain/repo"
 	"github.com/ssup2ket/service-auth/pkg/auth/hashing"
 	"github.com/ssup2ket/service-auth/pkg/auth/token"
+	"github.com/ssup2ket/service-auth/pkg/auth/webauthn"
 	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
 )
 
+const (
+	sessionDeviceNameMaxLen = 100
+	sessionUserAgentMaxLen  = 255
+)
+
 // Token service
 type TokenService interface {
-	CreateTokens(ctx context.Context, loginID, passwd string) (*token.TokenInfo, *token.TokenInfo, error)
-	RefreshToken(ctx context.Context, refreshToken string) (*token.TokenInfo, error)
+	// ErrPasswdExpired is returned with a limited access token which can only change the password.
+	// ErrEmailNotVerified is returned with a limited access token which can only verify the email if verification is required.
+	// ErrLoginLocked is returned if the login ID or the session's IP is locked by failed logins.
+	// ErrMFARequired is returned with a MFA challenge token if the user enabled TOTP.
+	CreateTokens(ctx context.Context, tenantID, loginID, passwd string, session *entity.Session, audience string) (*token.TokenInfo, *token.TokenInfo, error)
+	CreateMFATokens(ctx context.Context, mfaToken, code string, session *entity.Session) (*token.TokenInfo, *token.TokenInfo, error)
+	// Passkey login doesn't need a password or MFA, because passkeys verify users.
+	BeginPasskeyLogin(ctx context.Context, tenantID string) (*entity.PasskeyLogin, error)
+	CreatePasskeyTokens(ctx context.Context, tenantID string, challengeUUID uuid.EntityUUID, assertion *entity.PasskeyAssertion,
+		session *entity.Session, audience string) (*token.TokenInfo, *token.TokenInfo, error)
+	CreateUserTokens(ctx context.Context, userInfo *entity.UserInfo, session *entity.Session, audience string) (*token.TokenInfo, *token.TokenInfo, error)
+	CreateUserAccessToken(ctx context.Context, userInfo *entity.UserInfo, clientID string, scopes []string) (*token.TokenInfo, error)
+	RefreshToken(ctx context.Context, refreshToken string) (*token.TokenInfo, *token.TokenInfo, error)
+	RefreshClientToken(ctx context.Context, refreshToken, clientID string) (*token.TokenInfo, *token.TokenInfo, error)
 }
 
 type TokenServiceImp struct {
 	repoDBTx repo.DBTx
 
-	userInfoRepoSecondary   repo.UserInfoRepo
-	userSecretRepoPrimary   repo.UserSecretRepo
-	userSecretRepoSecondary repo.UserSecretRepo
+	userInfoRepoSecondary    repo.UserInfoRepo
+	userSecretRepoSecondary  repo.UserSecretRepo
+	roleRepoSecondary        repo.RoleRepo
+	groupRepoSecondary       repo.GroupRepo
+	groupMemberRepoSecondary repo.GroupMemberRepo
+	userSecretRepoPrimary    repo.UserSecretRepo
+	sessionRepoPrimary       repo.SessionRepo
+
+	tokenRevocationRepoPrimary repo.TokenRevocationRepo
+	revocationList             *token.RevocationList
+
+	loginLockRepo   repo.LoginLockRepo
+	loginLockPolicy *LoginLockPolicy
+
+	mfaRecoveryCodeRepoPrimary repo.MFARecoveryCodeRepo
+	mfaSecret                  []byte
+
+	webAuthnCredentialRepoPrimary repo.WebAuthnCredentialRepo
+	webAuthnChallengeRepoPrimary  repo.WebAuthnChallengeRepo
+	relyingParty                  *webauthn.RelyingParty
+
+	emailVerificationPolicy *EmailVerificationPolicy
+}
+
+// Repos of the token service
+type TokenRepos struct {
+	UserInfoSecondary    repo.UserInfoRepo
+	UserSecretSecondary  repo.UserSecretRepo
+	RoleSecondary        repo.RoleRepo
+	GroupSecondary       repo.GroupRepo
+	GroupMemberSecondary repo.GroupMemberRepo
+	UserSecretPrimary    repo.UserSecretRepo
+	SessionPrimary       repo.SessionRepo
+
+	TokenRevocationPrimary repo.TokenRevocationRepo
+	LoginLock              repo.LoginLockRepo
+	MFARecoveryCodePrimary repo.MFARecoveryCodeRepo
+
+	WebAuthnCredentialPrimary repo.WebAuthnCredentialRepo
+	WebAuthnChallengePrimary  repo.WebAuthnChallengeRepo
+}
+
+// Policies of the token service
+type TokenPolicies struct {
+	LoginLock         *LoginLockPolicy
+	EmailVerification *EmailVerificationPolicy
 }
 
-func NewTokenServiceImp(dbTx repo.DBTx, userInfoSecondary repo.UserInfoRepo, userSecretPrimary, userSecretSecondary repo.UserSecretRepo) *TokenServiceImp {
+func NewTokenServiceImp(dbTx repo.DBTx, repos TokenRepos, revocationList *token.RevocationList, policies TokenPolicies,
+	mfaSecret []byte, relyingParty *webauthn.RelyingParty) *TokenServiceImp {
 	return &TokenServiceImp{
 		repoDBTx: dbTx,
 
-		userInfoRepoSecondary:   userInfoSecondary,
-		userSecretRepoPrimary:   userSecretPrimary,
-		userSecretRepoSecondary: userSecretSecondary,
+		userInfoRepoSecondary:    repos.UserInfoSecondary,
+		userSecretRepoSecondary:  repos.UserSecretSecondary,
+		roleRepoSecondary:        repos.RoleSecondary,
+		groupRepoSecondary:       repos.GroupSecondary,
+		groupMemberRepoSecondary: repos.GroupMemberSecondary,
+		userSecretRepoPrimary:    repos.UserSecretPrimary,
+		sessionRepoPrimary:       repos.SessionPrimary,
+
+		tokenRevocationRepoPrimary: repos.TokenRevocationPrimary,
+		revocationList:             revocationList,
+
+		loginLockRepo:   repos.LoginLock,
+		loginLockPolicy: policies.LoginLock,
+
+		mfaRecoveryCodeRepoPrimary: repos.MFARecoveryCodePrimary,
+		mfaSecret:                  mfaSecret,
+
+		webAuthnCredentialRepoPrimary: repos.WebAuthnCredentialPrimary,
+		webAuthnChallengeRepoPrimary:  repos.WebAuthnChallengePrimary,
+		relyingParty:                  relyingParty,
+
+		emailVerificationPolicy: policies.EmailVerification,
+	}
+}
+
+// Login to the tenant and create a new session. Session has device name, user agent and IP of the client.
+// Tokens are created for the audience, and empty audience means the default audience.
+// Tokens are limited to the session's scopes, which must be allowed for one of the user's effective roles.
+func (t *TokenServiceImp) CreateTokens(ctx context.Context, tenantID, loginID, passwd string, session *entity.Session,
+	audience string) (*token.TokenInfo, *token.TokenInfo, error) {
+	// Check audience
+	if !token.IsAudienceAllowed(audience) {
+		log.Ctx(ctx).Error().Str("audience", audience).Msg("Token audience isn't allowed")
+		return nil, nil, ErrTokenAudienceNotAllowed
+	}
+
+	// Authenticate user
+	userInfo, userSecret, err := t.authenticateUser(ctx, tenantID, loginID, passwd, session.IP)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Check MFA. A MFA challenge token is issued instead of tokens, and it's exchanged for tokens with a MFA code.
+	if userSecret.IsTOTPEnabled() {
+		log.Ctx(ctx).Info().Str("user_id", userInfo.ID.String()).Msg("MFA is required")
+		mfaTokenInfo, err := createMFAChallengeToken(userInfo, session, audience)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to create MFA challenge token")
+			return nil, nil, getReturnErr(err)
+		}
+		return mfaTokenInfo, nil, ErrMFARequired
+	}
+
+	return t.createLoginTokens(ctx, userInfo, userSecret, session, audience)
+}
+
+// Exchange a MFA challenge token and a TOTP code or a recovery code for tokens. Session has the device name, user agent
+// and IP of the client, and scopes and audience are taken from the login. Wrong codes increase failed logins of the login ID
+// and the client IP, so codes can't be guessed before the challenge token expires.
+func (t *TokenServiceImp) CreateMFATokens(ctx context.Context, mfaToken, code string, session *entity.Session) (*token.TokenInfo, *token.TokenInfo, error) {
+	// Validate MFA challenge token
+	authInfo, err := token.ValidateMFAChallengeToken(mfaToken)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("MFA challenge token isn't valid")
+		return nil, nil, ErrUnauthorized
+	}
+	tenantID := entity.GetTenantIDOrDefault(authInfo.TenantID)
+
+	// Check login locks
+	if err := checkLoginLocks(ctx, t.loginLockRepo, t.loginLockPolicy, tenantID, authInfo.UserLoginID, session.IP); err != nil {
+		return nil, nil, err
+	}
+
+	// Get user info and user secret. MFA could be disabled after the login.
+	userInfo, err := t.userInfoRepoSecondary.Get(ctx, tenantID, uuid.FromStringOrNil(authInfo.UserID))
+	if err == repo.ErrNotFound {
+		log.Ctx(ctx).Error().Msg("User of MFA challenge token doesn't exist")
+		return nil, nil, ErrUnauthorized
+	} else if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get user info")
+		return nil, nil, getReturnErr(err)
+	}
+	userSecret, err := t.userSecretRepoPrimary.Get(ctx, userInfo.ID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get user secret")
+		return nil, nil, getReturnErr(err)
+	}
+	if !userSecret.IsTOTPEnabled() {
+		log.Ctx(ctx).Error().Msg("TOTP isn't enabled")
+		return nil, nil, ErrUnauthorized
+	}
+
+	// Verify MFA code
+	err = verifyMFACode(ctx, t.userSecretRepoPrimary, t.mfaRecoveryCodeRepoPrimary, t.mfaSecret, userSecret, code)
+	if err == ErrMFACodeWrong {
+		addLoginFailures(ctx, t.loginLockRepo, t.loginLockPolicy, tenantID, userInfo.LoginID, session.IP)
+		return nil, nil, err
+	} else if err != nil {
+		return nil, nil, err
+	}
+	resetLoginFailures(ctx, t.loginLockRepo, t.loginLockPolicy, tenantID, userInfo.LoginID)
+
+	session.Scopes = authInfo.Scopes
+	return t.createLoginTokens(ctx, userInfo, userSecret, session, authInfo.Audience)
+}
+
+// Begin a passkey login of the tenant. Any discoverable passkey of the relying party can be used,
+// and the user is found by the passkey.
+func (t *TokenServiceImp) BeginPasskeyLogin(ctx context.Context, tenantID string) (*entity.PasskeyLogin, error) {
+	if t.relyingParty == nil {
+		log.Ctx(ctx).Error().Msg("WebAuthn relying party isn't configured")
+		return nil, ErrPasskeyDisabled
+	}
+
+	challenge, err := createWebAuthnChallenge(ctx, t.webAuthnChallengeRepoPrimary, entity.WebAuthnChallengeTypeLogin, tenantID,
+		uuid.EntityUUID{})
+	if err != nil {
+		return nil, err
+	}
+	return &entity.PasskeyLogin{
+		ChallengeID: challenge.ID,
+		Options:     t.relyingParty.GetRequestOptions(challenge.Challenge, []webauthn.CredentialDescriptor{}),
+	}, nil
+}
+
+// Finish a passkey login by the authenticator's assertion and create tokens and a new session like login.
+// Failed assertions increase failed logins of the client IP, and also of the login ID if the passkey is found.
+func (t *TokenServiceImp) CreatePasskeyTokens(ctx context.Context, tenantID string, challengeUUID uuid.EntityUUID,
+	assertion *entity.PasskeyAssertion, session *entity.Session, audience string) (*token.TokenInfo, *token.TokenInfo, error) {
+	if t.relyingParty == nil {
+		log.Ctx(ctx).Error().Msg("WebAuthn relying party isn't configured")
+		return nil, nil, ErrPasskeyDisabled
+	}
+
+	// Check audience and login lock of the client IP
+	if !token.IsAudienceAllowed(audience) {
+		log.Ctx(ctx).Error().Str("audience", audience).Msg("Token audience isn't allowed")
+		return nil, nil, ErrTokenAudienceNotAllowed
+	}
+	if err := checkLoginLocks(ctx, t.loginLockRepo, t.loginLockPolicy, tenantID, "", session.IP); err != nil {
+		return nil, nil, err
+	}
+
+	// Use challenge
+	challenge, err := useWebAuthnChallenge(ctx, t.repoDBTx, t.webAuthnChallengeRepoPrimary, challengeUUID,
+		entity.WebAuthnChallengeTypeLogin, tenantID)
+	if err == ErrPasskeyVerificationFailed {
+		return nil, nil, ErrUnauthorized
+	} else if err != nil {
+		return nil, nil, err
+	}
+
+	// Get passkey and its user in the tenant
+	credential, err := t.webAuthnCredentialRepoPrimary.GetByCredentialID(ctx, assertion.CredentialID)
+	if err == repo.ErrNotFound {
+		log.Ctx(ctx).Error().Msg("WebAuthn credential doesn't exist")
+		addLoginFailures(ctx, t.loginLockRepo, t.loginLockPolicy, tenantID, "", session.IP)
+		return nil, nil, ErrUnauthorized
+	} else if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get WebAuthn credential")
+		return nil, nil, getReturnErr(err)
+	}
+	if len(assertion.UserHandle) > 0 && uuid.FromBytesOrNil(assertion.UserHandle) != credential.UserID {
+		log.Ctx(ctx).Error().Msg("User handle doesn't match WebAuthn credential")
+		addLoginFailures(ctx, t.loginLockRepo, t.loginLockPolicy, tenantID, "", session.IP)
+		return nil, nil, ErrUnauthorized
+	}
+	userInfo, err := t.userInfoRepoSecondary.Get(ctx, tenantID, credential.UserID)
+	if err == repo.ErrNotFound {
+		log.Ctx(ctx).Error().Msg("User of WebAuthn credential doesn't exist in tenant")
+		addLoginFailures(ctx, t.loginLockRepo, t.loginLockPolicy, tenantID, "", session.IP)
+		return nil, nil, ErrUnauthorized
+	} else if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get user info")
+		return nil, nil, getReturnErr(err)
+	}
+	if err := checkLoginLocks(ctx, t.loginLockRepo, t.loginLockPolicy, tenantID, userInfo.LoginID, ""); err != nil {
+		return nil, nil, err
+	}
+
+	// Verify assertion and update sign count
+	signCount, err := t.relyingParty.VerifyAssertion(challenge.Challenge, credential.PublicKey, credential.SignCount,
+		assertion.ClientDataJSON, assertion.AuthenticatorData, assertion.Signature)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to verify WebAuthn assertion")
+		addLoginFailures(ctx, t.loginLockRepo, t.loginLockPolicy, tenantID, userInfo.LoginID, session.IP)
+		return nil, nil, ErrUnauthorized
+	}
+	if err := t.webAuthnCredentialRepoPrimary.UpdateSignCount(ctx, credential.ID, signCount, time.Now()); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to update WebAuthn credential's sign count")
+		return nil, nil, getReturnErr(err)
 	}
+	resetLoginFailures(ctx, t.loginLockRepo, t.loginLockPolicy, tenantID, userInfo.LoginID)
+
+	// Password isn't used, so the password expiration isn't checked
+	return t.createLoginTokens(ctx, userInfo, nil, session, audience)
+}
+
+// Create tokens and a session for the user authenticated by login. Only a limited access token to change the password
+// is created if the password is expired, and only a limited access token to verify the email is created if the email
+// isn't verified but verification is required. User secret is nil for logins without password.
+func (t *TokenServiceImp) createLoginTokens(ctx context.Context, userInfo *entity.UserInfo, userSecret *entity.UserSecret,
+	session *entity.Session, audience string) (*token.TokenInfo, *token.TokenInfo, error) {
+	// Get effective roles
+	roles, userRoles, err := t.getUserRoles(ctx, userInfo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Check password expiration. Only a limited access token to change the password is issued without a session.
+	if userSecret != nil && isPasswdExpired(userSecret, userRoles) {
+		log.Ctx(ctx).Warn().Str("user_id", userInfo.ID.String()).Msg("Password is expired")
+		accTokenInfo, err := createPasswdChangeToken(userInfo, roles)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to create password change token")
+			return nil, nil, getReturnErr(err)
+		}
+		return accTokenInfo, nil, ErrPasswdExpired
+	}
+
+	// Check email verification. Only a limited access token to verify the email is issued without a session.
+	if t.isEmailVerificationRequired(userInfo) {
+		log.Ctx(ctx).Warn().Str("user_id", userInfo.ID.String()).Msg("Email isn't verified")
+		accTokenInfo, err := createEmailVerifyToken(userInfo, roles)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to create email verify token")
+			return nil, nil, getReturnErr(err)
+		}
+		return accTokenInfo, nil, ErrEmailNotVerified
+	}
+
+	// Check scopes
+	scopes := []string{}
+	if len(session.Scopes) > 0 {
+		for _, scope := range session.Scopes {
+			if !isScopeAllowedByRoles(userRoles, scope) {
+				log.Ctx(ctx).Error().Str("scope", scope).Msg("Token scope isn't allowed")
+				return nil, nil, ErrTokenScopeNotAllowed
+			}
+			if !containsStr(scopes, scope) {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+	session.Scopes = scopes
+
+	// Create tokens and session
+	return t.createUserTokens(ctx, userInfo, roles, session, audience)
+}
+
+// Check whether the user must verify the email before login
+func (t *TokenServiceImp) isEmailVerificationRequired(userInfo *entity.UserInfo) bool {
+	return t.emailVerificationPolicy != nil && t.emailVerificationPolicy.Required && !userInfo.EmailVerified
 }
 
-func (t *TokenServiceImp) CreateTokens(ctx context.Context, loginID, passwd string) (*token.TokenInfo, *token.TokenInfo, error) {
+// Authenticate a user checking login locks of the login ID and the client IP. Failed logins increase failures of them,
+// including logins of a login ID not existing.
+func (t *TokenServiceImp) authenticateUser(ctx context.Context, tenantID, loginID, passwd, ip string) (*entity.UserInfo, *entity.UserSecret, error) {
+	// Check login locks
+	if err := checkLoginLocks(ctx, t.loginLockRepo, t.loginLockPolicy, tenantID, loginID, ip); err != nil {
+		return nil, nil, err
+	}
+
+	userInfo, userSecret, err := t.validateUserPasswd(ctx, tenantID, loginID, passwd)
+	if err == ErrUnauthorized || err == ErrRepoNotFound {
+		addLoginFailures(ctx, t.loginLockRepo, t.loginLockPolicy, tenantID, loginID, ip)
+		return nil, nil, err
+	} else if err != nil {
+		return nil, nil, err
+	}
+	resetLoginFailures(ctx, t.loginLockRepo, t.loginLockPolicy, tenantID, loginID)
+
+	// Rehash password. Login doesn't fail even if rehashing fails, and the password is rehashed at the next login.
+	if hashing.IsPasswdHashOutdated(userSecret.GetPasswdHash()) {
+		t.rehashPasswd(ctx, userSecret, passwd)
+	}
+	return userInfo, userSecret, nil
+}
+
+func (t *TokenServiceImp) validateUserPasswd(ctx context.Context, tenantID, loginID, passwd string) (*entity.UserInfo, *entity.UserSecret, error) {
 	// Get user info, user secret by loginID
-	userInfo, err := t.userInfoRepoSecondary.GetByLoginID(ctx, loginID)
+	userInfo, err := t.userInfoRepoSecondary.GetByLoginID(ctx, tenantID, loginID)
 	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Msg("Failed to get user info by login ID")
 		return nil, nil, getReturnErr(err)
@@ -49,68 +385,365 @@ func (t *TokenServiceImp) CreateTokens(ctx context.Context, loginID, passwd stri
 		return nil, nil, getReturnErr(err)
 	}
 
-	// Validate login ID, password
-	if !hashing.ValidateStr(passwd, userSecret.PasswdHash, userSecret.PasswdSalt) {
+	// Validate login ID, password. Users without password can't login by password.
+	if !userSecret.HasPasswd() {
+		log.Ctx(ctx).Error().Msg("User doesn't have password")
+		return nil, nil, ErrUnauthorized
+	}
+	passwdHash := userSecret.GetPasswdHash()
+	valid, err := hashing.ValidatePasswd(passwd, passwdHash)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to validate password")
+		return nil, nil, ErrServerErr
+	} else if !valid {
 		return nil, nil, ErrUnauthorized
 	}
+	return userInfo, userSecret, nil
+}
 
-	// Create access, refresh token
-	accTokenInfo, err := token.CreateAccessToken(&token.AuthClaims{UserID: userInfo.ID.String(),
-		UserLoginID: userInfo.LoginID, UserRole: userInfo.Role})
+// Get the effective role names and roles of the user
+func (t *TokenServiceImp) getUserRoles(ctx context.Context, userInfo *entity.UserInfo) ([]entity.UserRole, []*entity.Role, error) {
+	roles, err := getUserRoles(ctx, t.groupRepoSecondary, t.groupMemberRepoSecondary, userInfo)
 	if err != nil {
-		log.Ctx(ctx).Error().Err(err).Msg("Failed to create access token")
 		return nil, nil, getReturnErr(err)
 	}
-	refTokenInfo, err := token.CreateRefreshToken(&token.AuthClaims{UserID: userInfo.ID.String(),
-		UserLoginID: userInfo.LoginID, UserRole: userInfo.Role})
+	userRoles := []*entity.Role{}
+	for _, roleName := range roles {
+		role, err := t.roleRepoSecondary.Get(ctx, string(roleName))
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to get role of user")
+			return nil, nil, getReturnErr(err)
+		}
+		userRoles = append(userRoles, role)
+	}
+	return roles, userRoles, nil
+}
+
+// Rehash the password keeping the password changed time, because the password isn't changed
+func (t *TokenServiceImp) rehashPasswd(ctx context.Context, userSecret *entity.UserSecret, passwd string) {
+	passwdHash, err := hashing.GetPasswdHash(passwd)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to create password hash")
+		return
+	}
+	if err := t.userSecretRepoPrimary.Update(ctx, &entity.UserSecret{ID: userSecret.ID, PasswdPHC: passwdHash,
+		PasswdChangedAt: userSecret.PasswdChangedAt}); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to update rehashed password")
+		return
+	}
+	log.Ctx(ctx).Info().Str("user_id", userSecret.ID.String()).Msg("Password is rehashed")
+}
+
+// Create tokens and a new session for the authenticated user. The session's client ID and scopes are set
+// to tokens, so refresh tokens issued to an OAuth2 client can be used only by the client.
+func (t *TokenServiceImp) CreateUserTokens(ctx context.Context, userInfo *entity.UserInfo, session *entity.Session,
+	audience string) (*token.TokenInfo, *token.TokenInfo, error) {
+	roles, err := getUserRoles(ctx, t.groupRepoSecondary, t.groupMemberRepoSecondary, userInfo)
 	if err != nil {
-		log.Ctx(ctx).Error().Err(err).Msg("Failed to create refresh token")
 		return nil, nil, getReturnErr(err)
 	}
+	return t.createUserTokens(ctx, userInfo, roles, session, audience)
+}
 
-	// Update refresh token to DB
-	hash, salt, err := hashing.GetStrHashAndSalt(refTokenInfo.Token)
+// Create only an access token of the client for the authenticated user. It doesn't have a session,
+// so it can't be refreshed and it is valid until it expires or the user's tokens are revoked.
+func (t *TokenServiceImp) CreateUserAccessToken(ctx context.Context, userInfo *entity.UserInfo, clientID string,
+	scopes []string) (*token.TokenInfo, error) {
+	roles, err := getUserRoles(ctx, t.groupRepoSecondary, t.groupMemberRepoSecondary, userInfo)
+	if err != nil {
+		return nil, getReturnErr(err)
+	}
+	accTokenInfo, err := token.CreateAccessToken(&token.AuthClaims{
+		UserID:      userInfo.ID.String(),
+		UserLoginID: userInfo.LoginID,
+		UserRole:    userInfo.Role,
+		Roles:       roles,
+		TenantID:    userInfo.TenantID,
+		ClientID:    clientID,
+		Scopes:      scopes,
+	}, "")
 	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to create access token")
+		return nil, getReturnErr(err)
+	}
+	return accTokenInfo, nil
+}
+
+func (t *TokenServiceImp) createUserTokens(ctx context.Context, userInfo *entity.UserInfo, roles []entity.UserRole, session *entity.Session,
+	audience string) (*token.TokenInfo, *token.TokenInfo, error) {
+	// Create access, refresh token
+	session.ID = uuid.NewV4()
+	session.UserID = userInfo.ID
+	accTokenInfo, refTokenInfo, err := createTokens(userInfo, roles, session, audience)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to create access, refresh tokens")
+		return nil, nil, getReturnErr(err)
+	}
+
+	// Create session with refresh token's hash
+	session.DeviceName = truncateStr(session.DeviceName, sessionDeviceNameMaxLen)
+	session.UserAgent = truncateStr(session.UserAgent, sessionUserAgentMaxLen)
+	if err = setSessionRefreshToken(session, refTokenInfo); err != nil {
 		log.Ctx(ctx).Error().Err(err).Msg("Failed to create refresh token's hash and salt")
 		return nil, nil, getReturnErr(err)
 	}
-	t.userSecretRepoPrimary.Update(ctx, &entity.UserSecret{
-		ID:               userInfo.ID,
-		RefreshTokenHash: hash,
-		RefreshTokenSalt: salt,
-	})
+	if err = t.sessionRepoPrimary.Create(ctx, session); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to create session")
+		return nil, nil, getReturnErr(err)
+	}
 
 	return accTokenInfo, refTokenInfo, nil
 }
 
-func (t *TokenServiceImp) RefreshToken(ctx context.Context, refreshToken string) (*token.TokenInfo, error) {
+// Rotate the refresh token of the session. Reusing an old refresh token deletes the session,
+// because it means that the refresh token may be stolen.
+func (t *TokenServiceImp) RefreshToken(ctx context.Context, refreshToken string) (*token.TokenInfo, *token.TokenInfo, error) {
+	return t.RefreshClientToken(ctx, refreshToken, "")
+}
+
+// Rotate the refresh token issued to the OAuth2 client. Empty client ID means the refresh token issued by login.
+func (t *TokenServiceImp) RefreshClientToken(ctx context.Context, refreshToken, clientID string) (*token.TokenInfo, *token.TokenInfo, error) {
+	var err error
+
 	// Validate refresh token and get auth info
 	authInfo, err := token.ValidateRefreshToken(refreshToken)
 	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Msg("Refresh token isn't valid")
-		return nil, getReturnErr(err)
+		return nil, nil, ErrUnauthorized
+	}
+	if authInfo.ClientID != clientID {
+		log.Ctx(ctx).Error().Str("client_id", authInfo.ClientID).Msg("Refresh token is issued to other client")
+		return nil, nil, ErrUnauthorized
+	}
+	sessionUUID := uuid.FromStringOrNil(authInfo.SessionID)
+
+	// Begin transaction
+	tx, _ := t.repoDBTx.Begin()
+	defer func() {
+		if err != nil {
+			if err = tx.Rollback(); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Rollback transaction error for refreshing token")
+				return
+			}
+			log.Ctx(ctx).Error().Err(err).Msg("Refresh token request is canceled")
+			return
+		}
+	}()
+
+	// Get session with lock not to rotate the refresh token concurrently
+	session, err := t.sessionRepoPrimary.WithTx(tx).GetForUpdate(ctx, sessionUUID)
+	if err == repo.ErrNotFound {
+		log.Ctx(ctx).Error().Msg("Session of refresh token doesn't exist")
+		return nil, nil, ErrUnauthorized
+	} else if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get session")
+		return nil, nil, getReturnErr(err)
 	}
 
-	// Get user secret
-	userSecret, err := t.userSecretRepoSecondary.Get(ctx, uuid.FromStringOrNil(authInfo.UserID))
+	// Check whether the refresh token matches in the session
+	if session.UserID.String() != authInfo.UserID ||
+		!hashing.ValidateStr(refreshToken, session.RefreshTokenHash, session.RefreshTokenSalt) {
+		// Revoke the token family by deleting the session
+		log.Ctx(ctx).Warn().Str("session_id", authInfo.SessionID).Msg("Old refresh token is reused, delete the session")
+		if err = t.deleteSession(ctx, tx, sessionUUID); err != nil {
+			return nil, nil, getReturnErr(err)
+		}
+		return nil, nil, ErrUnauthorized
+	}
+
+	// Get user info and effective roles to get current roles. Tokens issued before tenants were introduced are in the default tenant.
+	userInfo, err := t.userInfoRepoSecondary.Get(ctx, entity.GetTenantIDOrDefault(authInfo.TenantID), session.UserID)
+	if err == repo.ErrNotFound {
+		log.Ctx(ctx).Error().Msg("User of session doesn't exist")
+		if err = t.deleteSession(ctx, tx, sessionUUID); err != nil {
+			return nil, nil, getReturnErr(err)
+		}
+		return nil, nil, ErrUnauthorized
+	} else if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get user info")
+		return nil, nil, getReturnErr(err)
+	}
+
+	roles, userRoles, err := t.getUserRoles(ctx, userInfo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Check password expiration and email verification like login, because they may be changed after login.
+	// Only the limited access token is issued, and the refresh token isn't rotated.
+	userSecret, err := t.userSecretRepoSecondary.Get(ctx, userInfo.ID)
 	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Msg("Failed to get user secret")
-		return nil, getReturnErr(err)
+		return nil, nil, getReturnErr(err)
+	}
+	if userSecret.HasPasswd() && isPasswdExpired(userSecret, userRoles) {
+		log.Ctx(ctx).Warn().Str("user_id", userInfo.ID.String()).Msg("Password is expired")
+		var accTokenInfo *token.TokenInfo
+		if accTokenInfo, err = createPasswdChangeToken(userInfo, roles); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to create password change token")
+			return nil, nil, getReturnErr(err)
+		}
+		err = ErrPasswdExpired
+		return accTokenInfo, nil, err
+	}
+	if t.isEmailVerificationRequired(userInfo) {
+		log.Ctx(ctx).Warn().Str("user_id", userInfo.ID.String()).Msg("Email isn't verified")
+		var accTokenInfo *token.TokenInfo
+		if accTokenInfo, err = createEmailVerifyToken(userInfo, roles); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to create email verify token")
+			return nil, nil, getReturnErr(err)
+		}
+		err = ErrEmailNotVerified
+		return accTokenInfo, nil, err
 	}
 
-	// Check whether the refresh token matches in the DB
-	if !hashing.ValidateStr(refreshToken, userSecret.RefreshTokenHash, userSecret.RefreshTokenSalt) {
-		log.Ctx(ctx).Error().Err(err).Msg("Refresh token isn't matched")
-		return nil, ErrUnauthorized
+	// Create access, refresh token with the same audience
+	accTokenInfo, refTokenInfo, err := createTokens(userInfo, roles, session, authInfo.Audience)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to create access, refresh tokens")
+		return nil, nil, getReturnErr(err)
+	}
+
+	// Update session with new refresh token's hash
+	if err = setSessionRefreshToken(session, refTokenInfo); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to create refresh token's hash and salt")
+		return nil, nil, getReturnErr(err)
+	}
+	if err = t.sessionRepoPrimary.WithTx(tx).Update(ctx, session); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to update session")
+		return nil, nil, getReturnErr(err)
+	}
+
+	// Commit transaction
+	if err = tx.Commit(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Commit transaction error for refreshing token")
+		return nil, nil, getReturnErr(err)
 	}
+	return accTokenInfo, refTokenInfo, nil
+}
 
-	// Create access token
-	accTokenInfo, err := token.CreateAccessToken(&token.AuthClaims{UserID: authInfo.UserID,
-		UserLoginID: authInfo.UserLoginID, UserRole: authInfo.UserRole})
+// Delete the session, revoke access tokens of the session and commit the transaction
+func (t *TokenServiceImp) deleteSession(ctx context.Context, tx repo.DBTx, sessionUUID uuid.EntityUUID) error {
+	if err := t.sessionRepoPrimary.WithTx(tx).Delete(ctx, sessionUUID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete session")
+		return err
+	}
+	tokenRevocation, err := createTokenRevocation(ctx, t.tokenRevocationRepoPrimary, tx, entity.TokenRevocationTypeSession, sessionUUID.String())
 	if err != nil {
-		log.Ctx(ctx).Error().Err(err).Msg("Failed to create access token")
-		return nil, getReturnErr(err)
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Commit transaction error for deleting session")
+		return err
 	}
+	addTokenRevocationToList(t.revocationList, tokenRevocation)
+	return nil
+}
 
-	return accTokenInfo, nil
+func createTokens(userInfo *entity.UserInfo, roles []entity.UserRole, session *entity.Session, audience string) (*token.TokenInfo, *token.TokenInfo, error) {
+	authClaims := token.AuthClaims{
+		UserID:      userInfo.ID.String(),
+		UserLoginID: userInfo.LoginID,
+		UserRole:    userInfo.Role,
+		Roles:       roles,
+		TenantID:    userInfo.TenantID,
+		SessionID:   session.ID.String(),
+		ClientID:    session.ClientID,
+		Scopes:      session.Scopes,
+	}
+
+	accTokenInfo, err := token.CreateAccessToken(&authClaims, audience)
+	if err != nil {
+		return nil, nil, err
+	}
+	refTokenInfo, err := token.CreateRefreshToken(&authClaims, audience)
+	if err != nil {
+		return nil, nil, err
+	}
+	return accTokenInfo, refTokenInfo, nil
+}
+
+// Create an access token which can only change the password. It doesn't have a session, so it can't be refreshed
+// and it is valid until it expires. It's always for the default audience because only service-auth APIs accept it,
+// even if login requests another audience.
+func createPasswdChangeToken(userInfo *entity.UserInfo, roles []entity.UserRole) (*token.TokenInfo, error) {
+	authClaims := token.AuthClaims{
+		UserID:      userInfo.ID.String(),
+		UserLoginID: userInfo.LoginID,
+		UserRole:    userInfo.Role,
+		Roles:       roles,
+		TenantID:    userInfo.TenantID,
+		Scopes:      []string{entity.ScopeUsersMePasswd},
+	}
+	return token.CreateAccessToken(&authClaims, "")
+}
+
+// Create an access token which can only verify the email. Like the password change token, it can't be refreshed
+// and it's always for the default audience.
+func createEmailVerifyToken(userInfo *entity.UserInfo, roles []entity.UserRole) (*token.TokenInfo, error) {
+	authClaims := token.AuthClaims{
+		UserID:      userInfo.ID.String(),
+		UserLoginID: userInfo.LoginID,
+		UserRole:    userInfo.Role,
+		Roles:       roles,
+		TenantID:    userInfo.TenantID,
+		Scopes:      []string{entity.ScopeUsersMeEmail},
+	}
+	return token.CreateAccessToken(&authClaims, "")
+}
+
+// Create a MFA challenge token having the session's scopes and the audience of tokens issued after MFA
+func createMFAChallengeToken(userInfo *entity.UserInfo, session *entity.Session, audience string) (*token.TokenInfo, error) {
+	authClaims := token.AuthClaims{
+		UserID:      userInfo.ID.String(),
+		UserLoginID: userInfo.LoginID,
+		UserRole:    userInfo.Role,
+		TenantID:    userInfo.TenantID,
+		Scopes:      session.Scopes,
+	}
+	return token.CreateMFAChallengeToken(&authClaims, audience)
+}
+
+// Check whether the password is older than the shortest password max age of the roles
+func isPasswdExpired(userSecret *entity.UserSecret, roles []*entity.Role) bool {
+	maxAgeDays := 0
+	for _, role := range roles {
+		if role.PasswdMaxAgeDays > 0 && (maxAgeDays == 0 || role.PasswdMaxAgeDays < maxAgeDays) {
+			maxAgeDays = role.PasswdMaxAgeDays
+		}
+	}
+	if maxAgeDays == 0 {
+		return false
+	}
+	return time.Since(userSecret.GetPasswdChangedAt()) > time.Duration(maxAgeDays)*24*time.Hour
+}
+
+// Check whether one of the roles allows the scope
+func isScopeAllowedByRoles(roles []*entity.Role, scope string) bool {
+	for _, role := range roles {
+		if role.IsScopeAllowed(scope) {
+			return true
+		}
+	}
+	return false
+}
+
+func setSessionRefreshToken(session *entity.Session, refTokenInfo *token.TokenInfo) error {
+	hash, salt, err := hashing.GetStrHashAndSalt(refTokenInfo.Token)
+	if err != nil {
+		return err
+	}
+	session.RefreshTokenHash = hash
+	session.RefreshTokenSalt = salt
+	session.LastUsedAt = time.Now()
+	session.ExpiresAt = refTokenInfo.ExpiresAt
+	return nil
+}
+
+func truncateStr(str string, maxLen int) string {
+	runes := []rune(str)
+	if len(runes) > maxLen {
+		return string(runes[:maxLen])
+	}
+	return str
 }
@@ -0,0 +1,357 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/repo"
+	"github.com/ssup2ket/service-auth/pkg/auth/cipher"
+	"github.com/ssup2ket/service-auth/pkg/auth/token"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+const (
+	AggregateTypeTokenKey    = "TokenKey"
+	EventTypeTokenKeyRotated = "TokenKeyRotated"
+)
+
+// Replicas load token keys every sync period. New keys are pending keys which only verify tokens during
+// the activation delay, so all replicas verify tokens signed with them when they become primary keys.
+const (
+	TokenKeySyncPeriod      = time.Minute
+	TokenKeyActivationDelay = 2 * TokenKeySyncPeriod
+)
+
+type tokenKeyOutboxPayload struct {
+	AccessKeyID         string   `json:"accessKeyId"`
+	RetiredAccessKeyIDs []string `json:"retiredAccessKeyIds"`
+}
+
+// Token key service
+type TokenKeyService interface {
+	ListTokenKeys(ctx context.Context) ([]entity.TokenKey, error)
+	RotateTokenKeys(ctx context.Context) error
+	SyncTokenKeys(ctx context.Context) error
+}
+
+type TokenKeyServiceImp struct {
+	repoDBTx repo.DBTx
+
+	outboxRepoPrimary     repo.OutboxRepo
+	tokenKeyRepoPrimary   repo.TokenKeyRepo
+	tokenKeyRepoSecondary repo.TokenKeyRepo
+
+	keyring          *token.Keyring
+	accAlg           string
+	keySecret        []byte
+	rotationInterval time.Duration
+}
+
+// Keyring is nil if token keys aren't managed by DB. Rotation interval 0 means that keys are rotated only by admin.
+func NewTokenKeyServiceImp(dbTx repo.DBTx, outboxPrimary repo.OutboxRepo, tokenKeyPrimary, tokenKeySecondary repo.TokenKeyRepo,
+	keyring *token.Keyring, accAlg string, keySecret []byte, rotationInterval time.Duration) *TokenKeyServiceImp {
+	return &TokenKeyServiceImp{
+		repoDBTx: dbTx,
+
+		outboxRepoPrimary:     outboxPrimary,
+		tokenKeyRepoPrimary:   tokenKeyPrimary,
+		tokenKeyRepoSecondary: tokenKeySecondary,
+
+		keyring:          keyring,
+		accAlg:           accAlg,
+		keySecret:        keySecret,
+		rotationInterval: rotationInterval,
+	}
+}
+
+func (t *TokenKeyServiceImp) ListTokenKeys(ctx context.Context) ([]entity.TokenKey, error) {
+	if t.keyring == nil {
+		return nil, ErrTokenKeyRotationDisabled
+	}
+
+	// List token keys
+	tokenKeys, err := t.tokenKeyRepoSecondary.List(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to list token keys from DB")
+		return nil, getReturnErr(err)
+	}
+	return tokenKeys, nil
+}
+
+// Rotate token keys by admin. New keys become primary keys after the activation delay.
+func (t *TokenKeyServiceImp) RotateTokenKeys(ctx context.Context) error {
+	if t.keyring == nil {
+		return ErrTokenKeyRotationDisabled
+	}
+
+	if err := t.rotateTokenKeys(ctx, true); err != nil {
+		return err
+	}
+	return t.loadTokenKeys(ctx)
+}
+
+// Load token keys from DB to keyring. Create pending keys if keys are missing or too old, and promote
+// pending keys after the activation delay.
+func (t *TokenKeyServiceImp) SyncTokenKeys(ctx context.Context) error {
+	if t.keyring == nil {
+		return ErrTokenKeyRotationDisabled
+	}
+
+	if err := t.rotateTokenKeys(ctx, false); err != nil {
+		return err
+	}
+	return t.loadTokenKeys(ctx)
+}
+
+func (t *TokenKeyServiceImp) rotateTokenKeys(ctx context.Context, force bool) error {
+	var err error
+
+	// Check rotation without lock first, because most of sync calls don't need rotation
+	if !force {
+		tokenKeys, err := t.tokenKeyRepoPrimary.List(ctx)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to list token keys from DB")
+			return getReturnErr(err)
+		}
+		if !t.needRotation(tokenKeys, time.Now()) {
+			return nil
+		}
+	}
+
+	// Begin transaction
+	tx, _ := t.repoDBTx.Begin()
+	defer func() {
+		if err != nil {
+			if err = tx.Rollback(); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Rollback transaction error for rotating token keys")
+				return
+			}
+			log.Ctx(ctx).Error().Err(err).Msg("Rotate token keys request is canceled")
+			return
+		}
+	}()
+
+	// List token keys with lock. Check rotation again because other replicas may rotate keys already.
+	now := time.Now()
+	tokenKeys, err := t.tokenKeyRepoPrimary.WithTx(tx).ListForUpdate(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to list token keys for update from DB")
+		return getReturnErr(err)
+	}
+	if !force && !t.needRotation(tokenKeys, now) {
+		err = tx.Commit()
+		return err
+	}
+
+	// Create pending keys, or promote pending keys and retire primary keys. Keys are created as primary keys
+	// only if there is no primary key, because no token is signed yet.
+	payload := tokenKeyOutboxPayload{RetiredAccessKeyIDs: []string{}}
+	primaryChanged := false
+	for _, keyType := range []entity.TokenKeyType{entity.TokenKeyTypeAccess, entity.TokenKeyTypeRefresh} {
+		primaryKey, pendingKey := getRotationTokenKeys(tokenKeys, keyType)
+		switch t.getRotationStep(primaryKey, pendingKey, keyType, now, force) {
+		case tokenKeyRotationCreatePrimary, tokenKeyRotationCreatePending:
+			status := entity.TokenKeyStatusPending
+			if primaryKey == nil {
+				status = entity.TokenKeyStatusPrimary
+			}
+			var newTokenKey *entity.TokenKey
+			newTokenKey, err = t.newTokenKey(keyType, status)
+			if err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Failed to create new token key")
+				return ErrServerErr
+			}
+			if err = t.tokenKeyRepoPrimary.WithTx(tx).Create(ctx, newTokenKey); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Failed to create token key to DB")
+				return getReturnErr(err)
+			}
+			if primaryKey == nil {
+				primaryChanged = true
+				if keyType == entity.TokenKeyTypeAccess {
+					payload.AccessKeyID = newTokenKey.KeyID
+				}
+			}
+		case tokenKeyRotationPromote:
+			if err = t.tokenKeyRepoPrimary.WithTx(tx).Retire(ctx, primaryKey.ID, now, now.Add(token.GetMaxTokenLifetime())); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Failed to retire token key")
+				return getReturnErr(err)
+			}
+			if err = t.tokenKeyRepoPrimary.WithTx(tx).Promote(ctx, pendingKey.ID); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Failed to promote token key")
+				return getReturnErr(err)
+			}
+			primaryChanged = true
+			if keyType == entity.TokenKeyTypeAccess {
+				payload.AccessKeyID = pendingKey.KeyID
+				payload.RetiredAccessKeyIDs = append(payload.RetiredAccessKeyIDs, primaryKey.KeyID)
+			}
+		}
+	}
+
+	// Delete expired keys
+	if err = t.tokenKeyRepoPrimary.WithTx(tx).DeleteExpired(ctx, now); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete expired token keys")
+		return getReturnErr(err)
+	}
+
+	// Publish a token key rotated event for services caching JWKS when primary keys are changed
+	if primaryChanged {
+		if err = createOutbox(ctx, t.outboxRepoPrimary, tx, "RotateTokenKeys", AggregateTypeTokenKey,
+			payload.AccessKeyID, EventTypeTokenKeyRotated, payload); err != nil {
+			return getReturnErr(err)
+		}
+	}
+
+	// Commit transaction
+	if err = tx.Commit(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Commit transaction error for rotating token keys")
+		return getReturnErr(err)
+	}
+	log.Ctx(ctx).Info().Str("access_key_id", payload.AccessKeyID).Bool("primary_changed", primaryChanged).Msg("Token keys are rotated")
+	return nil
+}
+
+func (t *TokenKeyServiceImp) loadTokenKeys(ctx context.Context) error {
+	// Get token keys from primary DB not to miss just rotated keys
+	tokenKeys, err := t.tokenKeyRepoPrimary.List(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to list token keys from DB")
+		return getReturnErr(err)
+	}
+
+	// Get signing keys
+	now := time.Now()
+	var accPrimaryKey, refPrimaryKey *token.SigningKey
+	accKeys := []*token.SigningKey{}
+	refKeys := []*token.SigningKey{}
+	for _, tokenKey := range tokenKeys {
+		if tokenKey.ExpiresAt != nil && tokenKey.ExpiresAt.Before(now) {
+			continue
+		}
+
+		signingKey, err := t.getSigningKey(&tokenKey)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Str("key_id", tokenKey.KeyID).Msg("Failed to get signing key from token key")
+			continue
+		}
+
+		switch {
+		case tokenKey.Type == entity.TokenKeyTypeAccess && tokenKey.Status == entity.TokenKeyStatusPrimary:
+			accPrimaryKey = signingKey
+		case tokenKey.Type == entity.TokenKeyTypeRefresh && tokenKey.Status == entity.TokenKeyStatusPrimary:
+			refPrimaryKey = signingKey
+		// Pending and retired keys only verify tokens
+		case tokenKey.Type == entity.TokenKeyTypeAccess:
+			accKeys = append(accKeys, signingKey)
+		case tokenKey.Type == entity.TokenKeyTypeRefresh:
+			refKeys = append(refKeys, signingKey)
+		}
+	}
+	if accPrimaryKey == nil || refPrimaryKey == nil {
+		log.Ctx(ctx).Error().Msg("No primary token key")
+		return ErrTokenKeyNotFound
+	}
+
+	// Set keys to keyring
+	t.keyring.SetKeys(accPrimaryKey, refPrimaryKey, accKeys, refKeys)
+	return nil
+}
+
+type tokenKeyRotationStep int
+
+const (
+	tokenKeyRotationNone tokenKeyRotationStep = iota
+	tokenKeyRotationCreatePrimary
+	tokenKeyRotationCreatePending
+	tokenKeyRotationPromote
+)
+
+func (t *TokenKeyServiceImp) needRotation(tokenKeys []entity.TokenKey, now time.Time) bool {
+	for _, keyType := range []entity.TokenKeyType{entity.TokenKeyTypeAccess, entity.TokenKeyTypeRefresh} {
+		primaryKey, pendingKey := getRotationTokenKeys(tokenKeys, keyType)
+		if t.getRotationStep(primaryKey, pendingKey, keyType, now, false) != tokenKeyRotationNone {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *TokenKeyServiceImp) getRotationStep(primaryKey, pendingKey *entity.TokenKey, keyType entity.TokenKeyType,
+	now time.Time, force bool) tokenKeyRotationStep {
+	// No primary key
+	if primaryKey == nil {
+		return tokenKeyRotationCreatePrimary
+	}
+	// Pending key which all replicas have loaded
+	if pendingKey != nil {
+		if now.Sub(pendingKey.CreatedAt) >= TokenKeyActivationDelay {
+			return tokenKeyRotationPromote
+		}
+		return tokenKeyRotationNone
+	}
+	// Rotation by admin, primary key with changed algorithm or scheduled rotation
+	if force || primaryKey.Alg != t.getAlg(keyType) ||
+		(t.rotationInterval > 0 && now.Sub(primaryKey.CreatedAt) >= t.rotationInterval) {
+		return tokenKeyRotationCreatePending
+	}
+	return tokenKeyRotationNone
+}
+
+func getRotationTokenKeys(tokenKeys []entity.TokenKey, keyType entity.TokenKeyType) (primaryKey, pendingKey *entity.TokenKey) {
+	for i := range tokenKeys {
+		if tokenKeys[i].Type != keyType {
+			continue
+		}
+		switch tokenKeys[i].Status {
+		case entity.TokenKeyStatusPrimary:
+			primaryKey = &tokenKeys[i]
+		case entity.TokenKeyStatusPending:
+			pendingKey = &tokenKeys[i]
+		}
+	}
+	return primaryKey, pendingKey
+}
+
+func (t *TokenKeyServiceImp) newTokenKey(keyType entity.TokenKeyType, status entity.TokenKeyStatus) (*entity.TokenKey, error) {
+	alg := t.getAlg(keyType)
+	signingKey, err := token.NewRandomSigningKey(alg)
+	if err != nil {
+		return nil, err
+	}
+	signKey, err := signingKey.MarshalSignKey()
+	if err != nil {
+		return nil, err
+	}
+	encryptedKey, err := cipher.Encrypt(t.keySecret, signKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.TokenKey{
+		ID:     uuid.NewV4(),
+		KeyID:  signingKey.ID,
+		Type:   keyType,
+		Alg:    alg,
+		Status: status,
+		Key:    encryptedKey,
+	}, nil
+}
+
+func (t *TokenKeyServiceImp) getSigningKey(tokenKey *entity.TokenKey) (*token.SigningKey, error) {
+	signKey, err := cipher.Decrypt(t.keySecret, tokenKey.Key)
+	if err != nil {
+		return nil, err
+	}
+	return token.NewSigningKeyFromPEM(tokenKey.Alg, signKey)
+}
+
+// Refresh tokens are always signed with HMAC
+func (t *TokenKeyServiceImp) getAlg(keyType entity.TokenKeyType) string {
+	if keyType == entity.TokenKeyTypeAccess {
+		return t.accAlg
+	}
+	return token.AlgHS256
+}
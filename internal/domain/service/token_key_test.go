@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/repo"
+	"github.com/ssup2ket/service-auth/internal/domain/repo/mocks"
+	"github.com/ssup2ket/service-auth/pkg/auth/cipher"
+	"github.com/ssup2ket/service-auth/pkg/auth/token"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+const (
+	tokenKeySecret = "keyring-secret"
+)
+
+func TestTokenKey(t *testing.T) {
+	suite.Run(t, new(tokenKeySuite))
+}
+
+type tokenKeySuite struct {
+	suite.Suite
+
+	dbTx         mocks.DBTx
+	outboxRepo   mocks.OutboxRepo
+	tokenKeyRepo mocks.TokenKeyRepo
+
+	keyring         *token.Keyring
+	tokenKeyService TokenKeyService
+}
+
+func (t *tokenKeySuite) SetupTest() {
+	// Init transaction, repo
+	t.dbTx = mocks.DBTx{}
+	t.outboxRepo = mocks.OutboxRepo{}
+	t.tokenKeyRepo = mocks.TokenKeyRepo{}
+
+	// Set nooptracer
+	opentracing.SetGlobalTracer(opentracing.NoopTracer{})
+
+	// Init service
+	t.keyring = token.NewKeyring()
+	t.tokenKeyService = NewTokenKeyServiceImp(&t.dbTx, &t.outboxRepo, &t.tokenKeyRepo, &t.tokenKeyRepo,
+		t.keyring, token.AlgES256, []byte(tokenKeySecret), time.Hour)
+}
+
+func (t *tokenKeySuite) getTokenKey(keyType entity.TokenKeyType, alg string, createdAt time.Time) entity.TokenKey {
+	signingKey, err := token.NewRandomSigningKey(alg)
+	require.NoError(t.T(), err)
+	signKey, err := signingKey.MarshalSignKey()
+	require.NoError(t.T(), err)
+	encryptedKey, err := cipher.Encrypt([]byte(tokenKeySecret), signKey)
+	require.NoError(t.T(), err)
+
+	return entity.TokenKey{
+		ID:        uuid.NewV4(),
+		CreatedAt: createdAt,
+		KeyID:     signingKey.ID,
+		Type:      keyType,
+		Alg:       alg,
+		Status:    entity.TokenKeyStatusPrimary,
+		Key:       encryptedKey,
+	}
+}
+
+func (t *tokenKeySuite) TestSyncTokenKeysWithoutRotation() {
+	accKey := t.getTokenKey(entity.TokenKeyTypeAccess, token.AlgES256, time.Now())
+	refKey := t.getTokenKey(entity.TokenKeyTypeRefresh, token.AlgHS256, time.Now())
+	t.tokenKeyRepo.On("List", context.Background()).Return([]entity.TokenKey{accKey, refKey}, nil)
+
+	err := t.tokenKeyService.SyncTokenKeys(context.Background())
+	require.NoError(t.T(), err)
+	require.Equal(t.T(), accKey.KeyID, t.keyring.GetAccessTokenKey().ID)
+	require.Equal(t.T(), refKey.KeyID, t.keyring.GetRefreshTokenKey().ID)
+	t.tokenKeyRepo.AssertNotCalled(t.T(), "ListForUpdate", mock.Anything)
+}
+
+func (t *tokenKeySuite) mockRotation(tokenKeys []entity.TokenKey, newKeys *[]*entity.TokenKey) {
+	t.tokenKeyRepo.On("List", context.Background()).Return(tokenKeys, nil).Once()
+	t.dbTx.On("Begin").Return(&t.dbTx, nil)
+	t.tokenKeyRepo.On("WithTx", mock.Anything).Return(&t.tokenKeyRepo)
+	t.tokenKeyRepo.On("ListForUpdate", context.Background()).Return(tokenKeys, nil)
+	t.tokenKeyRepo.On("Create", context.Background(), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		*newKeys = append(*newKeys, args.Get(1).(*entity.TokenKey))
+	})
+	t.tokenKeyRepo.On("DeleteExpired", context.Background(), mock.Anything).Return(nil)
+	t.outboxRepo.On("WithTx", mock.Anything).Return(&t.outboxRepo)
+	t.outboxRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	t.dbTx.On("Commit").Return(nil)
+}
+
+func (t *tokenKeySuite) TestSyncTokenKeysWithoutKeys() {
+	var newKeys []*entity.TokenKey
+	t.mockRotation([]entity.TokenKey{}, &newKeys)
+	t.tokenKeyRepo.On("List", context.Background()).Return(func(ctx context.Context) []entity.TokenKey {
+		return []entity.TokenKey{*newKeys[0], *newKeys[1]}
+	}, nil)
+
+	// Keys are created as primary keys without primary keys
+	err := t.tokenKeyService.SyncTokenKeys(context.Background())
+	require.NoError(t.T(), err)
+	require.Len(t.T(), newKeys, 2)
+	require.Equal(t.T(), entity.TokenKeyStatusPrimary, newKeys[0].Status)
+	require.Equal(t.T(), newKeys[0].KeyID, t.keyring.GetAccessTokenKey().ID)
+	require.Equal(t.T(), newKeys[1].KeyID, t.keyring.GetRefreshTokenKey().ID)
+	t.outboxRepo.AssertNumberOfCalls(t.T(), "Create", 1)
+}
+
+func (t *tokenKeySuite) TestSyncTokenKeysWithRotation() {
+	// Primary keys are older than rotation interval
+	accKey := t.getTokenKey(entity.TokenKeyTypeAccess, token.AlgES256, time.Now().Add(-2*time.Hour))
+	refKey := t.getTokenKey(entity.TokenKeyTypeRefresh, token.AlgHS256, time.Now().Add(-2*time.Hour))
+
+	var newKeys []*entity.TokenKey
+	t.mockRotation([]entity.TokenKey{accKey, refKey}, &newKeys)
+	t.tokenKeyRepo.On("List", context.Background()).Return(func(ctx context.Context) []entity.TokenKey {
+		return []entity.TokenKey{accKey, refKey, *newKeys[0], *newKeys[1]}
+	}, nil)
+
+	// New keys are pending keys which only verify tokens, and primary keys still sign tokens
+	err := t.tokenKeyService.SyncTokenKeys(context.Background())
+	require.NoError(t.T(), err)
+	require.Len(t.T(), newKeys, 2)
+	require.Equal(t.T(), entity.TokenKeyStatusPending, newKeys[0].Status)
+	require.Equal(t.T(), entity.TokenKeyStatusPending, newKeys[1].Status)
+	require.Equal(t.T(), accKey.KeyID, t.keyring.GetAccessTokenKey().ID)
+	require.Equal(t.T(), refKey.KeyID, t.keyring.GetRefreshTokenKey().ID)
+	require.NotNil(t.T(), t.keyring.GetAccessTokenVerifyKey(newKeys[0].KeyID))
+	require.NotNil(t.T(), t.keyring.GetRefreshTokenVerifyKey(newKeys[1].KeyID))
+	t.tokenKeyRepo.AssertNotCalled(t.T(), "Retire", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	t.outboxRepo.AssertNotCalled(t.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (t *tokenKeySuite) TestSyncTokenKeysPendingNotActivated() {
+	accKey := t.getTokenKey(entity.TokenKeyTypeAccess, token.AlgES256, time.Now().Add(-2*time.Hour))
+	refKey := t.getTokenKey(entity.TokenKeyTypeRefresh, token.AlgHS256, time.Now().Add(-2*time.Hour))
+	accPendingKey := t.getTokenKey(entity.TokenKeyTypeAccess, token.AlgES256, time.Now())
+	accPendingKey.Status = entity.TokenKeyStatusPending
+	refPendingKey := t.getTokenKey(entity.TokenKeyTypeRefresh, token.AlgHS256, time.Now())
+	refPendingKey.Status = entity.TokenKeyStatusPending
+	t.tokenKeyRepo.On("List", context.Background()).Return([]entity.TokenKey{accKey, refKey, accPendingKey, refPendingKey}, nil)
+
+	err := t.tokenKeyService.SyncTokenKeys(context.Background())
+	require.NoError(t.T(), err)
+	require.Equal(t.T(), accKey.KeyID, t.keyring.GetAccessTokenKey().ID)
+	require.NotNil(t.T(), t.keyring.GetAccessTokenVerifyKey(accPendingKey.KeyID))
+	t.tokenKeyRepo.AssertNotCalled(t.T(), "ListForUpdate", mock.Anything)
+}
+
+func (t *tokenKeySuite) TestSyncTokenKeysPromotePending() {
+	// Pending keys are older than activation delay
+	accKey := t.getTokenKey(entity.TokenKeyTypeAccess, token.AlgES256, time.Now().Add(-2*time.Hour))
+	refKey := t.getTokenKey(entity.TokenKeyTypeRefresh, token.AlgHS256, time.Now().Add(-2*time.Hour))
+	accPendingKey := t.getTokenKey(entity.TokenKeyTypeAccess, token.AlgES256, time.Now().Add(-TokenKeyActivationDelay))
+	accPendingKey.Status = entity.TokenKeyStatusPending
+	refPendingKey := t.getTokenKey(entity.TokenKeyTypeRefresh, token.AlgHS256, time.Now().Add(-TokenKeyActivationDelay))
+	refPendingKey.Status = entity.TokenKeyStatusPending
+
+	var newKeys []*entity.TokenKey
+	t.mockRotation([]entity.TokenKey{accKey, refKey, accPendingKey, refPendingKey}, &newKeys)
+	t.tokenKeyRepo.On("Retire", context.Background(), accKey.ID, mock.Anything, mock.Anything).Return(nil).Once()
+	t.tokenKeyRepo.On("Retire", context.Background(), refKey.ID, mock.Anything, mock.Anything).Return(nil).Once()
+	t.tokenKeyRepo.On("Promote", context.Background(), accPendingKey.ID).Return(nil).Once()
+	t.tokenKeyRepo.On("Promote", context.Background(), refPendingKey.ID).Return(nil).Once()
+
+	// Keys after promotion
+	retiredAt := time.Now()
+	expiresAt := retiredAt.Add(time.Hour)
+	accKey.Status, accKey.RetiredAt, accKey.ExpiresAt = entity.TokenKeyStatusRetired, &retiredAt, &expiresAt
+	refKey.Status, refKey.RetiredAt, refKey.ExpiresAt = entity.TokenKeyStatusRetired, &retiredAt, &expiresAt
+	accPendingKey.Status, refPendingKey.Status = entity.TokenKeyStatusPrimary, entity.TokenKeyStatusPrimary
+	t.tokenKeyRepo.On("List", context.Background()).Return([]entity.TokenKey{accKey, refKey, accPendingKey, refPendingKey}, nil)
+
+	err := t.tokenKeyService.SyncTokenKeys(context.Background())
+	require.NoError(t.T(), err)
+	require.Empty(t.T(), newKeys)
+	require.Equal(t.T(), accPendingKey.KeyID, t.keyring.GetAccessTokenKey().ID)
+	require.Equal(t.T(), refPendingKey.KeyID, t.keyring.GetRefreshTokenKey().ID)
+	require.NotNil(t.T(), t.keyring.GetAccessTokenVerifyKey(accKey.KeyID))
+	require.NotNil(t.T(), t.keyring.GetRefreshTokenVerifyKey(refKey.KeyID))
+	t.tokenKeyRepo.AssertNumberOfCalls(t.T(), "Retire", 2)
+	t.tokenKeyRepo.AssertNumberOfCalls(t.T(), "Promote", 2)
+	t.outboxRepo.AssertNumberOfCalls(t.T(), "Create", 1)
+}
+
+func (t *tokenKeySuite) TestRotateTokenKeysServerError() {
+	t.dbTx.On("Begin").Return(&t.dbTx, nil)
+	t.tokenKeyRepo.On("WithTx", mock.Anything).Return(&t.tokenKeyRepo)
+	t.tokenKeyRepo.On("ListForUpdate", context.Background()).Return(nil, repo.ErrServerError)
+	t.dbTx.On("Rollback").Return(nil)
+
+	err := t.tokenKeyService.RotateTokenKeys(context.Background())
+	require.Equal(t.T(), ErrRepoServerError, err)
+}
+
+func (t *tokenKeySuite) TestRotateTokenKeysDisabled() {
+	tokenKeyService := NewTokenKeyServiceImp(&t.dbTx, &t.outboxRepo, &t.tokenKeyRepo, &t.tokenKeyRepo,
+		nil, token.AlgHS256, nil, 0)
+
+	err := tokenKeyService.RotateTokenKeys(context.Background())
+	require.Equal(t.T(), ErrTokenKeyRotationDisabled, err)
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/repo"
+	"github.com/ssup2ket/service-auth/pkg/auth/token"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+// Token revocation service
+type TokenRevocationService interface {
+	RevokeToken(ctx context.Context, tokenID string) error
+	IsTokenRevoked(ctx context.Context, claims *token.TokenClaims) (bool, error)
+	IntrospectToken(ctx context.Context, accessToken string) (*token.TokenClaims, bool, error)
+	SyncTokenRevocations(ctx context.Context) error
+}
+
+type TokenRevocationServiceImp struct {
+	tokenRevocationRepoPrimary   repo.TokenRevocationRepo
+	tokenRevocationRepoSecondary repo.TokenRevocationRepo
+
+	revocationList *token.RevocationList
+}
+
+// Revocation list is nil if revocations aren't cached in memory. Then revocations are checked from DB for every request.
+func NewTokenRevocationServiceImp(tokenRevocationPrimary, tokenRevocationSecondary repo.TokenRevocationRepo,
+	revocationList *token.RevocationList) *TokenRevocationServiceImp {
+	return &TokenRevocationServiceImp{
+		tokenRevocationRepoPrimary:   tokenRevocationPrimary,
+		tokenRevocationRepoSecondary: tokenRevocationSecondary,
+
+		revocationList: revocationList,
+	}
+}
+
+// Revoke a token by its token ID
+func (t *TokenRevocationServiceImp) RevokeToken(ctx context.Context, tokenID string) error {
+	tokenRevocation := newTokenRevocation(entity.TokenRevocationTypeToken, tokenID)
+	if err := t.tokenRevocationRepoPrimary.Create(ctx, tokenRevocation); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to create token revocation to DB")
+		return getReturnErr(err)
+	}
+	addTokenRevocationToList(t.revocationList, tokenRevocation)
+	return nil
+}
+
+// Check whether the token is revoked by its token ID, session ID or user ID
+func (t *TokenRevocationServiceImp) IsTokenRevoked(ctx context.Context, claims *token.TokenClaims) (bool, error) {
+	if t.revocationList != nil {
+		return t.revocationList.IsRevoked(claims), nil
+	}
+
+	revoked, err := t.tokenRevocationRepoSecondary.IsRevoked(ctx, token.GetRevocationSubjects(claims), claims.GetIssuedAt())
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to check token revocation from DB")
+		return false, getReturnErr(err)
+	}
+	return revoked, nil
+}
+
+// Introspect an access token of any allowed audience for other services. A token is active if it is valid
+// and isn't revoked, and claims are returned only for an active token.
+func (t *TokenRevocationServiceImp) IntrospectToken(ctx context.Context, accessToken string) (*token.TokenClaims, bool, error) {
+	claims, err := token.ValidateAccessTokenAnyAudience(accessToken)
+	if err != nil {
+		log.Ctx(ctx).Info().Err(err).Msg("Introspected token isn't valid")
+		return nil, false, nil
+	}
+
+	revoked, err := t.IsTokenRevoked(ctx, claims)
+	if err != nil {
+		return nil, false, err
+	} else if revoked {
+		log.Ctx(ctx).Info().Str("token_id", claims.Id).Msg("Introspected token is revoked")
+		return nil, false, nil
+	}
+	return claims, true, nil
+}
+
+// Delete expired revocations and load revocations from DB to revocation list to get revocations by other replicas
+func (t *TokenRevocationServiceImp) SyncTokenRevocations(ctx context.Context) error {
+	now := time.Now()
+	if err := t.tokenRevocationRepoPrimary.DeleteExpired(ctx, now); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete expired token revocations")
+		return getReturnErr(err)
+	}
+	if t.revocationList == nil {
+		return nil
+	}
+
+	// Get revocations from primary DB not to miss just created revocations
+	tokenRevocations, err := t.tokenRevocationRepoPrimary.ListActive(ctx, now)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to list token revocations from DB")
+		return getReturnErr(err)
+	}
+	for i := range tokenRevocations {
+		addTokenRevocationToList(t.revocationList, &tokenRevocations[i])
+	}
+	t.revocationList.DeleteExpired(now)
+	return nil
+}
+
+// Revocation is kept until all access tokens issued before the revocation are expired. Revocation time is
+// in milliseconds like the issuance time of tokens and the time stored in DB.
+func newTokenRevocation(revocationType entity.TokenRevocationType, subject string) *entity.TokenRevocation {
+	now := time.Now().Truncate(time.Millisecond)
+	return &entity.TokenRevocation{
+		ID:        uuid.NewV4(),
+		Type:      revocationType,
+		Subject:   subject,
+		RevokedAt: now,
+		ExpiresAt: now.Add(token.GetAccessTokenLifetime()),
+	}
+}
+
+// Create a token revocation in the transaction. Add the returned revocation to the revocation list after commit.
+func createTokenRevocation(ctx context.Context, tokenRevocationRepo repo.TokenRevocationRepo, tx repo.DBTx,
+	revocationType entity.TokenRevocationType, subject string) (*entity.TokenRevocation, error) {
+	tokenRevocation := newTokenRevocation(revocationType, subject)
+	if err := tokenRevocationRepo.WithTx(tx).Create(ctx, tokenRevocation); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to create token revocation to DB")
+		return nil, err
+	}
+	return tokenRevocation, nil
+}
+
+func addTokenRevocationToList(revocationList *token.RevocationList, tokenRevocation *entity.TokenRevocation) {
+	if revocationList == nil {
+		return
+	}
+	revocationList.Add(tokenRevocation.Subject, tokenRevocation.RevokedAt, tokenRevocation.ExpiresAt)
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/repo"
+	"github.com/ssup2ket/service-auth/internal/domain/repo/mocks"
+	"github.com/ssup2ket/service-auth/internal/test"
+	"github.com/ssup2ket/service-auth/pkg/auth/token"
+)
+
+func TestTokenRevocation(t *testing.T) {
+	suite.Run(t, new(tokenRevocationSuite))
+}
+
+type tokenRevocationSuite struct {
+	suite.Suite
+
+	tokenRevocationRepo mocks.TokenRevocationRepo
+	revocationList      *token.RevocationList
+	claims              *token.TokenClaims
+}
+
+func (t *tokenRevocationSuite) SetupTest() {
+	t.tokenRevocationRepo = mocks.TokenRevocationRepo{}
+	t.revocationList = token.NewRevocationList()
+	t.claims = &token.TokenClaims{
+		StandardClaims: jwt.StandardClaims{IssuedAt: time.Now().Add(-time.Minute).Unix()},
+		AuthClaims:     token.AuthClaims{UserID: test.UserIDCorrect.String()},
+	}
+}
+
+func (t *tokenRevocationSuite) TestIsTokenRevokedMySQL() {
+	tokenRevocationService := NewTokenRevocationServiceImp(&t.tokenRevocationRepo, &t.tokenRevocationRepo, nil)
+	t.tokenRevocationRepo.On("IsRevoked", context.Background(), []string{test.UserIDCorrect.String()}, t.claims.GetIssuedAt()).
+		Return(true, nil)
+
+	revoked, err := tokenRevocationService.IsTokenRevoked(context.Background(), t.claims)
+	require.NoError(t.T(), err)
+	require.True(t.T(), revoked)
+}
+
+func (t *tokenRevocationSuite) TestIsTokenRevokedMySQLError() {
+	tokenRevocationService := NewTokenRevocationServiceImp(&t.tokenRevocationRepo, &t.tokenRevocationRepo, nil)
+	t.tokenRevocationRepo.On("IsRevoked", context.Background(), mock.Anything, mock.Anything).Return(false, repo.ErrServerError)
+
+	_, err := tokenRevocationService.IsTokenRevoked(context.Background(), t.claims)
+	require.Equal(t.T(), ErrRepoServerError, err)
+}
+
+func (t *tokenRevocationSuite) TestRevokeTokenMemory() {
+	tokenRevocationService := NewTokenRevocationServiceImp(&t.tokenRevocationRepo, &t.tokenRevocationRepo, t.revocationList)
+	t.tokenRevocationRepo.On("Create", context.Background(), mock.Anything).Return(nil)
+	t.claims.Id = test.TokenRevocationTokenIDCorrect
+
+	err := tokenRevocationService.RevokeToken(context.Background(), t.claims.Id)
+	require.NoError(t.T(), err)
+	revoked, err := tokenRevocationService.IsTokenRevoked(context.Background(), t.claims)
+	require.NoError(t.T(), err)
+	require.True(t.T(), revoked)
+}
+
+func (t *tokenRevocationSuite) TestSyncTokenRevocations() {
+	tokenRevocationService := NewTokenRevocationServiceImp(&t.tokenRevocationRepo, &t.tokenRevocationRepo, t.revocationList)
+	now := time.Now()
+	t.tokenRevocationRepo.On("DeleteExpired", context.Background(), mock.Anything).Return(nil)
+	t.tokenRevocationRepo.On("ListActive", context.Background(), mock.Anything).Return([]entity.TokenRevocation{{
+		Type:      entity.TokenRevocationTypeUser,
+		Subject:   test.UserIDCorrect.String(),
+		RevokedAt: now,
+		ExpiresAt: now.Add(time.Hour),
+	}}, nil)
+
+	err := tokenRevocationService.SyncTokenRevocations(context.Background())
+	require.NoError(t.T(), err)
+	require.True(t.T(), t.revocationList.IsRevoked(t.claims))
+}
+
+func (t *tokenRevocationSuite) TestIntrospectTokenActive() {
+	tokenRevocationService := NewTokenRevocationServiceImp(&t.tokenRevocationRepo, &t.tokenRevocationRepo, t.revocationList)
+	t.setKeyProvider()
+	tokenInfo, err := token.CreateAccessToken(&token.AuthClaims{UserID: test.UserIDCorrect.String(), UserRole: test.UserRoleCorrect}, "")
+	require.NoError(t.T(), err)
+
+	claims, active, err := tokenRevocationService.IntrospectToken(context.Background(), tokenInfo.Token)
+	require.NoError(t.T(), err)
+	require.True(t.T(), active)
+	require.Equal(t.T(), test.UserIDCorrect.String(), claims.Subject)
+	require.Equal(t.T(), test.UserRoleCorrect, claims.UserRole)
+}
+
+func (t *tokenRevocationSuite) TestIntrospectTokenRevoked() {
+	tokenRevocationService := NewTokenRevocationServiceImp(&t.tokenRevocationRepo, &t.tokenRevocationRepo, t.revocationList)
+	t.setKeyProvider()
+	tokenInfo, err := token.CreateAccessToken(&token.AuthClaims{UserID: test.UserIDCorrect.String()}, "")
+	require.NoError(t.T(), err)
+	t.revocationList.Add(test.UserIDCorrect.String(), time.Now(), time.Now().Add(time.Hour))
+
+	claims, active, err := tokenRevocationService.IntrospectToken(context.Background(), tokenInfo.Token)
+	require.NoError(t.T(), err)
+	require.False(t.T(), active)
+	require.Nil(t.T(), claims)
+}
+
+func (t *tokenRevocationSuite) TestIntrospectTokenInvalid() {
+	tokenRevocationService := NewTokenRevocationServiceImp(&t.tokenRevocationRepo, &t.tokenRevocationRepo, t.revocationList)
+	t.setKeyProvider()
+
+	_, active, err := tokenRevocationService.IntrospectToken(context.Background(), "invalid")
+	require.NoError(t.T(), err)
+	require.False(t.T(), active)
+}
+
+func (t *tokenRevocationSuite) setKeyProvider() {
+	keyProvider, err := token.NewRandomKeyProvider(token.AlgHS256)
+	require.NoError(t.T(), err)
+	token.SetKeyProvider(keyProvider)
+}
@@ -0,0 +1,919 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/repo"
+	"github.com/ssup2ket/service-auth/internal/domain/repo/mocks"
+	"github.com/ssup2ket/service-auth/internal/test"
+	"github.com/ssup2ket/service-auth/pkg/auth/cipher"
+	"github.com/ssup2ket/service-auth/pkg/auth/hashing"
+	"github.com/ssup2ket/service-auth/pkg/auth/token"
+	"github.com/ssup2ket/service-auth/pkg/auth/totp"
+	"github.com/ssup2ket/service-auth/pkg/auth/webauthn"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+func TestToken(t *testing.T) {
+	suite.Run(t, new(tokenSuite))
+}
+
+type tokenSuite struct {
+	suite.Suite
+
+	dbTx            mocks.DBTx
+	userInfoRepo    mocks.UserInfoRepo
+	userSecretRepo  mocks.UserSecretRepo
+	roleRepo        mocks.RoleRepo
+	groupRepo       mocks.GroupRepo
+	groupMemberRepo mocks.GroupMemberRepo
+	sessionRepo     mocks.SessionRepo
+
+	tokenRevocationRepo mocks.TokenRevocationRepo
+	loginLockRepo       mocks.LoginLockRepo
+	mfaRecoveryCodeRepo mocks.MFARecoveryCodeRepo
+
+	webAuthnCredentialRepo mocks.WebAuthnCredentialRepo
+	webAuthnChallengeRepo  mocks.WebAuthnChallengeRepo
+
+	tokenService TokenService
+
+	userInfo     *entity.UserInfo
+	refreshToken string
+	session      *entity.Session
+}
+
+func (t *tokenSuite) SetupTest() {
+	// Init transaction, repo
+	t.dbTx = mocks.DBTx{}
+	t.userInfoRepo = mocks.UserInfoRepo{}
+	t.userSecretRepo = mocks.UserSecretRepo{}
+	t.roleRepo = mocks.RoleRepo{}
+	t.groupRepo = mocks.GroupRepo{}
+	t.groupMemberRepo = mocks.GroupMemberRepo{}
+	t.sessionRepo = mocks.SessionRepo{}
+	t.tokenRevocationRepo = mocks.TokenRevocationRepo{}
+	t.loginLockRepo = mocks.LoginLockRepo{}
+	t.mfaRecoveryCodeRepo = mocks.MFARecoveryCodeRepo{}
+	t.webAuthnCredentialRepo = mocks.WebAuthnCredentialRepo{}
+	t.webAuthnChallengeRepo = mocks.WebAuthnChallengeRepo{}
+
+	// Init token key provider
+	keyProvider, err := token.NewRandomKeyProvider(token.AlgHS256)
+	require.NoError(t.T(), err)
+	token.SetKeyProvider(keyProvider)
+
+	// Init service. Login locks are disabled except login lock tests.
+	t.tokenService = NewTokenServiceImp(&t.dbTx, t.tokenRepos(), nil, TokenPolicies{
+		LoginLock:         &LoginLockPolicy{},
+		EmailVerification: &EmailVerificationPolicy{},
+	}, []byte(test.MFASecretCorrect), &testRelyingParty)
+
+	// Get refresh token and session having the refresh token's hash
+	t.userInfo = &entity.UserInfo{
+		ID:       test.UserIDCorrect,
+		TenantID: test.TenantIDCorrect,
+		LoginID:  test.UserLoginIDCorrect,
+		Role:     test.UserRoleCorrect,
+	}
+	t.session = &entity.Session{
+		ID:     uuid.NewV4(),
+		UserID: test.UserIDCorrect,
+	}
+	_, refTokenInfo, err := createTokens(t.userInfo, nil, t.session, "")
+	require.NoError(t.T(), err)
+	require.NoError(t.T(), setSessionRefreshToken(t.session, refTokenInfo))
+	t.refreshToken = refTokenInfo.Token
+}
+
+// Get a token service locking login IDs after 3 failures and IPs after 10 failures
+func (t *tokenSuite) newLoginLockTokenService() TokenService {
+	return NewTokenServiceImp(&t.dbTx, t.tokenRepos(), nil, TokenPolicies{
+		LoginLock:         &testLoginLockPolicy,
+		EmailVerification: &EmailVerificationPolicy{},
+	}, []byte(test.MFASecretCorrect), &testRelyingParty)
+}
+
+// Get a token service requiring email verification
+func (t *tokenSuite) newEmailVerificationTokenService() TokenService {
+	return NewTokenServiceImp(&t.dbTx, t.tokenRepos(), nil, TokenPolicies{
+		LoginLock:         &LoginLockPolicy{},
+		EmailVerification: &EmailVerificationPolicy{Required: true, Lifetime: time.Hour},
+	}, []byte(test.MFASecretCorrect), &testRelyingParty)
+}
+
+// Get the mocked repos of the token service
+func (t *tokenSuite) tokenRepos() TokenRepos {
+	return TokenRepos{
+		UserInfoSecondary:    &t.userInfoRepo,
+		UserSecretSecondary:  &t.userSecretRepo,
+		RoleSecondary:        &t.roleRepo,
+		GroupSecondary:       &t.groupRepo,
+		GroupMemberSecondary: &t.groupMemberRepo,
+		UserSecretPrimary:    &t.userSecretRepo,
+		SessionPrimary:       &t.sessionRepo,
+
+		TokenRevocationPrimary: &t.tokenRevocationRepo,
+		LoginLock:              &t.loginLockRepo,
+		MFARecoveryCodePrimary: &t.mfaRecoveryCodeRepo,
+
+		WebAuthnCredentialPrimary: &t.webAuthnCredentialRepo,
+		WebAuthnChallengePrimary:  &t.webAuthnChallengeRepo,
+	}
+}
+
+// Mock the user not to be a member of any group
+func (t *tokenSuite) mockNoGroups() {
+	t.groupMemberRepo.On("ListByMemberIDs", context.Background(), []uuid.EntityUUID{test.UserIDCorrect}).Return([]entity.GroupMember{}, nil)
+	t.groupRepo.On("ListByIDs", context.Background(), test.TenantIDCorrect, []uuid.EntityUUID{}).Return([]entity.Group{}, nil)
+}
+
+func (t *tokenSuite) TestCreateTokensSuccess() {
+	t.mockNoGroups()
+	passwdHash, err := hashing.GetPasswdHash(test.UserPasswdCorrect)
+	require.NoError(t.T(), err)
+
+	var createdSession *entity.Session
+	t.userInfoRepo.On("GetByLoginID", context.Background(), test.TenantIDCorrect, test.UserLoginIDCorrect).Return(t.userInfo, nil)
+	t.userSecretRepo.On("Get", context.Background(), test.UserIDCorrect).Return(&entity.UserSecret{
+		ID:        test.UserIDCorrect,
+		PasswdPHC: passwdHash,
+	}, nil)
+	t.roleRepo.On("Get", context.Background(), string(test.UserRoleCorrect)).Return(&test.RoleAdminCorrect, nil)
+	t.sessionRepo.On("Create", context.Background(), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		createdSession = args.Get(1).(*entity.Session)
+	})
+
+	_, refTokenInfo, err := t.tokenService.CreateTokens(context.Background(), test.TenantIDCorrect, test.UserLoginIDCorrect, test.UserPasswdCorrect,
+		&entity.Session{DeviceName: test.SessionDeviceNameCorrect, UserAgent: test.SessionUserAgentCorrect, IP: test.SessionIPCorrect}, "")
+	require.NoError(t.T(), err)
+	require.Equal(t.T(), test.UserIDCorrect, createdSession.UserID)
+	require.Equal(t.T(), test.SessionDeviceNameCorrect, createdSession.DeviceName)
+	require.True(t.T(), hashing.ValidateStr(refTokenInfo.Token, createdSession.RefreshTokenHash, createdSession.RefreshTokenSalt))
+
+	authClaims, err := token.ValidateRefreshToken(refTokenInfo.Token)
+	require.NoError(t.T(), err)
+	require.Equal(t.T(), createdSession.ID.String(), authClaims.SessionID)
+	require.Equal(t.T(), test.TenantIDCorrect, authClaims.TenantID)
+}
+
+func (t *tokenSuite) TestCreateTokensRehashLegacyPasswd() {
+	t.mockNoGroups()
+	passwdHash, passwdSalt, err := hashing.GetStrHashAndSalt(test.UserPasswdCorrect)
+	require.NoError(t.T(), err)
+
+	var updatedSecret *entity.UserSecret
+	t.userInfoRepo.On("GetByLoginID", context.Background(), test.TenantIDCorrect, test.UserLoginIDCorrect).Return(t.userInfo, nil)
+	t.userSecretRepo.On("Get", context.Background(), test.UserIDCorrect).Return(&entity.UserSecret{
+		ID:         test.UserIDCorrect,
+		PasswdHash: passwdHash,
+		PasswdSalt: passwdSalt,
+	}, nil)
+	t.userSecretRepo.On("Update", context.Background(), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		updatedSecret = args.Get(1).(*entity.UserSecret)
+	})
+	t.roleRepo.On("Get", context.Background(), string(test.UserRoleCorrect)).Return(&test.RoleAdminCorrect, nil)
+	t.sessionRepo.On("Create", context.Background(), mock.Anything).Return(nil)
+
+	_, _, err = t.tokenService.CreateTokens(context.Background(), test.TenantIDCorrect, test.UserLoginIDCorrect, test.UserPasswdCorrect,
+		&entity.Session{}, "")
+	require.NoError(t.T(), err)
+	require.Equal(t.T(), test.UserIDCorrect, updatedSecret.ID)
+	require.Nil(t.T(), updatedSecret.PasswdHash)
+	require.False(t.T(), hashing.IsPasswdHashOutdated(updatedSecret.PasswdPHC))
+
+	valid, err := hashing.ValidatePasswd(test.UserPasswdCorrect, updatedSecret.PasswdPHC)
+	require.NoError(t.T(), err)
+	require.True(t.T(), valid)
+}
+
+func (t *tokenSuite) TestCreateTokensWrongPasswd() {
+	passwdHash, err := hashing.GetPasswdHash(test.UserPasswdCorrect)
+	require.NoError(t.T(), err)
+
+	t.userInfoRepo.On("GetByLoginID", context.Background(), test.TenantIDCorrect, test.UserLoginIDCorrect).Return(t.userInfo, nil)
+	t.userSecretRepo.On("Get", context.Background(), test.UserIDCorrect).Return(&entity.UserSecret{
+		ID:        test.UserIDCorrect,
+		PasswdPHC: passwdHash,
+	}, nil)
+
+	_, _, err = t.tokenService.CreateTokens(context.Background(), test.TenantIDCorrect, test.UserLoginIDCorrect, test.UserPasswdCorrect+"wrong",
+		&entity.Session{}, "")
+	require.Equal(t.T(), ErrUnauthorized, err)
+	t.userSecretRepo.AssertNotCalled(t.T(), "Update", mock.Anything, mock.Anything)
+	t.sessionRepo.AssertNotCalled(t.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (t *tokenSuite) TestCreateTokensScopes() {
+	t.mockNoGroups()
+	passwdHash, err := hashing.GetPasswdHash(test.UserPasswdCorrect)
+	require.NoError(t.T(), err)
+
+	t.userInfoRepo.On("GetByLoginID", context.Background(), test.TenantIDCorrect, test.UserLoginIDCorrect).Return(t.userInfo, nil)
+	t.userSecretRepo.On("Get", context.Background(), test.UserIDCorrect).Return(&entity.UserSecret{
+		ID:        test.UserIDCorrect,
+		PasswdPHC: passwdHash,
+	}, nil)
+	t.roleRepo.On("Get", context.Background(), string(test.UserRoleCorrect)).Return(&test.RoleAdminCorrect, nil)
+	t.sessionRepo.On("Create", context.Background(), mock.Anything).Return(nil)
+
+	accTokenInfo, _, err := t.tokenService.CreateTokens(context.Background(), test.TenantIDCorrect, test.UserLoginIDCorrect, test.UserPasswdCorrect,
+		&entity.Session{Scopes: test.SessionScopesCorrect}, "")
+	require.NoError(t.T(), err)
+
+	authClaims, err := token.ValidateAccessToken(accTokenInfo.Token)
+	require.NoError(t.T(), err)
+	require.Equal(t.T(), []string{entity.ScopeUsersMeRead, entity.ScopeUsersRead}, authClaims.Scopes)
+}
+
+func (t *tokenSuite) TestCreateTokensScopeNotAllowed() {
+	t.mockNoGroups()
+	passwdHash, err := hashing.GetPasswdHash(test.UserPasswdCorrect)
+	require.NoError(t.T(), err)
+
+	t.userInfoRepo.On("GetByLoginID", context.Background(), test.TenantIDCorrect, test.UserLoginIDCorrect).Return(t.userInfo, nil)
+	t.userSecretRepo.On("Get", context.Background(), test.UserIDCorrect).Return(&entity.UserSecret{
+		ID:        test.UserIDCorrect,
+		PasswdPHC: passwdHash,
+	}, nil)
+	t.roleRepo.On("Get", context.Background(), string(test.UserRoleCorrect)).Return(&test.RoleAdminCorrect, nil)
+
+	_, _, err = t.tokenService.CreateTokens(context.Background(), test.TenantIDCorrect, test.UserLoginIDCorrect, test.UserPasswdCorrect,
+		&entity.Session{Scopes: test.SessionScopesWrong}, "")
+	require.Equal(t.T(), ErrTokenScopeNotAllowed, err)
+	t.sessionRepo.AssertNotCalled(t.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (t *tokenSuite) TestCreateTokensScopeNotAllowedForRole() {
+	t.mockNoGroups()
+	passwdHash, err := hashing.GetPasswdHash(test.UserPasswdCorrect)
+	require.NoError(t.T(), err)
+
+	t.userInfoRepo.On("GetByLoginID", context.Background(), test.TenantIDCorrect, test.UserLoginIDCorrect).Return(t.userInfo, nil)
+	t.userSecretRepo.On("Get", context.Background(), test.UserIDCorrect).Return(&entity.UserSecret{
+		ID:        test.UserIDCorrect,
+		PasswdPHC: passwdHash,
+		CreatedAt: time.Now(),
+	}, nil)
+	t.roleRepo.On("Get", context.Background(), string(test.UserRoleCorrect)).Return(&test.RoleCorrect, nil)
+
+	_, _, err = t.tokenService.CreateTokens(context.Background(), test.TenantIDCorrect, test.UserLoginIDCorrect, test.UserPasswdCorrect,
+		&entity.Session{Scopes: test.SessionScopesCorrect}, "")
+	require.Equal(t.T(), ErrTokenScopeNotAllowed, err)
+	t.sessionRepo.AssertNotCalled(t.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (t *tokenSuite) TestCreateTokensPasswdExpired() {
+	t.mockNoGroups()
+	passwdHash, err := hashing.GetPasswdHash(test.UserPasswdCorrect)
+	require.NoError(t.T(), err)
+
+	// Password is changed before the max age of the role
+	passwdChangedAt := time.Now().AddDate(0, 0, -test.RolePasswdMaxAgeDaysCorrect-1)
+	t.userInfoRepo.On("GetByLoginID", context.Background(), test.TenantIDCorrect, test.UserLoginIDCorrect).Return(t.userInfo, nil)
+	t.userSecretRepo.On("Get", context.Background(), test.UserIDCorrect).Return(&entity.UserSecret{
+		ID:              test.UserIDCorrect,
+		PasswdPHC:       passwdHash,
+		PasswdChangedAt: &passwdChangedAt,
+	}, nil)
+	t.roleRepo.On("Get", context.Background(), string(test.UserRoleCorrect)).Return(&test.RoleCorrect, nil)
+
+	accTokenInfo, refTokenInfo, err := t.tokenService.CreateTokens(context.Background(), test.TenantIDCorrect, test.UserLoginIDCorrect,
+		test.UserPasswdCorrect, &entity.Session{}, "")
+	require.Equal(t.T(), ErrPasswdExpired, err)
+	require.Nil(t.T(), refTokenInfo)
+	t.sessionRepo.AssertNotCalled(t.T(), "Create", mock.Anything, mock.Anything)
+
+	authClaims, err := token.ValidateAccessToken(accTokenInfo.Token)
+	require.NoError(t.T(), err)
+	require.Equal(t.T(), []string{entity.ScopeUsersMePasswd}, authClaims.Scopes)
+	require.Empty(t.T(), authClaims.SessionID)
+}
+
+func (t *tokenSuite) TestCreateTokensPasswdExpiredAudience() {
+	config := token.GetDefaultConfig()
+	config.Audiences = []string{"service-a"}
+	token.SetConfig(config)
+	defer token.SetConfig(token.GetDefaultConfig())
+
+	t.mockNoGroups()
+	passwdHash, err := hashing.GetPasswdHash(test.UserPasswdCorrect)
+	require.NoError(t.T(), err)
+
+	passwdChangedAt := time.Now().AddDate(0, 0, -test.RolePasswdMaxAgeDaysCorrect-1)
+	t.userInfoRepo.On("GetByLoginID", context.Background(), test.TenantIDCorrect, test.UserLoginIDCorrect).Return(t.userInfo, nil)
+	t.userSecretRepo.On("Get", context.Background(), test.UserIDCorrect).Return(&entity.UserSecret{
+		ID:              test.UserIDCorrect,
+		PasswdPHC:       passwdHash,
+		PasswdChangedAt: &passwdChangedAt,
+	}, nil)
+	t.roleRepo.On("Get", context.Background(), string(test.UserRoleCorrect)).Return(&test.RoleCorrect, nil)
+
+	// Password change token of login for another audience is valid for service-auth APIs
+	accTokenInfo, _, err := t.tokenService.CreateTokens(context.Background(), test.TenantIDCorrect, test.UserLoginIDCorrect,
+		test.UserPasswdCorrect, &entity.Session{}, "service-a")
+	require.Equal(t.T(), ErrPasswdExpired, err)
+	authClaims, err := token.ValidateAccessToken(accTokenInfo.Token)
+	require.NoError(t.T(), err)
+	require.Equal(t.T(), []string{entity.ScopeUsersMePasswd}, authClaims.Scopes)
+}
+
+func (t *tokenSuite) TestCreateTokensEmailNotVerified() {
+	t.mockNoGroups()
+	passwdHash, err := hashing.GetPasswdHash(test.UserPasswdCorrect)
+	require.NoError(t.T(), err)
+
+	t.userInfoRepo.On("GetByLoginID", context.Background(), test.TenantIDCorrect, test.UserLoginIDCorrect).Return(t.userInfo, nil)
+	t.userSecretRepo.On("Get", context.Background(), test.UserIDCorrect).Return(&entity.UserSecret{
+		ID:        test.UserIDCorrect,
+		PasswdPHC: passwdHash,
+	}, nil)
+	t.roleRepo.On("Get", context.Background(), string(test.UserRoleCorrect)).Return(&test.RoleAdminCorrect, nil)
+
+	accTokenInfo, refTokenInfo, err := t.newEmailVerificationTokenService().CreateTokens(context.Background(), test.TenantIDCorrect,
+		test.UserLoginIDCorrect, test.UserPasswdCorrect, &entity.Session{}, "")
+	require.Equal(t.T(), ErrEmailNotVerified, err)
+	require.Nil(t.T(), refTokenInfo)
+	t.sessionRepo.AssertNotCalled(t.T(), "Create", mock.Anything, mock.Anything)
+
+	authClaims, err := token.ValidateAccessToken(accTokenInfo.Token)
+	require.NoError(t.T(), err)
+	require.Equal(t.T(), []string{entity.ScopeUsersMeEmail}, authClaims.Scopes)
+	require.Empty(t.T(), authClaims.SessionID)
+}
+
+func (t *tokenSuite) TestCreateTokensEmailNotVerifiedAudience() {
+	config := token.GetDefaultConfig()
+	config.Audiences = []string{"service-a"}
+	token.SetConfig(config)
+	defer token.SetConfig(token.GetDefaultConfig())
+
+	t.mockNoGroups()
+	passwdHash, err := hashing.GetPasswdHash(test.UserPasswdCorrect)
+	require.NoError(t.T(), err)
+
+	t.userInfoRepo.On("GetByLoginID", context.Background(), test.TenantIDCorrect, test.UserLoginIDCorrect).Return(t.userInfo, nil)
+	t.userSecretRepo.On("Get", context.Background(), test.UserIDCorrect).Return(&entity.UserSecret{
+		ID:        test.UserIDCorrect,
+		PasswdPHC: passwdHash,
+	}, nil)
+	t.roleRepo.On("Get", context.Background(), string(test.UserRoleCorrect)).Return(&test.RoleAdminCorrect, nil)
+
+	// Email verify token of login for another audience is valid for service-auth APIs
+	accTokenInfo, _, err := t.newEmailVerificationTokenService().CreateTokens(context.Background(), test.TenantIDCorrect,
+		test.UserLoginIDCorrect, test.UserPasswdCorrect, &entity.Session{}, "service-a")
+	require.Equal(t.T(), ErrEmailNotVerified, err)
+	authClaims, err := token.ValidateAccessToken(accTokenInfo.Token)
+	require.NoError(t.T(), err)
+	require.Equal(t.T(), []string{entity.ScopeUsersMeEmail}, authClaims.Scopes)
+}
+
+func (t *tokenSuite) TestCreateTokensEmailVerified() {
+	t.mockNoGroups()
+	passwdHash, err := hashing.GetPasswdHash(test.UserPasswdCorrect)
+	require.NoError(t.T(), err)
+
+	userInfo := *t.userInfo
+	userInfo.EmailVerified = true
+	t.userInfoRepo.On("GetByLoginID", context.Background(), test.TenantIDCorrect, test.UserLoginIDCorrect).Return(&userInfo, nil)
+	t.userSecretRepo.On("Get", context.Background(), test.UserIDCorrect).Return(&entity.UserSecret{
+		ID:        test.UserIDCorrect,
+		PasswdPHC: passwdHash,
+	}, nil)
+	t.roleRepo.On("Get", context.Background(), string(test.UserRoleCorrect)).Return(&test.RoleAdminCorrect, nil)
+	t.sessionRepo.On("Create", context.Background(), mock.Anything).Return(nil)
+
+	_, refTokenInfo, err := t.newEmailVerificationTokenService().CreateTokens(context.Background(), test.TenantIDCorrect,
+		test.UserLoginIDCorrect, test.UserPasswdCorrect, &entity.Session{}, "")
+	require.NoError(t.T(), err)
+	require.NotNil(t.T(), refTokenInfo)
+}
+
+func (t *tokenSuite) TestCreateTokensGroupRoles() {
+	passwdHash, err := hashing.GetPasswdHash(test.UserPasswdCorrect)
+	require.NoError(t.T(), err)
+
+	// User is a member of the group, which is a member of the parent group
+	parentGroup := test.GroupCorrect
+	parentGroup.ID = test.GroupIDCorrect2
+	parentGroup.Roles = entity.StrList{string(entity.UserRoleTenantAdmin)}
+	t.groupMemberRepo.On("ListByMemberIDs", context.Background(), []uuid.EntityUUID{test.UserIDCorrect}).
+		Return([]entity.GroupMember{test.GroupMemberUserCorrect}, nil)
+	t.groupMemberRepo.On("ListByMemberIDs", context.Background(), []uuid.EntityUUID{test.GroupIDCorrect}).
+		Return([]entity.GroupMember{test.GroupMemberGroupCorrect}, nil)
+	t.groupMemberRepo.On("ListByMemberIDs", context.Background(), []uuid.EntityUUID{test.GroupIDCorrect2}).
+		Return([]entity.GroupMember{}, nil)
+	t.groupRepo.On("ListByIDs", context.Background(), test.TenantIDCorrect, []uuid.EntityUUID{test.GroupIDCorrect, test.GroupIDCorrect2}).
+		Return([]entity.Group{test.GroupCorrect, parentGroup}, nil)
+
+	// Scopes are allowed by the group's role, not by the user's role
+	t.userInfoRepo.On("GetByLoginID", context.Background(), test.TenantIDCorrect, test.UserLoginIDCorrect).Return(t.userInfo, nil)
+	t.userSecretRepo.On("Get", context.Background(), test.UserIDCorrect).Return(&entity.UserSecret{
+		ID:        test.UserIDCorrect,
+		PasswdPHC: passwdHash,
+		CreatedAt: time.Now(),
+	}, nil)
+	t.roleRepo.On("Get", context.Background(), string(test.UserRoleCorrect)).Return(&test.RoleCorrect, nil)
+	t.roleRepo.On("Get", context.Background(), test.RoleNameCorrect).Return(&test.RoleAdminCorrect, nil)
+	t.roleRepo.On("Get", context.Background(), string(entity.UserRoleTenantAdmin)).Return(&test.RoleCorrect, nil)
+	t.sessionRepo.On("Create", context.Background(), mock.Anything).Return(nil)
+
+	accTokenInfo, _, err := t.tokenService.CreateTokens(context.Background(), test.TenantIDCorrect, test.UserLoginIDCorrect, test.UserPasswdCorrect,
+		&entity.Session{Scopes: test.SessionScopesCorrect}, "")
+	require.NoError(t.T(), err)
+
+	authClaims, err := token.ValidateAccessToken(accTokenInfo.Token)
+	require.NoError(t.T(), err)
+	require.Equal(t.T(), test.UserRoleCorrect, authClaims.UserRole)
+	require.Equal(t.T(), []entity.UserRole{test.UserRoleCorrect, entity.UserRole(test.RoleNameCorrect), entity.UserRoleTenantAdmin}, authClaims.Roles)
+}
+
+func (t *tokenSuite) TestCreateTokensAudienceNotAllowed() {
+	_, _, err := t.tokenService.CreateTokens(context.Background(), test.TenantIDCorrect, test.UserLoginIDCorrect, test.UserPasswdCorrect,
+		&entity.Session{}, "unknown")
+	require.Equal(t.T(), ErrTokenAudienceNotAllowed, err)
+}
+
+func (t *tokenSuite) TestCreateTokensWrongPassword() {
+	passwdHash, err := hashing.GetPasswdHash(test.UserPasswdCorrect)
+	require.NoError(t.T(), err)
+
+	t.userInfoRepo.On("GetByLoginID", context.Background(), test.TenantIDCorrect, test.UserLoginIDCorrect).Return(t.userInfo, nil)
+	t.userSecretRepo.On("Get", context.Background(), test.UserIDCorrect).Return(&entity.UserSecret{
+		ID:        test.UserIDCorrect,
+		PasswdPHC: passwdHash,
+	}, nil)
+
+	_, _, err = t.tokenService.CreateTokens(context.Background(), test.TenantIDCorrect, test.UserLoginIDCorrect, test.UserPasswdShort, &entity.Session{}, "")
+	require.Equal(t.T(), ErrUnauthorized, err)
+	t.sessionRepo.AssertNotCalled(t.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (t *tokenSuite) TestCreateTokensLoginLocked() {
+	t.loginLockRepo.On("GetBySubject", context.Background(), entity.LoginLockTypeLoginID, test.TenantIDCorrect, test.UserLoginIDCorrect).
+		Return(&entity.LoginLock{ID: test.LoginLockIDCorrect, Failures: 3, LockedUntil: time.Now().Add(time.Minute)}, nil)
+
+	_, _, err := t.newLoginLockTokenService().CreateTokens(context.Background(), test.TenantIDCorrect, test.UserLoginIDCorrect,
+		test.UserPasswdCorrect, &entity.Session{IP: test.LoginLockIPCorrect}, "")
+	require.Equal(t.T(), ErrLoginLocked, err)
+	t.userInfoRepo.AssertNotCalled(t.T(), "GetByLoginID", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (t *tokenSuite) TestCreateTokensIPLocked() {
+	t.loginLockRepo.On("GetBySubject", context.Background(), entity.LoginLockTypeLoginID, test.TenantIDCorrect, test.UserLoginIDCorrect).
+		Return(nil, repo.ErrNotFound)
+	t.loginLockRepo.On("GetBySubject", context.Background(), entity.LoginLockTypeIP, "", test.LoginLockIPCorrect).
+		Return(&entity.LoginLock{ID: test.LoginLockIDCorrect, Failures: 10, LockedUntil: time.Now().Add(time.Minute)}, nil)
+
+	_, _, err := t.newLoginLockTokenService().CreateTokens(context.Background(), test.TenantIDCorrect, test.UserLoginIDCorrect,
+		test.UserPasswdCorrect, &entity.Session{IP: test.LoginLockIPCorrect}, "")
+	require.Equal(t.T(), ErrLoginLocked, err)
+	t.userInfoRepo.AssertNotCalled(t.T(), "GetByLoginID", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (t *tokenSuite) TestCreateTokensWrongPasswdLocksLoginID() {
+	passwdHash, err := hashing.GetPasswdHash(test.UserPasswdCorrect)
+	require.NoError(t.T(), err)
+
+	t.loginLockRepo.On("GetBySubject", context.Background(), mock.Anything, mock.Anything, mock.Anything).Return(nil, repo.ErrNotFound)
+	t.userInfoRepo.On("GetByLoginID", context.Background(), test.TenantIDCorrect, test.UserLoginIDCorrect).Return(t.userInfo, nil)
+	t.userSecretRepo.On("Get", context.Background(), test.UserIDCorrect).Return(&entity.UserSecret{
+		ID:        test.UserIDCorrect,
+		PasswdPHC: passwdHash,
+	}, nil)
+
+	// The login ID reaches the threshold with the 4th failure, and the IP doesn't
+	var increasedIP *entity.LoginLock
+	t.loginLockRepo.On("IncreaseFailures", context.Background(), mock.MatchedBy(func(l *entity.LoginLock) bool {
+		return l.Type == entity.LoginLockTypeLoginID
+	}), mock.Anything).Return(&entity.LoginLock{ID: test.LoginLockIDCorrect, Failures: 4}, nil)
+	t.loginLockRepo.On("IncreaseFailures", context.Background(), mock.MatchedBy(func(l *entity.LoginLock) bool {
+		return l.Type == entity.LoginLockTypeIP
+	}), mock.Anything).Return(&entity.LoginLock{ID: test.LoginLockIDWrong, Failures: 4}, nil).Run(func(args mock.Arguments) {
+		increasedIP = args.Get(1).(*entity.LoginLock)
+	})
+	var lockedUntil time.Time
+	t.loginLockRepo.On("Lock", context.Background(), test.LoginLockIDCorrect, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		lockedUntil = args.Get(2).(time.Time)
+	})
+
+	_, _, err = t.newLoginLockTokenService().CreateTokens(context.Background(), test.TenantIDCorrect, test.UserLoginIDCorrect,
+		test.UserPasswdCorrect+"wrong", &entity.Session{IP: test.LoginLockIPCorrect}, "")
+	require.Equal(t.T(), ErrUnauthorized, err)
+	require.Equal(t.T(), test.LoginLockIPCorrect, increasedIP.Subject)
+	require.Empty(t.T(), increasedIP.TenantID)
+	t.loginLockRepo.AssertNumberOfCalls(t.T(), "Lock", 1)
+
+	// The lock duration is doubled once after the threshold
+	require.WithinDuration(t.T(), time.Now().Add(2*testLoginLockPolicy.Duration), lockedUntil, time.Second)
+}
+
+func (t *tokenSuite) TestCreateTokensNotExistLoginIDIncreasesFailures() {
+	t.loginLockRepo.On("GetBySubject", context.Background(), mock.Anything, mock.Anything, mock.Anything).Return(nil, repo.ErrNotFound)
+	t.userInfoRepo.On("GetByLoginID", context.Background(), test.TenantIDCorrect, test.UserLoginIDCorrect).Return(nil, repo.ErrNotFound)
+	t.loginLockRepo.On("IncreaseFailures", context.Background(), mock.Anything, mock.Anything).
+		Return(&entity.LoginLock{ID: test.LoginLockIDCorrect, Failures: 1}, nil)
+
+	_, _, err := t.newLoginLockTokenService().CreateTokens(context.Background(), test.TenantIDCorrect, test.UserLoginIDCorrect,
+		test.UserPasswdCorrect, &entity.Session{IP: test.LoginLockIPCorrect}, "")
+	require.Equal(t.T(), ErrRepoNotFound, err)
+	t.loginLockRepo.AssertNumberOfCalls(t.T(), "IncreaseFailures", 2)
+	t.loginLockRepo.AssertNotCalled(t.T(), "Lock", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (t *tokenSuite) TestCreateTokensSuccessResetsLoginFailures() {
+	t.mockNoGroups()
+	passwdHash, err := hashing.GetPasswdHash(test.UserPasswdCorrect)
+	require.NoError(t.T(), err)
+
+	t.loginLockRepo.On("GetBySubject", context.Background(), entity.LoginLockTypeLoginID, test.TenantIDCorrect, test.UserLoginIDCorrect).
+		Return(&entity.LoginLock{ID: test.LoginLockIDCorrect, Failures: 2}, nil)
+	t.loginLockRepo.On("GetBySubject", context.Background(), entity.LoginLockTypeIP, "", test.LoginLockIPCorrect).
+		Return(nil, repo.ErrNotFound)
+	t.loginLockRepo.On("DeleteBySubject", context.Background(), entity.LoginLockTypeLoginID, test.TenantIDCorrect, test.UserLoginIDCorrect).
+		Return(nil)
+	t.userInfoRepo.On("GetByLoginID", context.Background(), test.TenantIDCorrect, test.UserLoginIDCorrect).Return(t.userInfo, nil)
+	t.userSecretRepo.On("Get", context.Background(), test.UserIDCorrect).Return(&entity.UserSecret{
+		ID:        test.UserIDCorrect,
+		PasswdPHC: passwdHash,
+	}, nil)
+	t.roleRepo.On("Get", context.Background(), string(test.UserRoleCorrect)).Return(&test.RoleAdminCorrect, nil)
+	t.sessionRepo.On("Create", context.Background(), mock.Anything).Return(nil)
+
+	_, _, err = t.newLoginLockTokenService().CreateTokens(context.Background(), test.TenantIDCorrect, test.UserLoginIDCorrect,
+		test.UserPasswdCorrect, &entity.Session{IP: test.LoginLockIPCorrect}, "")
+	require.NoError(t.T(), err)
+	t.loginLockRepo.AssertCalled(t.T(), "DeleteBySubject", context.Background(), entity.LoginLockTypeLoginID, test.TenantIDCorrect,
+		test.UserLoginIDCorrect)
+	t.loginLockRepo.AssertNotCalled(t.T(), "DeleteBySubject", mock.Anything, entity.LoginLockTypeIP, mock.Anything, mock.Anything)
+}
+
+// Mock the user secret having the password and the enabled TOTP
+func (t *tokenSuite) mockTOTPUserSecret(lastStep int64) {
+	passwdHash, err := hashing.GetPasswdHash(test.UserPasswdCorrect)
+	require.NoError(t.T(), err)
+	totpSecret, err := cipher.Encrypt([]byte(test.MFASecretCorrect), test.MFATOTPSecretCorrect)
+	require.NoError(t.T(), err)
+
+	enabledAt := time.Now()
+	t.userSecretRepo.On("Get", context.Background(), test.UserIDCorrect).Return(&entity.UserSecret{
+		ID:            test.UserIDCorrect,
+		PasswdPHC:     passwdHash,
+		TOTPSecret:    totpSecret,
+		TOTPEnabledAt: &enabledAt,
+		TOTPLastStep:  lastStep,
+	}, nil)
+}
+
+// Get a MFA challenge token of the user
+func (t *tokenSuite) getMFAChallengeToken() string {
+	mfaTokenInfo, err := createMFAChallengeToken(t.userInfo, &entity.Session{Scopes: []string{entity.ScopeUsersMeRead}}, "")
+	require.NoError(t.T(), err)
+	return mfaTokenInfo.Token
+}
+
+func (t *tokenSuite) TestCreateTokensMFARequired() {
+	t.mockTOTPUserSecret(0)
+	t.userInfoRepo.On("GetByLoginID", context.Background(), test.TenantIDCorrect, test.UserLoginIDCorrect).Return(t.userInfo, nil)
+
+	mfaTokenInfo, refTokenInfo, err := t.tokenService.CreateTokens(context.Background(), test.TenantIDCorrect, test.UserLoginIDCorrect,
+		test.UserPasswdCorrect, &entity.Session{Scopes: []string{entity.ScopeUsersMeRead}}, "")
+	require.Equal(t.T(), ErrMFARequired, err)
+	require.Nil(t.T(), refTokenInfo)
+	t.sessionRepo.AssertNotCalled(t.T(), "Create", mock.Anything, mock.Anything)
+
+	// Challenge token isn't an access token
+	_, err = token.ValidateAccessToken(mfaTokenInfo.Token)
+	require.Error(t.T(), err)
+	authClaims, err := token.ValidateMFAChallengeToken(mfaTokenInfo.Token)
+	require.NoError(t.T(), err)
+	require.Equal(t.T(), test.UserIDCorrect.String(), authClaims.UserID)
+	require.Equal(t.T(), []string{entity.ScopeUsersMeRead}, authClaims.Scopes)
+}
+
+func (t *tokenSuite) TestCreateMFATokensTOTP() {
+	t.mockNoGroups()
+	t.mockTOTPUserSecret(0)
+	t.userInfoRepo.On("Get", context.Background(), test.TenantIDCorrect, test.UserIDCorrect).Return(t.userInfo, nil)
+	t.roleRepo.On("Get", context.Background(), string(test.UserRoleCorrect)).Return(&test.RoleAdminCorrect, nil)
+	t.userSecretRepo.On("UpdateTOTPLastStep", context.Background(), test.UserIDCorrect, totp.GetStep(time.Now())).Return(nil)
+	var createdSession *entity.Session
+	t.sessionRepo.On("Create", context.Background(), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		createdSession = args.Get(1).(*entity.Session)
+	})
+
+	accTokenInfo, _, err := t.tokenService.CreateMFATokens(context.Background(), t.getMFAChallengeToken(),
+		totp.GetCode(test.MFATOTPSecretCorrect, time.Now()), &entity.Session{DeviceName: test.SessionDeviceNameCorrect})
+	require.NoError(t.T(), err)
+	require.Equal(t.T(), test.SessionDeviceNameCorrect, createdSession.DeviceName)
+
+	// Scopes of the login are kept
+	authClaims, err := token.ValidateAccessToken(accTokenInfo.Token)
+	require.NoError(t.T(), err)
+	require.Equal(t.T(), []string{entity.ScopeUsersMeRead}, authClaims.Scopes)
+}
+
+func (t *tokenSuite) TestCreateMFATokensRecoveryCode() {
+	t.mockNoGroups()
+	t.mockTOTPUserSecret(0)
+	t.userInfoRepo.On("Get", context.Background(), test.TenantIDCorrect, test.UserIDCorrect).Return(t.userInfo, nil)
+	t.roleRepo.On("Get", context.Background(), string(test.UserRoleCorrect)).Return(&test.RoleAdminCorrect, nil)
+	t.mfaRecoveryCodeRepo.On("DeleteByCodeHash", context.Background(), test.UserIDCorrect,
+		getMFARecoveryCodeHash(test.UserIDCorrect, test.MFARecoveryCodeCorrect)).Return(nil)
+	t.sessionRepo.On("Create", context.Background(), mock.Anything).Return(nil)
+
+	_, _, err := t.tokenService.CreateMFATokens(context.Background(), t.getMFAChallengeToken(), strings.ToUpper(test.MFARecoveryCodeCorrect),
+		&entity.Session{})
+	require.NoError(t.T(), err)
+	t.userSecretRepo.AssertNotCalled(t.T(), "UpdateTOTPLastStep", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (t *tokenSuite) TestCreateMFATokensCodeUsed() {
+	// Code of the current time step is already used, and the failure is counted
+	t.mockTOTPUserSecret(totp.GetStep(time.Now()))
+	t.loginLockRepo.On("GetBySubject", context.Background(), mock.Anything, mock.Anything, mock.Anything).Return(nil, repo.ErrNotFound)
+	t.userInfoRepo.On("Get", context.Background(), test.TenantIDCorrect, test.UserIDCorrect).Return(t.userInfo, nil)
+	t.loginLockRepo.On("IncreaseFailures", context.Background(), mock.Anything, mock.Anything).
+		Return(&entity.LoginLock{ID: test.LoginLockIDCorrect, Failures: 1}, nil)
+
+	_, _, err := t.newLoginLockTokenService().CreateMFATokens(context.Background(), t.getMFAChallengeToken(),
+		totp.GetCode(test.MFATOTPSecretCorrect, time.Now()), &entity.Session{IP: test.LoginLockIPCorrect})
+	require.Equal(t.T(), ErrMFACodeWrong, err)
+	t.loginLockRepo.AssertNumberOfCalls(t.T(), "IncreaseFailures", 2)
+	t.sessionRepo.AssertNotCalled(t.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (t *tokenSuite) TestCreateMFATokensRecoveryCodeWrong() {
+	t.mockTOTPUserSecret(0)
+	t.userInfoRepo.On("Get", context.Background(), test.TenantIDCorrect, test.UserIDCorrect).Return(t.userInfo, nil)
+	t.mfaRecoveryCodeRepo.On("DeleteByCodeHash", context.Background(), test.UserIDCorrect, mock.Anything).Return(repo.ErrNotFound)
+
+	_, _, err := t.tokenService.CreateMFATokens(context.Background(), t.getMFAChallengeToken(), test.MFARecoveryCodeCorrect,
+		&entity.Session{})
+	require.Equal(t.T(), ErrMFACodeWrong, err)
+}
+
+func (t *tokenSuite) TestCreateMFATokensWrongToken() {
+	// Refresh tokens aren't MFA challenge tokens
+	_, _, err := t.tokenService.CreateMFATokens(context.Background(), t.refreshToken, "123456", &entity.Session{})
+	require.Equal(t.T(), ErrUnauthorized, err)
+}
+
+func (t *tokenSuite) TestCreateTokensPasswdless() {
+	t.userInfoRepo.On("GetByLoginID", context.Background(), test.TenantIDCorrect, test.UserLoginIDCorrect).Return(t.userInfo, nil)
+	t.userSecretRepo.On("Get", context.Background(), test.UserIDCorrect).Return(&entity.UserSecret{ID: test.UserIDCorrect}, nil)
+
+	_, _, err := t.tokenService.CreateTokens(context.Background(), test.TenantIDCorrect, test.UserLoginIDCorrect, "",
+		&entity.Session{}, "")
+	require.Equal(t.T(), ErrUnauthorized, err)
+	t.sessionRepo.AssertNotCalled(t.T(), "Create", mock.Anything, mock.Anything)
+}
+
+// Mock the login challenge and the passkey of the authenticator
+func (t *tokenSuite) mockPasskeyLogin(authenticator *testPasskeyAuthenticator, signCount uint32) {
+	mockUseWebAuthnChallenge(&t.dbTx, &t.webAuthnChallengeRepo, &entity.WebAuthnChallenge{
+		ID:        test.PasskeyChallengeIDCorrect,
+		Type:      entity.WebAuthnChallengeTypeLogin,
+		TenantID:  test.TenantIDCorrect,
+		Challenge: test.PasskeyChallengeCorrect,
+		ExpiresAt: time.Now().Add(time.Minute),
+	})
+	t.webAuthnCredentialRepo.On("GetByCredentialID", context.Background(), test.PasskeyCredentialIDCorrect).Return(
+		&entity.WebAuthnCredential{
+			ID:           test.PasskeyIDCorrect,
+			UserID:       test.UserIDCorrect,
+			CredentialID: test.PasskeyCredentialIDCorrect,
+			PublicKey:    authenticator.getPublicKey(),
+			SignCount:    signCount,
+		}, nil)
+	t.userInfoRepo.On("Get", context.Background(), test.TenantIDCorrect, test.UserIDCorrect).Return(t.userInfo, nil)
+}
+
+func (t *tokenSuite) TestBeginPasskeyLoginSuccess() {
+	var createdChallenge *entity.WebAuthnChallenge
+	t.webAuthnChallengeRepo.On("Create", context.Background(), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		createdChallenge = args.Get(1).(*entity.WebAuthnChallenge)
+	})
+
+	login, err := t.tokenService.BeginPasskeyLogin(context.Background(), test.TenantIDCorrect)
+	require.NoError(t.T(), err)
+	require.Equal(t.T(), createdChallenge.ID, login.ChallengeID)
+	require.Equal(t.T(), entity.WebAuthnChallengeTypeLogin, createdChallenge.Type)
+	require.Equal(t.T(), test.TenantIDCorrect, createdChallenge.TenantID)
+	require.Equal(t.T(), webauthn.EncodeBase64URL(createdChallenge.Challenge), login.Options.Challenge)
+	require.Equal(t.T(), test.PasskeyRPIDCorrect, login.Options.RPID)
+	require.Empty(t.T(), login.Options.AllowCredentials)
+}
+
+func (t *tokenSuite) TestCreatePasskeyTokensSuccess() {
+	t.mockNoGroups()
+	authenticator := newTestPasskeyAuthenticator(t.T())
+	t.mockPasskeyLogin(authenticator, 1)
+	t.webAuthnCredentialRepo.On("UpdateSignCount", context.Background(), test.PasskeyIDCorrect, uint32(2), mock.Anything).Return(nil)
+	t.roleRepo.On("Get", context.Background(), string(test.UserRoleCorrect)).Return(&test.RoleAdminCorrect, nil)
+	t.sessionRepo.On("Create", context.Background(), mock.Anything).Return(nil)
+
+	_, refTokenInfo, err := t.tokenService.CreatePasskeyTokens(context.Background(), test.TenantIDCorrect, test.PasskeyChallengeIDCorrect,
+		authenticator.getAssertion(t.T(), test.PasskeyChallengeCorrect, 2), &entity.Session{IP: test.SessionIPCorrect}, "")
+	require.NoError(t.T(), err)
+	t.webAuthnCredentialRepo.AssertCalled(t.T(), "UpdateSignCount", context.Background(), test.PasskeyIDCorrect, uint32(2), mock.Anything)
+
+	authClaims, err := token.ValidateRefreshToken(refTokenInfo.Token)
+	require.NoError(t.T(), err)
+	require.Equal(t.T(), test.UserIDCorrect.String(), authClaims.UserID)
+}
+
+func (t *tokenSuite) TestCreatePasskeyTokensSignCountNotIncreased() {
+	authenticator := newTestPasskeyAuthenticator(t.T())
+	t.mockPasskeyLogin(authenticator, 2)
+
+	_, _, err := t.tokenService.CreatePasskeyTokens(context.Background(), test.TenantIDCorrect, test.PasskeyChallengeIDCorrect,
+		authenticator.getAssertion(t.T(), test.PasskeyChallengeCorrect, 2), &entity.Session{IP: test.SessionIPCorrect}, "")
+	require.Equal(t.T(), ErrUnauthorized, err)
+	t.webAuthnCredentialRepo.AssertNotCalled(t.T(), "UpdateSignCount", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	t.sessionRepo.AssertNotCalled(t.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (t *tokenSuite) TestCreatePasskeyTokensOtherAuthenticator() {
+	t.mockPasskeyLogin(newTestPasskeyAuthenticator(t.T()), 0)
+
+	_, _, err := t.tokenService.CreatePasskeyTokens(context.Background(), test.TenantIDCorrect, test.PasskeyChallengeIDCorrect,
+		newTestPasskeyAuthenticator(t.T()).getAssertion(t.T(), test.PasskeyChallengeCorrect, 0), &entity.Session{IP: test.SessionIPCorrect}, "")
+	require.Equal(t.T(), ErrUnauthorized, err)
+	t.sessionRepo.AssertNotCalled(t.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (t *tokenSuite) TestCreatePasskeyTokensWrongUserHandle() {
+	authenticator := newTestPasskeyAuthenticator(t.T())
+	t.mockPasskeyLogin(authenticator, 0)
+	assertion := authenticator.getAssertion(t.T(), test.PasskeyChallengeCorrect, 0)
+	assertion.UserHandle = test.UserIDCorrect2.Bytes()
+
+	_, _, err := t.tokenService.CreatePasskeyTokens(context.Background(), test.TenantIDCorrect, test.PasskeyChallengeIDCorrect,
+		assertion, &entity.Session{IP: test.SessionIPCorrect}, "")
+	require.Equal(t.T(), ErrUnauthorized, err)
+	t.userInfoRepo.AssertNotCalled(t.T(), "Get", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (t *tokenSuite) TestCreatePasskeyTokensNotExistPasskey() {
+	authenticator := newTestPasskeyAuthenticator(t.T())
+	mockUseWebAuthnChallenge(&t.dbTx, &t.webAuthnChallengeRepo, &entity.WebAuthnChallenge{
+		ID:        test.PasskeyChallengeIDCorrect,
+		Type:      entity.WebAuthnChallengeTypeLogin,
+		TenantID:  test.TenantIDCorrect,
+		Challenge: test.PasskeyChallengeCorrect,
+		ExpiresAt: time.Now().Add(time.Minute),
+	})
+	t.webAuthnCredentialRepo.On("GetByCredentialID", context.Background(), test.PasskeyCredentialIDCorrect).Return(nil, repo.ErrNotFound)
+
+	_, _, err := t.tokenService.CreatePasskeyTokens(context.Background(), test.TenantIDCorrect, test.PasskeyChallengeIDCorrect,
+		authenticator.getAssertion(t.T(), test.PasskeyChallengeCorrect, 0), &entity.Session{IP: test.SessionIPCorrect}, "")
+	require.Equal(t.T(), ErrUnauthorized, err)
+}
+
+func (t *tokenSuite) TestCreatePasskeyTokensRegistrationChallenge() {
+	authenticator := newTestPasskeyAuthenticator(t.T())
+	mockUseWebAuthnChallenge(&t.dbTx, &t.webAuthnChallengeRepo, &entity.WebAuthnChallenge{
+		ID:        test.PasskeyChallengeIDCorrect,
+		Type:      entity.WebAuthnChallengeTypeRegistration,
+		TenantID:  test.TenantIDCorrect,
+		UserID:    test.UserIDCorrect,
+		Challenge: test.PasskeyChallengeCorrect,
+		ExpiresAt: time.Now().Add(time.Minute),
+	})
+
+	_, _, err := t.tokenService.CreatePasskeyTokens(context.Background(), test.TenantIDCorrect, test.PasskeyChallengeIDCorrect,
+		authenticator.getAssertion(t.T(), test.PasskeyChallengeCorrect, 0), &entity.Session{IP: test.SessionIPCorrect}, "")
+	require.Equal(t.T(), ErrUnauthorized, err)
+	t.webAuthnCredentialRepo.AssertNotCalled(t.T(), "GetByCredentialID", mock.Anything, mock.Anything)
+}
+
+func (t *tokenSuite) TestRefreshTokenSuccess() {
+	t.mockNoGroups()
+	var updatedSession *entity.Session
+	t.dbTx.On("Begin").Return(&t.dbTx, nil)
+	t.sessionRepo.On("WithTx", mock.Anything).Return(&t.sessionRepo)
+	t.sessionRepo.On("GetForUpdate", context.Background(), t.session.ID).Return(t.session, nil)
+	t.userInfoRepo.On("Get", context.Background(), test.TenantIDCorrect, test.UserIDCorrect).Return(t.userInfo, nil)
+	t.mockRefreshUserSecret(time.Now())
+	t.sessionRepo.On("Update", context.Background(), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		updatedSession = args.Get(1).(*entity.Session)
+	})
+	t.dbTx.On("Commit").Return(nil)
+
+	accTokenInfo, refTokenInfo, err := t.tokenService.RefreshToken(context.Background(), t.refreshToken)
+	require.NoError(t.T(), err)
+	require.NotEmpty(t.T(), accTokenInfo.Token)
+	require.NotEqual(t.T(), t.refreshToken, refTokenInfo.Token)
+	require.True(t.T(), hashing.ValidateStr(refTokenInfo.Token, updatedSession.RefreshTokenHash, updatedSession.RefreshTokenSalt))
+}
+
+// Mock the user secret and the role of the user whose password is changed at the time
+func (t *tokenSuite) mockRefreshUserSecret(passwdChangedAt time.Time) {
+	t.userSecretRepo.On("Get", context.Background(), test.UserIDCorrect).Return(&entity.UserSecret{
+		ID:              test.UserIDCorrect,
+		PasswdPHC:       "phc",
+		PasswdChangedAt: &passwdChangedAt,
+	}, nil)
+	t.roleRepo.On("Get", context.Background(), string(test.UserRoleCorrect)).Return(&test.RoleCorrect, nil)
+}
+
+func (t *tokenSuite) TestRefreshTokenPasswdExpired() {
+	t.mockNoGroups()
+	t.dbTx.On("Begin").Return(&t.dbTx, nil)
+	t.dbTx.On("Rollback").Return(nil)
+	t.sessionRepo.On("WithTx", mock.Anything).Return(&t.sessionRepo)
+	t.sessionRepo.On("GetForUpdate", context.Background(), t.session.ID).Return(t.session, nil)
+	t.userInfoRepo.On("Get", context.Background(), test.TenantIDCorrect, test.UserIDCorrect).Return(t.userInfo, nil)
+
+	// Password is expired after login
+	t.mockRefreshUserSecret(time.Now().AddDate(0, 0, -test.RolePasswdMaxAgeDaysCorrect-1))
+
+	accTokenInfo, refTokenInfo, err := t.tokenService.RefreshToken(context.Background(), t.refreshToken)
+	require.Equal(t.T(), ErrPasswdExpired, err)
+	require.Nil(t.T(), refTokenInfo)
+	t.sessionRepo.AssertNotCalled(t.T(), "Update", mock.Anything, mock.Anything)
+	t.dbTx.AssertCalled(t.T(), "Rollback")
+
+	authClaims, err := token.ValidateAccessToken(accTokenInfo.Token)
+	require.NoError(t.T(), err)
+	require.Equal(t.T(), []string{entity.ScopeUsersMePasswd}, authClaims.Scopes)
+	require.Empty(t.T(), authClaims.SessionID)
+}
+
+func (t *tokenSuite) TestRefreshTokenEmailNotVerified() {
+	t.mockNoGroups()
+	t.dbTx.On("Begin").Return(&t.dbTx, nil)
+	t.dbTx.On("Rollback").Return(nil)
+	t.sessionRepo.On("WithTx", mock.Anything).Return(&t.sessionRepo)
+	t.sessionRepo.On("GetForUpdate", context.Background(), t.session.ID).Return(t.session, nil)
+	t.userInfoRepo.On("Get", context.Background(), test.TenantIDCorrect, test.UserIDCorrect).Return(t.userInfo, nil)
+	t.mockRefreshUserSecret(time.Now())
+
+	accTokenInfo, refTokenInfo, err := t.newEmailVerificationTokenService().RefreshToken(context.Background(), t.refreshToken)
+	require.Equal(t.T(), ErrEmailNotVerified, err)
+	require.Nil(t.T(), refTokenInfo)
+	t.sessionRepo.AssertNotCalled(t.T(), "Update", mock.Anything, mock.Anything)
+	t.dbTx.AssertCalled(t.T(), "Rollback")
+
+	authClaims, err := token.ValidateAccessToken(accTokenInfo.Token)
+	require.NoError(t.T(), err)
+	require.Equal(t.T(), []string{entity.ScopeUsersMeEmail}, authClaims.Scopes)
+}
+
+func (t *tokenSuite) TestRefreshTokenReused() {
+	// Old refresh token is rotated already
+	oldRefreshToken := t.refreshToken
+	_, newRefTokenInfo, err := createTokens(t.userInfo, nil, t.session, "")
+	require.NoError(t.T(), err)
+	require.NoError(t.T(), setSessionRefreshToken(t.session, newRefTokenInfo))
+
+	t.dbTx.On("Begin").Return(&t.dbTx, nil)
+	t.sessionRepo.On("WithTx", mock.Anything).Return(&t.sessionRepo)
+	t.sessionRepo.On("GetForUpdate", context.Background(), t.session.ID).Return(t.session, nil)
+	t.sessionRepo.On("Delete", context.Background(), t.session.ID).Return(nil)
+	t.tokenRevocationRepo.On("WithTx", mock.Anything).Return(&t.tokenRevocationRepo)
+	t.tokenRevocationRepo.On("Create", context.Background(), mock.Anything).Return(nil)
+	t.dbTx.On("Commit").Return(nil)
+
+	_, _, err = t.tokenService.RefreshToken(context.Background(), oldRefreshToken)
+	require.Equal(t.T(), ErrUnauthorized, err)
+	t.sessionRepo.AssertCalled(t.T(), "Delete", context.Background(), t.session.ID)
+	t.tokenRevocationRepo.AssertCalled(t.T(), "Create", context.Background(), mock.Anything)
+}
+
+func (t *tokenSuite) TestRefreshTokenNoSession() {
+	t.dbTx.On("Begin").Return(&t.dbTx, nil)
+	t.sessionRepo.On("WithTx", mock.Anything).Return(&t.sessionRepo)
+	t.sessionRepo.On("GetForUpdate", context.Background(), t.session.ID).Return(nil, repo.ErrNotFound)
+	t.dbTx.On("Rollback").Return(nil)
+
+	_, _, err := t.tokenService.RefreshToken(context.Background(), t.refreshToken)
+	require.Equal(t.T(), ErrUnauthorized, err)
+}
+
+func (t *tokenSuite) TestRefreshTokenWrongToken() {
+	_, _, err := t.tokenService.RefreshToken(context.Background(), "wrong")
+	require.Equal(t.T(), ErrUnauthorized, err)
+}
+
+func (t *tokenSuite) TestRefreshClientTokenOtherClient() {
+	// Refresh token issued to a client can't be used by login's refresh or other clients
+	t.session.ClientID = test.OAuthClientIDCorrect.String()
+	_, refTokenInfo, err := createTokens(t.userInfo, nil, t.session, "")
+	require.NoError(t.T(), err)
+
+	_, _, err = t.tokenService.RefreshToken(context.Background(), refTokenInfo.Token)
+	require.Equal(t.T(), ErrUnauthorized, err)
+	_, _, err = t.tokenService.RefreshClientToken(context.Background(), refTokenInfo.Token, "other-client")
+	require.Equal(t.T(), ErrUnauthorized, err)
+	t.sessionRepo.AssertNotCalled(t.T(), "GetForUpdate", mock.Anything, mock.Anything)
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	opentracing "github.com/opentracing/opentracing-go"
 	"github.com/rs/zerolog/log"
@@ -10,7 +11,10 @@ import (
 	"github.com/ssup2ket/service-auth/internal/domain/entity"
 	"github.com/ssup2ket/service-auth/internal/domain/repo"
 	"github.com/ssup2ket/service-auth/pkg/auth/hashing"
+	"github.com/ssup2ket/service-auth/pkg/auth/passwd"
+	"github.com/ssup2ket/service-auth/pkg/auth/token"
 	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+	"github.com/ssup2ket/service-auth/pkg/mail"
 	"github.com/ssup2ket/service-auth/pkg/tracing"
 )
 
@@ -21,44 +25,122 @@ const (
 )
 
 type userOutboxPayload struct {
-	ID      string `json:"id"`
-	LoginID string `json:"loginId"`
-	Role    string `json:"role"`
+	ID       string `json:"id"`
+	TenantID string `json:"tenantId"`
+	LoginID  string `json:"loginId"`
+	Role     string `json:"role"`
 }
 
-// User Service
+// User service. Users are accessed only in the subject's tenant.
 type UserService interface {
-	ListUser(ctx context.Context, offset int, limit int) ([]entity.UserInfo, error)
-	CreateUser(ctx context.Context, userInfo *entity.UserInfo, passwd string) (*entity.UserInfo, error)
-	GetUser(ctx context.Context, userUUID uuid.EntityUUID) (*entity.UserInfo, error)
-	UpdateUser(ctx context.Context, userInfo *entity.UserInfo, passwd string) error
-	DeleteUser(ctx context.Context, userUUID uuid.EntityUUID) error
+	ListUser(ctx context.Context, tenantID string, offset int, limit int) ([]entity.UserInfo, error)
+	// Creating a user without subject is a sign up with the default role. A verification token is sent to the email.
+	CreateUser(ctx context.Context, subject *entity.Subject, userInfo *entity.UserInfo, passwd string) (*entity.UserInfo, error)
+	// Getting, updating and deleting a user are allowed only if the subject can access the user.
+	GetUser(ctx context.Context, subject *entity.Subject, userUUID uuid.EntityUUID) (*entity.UserInfo, error)
+	// A verification token is sent to the new email. A new password is a reset by an admin and revokes all sessions.
+	UpdateUser(ctx context.Context, subject *entity.Subject, userInfo *entity.UserInfo, passwd string) error
+	DeleteUser(ctx context.Context, subject *entity.Subject, userUUID uuid.EntityUUID) error
+	// New passwords can't be one of the last passwords of the password history. Other sessions are revoked.
+	UpdateUserPasswd(ctx context.Context, subject *entity.Subject, userUUID uuid.EntityUUID, passwd, newPasswd string) error
+	// ErrPasskeyRequired is returned if the user doesn't have a passkey to login without password.
+	RemoveUserPasswd(ctx context.Context, subject *entity.Subject, userUUID uuid.EntityUUID, passwd string) error
 }
 
 type UserServiceImp struct {
 	repoDBTx repo.DBTx
 
-	outBoxRepoPrimary       repo.OutboxRepo
-	userInfoRepoPrimary     repo.UserInfoRepo
-	userInfoRepoSecondary   repo.UserInfoRepo
-	userSecretRepoPrimary   repo.UserSecretRepo
-	userSecretRepoSecondary repo.UserSecretRepo
+	outBoxRepoPrimary        repo.OutboxRepo
+	userInfoRepoPrimary      repo.UserInfoRepo
+	userInfoRepoSecondary    repo.UserInfoRepo
+	userSecretRepoPrimary    repo.UserSecretRepo
+	userSecretRepoSecondary  repo.UserSecretRepo
+	passwdHistoryRepoPrimary repo.PasswdHistoryRepo
+	roleRepoPrimary          repo.RoleRepo
+	permissionRepoPrimary    repo.PermissionRepo
+	tenantRepoPrimary        repo.TenantRepo
+	groupRepoPrimary         repo.GroupRepo
+	groupMemberRepoPrimary   repo.GroupMemberRepo
+
+	mfaRecoveryCodeRepoPrimary    repo.MFARecoveryCodeRepo
+	webAuthnCredentialRepoPrimary repo.WebAuthnCredentialRepo
+
+	sessionRepoPrimary         repo.SessionRepo
+	tokenRevocationRepoPrimary repo.TokenRevocationRepo
+	revocationList             *token.RevocationList
+
+	passwdPolicy      passwd.Policy
+	passwdHistorySize int
+
+	emailVerificationRepoPrimary repo.EmailVerificationRepo
+	mailSender                   mail.Sender
+	emailVerificationPolicy      *EmailVerificationPolicy
 }
 
-func NewUserServiceImp(dbTx repo.DBTx, userOutBoxPrimary repo.OutboxRepo, userInfoPrimary, userInfoSecondary repo.UserInfoRepo,
-	userSecretPrimary, userSecretSecondary repo.UserSecretRepo) *UserServiceImp {
+// Repos of the user service
+type UserRepos struct {
+	OutboxPrimary        repo.OutboxRepo
+	UserInfoPrimary      repo.UserInfoRepo
+	UserInfoSecondary    repo.UserInfoRepo
+	UserSecretPrimary    repo.UserSecretRepo
+	UserSecretSecondary  repo.UserSecretRepo
+	PasswdHistoryPrimary repo.PasswdHistoryRepo
+	RolePrimary          repo.RoleRepo
+	PermissionPrimary    repo.PermissionRepo
+	TenantPrimary        repo.TenantRepo
+	GroupPrimary         repo.GroupRepo
+	GroupMemberPrimary   repo.GroupMemberRepo
+
+	MFARecoveryCodePrimary    repo.MFARecoveryCodeRepo
+	WebAuthnCredentialPrimary repo.WebAuthnCredentialRepo
+
+	SessionPrimary         repo.SessionRepo
+	TokenRevocationPrimary repo.TokenRevocationRepo
+
+	EmailVerificationPrimary repo.EmailVerificationRepo
+}
+
+// Policies of the user service. The password history size includes the current password.
+type UserPolicies struct {
+	Passwd            passwd.Policy
+	PasswdHistorySize int
+	EmailVerification *EmailVerificationPolicy
+}
+
+func NewUserServiceImp(dbTx repo.DBTx, repos UserRepos, revocationList *token.RevocationList, mailSender mail.Sender,
+	policies UserPolicies) *UserServiceImp {
 	return &UserServiceImp{
 		repoDBTx: dbTx,
 
-		outBoxRepoPrimary:       userOutBoxPrimary,
-		userInfoRepoPrimary:     userInfoPrimary,
-		userInfoRepoSecondary:   userInfoSecondary,
-		userSecretRepoPrimary:   userSecretPrimary,
-		userSecretRepoSecondary: userSecretSecondary,
+		outBoxRepoPrimary:        repos.OutboxPrimary,
+		userInfoRepoPrimary:      repos.UserInfoPrimary,
+		userInfoRepoSecondary:    repos.UserInfoSecondary,
+		userSecretRepoPrimary:    repos.UserSecretPrimary,
+		userSecretRepoSecondary:  repos.UserSecretSecondary,
+		passwdHistoryRepoPrimary: repos.PasswdHistoryPrimary,
+		roleRepoPrimary:          repos.RolePrimary,
+		permissionRepoPrimary:    repos.PermissionPrimary,
+		tenantRepoPrimary:        repos.TenantPrimary,
+		groupRepoPrimary:         repos.GroupPrimary,
+		groupMemberRepoPrimary:   repos.GroupMemberPrimary,
+
+		mfaRecoveryCodeRepoPrimary:    repos.MFARecoveryCodePrimary,
+		webAuthnCredentialRepoPrimary: repos.WebAuthnCredentialPrimary,
+
+		sessionRepoPrimary:         repos.SessionPrimary,
+		tokenRevocationRepoPrimary: repos.TokenRevocationPrimary,
+		revocationList:             revocationList,
+
+		passwdPolicy:      policies.Passwd,
+		passwdHistorySize: policies.PasswdHistorySize,
+
+		emailVerificationRepoPrimary: repos.EmailVerificationPrimary,
+		mailSender:                   mailSender,
+		emailVerificationPolicy:      policies.EmailVerification,
 	}
 }
 
-func (u *UserServiceImp) ListUser(ctx context.Context, offset int, limit int) ([]entity.UserInfo, error) {
+func (u *UserServiceImp) ListUser(ctx context.Context, tenantID string, offset int, limit int) ([]entity.UserInfo, error) {
 	var err error
 
 	// Set default limit
@@ -67,7 +149,7 @@ func (u *UserServiceImp) ListUser(ctx context.Context, offset int, limit int) ([
 	}
 
 	// List users
-	users, err := u.userInfoRepoSecondary.List(ctx, offset, limit)
+	users, err := u.userInfoRepoSecondary.List(ctx, tenantID, offset, limit)
 	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Msg("Failed to list user from DB")
 		return nil, getReturnErr(err)
@@ -75,8 +157,22 @@ func (u *UserServiceImp) ListUser(ctx context.Context, offset int, limit int) ([
 	return users, nil
 }
 
-func (u *UserServiceImp) CreateUser(ctx context.Context, userInfo *entity.UserInfo, passwd string) (*entity.UserInfo, error) {
+// Create a user in the user info's tenant. Empty tenant means the default tenant. A nil subject means an
+// unauthenticated sign up, which always creates a user with the default role.
+func (u *UserServiceImp) CreateUser(ctx context.Context, subject *entity.Subject, userInfo *entity.UserInfo, passwd string) (*entity.UserInfo, error) {
 	var err error
+	userInfo.TenantID = entity.GetTenantIDOrDefault(userInfo.TenantID)
+	if subject == nil {
+		userInfo.Role = entity.UserRoleUser
+	} else if err = checkTenantAccess(ctx, subject, userInfo.TenantID); err != nil {
+		return nil, err
+	}
+
+	// Check password policy
+	if err = u.passwdPolicy.Validate(passwd, userInfo.LoginID, userInfo.Email); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Password violates password policy")
+		return nil, err
+	}
 
 	// Begin transaction
 	tx, _ := u.repoDBTx.Begin()
@@ -91,6 +187,19 @@ func (u *UserServiceImp) CreateUser(ctx context.Context, userInfo *entity.UserIn
 		}
 	}()
 
+	// Check tenant and role exist, and check subject can grant the role
+	if err = checkTenantExist(ctx, u.tenantRepoPrimary, tx, userInfo.TenantID); err != nil {
+		return nil, err
+	}
+	if err = checkRoleExist(ctx, u.roleRepoPrimary, tx, string(userInfo.Role)); err != nil {
+		return nil, err
+	}
+	if subject != nil {
+		if err = checkRoleGrant(ctx, u.permissionRepoPrimary, subject, userInfo.Role); err != nil {
+			return nil, err
+		}
+	}
+
 	// Generate UUID to share to userInfo and userSecret
 	userUUID := uuid.NewV4()
 
@@ -102,15 +211,15 @@ func (u *UserServiceImp) CreateUser(ctx context.Context, userInfo *entity.UserIn
 	}
 
 	// Create user secret
-	hash, salt, err := hashing.GetStrHashAndSalt(passwd)
+	passwdHash, err := hashing.GetPasswdHash(passwd)
 	if err != nil {
-		log.Ctx(ctx).Error().Err(err).Msg("Failed to create password hash and salt")
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to create password hash")
 		return nil, err
 	}
 	userSecret := entity.UserSecret{
-		ID:         userUUID,
-		PasswdHash: hash,
-		PasswdSalt: salt,
+		ID:        userUUID,
+		TenantID:  userInfo.TenantID,
+		PasswdPHC: passwdHash,
 	}
 	if err = u.userSecretRepoPrimary.WithTx(tx).Create(ctx, &userSecret); err != nil {
 		log.Ctx(ctx).Error().Err(err).Msg("Failed to create user secret to DB")
@@ -119,9 +228,10 @@ func (u *UserServiceImp) CreateUser(ctx context.Context, userInfo *entity.UserIn
 
 	// Get user outbox payload
 	userOutboxPayload := userOutboxPayload{
-		ID:      userInfo.ID.String(),
-		LoginID: userInfo.LoginID,
-		Role:    string(userInfo.Role),
+		ID:       userInfo.ID.String(),
+		TenantID: userInfo.TenantID,
+		LoginID:  userInfo.LoginID,
+		Role:     string(userInfo.Role),
 	}
 	userOutboxPayloadJSON, err := json.Marshal(userOutboxPayload)
 	if err != nil {
@@ -157,14 +267,24 @@ func (u *UserServiceImp) CreateUser(ctx context.Context, userInfo *entity.UserIn
 		log.Ctx(ctx).Error().Err(err).Msg("Commit transaction error for creating user")
 		return nil, getReturnErr(err)
 	}
+
+	// Send email verification. Failure doesn't affect the new user, because the user can request it again.
+	if err := sendEmailVerification(ctx, u.emailVerificationRepoPrimary, u.mailSender, u.emailVerificationPolicy, userInfo); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("Failed to send email verification for created user")
+	}
 	return userInfo, nil
 }
 
-func (u *UserServiceImp) GetUser(ctx context.Context, userUUID uuid.EntityUUID) (*entity.UserInfo, error) {
+func (u *UserServiceImp) GetUser(ctx context.Context, subject *entity.Subject, userUUID uuid.EntityUUID) (*entity.UserInfo, error) {
 	var err error
 
+	// Check access
+	if err = checkUserAccess(ctx, subject, userUUID); err != nil {
+		return nil, err
+	}
+
 	// Get user info
-	userInfo, err := u.userInfoRepoSecondary.Get(ctx, userUUID)
+	userInfo, err := u.userInfoRepoSecondary.Get(ctx, subject.TenantID, userUUID)
 	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Msg("Failed to get user from DB")
 		return nil, getReturnErr(err)
@@ -172,9 +292,18 @@ func (u *UserServiceImp) GetUser(ctx context.Context, userUUID uuid.EntityUUID)
 	return userInfo, nil
 }
 
-func (u *UserServiceImp) UpdateUser(ctx context.Context, userInfo *entity.UserInfo, passwd string) error {
+func (u *UserServiceImp) UpdateUser(ctx context.Context, subject *entity.Subject, userInfo *entity.UserInfo, passwd string) error {
 	var err error
 
+	// Check access. Users change their own password only with the current password.
+	if err = checkUserAccess(ctx, subject, userInfo.ID); err != nil {
+		return err
+	}
+	if passwd != "" && subject.UserID == userInfo.ID.String() {
+		log.Ctx(ctx).Error().Msg("User can't change own password without the current password")
+		return ErrUnauthorized
+	}
+
 	// Begin transaction
 	tx, _ := u.repoDBTx.Begin()
 	defer func() {
@@ -188,12 +317,39 @@ func (u *UserServiceImp) UpdateUser(ctx context.Context, userInfo *entity.UserIn
 		}
 	}()
 
-	// Get user info
-	_, err = u.userInfoRepoPrimary.WithTx(tx).Get(ctx, userInfo.ID)
+	// Get user info in the subject's tenant
+	userInfo.TenantID = subject.TenantID
+	oldUserInfo, err := u.userInfoRepoPrimary.WithTx(tx).Get(ctx, userInfo.TenantID, userInfo.ID)
 	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Msg("Failed to get user from DB")
 		return getReturnErr(err)
 	}
+	if err = checkUserChange(ctx, u.groupRepoPrimary.WithTx(tx), u.groupMemberRepoPrimary.WithTx(tx), subject, oldUserInfo); err != nil {
+		return err
+	}
+
+	// Check password policy with the user's login ID and new email. Empty password means no password change
+	email := userInfo.Email
+	if email == "" {
+		email = oldUserInfo.Email
+	}
+	if passwd != "" {
+		if err = u.passwdPolicy.Validate(passwd, oldUserInfo.LoginID, email); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Password violates password policy")
+			return err
+		}
+	}
+
+	// Check role can be changed and exists
+	roleChanged := userInfo.Role != "" && userInfo.Role != oldUserInfo.Role
+	if roleChanged {
+		if err = checkRoleGrant(ctx, u.permissionRepoPrimary, subject, userInfo.Role); err != nil {
+			return err
+		}
+		if err = checkRoleExist(ctx, u.roleRepoPrimary, tx, string(userInfo.Role)); err != nil {
+			return err
+		}
+	}
 
 	// Update user info
 	if err = u.userInfoRepoPrimary.WithTx(tx).Update(ctx, userInfo); err != nil {
@@ -201,20 +357,44 @@ func (u *UserServiceImp) UpdateUser(ctx context.Context, userInfo *entity.UserIn
 		return getReturnErr(err)
 	}
 
-	// Update user secret
-	hash, salt, err := hashing.GetStrHashAndSalt(passwd)
-	if err != nil {
-		log.Ctx(ctx).Error().Err(err).Msg("Failed to create password hash and salt")
-		return getReturnErr(err)
+	// Reset email verification of the changed email, and tokens sent to the old email can't be used
+	emailChanged := userInfo.Email != "" && userInfo.Email != oldUserInfo.Email
+	if emailChanged {
+		if err = u.userInfoRepoPrimary.WithTx(tx).UpdateEmailVerified(ctx, userInfo.TenantID, userInfo.ID, nil); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to reset email verified from DB")
+			return getReturnErr(err)
+		}
+		if err = u.emailVerificationRepoPrimary.WithTx(tx).DeleteByUser(ctx, userInfo.ID); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to delete email verifications of user from DB")
+			return getReturnErr(err)
+		}
 	}
-	userSecret := entity.UserSecret{
-		ID:         userInfo.ID,
-		PasswdHash: hash,
-		PasswdSalt: salt,
+
+	// Change password
+	if passwd != "" {
+		var userSecret *entity.UserSecret
+		userSecret, err = u.userSecretRepoPrimary.WithTx(tx).Get(ctx, userInfo.ID)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to get user secret from DB")
+			return getReturnErr(err)
+		}
+		if err = u.changePasswd(ctx, tx, userSecret, passwd); err != nil {
+			return err
+		}
 	}
-	if err = u.userSecretRepoPrimary.WithTx(tx).Update(ctx, &userSecret); err != nil {
-		log.Ctx(ctx).Error().Err(err).Msg("Failed to create user secret to DB")
-		return getReturnErr(err)
+
+	// Revoke other sessions if the password is changed, which also revokes access tokens with the old role.
+	// Otherwise revoke access tokens with the old role. Refreshed tokens have the new role.
+	var tokenRevocation *entity.TokenRevocation
+	if passwd != "" {
+		if tokenRevocation, err = u.revokeOtherSessions(ctx, tx, subject, userInfo.ID); err != nil {
+			return getReturnErr(err)
+		}
+	} else if roleChanged {
+		tokenRevocation, err = createTokenRevocation(ctx, u.tokenRevocationRepoPrimary, tx, entity.TokenRevocationTypeUser, userInfo.ID.String())
+		if err != nil {
+			return getReturnErr(err)
+		}
 	}
 
 	// Commit transaction
@@ -222,12 +402,27 @@ func (u *UserServiceImp) UpdateUser(ctx context.Context, userInfo *entity.UserIn
 		log.Ctx(ctx).Error().Err(err).Msg("Commit transaction error for updating user")
 		return getReturnErr(err)
 	}
+	if tokenRevocation != nil {
+		addTokenRevocationToList(u.revocationList, tokenRevocation)
+	}
+
+	// Send email verification to the new email. Failure doesn't affect the update, because the user can request it again.
+	if emailChanged {
+		if err := sendEmailVerification(ctx, u.emailVerificationRepoPrimary, u.mailSender, u.emailVerificationPolicy, userInfo); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("Failed to send email verification for updated user")
+		}
+	}
 	return nil
 }
 
-func (u *UserServiceImp) DeleteUser(ctx context.Context, userUUID uuid.EntityUUID) error {
+func (u *UserServiceImp) DeleteUser(ctx context.Context, subject *entity.Subject, userUUID uuid.EntityUUID) error {
 	var err error
 
+	// Check access
+	if err = checkUserAccess(ctx, subject, userUUID); err != nil {
+		return err
+	}
+
 	// Begin transaction
 	tx, _ := u.repoDBTx.Begin()
 	defer func() {
@@ -241,15 +436,18 @@ func (u *UserServiceImp) DeleteUser(ctx context.Context, userUUID uuid.EntityUUI
 		}
 	}()
 
-	// Get user info
-	userInfo, err := u.userInfoRepoPrimary.WithTx(tx).Get(ctx, userUUID)
+	// Get user info in the subject's tenant
+	userInfo, err := u.userInfoRepoPrimary.WithTx(tx).Get(ctx, subject.TenantID, userUUID)
 	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Msg("Failed to get user info from DB")
 		return getReturnErr(err)
 	}
+	if err = checkUserChange(ctx, u.groupRepoPrimary.WithTx(tx), u.groupMemberRepoPrimary.WithTx(tx), subject, userInfo); err != nil {
+		return err
+	}
 
 	// Delete user info
-	if err := u.userInfoRepoPrimary.WithTx(tx).Delete(ctx, userUUID); err != nil {
+	if err := u.userInfoRepoPrimary.WithTx(tx).Delete(ctx, subject.TenantID, userUUID); err != nil {
 		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete user info from DB")
 		return getReturnErr(err)
 	}
@@ -260,11 +458,48 @@ func (u *UserServiceImp) DeleteUser(ctx context.Context, userUUID uuid.EntityUUI
 		return getReturnErr(err)
 	}
 
+	// Delete password histories of the user
+	if err = u.passwdHistoryRepoPrimary.WithTx(tx).DeleteByUser(ctx, userUUID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete password histories of user from DB")
+		return getReturnErr(err)
+	}
+
+	// Delete MFA recovery codes of the user
+	if err = u.mfaRecoveryCodeRepoPrimary.WithTx(tx).DeleteByUser(ctx, userUUID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete MFA recovery codes of user from DB")
+		return getReturnErr(err)
+	}
+
+	// Delete passkeys of the user
+	if err = u.webAuthnCredentialRepoPrimary.WithTx(tx).DeleteByUser(ctx, userUUID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete passkeys of user from DB")
+		return getReturnErr(err)
+	}
+
+	// Delete email verifications of the user
+	if err = u.emailVerificationRepoPrimary.WithTx(tx).DeleteByUser(ctx, userUUID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete email verifications of user from DB")
+		return getReturnErr(err)
+	}
+
+	// Delete group memberships of the user
+	if err = u.groupMemberRepoPrimary.WithTx(tx).DeleteByMember(ctx, userUUID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete group memberships of user from DB")
+		return getReturnErr(err)
+	}
+
+	// Revoke all access tokens of the user
+	tokenRevocation, err := createTokenRevocation(ctx, u.tokenRevocationRepoPrimary, tx, entity.TokenRevocationTypeUser, userUUID.String())
+	if err != nil {
+		return getReturnErr(err)
+	}
+
 	// Get user outbox payload
 	userOutboxPayload := userOutboxPayload{
-		ID:      userInfo.ID.String(),
-		LoginID: userInfo.LoginID,
-		Role:    string(userInfo.Role),
+		ID:       userInfo.ID.String(),
+		TenantID: userInfo.TenantID,
+		LoginID:  userInfo.LoginID,
+		Role:     string(userInfo.Role),
 	}
 	userOutboxPayloadJSON, err := json.Marshal(userOutboxPayload)
 	if err != nil {
@@ -300,5 +535,275 @@ func (u *UserServiceImp) DeleteUser(ctx context.Context, userUUID uuid.EntityUUI
 		log.Ctx(ctx).Error().Err(err).Msg("Commit transaction error for deleting user")
 		return getReturnErr(err)
 	}
+	addTokenRevocationToList(u.revocationList, tokenRevocation)
+	return nil
+}
+
+// Change the password of a user after checking the current password. It's allowed even if the password is expired.
+func (u *UserServiceImp) UpdateUserPasswd(ctx context.Context, subject *entity.Subject, userUUID uuid.EntityUUID, passwd, newPasswd string) error {
+	var err error
+
+	// Check access
+	if err = checkUserAccess(ctx, subject, userUUID); err != nil {
+		return err
+	}
+
+	// Begin transaction
+	tx, _ := u.repoDBTx.Begin()
+	defer func() {
+		if err != nil {
+			if err = tx.Rollback(); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Rollback transaction error for updating user password")
+				return
+			}
+			log.Ctx(ctx).Error().Err(err).Msg("Update user password request is canceled")
+			return
+		}
+	}()
+
+	// Get user info in the subject's tenant
+	userInfo, err := u.userInfoRepoPrimary.WithTx(tx).Get(ctx, subject.TenantID, userUUID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get user info from DB")
+		return getReturnErr(err)
+	}
+	if err = checkUserChange(ctx, u.groupRepoPrimary.WithTx(tx), u.groupMemberRepoPrimary.WithTx(tx), subject, userInfo); err != nil {
+		return err
+	}
+
+	// Check current password
+	userSecret, err := u.userSecretRepoPrimary.WithTx(tx).Get(ctx, userUUID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get user secret from DB")
+		return getReturnErr(err)
+	}
+	if err = validateCurrentPasswd(ctx, userSecret, passwd); err != nil {
+		return err
+	}
+
+	// Check password policy and change password
+	if err = u.passwdPolicy.Validate(newPasswd, userInfo.LoginID, userInfo.Email); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Password violates password policy")
+		return err
+	}
+	if err = u.changePasswd(ctx, tx, userSecret, newPasswd); err != nil {
+		return err
+	}
+
+	// Revoke other sessions
+	tokenRevocation, err := u.revokeOtherSessions(ctx, tx, subject, userUUID)
+	if err != nil {
+		return getReturnErr(err)
+	}
+
+	// Commit transaction
+	if err = tx.Commit(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Commit transaction error for updating user password")
+		return getReturnErr(err)
+	}
+	addTokenRevocationToList(u.revocationList, tokenRevocation)
+	return nil
+}
+
+// Revoke the sessions of the user except the subject's session after the password is changed, because the old
+// password may be stolen. All access tokens of the user are revoked, and the subject's session gets new access
+// tokens by refreshing.
+func (u *UserServiceImp) revokeOtherSessions(ctx context.Context, tx repo.DBTx, subject *entity.Subject,
+	userUUID uuid.EntityUUID) (*entity.TokenRevocation, error) {
+	currentSessionID := ""
+	if subject.UserID == userUUID.String() {
+		currentSessionID = subject.SessionID
+	}
+
+	// Delete other sessions
+	sessions, err := u.sessionRepoPrimary.WithTx(tx).ListByUserID(ctx, userUUID, time.Time{})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to list session from DB")
+		return nil, err
+	}
+	sessionIDs := []string{}
+	for _, session := range sessions {
+		if session.ID.String() == currentSessionID {
+			continue
+		}
+		if err := u.sessionRepoPrimary.WithTx(tx).Delete(ctx, session.ID); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to delete session from DB")
+			return nil, err
+		}
+		sessionIDs = append(sessionIDs, session.ID.String())
+	}
+
+	// Revoke all access tokens of the user
+	tokenRevocation, err := createTokenRevocation(ctx, u.tokenRevocationRepoPrimary, tx, entity.TokenRevocationTypeUser, userUUID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	// Publish a session revoked event
+	if len(sessionIDs) > 0 {
+		if err := createOutbox(ctx, u.outBoxRepoPrimary, tx, "RevokeOtherSessions", AggregateTypeSession, userUUID.String(),
+			EventTypeSessionRevoked, sessionOutboxPayload{UserID: userUUID.String(), SessionIDs: sessionIDs}); err != nil {
+			return nil, err
+		}
+	}
+	return tokenRevocation, nil
+}
+
+// Remove the password of a user after checking the current password, so the user can login only by passkeys.
+// The user must have a passkey not to be locked out.
+func (u *UserServiceImp) RemoveUserPasswd(ctx context.Context, subject *entity.Subject, userUUID uuid.EntityUUID, passwd string) error {
+	var err error
+
+	// Check access
+	if err = checkUserAccess(ctx, subject, userUUID); err != nil {
+		return err
+	}
+
+	// Begin transaction
+	tx, _ := u.repoDBTx.Begin()
+	defer func() {
+		if err != nil {
+			if err = tx.Rollback(); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Rollback transaction error for removing user password")
+				return
+			}
+			log.Ctx(ctx).Error().Err(err).Msg("Remove user password request is canceled")
+			return
+		}
+	}()
+
+	// Get user info in the subject's tenant
+	userInfo, err := u.userInfoRepoPrimary.WithTx(tx).Get(ctx, subject.TenantID, userUUID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get user info from DB")
+		return getReturnErr(err)
+	}
+	if err = checkUserChange(ctx, u.groupRepoPrimary.WithTx(tx), u.groupMemberRepoPrimary.WithTx(tx), subject, userInfo); err != nil {
+		return err
+	}
+
+	// Check current password and passkeys
+	userSecret, err := u.userSecretRepoPrimary.WithTx(tx).Get(ctx, userUUID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get user secret from DB")
+		return getReturnErr(err)
+	}
+	if err = validateCurrentPasswd(ctx, userSecret, passwd); err != nil {
+		return err
+	}
+	count, err := u.webAuthnCredentialRepoPrimary.WithTx(tx).CountByUser(ctx, userUUID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to count passkeys from DB")
+		return getReturnErr(err)
+	}
+	if count == 0 {
+		log.Ctx(ctx).Error().Msg("User without passkey can't remove password")
+		err = ErrPasskeyRequired
+		return err
+	}
+
+	// Remove password
+	if err = u.userSecretRepoPrimary.WithTx(tx).DeletePasswd(ctx, userUUID, time.Now()); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete password from DB")
+		return getReturnErr(err)
+	}
+
+	// Commit transaction
+	if err = tx.Commit(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Commit transaction error for removing user password")
+		return getReturnErr(err)
+	}
+	return nil
+}
+
+// Validate the current password of the user secret. Users without password don't have a current password.
+func validateCurrentPasswd(ctx context.Context, userSecret *entity.UserSecret, passwd string) error {
+	if !userSecret.HasPasswd() {
+		log.Ctx(ctx).Error().Msg("User doesn't have password")
+		return ErrUnauthorized
+	}
+	valid, err := hashing.ValidatePasswd(passwd, userSecret.GetPasswdHash())
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to validate password")
+		return ErrServerErr
+	} else if !valid {
+		log.Ctx(ctx).Error().Msg("Current password is wrong")
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+// Change the password of the user secret. The new password can't be the current password or one of the previous passwords
+// in the password history, and the current password is pushed to the history. The history keeps the last passwords
+// except the current one, so the history size includes the current password.
+func (u *UserServiceImp) changePasswd(ctx context.Context, tx repo.DBTx, userSecret *entity.UserSecret, newPasswd string) error {
+	var err error
+
+	// Check password history
+	passwdHistories := []entity.PasswdHistory{}
+	if u.passwdHistorySize > 1 {
+		passwdHistories, err = u.passwdHistoryRepoPrimary.WithTx(tx).ListByUser(ctx, userSecret.ID, u.passwdHistorySize-1)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to list password histories from DB")
+			return getReturnErr(err)
+		}
+	}
+	if u.passwdHistorySize > 0 {
+		passwdHashes := []string{}
+		if userSecret.HasPasswd() {
+			passwdHashes = append(passwdHashes, userSecret.GetPasswdHash())
+		}
+		for _, passwdHistory := range passwdHistories {
+			passwdHashes = append(passwdHashes, passwdHistory.PasswdPHC)
+		}
+		for _, passwdHash := range passwdHashes {
+			valid, err := hashing.ValidatePasswd(newPasswd, passwdHash)
+			if err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Failed to validate password with password history")
+				return ErrServerErr
+			} else if valid {
+				log.Ctx(ctx).Error().Msg("Password is one of the last passwords")
+				return ErrPasswdReused
+			}
+		}
+	}
+
+	// Update user secret
+	passwdHash, err := hashing.GetPasswdHash(newPasswd)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to create password hash")
+		return getReturnErr(err)
+	}
+	passwdChangedAt := time.Now()
+	newUserSecret := entity.UserSecret{
+		ID:              userSecret.ID,
+		PasswdPHC:       passwdHash,
+		PasswdChangedAt: &passwdChangedAt,
+	}
+	if err = u.userSecretRepoPrimary.WithTx(tx).Update(ctx, &newUserSecret); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to update user secret to DB")
+		return getReturnErr(err)
+	}
+
+	// Push the current password to the password history and delete histories out of the history size.
+	// Users without password don't have the current password.
+	if u.passwdHistorySize > 1 && userSecret.HasPasswd() {
+		passwdHistory := entity.PasswdHistory{
+			UserID:    userSecret.ID,
+			PasswdPHC: userSecret.GetPasswdHash(),
+		}
+		if err = u.passwdHistoryRepoPrimary.WithTx(tx).Create(ctx, &passwdHistory); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to create password history to DB")
+			return getReturnErr(err)
+		}
+		passwdHistories = append([]entity.PasswdHistory{passwdHistory}, passwdHistories...)
+		if len(passwdHistories) >= u.passwdHistorySize-1 {
+			oldestID := passwdHistories[u.passwdHistorySize-2].ID
+			if err = u.passwdHistoryRepoPrimary.WithTx(tx).DeleteByUserBefore(ctx, userSecret.ID, oldestID); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Failed to delete old password histories from DB")
+				return getReturnErr(err)
+			}
+		}
+	}
 	return nil
 }
@@ -2,8 +2,12 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/golang-jwt/jwt"
 	"github.com/opentracing/opentracing-go"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -13,6 +17,12 @@ import (
 	"github.com/ssup2ket/service-auth/internal/domain/repo"
 	"github.com/ssup2ket/service-auth/internal/domain/repo/mocks"
 	"github.com/ssup2ket/service-auth/internal/test"
+	"github.com/ssup2ket/service-auth/pkg/auth/hashing"
+	"github.com/ssup2ket/service-auth/pkg/auth/passwd"
+	"github.com/ssup2ket/service-auth/pkg/auth/token"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+	"github.com/ssup2ket/service-auth/pkg/mail"
+	mailmocks "github.com/ssup2ket/service-auth/pkg/mail/mocks"
 )
 
 func TestUser(t *testing.T) {
@@ -22,10 +32,26 @@ func TestUser(t *testing.T) {
 type userSuite struct {
 	suite.Suite
 
-	dbTx           mocks.DBTx
-	outboxRepo     mocks.OutboxRepo
-	userInfoRepo   mocks.UserInfoRepo
-	userSecretRepo mocks.UserSecretRepo
+	dbTx              mocks.DBTx
+	outboxRepo        mocks.OutboxRepo
+	userInfoRepo      mocks.UserInfoRepo
+	userSecretRepo    mocks.UserSecretRepo
+	passwdHistoryRepo mocks.PasswdHistoryRepo
+	roleRepo          mocks.RoleRepo
+	permissionRepo    mocks.PermissionRepo
+	tenantRepo        mocks.TenantRepo
+	groupRepo         mocks.GroupRepo
+	groupMemberRepo   mocks.GroupMemberRepo
+
+	mfaRecoveryCodeRepo    mocks.MFARecoveryCodeRepo
+	webAuthnCredentialRepo mocks.WebAuthnCredentialRepo
+
+	sessionRepo         mocks.SessionRepo
+	tokenRevocationRepo mocks.TokenRevocationRepo
+	revocationList      *token.RevocationList
+
+	emailVerificationRepo mocks.EmailVerificationRepo
+	mailSender            mailmocks.Sender
 
 	userService UserService
 }
@@ -36,16 +62,117 @@ func (u *userSuite) SetupTest() {
 	u.outboxRepo = mocks.OutboxRepo{}
 	u.userInfoRepo = mocks.UserInfoRepo{}
 	u.userSecretRepo = mocks.UserSecretRepo{}
+	u.passwdHistoryRepo = mocks.PasswdHistoryRepo{}
+	u.roleRepo = mocks.RoleRepo{}
+	u.permissionRepo = mocks.PermissionRepo{}
+	u.tenantRepo = mocks.TenantRepo{}
+	u.groupRepo = mocks.GroupRepo{}
+	u.groupMemberRepo = mocks.GroupMemberRepo{}
+	u.mfaRecoveryCodeRepo = mocks.MFARecoveryCodeRepo{}
+	u.webAuthnCredentialRepo = mocks.WebAuthnCredentialRepo{}
+	u.sessionRepo = mocks.SessionRepo{}
+	u.tokenRevocationRepo = mocks.TokenRevocationRepo{}
+	u.revocationList = token.NewRevocationList()
+	u.emailVerificationRepo = mocks.EmailVerificationRepo{}
+	u.mailSender = mailmocks.Sender{}
 
 	// Set nooptracer
 	opentracing.SetGlobalTracer(opentracing.NoopTracer{})
 
-	// Init service
-	u.userService = NewUserServiceImp(&u.dbTx, &u.outboxRepo, &u.userInfoRepo, &u.userInfoRepo, &u.userSecretRepo, &u.userSecretRepo)
+	// Init password policy
+	passwdPolicyConfig := passwd.GetDefaultPolicyConfig()
+	passwdPolicyConfig.BreachedPasswds = map[string]bool{strings.ToLower(test.UserPasswdBreached): true}
+
+	// Init service. The password history keeps the last 3 passwords including the current one.
+	u.userService = NewUserServiceImp(&u.dbTx, UserRepos{
+		OutboxPrimary:        &u.outboxRepo,
+		UserInfoPrimary:      &u.userInfoRepo,
+		UserInfoSecondary:    &u.userInfoRepo,
+		UserSecretPrimary:    &u.userSecretRepo,
+		UserSecretSecondary:  &u.userSecretRepo,
+		PasswdHistoryPrimary: &u.passwdHistoryRepo,
+		RolePrimary:          &u.roleRepo,
+		PermissionPrimary:    &u.permissionRepo,
+		TenantPrimary:        &u.tenantRepo,
+		GroupPrimary:         &u.groupRepo,
+		GroupMemberPrimary:   &u.groupMemberRepo,
+
+		MFARecoveryCodePrimary:    &u.mfaRecoveryCodeRepo,
+		WebAuthnCredentialPrimary: &u.webAuthnCredentialRepo,
+
+		SessionPrimary:         &u.sessionRepo,
+		TokenRevocationPrimary: &u.tokenRevocationRepo,
+
+		EmailVerificationPrimary: &u.emailVerificationRepo,
+	}, u.revocationList, &u.mailSender, UserPolicies{
+		Passwd:            passwd.NewDefaultPolicy(passwdPolicyConfig),
+		PasswdHistorySize: 3,
+		EmailVerification: &EmailVerificationPolicy{Lifetime: time.Hour},
+	})
+
+	// Roles granted by tenant admins are checked with permissions
+	u.permissionRepo.On("ListAll", context.Background()).Return(test.PermissionsCorrect, nil)
+}
+
+// Mock the user's current password and empty password history to change the password
+func (u *userSuite) mockPasswdChange(currentPasswd string) {
+	passwdHash, err := hashing.GetPasswdHash(currentPasswd)
+	require.NoError(u.T(), err)
+
+	u.userSecretRepo.On("WithTx", mock.Anything).Return(&u.userSecretRepo)
+	u.userSecretRepo.On("Get", context.Background(), test.UserIDCorrect).Return(&entity.UserSecret{ID: test.UserIDCorrect, PasswdPHC: passwdHash}, nil)
+	u.userSecretRepo.On("Update", context.Background(), mock.Anything).Return(nil)
+	u.passwdHistoryRepo.On("WithTx", mock.Anything).Return(&u.passwdHistoryRepo)
+	u.passwdHistoryRepo.On("ListByUser", context.Background(), test.UserIDCorrect, 2).Return([]entity.PasswdHistory{}, nil)
+	u.passwdHistoryRepo.On("Create", context.Background(), mock.Anything).Return(nil)
+	u.mockSessionRevocation()
+}
+
+// Mock revoking the sessions and the access tokens of the user after the password is changed
+func (u *userSuite) mockSessionRevocation() {
+	u.sessionRepo.On("WithTx", mock.Anything).Return(&u.sessionRepo)
+	u.sessionRepo.On("ListByUserID", context.Background(), test.UserIDCorrect, time.Time{}).Return([]entity.Session{
+		{ID: test.SessionIDCorrect, UserID: test.UserIDCorrect},
+		{ID: test.SessionIDCorrect2, UserID: test.UserIDCorrect},
+	}, nil)
+	u.sessionRepo.On("Delete", context.Background(), mock.Anything).Return(nil)
+	u.tokenRevocationRepo.On("WithTx", mock.Anything).Return(&u.tokenRevocationRepo)
+	u.tokenRevocationRepo.On("Create", context.Background(), mock.Anything).Return(nil)
+	u.outboxRepo.On("WithTx", mock.Anything).Return(&u.outboxRepo)
+	u.outboxRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+}
+
+// Mock the user without groups
+func (u *userSuite) mockNoGroups() {
+	u.groupRepo.On("WithTx", mock.Anything).Return(&u.groupRepo)
+	u.groupRepo.On("ListByIDs", context.Background(), mock.Anything, []uuid.EntityUUID{}).Return([]entity.Group{}, nil)
+	u.groupMemberRepo.On("WithTx", mock.Anything).Return(&u.groupMemberRepo)
+	u.groupMemberRepo.On("ListByMemberIDs", context.Background(), []uuid.EntityUUID{test.UserIDCorrect}).Return([]entity.GroupMember{}, nil)
+}
+
+// Mock the user who is an admin by the group
+func (u *userSuite) mockAdminGroup() {
+	adminGroup := test.GroupCorrect
+	adminGroup.Roles = entity.StrList{string(entity.UserRoleAdmin)}
+
+	u.groupRepo.On("WithTx", mock.Anything).Return(&u.groupRepo)
+	u.groupRepo.On("ListByIDs", context.Background(), mock.Anything, []uuid.EntityUUID{test.GroupIDCorrect}).Return([]entity.Group{adminGroup}, nil)
+	u.groupMemberRepo.On("WithTx", mock.Anything).Return(&u.groupMemberRepo)
+	u.groupMemberRepo.On("ListByMemberIDs", context.Background(), []uuid.EntityUUID{test.UserIDCorrect}).
+		Return([]entity.GroupMember{test.GroupMemberUserCorrect}, nil)
+	u.groupMemberRepo.On("ListByMemberIDs", context.Background(), []uuid.EntityUUID{test.GroupIDCorrect}).Return([]entity.GroupMember{}, nil)
+}
+
+// Mock sending an email verification. Contexts have the span after creating a user.
+func (u *userSuite) mockEmailVerification(sendErr error) {
+	u.emailVerificationRepo.On("DeleteByUser", mock.Anything, mock.Anything).Return(nil)
+	u.emailVerificationRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	u.emailVerificationRepo.On("DeleteExpired", mock.Anything, mock.Anything).Return(nil)
+	u.mailSender.On("Send", mock.Anything, mock.Anything).Return(sendErr)
 }
 
 func (u *userSuite) TestListUserSuccess() {
-	u.userInfoRepo.On("List", context.Background(), 0, 50).Return([]entity.UserInfo{
+	u.userInfoRepo.On("List", context.Background(), test.TenantIDCorrect, 0, 50).Return([]entity.UserInfo{
 		{
 			ID:      test.UserIDCorrect,
 			LoginID: test.UserLoginIDCorrect,
@@ -62,7 +189,7 @@ func (u *userSuite) TestListUserSuccess() {
 		},
 	}, nil)
 
-	userInfos, err := u.userService.ListUser(context.Background(), 0, 0)
+	userInfos, err := u.userService.ListUser(context.Background(), test.TenantIDCorrect, 0, 0)
 	require.NoError(u.T(), err)
 	require.Equal(u.T(), test.UserIDCorrect, userInfos[0].ID)
 	require.Equal(u.T(), test.UserLoginIDCorrect, userInfos[0].LoginID)
@@ -71,21 +198,26 @@ func (u *userSuite) TestListUserSuccess() {
 }
 
 func (u *userSuite) TestListUserServerError() {
-	u.userInfoRepo.On("List", context.Background(), 0, 50).Return(nil, repo.ErrServerError)
+	u.userInfoRepo.On("List", context.Background(), test.TenantIDCorrect, 0, 50).Return(nil, repo.ErrServerError)
 
-	_, err := u.userService.ListUser(context.Background(), 0, 0)
+	_, err := u.userService.ListUser(context.Background(), test.TenantIDCorrect, 0, 0)
 	require.Equal(u.T(), ErrRepoServerError, err)
 }
 
 func (u *userSuite) TestCreateUserSuccess() {
 	userInfo := &entity.UserInfo{
-		LoginID: test.UserLoginIDCorrect,
-		Role:    test.UserRoleCorrect,
-		Phone:   test.UserPhoneCorrect,
-		Email:   test.UserEmailCorrect,
+		TenantID: test.TenantIDCorrect,
+		LoginID:  test.UserLoginIDCorrect,
+		Role:     test.UserRoleCorrect,
+		Phone:    test.UserPhoneCorrect,
+		Email:    test.UserEmailCorrect,
 	}
 
 	u.dbTx.On("Begin").Return(&u.dbTx, nil)
+	u.tenantRepo.On("WithTx", mock.Anything).Return(&u.tenantRepo)
+	u.tenantRepo.On("Get", context.Background(), test.TenantIDCorrect).Return(&test.TenantCorrect, nil)
+	u.roleRepo.On("WithTx", mock.Anything).Return(&u.roleRepo)
+	u.roleRepo.On("Get", context.Background(), string(test.UserRoleCorrect)).Return(&test.RoleAdminCorrect, nil)
 	u.userInfoRepo.On("WithTx", mock.Anything).Return(&u.userInfoRepo)
 	u.userInfoRepo.On("Create", context.Background(), userInfo).Return(nil)
 	u.userSecretRepo.On("WithTx", mock.Anything).Return(&u.userSecretRepo)
@@ -93,17 +225,197 @@ func (u *userSuite) TestCreateUserSuccess() {
 	u.outboxRepo.On("WithTx", mock.Anything).Return(&u.outboxRepo)
 	u.outboxRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
 	u.dbTx.On("Commit").Return(nil)
+	u.mockEmailVerification(nil)
 
-	userInfo, err := u.userService.CreateUser(context.Background(), userInfo, test.UserPasswdCorrect)
+	userInfo, err := u.userService.CreateUser(context.Background(), &test.SubjectAdminCorrect, userInfo, test.UserPasswdCorrect)
 	require.NoError(u.T(), err)
+	require.Equal(u.T(), test.TenantIDCorrect, userInfo.TenantID)
 	require.Equal(u.T(), test.UserLoginIDCorrect, userInfo.LoginID)
 	require.Equal(u.T(), test.UserRoleCorrect, userInfo.Role)
 	require.Equal(u.T(), test.UserPhoneCorrect, userInfo.Phone)
 	require.Equal(u.T(), test.UserEmailCorrect, userInfo.Email)
+
+	// A verification token is sent to the email
+	u.mailSender.AssertCalled(u.T(), "Send", mock.Anything, mock.MatchedBy(func(msg *mail.Message) bool {
+		return msg.To == test.UserEmailCorrect
+	}))
+}
+
+func (u *userSuite) TestCreateUserEmailVerificationError() {
+	userInfo := &entity.UserInfo{
+		TenantID: test.TenantIDCorrect,
+		LoginID:  test.UserLoginIDCorrect,
+		Role:     test.UserRoleCorrect,
+		Email:    test.UserEmailCorrect,
+	}
+
+	u.dbTx.On("Begin").Return(&u.dbTx, nil)
+	u.tenantRepo.On("WithTx", mock.Anything).Return(&u.tenantRepo)
+	u.tenantRepo.On("Get", context.Background(), test.TenantIDCorrect).Return(&test.TenantCorrect, nil)
+	u.roleRepo.On("WithTx", mock.Anything).Return(&u.roleRepo)
+	u.roleRepo.On("Get", context.Background(), string(test.UserRoleCorrect)).Return(&test.RoleAdminCorrect, nil)
+	u.userInfoRepo.On("WithTx", mock.Anything).Return(&u.userInfoRepo)
+	u.userInfoRepo.On("Create", context.Background(), userInfo).Return(nil)
+	u.userSecretRepo.On("WithTx", mock.Anything).Return(&u.userSecretRepo)
+	u.userSecretRepo.On("Create", context.Background(), mock.Anything).Return(nil)
+	u.outboxRepo.On("WithTx", mock.Anything).Return(&u.outboxRepo)
+	u.outboxRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	u.dbTx.On("Commit").Return(nil)
+	u.mockEmailVerification(fmt.Errorf("error"))
+
+	// Failure of sending the mail doesn't affect the created user
+	_, err := u.userService.CreateUser(context.Background(), &test.SubjectAdminCorrect, userInfo, test.UserPasswdCorrect)
+	require.NoError(u.T(), err)
+}
+
+func (u *userSuite) TestCreateUserDefaultTenant() {
+	userInfo := &entity.UserInfo{
+		LoginID: test.UserLoginIDCorrect,
+		Role:    test.UserRoleCorrect,
+	}
+
+	u.dbTx.On("Begin").Return(&u.dbTx, nil)
+	u.tenantRepo.On("WithTx", mock.Anything).Return(&u.tenantRepo)
+	u.tenantRepo.On("Get", context.Background(), entity.TenantIDDefault).Return(&entity.Tenant{ID: entity.TenantIDDefault}, nil)
+	u.roleRepo.On("WithTx", mock.Anything).Return(&u.roleRepo)
+	u.roleRepo.On("Get", context.Background(), string(test.UserRoleCorrect)).Return(&test.RoleAdminCorrect, nil)
+	u.userInfoRepo.On("WithTx", mock.Anything).Return(&u.userInfoRepo)
+	u.userInfoRepo.On("Create", context.Background(), userInfo).Return(nil)
+	u.userSecretRepo.On("WithTx", mock.Anything).Return(&u.userSecretRepo)
+	u.userSecretRepo.On("Create", context.Background(), mock.Anything).Return(nil)
+	u.outboxRepo.On("WithTx", mock.Anything).Return(&u.outboxRepo)
+	u.outboxRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	u.dbTx.On("Commit").Return(nil)
+	u.mockEmailVerification(nil)
+
+	userInfo, err := u.userService.CreateUser(context.Background(), &test.SubjectAdminCorrect, userInfo, test.UserPasswdCorrect)
+	require.NoError(u.T(), err)
+	require.Equal(u.T(), entity.TenantIDDefault, userInfo.TenantID)
+}
+
+func (u *userSuite) TestCreateUserTenantNotExist() {
+	userInfo := &entity.UserInfo{
+		TenantID: test.TenantIDCorrect2,
+		LoginID:  test.UserLoginIDCorrect,
+		Role:     test.UserRoleCorrect,
+	}
+
+	u.dbTx.On("Begin").Return(&u.dbTx, nil)
+	u.tenantRepo.On("WithTx", mock.Anything).Return(&u.tenantRepo)
+	u.tenantRepo.On("Get", context.Background(), test.TenantIDCorrect2).Return(nil, repo.ErrNotFound)
+	u.dbTx.On("Rollback").Return(nil)
+
+	_, err := u.userService.CreateUser(context.Background(), &test.SubjectAdminCorrect, userInfo, test.UserPasswdCorrect)
+	require.Equal(u.T(), ErrTenantNotExist, err)
+	u.userInfoRepo.AssertNotCalled(u.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (u *userSuite) TestCreateUserRoleNotExist() {
+	userInfo := &entity.UserInfo{
+		TenantID: test.TenantIDCorrect,
+		LoginID:  test.UserLoginIDCorrect,
+		Role:     test.RoleNameCorrect,
+		Phone:    test.UserPhoneCorrect,
+		Email:    test.UserEmailCorrect,
+	}
+
+	u.dbTx.On("Begin").Return(&u.dbTx, nil)
+	u.tenantRepo.On("WithTx", mock.Anything).Return(&u.tenantRepo)
+	u.tenantRepo.On("Get", context.Background(), test.TenantIDCorrect).Return(&test.TenantCorrect, nil)
+	u.roleRepo.On("WithTx", mock.Anything).Return(&u.roleRepo)
+	u.roleRepo.On("Get", context.Background(), test.RoleNameCorrect).Return(nil, repo.ErrNotFound)
+	u.dbTx.On("Rollback").Return(nil)
+
+	_, err := u.userService.CreateUser(context.Background(), &test.SubjectAdminCorrect, userInfo, test.UserPasswdCorrect)
+	require.Equal(u.T(), ErrRoleNotExist, err)
+	u.userInfoRepo.AssertNotCalled(u.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (u *userSuite) TestCreateUserSignUpDefaultRole() {
+	userInfo := &entity.UserInfo{
+		TenantID: test.TenantIDCorrect,
+		LoginID:  test.UserLoginIDCorrect,
+		Role:     entity.UserRoleAdmin,
+	}
+
+	u.dbTx.On("Begin").Return(&u.dbTx, nil)
+	u.tenantRepo.On("WithTx", mock.Anything).Return(&u.tenantRepo)
+	u.tenantRepo.On("Get", context.Background(), test.TenantIDCorrect).Return(&test.TenantCorrect, nil)
+	u.roleRepo.On("WithTx", mock.Anything).Return(&u.roleRepo)
+	u.roleRepo.On("Get", context.Background(), string(entity.UserRoleUser)).Return(&test.RoleAdminCorrect, nil)
+	u.userInfoRepo.On("WithTx", mock.Anything).Return(&u.userInfoRepo)
+	u.userInfoRepo.On("Create", context.Background(), userInfo).Return(nil)
+	u.userSecretRepo.On("WithTx", mock.Anything).Return(&u.userSecretRepo)
+	u.userSecretRepo.On("Create", context.Background(), mock.Anything).Return(nil)
+	u.outboxRepo.On("WithTx", mock.Anything).Return(&u.outboxRepo)
+	u.outboxRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	u.dbTx.On("Commit").Return(nil)
+	u.mockEmailVerification(nil)
+
+	// A user signed up without subject has the default role
+	userInfo, err := u.userService.CreateUser(context.Background(), nil, userInfo, test.UserPasswdCorrect)
+	require.NoError(u.T(), err)
+	require.Equal(u.T(), entity.UserRoleUser, userInfo.Role)
+}
+
+func (u *userSuite) TestCreateUserTenantAdminOtherTenantUnauthorized() {
+	userInfo := &entity.UserInfo{
+		TenantID: test.TenantIDCorrect2,
+		LoginID:  test.UserLoginIDCorrect,
+		Role:     entity.UserRoleUser,
+	}
+
+	_, err := u.userService.CreateUser(context.Background(), &test.SubjectTenantAdminCorrect, userInfo, test.UserPasswdCorrect)
+	require.Equal(u.T(), ErrUnauthorized, err)
+	u.dbTx.AssertNotCalled(u.T(), "Begin")
+}
+
+func (u *userSuite) TestCreateUserTenantAdminRoleUnauthorized() {
+	for _, role := range []entity.UserRole{entity.UserRoleAdmin, test.RoleNameWideCorrect} {
+		userInfo := &entity.UserInfo{
+			TenantID: test.TenantIDCorrect,
+			LoginID:  test.UserLoginIDCorrect,
+			Role:     role,
+		}
+
+		u.dbTx.On("Begin").Return(&u.dbTx, nil)
+		u.tenantRepo.On("WithTx", mock.Anything).Return(&u.tenantRepo)
+		u.tenantRepo.On("Get", context.Background(), test.TenantIDCorrect).Return(&test.TenantCorrect, nil)
+		u.roleRepo.On("WithTx", mock.Anything).Return(&u.roleRepo)
+		u.roleRepo.On("Get", context.Background(), string(role)).Return(&test.RoleAdminCorrect, nil)
+		u.dbTx.On("Rollback").Return(nil)
+
+		_, err := u.userService.CreateUser(context.Background(), &test.SubjectTenantAdminCorrect, userInfo, test.UserPasswdCorrect)
+		require.Equal(u.T(), ErrUnauthorized, err, role)
+	}
+	u.userInfoRepo.AssertNotCalled(u.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (u *userSuite) TestCreateUserPasswdPolicy() {
+	userInfo := &entity.UserInfo{
+		TenantID: test.TenantIDCorrect,
+		LoginID:  test.UserLoginIDCorrect,
+		Role:     test.UserRoleCorrect,
+		Email:    test.UserEmailCorrect,
+	}
+
+	for passwd, policyErr := range map[string]error{
+		test.UserPasswdShort:            ErrPasswdTooShort,
+		test.UserPasswdLong:             ErrPasswdTooLong,
+		"secretpasswd":                  ErrPasswdCharClasses,
+		test.UserPasswdBreached:         ErrPasswdBreached,
+		"my-" + test.UserLoginIDCorrect: ErrPasswdUserInput,
+		"my-" + test.UserEmailCorrect:   ErrPasswdUserInput,
+	} {
+		_, err := u.userService.CreateUser(context.Background(), nil, userInfo, passwd)
+		require.Equal(u.T(), policyErr, err, passwd)
+		require.True(u.T(), IsPasswdPolicyErr(err))
+	}
+	u.dbTx.AssertNotCalled(u.T(), "Begin")
 }
 
 func (u *userSuite) TestGetUserSuccess() {
-	u.userInfoRepo.On("Get", context.Background(), test.UserIDCorrect).Return(&entity.UserInfo{
+	u.userInfoRepo.On("Get", context.Background(), test.TenantIDCorrect, test.UserIDCorrect).Return(&entity.UserInfo{
 		ID:      test.UserIDCorrect,
 		LoginID: test.UserLoginIDCorrect,
 		Role:    test.UserRoleCorrect,
@@ -111,7 +423,7 @@ func (u *userSuite) TestGetUserSuccess() {
 		Email:   test.UserEmailCorrect,
 	}, nil)
 
-	userInfo, err := u.userService.GetUser(context.Background(), test.UserIDCorrect)
+	userInfo, err := u.userService.GetUser(context.Background(), &test.SubjectUserCorrect, test.UserIDCorrect)
 	require.NoError(u.T(), err)
 	require.Equal(u.T(), test.UserIDCorrect, userInfo.ID)
 	require.Equal(u.T(), test.UserLoginIDCorrect, userInfo.LoginID)
@@ -121,35 +433,350 @@ func (u *userSuite) TestGetUserSuccess() {
 }
 
 func (u *userSuite) TestGetUserRepoNotFoundError() {
-	u.userInfoRepo.On("Get", context.Background(), test.UserIDCorrect).Return(&entity.UserInfo{}, repo.ErrNotFound)
+	u.userInfoRepo.On("Get", context.Background(), test.TenantIDCorrect, test.UserIDCorrect).Return(&entity.UserInfo{}, repo.ErrNotFound)
 
-	_, err := u.userService.GetUser(context.Background(), test.UserIDCorrect)
+	_, err := u.userService.GetUser(context.Background(), &test.SubjectAdminCorrect, test.UserIDCorrect)
 	require.Equal(u.T(), ErrRepoNotFound, err)
 }
 
+func (u *userSuite) TestGetUserOtherUserUnauthorized() {
+	_, err := u.userService.GetUser(context.Background(), &test.SubjectOtherUserCorrect, test.UserIDCorrect)
+	require.Equal(u.T(), ErrUnauthorized, err)
+	u.userInfoRepo.AssertNotCalled(u.T(), "Get", mock.Anything, mock.Anything)
+}
+
 func (u *userSuite) TestUpdateUserSuccess() {
+	u.mockNoGroups()
 	userInfo := &entity.UserInfo{
 		ID:      test.UserIDCorrect,
 		LoginID: test.UserLoginIDCorrect,
-		Role:    test.UserRoleCorrect,
+		Role:    entity.UserRoleUser,
 		Phone:   test.UserPhoneCorrect,
 		Email:   test.UserEmailCorrect,
 	}
 
 	u.dbTx.On("Begin").Return(&u.dbTx, nil)
 	u.userInfoRepo.On("WithTx", mock.Anything).Return(&u.userInfoRepo)
-	u.userInfoRepo.On("Get", context.Background(), test.UserIDCorrect).Return(nil, nil)
+	u.userInfoRepo.On("Get", context.Background(), test.TenantIDCorrect, test.UserIDCorrect).Return(&entity.UserInfo{ID: test.UserIDCorrect, Role: entity.UserRoleUser,
+		Email: test.UserEmailCorrect}, nil)
+	u.userInfoRepo.On("WithTx", mock.Anything).Return(&u.userInfoRepo)
+	u.userInfoRepo.On("Update", context.Background(), userInfo).Return(nil)
+	u.mockPasswdChange(test.UserPasswdCorrect2)
+	u.dbTx.On("Commit").Return(nil)
+
+	err := u.userService.UpdateUser(context.Background(), &test.SubjectAdminCorrect, userInfo, test.UserPasswdCorrect)
+	require.NoError(u.T(), err)
+	u.userInfoRepo.AssertNotCalled(u.T(), "UpdateEmailVerified", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	// The old password is pushed to the password history
+	u.passwdHistoryRepo.AssertNumberOfCalls(u.T(), "Create", 1)
+	u.passwdHistoryRepo.AssertNotCalled(u.T(), "DeleteByUserBefore", mock.Anything, mock.Anything, mock.Anything)
+
+	// The password reset revokes all sessions of the user
+	u.sessionRepo.AssertNumberOfCalls(u.T(), "Delete", 2)
+	u.tokenRevocationRepo.AssertNumberOfCalls(u.T(), "Create", 1)
+}
+
+func (u *userSuite) TestUpdateUserWithoutPasswd() {
+	u.mockNoGroups()
+	userInfo := &entity.UserInfo{
+		ID:    test.UserIDCorrect,
+		Phone: test.UserPhoneCorrect,
+	}
+
+	u.dbTx.On("Begin").Return(&u.dbTx, nil)
+	u.userInfoRepo.On("WithTx", mock.Anything).Return(&u.userInfoRepo)
+	u.userInfoRepo.On("Get", context.Background(), test.TenantIDCorrect, test.UserIDCorrect).Return(&entity.UserInfo{ID: test.UserIDCorrect, Role: entity.UserRoleUser,
+		Email: test.UserEmailCorrect}, nil)
+	u.userInfoRepo.On("Update", context.Background(), userInfo).Return(nil)
+	u.dbTx.On("Commit").Return(nil)
+
+	// Empty password doesn't change the password
+	err := u.userService.UpdateUser(context.Background(), &test.SubjectUserCorrect, userInfo, "")
+	require.NoError(u.T(), err)
+	u.userSecretRepo.AssertNotCalled(u.T(), "Get", mock.Anything, mock.Anything)
+	u.userSecretRepo.AssertNotCalled(u.T(), "Update", mock.Anything, mock.Anything)
+	u.passwdHistoryRepo.AssertNotCalled(u.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (u *userSuite) TestUpdateUserPasswdReused() {
+	u.mockNoGroups()
+	userInfo := &entity.UserInfo{
+		ID:    test.UserIDCorrect,
+		Phone: test.UserPhoneCorrect,
+	}
+	currentPasswdHash, err := hashing.GetPasswdHash(test.UserPasswdCorrect2)
+	require.NoError(u.T(), err)
+	oldPasswdHash, err := hashing.GetPasswdHash(test.UserPasswdCorrect)
+	require.NoError(u.T(), err)
+
+	u.dbTx.On("Begin").Return(&u.dbTx, nil)
+	u.userInfoRepo.On("WithTx", mock.Anything).Return(&u.userInfoRepo)
+	u.userInfoRepo.On("Get", context.Background(), test.TenantIDCorrect, test.UserIDCorrect).Return(&entity.UserInfo{ID: test.UserIDCorrect, Role: entity.UserRoleUser}, nil)
+	u.userInfoRepo.On("Update", context.Background(), userInfo).Return(nil)
+	u.userSecretRepo.On("WithTx", mock.Anything).Return(&u.userSecretRepo)
+	u.userSecretRepo.On("Get", context.Background(), test.UserIDCorrect).Return(&entity.UserSecret{ID: test.UserIDCorrect, PasswdPHC: currentPasswdHash}, nil)
+	u.passwdHistoryRepo.On("WithTx", mock.Anything).Return(&u.passwdHistoryRepo)
+	u.passwdHistoryRepo.On("ListByUser", context.Background(), test.UserIDCorrect, 2).Return([]entity.PasswdHistory{
+		{ID: 1, UserID: test.UserIDCorrect, PasswdPHC: oldPasswdHash},
+	}, nil)
+	u.dbTx.On("Rollback").Return(nil)
+
+	// Both the current password and the previous password can't be reused
+	err = u.userService.UpdateUser(context.Background(), &test.SubjectAdminCorrect, userInfo, test.UserPasswdCorrect)
+	require.Equal(u.T(), ErrPasswdReused, err)
+	require.True(u.T(), IsPasswdPolicyErr(err))
+	err = u.userService.UpdateUser(context.Background(), &test.SubjectAdminCorrect, userInfo, test.UserPasswdCorrect2)
+	require.Equal(u.T(), ErrPasswdReused, err)
+	u.userSecretRepo.AssertNotCalled(u.T(), "Update", mock.Anything, mock.Anything)
+}
+
+func (u *userSuite) TestUpdateUserPasswdHistoryTrimmed() {
+	u.mockNoGroups()
+	userInfo := &entity.UserInfo{
+		ID:    test.UserIDCorrect,
+		Phone: test.UserPhoneCorrect,
+	}
+	currentPasswdHash, err := hashing.GetPasswdHash(test.UserPasswdCorrect2)
+	require.NoError(u.T(), err)
+
+	u.dbTx.On("Begin").Return(&u.dbTx, nil)
 	u.userInfoRepo.On("WithTx", mock.Anything).Return(&u.userInfoRepo)
+	u.userInfoRepo.On("Get", context.Background(), test.TenantIDCorrect, test.UserIDCorrect).Return(&entity.UserInfo{ID: test.UserIDCorrect, Role: entity.UserRoleUser}, nil)
 	u.userInfoRepo.On("Update", context.Background(), userInfo).Return(nil)
 	u.userSecretRepo.On("WithTx", mock.Anything).Return(&u.userSecretRepo)
+	u.userSecretRepo.On("Get", context.Background(), test.UserIDCorrect).Return(&entity.UserSecret{ID: test.UserIDCorrect, PasswdPHC: currentPasswdHash}, nil)
 	u.userSecretRepo.On("Update", context.Background(), mock.Anything).Return(nil)
+	u.passwdHistoryRepo.On("WithTx", mock.Anything).Return(&u.passwdHistoryRepo)
+	u.passwdHistoryRepo.On("ListByUser", context.Background(), test.UserIDCorrect, 2).Return([]entity.PasswdHistory{
+		{ID: 2, UserID: test.UserIDCorrect, PasswdPHC: currentPasswdHash},
+		{ID: 1, UserID: test.UserIDCorrect, PasswdPHC: currentPasswdHash},
+	}, nil)
+	u.passwdHistoryRepo.On("Create", context.Background(), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		args.Get(1).(*entity.PasswdHistory).ID = 3
+	})
+	u.passwdHistoryRepo.On("DeleteByUserBefore", context.Background(), test.UserIDCorrect, uint64(2)).Return(nil)
+	u.mockSessionRevocation()
+	u.dbTx.On("Commit").Return(nil)
+
+	err = u.userService.UpdateUser(context.Background(), &test.SubjectAdminCorrect, userInfo, test.UserPasswdPassphrase)
+	require.NoError(u.T(), err)
+	u.passwdHistoryRepo.AssertCalled(u.T(), "DeleteByUserBefore", context.Background(), test.UserIDCorrect, uint64(2))
+
+	updatedSecret := u.userSecretRepo.Calls[len(u.userSecretRepo.Calls)-1].Arguments.Get(1).(*entity.UserSecret)
+	require.NotNil(u.T(), updatedSecret.PasswdChangedAt)
+}
+
+func (u *userSuite) TestUpdateUserOwnPasswdUnauthorized() {
+	userInfo := &entity.UserInfo{
+		ID:    test.UserIDCorrect,
+		Phone: test.UserPhoneCorrect,
+	}
+
+	// Users change their own password only with the current password
+	err := u.userService.UpdateUser(context.Background(), &test.SubjectUserCorrect, userInfo, test.UserPasswdCorrect2)
+	require.Equal(u.T(), ErrUnauthorized, err)
+	u.userSecretRepo.AssertNotCalled(u.T(), "Update", mock.Anything, mock.Anything)
+}
+
+func (u *userSuite) TestUpdateUserPasswdUserInput() {
+	u.mockNoGroups()
+	userInfo := &entity.UserInfo{
+		ID:    test.UserIDCorrect,
+		Phone: test.UserPhoneCorrect,
+	}
+
+	u.dbTx.On("Begin").Return(&u.dbTx, nil)
+	u.userInfoRepo.On("WithTx", mock.Anything).Return(&u.userInfoRepo)
+	u.userInfoRepo.On("Get", context.Background(), test.TenantIDCorrect, test.UserIDCorrect).Return(&entity.UserInfo{ID: test.UserIDCorrect,
+		LoginID: test.UserLoginIDCorrect, Role: entity.UserRoleUser, Email: test.UserEmailCorrect}, nil)
+	u.dbTx.On("Rollback").Return(nil)
+
+	// The password is checked with the user's login ID and email in DB
+	err := u.userService.UpdateUser(context.Background(), &test.SubjectAdminCorrect, userInfo, "my-"+test.UserEmailCorrect)
+	require.Equal(u.T(), ErrPasswdUserInput, err)
+	u.userSecretRepo.AssertNotCalled(u.T(), "Update", mock.Anything, mock.Anything)
+}
+
+func (u *userSuite) TestUpdateUserEmailChanged() {
+	u.mockNoGroups()
+	userInfo := &entity.UserInfo{
+		ID:    test.UserIDCorrect,
+		Email: test.UserEmailCorrect2,
+	}
+
+	u.dbTx.On("Begin").Return(&u.dbTx, nil)
+	u.userInfoRepo.On("WithTx", mock.Anything).Return(&u.userInfoRepo)
+	u.userInfoRepo.On("Get", context.Background(), test.TenantIDCorrect, test.UserIDCorrect).Return(&entity.UserInfo{ID: test.UserIDCorrect, Role: entity.UserRoleUser,
+		Email: test.UserEmailCorrect, EmailVerified: true}, nil)
+	u.userInfoRepo.On("Update", context.Background(), userInfo).Return(nil)
+	u.userInfoRepo.On("UpdateEmailVerified", context.Background(), test.TenantIDCorrect, test.UserIDCorrect, (*time.Time)(nil)).Return(nil)
+	u.emailVerificationRepo.On("WithTx", mock.Anything).Return(&u.emailVerificationRepo)
+	u.dbTx.On("Commit").Return(nil)
+	u.mockEmailVerification(nil)
+
+	err := u.userService.UpdateUser(context.Background(), &test.SubjectUserCorrect, userInfo, "")
+	require.NoError(u.T(), err)
+
+	// Verification of the old email is reset, and a verification token is sent to the new email
+	u.userInfoRepo.AssertCalled(u.T(), "UpdateEmailVerified", context.Background(), test.TenantIDCorrect, test.UserIDCorrect, (*time.Time)(nil))
+	u.emailVerificationRepo.AssertNumberOfCalls(u.T(), "DeleteByUser", 2)
+	u.mailSender.AssertCalled(u.T(), "Send", mock.Anything, mock.MatchedBy(func(msg *mail.Message) bool {
+		return msg.To == test.UserEmailCorrect2
+	}))
+}
+
+func (u *userSuite) TestUpdateUserRoleChanged() {
+	u.mockNoGroups()
+	userInfo := &entity.UserInfo{
+		ID:      test.UserIDCorrect,
+		LoginID: test.UserLoginIDCorrect,
+		Role:    entity.UserRoleUser,
+		Phone:   test.UserPhoneCorrect,
+		Email:   test.UserEmailCorrect,
+	}
+
+	u.dbTx.On("Begin").Return(&u.dbTx, nil)
+	u.userInfoRepo.On("WithTx", mock.Anything).Return(&u.userInfoRepo)
+	u.userInfoRepo.On("Get", context.Background(), test.TenantIDCorrect, test.UserIDCorrect).Return(&entity.UserInfo{ID: test.UserIDCorrect, Role: entity.UserRoleAdmin,
+		Email: test.UserEmailCorrect}, nil)
+	u.roleRepo.On("WithTx", mock.Anything).Return(&u.roleRepo)
+	u.roleRepo.On("Get", context.Background(), string(entity.UserRoleUser)).Return(&entity.Role{Name: string(entity.UserRoleUser)}, nil)
+	u.userInfoRepo.On("Update", context.Background(), userInfo).Return(nil)
+	u.mockPasswdChange(test.UserPasswdCorrect2)
+	u.tokenRevocationRepo.On("WithTx", mock.Anything).Return(&u.tokenRevocationRepo)
+	u.tokenRevocationRepo.On("Create", context.Background(), mock.Anything).Return(nil)
+	u.dbTx.On("Commit").Return(nil)
+
+	issuedAt := time.Now().Add(-time.Minute)
+	err := u.userService.UpdateUser(context.Background(), &test.SubjectAdminCorrect, userInfo, test.UserPasswdCorrect)
+	require.NoError(u.T(), err)
+	require.True(u.T(), u.revocationList.IsRevoked(&token.TokenClaims{
+		StandardClaims: jwt.StandardClaims{IssuedAt: issuedAt.Unix()},
+		AuthClaims:     token.AuthClaims{UserID: test.UserIDCorrect.String()},
+	}))
+}
+
+func (u *userSuite) TestUpdateUserOwnRoleUnauthorized() {
+	u.mockNoGroups()
+	userInfo := &entity.UserInfo{
+		ID:   test.UserIDCorrect,
+		Role: entity.UserRoleAdmin,
+	}
+
+	u.dbTx.On("Begin").Return(&u.dbTx, nil)
+	u.dbTx.On("Rollback").Return(nil)
+	u.userInfoRepo.On("WithTx", mock.Anything).Return(&u.userInfoRepo)
+	u.userInfoRepo.On("Get", context.Background(), test.TenantIDCorrect, test.UserIDCorrect).Return(&entity.UserInfo{ID: test.UserIDCorrect, Role: entity.UserRoleUser}, nil)
+
+	err := u.userService.UpdateUser(context.Background(), &test.SubjectUserCorrect, userInfo, test.UserPasswdCorrect)
+	require.Equal(u.T(), ErrUnauthorized, err)
+	u.userInfoRepo.AssertNotCalled(u.T(), "Update", mock.Anything, mock.Anything)
+}
+
+func (u *userSuite) TestUpdateUserTenantAdminRoleChanged() {
+	u.mockNoGroups()
+	userInfo := &entity.UserInfo{
+		ID:   test.UserIDCorrect,
+		Role: entity.UserRoleTenantAdmin,
+	}
+
+	u.dbTx.On("Begin").Return(&u.dbTx, nil)
+	u.userInfoRepo.On("WithTx", mock.Anything).Return(&u.userInfoRepo)
+	u.userInfoRepo.On("Get", context.Background(), test.TenantIDCorrect, test.UserIDCorrect).Return(&entity.UserInfo{ID: test.UserIDCorrect, Role: entity.UserRoleUser}, nil)
+	u.roleRepo.On("WithTx", mock.Anything).Return(&u.roleRepo)
+	u.roleRepo.On("Get", context.Background(), string(entity.UserRoleTenantAdmin)).Return(&entity.Role{Name: string(entity.UserRoleTenantAdmin)}, nil)
+	u.userInfoRepo.On("Update", context.Background(), userInfo).Return(nil)
+	u.mockPasswdChange(test.UserPasswdCorrect2)
+	u.tokenRevocationRepo.On("WithTx", mock.Anything).Return(&u.tokenRevocationRepo)
+	u.tokenRevocationRepo.On("Create", context.Background(), mock.Anything).Return(nil)
 	u.dbTx.On("Commit").Return(nil)
 
-	err := u.userService.UpdateUser(context.Background(), userInfo, test.UserPasswdCorrect)
+	err := u.userService.UpdateUser(context.Background(), &test.SubjectTenantAdminCorrect, userInfo, test.UserPasswdCorrect)
 	require.NoError(u.T(), err)
+	require.Equal(u.T(), test.TenantIDCorrect, userInfo.TenantID)
+}
+
+func (u *userSuite) TestUpdateUserTenantAdminToAdminUnauthorized() {
+	u.mockNoGroups()
+	userInfo := &entity.UserInfo{
+		ID:   test.UserIDCorrect,
+		Role: entity.UserRoleAdmin,
+	}
+
+	u.dbTx.On("Begin").Return(&u.dbTx, nil)
+	u.dbTx.On("Rollback").Return(nil)
+	u.userInfoRepo.On("WithTx", mock.Anything).Return(&u.userInfoRepo)
+	u.userInfoRepo.On("Get", context.Background(), test.TenantIDCorrect, test.UserIDCorrect).Return(&entity.UserInfo{ID: test.UserIDCorrect, Role: entity.UserRoleUser}, nil)
+
+	err := u.userService.UpdateUser(context.Background(), &test.SubjectTenantAdminCorrect, userInfo, test.UserPasswdCorrect)
+	require.Equal(u.T(), ErrUnauthorized, err)
+	u.userInfoRepo.AssertNotCalled(u.T(), "Update", mock.Anything, mock.Anything)
+}
+
+func (u *userSuite) TestUpdateUserTenantAdminGroupAdminUnauthorized() {
+	u.mockAdminGroup()
+	userInfo := &entity.UserInfo{
+		ID:   test.UserIDCorrect,
+		Role: entity.UserRoleUser,
+	}
+
+	// Tenant admins can't reset the password of admins by groups
+	u.dbTx.On("Begin").Return(&u.dbTx, nil)
+	u.dbTx.On("Rollback").Return(nil)
+	u.userInfoRepo.On("WithTx", mock.Anything).Return(&u.userInfoRepo)
+	u.userInfoRepo.On("Get", context.Background(), test.TenantIDCorrect, test.UserIDCorrect).Return(&entity.UserInfo{ID: test.UserIDCorrect, Role: entity.UserRoleUser}, nil)
+
+	err := u.userService.UpdateUser(context.Background(), &test.SubjectTenantAdminCorrect, userInfo, test.UserPasswdCorrect)
+	require.Equal(u.T(), ErrUnauthorized, err)
+	u.userInfoRepo.AssertNotCalled(u.T(), "Update", mock.Anything, mock.Anything)
+	u.userSecretRepo.AssertNotCalled(u.T(), "Update", mock.Anything, mock.Anything)
+}
+
+func (u *userSuite) TestUpdateUserTenantAdminWideRoleUnauthorized() {
+	u.mockNoGroups()
+	// Tenant admins can't grant roles having wider permissions than theirs
+	userInfo := &entity.UserInfo{
+		ID:   test.UserIDCorrect,
+		Role: entity.UserRole(test.RoleNameWideCorrect),
+	}
+
+	u.dbTx.On("Begin").Return(&u.dbTx, nil)
+	u.dbTx.On("Rollback").Return(nil)
+	u.userInfoRepo.On("WithTx", mock.Anything).Return(&u.userInfoRepo)
+	u.userInfoRepo.On("Get", context.Background(), test.TenantIDCorrect, test.UserIDCorrect).Return(&entity.UserInfo{ID: test.UserIDCorrect, Role: entity.UserRoleUser}, nil)
+
+	err := u.userService.UpdateUser(context.Background(), &test.SubjectTenantAdminCorrect, userInfo, test.UserPasswdCorrect)
+	require.Equal(u.T(), ErrUnauthorized, err)
+	u.userInfoRepo.AssertNotCalled(u.T(), "Update", mock.Anything, mock.Anything)
+}
+
+func (u *userSuite) TestDeleteUserTenantAdminAdminUnauthorized() {
+	u.mockNoGroups()
+	u.dbTx.On("Begin").Return(&u.dbTx, nil)
+	u.dbTx.On("Rollback").Return(nil)
+	u.userInfoRepo.On("WithTx", mock.Anything).Return(&u.userInfoRepo)
+	u.userInfoRepo.On("Get", context.Background(), test.TenantIDCorrect, test.UserIDCorrect).Return(&entity.UserInfo{ID: test.UserIDCorrect, Role: entity.UserRoleAdmin}, nil)
+
+	err := u.userService.DeleteUser(context.Background(), &test.SubjectTenantAdminCorrect, test.UserIDCorrect)
+	require.Equal(u.T(), ErrUnauthorized, err)
+	u.userInfoRepo.AssertNotCalled(u.T(), "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (u *userSuite) TestDeleteUserTenantAdminGroupAdminUnauthorized() {
+	u.mockAdminGroup()
+	u.dbTx.On("Begin").Return(&u.dbTx, nil)
+	u.dbTx.On("Rollback").Return(nil)
+	u.userInfoRepo.On("WithTx", mock.Anything).Return(&u.userInfoRepo)
+	u.userInfoRepo.On("Get", context.Background(), test.TenantIDCorrect, test.UserIDCorrect).Return(&entity.UserInfo{ID: test.UserIDCorrect, Role: entity.UserRoleUser}, nil)
+
+	err := u.userService.DeleteUser(context.Background(), &test.SubjectTenantAdminCorrect, test.UserIDCorrect)
+	require.Equal(u.T(), ErrUnauthorized, err)
+	u.userInfoRepo.AssertNotCalled(u.T(), "Delete", mock.Anything, mock.Anything, mock.Anything)
 }
 
 func (u *userSuite) TestDeleteUserSuccess() {
+	u.mockNoGroups()
 	userInfo := &entity.UserInfo{
 		ID:      test.UserIDCorrect,
 		LoginID: test.UserLoginIDCorrect,
@@ -160,15 +787,117 @@ func (u *userSuite) TestDeleteUserSuccess() {
 
 	u.dbTx.On("Begin").Return(&u.dbTx, nil)
 	u.userInfoRepo.On("WithTx", mock.Anything).Return(&u.userInfoRepo)
-	u.userInfoRepo.On("Get", context.Background(), test.UserIDCorrect).Return(userInfo, nil)
+	u.userInfoRepo.On("Get", context.Background(), test.TenantIDCorrect, test.UserIDCorrect).Return(userInfo, nil)
 	u.userInfoRepo.On("WithTx", mock.Anything).Return(&u.userInfoRepo)
-	u.userInfoRepo.On("Delete", context.Background(), test.UserIDCorrect).Return(nil)
+	u.userInfoRepo.On("Delete", context.Background(), test.TenantIDCorrect, test.UserIDCorrect).Return(nil)
 	u.userSecretRepo.On("WithTx", mock.Anything).Return(&u.userSecretRepo)
 	u.userSecretRepo.On("Delete", context.Background(), mock.Anything).Return(nil)
+	u.passwdHistoryRepo.On("WithTx", mock.Anything).Return(&u.passwdHistoryRepo)
+	u.passwdHistoryRepo.On("DeleteByUser", context.Background(), test.UserIDCorrect).Return(nil)
+	u.mfaRecoveryCodeRepo.On("WithTx", mock.Anything).Return(&u.mfaRecoveryCodeRepo)
+	u.mfaRecoveryCodeRepo.On("DeleteByUser", context.Background(), test.UserIDCorrect).Return(nil)
+	u.webAuthnCredentialRepo.On("WithTx", mock.Anything).Return(&u.webAuthnCredentialRepo)
+	u.webAuthnCredentialRepo.On("DeleteByUser", context.Background(), test.UserIDCorrect).Return(nil)
+	u.emailVerificationRepo.On("WithTx", mock.Anything).Return(&u.emailVerificationRepo)
+	u.emailVerificationRepo.On("DeleteByUser", context.Background(), test.UserIDCorrect).Return(nil)
+	u.groupMemberRepo.On("WithTx", mock.Anything).Return(&u.groupMemberRepo)
+	u.groupMemberRepo.On("DeleteByMember", context.Background(), test.UserIDCorrect).Return(nil)
+	u.tokenRevocationRepo.On("WithTx", mock.Anything).Return(&u.tokenRevocationRepo)
+	u.tokenRevocationRepo.On("Create", context.Background(), mock.Anything).Return(nil)
 	u.outboxRepo.On("WithTx", mock.Anything).Return(&u.outboxRepo)
 	u.outboxRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
 	u.dbTx.On("Commit").Return(nil)
 
-	err := u.userService.DeleteUser(context.Background(), test.UserIDCorrect)
+	err := u.userService.DeleteUser(context.Background(), &test.SubjectAdminCorrect, test.UserIDCorrect)
+	require.NoError(u.T(), err)
+	u.tokenRevocationRepo.AssertCalled(u.T(), "Create", context.Background(), mock.Anything)
+}
+
+func (u *userSuite) TestUpdateUserPasswdSuccess() {
+	u.mockNoGroups()
+	u.dbTx.On("Begin").Return(&u.dbTx, nil)
+	u.userInfoRepo.On("WithTx", mock.Anything).Return(&u.userInfoRepo)
+	u.userInfoRepo.On("Get", context.Background(), test.TenantIDCorrect, test.UserIDCorrect).Return(&entity.UserInfo{ID: test.UserIDCorrect,
+		LoginID: test.UserLoginIDCorrect, Role: entity.UserRoleUser}, nil)
+	u.mockPasswdChange(test.UserPasswdCorrect)
+	u.dbTx.On("Commit").Return(nil)
+
+	// Only the session of the subject is kept
+	subject := test.SubjectUserCorrect
+	subject.SessionID = test.SessionIDCorrect.String()
+	issuedAt := time.Now().Add(-time.Minute)
+	err := u.userService.UpdateUserPasswd(context.Background(), &subject, test.UserIDCorrect, test.UserPasswdCorrect, test.UserPasswdCorrect2)
+	require.NoError(u.T(), err)
+	u.userSecretRepo.AssertNumberOfCalls(u.T(), "Update", 1)
+	u.sessionRepo.AssertNumberOfCalls(u.T(), "Delete", 1)
+	u.sessionRepo.AssertCalled(u.T(), "Delete", context.Background(), test.SessionIDCorrect2)
+	require.True(u.T(), u.revocationList.IsRevoked(&token.TokenClaims{
+		StandardClaims: jwt.StandardClaims{IssuedAt: issuedAt.Unix()},
+		AuthClaims:     token.AuthClaims{UserID: test.UserIDCorrect.String()},
+	}))
+}
+
+func (u *userSuite) TestUpdateUserPasswdWrongPasswd() {
+	u.mockNoGroups()
+	u.dbTx.On("Begin").Return(&u.dbTx, nil)
+	u.userInfoRepo.On("WithTx", mock.Anything).Return(&u.userInfoRepo)
+	u.userInfoRepo.On("Get", context.Background(), test.TenantIDCorrect, test.UserIDCorrect).Return(&entity.UserInfo{ID: test.UserIDCorrect,
+		LoginID: test.UserLoginIDCorrect, Role: entity.UserRoleUser}, nil)
+	u.mockPasswdChange(test.UserPasswdCorrect)
+	u.dbTx.On("Rollback").Return(nil)
+
+	err := u.userService.UpdateUserPasswd(context.Background(), &test.SubjectUserCorrect, test.UserIDCorrect, test.UserPasswdCorrect2, test.UserPasswdPassphrase)
+	require.Equal(u.T(), ErrUnauthorized, err)
+	u.userSecretRepo.AssertNotCalled(u.T(), "Update", mock.Anything, mock.Anything)
+}
+
+// Mock the transaction removing the password of the user having passkeys
+func (u *userSuite) mockPasswdRemove(passkeyCount int) {
+	passwdHash, err := hashing.GetPasswdHash(test.UserPasswdCorrect)
+	require.NoError(u.T(), err)
+
+	u.dbTx.On("Begin").Return(&u.dbTx, nil)
+	u.userInfoRepo.On("WithTx", mock.Anything).Return(&u.userInfoRepo)
+	u.userInfoRepo.On("Get", context.Background(), test.TenantIDCorrect, test.UserIDCorrect).Return(&entity.UserInfo{ID: test.UserIDCorrect,
+		LoginID: test.UserLoginIDCorrect, Role: entity.UserRoleUser}, nil)
+	u.userSecretRepo.On("WithTx", mock.Anything).Return(&u.userSecretRepo)
+	u.userSecretRepo.On("Get", context.Background(), test.UserIDCorrect).Return(&entity.UserSecret{ID: test.UserIDCorrect, PasswdPHC: passwdHash}, nil)
+	u.userSecretRepo.On("DeletePasswd", context.Background(), test.UserIDCorrect, mock.Anything).Return(nil)
+	u.webAuthnCredentialRepo.On("WithTx", mock.Anything).Return(&u.webAuthnCredentialRepo)
+	u.webAuthnCredentialRepo.On("CountByUser", context.Background(), test.UserIDCorrect).Return(passkeyCount, nil)
+}
+
+func (u *userSuite) TestRemoveUserPasswdSuccess() {
+	u.mockNoGroups()
+	u.mockPasswdRemove(1)
+	u.dbTx.On("Commit").Return(nil)
+
+	err := u.userService.RemoveUserPasswd(context.Background(), &test.SubjectUserCorrect, test.UserIDCorrect, test.UserPasswdCorrect)
 	require.NoError(u.T(), err)
+	u.userSecretRepo.AssertCalled(u.T(), "DeletePasswd", context.Background(), test.UserIDCorrect, mock.Anything)
+}
+
+func (u *userSuite) TestRemoveUserPasswdWrongPasswd() {
+	u.mockNoGroups()
+	u.mockPasswdRemove(1)
+	u.dbTx.On("Rollback").Return(nil)
+
+	err := u.userService.RemoveUserPasswd(context.Background(), &test.SubjectUserCorrect, test.UserIDCorrect, test.UserPasswdCorrect2)
+	require.Equal(u.T(), ErrUnauthorized, err)
+	u.userSecretRepo.AssertNotCalled(u.T(), "DeletePasswd", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (u *userSuite) TestRemoveUserPasswdPasskeyRequired() {
+	u.mockNoGroups()
+	u.mockPasswdRemove(0)
+	u.dbTx.On("Rollback").Return(nil)
+
+	err := u.userService.RemoveUserPasswd(context.Background(), &test.SubjectUserCorrect, test.UserIDCorrect, test.UserPasswdCorrect)
+	require.Equal(u.T(), ErrPasskeyRequired, err)
+	u.userSecretRepo.AssertNotCalled(u.T(), "DeletePasswd", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (u *userSuite) TestRemoveUserPasswdOtherUser() {
+	err := u.userService.RemoveUserPasswd(context.Background(), &test.SubjectOtherUserCorrect, test.UserIDCorrect, test.UserPasswdCorrect)
+	require.Equal(u.T(), ErrUnauthorized, err)
 }
@@ -4,7 +4,15 @@ package errors
 const (
 	// Code
 	// Resource
-	codeResouceUser = "_USER"
+	codeResouceUser        = "_USER"
+	codeResouceOAuthClient = "_OAUTH_CLIENT"
+	codeResouceRole        = "_ROLE"
+	codeResoucePermission  = "_PERMISSION"
+	codeResouceTenant      = "_TENANT"
+	codeResouceGroup       = "_GROUP"
+	codeResouceGroupMember = "_GROUP_MEMBER"
+	codeResouceLoginLock   = "_LOGIN_LOCK"
+	codeResoucePasskey     = "_PASSKEY"
 
 	// Common error
 	CodeBadRequest   = "BAD_REQEUEST"
@@ -12,16 +20,92 @@ const (
 	CodeServerError  = "INTERNAL_SERVER_ERROR"
 
 	// Resource not found
-	CodeNotFound     = "NOT_FOUND"
-	CodeNotFoundUser = CodeNotFound + codeResouceUser
+	CodeNotFound            = "NOT_FOUND"
+	CodeNotFoundUser        = CodeNotFound + codeResouceUser
+	CodeNotFoundOAuthClient = CodeNotFound + codeResouceOAuthClient
+	CodeNotFoundRole        = CodeNotFound + codeResouceRole
+	CodeNotFoundPermission  = CodeNotFound + codeResoucePermission
+	CodeNotFoundTenant      = CodeNotFound + codeResouceTenant
+	CodeNotFoundGroup       = CodeNotFound + codeResouceGroup
+	CodeNotFoundGroupMember = CodeNotFound + codeResouceGroupMember
+	CodeNotFoundLoginLock   = CodeNotFound + codeResouceLoginLock
+	CodeNotFoundPasskey     = CodeNotFound + codeResoucePasskey
 
 	// Resource confilct
-	CodeConflict     = "CONFLICT"
-	CodeConflictUser = CodeConflict + codeResouceUser
+	CodeConflict            = "CONFLICT"
+	CodeConflictUser        = CodeConflict + codeResouceUser
+	CodeConflictRole        = CodeConflict + codeResouceRole
+	CodeConflictPermission  = CodeConflict + codeResoucePermission
+	CodeConflictTenant      = CodeConflict + codeResouceTenant
+	CodeConflictGroup       = CodeConflict + codeResouceGroup
+	CodeConflictGroupMember = CodeConflict + codeResouceGroupMember
+
+	// Token key
+	CodeTokenKeyRotationDisabled = "TOKEN_KEY_ROTATION_DISABLED"
+
+	// Token
+	CodeTokenAudienceNotAllowed = "TOKEN_AUDIENCE_NOT_ALLOWED"
+	CodeTokenScopeNotAllowed    = "TOKEN_SCOPE_NOT_ALLOWED"
+
+	// OAuth client
+	CodeOAuthClientGrantTypeNotAllowed = "OAUTH_CLIENT_GRANT_TYPE_NOT_ALLOWED"
+
+	// Role
+	CodeRoleNotExist = "ROLE_NOT_EXIST"
+	CodeRoleInUse    = "ROLE_IN_USE"
+
+	// Tenant
+	CodeTenantNotExist = "TENANT_NOT_EXIST"
+	CodeTenantInUse    = "TENANT_IN_USE"
+
+	// Group
+	CodeGroupMemberNotExist = "GROUP_MEMBER_NOT_EXIST"
+	CodeGroupMemberCycle    = "GROUP_MEMBER_CYCLE"
+
+	// Password policy
+	CodePasswdTooShort    = "PASSWD_TOO_SHORT"
+	CodePasswdTooLong     = "PASSWD_TOO_LONG"
+	CodePasswdCharClasses = "PASSWD_CHAR_CLASSES"
+	CodePasswdBreached    = "PASSWD_BREACHED"
+	CodePasswdUserInput   = "PASSWD_USER_INPUT"
+	CodePasswdReused      = "PASSWD_REUSED"
+
+	// Password expiration
+	CodePasswdExpired = "PASSWD_EXPIRED"
+
+	// Login lock
+	CodeLoginLocked = "LOGIN_LOCKED"
+
+	// Rate limit
+	CodeRateLimited = "RATE_LIMITED"
+
+	// MFA
+	CodeMFADisabled       = "MFA_DISABLED"
+	CodeMFARequired       = "MFA_REQUIRED"
+	CodeMFACodeWrong      = "MFA_CODE_WRONG"
+	CodeMFAAlreadyEnabled = "MFA_ALREADY_ENABLED"
+	CodeMFANotEnabled     = "MFA_NOT_ENABLED"
+
+	// Passkey
+	CodePasskeyDisabled           = "PASSKEY_DISABLED"
+	CodePasskeyVerificationFailed = "PASSKEY_VERIFICATION_FAILED"
+	CodePasskeyRequired           = "PASSKEY_REQUIRED"
+
+	// Email verification
+	CodeEmailNotVerified     = "EMAIL_NOT_VERIFIED"
+	CodeEmailAlreadyVerified = "EMAIL_ALREADY_VERIFIED"
 
 	// Message
 	// Resource
-	msgResourcesUser = "User "
+	msgResourcesUser        = "User "
+	msgResourcesOAuthClient = "OAuth client "
+	msgResourcesRole        = "Role "
+	msgResourcesPermission  = "Permission "
+	msgResourcesTenant      = "Tenant "
+	msgResourcesGroup       = "Group "
+	msgResourcesGroupMember = "Group member "
+	msgResourcesLoginLock   = "Login lock "
+	msgResourcesPasskey     = "Passkey "
 
 	// Common error
 	MsgBadRequest   = "Bad Request"
@@ -29,17 +113,93 @@ const (
 	MsgServerError  = "Internal server error"
 
 	// Resource not found
-	MsgNotFound     = "Not found"
-	MsgNotFoundUser = msgResourcesUser + MsgNotFound
+	MsgNotFound            = "Not found"
+	MsgNotFoundUser        = msgResourcesUser + MsgNotFound
+	MsgNotFoundOAuthClient = msgResourcesOAuthClient + MsgNotFound
+	MsgNotFoundRole        = msgResourcesRole + MsgNotFound
+	MsgNotFoundPermission  = msgResourcesPermission + MsgNotFound
+	MsgNotFoundTenant      = msgResourcesTenant + MsgNotFound
+	MsgNotFoundGroup       = msgResourcesGroup + MsgNotFound
+	MsgNotFoundGroupMember = msgResourcesGroupMember + MsgNotFound
+	MsgNotFoundLoginLock   = msgResourcesLoginLock + MsgNotFound
+	MsgNotFoundPasskey     = msgResourcesPasskey + MsgNotFound
 
 	// Resource conflict
-	MsgConflict     = "Conflit"
-	MsgConflictUser = msgResourcesUser + MsgConflict
+	MsgConflict            = "Conflit"
+	MsgConflictUser        = msgResourcesUser + MsgConflict
+	MsgConflictRole        = msgResourcesRole + MsgConflict
+	MsgConflictPermission  = msgResourcesPermission + MsgConflict
+	MsgConflictTenant      = msgResourcesTenant + MsgConflict
+	MsgConflictGroup       = msgResourcesGroup + MsgConflict
+	MsgConflictGroupMember = msgResourcesGroupMember + MsgConflict
+
+	// Token key
+	MsgTokenKeyRotationDisabled = "Token key rotation is disabled"
+
+	// Token
+	MsgTokenAudienceNotAllowed = "Token audience isn't allowed"
+	MsgTokenScopeNotAllowed    = "Token scope isn't allowed"
+
+	// OAuth client
+	MsgOAuthClientGrantTypeNotAllowed = "Client credentials grant isn't allowed for public OAuth client"
+
+	// Role
+	MsgRoleNotExist = "Role doesn't exist"
+	MsgRoleInUse    = "Role is in use by users or groups"
+
+	// Tenant
+	MsgTenantNotExist = "Tenant doesn't exist"
+	MsgTenantInUse    = "Tenant is in use by users or groups"
+
+	// Group
+	MsgGroupMemberNotExist = "Group member doesn't exist"
+	MsgGroupMemberCycle    = "Group member makes a cycle of groups"
+
+	// Password policy
+	MsgPasswdTooShort    = "Password is too short"
+	MsgPasswdTooLong     = "Password is too long"
+	MsgPasswdCharClasses = "Password doesn't have enough character classes"
+	MsgPasswdBreached    = "Password is found in breached passwords"
+	MsgPasswdUserInput   = "Password contains the login ID or email"
+	MsgPasswdReused      = "Password is one of the last passwords"
+
+	// Password expiration
+	MsgPasswdExpired = "Password is expired and needs to be changed"
+
+	// Login lock
+	MsgLoginLocked = "Login is locked by failed logins, try again later"
+
+	// Rate limit
+	MsgRateLimited = "Too many requests, try again later"
+
+	// MFA
+	MsgMFADisabled       = "MFA is disabled"
+	MsgMFARequired       = "MFA code is required, exchange the MFA token and a MFA code for tokens"
+	MsgMFACodeWrong      = "MFA code is wrong"
+	MsgMFAAlreadyEnabled = "MFA is already enabled"
+	MsgMFANotEnabled     = "MFA isn't enabled"
+
+	// Passkey
+	MsgPasskeyDisabled           = "Passkey is disabled"
+	MsgPasskeyVerificationFailed = "Passkey verification failed, begin the ceremony again"
+	MsgPasskeyRequired           = "Passkey is required for user without password"
+
+	// Email verification
+	MsgEmailNotVerified     = "Email isn't verified and needs to be verified"
+	MsgEmailAlreadyVerified = "Email is already verified"
 )
 
 // Error resource
 type ErrResouce string
 
 const (
-	ErrResouceUser ErrResouce = "USER"
+	ErrResouceUser        ErrResouce = "USER"
+	ErrResouceOAuthClient ErrResouce = "OAUTH_CLIENT"
+	ErrResouceRole        ErrResouce = "ROLE"
+	ErrResoucePermission  ErrResouce = "PERMISSION"
+	ErrResouceTenant      ErrResouce = "TENANT"
+	ErrResouceGroup       ErrResouce = "GROUP"
+	ErrResouceGroupMember ErrResouce = "GROUP_MEMBER"
+	ErrResouceLoginLock   ErrResouce = "LOGIN_LOCK"
+	ErrResoucePasskey     ErrResouce = "PASSKEY"
 )
@@ -20,7 +20,15 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type TokenClient interface {
 	LoginToken(ctx context.Context, in *TokenLoginRequest, opts ...grpc.CallOption) (*TokenInfosResponse, error)
-	RefreshToken(ctx context.Context, in *TokenRefreshRequest, opts ...grpc.CallOption) (*TokenInfoResponse, error)
+	MFAToken(ctx context.Context, in *TokenMFARequest, opts ...grpc.CallOption) (*TokenInfosResponse, error)
+	BeginPasskeyToken(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*PasskeyOptionsResponse, error)
+	FinishPasskeyToken(ctx context.Context, in *TokenPasskeyFinishRequest, opts ...grpc.CallOption) (*TokenInfosResponse, error)
+	RefreshToken(ctx context.Context, in *TokenRefreshRequest, opts ...grpc.CallOption) (*TokenInfosResponse, error)
+	GetJWKS(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*JWKSResponse, error)
+	LogoutToken(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
+	LogoutAllToken(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
+	RevokeUserToken(ctx context.Context, in *UserIDRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	IntrospectToken(ctx context.Context, in *TokenIntrospectRequest, opts ...grpc.CallOption) (*TokenIntrospectionResponse, error)
 }
 
 type tokenClient struct {
@@ -40,8 +48,35 @@ func (c *tokenClient) LoginToken(ctx context.Context, in *TokenLoginRequest, opt
 	return out, nil
 }
 
-func (c *tokenClient) RefreshToken(ctx context.Context, in *TokenRefreshRequest, opts ...grpc.CallOption) (*TokenInfoResponse, error) {
-	out := new(TokenInfoResponse)
+func (c *tokenClient) MFAToken(ctx context.Context, in *TokenMFARequest, opts ...grpc.CallOption) (*TokenInfosResponse, error) {
+	out := new(TokenInfosResponse)
+	err := c.cc.Invoke(ctx, "/Token/MFAToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tokenClient) BeginPasskeyToken(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*PasskeyOptionsResponse, error) {
+	out := new(PasskeyOptionsResponse)
+	err := c.cc.Invoke(ctx, "/Token/BeginPasskeyToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tokenClient) FinishPasskeyToken(ctx context.Context, in *TokenPasskeyFinishRequest, opts ...grpc.CallOption) (*TokenInfosResponse, error) {
+	out := new(TokenInfosResponse)
+	err := c.cc.Invoke(ctx, "/Token/FinishPasskeyToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tokenClient) RefreshToken(ctx context.Context, in *TokenRefreshRequest, opts ...grpc.CallOption) (*TokenInfosResponse, error) {
+	out := new(TokenInfosResponse)
 	err := c.cc.Invoke(ctx, "/Token/RefreshToken", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -49,12 +84,65 @@ func (c *tokenClient) RefreshToken(ctx context.Context, in *TokenRefreshRequest,
 	return out, nil
 }
 
+func (c *tokenClient) GetJWKS(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*JWKSResponse, error) {
+	out := new(JWKSResponse)
+	err := c.cc.Invoke(ctx, "/Token/GetJWKS", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tokenClient) LogoutToken(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/Token/LogoutToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tokenClient) LogoutAllToken(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/Token/LogoutAllToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tokenClient) RevokeUserToken(ctx context.Context, in *UserIDRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/Token/RevokeUserToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tokenClient) IntrospectToken(ctx context.Context, in *TokenIntrospectRequest, opts ...grpc.CallOption) (*TokenIntrospectionResponse, error) {
+	out := new(TokenIntrospectionResponse)
+	err := c.cc.Invoke(ctx, "/Token/IntrospectToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TokenServer is the server API for Token service.
 // All implementations must embed UnimplementedTokenServer
 // for forward compatibility
 type TokenServer interface {
 	LoginToken(context.Context, *TokenLoginRequest) (*TokenInfosResponse, error)
-	RefreshToken(context.Context, *TokenRefreshRequest) (*TokenInfoResponse, error)
+	MFAToken(context.Context, *TokenMFARequest) (*TokenInfosResponse, error)
+	BeginPasskeyToken(context.Context, *empty.Empty) (*PasskeyOptionsResponse, error)
+	FinishPasskeyToken(context.Context, *TokenPasskeyFinishRequest) (*TokenInfosResponse, error)
+	RefreshToken(context.Context, *TokenRefreshRequest) (*TokenInfosResponse, error)
+	GetJWKS(context.Context, *empty.Empty) (*JWKSResponse, error)
+	LogoutToken(context.Context, *empty.Empty) (*empty.Empty, error)
+	LogoutAllToken(context.Context, *empty.Empty) (*empty.Empty, error)
+	RevokeUserToken(context.Context, *UserIDRequest) (*empty.Empty, error)
+	IntrospectToken(context.Context, *TokenIntrospectRequest) (*TokenIntrospectionResponse, error)
 	mustEmbedUnimplementedTokenServer()
 }
 
@@ -65,9 +153,33 @@ type UnimplementedTokenServer struct {
 func (UnimplementedTokenServer) LoginToken(context.Context, *TokenLoginRequest) (*TokenInfosResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method LoginToken not implemented")
 }
-func (UnimplementedTokenServer) RefreshToken(context.Context, *TokenRefreshRequest) (*TokenInfoResponse, error) {
+func (UnimplementedTokenServer) MFAToken(context.Context, *TokenMFARequest) (*TokenInfosResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MFAToken not implemented")
+}
+func (UnimplementedTokenServer) BeginPasskeyToken(context.Context, *empty.Empty) (*PasskeyOptionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BeginPasskeyToken not implemented")
+}
+func (UnimplementedTokenServer) FinishPasskeyToken(context.Context, *TokenPasskeyFinishRequest) (*TokenInfosResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinishPasskeyToken not implemented")
+}
+func (UnimplementedTokenServer) RefreshToken(context.Context, *TokenRefreshRequest) (*TokenInfosResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RefreshToken not implemented")
 }
+func (UnimplementedTokenServer) GetJWKS(context.Context, *empty.Empty) (*JWKSResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJWKS not implemented")
+}
+func (UnimplementedTokenServer) LogoutToken(context.Context, *empty.Empty) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LogoutToken not implemented")
+}
+func (UnimplementedTokenServer) LogoutAllToken(context.Context, *empty.Empty) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LogoutAllToken not implemented")
+}
+func (UnimplementedTokenServer) RevokeUserToken(context.Context, *UserIDRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeUserToken not implemented")
+}
+func (UnimplementedTokenServer) IntrospectToken(context.Context, *TokenIntrospectRequest) (*TokenIntrospectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IntrospectToken not implemented")
+}
 func (UnimplementedTokenServer) mustEmbedUnimplementedTokenServer() {}
 
 // UnsafeTokenServer may be embedded to opt out of forward compatibility for this service.
@@ -99,259 +211,1973 @@ func _Token_LoginToken_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Token_RefreshToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TokenRefreshRequest)
+func _Token_MFAToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TokenMFARequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TokenServer).RefreshToken(ctx, in)
+		return srv.(TokenServer).MFAToken(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Token/RefreshToken",
+		FullMethod: "/Token/MFAToken",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TokenServer).RefreshToken(ctx, req.(*TokenRefreshRequest))
+		return srv.(TokenServer).MFAToken(ctx, req.(*TokenMFARequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// Token_ServiceDesc is the grpc.ServiceDesc for Token service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var Token_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "Token",
-	HandlerType: (*TokenServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "LoginToken",
-			Handler:    _Token_LoginToken_Handler,
-		},
-		{
-			MethodName: "RefreshToken",
-			Handler:    _Token_RefreshToken_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "api/protobuf/api.proto",
-}
-
-// UserClient is the client API for User service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
-type UserClient interface {
-	ListUser(ctx context.Context, in *UserListRequest, opts ...grpc.CallOption) (*UserListResponse, error)
-	CreateUser(ctx context.Context, in *UserCreateRequest, opts ...grpc.CallOption) (*UserInfoResponse, error)
-	GetUser(ctx context.Context, in *UserIDRequest, opts ...grpc.CallOption) (*UserInfoResponse, error)
-	UpdateUser(ctx context.Context, in *UserUpdateRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	DeleteUser(ctx context.Context, in *UserIDRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-}
-
-type userClient struct {
-	cc grpc.ClientConnInterface
-}
-
-func NewUserClient(cc grpc.ClientConnInterface) UserClient {
-	return &userClient{cc}
-}
-
-func (c *userClient) ListUser(ctx context.Context, in *UserListRequest, opts ...grpc.CallOption) (*UserListResponse, error) {
-	out := new(UserListResponse)
-	err := c.cc.Invoke(ctx, "/User/ListUser", in, out, opts...)
-	if err != nil {
+func _Token_BeginPasskeyToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-func (c *userClient) CreateUser(ctx context.Context, in *UserCreateRequest, opts ...grpc.CallOption) (*UserInfoResponse, error) {
-	out := new(UserInfoResponse)
-	err := c.cc.Invoke(ctx, "/User/CreateUser", in, out, opts...)
-	if err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(TokenServer).BeginPasskeyToken(ctx, in)
 	}
-	return out, nil
-}
-
-func (c *userClient) GetUser(ctx context.Context, in *UserIDRequest, opts ...grpc.CallOption) (*UserInfoResponse, error) {
-	out := new(UserInfoResponse)
-	err := c.cc.Invoke(ctx, "/User/GetUser", in, out, opts...)
-	if err != nil {
-		return nil, err
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Token/BeginPasskeyToken",
 	}
-	return out, nil
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TokenServer).BeginPasskeyToken(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *userClient) UpdateUser(ctx context.Context, in *UserUpdateRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/User/UpdateUser", in, out, opts...)
-	if err != nil {
+func _Token_FinishPasskeyToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TokenPasskeyFinishRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(TokenServer).FinishPasskeyToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Token/FinishPasskeyToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TokenServer).FinishPasskeyToken(ctx, req.(*TokenPasskeyFinishRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *userClient) DeleteUser(ctx context.Context, in *UserIDRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/User/DeleteUser", in, out, opts...)
-	if err != nil {
+func _Token_RefreshToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TokenRefreshRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-// UserServer is the server API for User service.
-// All implementations must embed UnimplementedUserServer
-// for forward compatibility
-type UserServer interface {
-	ListUser(context.Context, *UserListRequest) (*UserListResponse, error)
-	CreateUser(context.Context, *UserCreateRequest) (*UserInfoResponse, error)
-	GetUser(context.Context, *UserIDRequest) (*UserInfoResponse, error)
-	UpdateUser(context.Context, *UserUpdateRequest) (*empty.Empty, error)
-	DeleteUser(context.Context, *UserIDRequest) (*empty.Empty, error)
-	mustEmbedUnimplementedUserServer()
-}
-
-// UnimplementedUserServer must be embedded to have forward compatible implementations.
-type UnimplementedUserServer struct {
-}
-
-func (UnimplementedUserServer) ListUser(context.Context, *UserListRequest) (*UserListResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListUser not implemented")
-}
-func (UnimplementedUserServer) CreateUser(context.Context, *UserCreateRequest) (*UserInfoResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
-}
-func (UnimplementedUserServer) GetUser(context.Context, *UserIDRequest) (*UserInfoResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
-}
-func (UnimplementedUserServer) UpdateUser(context.Context, *UserUpdateRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateUser not implemented")
-}
-func (UnimplementedUserServer) DeleteUser(context.Context, *UserIDRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
-}
-func (UnimplementedUserServer) mustEmbedUnimplementedUserServer() {}
-
-// UnsafeUserServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to UserServer will
-// result in compilation errors.
-type UnsafeUserServer interface {
-	mustEmbedUnimplementedUserServer()
-}
-
-func RegisterUserServer(s grpc.ServiceRegistrar, srv UserServer) {
-	s.RegisterService(&User_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(TokenServer).RefreshToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Token/RefreshToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TokenServer).RefreshToken(ctx, req.(*TokenRefreshRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _User_ListUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UserListRequest)
+func _Token_GetJWKS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServer).ListUser(ctx, in)
+		return srv.(TokenServer).GetJWKS(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/User/ListUser",
+		FullMethod: "/Token/GetJWKS",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServer).ListUser(ctx, req.(*UserListRequest))
+		return srv.(TokenServer).GetJWKS(ctx, req.(*empty.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _User_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UserCreateRequest)
+func _Token_LogoutToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServer).CreateUser(ctx, in)
+		return srv.(TokenServer).LogoutToken(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/User/CreateUser",
+		FullMethod: "/Token/LogoutToken",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServer).CreateUser(ctx, req.(*UserCreateRequest))
+		return srv.(TokenServer).LogoutToken(ctx, req.(*empty.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _User_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UserIDRequest)
+func _Token_LogoutAllToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServer).GetUser(ctx, in)
+		return srv.(TokenServer).LogoutAllToken(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/User/GetUser",
+		FullMethod: "/Token/LogoutAllToken",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServer).GetUser(ctx, req.(*UserIDRequest))
+		return srv.(TokenServer).LogoutAllToken(ctx, req.(*empty.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _User_UpdateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UserUpdateRequest)
+func _Token_RevokeUserToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserIDRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServer).UpdateUser(ctx, in)
+		return srv.(TokenServer).RevokeUserToken(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/User/UpdateUser",
+		FullMethod: "/Token/RevokeUserToken",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServer).UpdateUser(ctx, req.(*UserUpdateRequest))
+		return srv.(TokenServer).RevokeUserToken(ctx, req.(*UserIDRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _User_DeleteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UserIDRequest)
+func _Token_IntrospectToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TokenIntrospectRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServer).DeleteUser(ctx, in)
+		return srv.(TokenServer).IntrospectToken(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/User/DeleteUser",
+		FullMethod: "/Token/IntrospectToken",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServer).DeleteUser(ctx, req.(*UserIDRequest))
+		return srv.(TokenServer).IntrospectToken(ctx, req.(*TokenIntrospectRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// User_ServiceDesc is the grpc.ServiceDesc for User service.
+// Token_ServiceDesc is the grpc.ServiceDesc for Token service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
-var User_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "User",
-	HandlerType: (*UserServer)(nil),
+var Token_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "Token",
+	HandlerType: (*TokenServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "ListUser",
-			Handler:    _User_ListUser_Handler,
+			MethodName: "LoginToken",
+			Handler:    _Token_LoginToken_Handler,
 		},
 		{
-			MethodName: "CreateUser",
-			Handler:    _User_CreateUser_Handler,
+			MethodName: "MFAToken",
+			Handler:    _Token_MFAToken_Handler,
 		},
 		{
-			MethodName: "GetUser",
+			MethodName: "BeginPasskeyToken",
+			Handler:    _Token_BeginPasskeyToken_Handler,
+		},
+		{
+			MethodName: "FinishPasskeyToken",
+			Handler:    _Token_FinishPasskeyToken_Handler,
+		},
+		{
+			MethodName: "RefreshToken",
+			Handler:    _Token_RefreshToken_Handler,
+		},
+		{
+			MethodName: "GetJWKS",
+			Handler:    _Token_GetJWKS_Handler,
+		},
+		{
+			MethodName: "LogoutToken",
+			Handler:    _Token_LogoutToken_Handler,
+		},
+		{
+			MethodName: "LogoutAllToken",
+			Handler:    _Token_LogoutAllToken_Handler,
+		},
+		{
+			MethodName: "RevokeUserToken",
+			Handler:    _Token_RevokeUserToken_Handler,
+		},
+		{
+			MethodName: "IntrospectToken",
+			Handler:    _Token_IntrospectToken_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/protobuf/api.proto",
+}
+
+// KeyClient is the client API for Key service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type KeyClient interface {
+	ListKey(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*KeyListResponse, error)
+	RotateKey(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type keyClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewKeyClient(cc grpc.ClientConnInterface) KeyClient {
+	return &keyClient{cc}
+}
+
+func (c *keyClient) ListKey(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*KeyListResponse, error) {
+	out := new(KeyListResponse)
+	err := c.cc.Invoke(ctx, "/Key/ListKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyClient) RotateKey(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/Key/RotateKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// KeyServer is the server API for Key service.
+// All implementations must embed UnimplementedKeyServer
+// for forward compatibility
+type KeyServer interface {
+	ListKey(context.Context, *empty.Empty) (*KeyListResponse, error)
+	RotateKey(context.Context, *empty.Empty) (*empty.Empty, error)
+	mustEmbedUnimplementedKeyServer()
+}
+
+// UnimplementedKeyServer must be embedded to have forward compatible implementations.
+type UnimplementedKeyServer struct {
+}
+
+func (UnimplementedKeyServer) ListKey(context.Context, *empty.Empty) (*KeyListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListKey not implemented")
+}
+func (UnimplementedKeyServer) RotateKey(context.Context, *empty.Empty) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateKey not implemented")
+}
+func (UnimplementedKeyServer) mustEmbedUnimplementedKeyServer() {}
+
+// UnsafeKeyServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to KeyServer will
+// result in compilation errors.
+type UnsafeKeyServer interface {
+	mustEmbedUnimplementedKeyServer()
+}
+
+func RegisterKeyServer(s grpc.ServiceRegistrar, srv KeyServer) {
+	s.RegisterService(&Key_ServiceDesc, srv)
+}
+
+func _Key_ListKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServer).ListKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Key/ListKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServer).ListKey(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Key_RotateKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServer).RotateKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Key/RotateKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServer).RotateKey(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Key_ServiceDesc is the grpc.ServiceDesc for Key service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Key_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "Key",
+	HandlerType: (*KeyServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListKey",
+			Handler:    _Key_ListKey_Handler,
+		},
+		{
+			MethodName: "RotateKey",
+			Handler:    _Key_RotateKey_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/protobuf/api.proto",
+}
+
+// OAuthClientClient is the client API for OAuthClient service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type OAuthClientClient interface {
+	ListOAuthClient(ctx context.Context, in *OAuthClientListRequest, opts ...grpc.CallOption) (*OAuthClientListResponse, error)
+	CreateOAuthClient(ctx context.Context, in *OAuthClientCreateRequest, opts ...grpc.CallOption) (*OAuthClientInfoResponse, error)
+	GetOAuthClient(ctx context.Context, in *OAuthClientIDRequest, opts ...grpc.CallOption) (*OAuthClientInfoResponse, error)
+	UpdateOAuthClient(ctx context.Context, in *OAuthClientUpdateRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	DeleteOAuthClient(ctx context.Context, in *OAuthClientIDRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type oAuthClientClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOAuthClientClient(cc grpc.ClientConnInterface) OAuthClientClient {
+	return &oAuthClientClient{cc}
+}
+
+func (c *oAuthClientClient) ListOAuthClient(ctx context.Context, in *OAuthClientListRequest, opts ...grpc.CallOption) (*OAuthClientListResponse, error) {
+	out := new(OAuthClientListResponse)
+	err := c.cc.Invoke(ctx, "/OAuthClient/ListOAuthClient", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oAuthClientClient) CreateOAuthClient(ctx context.Context, in *OAuthClientCreateRequest, opts ...grpc.CallOption) (*OAuthClientInfoResponse, error) {
+	out := new(OAuthClientInfoResponse)
+	err := c.cc.Invoke(ctx, "/OAuthClient/CreateOAuthClient", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oAuthClientClient) GetOAuthClient(ctx context.Context, in *OAuthClientIDRequest, opts ...grpc.CallOption) (*OAuthClientInfoResponse, error) {
+	out := new(OAuthClientInfoResponse)
+	err := c.cc.Invoke(ctx, "/OAuthClient/GetOAuthClient", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oAuthClientClient) UpdateOAuthClient(ctx context.Context, in *OAuthClientUpdateRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/OAuthClient/UpdateOAuthClient", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oAuthClientClient) DeleteOAuthClient(ctx context.Context, in *OAuthClientIDRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/OAuthClient/DeleteOAuthClient", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OAuthClientServer is the server API for OAuthClient service.
+// All implementations must embed UnimplementedOAuthClientServer
+// for forward compatibility
+type OAuthClientServer interface {
+	ListOAuthClient(context.Context, *OAuthClientListRequest) (*OAuthClientListResponse, error)
+	CreateOAuthClient(context.Context, *OAuthClientCreateRequest) (*OAuthClientInfoResponse, error)
+	GetOAuthClient(context.Context, *OAuthClientIDRequest) (*OAuthClientInfoResponse, error)
+	UpdateOAuthClient(context.Context, *OAuthClientUpdateRequest) (*empty.Empty, error)
+	DeleteOAuthClient(context.Context, *OAuthClientIDRequest) (*empty.Empty, error)
+	mustEmbedUnimplementedOAuthClientServer()
+}
+
+// UnimplementedOAuthClientServer must be embedded to have forward compatible implementations.
+type UnimplementedOAuthClientServer struct {
+}
+
+func (UnimplementedOAuthClientServer) ListOAuthClient(context.Context, *OAuthClientListRequest) (*OAuthClientListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOAuthClient not implemented")
+}
+func (UnimplementedOAuthClientServer) CreateOAuthClient(context.Context, *OAuthClientCreateRequest) (*OAuthClientInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOAuthClient not implemented")
+}
+func (UnimplementedOAuthClientServer) GetOAuthClient(context.Context, *OAuthClientIDRequest) (*OAuthClientInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOAuthClient not implemented")
+}
+func (UnimplementedOAuthClientServer) UpdateOAuthClient(context.Context, *OAuthClientUpdateRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateOAuthClient not implemented")
+}
+func (UnimplementedOAuthClientServer) DeleteOAuthClient(context.Context, *OAuthClientIDRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteOAuthClient not implemented")
+}
+func (UnimplementedOAuthClientServer) mustEmbedUnimplementedOAuthClientServer() {}
+
+// UnsafeOAuthClientServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OAuthClientServer will
+// result in compilation errors.
+type UnsafeOAuthClientServer interface {
+	mustEmbedUnimplementedOAuthClientServer()
+}
+
+func RegisterOAuthClientServer(s grpc.ServiceRegistrar, srv OAuthClientServer) {
+	s.RegisterService(&OAuthClient_ServiceDesc, srv)
+}
+
+func _OAuthClient_ListOAuthClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OAuthClientListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OAuthClientServer).ListOAuthClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/OAuthClient/ListOAuthClient",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OAuthClientServer).ListOAuthClient(ctx, req.(*OAuthClientListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OAuthClient_CreateOAuthClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OAuthClientCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OAuthClientServer).CreateOAuthClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/OAuthClient/CreateOAuthClient",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OAuthClientServer).CreateOAuthClient(ctx, req.(*OAuthClientCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OAuthClient_GetOAuthClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OAuthClientIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OAuthClientServer).GetOAuthClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/OAuthClient/GetOAuthClient",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OAuthClientServer).GetOAuthClient(ctx, req.(*OAuthClientIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OAuthClient_UpdateOAuthClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OAuthClientUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OAuthClientServer).UpdateOAuthClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/OAuthClient/UpdateOAuthClient",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OAuthClientServer).UpdateOAuthClient(ctx, req.(*OAuthClientUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OAuthClient_DeleteOAuthClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OAuthClientIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OAuthClientServer).DeleteOAuthClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/OAuthClient/DeleteOAuthClient",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OAuthClientServer).DeleteOAuthClient(ctx, req.(*OAuthClientIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// OAuthClient_ServiceDesc is the grpc.ServiceDesc for OAuthClient service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var OAuthClient_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "OAuthClient",
+	HandlerType: (*OAuthClientServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListOAuthClient",
+			Handler:    _OAuthClient_ListOAuthClient_Handler,
+		},
+		{
+			MethodName: "CreateOAuthClient",
+			Handler:    _OAuthClient_CreateOAuthClient_Handler,
+		},
+		{
+			MethodName: "GetOAuthClient",
+			Handler:    _OAuthClient_GetOAuthClient_Handler,
+		},
+		{
+			MethodName: "UpdateOAuthClient",
+			Handler:    _OAuthClient_UpdateOAuthClient_Handler,
+		},
+		{
+			MethodName: "DeleteOAuthClient",
+			Handler:    _OAuthClient_DeleteOAuthClient_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/protobuf/api.proto",
+}
+
+// RoleClient is the client API for Role service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RoleClient interface {
+	ListRole(ctx context.Context, in *RoleListRequest, opts ...grpc.CallOption) (*RoleListResponse, error)
+	CreateRole(ctx context.Context, in *RoleCreateRequest, opts ...grpc.CallOption) (*RoleInfoResponse, error)
+	GetRole(ctx context.Context, in *RoleNameRequest, opts ...grpc.CallOption) (*RoleInfoResponse, error)
+	UpdateRole(ctx context.Context, in *RoleUpdateRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	DeleteRole(ctx context.Context, in *RoleNameRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type roleClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRoleClient(cc grpc.ClientConnInterface) RoleClient {
+	return &roleClient{cc}
+}
+
+func (c *roleClient) ListRole(ctx context.Context, in *RoleListRequest, opts ...grpc.CallOption) (*RoleListResponse, error) {
+	out := new(RoleListResponse)
+	err := c.cc.Invoke(ctx, "/Role/ListRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleClient) CreateRole(ctx context.Context, in *RoleCreateRequest, opts ...grpc.CallOption) (*RoleInfoResponse, error) {
+	out := new(RoleInfoResponse)
+	err := c.cc.Invoke(ctx, "/Role/CreateRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleClient) GetRole(ctx context.Context, in *RoleNameRequest, opts ...grpc.CallOption) (*RoleInfoResponse, error) {
+	out := new(RoleInfoResponse)
+	err := c.cc.Invoke(ctx, "/Role/GetRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleClient) UpdateRole(ctx context.Context, in *RoleUpdateRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/Role/UpdateRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleClient) DeleteRole(ctx context.Context, in *RoleNameRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/Role/DeleteRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RoleServer is the server API for Role service.
+// All implementations must embed UnimplementedRoleServer
+// for forward compatibility
+type RoleServer interface {
+	ListRole(context.Context, *RoleListRequest) (*RoleListResponse, error)
+	CreateRole(context.Context, *RoleCreateRequest) (*RoleInfoResponse, error)
+	GetRole(context.Context, *RoleNameRequest) (*RoleInfoResponse, error)
+	UpdateRole(context.Context, *RoleUpdateRequest) (*empty.Empty, error)
+	DeleteRole(context.Context, *RoleNameRequest) (*empty.Empty, error)
+	mustEmbedUnimplementedRoleServer()
+}
+
+// UnimplementedRoleServer must be embedded to have forward compatible implementations.
+type UnimplementedRoleServer struct {
+}
+
+func (UnimplementedRoleServer) ListRole(context.Context, *RoleListRequest) (*RoleListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRole not implemented")
+}
+func (UnimplementedRoleServer) CreateRole(context.Context, *RoleCreateRequest) (*RoleInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRole not implemented")
+}
+func (UnimplementedRoleServer) GetRole(context.Context, *RoleNameRequest) (*RoleInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRole not implemented")
+}
+func (UnimplementedRoleServer) UpdateRole(context.Context, *RoleUpdateRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRole not implemented")
+}
+func (UnimplementedRoleServer) DeleteRole(context.Context, *RoleNameRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRole not implemented")
+}
+func (UnimplementedRoleServer) mustEmbedUnimplementedRoleServer() {}
+
+// UnsafeRoleServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RoleServer will
+// result in compilation errors.
+type UnsafeRoleServer interface {
+	mustEmbedUnimplementedRoleServer()
+}
+
+func RegisterRoleServer(s grpc.ServiceRegistrar, srv RoleServer) {
+	s.RegisterService(&Role_ServiceDesc, srv)
+}
+
+func _Role_ListRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoleListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleServer).ListRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Role/ListRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleServer).ListRole(ctx, req.(*RoleListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Role_CreateRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoleCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleServer).CreateRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Role/CreateRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleServer).CreateRole(ctx, req.(*RoleCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Role_GetRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoleNameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleServer).GetRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Role/GetRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleServer).GetRole(ctx, req.(*RoleNameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Role_UpdateRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoleUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleServer).UpdateRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Role/UpdateRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleServer).UpdateRole(ctx, req.(*RoleUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Role_DeleteRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoleNameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleServer).DeleteRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Role/DeleteRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleServer).DeleteRole(ctx, req.(*RoleNameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Role_ServiceDesc is the grpc.ServiceDesc for Role service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Role_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "Role",
+	HandlerType: (*RoleServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListRole",
+			Handler:    _Role_ListRole_Handler,
+		},
+		{
+			MethodName: "CreateRole",
+			Handler:    _Role_CreateRole_Handler,
+		},
+		{
+			MethodName: "GetRole",
+			Handler:    _Role_GetRole_Handler,
+		},
+		{
+			MethodName: "UpdateRole",
+			Handler:    _Role_UpdateRole_Handler,
+		},
+		{
+			MethodName: "DeleteRole",
+			Handler:    _Role_DeleteRole_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/protobuf/api.proto",
+}
+
+// TenantClient is the client API for Tenant service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TenantClient interface {
+	ListTenant(ctx context.Context, in *TenantListRequest, opts ...grpc.CallOption) (*TenantListResponse, error)
+	CreateTenant(ctx context.Context, in *TenantCreateRequest, opts ...grpc.CallOption) (*TenantInfoResponse, error)
+	GetTenant(ctx context.Context, in *TenantIDRequest, opts ...grpc.CallOption) (*TenantInfoResponse, error)
+	UpdateTenant(ctx context.Context, in *TenantUpdateRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	DeleteTenant(ctx context.Context, in *TenantIDRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type tenantClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTenantClient(cc grpc.ClientConnInterface) TenantClient {
+	return &tenantClient{cc}
+}
+
+func (c *tenantClient) ListTenant(ctx context.Context, in *TenantListRequest, opts ...grpc.CallOption) (*TenantListResponse, error) {
+	out := new(TenantListResponse)
+	err := c.cc.Invoke(ctx, "/Tenant/ListTenant", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tenantClient) CreateTenant(ctx context.Context, in *TenantCreateRequest, opts ...grpc.CallOption) (*TenantInfoResponse, error) {
+	out := new(TenantInfoResponse)
+	err := c.cc.Invoke(ctx, "/Tenant/CreateTenant", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tenantClient) GetTenant(ctx context.Context, in *TenantIDRequest, opts ...grpc.CallOption) (*TenantInfoResponse, error) {
+	out := new(TenantInfoResponse)
+	err := c.cc.Invoke(ctx, "/Tenant/GetTenant", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tenantClient) UpdateTenant(ctx context.Context, in *TenantUpdateRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/Tenant/UpdateTenant", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tenantClient) DeleteTenant(ctx context.Context, in *TenantIDRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/Tenant/DeleteTenant", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TenantServer is the server API for Tenant service.
+// All implementations must embed UnimplementedTenantServer
+// for forward compatibility
+type TenantServer interface {
+	ListTenant(context.Context, *TenantListRequest) (*TenantListResponse, error)
+	CreateTenant(context.Context, *TenantCreateRequest) (*TenantInfoResponse, error)
+	GetTenant(context.Context, *TenantIDRequest) (*TenantInfoResponse, error)
+	UpdateTenant(context.Context, *TenantUpdateRequest) (*empty.Empty, error)
+	DeleteTenant(context.Context, *TenantIDRequest) (*empty.Empty, error)
+	mustEmbedUnimplementedTenantServer()
+}
+
+// UnimplementedTenantServer must be embedded to have forward compatible implementations.
+type UnimplementedTenantServer struct {
+}
+
+func (UnimplementedTenantServer) ListTenant(context.Context, *TenantListRequest) (*TenantListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTenant not implemented")
+}
+func (UnimplementedTenantServer) CreateTenant(context.Context, *TenantCreateRequest) (*TenantInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTenant not implemented")
+}
+func (UnimplementedTenantServer) GetTenant(context.Context, *TenantIDRequest) (*TenantInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTenant not implemented")
+}
+func (UnimplementedTenantServer) UpdateTenant(context.Context, *TenantUpdateRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTenant not implemented")
+}
+func (UnimplementedTenantServer) DeleteTenant(context.Context, *TenantIDRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTenant not implemented")
+}
+func (UnimplementedTenantServer) mustEmbedUnimplementedTenantServer() {}
+
+// UnsafeTenantServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TenantServer will
+// result in compilation errors.
+type UnsafeTenantServer interface {
+	mustEmbedUnimplementedTenantServer()
+}
+
+func RegisterTenantServer(s grpc.ServiceRegistrar, srv TenantServer) {
+	s.RegisterService(&Tenant_ServiceDesc, srv)
+}
+
+func _Tenant_ListTenant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TenantListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TenantServer).ListTenant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Tenant/ListTenant",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TenantServer).ListTenant(ctx, req.(*TenantListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tenant_CreateTenant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TenantCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TenantServer).CreateTenant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Tenant/CreateTenant",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TenantServer).CreateTenant(ctx, req.(*TenantCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tenant_GetTenant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TenantIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TenantServer).GetTenant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Tenant/GetTenant",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TenantServer).GetTenant(ctx, req.(*TenantIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tenant_UpdateTenant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TenantUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TenantServer).UpdateTenant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Tenant/UpdateTenant",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TenantServer).UpdateTenant(ctx, req.(*TenantUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tenant_DeleteTenant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TenantIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TenantServer).DeleteTenant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Tenant/DeleteTenant",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TenantServer).DeleteTenant(ctx, req.(*TenantIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Tenant_ServiceDesc is the grpc.ServiceDesc for Tenant service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Tenant_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "Tenant",
+	HandlerType: (*TenantServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListTenant",
+			Handler:    _Tenant_ListTenant_Handler,
+		},
+		{
+			MethodName: "CreateTenant",
+			Handler:    _Tenant_CreateTenant_Handler,
+		},
+		{
+			MethodName: "GetTenant",
+			Handler:    _Tenant_GetTenant_Handler,
+		},
+		{
+			MethodName: "UpdateTenant",
+			Handler:    _Tenant_UpdateTenant_Handler,
+		},
+		{
+			MethodName: "DeleteTenant",
+			Handler:    _Tenant_DeleteTenant_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/protobuf/api.proto",
+}
+
+// LoginLockClient is the client API for LoginLock service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type LoginLockClient interface {
+	ListLoginLock(ctx context.Context, in *LoginLockListRequest, opts ...grpc.CallOption) (*LoginLockListResponse, error)
+	GetLoginLock(ctx context.Context, in *LoginLockIDRequest, opts ...grpc.CallOption) (*LoginLockInfoResponse, error)
+	DeleteLoginLock(ctx context.Context, in *LoginLockIDRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type loginLockClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLoginLockClient(cc grpc.ClientConnInterface) LoginLockClient {
+	return &loginLockClient{cc}
+}
+
+func (c *loginLockClient) ListLoginLock(ctx context.Context, in *LoginLockListRequest, opts ...grpc.CallOption) (*LoginLockListResponse, error) {
+	out := new(LoginLockListResponse)
+	err := c.cc.Invoke(ctx, "/LoginLock/ListLoginLock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *loginLockClient) GetLoginLock(ctx context.Context, in *LoginLockIDRequest, opts ...grpc.CallOption) (*LoginLockInfoResponse, error) {
+	out := new(LoginLockInfoResponse)
+	err := c.cc.Invoke(ctx, "/LoginLock/GetLoginLock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *loginLockClient) DeleteLoginLock(ctx context.Context, in *LoginLockIDRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/LoginLock/DeleteLoginLock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LoginLockServer is the server API for LoginLock service.
+// All implementations must embed UnimplementedLoginLockServer
+// for forward compatibility
+type LoginLockServer interface {
+	ListLoginLock(context.Context, *LoginLockListRequest) (*LoginLockListResponse, error)
+	GetLoginLock(context.Context, *LoginLockIDRequest) (*LoginLockInfoResponse, error)
+	DeleteLoginLock(context.Context, *LoginLockIDRequest) (*empty.Empty, error)
+	mustEmbedUnimplementedLoginLockServer()
+}
+
+// UnimplementedLoginLockServer must be embedded to have forward compatible implementations.
+type UnimplementedLoginLockServer struct {
+}
+
+func (UnimplementedLoginLockServer) ListLoginLock(context.Context, *LoginLockListRequest) (*LoginLockListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLoginLock not implemented")
+}
+func (UnimplementedLoginLockServer) GetLoginLock(context.Context, *LoginLockIDRequest) (*LoginLockInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLoginLock not implemented")
+}
+func (UnimplementedLoginLockServer) DeleteLoginLock(context.Context, *LoginLockIDRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteLoginLock not implemented")
+}
+func (UnimplementedLoginLockServer) mustEmbedUnimplementedLoginLockServer() {}
+
+// UnsafeLoginLockServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LoginLockServer will
+// result in compilation errors.
+type UnsafeLoginLockServer interface {
+	mustEmbedUnimplementedLoginLockServer()
+}
+
+func RegisterLoginLockServer(s grpc.ServiceRegistrar, srv LoginLockServer) {
+	s.RegisterService(&LoginLock_ServiceDesc, srv)
+}
+
+func _LoginLock_ListLoginLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginLockListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LoginLockServer).ListLoginLock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/LoginLock/ListLoginLock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LoginLockServer).ListLoginLock(ctx, req.(*LoginLockListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LoginLock_GetLoginLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginLockIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LoginLockServer).GetLoginLock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/LoginLock/GetLoginLock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LoginLockServer).GetLoginLock(ctx, req.(*LoginLockIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LoginLock_DeleteLoginLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginLockIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LoginLockServer).DeleteLoginLock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/LoginLock/DeleteLoginLock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LoginLockServer).DeleteLoginLock(ctx, req.(*LoginLockIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// LoginLock_ServiceDesc is the grpc.ServiceDesc for LoginLock service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var LoginLock_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "LoginLock",
+	HandlerType: (*LoginLockServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListLoginLock",
+			Handler:    _LoginLock_ListLoginLock_Handler,
+		},
+		{
+			MethodName: "GetLoginLock",
+			Handler:    _LoginLock_GetLoginLock_Handler,
+		},
+		{
+			MethodName: "DeleteLoginLock",
+			Handler:    _LoginLock_DeleteLoginLock_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/protobuf/api.proto",
+}
+
+// GroupClient is the client API for Group service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type GroupClient interface {
+	ListGroup(ctx context.Context, in *GroupListRequest, opts ...grpc.CallOption) (*GroupListResponse, error)
+	CreateGroup(ctx context.Context, in *GroupCreateRequest, opts ...grpc.CallOption) (*GroupInfoResponse, error)
+	GetGroup(ctx context.Context, in *GroupIDRequest, opts ...grpc.CallOption) (*GroupInfoResponse, error)
+	UpdateGroup(ctx context.Context, in *GroupUpdateRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	DeleteGroup(ctx context.Context, in *GroupIDRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	ListGroupMember(ctx context.Context, in *GroupMemberListRequest, opts ...grpc.CallOption) (*GroupMemberListResponse, error)
+	AddGroupMember(ctx context.Context, in *GroupMemberCreateRequest, opts ...grpc.CallOption) (*GroupMemberInfoResponse, error)
+	RemoveGroupMember(ctx context.Context, in *GroupMemberIDRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type groupClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGroupClient(cc grpc.ClientConnInterface) GroupClient {
+	return &groupClient{cc}
+}
+
+func (c *groupClient) ListGroup(ctx context.Context, in *GroupListRequest, opts ...grpc.CallOption) (*GroupListResponse, error) {
+	out := new(GroupListResponse)
+	err := c.cc.Invoke(ctx, "/Group/ListGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *groupClient) CreateGroup(ctx context.Context, in *GroupCreateRequest, opts ...grpc.CallOption) (*GroupInfoResponse, error) {
+	out := new(GroupInfoResponse)
+	err := c.cc.Invoke(ctx, "/Group/CreateGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *groupClient) GetGroup(ctx context.Context, in *GroupIDRequest, opts ...grpc.CallOption) (*GroupInfoResponse, error) {
+	out := new(GroupInfoResponse)
+	err := c.cc.Invoke(ctx, "/Group/GetGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *groupClient) UpdateGroup(ctx context.Context, in *GroupUpdateRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/Group/UpdateGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *groupClient) DeleteGroup(ctx context.Context, in *GroupIDRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/Group/DeleteGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *groupClient) ListGroupMember(ctx context.Context, in *GroupMemberListRequest, opts ...grpc.CallOption) (*GroupMemberListResponse, error) {
+	out := new(GroupMemberListResponse)
+	err := c.cc.Invoke(ctx, "/Group/ListGroupMember", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *groupClient) AddGroupMember(ctx context.Context, in *GroupMemberCreateRequest, opts ...grpc.CallOption) (*GroupMemberInfoResponse, error) {
+	out := new(GroupMemberInfoResponse)
+	err := c.cc.Invoke(ctx, "/Group/AddGroupMember", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *groupClient) RemoveGroupMember(ctx context.Context, in *GroupMemberIDRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/Group/RemoveGroupMember", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GroupServer is the server API for Group service.
+// All implementations must embed UnimplementedGroupServer
+// for forward compatibility
+type GroupServer interface {
+	ListGroup(context.Context, *GroupListRequest) (*GroupListResponse, error)
+	CreateGroup(context.Context, *GroupCreateRequest) (*GroupInfoResponse, error)
+	GetGroup(context.Context, *GroupIDRequest) (*GroupInfoResponse, error)
+	UpdateGroup(context.Context, *GroupUpdateRequest) (*empty.Empty, error)
+	DeleteGroup(context.Context, *GroupIDRequest) (*empty.Empty, error)
+	ListGroupMember(context.Context, *GroupMemberListRequest) (*GroupMemberListResponse, error)
+	AddGroupMember(context.Context, *GroupMemberCreateRequest) (*GroupMemberInfoResponse, error)
+	RemoveGroupMember(context.Context, *GroupMemberIDRequest) (*empty.Empty, error)
+	mustEmbedUnimplementedGroupServer()
+}
+
+// UnimplementedGroupServer must be embedded to have forward compatible implementations.
+type UnimplementedGroupServer struct {
+}
+
+func (UnimplementedGroupServer) ListGroup(context.Context, *GroupListRequest) (*GroupListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGroup not implemented")
+}
+func (UnimplementedGroupServer) CreateGroup(context.Context, *GroupCreateRequest) (*GroupInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGroup not implemented")
+}
+func (UnimplementedGroupServer) GetGroup(context.Context, *GroupIDRequest) (*GroupInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGroup not implemented")
+}
+func (UnimplementedGroupServer) UpdateGroup(context.Context, *GroupUpdateRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateGroup not implemented")
+}
+func (UnimplementedGroupServer) DeleteGroup(context.Context, *GroupIDRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteGroup not implemented")
+}
+func (UnimplementedGroupServer) ListGroupMember(context.Context, *GroupMemberListRequest) (*GroupMemberListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGroupMember not implemented")
+}
+func (UnimplementedGroupServer) AddGroupMember(context.Context, *GroupMemberCreateRequest) (*GroupMemberInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddGroupMember not implemented")
+}
+func (UnimplementedGroupServer) RemoveGroupMember(context.Context, *GroupMemberIDRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveGroupMember not implemented")
+}
+func (UnimplementedGroupServer) mustEmbedUnimplementedGroupServer() {}
+
+// UnsafeGroupServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GroupServer will
+// result in compilation errors.
+type UnsafeGroupServer interface {
+	mustEmbedUnimplementedGroupServer()
+}
+
+func RegisterGroupServer(s grpc.ServiceRegistrar, srv GroupServer) {
+	s.RegisterService(&Group_ServiceDesc, srv)
+}
+
+func _Group_ListGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GroupServer).ListGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Group/ListGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GroupServer).ListGroup(ctx, req.(*GroupListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Group_CreateGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GroupServer).CreateGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Group/CreateGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GroupServer).CreateGroup(ctx, req.(*GroupCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Group_GetGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GroupServer).GetGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Group/GetGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GroupServer).GetGroup(ctx, req.(*GroupIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Group_UpdateGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GroupServer).UpdateGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Group/UpdateGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GroupServer).UpdateGroup(ctx, req.(*GroupUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Group_DeleteGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GroupServer).DeleteGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Group/DeleteGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GroupServer).DeleteGroup(ctx, req.(*GroupIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Group_ListGroupMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupMemberListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GroupServer).ListGroupMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Group/ListGroupMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GroupServer).ListGroupMember(ctx, req.(*GroupMemberListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Group_AddGroupMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupMemberCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GroupServer).AddGroupMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Group/AddGroupMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GroupServer).AddGroupMember(ctx, req.(*GroupMemberCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Group_RemoveGroupMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupMemberIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GroupServer).RemoveGroupMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Group/RemoveGroupMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GroupServer).RemoveGroupMember(ctx, req.(*GroupMemberIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Group_ServiceDesc is the grpc.ServiceDesc for Group service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Group_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "Group",
+	HandlerType: (*GroupServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListGroup",
+			Handler:    _Group_ListGroup_Handler,
+		},
+		{
+			MethodName: "CreateGroup",
+			Handler:    _Group_CreateGroup_Handler,
+		},
+		{
+			MethodName: "GetGroup",
+			Handler:    _Group_GetGroup_Handler,
+		},
+		{
+			MethodName: "UpdateGroup",
+			Handler:    _Group_UpdateGroup_Handler,
+		},
+		{
+			MethodName: "DeleteGroup",
+			Handler:    _Group_DeleteGroup_Handler,
+		},
+		{
+			MethodName: "ListGroupMember",
+			Handler:    _Group_ListGroupMember_Handler,
+		},
+		{
+			MethodName: "AddGroupMember",
+			Handler:    _Group_AddGroupMember_Handler,
+		},
+		{
+			MethodName: "RemoveGroupMember",
+			Handler:    _Group_RemoveGroupMember_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/protobuf/api.proto",
+}
+
+// PermissionClient is the client API for Permission service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PermissionClient interface {
+	ListPermission(ctx context.Context, in *PermissionListRequest, opts ...grpc.CallOption) (*PermissionListResponse, error)
+	CreatePermission(ctx context.Context, in *PermissionCreateRequest, opts ...grpc.CallOption) (*PermissionInfoResponse, error)
+	GetPermission(ctx context.Context, in *PermissionIDRequest, opts ...grpc.CallOption) (*PermissionInfoResponse, error)
+	UpdatePermission(ctx context.Context, in *PermissionUpdateRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	DeletePermission(ctx context.Context, in *PermissionIDRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type permissionClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPermissionClient(cc grpc.ClientConnInterface) PermissionClient {
+	return &permissionClient{cc}
+}
+
+func (c *permissionClient) ListPermission(ctx context.Context, in *PermissionListRequest, opts ...grpc.CallOption) (*PermissionListResponse, error) {
+	out := new(PermissionListResponse)
+	err := c.cc.Invoke(ctx, "/Permission/ListPermission", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionClient) CreatePermission(ctx context.Context, in *PermissionCreateRequest, opts ...grpc.CallOption) (*PermissionInfoResponse, error) {
+	out := new(PermissionInfoResponse)
+	err := c.cc.Invoke(ctx, "/Permission/CreatePermission", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionClient) GetPermission(ctx context.Context, in *PermissionIDRequest, opts ...grpc.CallOption) (*PermissionInfoResponse, error) {
+	out := new(PermissionInfoResponse)
+	err := c.cc.Invoke(ctx, "/Permission/GetPermission", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionClient) UpdatePermission(ctx context.Context, in *PermissionUpdateRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/Permission/UpdatePermission", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionClient) DeletePermission(ctx context.Context, in *PermissionIDRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/Permission/DeletePermission", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PermissionServer is the server API for Permission service.
+// All implementations must embed UnimplementedPermissionServer
+// for forward compatibility
+type PermissionServer interface {
+	ListPermission(context.Context, *PermissionListRequest) (*PermissionListResponse, error)
+	CreatePermission(context.Context, *PermissionCreateRequest) (*PermissionInfoResponse, error)
+	GetPermission(context.Context, *PermissionIDRequest) (*PermissionInfoResponse, error)
+	UpdatePermission(context.Context, *PermissionUpdateRequest) (*empty.Empty, error)
+	DeletePermission(context.Context, *PermissionIDRequest) (*empty.Empty, error)
+	mustEmbedUnimplementedPermissionServer()
+}
+
+// UnimplementedPermissionServer must be embedded to have forward compatible implementations.
+type UnimplementedPermissionServer struct {
+}
+
+func (UnimplementedPermissionServer) ListPermission(context.Context, *PermissionListRequest) (*PermissionListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPermission not implemented")
+}
+func (UnimplementedPermissionServer) CreatePermission(context.Context, *PermissionCreateRequest) (*PermissionInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePermission not implemented")
+}
+func (UnimplementedPermissionServer) GetPermission(context.Context, *PermissionIDRequest) (*PermissionInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPermission not implemented")
+}
+func (UnimplementedPermissionServer) UpdatePermission(context.Context, *PermissionUpdateRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePermission not implemented")
+}
+func (UnimplementedPermissionServer) DeletePermission(context.Context, *PermissionIDRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePermission not implemented")
+}
+func (UnimplementedPermissionServer) mustEmbedUnimplementedPermissionServer() {}
+
+// UnsafePermissionServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PermissionServer will
+// result in compilation errors.
+type UnsafePermissionServer interface {
+	mustEmbedUnimplementedPermissionServer()
+}
+
+func RegisterPermissionServer(s grpc.ServiceRegistrar, srv PermissionServer) {
+	s.RegisterService(&Permission_ServiceDesc, srv)
+}
+
+func _Permission_ListPermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PermissionListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionServer).ListPermission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Permission/ListPermission",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionServer).ListPermission(ctx, req.(*PermissionListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Permission_CreatePermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PermissionCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionServer).CreatePermission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Permission/CreatePermission",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionServer).CreatePermission(ctx, req.(*PermissionCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Permission_GetPermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PermissionIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionServer).GetPermission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Permission/GetPermission",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionServer).GetPermission(ctx, req.(*PermissionIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Permission_UpdatePermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PermissionUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionServer).UpdatePermission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Permission/UpdatePermission",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionServer).UpdatePermission(ctx, req.(*PermissionUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Permission_DeletePermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PermissionIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionServer).DeletePermission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Permission/DeletePermission",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionServer).DeletePermission(ctx, req.(*PermissionIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Permission_ServiceDesc is the grpc.ServiceDesc for Permission service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Permission_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "Permission",
+	HandlerType: (*PermissionServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListPermission",
+			Handler:    _Permission_ListPermission_Handler,
+		},
+		{
+			MethodName: "CreatePermission",
+			Handler:    _Permission_CreatePermission_Handler,
+		},
+		{
+			MethodName: "GetPermission",
+			Handler:    _Permission_GetPermission_Handler,
+		},
+		{
+			MethodName: "UpdatePermission",
+			Handler:    _Permission_UpdatePermission_Handler,
+		},
+		{
+			MethodName: "DeletePermission",
+			Handler:    _Permission_DeletePermission_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/protobuf/api.proto",
+}
+
+// UserClient is the client API for User service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type UserClient interface {
+	ListUser(ctx context.Context, in *UserListRequest, opts ...grpc.CallOption) (*UserListResponse, error)
+	CreateUser(ctx context.Context, in *UserCreateRequest, opts ...grpc.CallOption) (*UserInfoResponse, error)
+	GetUser(ctx context.Context, in *UserIDRequest, opts ...grpc.CallOption) (*UserInfoResponse, error)
+	UpdateUser(ctx context.Context, in *UserUpdateRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	DeleteUser(ctx context.Context, in *UserIDRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type userClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewUserClient(cc grpc.ClientConnInterface) UserClient {
+	return &userClient{cc}
+}
+
+func (c *userClient) ListUser(ctx context.Context, in *UserListRequest, opts ...grpc.CallOption) (*UserListResponse, error) {
+	out := new(UserListResponse)
+	err := c.cc.Invoke(ctx, "/User/ListUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) CreateUser(ctx context.Context, in *UserCreateRequest, opts ...grpc.CallOption) (*UserInfoResponse, error) {
+	out := new(UserInfoResponse)
+	err := c.cc.Invoke(ctx, "/User/CreateUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) GetUser(ctx context.Context, in *UserIDRequest, opts ...grpc.CallOption) (*UserInfoResponse, error) {
+	out := new(UserInfoResponse)
+	err := c.cc.Invoke(ctx, "/User/GetUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) UpdateUser(ctx context.Context, in *UserUpdateRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/User/UpdateUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) DeleteUser(ctx context.Context, in *UserIDRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/User/DeleteUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UserServer is the server API for User service.
+// All implementations must embed UnimplementedUserServer
+// for forward compatibility
+type UserServer interface {
+	ListUser(context.Context, *UserListRequest) (*UserListResponse, error)
+	CreateUser(context.Context, *UserCreateRequest) (*UserInfoResponse, error)
+	GetUser(context.Context, *UserIDRequest) (*UserInfoResponse, error)
+	UpdateUser(context.Context, *UserUpdateRequest) (*empty.Empty, error)
+	DeleteUser(context.Context, *UserIDRequest) (*empty.Empty, error)
+	mustEmbedUnimplementedUserServer()
+}
+
+// UnimplementedUserServer must be embedded to have forward compatible implementations.
+type UnimplementedUserServer struct {
+}
+
+func (UnimplementedUserServer) ListUser(context.Context, *UserListRequest) (*UserListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUser not implemented")
+}
+func (UnimplementedUserServer) CreateUser(context.Context, *UserCreateRequest) (*UserInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
+}
+func (UnimplementedUserServer) GetUser(context.Context, *UserIDRequest) (*UserInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
+}
+func (UnimplementedUserServer) UpdateUser(context.Context, *UserUpdateRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateUser not implemented")
+}
+func (UnimplementedUserServer) DeleteUser(context.Context, *UserIDRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
+}
+func (UnimplementedUserServer) mustEmbedUnimplementedUserServer() {}
+
+// UnsafeUserServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to UserServer will
+// result in compilation errors.
+type UnsafeUserServer interface {
+	mustEmbedUnimplementedUserServer()
+}
+
+func RegisterUserServer(s grpc.ServiceRegistrar, srv UserServer) {
+	s.RegisterService(&User_ServiceDesc, srv)
+}
+
+func _User_ListUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).ListUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/User/ListUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).ListUser(ctx, req.(*UserListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).CreateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/User/CreateUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).CreateUser(ctx, req.(*UserCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).GetUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/User/GetUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).GetUser(ctx, req.(*UserIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_UpdateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).UpdateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/User/UpdateUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).UpdateUser(ctx, req.(*UserUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_DeleteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).DeleteUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/User/DeleteUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).DeleteUser(ctx, req.(*UserIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// User_ServiceDesc is the grpc.ServiceDesc for User service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var User_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "User",
+	HandlerType: (*UserServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListUser",
+			Handler:    _User_ListUser_Handler,
+		},
+		{
+			MethodName: "CreateUser",
+			Handler:    _User_CreateUser_Handler,
+		},
+		{
+			MethodName: "GetUser",
 			Handler:    _User_GetUser_Handler,
 		},
 		{
@@ -374,37 +2200,177 @@ type UserMeClient interface {
 	GetUserMe(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*UserInfoResponse, error)
 	UpdateUserMe(ctx context.Context, in *UserUpdateRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	DeleteUserMe(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
+	ListSessionUserMe(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*SessionListResponse, error)
+	UpdatePasswdUserMe(ctx context.Context, in *UserPasswdUpdateRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	GetMFAUserMe(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*MFAInfoResponse, error)
+	EnrollTOTPUserMe(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*MFATOTPEnrollmentResponse, error)
+	ConfirmTOTPUserMe(ctx context.Context, in *MFACodeRequest, opts ...grpc.CallOption) (*MFARecoveryCodesResponse, error)
+	DisableTOTPUserMe(ctx context.Context, in *MFACodeRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	RegenerateRecoveryCodesUserMe(ctx context.Context, in *MFACodeRequest, opts ...grpc.CallOption) (*MFARecoveryCodesResponse, error)
+	RemovePasswdUserMe(ctx context.Context, in *UserPasswdRemoveRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	ListPasskeyUserMe(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*PasskeyListResponse, error)
+	BeginPasskeyUserMe(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*PasskeyOptionsResponse, error)
+	FinishPasskeyUserMe(ctx context.Context, in *PasskeyRegistrationFinishRequest, opts ...grpc.CallOption) (*PasskeyInfoResponse, error)
+	DeletePasskeyUserMe(ctx context.Context, in *PasskeyIDRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	SendEmailVerificationUserMe(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
+	VerifyEmailUserMe(ctx context.Context, in *EmailVerifyRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type userMeClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewUserMeClient(cc grpc.ClientConnInterface) UserMeClient {
+	return &userMeClient{cc}
+}
+
+func (c *userMeClient) GetUserMe(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*UserInfoResponse, error) {
+	out := new(UserInfoResponse)
+	err := c.cc.Invoke(ctx, "/UserMe/GetUserMe", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userMeClient) UpdateUserMe(ctx context.Context, in *UserUpdateRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/UserMe/UpdateUserMe", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userMeClient) DeleteUserMe(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/UserMe/DeleteUserMe", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userMeClient) ListSessionUserMe(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*SessionListResponse, error) {
+	out := new(SessionListResponse)
+	err := c.cc.Invoke(ctx, "/UserMe/ListSessionUserMe", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userMeClient) UpdatePasswdUserMe(ctx context.Context, in *UserPasswdUpdateRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/UserMe/UpdatePasswdUserMe", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userMeClient) GetMFAUserMe(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*MFAInfoResponse, error) {
+	out := new(MFAInfoResponse)
+	err := c.cc.Invoke(ctx, "/UserMe/GetMFAUserMe", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userMeClient) EnrollTOTPUserMe(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*MFATOTPEnrollmentResponse, error) {
+	out := new(MFATOTPEnrollmentResponse)
+	err := c.cc.Invoke(ctx, "/UserMe/EnrollTOTPUserMe", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userMeClient) ConfirmTOTPUserMe(ctx context.Context, in *MFACodeRequest, opts ...grpc.CallOption) (*MFARecoveryCodesResponse, error) {
+	out := new(MFARecoveryCodesResponse)
+	err := c.cc.Invoke(ctx, "/UserMe/ConfirmTOTPUserMe", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userMeClient) DisableTOTPUserMe(ctx context.Context, in *MFACodeRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/UserMe/DisableTOTPUserMe", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userMeClient) RegenerateRecoveryCodesUserMe(ctx context.Context, in *MFACodeRequest, opts ...grpc.CallOption) (*MFARecoveryCodesResponse, error) {
+	out := new(MFARecoveryCodesResponse)
+	err := c.cc.Invoke(ctx, "/UserMe/RegenerateRecoveryCodesUserMe", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userMeClient) RemovePasswdUserMe(ctx context.Context, in *UserPasswdRemoveRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/UserMe/RemovePasswdUserMe", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-type userMeClient struct {
-	cc grpc.ClientConnInterface
+func (c *userMeClient) ListPasskeyUserMe(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*PasskeyListResponse, error) {
+	out := new(PasskeyListResponse)
+	err := c.cc.Invoke(ctx, "/UserMe/ListPasskeyUserMe", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func NewUserMeClient(cc grpc.ClientConnInterface) UserMeClient {
-	return &userMeClient{cc}
+func (c *userMeClient) BeginPasskeyUserMe(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*PasskeyOptionsResponse, error) {
+	out := new(PasskeyOptionsResponse)
+	err := c.cc.Invoke(ctx, "/UserMe/BeginPasskeyUserMe", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (c *userMeClient) GetUserMe(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*UserInfoResponse, error) {
-	out := new(UserInfoResponse)
-	err := c.cc.Invoke(ctx, "/UserMe/GetUserMe", in, out, opts...)
+func (c *userMeClient) FinishPasskeyUserMe(ctx context.Context, in *PasskeyRegistrationFinishRequest, opts ...grpc.CallOption) (*PasskeyInfoResponse, error) {
+	out := new(PasskeyInfoResponse)
+	err := c.cc.Invoke(ctx, "/UserMe/FinishPasskeyUserMe", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *userMeClient) UpdateUserMe(ctx context.Context, in *UserUpdateRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+func (c *userMeClient) DeletePasskeyUserMe(ctx context.Context, in *PasskeyIDRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/UserMe/UpdateUserMe", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/UserMe/DeletePasskeyUserMe", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *userMeClient) DeleteUserMe(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
+func (c *userMeClient) SendEmailVerificationUserMe(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
 	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/UserMe/DeleteUserMe", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/UserMe/SendEmailVerificationUserMe", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userMeClient) VerifyEmailUserMe(ctx context.Context, in *EmailVerifyRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/UserMe/VerifyEmailUserMe", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -418,6 +2384,20 @@ type UserMeServer interface {
 	GetUserMe(context.Context, *empty.Empty) (*UserInfoResponse, error)
 	UpdateUserMe(context.Context, *UserUpdateRequest) (*empty.Empty, error)
 	DeleteUserMe(context.Context, *empty.Empty) (*empty.Empty, error)
+	ListSessionUserMe(context.Context, *empty.Empty) (*SessionListResponse, error)
+	UpdatePasswdUserMe(context.Context, *UserPasswdUpdateRequest) (*empty.Empty, error)
+	GetMFAUserMe(context.Context, *empty.Empty) (*MFAInfoResponse, error)
+	EnrollTOTPUserMe(context.Context, *empty.Empty) (*MFATOTPEnrollmentResponse, error)
+	ConfirmTOTPUserMe(context.Context, *MFACodeRequest) (*MFARecoveryCodesResponse, error)
+	DisableTOTPUserMe(context.Context, *MFACodeRequest) (*empty.Empty, error)
+	RegenerateRecoveryCodesUserMe(context.Context, *MFACodeRequest) (*MFARecoveryCodesResponse, error)
+	RemovePasswdUserMe(context.Context, *UserPasswdRemoveRequest) (*empty.Empty, error)
+	ListPasskeyUserMe(context.Context, *empty.Empty) (*PasskeyListResponse, error)
+	BeginPasskeyUserMe(context.Context, *empty.Empty) (*PasskeyOptionsResponse, error)
+	FinishPasskeyUserMe(context.Context, *PasskeyRegistrationFinishRequest) (*PasskeyInfoResponse, error)
+	DeletePasskeyUserMe(context.Context, *PasskeyIDRequest) (*empty.Empty, error)
+	SendEmailVerificationUserMe(context.Context, *empty.Empty) (*empty.Empty, error)
+	VerifyEmailUserMe(context.Context, *EmailVerifyRequest) (*empty.Empty, error)
 	mustEmbedUnimplementedUserMeServer()
 }
 
@@ -434,6 +2414,48 @@ func (UnimplementedUserMeServer) UpdateUserMe(context.Context, *UserUpdateReques
 func (UnimplementedUserMeServer) DeleteUserMe(context.Context, *empty.Empty) (*empty.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteUserMe not implemented")
 }
+func (UnimplementedUserMeServer) ListSessionUserMe(context.Context, *empty.Empty) (*SessionListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSessionUserMe not implemented")
+}
+func (UnimplementedUserMeServer) UpdatePasswdUserMe(context.Context, *UserPasswdUpdateRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePasswdUserMe not implemented")
+}
+func (UnimplementedUserMeServer) GetMFAUserMe(context.Context, *empty.Empty) (*MFAInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMFAUserMe not implemented")
+}
+func (UnimplementedUserMeServer) EnrollTOTPUserMe(context.Context, *empty.Empty) (*MFATOTPEnrollmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnrollTOTPUserMe not implemented")
+}
+func (UnimplementedUserMeServer) ConfirmTOTPUserMe(context.Context, *MFACodeRequest) (*MFARecoveryCodesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmTOTPUserMe not implemented")
+}
+func (UnimplementedUserMeServer) DisableTOTPUserMe(context.Context, *MFACodeRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisableTOTPUserMe not implemented")
+}
+func (UnimplementedUserMeServer) RegenerateRecoveryCodesUserMe(context.Context, *MFACodeRequest) (*MFARecoveryCodesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegenerateRecoveryCodesUserMe not implemented")
+}
+func (UnimplementedUserMeServer) RemovePasswdUserMe(context.Context, *UserPasswdRemoveRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemovePasswdUserMe not implemented")
+}
+func (UnimplementedUserMeServer) ListPasskeyUserMe(context.Context, *empty.Empty) (*PasskeyListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPasskeyUserMe not implemented")
+}
+func (UnimplementedUserMeServer) BeginPasskeyUserMe(context.Context, *empty.Empty) (*PasskeyOptionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BeginPasskeyUserMe not implemented")
+}
+func (UnimplementedUserMeServer) FinishPasskeyUserMe(context.Context, *PasskeyRegistrationFinishRequest) (*PasskeyInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinishPasskeyUserMe not implemented")
+}
+func (UnimplementedUserMeServer) DeletePasskeyUserMe(context.Context, *PasskeyIDRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePasskeyUserMe not implemented")
+}
+func (UnimplementedUserMeServer) SendEmailVerificationUserMe(context.Context, *empty.Empty) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendEmailVerificationUserMe not implemented")
+}
+func (UnimplementedUserMeServer) VerifyEmailUserMe(context.Context, *EmailVerifyRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyEmailUserMe not implemented")
+}
 func (UnimplementedUserMeServer) mustEmbedUnimplementedUserMeServer() {}
 
 // UnsafeUserMeServer may be embedded to opt out of forward compatibility for this service.
@@ -501,6 +2523,258 @@ func _UserMe_DeleteUserMe_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserMe_ListSessionUserMe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserMeServer).ListSessionUserMe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/UserMe/ListSessionUserMe",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserMeServer).ListSessionUserMe(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserMe_UpdatePasswdUserMe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserPasswdUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserMeServer).UpdatePasswdUserMe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/UserMe/UpdatePasswdUserMe",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserMeServer).UpdatePasswdUserMe(ctx, req.(*UserPasswdUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserMe_GetMFAUserMe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserMeServer).GetMFAUserMe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/UserMe/GetMFAUserMe",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserMeServer).GetMFAUserMe(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserMe_EnrollTOTPUserMe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserMeServer).EnrollTOTPUserMe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/UserMe/EnrollTOTPUserMe",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserMeServer).EnrollTOTPUserMe(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserMe_ConfirmTOTPUserMe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MFACodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserMeServer).ConfirmTOTPUserMe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/UserMe/ConfirmTOTPUserMe",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserMeServer).ConfirmTOTPUserMe(ctx, req.(*MFACodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserMe_DisableTOTPUserMe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MFACodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserMeServer).DisableTOTPUserMe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/UserMe/DisableTOTPUserMe",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserMeServer).DisableTOTPUserMe(ctx, req.(*MFACodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserMe_RegenerateRecoveryCodesUserMe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MFACodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserMeServer).RegenerateRecoveryCodesUserMe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/UserMe/RegenerateRecoveryCodesUserMe",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserMeServer).RegenerateRecoveryCodesUserMe(ctx, req.(*MFACodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserMe_RemovePasswdUserMe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserPasswdRemoveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserMeServer).RemovePasswdUserMe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/UserMe/RemovePasswdUserMe",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserMeServer).RemovePasswdUserMe(ctx, req.(*UserPasswdRemoveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserMe_ListPasskeyUserMe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserMeServer).ListPasskeyUserMe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/UserMe/ListPasskeyUserMe",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserMeServer).ListPasskeyUserMe(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserMe_BeginPasskeyUserMe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserMeServer).BeginPasskeyUserMe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/UserMe/BeginPasskeyUserMe",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserMeServer).BeginPasskeyUserMe(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserMe_FinishPasskeyUserMe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PasskeyRegistrationFinishRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserMeServer).FinishPasskeyUserMe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/UserMe/FinishPasskeyUserMe",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserMeServer).FinishPasskeyUserMe(ctx, req.(*PasskeyRegistrationFinishRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserMe_DeletePasskeyUserMe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PasskeyIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserMeServer).DeletePasskeyUserMe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/UserMe/DeletePasskeyUserMe",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserMeServer).DeletePasskeyUserMe(ctx, req.(*PasskeyIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserMe_SendEmailVerificationUserMe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserMeServer).SendEmailVerificationUserMe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/UserMe/SendEmailVerificationUserMe",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserMeServer).SendEmailVerificationUserMe(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserMe_VerifyEmailUserMe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmailVerifyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserMeServer).VerifyEmailUserMe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/UserMe/VerifyEmailUserMe",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserMeServer).VerifyEmailUserMe(ctx, req.(*EmailVerifyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserMe_ServiceDesc is the grpc.ServiceDesc for UserMe service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -520,6 +2794,62 @@ var UserMe_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteUserMe",
 			Handler:    _UserMe_DeleteUserMe_Handler,
 		},
+		{
+			MethodName: "ListSessionUserMe",
+			Handler:    _UserMe_ListSessionUserMe_Handler,
+		},
+		{
+			MethodName: "UpdatePasswdUserMe",
+			Handler:    _UserMe_UpdatePasswdUserMe_Handler,
+		},
+		{
+			MethodName: "GetMFAUserMe",
+			Handler:    _UserMe_GetMFAUserMe_Handler,
+		},
+		{
+			MethodName: "EnrollTOTPUserMe",
+			Handler:    _UserMe_EnrollTOTPUserMe_Handler,
+		},
+		{
+			MethodName: "ConfirmTOTPUserMe",
+			Handler:    _UserMe_ConfirmTOTPUserMe_Handler,
+		},
+		{
+			MethodName: "DisableTOTPUserMe",
+			Handler:    _UserMe_DisableTOTPUserMe_Handler,
+		},
+		{
+			MethodName: "RegenerateRecoveryCodesUserMe",
+			Handler:    _UserMe_RegenerateRecoveryCodesUserMe_Handler,
+		},
+		{
+			MethodName: "RemovePasswdUserMe",
+			Handler:    _UserMe_RemovePasswdUserMe_Handler,
+		},
+		{
+			MethodName: "ListPasskeyUserMe",
+			Handler:    _UserMe_ListPasskeyUserMe_Handler,
+		},
+		{
+			MethodName: "BeginPasskeyUserMe",
+			Handler:    _UserMe_BeginPasskeyUserMe_Handler,
+		},
+		{
+			MethodName: "FinishPasskeyUserMe",
+			Handler:    _UserMe_FinishPasskeyUserMe_Handler,
+		},
+		{
+			MethodName: "DeletePasskeyUserMe",
+			Handler:    _UserMe_DeletePasskeyUserMe_Handler,
+		},
+		{
+			MethodName: "SendEmailVerificationUserMe",
+			Handler:    _UserMe_SendEmailVerificationUserMe_Handler,
+		},
+		{
+			MethodName: "VerifyEmailUserMe",
+			Handler:    _UserMe_VerifyEmailUserMe_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/protobuf/api.proto",
@@ -4,6 +4,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/ssup2ket/service-auth/internal/domain/service"
 	"github.com/ssup2ket/service-auth/internal/server/errors"
 )
 
@@ -20,6 +21,22 @@ func getErrNotFound(res errors.ErrResouce) error {
 	switch res {
 	case errors.ErrResouceUser:
 		errCode = errors.CodeNotFoundUser
+	case errors.ErrResouceOAuthClient:
+		errCode = errors.CodeNotFoundOAuthClient
+	case errors.ErrResouceRole:
+		errCode = errors.CodeNotFoundRole
+	case errors.ErrResoucePermission:
+		errCode = errors.CodeNotFoundPermission
+	case errors.ErrResouceTenant:
+		errCode = errors.CodeNotFoundTenant
+	case errors.ErrResouceGroup:
+		errCode = errors.CodeNotFoundGroup
+	case errors.ErrResouceGroupMember:
+		errCode = errors.CodeNotFoundGroupMember
+	case errors.ErrResouceLoginLock:
+		errCode = errors.CodeNotFoundLoginLock
+	case errors.ErrResoucePasskey:
+		errCode = errors.CodeNotFoundPasskey
 	}
 
 	return status.Error(codes.NotFound, errCode)
@@ -30,11 +47,133 @@ func getErrConflict(res errors.ErrResouce) error {
 	switch res {
 	case errors.ErrResouceUser:
 		errCode = errors.CodeConflictUser
+	case errors.ErrResouceRole:
+		errCode = errors.CodeConflictRole
+	case errors.ErrResoucePermission:
+		errCode = errors.CodeConflictPermission
+	case errors.ErrResouceTenant:
+		errCode = errors.CodeConflictTenant
+	case errors.ErrResouceGroup:
+		errCode = errors.CodeConflictGroup
+	case errors.ErrResouceGroupMember:
+		errCode = errors.CodeConflictGroupMember
 	}
 
 	return status.Error(codes.AlreadyExists, errCode)
 }
 
+func getErrTokenKeyRotationDisabled() error {
+	return status.Error(codes.FailedPrecondition, errors.CodeTokenKeyRotationDisabled)
+}
+
+func getErrTokenAudienceNotAllowed() error {
+	return status.Error(codes.InvalidArgument, errors.CodeTokenAudienceNotAllowed)
+}
+
+func getErrTokenScopeNotAllowed() error {
+	return status.Error(codes.InvalidArgument, errors.CodeTokenScopeNotAllowed)
+}
+
+func getErrOAuthClientGrantTypeNotAllowed() error {
+	return status.Error(codes.InvalidArgument, errors.CodeOAuthClientGrantTypeNotAllowed)
+}
+
+func getErrRoleNotExist() error {
+	return status.Error(codes.InvalidArgument, errors.CodeRoleNotExist)
+}
+
+func getErrRoleInUse() error {
+	return status.Error(codes.FailedPrecondition, errors.CodeRoleInUse)
+}
+
+func getErrTenantNotExist() error {
+	return status.Error(codes.InvalidArgument, errors.CodeTenantNotExist)
+}
+
+func getErrTenantInUse() error {
+	return status.Error(codes.FailedPrecondition, errors.CodeTenantInUse)
+}
+
+func getErrGroupMemberNotExist() error {
+	return status.Error(codes.InvalidArgument, errors.CodeGroupMemberNotExist)
+}
+
+func getErrGroupMemberCycle() error {
+	return status.Error(codes.FailedPrecondition, errors.CodeGroupMemberCycle)
+}
+
+func getErrPasswdPolicy(err error) error {
+	errCode := errors.CodeBadRequest
+	switch err {
+	case service.ErrPasswdTooShort:
+		errCode = errors.CodePasswdTooShort
+	case service.ErrPasswdTooLong:
+		errCode = errors.CodePasswdTooLong
+	case service.ErrPasswdCharClasses:
+		errCode = errors.CodePasswdCharClasses
+	case service.ErrPasswdBreached:
+		errCode = errors.CodePasswdBreached
+	case service.ErrPasswdUserInput:
+		errCode = errors.CodePasswdUserInput
+	case service.ErrPasswdReused:
+		errCode = errors.CodePasswdReused
+	}
+
+	return status.Error(codes.InvalidArgument, errCode)
+}
+
+func getErrPasswdExpired() error {
+	return status.Error(codes.PermissionDenied, errors.CodePasswdExpired)
+}
+
+func getErrLoginLocked() error {
+	return status.Error(codes.ResourceExhausted, errors.CodeLoginLocked)
+}
+
+func getErrRateLimited() error {
+	return status.Error(codes.ResourceExhausted, errors.CodeRateLimited)
+}
+
+func getErrMFADisabled() error {
+	return status.Error(codes.FailedPrecondition, errors.CodeMFADisabled)
+}
+
+func getErrMFARequired() error {
+	return status.Error(codes.PermissionDenied, errors.CodeMFARequired)
+}
+
+func getErrMFACodeWrong() error {
+	return status.Error(codes.InvalidArgument, errors.CodeMFACodeWrong)
+}
+
+func getErrMFAAlreadyEnabled() error {
+	return status.Error(codes.FailedPrecondition, errors.CodeMFAAlreadyEnabled)
+}
+
+func getErrMFANotEnabled() error {
+	return status.Error(codes.FailedPrecondition, errors.CodeMFANotEnabled)
+}
+
+func getErrPasskeyDisabled() error {
+	return status.Error(codes.FailedPrecondition, errors.CodePasskeyDisabled)
+}
+
+func getErrPasskeyVerificationFailed() error {
+	return status.Error(codes.InvalidArgument, errors.CodePasskeyVerificationFailed)
+}
+
+func getErrPasskeyRequired() error {
+	return status.Error(codes.FailedPrecondition, errors.CodePasskeyRequired)
+}
+
+func getErrEmailNotVerified() error {
+	return status.Error(codes.PermissionDenied, errors.CodeEmailNotVerified)
+}
+
+func getErrEmailAlreadyVerified() error {
+	return status.Error(codes.FailedPrecondition, errors.CodeEmailAlreadyVerified)
+}
+
 func getErrServerError() error {
 	return status.Error(codes.Unknown, errors.CodeServerError)
 }
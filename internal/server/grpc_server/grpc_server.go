@@ -11,6 +11,7 @@ import (
 	"google.golang.org/grpc/reflection"
 
 	"github.com/ssup2ket/service-auth/internal/domain"
+	"github.com/ssup2ket/service-auth/internal/server/middleware"
 )
 
 // ServerGRPC
@@ -19,11 +20,18 @@ type ServerGRPC struct {
 	domain     *domain.Domain
 
 	UnimplementedTokenServer
+	UnimplementedKeyServer
+	UnimplementedOAuthClientServer
+	UnimplementedRoleServer
+	UnimplementedPermissionServer
+	UnimplementedTenantServer
+	UnimplementedLoginLockServer
+	UnimplementedGroupServer
 	UnimplementedUserServer
 	UnimplementedUserMeServer
 }
 
-func New(d *domain.Domain, e *casbin.Enforcer) (*ServerGRPC, error) {
+func New(d *domain.Domain, tenantDomain string, e *casbin.SyncedEnforcer, limiter *middleware.RateLimiter) (*ServerGRPC, error) {
 	server := ServerGRPC{
 		grpcServer: grpc.NewServer(
 			grpc_middleware.WithUnaryServerChain(
@@ -34,7 +42,9 @@ func New(d *domain.Domain, e *casbin.Enforcer) (*ServerGRPC, error) {
 				icOpenTracingSetterUnary(),
 				icAccessLoggerUnary(),
 
-				icAccessTokenValidaterAndSetterUnary(),
+				icTenantIDSetterUnary(tenantDomain),
+				icAccessTokenValidaterAndSetterUnary(d.TokenRevocation),
+				icRateLimiterUnary(limiter),
 				icAuthorizerUnary(e),
 				icUserIDLoggerSetterUnary(),
 			),
@@ -44,6 +54,13 @@ func New(d *domain.Domain, e *casbin.Enforcer) (*ServerGRPC, error) {
 
 	// Regist service
 	RegisterTokenServer(server.grpcServer, &server)
+	RegisterKeyServer(server.grpcServer, &server)
+	RegisterOAuthClientServer(server.grpcServer, &server)
+	RegisterRoleServer(server.grpcServer, &server)
+	RegisterPermissionServer(server.grpcServer, &server)
+	RegisterTenantServer(server.grpcServer, &server)
+	RegisterLoginLockServer(server.grpcServer, &server)
+	RegisterGroupServer(server.grpcServer, &server)
 	RegisterUserServer(server.grpcServer, &server)
 	RegisterUserMeServer(server.grpcServer, &server)
 
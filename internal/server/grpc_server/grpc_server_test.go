@@ -0,0 +1,59 @@
+package grpc_server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ssup2ket/service-auth/internal/domain"
+	"github.com/ssup2ket/service-auth/internal/server/middleware"
+)
+
+// Services not authorized by the permission catalogue
+var servicesNotInCatalogue = map[string]struct{}{
+	"grpc.reflection.v1alpha.ServerReflection": {},
+}
+
+type methodSuite struct {
+	suite.Suite
+
+	server *ServerGRPC
+}
+
+func TestMethod(t *testing.T) {
+	suite.Run(t, new(methodSuite))
+}
+
+func (m *methodSuite) SetupTest() {
+	var err error
+	m.server, err = New(&domain.Domain{}, "", nil, nil)
+	require.NoError(m.T(), err)
+}
+
+func (m *methodSuite) TestMethodsInCatalogue() {
+	for service, info := range m.server.grpcServer.GetServiceInfo() {
+		if _, ok := servicesNotInCatalogue[service]; ok {
+			continue
+		}
+		for _, method := range info.Methods {
+			fullMethod := "/" + service + "/" + method.Name
+			_, ok := middleware.GetGRPCOperation(fullMethod)
+			require.True(m.T(), ok, "%s isn't in the permission catalogue", fullMethod)
+		}
+	}
+}
+
+func (m *methodSuite) TestCatalogueInMethods() {
+	methods := map[string]struct{}{}
+	for service, info := range m.server.grpcServer.GetServiceInfo() {
+		for _, method := range info.Methods {
+			methods["/"+service+"/"+method.Name] = struct{}{}
+		}
+	}
+
+	for _, method := range middleware.GetGRPCMethods() {
+		_, ok := methods[method]
+		require.True(m.T(), ok, "%s of the permission catalogue isn't registered", method)
+	}
+}
@@ -0,0 +1,73 @@
+package grpc_server
+
+import (
+	"context"
+
+	empty "github.com/golang/protobuf/ptypes/empty"
+	"github.com/rs/zerolog/log"
+
+	"github.com/ssup2ket/service-auth/internal/domain/service"
+	"github.com/ssup2ket/service-auth/internal/server/errors"
+	"github.com/ssup2ket/service-auth/internal/server/middleware"
+	"github.com/ssup2ket/service-auth/internal/server/request"
+)
+
+// Send a verification token to the email of the user of the access token
+func (s *ServerGRPC) SendEmailVerificationUserMe(ctx context.Context, req *empty.Empty) (*empty.Empty, error) {
+	// Get subject
+	subject, err := getUserMeSubject(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send email verification
+	if err := s.domain.EmailVerification.SendEmailVerification(ctx, subject); err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("User doesn't exist")
+			return nil, getErrNotFound(errors.ErrResouceUser)
+		} else if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Subject doesn't have email")
+			return nil, getErrUnauthorized()
+		} else if err == service.ErrEmailAlreadyVerified {
+			log.Ctx(ctx).Error().Err(err).Msg("Email is already verified")
+			return nil, getErrEmailAlreadyVerified()
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to send email verification")
+		return nil, getErrServerError()
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// Verify an email with the token sent to the email. It doesn't need an access token, because the token is opened from the mail.
+func (s *ServerGRPC) VerifyEmailUserMe(ctx context.Context, req *EmailVerifyRequest) (*empty.Empty, error) {
+	// Validate request
+	if err := req.validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong verify email request")
+		return nil, getErrBadRequest()
+	}
+
+	// Get tenant ID
+	tenantID, err := middleware.GetTenantIDFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No tenant ID in context")
+		return nil, getErrServerError()
+	}
+
+	// Verify email
+	if err := s.domain.EmailVerification.VerifyEmail(ctx, tenantID, req.Token); err != nil {
+		if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Wrong email verification token")
+			return nil, getErrUnauthorized()
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to verify email")
+		return nil, getErrServerError()
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// Request validate
+func (e *EmailVerifyRequest) validate() error {
+	return request.ValidateEmailVerifyToken(e.Token)
+}
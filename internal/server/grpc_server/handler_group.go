@@ -0,0 +1,346 @@
+package grpc_server
+
+import (
+	"context"
+
+	empty "github.com/golang/protobuf/ptypes/empty"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/service"
+	"github.com/ssup2ket/service-auth/internal/server/errors"
+	"github.com/ssup2ket/service-auth/internal/server/middleware"
+	"github.com/ssup2ket/service-auth/internal/server/request"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+func (s *ServerGRPC) ListGroup(ctx context.Context, req *GroupListRequest) (*GroupListResponse, error) {
+	// Get tenant ID
+	tenantID, err := middleware.GetTenantIDFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No tenant ID in context")
+		return nil, getErrServerError()
+	}
+
+	// List groups
+	groupModels, err := s.domain.Group.ListGroup(ctx, tenantID, int(req.Offset), int(req.Limit))
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to list groups")
+		return nil, getErrServerError()
+	}
+
+	groups := []*GroupInfoResponse{}
+	for i := range groupModels {
+		groups = append(groups, groupModelToGroupInfo(&groupModels[i]))
+	}
+	return &GroupListResponse{
+		Groups: groups,
+	}, nil
+}
+
+func (s *ServerGRPC) CreateGroup(ctx context.Context, req *GroupCreateRequest) (*GroupInfoResponse, error) {
+	// Validate request
+	if err := req.validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong create group request")
+		return nil, getErrBadRequest()
+	}
+
+	// Get subject
+	subject, err := middleware.GetSubjectFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No subject in context")
+		return nil, getErrServerError()
+	}
+
+	// Create group
+	group, err := s.domain.Group.CreateGroup(ctx, subject, groupCreateToGroupModel(req))
+	if err != nil {
+		if err == service.ErrRepoConflict {
+			log.Ctx(ctx).Error().Err(err).Msg("Group already exists")
+			return nil, getErrConflict(errors.ErrResouceGroup)
+		} else if err == service.ErrRoleNotExist {
+			log.Ctx(ctx).Error().Err(err).Msg("Role doesn't exist")
+			return nil, getErrRoleNotExist()
+		} else if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Group isn't allowed to be created")
+			return nil, getErrUnauthorized()
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to create group")
+		return nil, getErrServerError()
+	}
+
+	return groupModelToGroupInfo(group), nil
+}
+
+func (s *ServerGRPC) GetGroup(ctx context.Context, req *GroupIDRequest) (*GroupInfoResponse, error) {
+	// Validate request
+	if err := req.validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong get group request")
+		return nil, getErrBadRequest()
+	}
+
+	// Get tenant ID
+	tenantID, err := middleware.GetTenantIDFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No tenant ID in context")
+		return nil, getErrServerError()
+	}
+
+	// Get group
+	group, err := s.domain.Group.GetGroup(ctx, tenantID, uuid.FromStringOrNil(req.Id))
+	if err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("Group doesn't exist")
+			return nil, getErrNotFound(errors.ErrResouceGroup)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get group")
+		return nil, getErrServerError()
+	}
+
+	return groupModelToGroupInfo(group), nil
+}
+
+func (s *ServerGRPC) UpdateGroup(ctx context.Context, req *GroupUpdateRequest) (*empty.Empty, error) {
+	// Validate request
+	if err := req.validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong update group request")
+		return nil, getErrBadRequest()
+	}
+
+	// Get subject
+	subject, err := middleware.GetSubjectFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No subject in context")
+		return nil, getErrServerError()
+	}
+
+	// Update group
+	if err := s.domain.Group.UpdateGroup(ctx, subject, groupUpdateToGroupModel(req)); err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("Group doesn't exist")
+			return nil, getErrNotFound(errors.ErrResouceGroup)
+		} else if err == service.ErrRepoConflict {
+			log.Ctx(ctx).Error().Err(err).Msg("Group already exists")
+			return nil, getErrConflict(errors.ErrResouceGroup)
+		} else if err == service.ErrRoleNotExist {
+			log.Ctx(ctx).Error().Err(err).Msg("Role doesn't exist")
+			return nil, getErrRoleNotExist()
+		} else if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Group isn't allowed to be changed")
+			return nil, getErrUnauthorized()
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to update group")
+		return nil, getErrServerError()
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (s *ServerGRPC) DeleteGroup(ctx context.Context, req *GroupIDRequest) (*empty.Empty, error) {
+	// Validate request
+	if err := req.validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong delete group request")
+		return nil, getErrBadRequest()
+	}
+
+	// Get subject
+	subject, err := middleware.GetSubjectFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No subject in context")
+		return nil, getErrServerError()
+	}
+
+	// Delete group
+	if err := s.domain.Group.DeleteGroup(ctx, subject, uuid.FromStringOrNil(req.Id)); err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("Group doesn't exist")
+			return nil, getErrNotFound(errors.ErrResouceGroup)
+		} else if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Group isn't allowed to be changed")
+			return nil, getErrUnauthorized()
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete group")
+		return nil, getErrServerError()
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (s *ServerGRPC) ListGroupMember(ctx context.Context, req *GroupMemberListRequest) (*GroupMemberListResponse, error) {
+	// Validate request
+	if err := req.validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong list group member request")
+		return nil, getErrBadRequest()
+	}
+
+	// Get tenant ID
+	tenantID, err := middleware.GetTenantIDFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No tenant ID in context")
+		return nil, getErrServerError()
+	}
+
+	// List group members
+	groupMemberModels, err := s.domain.Group.ListGroupMember(ctx, tenantID, uuid.FromStringOrNil(req.GroupId), int(req.Offset), int(req.Limit))
+	if err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("Group doesn't exist")
+			return nil, getErrNotFound(errors.ErrResouceGroup)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to list group members")
+		return nil, getErrServerError()
+	}
+
+	members := []*GroupMemberInfoResponse{}
+	for i := range groupMemberModels {
+		members = append(members, groupMemberModelToGroupMemberInfo(&groupMemberModels[i]))
+	}
+	return &GroupMemberListResponse{
+		Members: members,
+	}, nil
+}
+
+func (s *ServerGRPC) AddGroupMember(ctx context.Context, req *GroupMemberCreateRequest) (*GroupMemberInfoResponse, error) {
+	// Validate request
+	if err := req.validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong add group member request")
+		return nil, getErrBadRequest()
+	}
+
+	// Get subject
+	subject, err := middleware.GetSubjectFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No subject in context")
+		return nil, getErrServerError()
+	}
+
+	// Add group member
+	groupMember, err := s.domain.Group.AddGroupMember(ctx, subject, groupMemberCreateToGroupMemberModel(req))
+	if err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("Group doesn't exist")
+			return nil, getErrNotFound(errors.ErrResouceGroup)
+		} else if err == service.ErrRepoConflict {
+			log.Ctx(ctx).Error().Err(err).Msg("Group member already exists")
+			return nil, getErrConflict(errors.ErrResouceGroupMember)
+		} else if err == service.ErrGroupMemberNotExist {
+			log.Ctx(ctx).Error().Err(err).Msg("Group member doesn't exist")
+			return nil, getErrGroupMemberNotExist()
+		} else if err == service.ErrGroupMemberCycle {
+			log.Ctx(ctx).Error().Err(err).Msg("Group member makes a cycle")
+			return nil, getErrGroupMemberCycle()
+		} else if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Group isn't allowed to be changed")
+			return nil, getErrUnauthorized()
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to add group member")
+		return nil, getErrServerError()
+	}
+
+	return groupMemberModelToGroupMemberInfo(groupMember), nil
+}
+
+func (s *ServerGRPC) RemoveGroupMember(ctx context.Context, req *GroupMemberIDRequest) (*empty.Empty, error) {
+	// Validate request
+	if err := req.validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong remove group member request")
+		return nil, getErrBadRequest()
+	}
+
+	// Get subject
+	subject, err := middleware.GetSubjectFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No subject in context")
+		return nil, getErrServerError()
+	}
+
+	// Remove group member
+	if err := s.domain.Group.RemoveGroupMember(ctx, subject, uuid.FromStringOrNil(req.GroupId), uuid.FromStringOrNil(req.MemberId)); err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("Group or group member doesn't exist")
+			return nil, getErrNotFound(errors.ErrResouceGroupMember)
+		} else if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Group isn't allowed to be changed")
+			return nil, getErrUnauthorized()
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to remove group member")
+		return nil, getErrServerError()
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// Request validate
+func (r *GroupIDRequest) validate() error {
+	return request.ValidateGroupUUID(r.Id)
+}
+
+func (r *GroupCreateRequest) validate() error {
+	return request.ValidateGroupCreate(r.Name, r.Description, r.Roles)
+}
+
+func (r *GroupUpdateRequest) validate() error {
+	return request.ValidateGroupUpdate(r.Id, r.Name, r.Description, r.Roles)
+}
+
+func (r *GroupMemberListRequest) validate() error {
+	return request.ValidateGroupUUID(r.GroupId)
+}
+
+func (r *GroupMemberCreateRequest) validate() error {
+	return request.ValidateGroupMemberCreate(r.GroupId, r.MemberId, r.MemberType)
+}
+
+func (r *GroupMemberIDRequest) validate() error {
+	if err := request.ValidateGroupUUID(r.GroupId); err != nil {
+		return err
+	}
+	return request.ValidateUserUUID(r.MemberId)
+}
+
+// DTO <-> Model
+func groupCreateToGroupModel(groupCreate *GroupCreateRequest) *entity.Group {
+	return &entity.Group{
+		Name:        groupCreate.Name,
+		Description: groupCreate.Description,
+		Roles:       groupCreate.Roles,
+	}
+}
+
+func groupUpdateToGroupModel(groupUpdate *GroupUpdateRequest) *entity.Group {
+	return &entity.Group{
+		ID:          uuid.FromStringOrNil(groupUpdate.Id),
+		Name:        groupUpdate.Name,
+		Description: groupUpdate.Description,
+		Roles:       groupUpdate.Roles,
+	}
+}
+
+func groupModelToGroupInfo(groupModel *entity.Group) *GroupInfoResponse {
+	return &GroupInfoResponse{
+		Id:          groupModel.ID.String(),
+		TenantId:    groupModel.TenantID,
+		Name:        groupModel.Name,
+		Description: groupModel.Description,
+		Roles:       []string(groupModel.Roles),
+		CreatedAt:   timestamppb.New(groupModel.CreatedAt),
+	}
+}
+
+func groupMemberCreateToGroupMemberModel(groupMemberCreate *GroupMemberCreateRequest) *entity.GroupMember {
+	return &entity.GroupMember{
+		GroupID:    uuid.FromStringOrNil(groupMemberCreate.GroupId),
+		MemberID:   uuid.FromStringOrNil(groupMemberCreate.MemberId),
+		MemberType: entity.GroupMemberType(groupMemberCreate.MemberType),
+	}
+}
+
+func groupMemberModelToGroupMemberInfo(groupMemberModel *entity.GroupMember) *GroupMemberInfoResponse {
+	return &GroupMemberInfoResponse{
+		GroupId:    groupMemberModel.GroupID.String(),
+		MemberId:   groupMemberModel.MemberID.String(),
+		MemberType: string(groupMemberModel.MemberType),
+		CreatedAt:  timestamppb.New(groupMemberModel.CreatedAt),
+	}
+}
@@ -0,0 +1,65 @@
+package grpc_server
+
+import (
+	"context"
+
+	empty "github.com/golang/protobuf/ptypes/empty"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/service"
+)
+
+func (s *ServerGRPC) ListKey(ctx context.Context, req *empty.Empty) (*KeyListResponse, error) {
+	// List token keys
+	tokenKeys, err := s.domain.Key.ListTokenKeys(ctx)
+	if err != nil {
+		if err == service.ErrTokenKeyRotationDisabled {
+			log.Ctx(ctx).Error().Err(err).Msg("Token keys aren't managed by keyring")
+			return nil, getErrTokenKeyRotationDisabled()
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to list token keys")
+		return nil, getErrServerError()
+	}
+
+	return &KeyListResponse{
+		Keys: TokenKeyModelListToKeyInfoList(tokenKeys),
+	}, nil
+}
+
+func (s *ServerGRPC) RotateKey(ctx context.Context, req *empty.Empty) (*empty.Empty, error) {
+	// Rotate token keys
+	if err := s.domain.Key.RotateTokenKeys(ctx); err != nil {
+		if err == service.ErrTokenKeyRotationDisabled {
+			log.Ctx(ctx).Error().Err(err).Msg("Token keys aren't managed by keyring")
+			return nil, getErrTokenKeyRotationDisabled()
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to rotate token keys")
+		return nil, getErrServerError()
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// DTO <-> Model
+func TokenKeyModelListToKeyInfoList(tokenKeyModelList []entity.TokenKey) []*KeyInfoResponse {
+	keyInfos := []*KeyInfoResponse{}
+	for _, tokenKeyModel := range tokenKeyModelList {
+		tmp := KeyInfoResponse{
+			Id:        tokenKeyModel.KeyID,
+			Type:      string(tokenKeyModel.Type),
+			Alg:       tokenKeyModel.Alg,
+			Status:    string(tokenKeyModel.Status),
+			CreatedAt: timestamppb.New(tokenKeyModel.CreatedAt),
+		}
+		if tokenKeyModel.RetiredAt != nil {
+			tmp.RetiredAt = timestamppb.New(*tokenKeyModel.RetiredAt)
+		}
+		if tokenKeyModel.ExpiresAt != nil {
+			tmp.ExpiresAt = timestamppb.New(*tokenKeyModel.ExpiresAt)
+		}
+		keyInfos = append(keyInfos, &tmp)
+	}
+	return keyInfos
+}
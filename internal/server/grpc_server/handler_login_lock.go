@@ -0,0 +1,94 @@
+package grpc_server
+
+import (
+	"context"
+	"time"
+
+	empty "github.com/golang/protobuf/ptypes/empty"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/service"
+	"github.com/ssup2ket/service-auth/internal/server/errors"
+	"github.com/ssup2ket/service-auth/internal/server/request"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+func (s *ServerGRPC) ListLoginLock(ctx context.Context, req *LoginLockListRequest) (*LoginLockListResponse, error) {
+	// List login locks
+	loginLockModels, err := s.domain.LoginLock.ListLoginLock(ctx, int(req.Offset), int(req.Limit))
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to list login locks")
+		return nil, getErrServerError()
+	}
+
+	now := time.Now()
+	loginLocks := []*LoginLockInfoResponse{}
+	for i := range loginLockModels {
+		loginLocks = append(loginLocks, loginLockModelToLoginLockInfo(&loginLockModels[i], now))
+	}
+	return &LoginLockListResponse{
+		LoginLocks: loginLocks,
+	}, nil
+}
+
+func (s *ServerGRPC) GetLoginLock(ctx context.Context, req *LoginLockIDRequest) (*LoginLockInfoResponse, error) {
+	// Validate request
+	if err := req.validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong get login lock request")
+		return nil, getErrBadRequest()
+	}
+
+	// Get login lock
+	loginLock, err := s.domain.LoginLock.GetLoginLock(ctx, uuid.FromStringOrNil(req.Id))
+	if err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("Login lock doesn't exist")
+			return nil, getErrNotFound(errors.ErrResouceLoginLock)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get login lock")
+		return nil, getErrServerError()
+	}
+
+	return loginLockModelToLoginLockInfo(loginLock, time.Now()), nil
+}
+
+func (s *ServerGRPC) DeleteLoginLock(ctx context.Context, req *LoginLockIDRequest) (*empty.Empty, error) {
+	// Validate request
+	if err := req.validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong delete login lock request")
+		return nil, getErrBadRequest()
+	}
+
+	// Delete login lock
+	if err := s.domain.LoginLock.DeleteLoginLock(ctx, uuid.FromStringOrNil(req.Id)); err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("Login lock doesn't exist")
+			return nil, getErrNotFound(errors.ErrResouceLoginLock)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete login lock")
+		return nil, getErrServerError()
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// Request validate
+func (r *LoginLockIDRequest) validate() error {
+	return request.ValidateLoginLockUUID(r.Id)
+}
+
+// DTO <-> Model
+func loginLockModelToLoginLockInfo(loginLockModel *entity.LoginLock, now time.Time) *LoginLockInfoResponse {
+	return &LoginLockInfoResponse{
+		Id:           loginLockModel.ID.String(),
+		Type:         string(loginLockModel.Type),
+		TenantId:     loginLockModel.TenantID,
+		Subject:      loginLockModel.Subject,
+		Failures:     int32(loginLockModel.Failures),
+		LastFailedAt: timestamppb.New(loginLockModel.LastFailedAt),
+		LockedUntil:  timestamppb.New(loginLockModel.LockedUntil),
+		Locked:       loginLockModel.IsLocked(now),
+	}
+}
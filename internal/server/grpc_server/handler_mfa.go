@@ -0,0 +1,226 @@
+package grpc_server
+
+import (
+	"context"
+
+	empty "github.com/golang/protobuf/ptypes/empty"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/service"
+	"github.com/ssup2ket/service-auth/internal/server/errors"
+	"github.com/ssup2ket/service-auth/internal/server/middleware"
+	"github.com/ssup2ket/service-auth/internal/server/request"
+)
+
+// Get MFA status of the user of the access token
+func (s *ServerGRPC) GetMFAUserMe(ctx context.Context, req *empty.Empty) (*MFAInfoResponse, error) {
+	// Get subject
+	subject, err := getUserMeSubject(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get MFA
+	mfaInfo, err := s.domain.MFA.GetMFA(ctx, subject)
+	if err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("User doesn't exist")
+			return nil, getErrNotFound(errors.ErrResouceUser)
+		} else if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Subject doesn't have MFA")
+			return nil, getErrUnauthorized()
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get MFA")
+		return nil, getErrServerError()
+	}
+
+	return mfaInfoModelToMFAInfoResponse(mfaInfo), nil
+}
+
+// Enroll TOTP. The TOTP secret is returned only once, and TOTP is enabled after it's confirmed.
+func (s *ServerGRPC) EnrollTOTPUserMe(ctx context.Context, req *empty.Empty) (*MFATOTPEnrollmentResponse, error) {
+	// Get subject
+	subject, err := getUserMeSubject(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Enroll TOTP
+	enrollment, err := s.domain.MFA.EnrollTOTP(ctx, subject)
+	if err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("User doesn't exist")
+			return nil, getErrNotFound(errors.ErrResouceUser)
+		} else if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Subject doesn't have MFA")
+			return nil, getErrUnauthorized()
+		} else if err == service.ErrMFADisabled {
+			log.Ctx(ctx).Error().Err(err).Msg("MFA is disabled")
+			return nil, getErrMFADisabled()
+		} else if err == service.ErrMFAAlreadyEnabled {
+			log.Ctx(ctx).Error().Err(err).Msg("TOTP is already enabled")
+			return nil, getErrMFAAlreadyEnabled()
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to enroll TOTP")
+		return nil, getErrServerError()
+	}
+
+	return &MFATOTPEnrollmentResponse{
+		Secret: enrollment.Secret,
+		Uri:    enrollment.URI,
+	}, nil
+}
+
+// Confirm the enrolled TOTP with a first code to enable it. Recovery codes are returned only once.
+func (s *ServerGRPC) ConfirmTOTPUserMe(ctx context.Context, req *MFACodeRequest) (*MFARecoveryCodesResponse, error) {
+	// Validate request. Only a TOTP code confirms TOTP.
+	if err := request.ValidateTOTPCode(req.Code); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong confirm TOTP request")
+		return nil, getErrBadRequest()
+	}
+
+	// Get subject
+	subject, err := getUserMeSubject(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Confirm TOTP
+	recoveryCodes, err := s.domain.MFA.ConfirmTOTP(ctx, subject, req.Code)
+	if err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("User doesn't exist")
+			return nil, getErrNotFound(errors.ErrResouceUser)
+		} else if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Subject doesn't have MFA")
+			return nil, getErrUnauthorized()
+		} else if err == service.ErrMFADisabled {
+			log.Ctx(ctx).Error().Err(err).Msg("MFA is disabled")
+			return nil, getErrMFADisabled()
+		} else if err == service.ErrMFAAlreadyEnabled {
+			log.Ctx(ctx).Error().Err(err).Msg("TOTP is already enabled")
+			return nil, getErrMFAAlreadyEnabled()
+		} else if err == service.ErrMFANotEnabled {
+			log.Ctx(ctx).Error().Err(err).Msg("TOTP isn't enrolled")
+			return nil, getErrMFANotEnabled()
+		} else if err == service.ErrMFACodeWrong {
+			log.Ctx(ctx).Error().Err(err).Msg("Wrong TOTP code")
+			return nil, getErrMFACodeWrong()
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to confirm TOTP")
+		return nil, getErrServerError()
+	}
+
+	return &MFARecoveryCodesResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+// Disable TOTP with a TOTP code or a recovery code
+func (s *ServerGRPC) DisableTOTPUserMe(ctx context.Context, req *MFACodeRequest) (*empty.Empty, error) {
+	// Validate request
+	if err := req.validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong disable TOTP request")
+		return nil, getErrBadRequest()
+	}
+
+	// Get subject
+	subject, err := getUserMeSubject(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Disable TOTP
+	if err := s.domain.MFA.DisableTOTP(ctx, subject, req.Code, getClientIP(ctx)); err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("User doesn't exist")
+			return nil, getErrNotFound(errors.ErrResouceUser)
+		} else if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Subject doesn't have MFA")
+			return nil, getErrUnauthorized()
+		} else if err == service.ErrMFANotEnabled {
+			log.Ctx(ctx).Error().Err(err).Msg("TOTP isn't enabled")
+			return nil, getErrMFANotEnabled()
+		} else if err == service.ErrMFACodeWrong {
+			log.Ctx(ctx).Error().Err(err).Msg("Wrong MFA code")
+			return nil, getErrMFACodeWrong()
+		} else if err == service.ErrLoginLocked {
+			log.Ctx(ctx).Error().Err(err).Msg("Login is locked")
+			return nil, getErrLoginLocked()
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to disable TOTP")
+		return nil, getErrServerError()
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// Regenerate recovery codes with a TOTP code or a recovery code. Recovery codes are returned only once.
+func (s *ServerGRPC) RegenerateRecoveryCodesUserMe(ctx context.Context, req *MFACodeRequest) (*MFARecoveryCodesResponse, error) {
+	// Validate request
+	if err := req.validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong regenerate recovery codes request")
+		return nil, getErrBadRequest()
+	}
+
+	// Get subject
+	subject, err := getUserMeSubject(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Regenerate recovery codes
+	recoveryCodes, err := s.domain.MFA.RegenerateMFARecoveryCodes(ctx, subject, req.Code, getClientIP(ctx))
+	if err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("User doesn't exist")
+			return nil, getErrNotFound(errors.ErrResouceUser)
+		} else if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Subject doesn't have MFA")
+			return nil, getErrUnauthorized()
+		} else if err == service.ErrMFANotEnabled {
+			log.Ctx(ctx).Error().Err(err).Msg("TOTP isn't enabled")
+			return nil, getErrMFANotEnabled()
+		} else if err == service.ErrMFACodeWrong {
+			log.Ctx(ctx).Error().Err(err).Msg("Wrong MFA code")
+			return nil, getErrMFACodeWrong()
+		} else if err == service.ErrLoginLocked {
+			log.Ctx(ctx).Error().Err(err).Msg("Login is locked")
+			return nil, getErrLoginLocked()
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to regenerate recovery codes")
+		return nil, getErrServerError()
+	}
+
+	return &MFARecoveryCodesResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+// Get the subject of the access token having a user
+func getUserMeSubject(ctx context.Context) (*entity.Subject, error) {
+	if _, err := getUserMeID(ctx); err != nil {
+		return nil, err
+	}
+	subject, err := middleware.GetSubjectFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No subject in context")
+		return nil, getErrServerError()
+	}
+	return subject, nil
+}
+
+// Request validate
+func (m *MFACodeRequest) validate() error {
+	return request.ValidateMFACode(m.Code)
+}
+
+// DTO <-> Model
+func mfaInfoModelToMFAInfoResponse(mfaInfo *entity.MFAInfo) *MFAInfoResponse {
+	resp := &MFAInfoResponse{
+		TotpEnabled:   mfaInfo.TOTPEnabled,
+		RecoveryCodes: int32(mfaInfo.RecoveryCodes),
+	}
+	if mfaInfo.TOTPEnabledAt != nil {
+		resp.TotpEnabledAt = timestamppb.New(*mfaInfo.TOTPEnabledAt)
+	}
+	return resp
+}
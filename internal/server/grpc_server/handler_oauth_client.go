@@ -0,0 +1,164 @@
+package grpc_server
+
+import (
+	"context"
+
+	empty "github.com/golang/protobuf/ptypes/empty"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/service"
+	"github.com/ssup2ket/service-auth/internal/server/errors"
+	"github.com/ssup2ket/service-auth/internal/server/request"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+func (s *ServerGRPC) ListOAuthClient(ctx context.Context, req *OAuthClientListRequest) (*OAuthClientListResponse, error) {
+	// List OAuth clients
+	clientModels, err := s.domain.OAuthClient.ListOAuthClient(ctx, int(req.Offset), int(req.Limit))
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to list OAuth clients")
+		return nil, getErrServerError()
+	}
+
+	clients := []*OAuthClientInfoResponse{}
+	for i := range clientModels {
+		clients = append(clients, oauthClientModelToOAuthClientInfo(&clientModels[i], ""))
+	}
+	return &OAuthClientListResponse{
+		Clients: clients,
+	}, nil
+}
+
+func (s *ServerGRPC) CreateOAuthClient(ctx context.Context, req *OAuthClientCreateRequest) (*OAuthClientInfoResponse, error) {
+	// Validate request
+	if err := req.validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong create OAuth client request")
+		return nil, getErrBadRequest()
+	}
+
+	// Create OAuth client
+	client, secret, err := s.domain.OAuthClient.CreateOAuthClient(ctx, oauthClientCreateToOAuthClientModel(req))
+	if err != nil {
+		if err == service.ErrOAuthClientInvalidGrantType {
+			log.Ctx(ctx).Error().Err(err).Msg("Grant type isn't allowed for the OAuth client")
+			return nil, getErrOAuthClientGrantTypeNotAllowed()
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to create OAuth client")
+		return nil, getErrServerError()
+	}
+
+	return oauthClientModelToOAuthClientInfo(client, secret), nil
+}
+
+func (s *ServerGRPC) GetOAuthClient(ctx context.Context, req *OAuthClientIDRequest) (*OAuthClientInfoResponse, error) {
+	// Validate request
+	if err := req.validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong get OAuth client request")
+		return nil, getErrBadRequest()
+	}
+
+	// Get OAuth client
+	client, err := s.domain.OAuthClient.GetOAuthClient(ctx, uuid.FromStringOrNil(req.Id))
+	if err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("OAuth client doesn't exist")
+			return nil, getErrNotFound(errors.ErrResouceOAuthClient)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get OAuth client")
+		return nil, getErrServerError()
+	}
+
+	return oauthClientModelToOAuthClientInfo(client, ""), nil
+}
+
+func (s *ServerGRPC) UpdateOAuthClient(ctx context.Context, req *OAuthClientUpdateRequest) (*empty.Empty, error) {
+	// Validate request
+	if err := req.validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong update OAuth client request")
+		return nil, getErrBadRequest()
+	}
+
+	// Update OAuth client
+	if err := s.domain.OAuthClient.UpdateOAuthClient(ctx, oauthClientUpdateToOAuthClientModel(req)); err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("OAuth client doesn't exist")
+			return nil, getErrNotFound(errors.ErrResouceOAuthClient)
+		} else if err == service.ErrOAuthClientInvalidGrantType {
+			log.Ctx(ctx).Error().Err(err).Msg("Grant type isn't allowed for the OAuth client")
+			return nil, getErrOAuthClientGrantTypeNotAllowed()
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to update OAuth client")
+		return nil, getErrServerError()
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (s *ServerGRPC) DeleteOAuthClient(ctx context.Context, req *OAuthClientIDRequest) (*empty.Empty, error) {
+	// Validate request
+	if err := req.validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong delete OAuth client request")
+		return nil, getErrBadRequest()
+	}
+
+	// Delete OAuth client
+	if err := s.domain.OAuthClient.DeleteOAuthClient(ctx, uuid.FromStringOrNil(req.Id)); err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("OAuth client doesn't exist")
+			return nil, getErrNotFound(errors.ErrResouceOAuthClient)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete OAuth client")
+		return nil, getErrServerError()
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// Request validate
+func (o *OAuthClientIDRequest) validate() error {
+	return request.ValidateOAuthClientUUID(o.Id)
+}
+
+func (o *OAuthClientCreateRequest) validate() error {
+	return request.ValidateOAuthClientCreate(o.Name, o.RedirectUris, o.GrantTypes, o.Scopes)
+}
+
+func (o *OAuthClientUpdateRequest) validate() error {
+	return request.ValidateOAuthClientUpdate(o.Id, o.Name, o.RedirectUris, o.GrantTypes, o.Scopes)
+}
+
+// DTO <-> Model
+func oauthClientCreateToOAuthClientModel(clientCreate *OAuthClientCreateRequest) *entity.OAuthClient {
+	return &entity.OAuthClient{
+		Name:         clientCreate.Name,
+		RedirectURIs: clientCreate.RedirectUris,
+		GrantTypes:   clientCreate.GrantTypes,
+		Scopes:       clientCreate.Scopes,
+		Public:       clientCreate.Public,
+	}
+}
+
+func oauthClientUpdateToOAuthClientModel(clientUpdate *OAuthClientUpdateRequest) *entity.OAuthClient {
+	return &entity.OAuthClient{
+		ID:           uuid.FromStringOrNil(clientUpdate.Id),
+		Name:         clientUpdate.Name,
+		RedirectURIs: clientUpdate.RedirectUris,
+		GrantTypes:   clientUpdate.GrantTypes,
+		Scopes:       clientUpdate.Scopes,
+	}
+}
+
+func oauthClientModelToOAuthClientInfo(clientModel *entity.OAuthClient, secret string) *OAuthClientInfoResponse {
+	return &OAuthClientInfoResponse{
+		Id:           clientModel.ID.String(),
+		Name:         clientModel.Name,
+		Secret:       secret,
+		RedirectUris: clientModel.RedirectURIs,
+		GrantTypes:   clientModel.GrantTypes,
+		Scopes:       clientModel.Scopes,
+		Public:       clientModel.Public,
+		CreatedAt:    timestamppb.New(clientModel.CreatedAt),
+	}
+}
@@ -0,0 +1,230 @@
+package grpc_server
+
+import (
+	"context"
+	"encoding/json"
+
+	empty "github.com/golang/protobuf/ptypes/empty"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/service"
+	"github.com/ssup2ket/service-auth/internal/server/errors"
+	"github.com/ssup2ket/service-auth/internal/server/request"
+	"github.com/ssup2ket/service-auth/pkg/auth/webauthn"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+// List passkeys of the user of the access token
+func (s *ServerGRPC) ListPasskeyUserMe(ctx context.Context, req *empty.Empty) (*PasskeyListResponse, error) {
+	// Get subject
+	subject, err := getUserMeSubject(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// List passkeys
+	passkeys, err := s.domain.Passkey.ListPasskey(ctx, subject)
+	if err != nil {
+		if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Subject doesn't have passkeys")
+			return nil, getErrUnauthorized()
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to list passkeys")
+		return nil, getErrServerError()
+	}
+
+	return passkeyModelListToPasskeyListResponse(passkeys), nil
+}
+
+// Begin a passkey registration. The options are passed to navigator.credentials.create().
+func (s *ServerGRPC) BeginPasskeyUserMe(ctx context.Context, req *empty.Empty) (*PasskeyOptionsResponse, error) {
+	// Get subject
+	subject, err := getUserMeSubject(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Begin registration
+	registration, err := s.domain.Passkey.BeginPasskeyRegistration(ctx, subject)
+	if err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("User doesn't exist")
+			return nil, getErrNotFound(errors.ErrResouceUser)
+		} else if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Subject doesn't have passkeys")
+			return nil, getErrUnauthorized()
+		} else if err == service.ErrPasskeyDisabled {
+			log.Ctx(ctx).Error().Err(err).Msg("Passkey is disabled")
+			return nil, getErrPasskeyDisabled()
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to begin passkey registration")
+		return nil, getErrServerError()
+	}
+
+	resp, err := getPasskeyOptionsResponse(registration.ChallengeID, registration.Options)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to encode passkey registration options")
+		return nil, getErrServerError()
+	}
+	return resp, nil
+}
+
+// Finish a passkey registration with the credential of navigator.credentials.create()
+func (s *ServerGRPC) FinishPasskeyUserMe(ctx context.Context, req *PasskeyRegistrationFinishRequest) (*PasskeyInfoResponse, error) {
+	// Validate request
+	if err := req.validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong finish passkey registration request")
+		return nil, getErrBadRequest()
+	}
+	attestation, err := passkeyRegistrationFinishRequestToPasskeyAttestationModel(req)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong passkey attestation")
+		return nil, getErrBadRequest()
+	}
+
+	// Get subject
+	subject, err := getUserMeSubject(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Finish registration
+	passkey, err := s.domain.Passkey.FinishPasskeyRegistration(ctx, subject, uuid.FromStringOrNil(req.ChallengeId), req.Name, attestation)
+	if err != nil {
+		if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Subject doesn't have passkeys")
+			return nil, getErrUnauthorized()
+		} else if err == service.ErrPasskeyDisabled {
+			log.Ctx(ctx).Error().Err(err).Msg("Passkey is disabled")
+			return nil, getErrPasskeyDisabled()
+		} else if err == service.ErrPasskeyVerificationFailed {
+			log.Ctx(ctx).Error().Err(err).Msg("Passkey verification failed")
+			return nil, getErrPasskeyVerificationFailed()
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to finish passkey registration")
+		return nil, getErrServerError()
+	}
+
+	return passkeyModelToPasskeyInfoResponse(passkey), nil
+}
+
+// Delete a passkey of the user of the access token
+func (s *ServerGRPC) DeletePasskeyUserMe(ctx context.Context, req *PasskeyIDRequest) (*empty.Empty, error) {
+	// Validate request
+	if err := req.validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong passkey ID")
+		return nil, getErrBadRequest()
+	}
+
+	// Get subject
+	subject, err := getUserMeSubject(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Delete passkey
+	if err := s.domain.Passkey.DeletePasskey(ctx, subject, uuid.FromStringOrNil(req.Id)); err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("Passkey doesn't exist")
+			return nil, getErrNotFound(errors.ErrResoucePasskey)
+		} else if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Subject doesn't have passkeys")
+			return nil, getErrUnauthorized()
+		} else if err == service.ErrPasskeyRequired {
+			log.Ctx(ctx).Error().Err(err).Msg("Last passkey of user without password")
+			return nil, getErrPasskeyRequired()
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete passkey")
+		return nil, getErrServerError()
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// Request validate
+func (p *PasskeyIDRequest) validate() error {
+	return request.ValidatePasskeyUUID(p.Id)
+}
+
+func (p *PasskeyRegistrationFinishRequest) validate() error {
+	// gRPC requests have only public key credentials
+	return request.ValidatePasskeyRegistrationFinish(p.ChallengeId, p.Name, webauthn.CredentialType, p.CredentialId,
+		p.ClientDataJSON, p.AttestationObject, p.Transports)
+}
+
+// DTO <-> Model
+func getPasskeyOptionsResponse(challengeID uuid.EntityUUID, options interface{}) (*PasskeyOptionsResponse, error) {
+	// Options are encoded as the JSON of WebAuthn
+	publicKey, err := json.Marshal(options)
+	if err != nil {
+		return nil, err
+	}
+	return &PasskeyOptionsResponse{
+		ChallengeId: challengeID.String(),
+		PublicKey:   string(publicKey),
+	}, nil
+}
+
+func passkeyRegistrationFinishRequestToPasskeyAttestationModel(req *PasskeyRegistrationFinishRequest) (*entity.PasskeyAttestation, error) {
+	var err error
+	attestation := entity.PasskeyAttestation{Transports: []string{}}
+	if attestation.CredentialID, err = webauthn.DecodeBase64URL(req.CredentialId); err != nil {
+		return nil, err
+	}
+	if attestation.ClientDataJSON, err = webauthn.DecodeBase64URL(req.ClientDataJSON); err != nil {
+		return nil, err
+	}
+	if attestation.AttestationObject, err = webauthn.DecodeBase64URL(req.AttestationObject); err != nil {
+		return nil, err
+	}
+	if req.Transports != nil {
+		attestation.Transports = req.Transports
+	}
+	return &attestation, nil
+}
+
+func tokenPasskeyFinishRequestToPasskeyAssertionModel(req *TokenPasskeyFinishRequest) (*entity.PasskeyAssertion, error) {
+	var err error
+	assertion := entity.PasskeyAssertion{}
+	if assertion.CredentialID, err = webauthn.DecodeBase64URL(req.CredentialId); err != nil {
+		return nil, err
+	}
+	if assertion.ClientDataJSON, err = webauthn.DecodeBase64URL(req.ClientDataJSON); err != nil {
+		return nil, err
+	}
+	if assertion.AuthenticatorData, err = webauthn.DecodeBase64URL(req.AuthenticatorData); err != nil {
+		return nil, err
+	}
+	if assertion.Signature, err = webauthn.DecodeBase64URL(req.Signature); err != nil {
+		return nil, err
+	}
+	if req.UserHandle != "" {
+		if assertion.UserHandle, err = webauthn.DecodeBase64URL(req.UserHandle); err != nil {
+			return nil, err
+		}
+	}
+	return &assertion, nil
+}
+
+func passkeyModelToPasskeyInfoResponse(passkeyModel *entity.WebAuthnCredential) *PasskeyInfoResponse {
+	resp := &PasskeyInfoResponse{
+		Id:         passkeyModel.ID.String(),
+		Name:       passkeyModel.Name,
+		Transports: []string(passkeyModel.Transports),
+		CreatedAt:  timestamppb.New(passkeyModel.CreatedAt),
+	}
+	if passkeyModel.LastUsedAt != nil {
+		resp.LastUsedAt = timestamppb.New(*passkeyModel.LastUsedAt)
+	}
+	return resp
+}
+
+func passkeyModelListToPasskeyListResponse(passkeyModelList []entity.WebAuthnCredential) *PasskeyListResponse {
+	passkeyInfoList := []*PasskeyInfoResponse{}
+	for i := range passkeyModelList {
+		passkeyInfoList = append(passkeyInfoList, passkeyModelToPasskeyInfoResponse(&passkeyModelList[i]))
+	}
+	return &PasskeyListResponse{Passkeys: passkeyInfoList}
+}
@@ -0,0 +1,164 @@
+package grpc_server
+
+import (
+	"context"
+
+	empty "github.com/golang/protobuf/ptypes/empty"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/service"
+	"github.com/ssup2ket/service-auth/internal/server/errors"
+	"github.com/ssup2ket/service-auth/internal/server/request"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+func (s *ServerGRPC) ListPermission(ctx context.Context, req *PermissionListRequest) (*PermissionListResponse, error) {
+	// List permissions
+	permissionModels, err := s.domain.Permission.ListPermission(ctx, int(req.Offset), int(req.Limit))
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to list permissions")
+		return nil, getErrServerError()
+	}
+
+	permissions := []*PermissionInfoResponse{}
+	for i := range permissionModels {
+		permissions = append(permissions, permissionModelToPermissionInfo(&permissionModels[i]))
+	}
+	return &PermissionListResponse{
+		Permissions: permissions,
+	}, nil
+}
+
+func (s *ServerGRPC) CreatePermission(ctx context.Context, req *PermissionCreateRequest) (*PermissionInfoResponse, error) {
+	// Validate request
+	if err := req.validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong create permission request")
+		return nil, getErrBadRequest()
+	}
+
+	// Create permission
+	permission, err := s.domain.Permission.CreatePermission(ctx, permissionCreateToPermissionModel(req))
+	if err != nil {
+		if err == service.ErrRoleNotExist {
+			log.Ctx(ctx).Error().Err(err).Msg("Role of subject doesn't exist")
+			return nil, getErrRoleNotExist()
+		} else if err == service.ErrRepoConflict {
+			log.Ctx(ctx).Error().Err(err).Msg("Permission already exists")
+			return nil, getErrConflict(errors.ErrResoucePermission)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to create permission")
+		return nil, getErrServerError()
+	}
+
+	return permissionModelToPermissionInfo(permission), nil
+}
+
+func (s *ServerGRPC) GetPermission(ctx context.Context, req *PermissionIDRequest) (*PermissionInfoResponse, error) {
+	// Validate request
+	if err := req.validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong get permission request")
+		return nil, getErrBadRequest()
+	}
+
+	// Get permission
+	permission, err := s.domain.Permission.GetPermission(ctx, uuid.FromStringOrNil(req.Id))
+	if err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("Permission doesn't exist")
+			return nil, getErrNotFound(errors.ErrResoucePermission)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get permission")
+		return nil, getErrServerError()
+	}
+
+	return permissionModelToPermissionInfo(permission), nil
+}
+
+func (s *ServerGRPC) UpdatePermission(ctx context.Context, req *PermissionUpdateRequest) (*empty.Empty, error) {
+	// Validate request
+	if err := req.validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong update permission request")
+		return nil, getErrBadRequest()
+	}
+
+	// Update permission
+	if err := s.domain.Permission.UpdatePermission(ctx, permissionUpdateToPermissionModel(req)); err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("Permission doesn't exist")
+			return nil, getErrNotFound(errors.ErrResoucePermission)
+		} else if err == service.ErrRoleNotExist {
+			log.Ctx(ctx).Error().Err(err).Msg("Role of subject doesn't exist")
+			return nil, getErrRoleNotExist()
+		} else if err == service.ErrRepoConflict {
+			log.Ctx(ctx).Error().Err(err).Msg("Permission already exists")
+			return nil, getErrConflict(errors.ErrResoucePermission)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to update permission")
+		return nil, getErrServerError()
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (s *ServerGRPC) DeletePermission(ctx context.Context, req *PermissionIDRequest) (*empty.Empty, error) {
+	// Validate request
+	if err := req.validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong delete permission request")
+		return nil, getErrBadRequest()
+	}
+
+	// Delete permission
+	if err := s.domain.Permission.DeletePermission(ctx, uuid.FromStringOrNil(req.Id)); err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("Permission doesn't exist")
+			return nil, getErrNotFound(errors.ErrResoucePermission)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete permission")
+		return nil, getErrServerError()
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// Request validate
+func (p *PermissionIDRequest) validate() error {
+	return request.ValidatePermissionUUID(p.Id)
+}
+
+func (p *PermissionCreateRequest) validate() error {
+	return request.ValidatePermissionCreate(p.Subject, p.Object, p.Action)
+}
+
+func (p *PermissionUpdateRequest) validate() error {
+	return request.ValidatePermissionUpdate(p.Id, p.Subject, p.Object, p.Action)
+}
+
+// DTO <-> Model
+func permissionCreateToPermissionModel(permissionCreate *PermissionCreateRequest) *entity.Permission {
+	return &entity.Permission{
+		Subject: permissionCreate.Subject,
+		Object:  permissionCreate.Object,
+		Action:  permissionCreate.Action,
+	}
+}
+
+func permissionUpdateToPermissionModel(permissionUpdate *PermissionUpdateRequest) *entity.Permission {
+	return &entity.Permission{
+		ID:      uuid.FromStringOrNil(permissionUpdate.Id),
+		Subject: permissionUpdate.Subject,
+		Object:  permissionUpdate.Object,
+		Action:  permissionUpdate.Action,
+	}
+}
+
+func permissionModelToPermissionInfo(permissionModel *entity.Permission) *PermissionInfoResponse {
+	return &PermissionInfoResponse{
+		Id:        permissionModel.ID.String(),
+		Subject:   permissionModel.Subject,
+		Object:    permissionModel.Object,
+		Action:    permissionModel.Action,
+		CreatedAt: timestamppb.New(permissionModel.CreatedAt),
+	}
+}
@@ -0,0 +1,164 @@
+package grpc_server
+
+import (
+	"context"
+
+	empty "github.com/golang/protobuf/ptypes/empty"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/service"
+	"github.com/ssup2ket/service-auth/internal/server/errors"
+	"github.com/ssup2ket/service-auth/internal/server/request"
+)
+
+func (s *ServerGRPC) ListRole(ctx context.Context, req *RoleListRequest) (*RoleListResponse, error) {
+	// List roles
+	roleModels, err := s.domain.Role.ListRole(ctx, int(req.Offset), int(req.Limit))
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to list roles")
+		return nil, getErrServerError()
+	}
+
+	roles := []*RoleInfoResponse{}
+	for i := range roleModels {
+		roles = append(roles, roleModelToRoleInfo(&roleModels[i]))
+	}
+	return &RoleListResponse{
+		Roles: roles,
+	}, nil
+}
+
+func (s *ServerGRPC) CreateRole(ctx context.Context, req *RoleCreateRequest) (*RoleInfoResponse, error) {
+	// Validate request
+	if err := req.validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong create role request")
+		return nil, getErrBadRequest()
+	}
+
+	// Create role
+	role, err := s.domain.Role.CreateRole(ctx, roleCreateToRoleModel(req))
+	if err != nil {
+		if err == service.ErrRepoConflict {
+			log.Ctx(ctx).Error().Err(err).Msg("Role already exists")
+			return nil, getErrConflict(errors.ErrResouceRole)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to create role")
+		return nil, getErrServerError()
+	}
+
+	return roleModelToRoleInfo(role), nil
+}
+
+func (s *ServerGRPC) GetRole(ctx context.Context, req *RoleNameRequest) (*RoleInfoResponse, error) {
+	// Validate request
+	if err := req.validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong get role request")
+		return nil, getErrBadRequest()
+	}
+
+	// Get role
+	role, err := s.domain.Role.GetRole(ctx, req.Name)
+	if err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("Role doesn't exist")
+			return nil, getErrNotFound(errors.ErrResouceRole)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get role")
+		return nil, getErrServerError()
+	}
+
+	return roleModelToRoleInfo(role), nil
+}
+
+func (s *ServerGRPC) UpdateRole(ctx context.Context, req *RoleUpdateRequest) (*empty.Empty, error) {
+	// Validate request
+	if err := req.validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong update role request")
+		return nil, getErrBadRequest()
+	}
+
+	// Update role
+	if err := s.domain.Role.UpdateRole(ctx, roleUpdateToRoleModel(req)); err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("Role doesn't exist")
+			return nil, getErrNotFound(errors.ErrResouceRole)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to update role")
+		return nil, getErrServerError()
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (s *ServerGRPC) DeleteRole(ctx context.Context, req *RoleNameRequest) (*empty.Empty, error) {
+	// Validate request
+	if err := req.validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong delete role request")
+		return nil, getErrBadRequest()
+	}
+
+	// Delete role
+	if err := s.domain.Role.DeleteRole(ctx, req.Name); err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("Role doesn't exist")
+			return nil, getErrNotFound(errors.ErrResouceRole)
+		} else if err == service.ErrRoleInUse {
+			log.Ctx(ctx).Error().Err(err).Msg("Role is in use")
+			return nil, getErrRoleInUse()
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete role")
+		return nil, getErrServerError()
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// Request validate
+func (r *RoleNameRequest) validate() error {
+	return request.ValidateRoleName(r.Name)
+}
+
+func (r *RoleCreateRequest) validate() error {
+	return request.ValidateRoleCreate(r.Name, r.Description, r.Scopes, int(r.PasswordMaxAgeDays))
+}
+
+func (r *RoleUpdateRequest) validate() error {
+	if err := request.ValidateRoleName(r.Name); err != nil {
+		return err
+	}
+	return request.ValidateRoleUpdate(r.Name, r.Description, r.Scopes, int(r.PasswordMaxAgeDays))
+}
+
+// DTO <-> Model
+func roleCreateToRoleModel(roleCreate *RoleCreateRequest) *entity.Role {
+	return &entity.Role{
+		Name:        roleCreate.Name,
+		Description: roleCreate.Description,
+		Scopes:      roleCreate.Scopes,
+
+		PasswdMaxAgeDays: int(roleCreate.PasswordMaxAgeDays),
+	}
+}
+
+func roleUpdateToRoleModel(roleUpdate *RoleUpdateRequest) *entity.Role {
+	return &entity.Role{
+		Name:        roleUpdate.Name,
+		Description: roleUpdate.Description,
+		Scopes:      roleUpdate.Scopes,
+
+		PasswdMaxAgeDays: int(roleUpdate.PasswordMaxAgeDays),
+	}
+}
+
+func roleModelToRoleInfo(roleModel *entity.Role) *RoleInfoResponse {
+	return &RoleInfoResponse{
+		Name:        roleModel.Name,
+		Description: roleModel.Description,
+		Scopes:      roleModel.Scopes,
+		CreatedAt:   timestamppb.New(roleModel.CreatedAt),
+
+		PasswordMaxAgeDays: int32(roleModel.PasswdMaxAgeDays),
+	}
+}
@@ -0,0 +1,53 @@
+package grpc_server
+
+import (
+	"context"
+
+	empty "github.com/golang/protobuf/ptypes/empty"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/server/middleware"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+func (s *ServerGRPC) ListSessionUserMe(ctx context.Context, req *empty.Empty) (*SessionListResponse, error) {
+	// Get user ID, session ID
+	userID, err := middleware.GetUserIDFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No user ID in context")
+		return nil, getErrServerError()
+	}
+	sessionID, _ := middleware.GetSessionIDFromCtx(ctx)
+
+	// List sessions
+	sessions, err := s.domain.Session.ListSession(ctx, uuid.FromStringOrNil(userID))
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to list sessions")
+		return nil, getErrServerError()
+	}
+
+	return &SessionListResponse{
+		Sessions: SessionModelListToSessionInfoList(sessions, sessionID),
+	}, nil
+}
+
+// DTO <-> Model
+func SessionModelListToSessionInfoList(sessionModelList []entity.Session, currentSessionID string) []*SessionInfoResponse {
+	sessionInfos := []*SessionInfoResponse{}
+	for _, sessionModel := range sessionModelList {
+		tmp := SessionInfoResponse{
+			Id:         sessionModel.ID.String(),
+			DeviceName: sessionModel.DeviceName,
+			UserAgent:  sessionModel.UserAgent,
+			Ip:         sessionModel.IP,
+			CreatedAt:  timestamppb.New(sessionModel.CreatedAt),
+			LastUsedAt: timestamppb.New(sessionModel.LastUsedAt),
+			ExpiresAt:  timestamppb.New(sessionModel.ExpiresAt),
+			Current:    sessionModel.ID.String() == currentSessionID,
+		}
+		sessionInfos = append(sessionInfos, &tmp)
+	}
+	return sessionInfos
+}
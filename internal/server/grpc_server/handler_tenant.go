@@ -0,0 +1,152 @@
+package grpc_server
+
+import (
+	"context"
+
+	empty "github.com/golang/protobuf/ptypes/empty"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/service"
+	"github.com/ssup2ket/service-auth/internal/server/errors"
+	"github.com/ssup2ket/service-auth/internal/server/request"
+)
+
+func (s *ServerGRPC) ListTenant(ctx context.Context, req *TenantListRequest) (*TenantListResponse, error) {
+	// List tenants
+	tenantModels, err := s.domain.Tenant.ListTenant(ctx, int(req.Offset), int(req.Limit))
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to list tenants")
+		return nil, getErrServerError()
+	}
+
+	tenants := []*TenantInfoResponse{}
+	for i := range tenantModels {
+		tenants = append(tenants, tenantModelToTenantInfo(&tenantModels[i]))
+	}
+	return &TenantListResponse{
+		Tenants: tenants,
+	}, nil
+}
+
+func (s *ServerGRPC) CreateTenant(ctx context.Context, req *TenantCreateRequest) (*TenantInfoResponse, error) {
+	// Validate request
+	if err := req.validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong create tenant request")
+		return nil, getErrBadRequest()
+	}
+
+	// Create tenant
+	tenant, err := s.domain.Tenant.CreateTenant(ctx, tenantCreateToTenantModel(req))
+	if err != nil {
+		if err == service.ErrRepoConflict {
+			log.Ctx(ctx).Error().Err(err).Msg("Tenant already exists")
+			return nil, getErrConflict(errors.ErrResouceTenant)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to create tenant")
+		return nil, getErrServerError()
+	}
+
+	return tenantModelToTenantInfo(tenant), nil
+}
+
+func (s *ServerGRPC) GetTenant(ctx context.Context, req *TenantIDRequest) (*TenantInfoResponse, error) {
+	// Validate request
+	if err := req.validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong get tenant request")
+		return nil, getErrBadRequest()
+	}
+
+	// Get tenant
+	tenant, err := s.domain.Tenant.GetTenant(ctx, req.Id)
+	if err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("Tenant doesn't exist")
+			return nil, getErrNotFound(errors.ErrResouceTenant)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get tenant")
+		return nil, getErrServerError()
+	}
+
+	return tenantModelToTenantInfo(tenant), nil
+}
+
+func (s *ServerGRPC) UpdateTenant(ctx context.Context, req *TenantUpdateRequest) (*empty.Empty, error) {
+	// Validate request
+	if err := req.validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong update tenant request")
+		return nil, getErrBadRequest()
+	}
+
+	// Update tenant
+	if err := s.domain.Tenant.UpdateTenant(ctx, tenantUpdateToTenantModel(req)); err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("Tenant doesn't exist")
+			return nil, getErrNotFound(errors.ErrResouceTenant)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to update tenant")
+		return nil, getErrServerError()
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (s *ServerGRPC) DeleteTenant(ctx context.Context, req *TenantIDRequest) (*empty.Empty, error) {
+	// Validate request
+	if err := req.validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong delete tenant request")
+		return nil, getErrBadRequest()
+	}
+
+	// Delete tenant
+	if err := s.domain.Tenant.DeleteTenant(ctx, req.Id); err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("Tenant doesn't exist")
+			return nil, getErrNotFound(errors.ErrResouceTenant)
+		} else if err == service.ErrTenantInUse {
+			log.Ctx(ctx).Error().Err(err).Msg("Tenant is in use")
+			return nil, getErrTenantInUse()
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete tenant")
+		return nil, getErrServerError()
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// Request validate
+func (r *TenantIDRequest) validate() error {
+	return request.ValidateTenantID(r.Id)
+}
+
+func (r *TenantCreateRequest) validate() error {
+	return request.ValidateTenantCreate(r.Id, r.Description)
+}
+
+func (r *TenantUpdateRequest) validate() error {
+	return request.ValidateTenantUpdate(r.Id, r.Description)
+}
+
+// DTO <-> Model
+func tenantCreateToTenantModel(tenantCreate *TenantCreateRequest) *entity.Tenant {
+	return &entity.Tenant{
+		ID:          tenantCreate.Id,
+		Description: tenantCreate.Description,
+	}
+}
+
+func tenantUpdateToTenantModel(tenantUpdate *TenantUpdateRequest) *entity.Tenant {
+	return &entity.Tenant{
+		ID:          tenantUpdate.Id,
+		Description: tenantUpdate.Description,
+	}
+}
+
+func tenantModelToTenantInfo(tenantModel *entity.Tenant) *TenantInfoResponse {
+	return &TenantInfoResponse{
+		Id:          tenantModel.ID,
+		Description: tenantModel.Description,
+		CreatedAt:   timestamppb.New(tenantModel.CreatedAt),
+	}
+}
@@ -2,13 +2,23 @@ package grpc_server
 
 import (
 	"context"
+	"strings"
+	"time"
 
+	empty "github.com/golang/protobuf/ptypes/empty"
 	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
 	"github.com/ssup2ket/service-auth/internal/domain/service"
 	"github.com/ssup2ket/service-auth/internal/server/errors"
+	"github.com/ssup2ket/service-auth/internal/server/middleware"
+	"github.com/ssup2ket/service-auth/internal/server/request"
+	authtoken "github.com/ssup2ket/service-auth/pkg/auth/token"
+	"github.com/ssup2ket/service-auth/pkg/auth/webauthn"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
 )
 
 func (s *ServerGRPC) LoginToken(ctx context.Context, req *TokenLoginRequest) (*TokenInfosResponse, error) {
@@ -31,9 +41,34 @@ func (s *ServerGRPC) LoginToken(ctx context.Context, req *TokenLoginRequest) (*T
 	}
 	password := passwords[0]
 
+	// Get session info
+	session := entity.Session{
+		DeviceName: req.DeviceName,
+		IP:         getClientIP(ctx),
+		Scopes:     strings.Fields(req.Scope),
+	}
+	if userAgents, ok := md["user-agent"]; ok {
+		session.UserAgent = userAgents[0]
+	}
+
+	// Get tenant ID
+	tenantID, err := middleware.GetTenantIDFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No tenant ID in context")
+		return nil, getErrServerError()
+	}
+
 	// Create token
-	accTokenInfo, refTokenInfo, err := s.domain.Token.CreateTokens(ctx, loginID, password)
+	accTokenInfo, refTokenInfo, err := s.domain.Token.CreateTokens(ctx, tenantID, loginID, password, &session, req.Audience)
 	if err != nil {
+		if err == service.ErrTokenAudienceNotAllowed {
+			log.Ctx(ctx).Error().Err(err).Msg("Wrong audience")
+			return nil, getErrTokenAudienceNotAllowed()
+		}
+		if err == service.ErrTokenScopeNotAllowed {
+			log.Ctx(ctx).Error().Err(err).Msg("Wrong scope")
+			return nil, getErrTokenScopeNotAllowed()
+		}
 		if err == service.ErrRepoNotFound {
 			log.Ctx(ctx).Error().Err(err).Msg("ID doesn't exists")
 			return nil, getErrNotFound(errors.ErrResouceUser)
@@ -42,6 +77,37 @@ func (s *ServerGRPC) LoginToken(ctx context.Context, req *TokenLoginRequest) (*T
 			log.Ctx(ctx).Error().Err(err).Msg("Wrong ID/password")
 			return nil, getErrUnauthorized()
 		}
+		if err == service.ErrLoginLocked {
+			log.Ctx(ctx).Error().Err(err).Msg("Login is locked")
+			return nil, getErrLoginLocked()
+		}
+		if err == service.ErrPasswdExpired {
+			// Send the access token to change the password by the response header
+			log.Ctx(ctx).Error().Err(err).Msg("Password is expired")
+			if err := grpc.SetHeader(ctx, metadata.Pairs(middleware.HeaderPasswdChangeToken, accTokenInfo.Token)); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Failed to set password change token to header")
+				return nil, getErrServerError()
+			}
+			return nil, getErrPasswdExpired()
+		}
+		if err == service.ErrEmailNotVerified {
+			// Send the access token to verify the email by the response header
+			log.Ctx(ctx).Error().Err(err).Msg("Email isn't verified")
+			if err := grpc.SetHeader(ctx, metadata.Pairs(middleware.HeaderEmailVerifyToken, accTokenInfo.Token)); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Failed to set email verify token to header")
+				return nil, getErrServerError()
+			}
+			return nil, getErrEmailNotVerified()
+		}
+		if err == service.ErrMFARequired {
+			// Send the MFA challenge token by the response header
+			log.Ctx(ctx).Info().Msg("MFA is required")
+			if err := grpc.SetHeader(ctx, metadata.Pairs(middleware.HeaderMFAToken, accTokenInfo.Token)); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Failed to set MFA token to header")
+				return nil, getErrServerError()
+			}
+			return nil, getErrMFARequired()
+		}
 		log.Ctx(ctx).Error().Err(err).Msg("Failed to create access, refresh tokens")
 		return nil, getErrServerError()
 	}
@@ -60,24 +126,386 @@ func (s *ServerGRPC) LoginToken(ctx context.Context, req *TokenLoginRequest) (*T
 	}, nil
 }
 
-func (s *ServerGRPC) RefreshToken(ctx context.Context, req *TokenRefreshRequest) (*TokenInfoResponse, error) {
+// Exchange a MFA challenge token of the login and a MFA code for tokens
+func (s *ServerGRPC) MFAToken(ctx context.Context, req *TokenMFARequest) (*TokenInfosResponse, error) {
+	// Validate request
+	if err := req.validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong MFA token request")
+		return nil, getErrBadRequest()
+	}
+
+	// Get session info
+	session := entity.Session{
+		DeviceName: req.DeviceName,
+		IP:         getClientIP(ctx),
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if userAgents, ok := md["user-agent"]; ok {
+			session.UserAgent = userAgents[0]
+		}
+	}
+
+	// Create token
+	accTokenInfo, refTokenInfo, err := s.domain.Token.CreateMFATokens(ctx, req.MfaToken, req.Code, &session)
+	if err != nil {
+		if err == service.ErrTokenScopeNotAllowed {
+			log.Ctx(ctx).Error().Err(err).Msg("Wrong scope")
+			return nil, getErrTokenScopeNotAllowed()
+		}
+		if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Wrong MFA token")
+			return nil, getErrUnauthorized()
+		}
+		if err == service.ErrMFACodeWrong {
+			log.Ctx(ctx).Error().Err(err).Msg("Wrong MFA code")
+			return nil, getErrMFACodeWrong()
+		}
+		if err == service.ErrLoginLocked {
+			log.Ctx(ctx).Error().Err(err).Msg("Login is locked")
+			return nil, getErrLoginLocked()
+		}
+		if err == service.ErrPasswdExpired {
+			// Send the access token to change the password by the response header
+			log.Ctx(ctx).Error().Err(err).Msg("Password is expired")
+			if err := grpc.SetHeader(ctx, metadata.Pairs(middleware.HeaderPasswdChangeToken, accTokenInfo.Token)); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Failed to set password change token to header")
+				return nil, getErrServerError()
+			}
+			return nil, getErrPasswdExpired()
+		}
+		if err == service.ErrEmailNotVerified {
+			// Send the access token to verify the email by the response header
+			log.Ctx(ctx).Error().Err(err).Msg("Email isn't verified")
+			if err := grpc.SetHeader(ctx, metadata.Pairs(middleware.HeaderEmailVerifyToken, accTokenInfo.Token)); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Failed to set email verify token to header")
+				return nil, getErrServerError()
+			}
+			return nil, getErrEmailNotVerified()
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to create access, refresh tokens with MFA")
+		return nil, getErrServerError()
+	}
+
+	return &TokenInfosResponse{
+		AccessToken: &TokenInfoResponse{
+			Token:     accTokenInfo.Token,
+			IssuedAt:  timestamppb.New(accTokenInfo.IssuedAt),
+			ExpiresAt: timestamppb.New(accTokenInfo.ExpiresAt),
+		},
+		RefreshToken: &TokenInfoResponse{
+			Token:     refTokenInfo.Token,
+			IssuedAt:  timestamppb.New(refTokenInfo.IssuedAt),
+			ExpiresAt: timestamppb.New(refTokenInfo.ExpiresAt),
+		},
+	}, nil
+}
+
+// Begin a passkey login. The options are passed to navigator.credentials.get().
+func (s *ServerGRPC) BeginPasskeyToken(ctx context.Context, req *empty.Empty) (*PasskeyOptionsResponse, error) {
+	// Get tenant ID
+	tenantID, err := middleware.GetTenantIDFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No tenant ID in context")
+		return nil, getErrServerError()
+	}
+
+	// Begin login
+	login, err := s.domain.Token.BeginPasskeyLogin(ctx, tenantID)
+	if err != nil {
+		if err == service.ErrPasskeyDisabled {
+			log.Ctx(ctx).Error().Err(err).Msg("Passkey is disabled")
+			return nil, getErrPasskeyDisabled()
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to begin passkey login")
+		return nil, getErrServerError()
+	}
+
+	resp, err := getPasskeyOptionsResponse(login.ChallengeID, login.Options)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to encode passkey login options")
+		return nil, getErrServerError()
+	}
+	return resp, nil
+}
+
+// Finish a passkey login with the credential of navigator.credentials.get() and create tokens like login
+func (s *ServerGRPC) FinishPasskeyToken(ctx context.Context, req *TokenPasskeyFinishRequest) (*TokenInfosResponse, error) {
+	// Validate request
+	if err := req.validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong finish passkey login request")
+		return nil, getErrBadRequest()
+	}
+	assertion, err := tokenPasskeyFinishRequestToPasskeyAssertionModel(req)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong passkey assertion")
+		return nil, getErrBadRequest()
+	}
+
+	// Get session info
+	session := entity.Session{
+		DeviceName: req.DeviceName,
+		IP:         getClientIP(ctx),
+		Scopes:     strings.Fields(req.Scope),
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if userAgents, ok := md["user-agent"]; ok {
+			session.UserAgent = userAgents[0]
+		}
+	}
+
+	// Get tenant ID
+	tenantID, err := middleware.GetTenantIDFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No tenant ID in context")
+		return nil, getErrServerError()
+	}
+
+	// Create token
+	accTokenInfo, refTokenInfo, err := s.domain.Token.CreatePasskeyTokens(ctx, tenantID, uuid.FromStringOrNil(req.ChallengeId),
+		assertion, &session, req.Audience)
+	if err != nil {
+		if err == service.ErrTokenAudienceNotAllowed {
+			log.Ctx(ctx).Error().Err(err).Msg("Wrong audience")
+			return nil, getErrTokenAudienceNotAllowed()
+		}
+		if err == service.ErrTokenScopeNotAllowed {
+			log.Ctx(ctx).Error().Err(err).Msg("Wrong scope")
+			return nil, getErrTokenScopeNotAllowed()
+		}
+		if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Wrong passkey or challenge")
+			return nil, getErrUnauthorized()
+		}
+		if err == service.ErrLoginLocked {
+			log.Ctx(ctx).Error().Err(err).Msg("Login is locked")
+			return nil, getErrLoginLocked()
+		}
+		if err == service.ErrPasskeyDisabled {
+			log.Ctx(ctx).Error().Err(err).Msg("Passkey is disabled")
+			return nil, getErrPasskeyDisabled()
+		}
+		if err == service.ErrEmailNotVerified {
+			// Send the access token to verify the email by the response header
+			log.Ctx(ctx).Error().Err(err).Msg("Email isn't verified")
+			if err := grpc.SetHeader(ctx, metadata.Pairs(middleware.HeaderEmailVerifyToken, accTokenInfo.Token)); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Failed to set email verify token to header")
+				return nil, getErrServerError()
+			}
+			return nil, getErrEmailNotVerified()
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to create access, refresh tokens with passkey")
+		return nil, getErrServerError()
+	}
+
+	return &TokenInfosResponse{
+		AccessToken: &TokenInfoResponse{
+			Token:     accTokenInfo.Token,
+			IssuedAt:  timestamppb.New(accTokenInfo.IssuedAt),
+			ExpiresAt: timestamppb.New(accTokenInfo.ExpiresAt),
+		},
+		RefreshToken: &TokenInfoResponse{
+			Token:     refTokenInfo.Token,
+			IssuedAt:  timestamppb.New(refTokenInfo.IssuedAt),
+			ExpiresAt: timestamppb.New(refTokenInfo.ExpiresAt),
+		},
+	}, nil
+}
+
+func (s *ServerGRPC) RefreshToken(ctx context.Context, req *TokenRefreshRequest) (*TokenInfosResponse, error) {
 	// Get refresh token
 	refreshToken := req.RefreshToken
 
 	// Refresh token
-	refTokenInfo, err := s.domain.Token.RefreshToken(ctx, refreshToken)
+	accTokenInfo, refTokenInfo, err := s.domain.Token.RefreshToken(ctx, refreshToken)
 	if err != nil {
 		if err == service.ErrUnauthorized {
 			log.Ctx(ctx).Error().Err(err).Msg("Wrong refresh token")
 			return nil, getErrUnauthorized()
 		}
+		if err == service.ErrPasswdExpired {
+			// Send the access token to change the password by the response header
+			log.Ctx(ctx).Error().Err(err).Msg("Password is expired")
+			if err := grpc.SetHeader(ctx, metadata.Pairs(middleware.HeaderPasswdChangeToken, accTokenInfo.Token)); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Failed to set password change token to header")
+				return nil, getErrServerError()
+			}
+			return nil, getErrPasswdExpired()
+		}
+		if err == service.ErrEmailNotVerified {
+			// Send the access token to verify the email by the response header
+			log.Ctx(ctx).Error().Err(err).Msg("Email isn't verified")
+			if err := grpc.SetHeader(ctx, metadata.Pairs(middleware.HeaderEmailVerifyToken, accTokenInfo.Token)); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Failed to set email verify token to header")
+				return nil, getErrServerError()
+			}
+			return nil, getErrEmailNotVerified()
+		}
 		log.Ctx(ctx).Error().Err(err).Msg("Failed to refresh token")
 		return nil, getErrServerError()
 	}
 
-	return &TokenInfoResponse{
-		Token:     refTokenInfo.Token,
-		IssuedAt:  timestamppb.New(refTokenInfo.IssuedAt),
-		ExpiresAt: timestamppb.New(refTokenInfo.ExpiresAt),
+	return &TokenInfosResponse{
+		AccessToken: &TokenInfoResponse{
+			Token:     accTokenInfo.Token,
+			IssuedAt:  timestamppb.New(accTokenInfo.IssuedAt),
+			ExpiresAt: timestamppb.New(accTokenInfo.ExpiresAt),
+		},
+		RefreshToken: &TokenInfoResponse{
+			Token:     refTokenInfo.Token,
+			IssuedAt:  timestamppb.New(refTokenInfo.IssuedAt),
+			ExpiresAt: timestamppb.New(refTokenInfo.ExpiresAt),
+		},
 	}, nil
 }
+
+// Logout by revoking the session of the access token
+func (s *ServerGRPC) LogoutToken(ctx context.Context, req *empty.Empty) (*empty.Empty, error) {
+	// Get user ID, session ID, token ID
+	userID, err := middleware.GetUserIDFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No user ID in context")
+		return nil, getErrServerError()
+	}
+	sessionID, err := middleware.GetSessionIDFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No session ID in context")
+		return nil, getErrServerError()
+	}
+	tokenID, err := middleware.GetTokenIDFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No token ID in context")
+		return nil, getErrServerError()
+	}
+
+	// Revoke access token
+	if err := s.domain.TokenRevocation.RevokeToken(ctx, tokenID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to revoke access token")
+		return nil, getErrServerError()
+	}
+
+	// Revoke session. Session may be revoked already.
+	err = s.domain.Session.RevokeSession(ctx, uuid.FromStringOrNil(userID), uuid.FromStringOrNil(sessionID))
+	if err != nil && err != service.ErrRepoNotFound {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to revoke session")
+		return nil, getErrServerError()
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// Logout from all devices
+func (s *ServerGRPC) LogoutAllToken(ctx context.Context, req *empty.Empty) (*empty.Empty, error) {
+	// Get user ID
+	userID, err := middleware.GetUserIDFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No user ID in context")
+		return nil, getErrServerError()
+	}
+
+	// Revoke sessions
+	if err := s.domain.Session.RevokeUserSessions(ctx, uuid.FromStringOrNil(userID)); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to revoke sessions")
+		return nil, getErrServerError()
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// Revoke all sessions of a user
+func (s *ServerGRPC) RevokeUserToken(ctx context.Context, req *UserIDRequest) (*empty.Empty, error) {
+	// Validate request
+	if err := request.ValidateUserUUID(req.Id); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong user ID")
+		return nil, getErrBadRequest()
+	}
+
+	// Revoke sessions
+	if err := s.domain.Session.RevokeUserSessions(ctx, uuid.FromStringOrNil(req.Id)); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to revoke sessions")
+		return nil, getErrServerError()
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// Introspect an access token for other services
+func (s *ServerGRPC) IntrospectToken(ctx context.Context, req *TokenIntrospectRequest) (*TokenIntrospectionResponse, error) {
+	// Validate request
+	if req.Token == "" {
+		log.Ctx(ctx).Error().Msg("No token to introspect")
+		return nil, getErrBadRequest()
+	}
+
+	// Introspect token
+	claims, active, err := s.domain.TokenRevocation.IntrospectToken(ctx, req.Token)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to introspect token")
+		return nil, getErrServerError()
+	}
+
+	return tokenClaimsToTokenIntrospection(claims, active), nil
+}
+
+func (s *ServerGRPC) GetJWKS(ctx context.Context, req *empty.Empty) (*JWKSResponse, error) {
+	// Get public keys
+	jwks, err := authtoken.GetJWKS()
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get JWKS")
+		return nil, getErrServerError()
+	}
+
+	return JWKSToJWKSResponse(jwks), nil
+}
+
+// Request validate
+func (t *TokenMFARequest) validate() error {
+	return request.ValidateTokenMFA(t.MfaToken, t.Code)
+}
+
+func (t *TokenPasskeyFinishRequest) validate() error {
+	// gRPC requests have only public key credentials
+	return request.ValidatePasskeyLoginFinish(t.ChallengeId, webauthn.CredentialType, t.CredentialId, t.ClientDataJSON,
+		t.AuthenticatorData, t.Signature, t.UserHandle)
+}
+
+// DTO <-> Model
+func tokenClaimsToTokenIntrospection(claims *authtoken.TokenClaims, active bool) *TokenIntrospectionResponse {
+	if !active {
+		return &TokenIntrospectionResponse{Active: false}
+	}
+	return &TokenIntrospectionResponse{
+		Active:      true,
+		TokenId:     claims.Id,
+		Subject:     claims.Subject,
+		UserId:      claims.UserID,
+		UserLoginId: claims.UserLoginID,
+		Role:        string(claims.UserRole),
+		ClientId:    claims.ClientID,
+		Scopes:      claims.Scopes,
+		Issuer:      claims.Issuer,
+		Audience:    claims.Audience,
+		IssuedAt:    timestamppb.New(time.Unix(claims.IssuedAt, 0)),
+		ExpiresAt:   timestamppb.New(time.Unix(claims.ExpiresAt, 0)),
+	}
+}
+
+func JWKSToJWKSResponse(jwks *authtoken.JWKS) *JWKSResponse {
+	keys := []*JWKResponse{}
+	for _, jwk := range jwks.Keys {
+		tmp := JWKResponse{
+			Kty: jwk.Kty,
+			Use: jwk.Use,
+			Kid: jwk.Kid,
+			Alg: jwk.Alg,
+			N:   jwk.N,
+			E:   jwk.E,
+			Crv: jwk.Crv,
+			X:   jwk.X,
+			Y:   jwk.Y,
+		}
+		keys = append(keys, &tmp)
+	}
+	return &JWKSResponse{
+		Keys: keys,
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	empty "github.com/golang/protobuf/ptypes/empty"
 	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/ssup2ket/service-auth/internal/domain/entity"
 	"github.com/ssup2ket/service-auth/internal/domain/service"
@@ -21,8 +22,15 @@ func (s *ServerGRPC) ListUser(ctx context.Context, req *UserListRequest) (*UserL
 		return nil, getErrBadRequest()
 	}
 
+	// Get tenant ID
+	tenantID, err := middleware.GetTenantIDFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No tenant ID in context")
+		return nil, getErrServerError()
+	}
+
 	// List user
-	userModels, err := s.domain.User.ListUser(ctx, int(req.Offset), int(req.Limit))
+	userModels, err := s.domain.User.ListUser(ctx, tenantID, int(req.Offset), int(req.Limit))
 	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Msg("Failed to list user")
 		return nil, getErrServerError()
@@ -38,12 +46,34 @@ func (s *ServerGRPC) CreateUser(ctx context.Context, req *UserCreateRequest) (*U
 		return nil, getErrBadRequest()
 	}
 
+	// Get tenant ID
+	tenantID, err := middleware.GetTenantIDFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No tenant ID in context")
+		return nil, getErrServerError()
+	}
+
+	// Get subject. A request without access token has no subject
+	subject, _ := middleware.GetSubjectFromCtx(ctx)
+
 	// Create user
-	user, err := s.domain.User.CreateUser(ctx, userCreateToUserInfoModel(req), req.Password)
+	user, err := s.domain.User.CreateUser(ctx, subject, userCreateToUserInfoModel(tenantID, req), req.Password)
 	if err != nil {
 		if err == service.ErrRepoConflict {
 			log.Ctx(ctx).Error().Err(err).Msg("Failed to create user becase of duplication")
 			return nil, getErrConflict(errors.ErrResouceUser)
+		} else if err == service.ErrRoleNotExist {
+			log.Ctx(ctx).Error().Err(err).Msg("Role doesn't exist")
+			return nil, getErrRoleNotExist()
+		} else if err == service.ErrTenantNotExist {
+			log.Ctx(ctx).Error().Err(err).Msg("Tenant doesn't exist")
+			return nil, getErrTenantNotExist()
+		} else if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Role or tenant isn't allowed to be granted")
+			return nil, getErrUnauthorized()
+		} else if service.IsPasswdPolicyErr(err) {
+			log.Ctx(ctx).Error().Err(err).Msg("Password violates password policy")
+			return nil, getErrPasswdPolicy(err)
 		}
 		log.Ctx(ctx).Error().Err(err).Msg("Failed to create user")
 		return nil, getErrBadRequest()
@@ -59,12 +89,22 @@ func (s *ServerGRPC) GetUser(ctx context.Context, req *UserIDRequest) (*UserInfo
 		return nil, getErrBadRequest()
 	}
 
+	// Get subject
+	subject, err := middleware.GetSubjectFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No subject in context")
+		return nil, getErrServerError()
+	}
+
 	// Get user
-	userInfo, err := s.domain.User.GetUser(ctx, uuid.FromStringOrNil(string(req.Id)))
+	userInfo, err := s.domain.User.GetUser(ctx, subject, uuid.FromStringOrNil(string(req.Id)))
 	if err != nil {
 		if err == service.ErrRepoNotFound {
 			log.Ctx(ctx).Error().Err(err).Msg("User doesn't exist")
 			return nil, getErrNotFound(errors.ErrResouceUser)
+		} else if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("User isn't allowed to be accessed")
+			return nil, getErrUnauthorized()
 		}
 		log.Ctx(ctx).Error().Err(err).Msg("Failed to get user")
 		return nil, getErrServerError()
@@ -81,11 +121,27 @@ func (s *ServerGRPC) UpdateUser(ctx context.Context, req *UserUpdateRequest) (*e
 		return nil, getErrBadRequest()
 	}
 
+	// Get subject
+	subject, err := middleware.GetSubjectFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No subject in context")
+		return nil, getErrServerError()
+	}
+
 	// Update user
-	if err := s.domain.User.UpdateUser(ctx, userUpdateToUserInfoModel(req), req.Password); err != nil {
+	if err := s.domain.User.UpdateUser(ctx, subject, userUpdateToUserInfoModel(req), req.Password); err != nil {
 		if err == service.ErrRepoNotFound {
 			log.Ctx(ctx).Error().Err(err).Msg("User doesn't exist")
 			return nil, getErrNotFound(errors.ErrResouceUser)
+		} else if err == service.ErrRoleNotExist {
+			log.Ctx(ctx).Error().Err(err).Msg("Role doesn't exist")
+			return nil, getErrRoleNotExist()
+		} else if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("User isn't allowed to be accessed")
+			return nil, getErrUnauthorized()
+		} else if service.IsPasswdPolicyErr(err) {
+			log.Ctx(ctx).Error().Err(err).Msg("Password violates password policy")
+			return nil, getErrPasswdPolicy(err)
 		}
 		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete user")
 		return nil, getErrBadRequest()
@@ -101,11 +157,21 @@ func (s *ServerGRPC) DeleteUser(ctx context.Context, req *UserIDRequest) (*empty
 		return nil, getErrBadRequest()
 	}
 
+	// Get subject
+	subject, err := middleware.GetSubjectFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No subject in context")
+		return nil, getErrServerError()
+	}
+
 	// Delete user
-	if err := s.domain.User.DeleteUser(ctx, uuid.FromStringOrNil(req.Id)); err != nil {
+	if err := s.domain.User.DeleteUser(ctx, subject, uuid.FromStringOrNil(req.Id)); err != nil {
 		if err == service.ErrRepoNotFound {
 			log.Ctx(ctx).Error().Err(err).Msg("User doesn't exist")
 			return nil, getErrNotFound(errors.ErrResouceUser)
+		} else if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("User isn't allowed to be accessed")
+			return nil, getErrUnauthorized()
 		}
 		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete user")
 		return nil, getErrServerError()
@@ -114,72 +180,123 @@ func (s *ServerGRPC) DeleteUser(ctx context.Context, req *UserIDRequest) (*empty
 	return &empty.Empty{}, nil
 }
 
+// Alias of getting the user of the access token
 func (s *ServerGRPC) GetUserMe(ctx context.Context, req *empty.Empty) (*UserInfoResponse, error) {
-	// Get user ID
-	userID, err := middleware.GetUserIDFromCtx(ctx)
+	userID, err := getUserMeID(ctx)
 	if err != nil {
-		log.Ctx(ctx).Error().Err(err).Msg("No user ID in context")
-		return nil, getErrServerError()
+		return nil, err
 	}
+	return s.GetUser(ctx, &UserIDRequest{Id: userID})
+}
 
-	// Get user
-	userInfo, err := s.domain.User.GetUser(ctx, uuid.FromStringOrNil(string(userID)))
+// Alias of updating the user of the access token
+func (s *ServerGRPC) UpdateUserMe(ctx context.Context, req *UserUpdateRequest) (*empty.Empty, error) {
+	userID, err := getUserMeID(ctx)
 	if err != nil {
-		if err == service.ErrRepoNotFound {
-			log.Ctx(ctx).Error().Err(err).Msg("User doesn't exist")
-			return nil, getErrNotFound(errors.ErrResouceUser)
-		}
-		log.Ctx(ctx).Error().Err(err).Msg("Failed to get user")
-		return nil, getErrServerError()
+		return nil, err
 	}
+	req.Id = userID
+	return s.UpdateUser(ctx, req)
+}
 
-	// Return user info
-	return UserModelToUserInfo(userInfo), nil
+// Alias of deleting the user of the access token
+func (s *ServerGRPC) DeleteUserMe(ctx context.Context, req *empty.Empty) (*empty.Empty, error) {
+	userID, err := getUserMeID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.DeleteUser(ctx, &UserIDRequest{Id: userID})
 }
 
-func (s *ServerGRPC) UpdateUserMe(ctx context.Context, req *UserUpdateRequest) (*empty.Empty, error) {
-	// Get and set user ID
-	userID, err := middleware.GetUserIDFromCtx(ctx)
+// Change the password of the user of the access token. Tokens issued for expired passwords can call only this API.
+func (s *ServerGRPC) UpdatePasswdUserMe(ctx context.Context, req *UserPasswdUpdateRequest) (*empty.Empty, error) {
+	// Validate request
+	if err := req.validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong update user password request")
+		return nil, getErrBadRequest()
+	}
+
+	// Get user ID and subject
+	userID, err := getUserMeID(ctx)
 	if err != nil {
-		log.Ctx(ctx).Error().Err(err).Msg("No user ID in context")
+		return nil, err
+	}
+	subject, err := middleware.GetSubjectFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No subject in context")
 		return nil, getErrServerError()
 	}
-	req.Id = userID
 
-	// Update user
-	if err := s.domain.User.UpdateUser(ctx, userUpdateToUserInfoModel(req), req.Password); err != nil {
+	// Update password
+	if err := s.domain.User.UpdateUserPasswd(ctx, subject, uuid.FromStringOrNil(userID), req.Password, req.NewPassword); err != nil {
 		if err == service.ErrRepoNotFound {
 			log.Ctx(ctx).Error().Err(err).Msg("User doesn't exist")
 			return nil, getErrNotFound(errors.ErrResouceUser)
+		} else if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Wrong password or user isn't allowed to be accessed")
+			return nil, getErrUnauthorized()
+		} else if service.IsPasswdPolicyErr(err) {
+			log.Ctx(ctx).Error().Err(err).Msg("Password violates password policy")
+			return nil, getErrPasswdPolicy(err)
 		}
-		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete user")
-		return nil, getErrBadRequest()
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to update user password")
+		return nil, getErrServerError()
 	}
 
 	return &empty.Empty{}, nil
 }
 
-func (s *ServerGRPC) DeleteUserMe(ctx context.Context, req *empty.Empty) (*empty.Empty, error) {
-	// Get user ID
-	userID, err := middleware.GetUserIDFromCtx(ctx)
+// Remove the password of the user of the access token to login only by passkeys
+func (s *ServerGRPC) RemovePasswdUserMe(ctx context.Context, req *UserPasswdRemoveRequest) (*empty.Empty, error) {
+	// Validate request
+	if err := req.validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong remove user password request")
+		return nil, getErrBadRequest()
+	}
+
+	// Get user ID and subject
+	userID, err := getUserMeID(ctx)
 	if err != nil {
-		log.Ctx(ctx).Error().Err(err).Msg("No user ID in context")
+		return nil, err
+	}
+	subject, err := middleware.GetSubjectFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No subject in context")
 		return nil, getErrServerError()
 	}
 
-	// Delete user
-	if err := s.domain.User.DeleteUser(ctx, uuid.FromStringOrNil(userID)); err != nil {
+	// Remove password
+	if err := s.domain.User.RemoveUserPasswd(ctx, subject, uuid.FromStringOrNil(userID), req.Password); err != nil {
 		if err == service.ErrRepoNotFound {
 			log.Ctx(ctx).Error().Err(err).Msg("User doesn't exist")
 			return nil, getErrNotFound(errors.ErrResouceUser)
+		} else if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Wrong password or user isn't allowed to be accessed")
+			return nil, getErrUnauthorized()
+		} else if err == service.ErrPasskeyRequired {
+			log.Ctx(ctx).Error().Err(err).Msg("User doesn't have passkey")
+			return nil, getErrPasskeyRequired()
 		}
-		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete user")
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to remove user password")
 		return nil, getErrServerError()
 	}
 
 	return &empty.Empty{}, nil
 }
 
+// Get the user ID of the access token. Access tokens of clients don't have user.
+func getUserMeID(ctx context.Context) (string, error) {
+	userID, err := middleware.GetUserIDFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No user ID in context")
+		return "", getErrServerError()
+	} else if userID == "" {
+		log.Ctx(ctx).Error().Msg("No user in access token")
+		return "", getErrUnauthorized()
+	}
+	return userID, nil
+}
+
 // Request validate
 func (u *UserListRequest) validate() error {
 	return nil
@@ -197,13 +314,22 @@ func (u *UserUpdateRequest) validate() error {
 	return request.ValidateUserUpdate(u.Id, u.Password, u.Role, u.Phone, u.Email)
 }
 
+func (u *UserPasswdUpdateRequest) validate() error {
+	return request.ValidateUserPasswdUpdate(u.Password, u.NewPassword)
+}
+
+func (u *UserPasswdRemoveRequest) validate() error {
+	return request.ValidateUserPasswdRemove(u.Password)
+}
+
 // DTO <-> Model
-func userCreateToUserInfoModel(userCreate *UserCreateRequest) *entity.UserInfo {
+func userCreateToUserInfoModel(tenantID string, userCreate *UserCreateRequest) *entity.UserInfo {
 	return &entity.UserInfo{
-		LoginID: userCreate.LoginId,
-		Role:    entity.UserRole(userCreate.Role),
-		Phone:   userCreate.Phone,
-		Email:   userCreate.Email,
+		TenantID: tenantID,
+		LoginID:  userCreate.LoginId,
+		Role:     entity.UserRole(userCreate.Role),
+		Phone:    userCreate.Phone,
+		Email:    userCreate.Email,
 	}
 }
 
@@ -217,23 +343,33 @@ func userUpdateToUserInfoModel(userUpdate *UserUpdateRequest) *entity.UserInfo {
 }
 
 func UserModelToUserInfo(userModel *entity.UserInfo) *UserInfoResponse {
-	return &UserInfoResponse{
-		Id:      userModel.ID.String(),
-		LoginId: userModel.LoginID,
-		Role:    string(userModel.Role),
-		Phone:   userModel.Phone,
-		Email:   userModel.Email,
+	resp := &UserInfoResponse{
+		Id:            userModel.ID.String(),
+		LoginId:       userModel.LoginID,
+		Role:          string(userModel.Role),
+		Phone:         userModel.Phone,
+		Email:         userModel.Email,
+		TenantId:      userModel.TenantID,
+		EmailVerified: userModel.EmailVerified,
 	}
+	if userModel.EmailVerifiedAt != nil {
+		resp.EmailVerifiedAt = timestamppb.New(*userModel.EmailVerifiedAt)
+	}
+	return resp
 }
 
 func UserModelListToUserInfoList(userModelList []entity.UserInfo) *UserListResponse {
 	userInfos := []*UserInfoResponse{}
 	for _, userModel := range userModelList {
 		tmp := UserInfoResponse{
-			Id:      userModel.ID.String(),
-			LoginId: userModel.LoginID,
-			Phone:   userModel.Phone,
-			Email:   userModel.Email,
+			Id:            userModel.ID.String(),
+			LoginId:       userModel.LoginID,
+			Phone:         userModel.Phone,
+			Email:         userModel.Email,
+			EmailVerified: userModel.EmailVerified,
+		}
+		if userModel.EmailVerifiedAt != nil {
+			tmp.EmailVerifiedAt = timestamppb.New(*userModel.EmailVerifiedAt)
 		}
 		userInfos = append(userInfos, &tmp)
 	}
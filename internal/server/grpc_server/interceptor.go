@@ -3,6 +3,7 @@ package grpc_server
 import (
 	"context"
 	"fmt"
+	"net"
 	"strings"
 	"time"
 
@@ -19,11 +20,14 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/service"
 	"github.com/ssup2ket/service-auth/internal/server/middleware"
 	authtoken "github.com/ssup2ket/service-auth/pkg/auth/token"
 	grpcmeta "github.com/ssup2ket/service-auth/pkg/grpc/meta"
 )
 
+// Methods which don't require access token
 func icLoggerSetterUnary() grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 		// Create logger form global logger and set the logger in the context
@@ -126,8 +130,7 @@ func icAccessLoggerUnary() grpc.UnaryServerInterceptor {
 		proto.Size(respMsg)
 
 		// Get client ip
-		clientPeer, _ := peer.FromContext(ctx)
-		clientIp := clientPeer.Addr.String()
+		clientIp := getClientIP(ctx)
 
 		// Logging
 		log.Ctx(ctx).Info().
@@ -141,22 +144,40 @@ func icAccessLoggerUnary() grpc.UnaryServerInterceptor {
 	}
 }
 
-func icAccessTokenValidaterAndSetterUnary() grpc.UnaryServerInterceptor {
+func icTenantIDSetterUnary(tenantDomain string) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		// Pass token validation for some requests
-		if info.FullMethod == "/Token/LoginToken" || info.FullMethod == "/Token/RefreshToken" || info.FullMethod == "/User/CreateUser" {
-			return handler(ctx, req)
+		// Resolve requested tenant ID from meta or authority's subdomain
+		md := grpcmeta.ExtractMetaFromContext(ctx)
+		headerTenantID := ""
+		if tenantIDs := md.Get(middleware.HeaderTenantID); len(tenantIDs) == 1 {
+			headerTenantID = tenantIDs[0]
+		}
+		authority := ""
+		if authorities := md.Get(":authority"); len(authorities) == 1 {
+			authority = authorities[0]
 		}
+		requestTenantID := middleware.ResolveRequestTenantID(headerTenantID, authority, tenantDomain)
 
-		// Get request's meta data
-		md, okMeta := metadata.FromIncomingContext(ctx)
-		if !okMeta {
-			log.Ctx(ctx).Error().Msg("Failed to get metadata for access token")
-			return nil, getErrServerError()
+		// Set tenant ID to new context. The tenant ID is fixed by access token later
+		newCtx := middleware.SetRequestTenantIDToCtx(ctx, requestTenantID)
+		newCtx = middleware.SetTenantIDToCtx(newCtx, entity.GetTenantIDOrDefault(requestTenantID))
+
+		// Call next handler
+		return handler(newCtx, req)
+	}
+}
+
+func icAccessTokenValidaterAndSetterUnary(tokenRevocation service.TokenRevocationService) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		// Pass token validation for public operations. Auth optional operations validate the token if it's given
+		md, _ := metadata.FromIncomingContext(ctx)
+		tokens, okToken := md["authorization"]
+		if operation, ok := middleware.GetGRPCOperation(info.FullMethod); ok && operation.Public &&
+			(!operation.IsAuthOptional() || !okToken) {
+			return handler(ctx, req)
 		}
 
 		// Get access token
-		tokens, okToken := md["authorization"]
 		if !okToken || len(tokens) != 1 {
 			log.Ctx(ctx).Error().Msg("Failed to get access token")
 			return nil, getErrUnauthorized()
@@ -170,14 +191,37 @@ func icAccessTokenValidaterAndSetterUnary() grpc.UnaryServerInterceptor {
 			return nil, getErrUnauthorized()
 		}
 
+		// Check revocation of access token
+		revoked, err := tokenRevocation.IsTokenRevoked(ctx, authInfo)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to check access token revocation")
+			return nil, getErrServerError()
+		} else if revoked {
+			log.Ctx(ctx).Error().Msg("Access token is revoked")
+			return nil, getErrUnauthorized()
+		}
+
+		// Select tenant ID
+		requestTenantID, _ := middleware.GetRequestTenantIDFromCtx(ctx)
+		tenantID, ok := middleware.SelectTenantID(requestTenantID, authInfo.TenantID, authInfo.GetRoles())
+		if !ok {
+			log.Ctx(ctx).Error().Str("tenant_id", requestTenantID).Msg("Access token isn't allowed for the tenant")
+			return nil, getErrUnauthorized()
+		}
+
 		// Set auth context to context
 		newCtx := middleware.SetUserIDToCtx(ctx, authInfo.UserID)
 		newCtx = middleware.SetUserLoginIDToCtx(newCtx, authInfo.UserLoginID)
-		newCtx = middleware.SetUserRoleToCtx(newCtx, authInfo.UserRole)
+		newCtx = middleware.SetUserRolesToCtx(newCtx, authInfo.GetRoles())
+		newCtx = middleware.SetSessionIDToCtx(newCtx, authInfo.SessionID)
+		newCtx = middleware.SetTokenIDToCtx(newCtx, authInfo.Id)
+		newCtx = middleware.SetScopesToCtx(newCtx, authInfo.Scopes)
+		newCtx = middleware.SetClientIDToCtx(newCtx, authInfo.ClientID)
+		newCtx = middleware.SetTenantIDToCtx(newCtx, tenantID)
 
 		// Set auth info to logger
 		zerolog.Ctx(newCtx).UpdateContext(func(c zerolog.Context) zerolog.Context {
-			return c.Str("token_user_id", authInfo.UserID).Str("token_user_loginid", authInfo.UserLoginID)
+			return c.Str("token_user_id", authInfo.UserID).Str("token_user_loginid", authInfo.UserLoginID).Str("tenant_id", tenantID)
 		})
 
 		// Call next handler
@@ -185,32 +229,38 @@ func icAccessTokenValidaterAndSetterUnary() grpc.UnaryServerInterceptor {
 	}
 }
 
-func icAuthorizerUnary(e *casbin.Enforcer) grpc.UnaryServerInterceptor {
+func icAuthorizerUnary(e *casbin.SyncedEnforcer) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		// Pass token validation for some requests
-		if info.FullMethod == "/Token/LoginToken" || info.FullMethod == "/Token/RefreshToken" || info.FullMethod == "/User/CreateUser" {
+		// Get operation from method. A method not in the catalogue isn't allowed
+		operation, ok := middleware.GetGRPCOperation(info.FullMethod)
+		if !ok {
+			log.Ctx(ctx).Error().Msg("No operation of the method in the catalogue")
+			return nil, getErrUnauthorized()
+		} else if operation.Public && !operation.IsAuthOptional() {
 			return handler(ctx, req)
 		}
 
-		// Get role from context
-		role, err := middleware.GetUserRoleFromCtx(ctx)
+		// Get roles, scopes from context. Auth optional operations without access token have no roles
+		roles, err := middleware.GetUserRolesFromCtx(ctx)
+		if err != nil && operation.IsAuthOptional() {
+			return handler(ctx, req)
+		} else if err != nil {
+			log.Ctx(ctx).Error().Msg("No user roles in context")
+			return nil, getErrServerError()
+		}
+		scopes, err := middleware.GetScopesFromCtx(ctx)
 		if err != nil {
-			log.Ctx(ctx).Error().Msg("No user role in context")
+			log.Ctx(ctx).Error().Msg("No scopes in context")
 			return nil, getErrServerError()
 		}
-
-		// Get object and action from method info
-		// Method Format : /[Service(Object)]/[Action][Service[Object]] ex) /Token/CreateToken
-		tokens := strings.Split(info.FullMethod, "/")
-		if len(tokens) != 3 {
-			log.Ctx(ctx).Error().Msg("Invalid method format")
+		clientID, err := middleware.GetClientIDFromCtx(ctx)
+		if err != nil {
+			log.Ctx(ctx).Error().Msg("No client ID in context")
 			return nil, getErrServerError()
 		}
-		object := strings.ToLower(tokens[1])
-		action := strings.TrimSuffix(strings.ToLower(tokens[2]), object)
 
 		// Check authority
-		if !e.Enforce(string(role), object, action) {
+		if !middleware.Authorize(e, clientID, roles, scopes, operation) {
 			log.Ctx(ctx).Error().Msg("This request isn't allowed")
 			return nil, getErrUnauthorized()
 		}
@@ -220,6 +270,39 @@ func icAuthorizerUnary(e *casbin.Enforcer) grpc.UnaryServerInterceptor {
 	}
 }
 
+// Limit requests by the rate limit of the method. It's set after the access token validator to limit requests by user.
+func icRateLimiterUnary(limiter *middleware.RateLimiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		// Get names of the method. The operation's rate limit takes precedence over the method's rate limit
+		names := []string{}
+		if operation, ok := middleware.GetGRPCOperation(info.FullMethod); ok {
+			names = append(names, operation.String())
+		}
+		names = append(names, info.FullMethod)
+
+		// Take a token. User ID is empty without access token
+		userID, _ := middleware.GetUserIDFromCtx(ctx)
+		result, ok := limiter.Take(names, getClientIP(ctx), userID)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		// Set rate limit headers to response meta and check rate limit
+		header := metadata.MD{}
+		for key, value := range result.GetHeaders() {
+			header.Set(key, value)
+		}
+		grpc.SetHeader(ctx, header)
+		if !result.Allowed {
+			log.Ctx(ctx).Warn().Str("method", info.FullMethod).Msg("Request is rate limited")
+			return nil, getErrRateLimited()
+		}
+
+		// Call next handler
+		return handler(ctx, req)
+	}
+}
+
 func icUserIDLoggerSetterUnary() grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 		// If not user service, skip this interceptor
@@ -244,3 +327,16 @@ func icUserIDLoggerSetterUnary() grpc.UnaryServerInterceptor {
 		return handler(ctx, req)
 	}
 }
+
+// Get client IP without port
+func getClientIP(ctx context.Context) string {
+	clientPeer, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(clientPeer.Addr.String())
+	if err != nil {
+		return clientPeer.Addr.String()
+	}
+	return host
+}
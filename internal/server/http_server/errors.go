@@ -5,6 +5,7 @@ import (
 
 	"github.com/go-chi/render"
 
+	"github.com/ssup2ket/service-auth/internal/domain/service"
 	"github.com/ssup2ket/service-auth/internal/server/errors"
 )
 
@@ -47,6 +48,30 @@ func getErrRendererNotFound(res errors.ErrResouce) render.Renderer {
 	case errors.ErrResouceUser:
 		errCode = errors.CodeNotFoundUser
 		errMsg = errors.MsgNotFoundUser
+	case errors.ErrResouceOAuthClient:
+		errCode = errors.CodeNotFoundOAuthClient
+		errMsg = errors.MsgNotFoundOAuthClient
+	case errors.ErrResouceRole:
+		errCode = errors.CodeNotFoundRole
+		errMsg = errors.MsgNotFoundRole
+	case errors.ErrResoucePermission:
+		errCode = errors.CodeNotFoundPermission
+		errMsg = errors.MsgNotFoundPermission
+	case errors.ErrResouceTenant:
+		errCode = errors.CodeNotFoundTenant
+		errMsg = errors.MsgNotFoundTenant
+	case errors.ErrResouceGroup:
+		errCode = errors.CodeNotFoundGroup
+		errMsg = errors.MsgNotFoundGroup
+	case errors.ErrResouceGroupMember:
+		errCode = errors.CodeNotFoundGroupMember
+		errMsg = errors.MsgNotFoundGroupMember
+	case errors.ErrResouceLoginLock:
+		errCode = errors.CodeNotFoundLoginLock
+		errMsg = errors.MsgNotFoundLoginLock
+	case errors.ErrResoucePasskey:
+		errCode = errors.CodeNotFoundPasskey
+		errMsg = errors.MsgNotFoundPasskey
 	}
 
 	return &errResponse{
@@ -65,6 +90,21 @@ func getErrRendererConflict(res errors.ErrResouce) render.Renderer {
 	case errors.ErrResouceUser:
 		errCode = errors.CodeConflictUser
 		errMsg = errors.MsgConflictUser
+	case errors.ErrResouceRole:
+		errCode = errors.CodeConflictRole
+		errMsg = errors.MsgConflictRole
+	case errors.ErrResoucePermission:
+		errCode = errors.CodeConflictPermission
+		errMsg = errors.MsgConflictPermission
+	case errors.ErrResouceTenant:
+		errCode = errors.CodeConflictTenant
+		errMsg = errors.MsgConflictTenant
+	case errors.ErrResouceGroup:
+		errCode = errors.CodeConflictGroup
+		errMsg = errors.MsgConflictGroup
+	case errors.ErrResouceGroupMember:
+		errCode = errors.CodeConflictGroupMember
+		errMsg = errors.MsgConflictGroupMember
 	}
 
 	return &errResponse{
@@ -76,6 +116,269 @@ func getErrRendererConflict(res errors.ErrResouce) render.Renderer {
 	}
 }
 
+func getErrRendererTokenKeyRotationDisabled() render.Renderer {
+	return &errResponse{
+		ErrorInfo: ErrorInfo{
+			Code:    errors.CodeTokenKeyRotationDisabled,
+			Message: errors.MsgTokenKeyRotationDisabled,
+		},
+		HTTPStatusCode: http.StatusConflict, // 409
+	}
+}
+
+func getErrRendererTokenAudienceNotAllowed() render.Renderer {
+	return &errResponse{
+		ErrorInfo: ErrorInfo{
+			Code:    errors.CodeTokenAudienceNotAllowed,
+			Message: errors.MsgTokenAudienceNotAllowed,
+		},
+		HTTPStatusCode: http.StatusBadRequest, // 400
+	}
+}
+
+func getErrRendererTokenScopeNotAllowed() render.Renderer {
+	return &errResponse{
+		ErrorInfo: ErrorInfo{
+			Code:    errors.CodeTokenScopeNotAllowed,
+			Message: errors.MsgTokenScopeNotAllowed,
+		},
+		HTTPStatusCode: http.StatusBadRequest, // 400
+	}
+}
+
+func getErrRendererOAuthClientGrantTypeNotAllowed() render.Renderer {
+	return &errResponse{
+		ErrorInfo: ErrorInfo{
+			Code:    errors.CodeOAuthClientGrantTypeNotAllowed,
+			Message: errors.MsgOAuthClientGrantTypeNotAllowed,
+		},
+		HTTPStatusCode: http.StatusBadRequest, // 400
+	}
+}
+
+func getErrRendererRoleNotExist() render.Renderer {
+	return &errResponse{
+		ErrorInfo: ErrorInfo{
+			Code:    errors.CodeRoleNotExist,
+			Message: errors.MsgRoleNotExist,
+		},
+		HTTPStatusCode: http.StatusBadRequest, // 400
+	}
+}
+
+func getErrRendererRoleInUse() render.Renderer {
+	return &errResponse{
+		ErrorInfo: ErrorInfo{
+			Code:    errors.CodeRoleInUse,
+			Message: errors.MsgRoleInUse,
+		},
+		HTTPStatusCode: http.StatusConflict, // 409
+	}
+}
+
+func getErrRendererTenantNotExist() render.Renderer {
+	return &errResponse{
+		ErrorInfo: ErrorInfo{
+			Code:    errors.CodeTenantNotExist,
+			Message: errors.MsgTenantNotExist,
+		},
+		HTTPStatusCode: http.StatusBadRequest, // 400
+	}
+}
+
+func getErrRendererTenantInUse() render.Renderer {
+	return &errResponse{
+		ErrorInfo: ErrorInfo{
+			Code:    errors.CodeTenantInUse,
+			Message: errors.MsgTenantInUse,
+		},
+		HTTPStatusCode: http.StatusConflict, // 409
+	}
+}
+
+func getErrRendererGroupMemberNotExist() render.Renderer {
+	return &errResponse{
+		ErrorInfo: ErrorInfo{
+			Code:    errors.CodeGroupMemberNotExist,
+			Message: errors.MsgGroupMemberNotExist,
+		},
+		HTTPStatusCode: http.StatusBadRequest, // 400
+	}
+}
+
+func getErrRendererGroupMemberCycle() render.Renderer {
+	return &errResponse{
+		ErrorInfo: ErrorInfo{
+			Code:    errors.CodeGroupMemberCycle,
+			Message: errors.MsgGroupMemberCycle,
+		},
+		HTTPStatusCode: http.StatusConflict, // 409
+	}
+}
+
+func getErrRendererPasswdPolicy(err error) render.Renderer {
+	errCode := errors.CodeBadRequest
+	errMsg := errors.MsgBadRequest
+	switch err {
+	case service.ErrPasswdTooShort:
+		errCode = errors.CodePasswdTooShort
+		errMsg = errors.MsgPasswdTooShort
+	case service.ErrPasswdTooLong:
+		errCode = errors.CodePasswdTooLong
+		errMsg = errors.MsgPasswdTooLong
+	case service.ErrPasswdCharClasses:
+		errCode = errors.CodePasswdCharClasses
+		errMsg = errors.MsgPasswdCharClasses
+	case service.ErrPasswdBreached:
+		errCode = errors.CodePasswdBreached
+		errMsg = errors.MsgPasswdBreached
+	case service.ErrPasswdUserInput:
+		errCode = errors.CodePasswdUserInput
+		errMsg = errors.MsgPasswdUserInput
+	case service.ErrPasswdReused:
+		errCode = errors.CodePasswdReused
+		errMsg = errors.MsgPasswdReused
+	}
+
+	return &errResponse{
+		ErrorInfo: ErrorInfo{
+			Code:    errCode,
+			Message: errMsg,
+		},
+		HTTPStatusCode: http.StatusBadRequest, // 400
+	}
+}
+
+func getErrRendererPasswdExpired() render.Renderer {
+	return &errResponse{
+		ErrorInfo: ErrorInfo{
+			Code:    errors.CodePasswdExpired,
+			Message: errors.MsgPasswdExpired,
+		},
+		HTTPStatusCode: http.StatusForbidden, // 403
+	}
+}
+
+func getErrRendererLoginLocked() render.Renderer {
+	return &errResponse{
+		ErrorInfo: ErrorInfo{
+			Code:    errors.CodeLoginLocked,
+			Message: errors.MsgLoginLocked,
+		},
+		HTTPStatusCode: http.StatusTooManyRequests, // 429
+	}
+}
+
+func getErrRendererRateLimited() render.Renderer {
+	return &errResponse{
+		ErrorInfo: ErrorInfo{
+			Code:    errors.CodeRateLimited,
+			Message: errors.MsgRateLimited,
+		},
+		HTTPStatusCode: http.StatusTooManyRequests, // 429
+	}
+}
+
+func getErrRendererMFADisabled() render.Renderer {
+	return &errResponse{
+		ErrorInfo: ErrorInfo{
+			Code:    errors.CodeMFADisabled,
+			Message: errors.MsgMFADisabled,
+		},
+		HTTPStatusCode: http.StatusConflict, // 409
+	}
+}
+
+func getErrRendererMFARequired() render.Renderer {
+	return &errResponse{
+		ErrorInfo: ErrorInfo{
+			Code:    errors.CodeMFARequired,
+			Message: errors.MsgMFARequired,
+		},
+		HTTPStatusCode: http.StatusUnauthorized, // 401
+	}
+}
+
+func getErrRendererMFACodeWrong() render.Renderer {
+	return &errResponse{
+		ErrorInfo: ErrorInfo{
+			Code:    errors.CodeMFACodeWrong,
+			Message: errors.MsgMFACodeWrong,
+		},
+		HTTPStatusCode: http.StatusBadRequest, // 400
+	}
+}
+
+func getErrRendererMFAAlreadyEnabled() render.Renderer {
+	return &errResponse{
+		ErrorInfo: ErrorInfo{
+			Code:    errors.CodeMFAAlreadyEnabled,
+			Message: errors.MsgMFAAlreadyEnabled,
+		},
+		HTTPStatusCode: http.StatusConflict, // 409
+	}
+}
+
+func getErrRendererMFANotEnabled() render.Renderer {
+	return &errResponse{
+		ErrorInfo: ErrorInfo{
+			Code:    errors.CodeMFANotEnabled,
+			Message: errors.MsgMFANotEnabled,
+		},
+		HTTPStatusCode: http.StatusConflict, // 409
+	}
+}
+
+func getErrRendererPasskeyDisabled() render.Renderer {
+	return &errResponse{
+		ErrorInfo: ErrorInfo{
+			Code:    errors.CodePasskeyDisabled,
+			Message: errors.MsgPasskeyDisabled,
+		},
+		HTTPStatusCode: http.StatusConflict, // 409
+	}
+}
+
+func getErrRendererPasskeyVerificationFailed() render.Renderer {
+	return &errResponse{
+		ErrorInfo: ErrorInfo{
+			Code:    errors.CodePasskeyVerificationFailed,
+			Message: errors.MsgPasskeyVerificationFailed,
+		},
+		HTTPStatusCode: http.StatusBadRequest, // 400
+	}
+}
+
+func getErrRendererPasskeyRequired() render.Renderer {
+	return &errResponse{
+		ErrorInfo: ErrorInfo{
+			Code:    errors.CodePasskeyRequired,
+			Message: errors.MsgPasskeyRequired,
+		},
+		HTTPStatusCode: http.StatusConflict, // 409
+	}
+}
+
+func getErrRendererEmailNotVerified() render.Renderer {
+	return &errResponse{
+		ErrorInfo: ErrorInfo{
+			Code:    errors.CodeEmailNotVerified,
+			Message: errors.MsgEmailNotVerified,
+		},
+		HTTPStatusCode: http.StatusForbidden, // 403
+	}
+}
+
+func getErrRendererEmailAlreadyVerified() render.Renderer {
+	return &errResponse{
+		ErrorInfo: ErrorInfo{
+			Code:    errors.CodeEmailAlreadyVerified,
+			Message: errors.MsgEmailAlreadyVerified,
+		},
+		HTTPStatusCode: http.StatusConflict, // 409
+	}
+}
+
 func getErrRendererServerError() render.Renderer {
 	return &errResponse{
 		ErrorInfo: ErrorInfo{
@@ -0,0 +1,86 @@
+package http_server
+
+import (
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/rs/zerolog/log"
+
+	"github.com/ssup2ket/service-auth/internal/domain/service"
+	"github.com/ssup2ket/service-auth/internal/server/errors"
+	"github.com/ssup2ket/service-auth/internal/server/middleware"
+	"github.com/ssup2ket/service-auth/internal/server/request"
+)
+
+// Send a verification token to my email
+func (s *ServerHTTP) PostUsersMeEmailVerify(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Get subject
+	subject, ok := getUserMeSubject(w, r)
+	if !ok {
+		return
+	}
+
+	// Send email verification
+	if err := s.domain.EmailVerification.SendEmailVerification(ctx, subject); err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("User doesn't exist")
+			render.Render(w, r, getErrRendererNotFound(errors.ErrResouceUser))
+			return
+		} else if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Subject doesn't have email")
+			render.Render(w, r, getErrRendererUnauthorized())
+			return
+		} else if err == service.ErrEmailAlreadyVerified {
+			log.Ctx(ctx).Error().Err(err).Msg("Email is already verified")
+			render.Render(w, r, getErrRendererEmailAlreadyVerified())
+			return
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to send email verification")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, nil)
+}
+
+// Verify an email with the token sent to the email. It doesn't need an access token, because the token is opened from the mail.
+func (s *ServerHTTP) PostUsersMeEmailVerifyConfirm(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	emailVerifyConfirm := EmailVerifyConfirm{}
+
+	// Unmarshal request
+	if err := render.Bind(r, &emailVerifyConfirm); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong verify email request")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+
+	// Get tenant ID
+	tenantID, err := middleware.GetTenantIDFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No tenant ID in context")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	// Verify email
+	if err := s.domain.EmailVerification.VerifyEmail(ctx, tenantID, emailVerifyConfirm.Token); err != nil {
+		if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Wrong email verification token")
+			render.Render(w, r, getErrRendererUnauthorized())
+			return
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to verify email")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, nil)
+}
+
+// Validate & Bind
+func (e *EmailVerifyConfirm) Bind(r *http.Request) error {
+	return request.ValidateEmailVerifyToken(e.Token)
+}
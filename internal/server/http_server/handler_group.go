@@ -0,0 +1,445 @@
+package http_server
+
+import (
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/rs/zerolog/log"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/service"
+	"github.com/ssup2ket/service-auth/internal/server/errors"
+	"github.com/ssup2ket/service-auth/internal/server/middleware"
+	"github.com/ssup2ket/service-auth/internal/server/request"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+// List groups
+func (s *ServerHTTP) GetGroups(w http.ResponseWriter, r *http.Request, params GetGroupsParams) {
+	ctx := r.Context()
+
+	// Set offset, limit
+	offset := 0
+	if params.Offset != nil {
+		offset = int(*params.Offset)
+	}
+	limit := 0
+	if params.Limit != nil {
+		limit = int(*params.Limit)
+	}
+
+	// Get tenant ID
+	tenantID, err := middleware.GetTenantIDFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No tenant ID in context")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	// List groups
+	groupModels, err := s.domain.Group.ListGroup(ctx, tenantID, offset, limit)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to list groups")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	groups := []GroupInfo{}
+	for i := range groupModels {
+		groups = append(groups, *groupModelToGroupInfo(&groupModels[i]))
+	}
+	render.JSON(w, r, GroupInfoList{
+		Groups: groups,
+	})
+}
+
+// Create a group
+func (s *ServerHTTP) PostGroups(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	groupCreate := GroupCreate{}
+
+	// Unmarshal request
+	if err := render.Bind(r, &groupCreate); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong create group request")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+
+	// Get subject
+	subject, err := middleware.GetSubjectFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No subject in context")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	// Create group
+	group, err := s.domain.Group.CreateGroup(ctx, subject, groupCreateToGroupModel(&groupCreate))
+	if err != nil {
+		if err == service.ErrRepoConflict {
+			log.Ctx(ctx).Error().Err(err).Msg("Group already exists")
+			render.Render(w, r, getErrRendererConflict(errors.ErrResouceGroup))
+			return
+		} else if err == service.ErrRoleNotExist {
+			log.Ctx(ctx).Error().Err(err).Msg("Role doesn't exist")
+			render.Render(w, r, getErrRendererRoleNotExist())
+			return
+		} else if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Group isn't allowed to be created")
+			render.Render(w, r, getErrRendererUnauthorized())
+			return
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to create group")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, groupModelToGroupInfo(group))
+}
+
+// Get a group
+func (s *ServerHTTP) GetGroupsGroupID(w http.ResponseWriter, r *http.Request, groupID GroupID) {
+	ctx := r.Context()
+
+	// Validate request
+	if err := groupID.Validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong group ID")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+
+	// Get tenant ID
+	tenantID, err := middleware.GetTenantIDFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No tenant ID in context")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	// Get group
+	group, err := s.domain.Group.GetGroup(ctx, tenantID, uuid.FromStringOrNil(string(groupID)))
+	if err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("Group doesn't exist")
+			render.Render(w, r, getErrRendererNotFound(errors.ErrResouceGroup))
+			return
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get group")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, groupModelToGroupInfo(group))
+}
+
+// Update a group
+func (s *ServerHTTP) PutGroupsGroupID(w http.ResponseWriter, r *http.Request, groupID GroupID) {
+	ctx := r.Context()
+	groupUpdate := GroupUpdate{}
+
+	// Unmarshal request
+	if err := render.Bind(r, &groupUpdate); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong update group request")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+
+	// Validate request
+	if err := groupID.Validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong group ID")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+
+	// Get subject
+	subject, err := middleware.GetSubjectFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No subject in context")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	// Update group
+	if err := s.domain.Group.UpdateGroup(ctx, subject, groupUpdateToGroupModel(string(groupID), &groupUpdate)); err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("Group doesn't exist")
+			render.Render(w, r, getErrRendererNotFound(errors.ErrResouceGroup))
+			return
+		} else if err == service.ErrRepoConflict {
+			log.Ctx(ctx).Error().Err(err).Msg("Group already exists")
+			render.Render(w, r, getErrRendererConflict(errors.ErrResouceGroup))
+			return
+		} else if err == service.ErrRoleNotExist {
+			log.Ctx(ctx).Error().Err(err).Msg("Role doesn't exist")
+			render.Render(w, r, getErrRendererRoleNotExist())
+			return
+		} else if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Group isn't allowed to be changed")
+			render.Render(w, r, getErrRendererUnauthorized())
+			return
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to update group")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, nil)
+}
+
+// Delete a group with its members
+func (s *ServerHTTP) DeleteGroupsGroupID(w http.ResponseWriter, r *http.Request, groupID GroupID) {
+	ctx := r.Context()
+
+	// Validate request
+	if err := groupID.Validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong group ID")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+
+	// Get subject
+	subject, err := middleware.GetSubjectFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No subject in context")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	// Delete group
+	if err := s.domain.Group.DeleteGroup(ctx, subject, uuid.FromStringOrNil(string(groupID))); err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("Group doesn't exist")
+			render.Render(w, r, getErrRendererNotFound(errors.ErrResouceGroup))
+			return
+		} else if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Group isn't allowed to be changed")
+			render.Render(w, r, getErrRendererUnauthorized())
+			return
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete group")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, nil)
+}
+
+// List members of a group
+func (s *ServerHTTP) GetGroupsGroupIDMembers(w http.ResponseWriter, r *http.Request, groupID GroupID, params GetGroupsGroupIDMembersParams) {
+	ctx := r.Context()
+
+	// Validate request
+	if err := groupID.Validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong group ID")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+
+	// Set offset, limit
+	offset := 0
+	if params.Offset != nil {
+		offset = int(*params.Offset)
+	}
+	limit := 0
+	if params.Limit != nil {
+		limit = int(*params.Limit)
+	}
+
+	// Get tenant ID
+	tenantID, err := middleware.GetTenantIDFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No tenant ID in context")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	// List group members
+	groupMemberModels, err := s.domain.Group.ListGroupMember(ctx, tenantID, uuid.FromStringOrNil(string(groupID)), offset, limit)
+	if err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("Group doesn't exist")
+			render.Render(w, r, getErrRendererNotFound(errors.ErrResouceGroup))
+			return
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to list group members")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	members := []GroupMemberInfo{}
+	for i := range groupMemberModels {
+		members = append(members, *groupMemberModelToGroupMemberInfo(&groupMemberModels[i]))
+	}
+	render.JSON(w, r, GroupMemberInfoList{
+		Members: members,
+	})
+}
+
+// Add a user or a group to a group
+func (s *ServerHTTP) PostGroupsGroupIDMembers(w http.ResponseWriter, r *http.Request, groupID GroupID) {
+	ctx := r.Context()
+	groupMemberCreate := GroupMemberCreate{}
+
+	// Unmarshal request
+	if err := render.Bind(r, &groupMemberCreate); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong add group member request")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+
+	// Validate request
+	if err := groupID.Validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong group ID")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+
+	// Get subject
+	subject, err := middleware.GetSubjectFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No subject in context")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	// Add group member
+	groupMember, err := s.domain.Group.AddGroupMember(ctx, subject, groupMemberCreateToGroupMemberModel(string(groupID), &groupMemberCreate))
+	if err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("Group doesn't exist")
+			render.Render(w, r, getErrRendererNotFound(errors.ErrResouceGroup))
+			return
+		} else if err == service.ErrRepoConflict {
+			log.Ctx(ctx).Error().Err(err).Msg("Group member already exists")
+			render.Render(w, r, getErrRendererConflict(errors.ErrResouceGroupMember))
+			return
+		} else if err == service.ErrGroupMemberNotExist {
+			log.Ctx(ctx).Error().Err(err).Msg("Group member doesn't exist")
+			render.Render(w, r, getErrRendererGroupMemberNotExist())
+			return
+		} else if err == service.ErrGroupMemberCycle {
+			log.Ctx(ctx).Error().Err(err).Msg("Group member makes a cycle")
+			render.Render(w, r, getErrRendererGroupMemberCycle())
+			return
+		} else if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Group isn't allowed to be changed")
+			render.Render(w, r, getErrRendererUnauthorized())
+			return
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to add group member")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, groupMemberModelToGroupMemberInfo(groupMember))
+}
+
+// Remove a member from a group
+func (s *ServerHTTP) DeleteGroupsGroupIDMembersMemberID(w http.ResponseWriter, r *http.Request, groupID GroupID, memberID MemberID) {
+	ctx := r.Context()
+
+	// Validate request
+	if err := groupID.Validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong group ID")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+	if err := memberID.Validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong member ID")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+
+	// Get subject
+	subject, err := middleware.GetSubjectFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No subject in context")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	// Remove group member
+	if err := s.domain.Group.RemoveGroupMember(ctx, subject, uuid.FromStringOrNil(string(groupID)), uuid.FromStringOrNil(string(memberID))); err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("Group or group member doesn't exist")
+			render.Render(w, r, getErrRendererNotFound(errors.ErrResouceGroupMember))
+			return
+		} else if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Group isn't allowed to be changed")
+			render.Render(w, r, getErrRendererUnauthorized())
+			return
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to remove group member")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, nil)
+}
+
+// Validate & Bind
+func (g *GroupID) Validate() error {
+	return request.ValidateGroupUUID(string(*g))
+}
+
+func (m *MemberID) Validate() error {
+	return request.ValidateUserUUID(string(*m))
+}
+
+func (g *GroupCreate) Bind(r *http.Request) error {
+	return request.ValidateGroupCreate(g.Name, g.Description, g.Roles)
+}
+
+func (g *GroupUpdate) Bind(r *http.Request) error {
+	return request.ValidateGroupUpdate("", g.Name, g.Description, g.Roles)
+}
+
+func (g *GroupMemberCreate) Bind(r *http.Request) error {
+	return request.ValidateGroupMemberCreate("", g.MemberId, g.MemberType)
+}
+
+// DTO <-> Model
+func groupCreateToGroupModel(groupCreate *GroupCreate) *entity.Group {
+	return &entity.Group{
+		Name:        groupCreate.Name,
+		Description: groupCreate.Description,
+		Roles:       groupCreate.Roles,
+	}
+}
+
+func groupUpdateToGroupModel(groupID string, groupUpdate *GroupUpdate) *entity.Group {
+	return &entity.Group{
+		ID:          uuid.FromStringOrNil(groupID),
+		Name:        groupUpdate.Name,
+		Description: groupUpdate.Description,
+		Roles:       groupUpdate.Roles,
+	}
+}
+
+func groupModelToGroupInfo(groupModel *entity.Group) *GroupInfo {
+	return &GroupInfo{
+		Id:          groupModel.ID.String(),
+		TenantId:    groupModel.TenantID,
+		Name:        groupModel.Name,
+		Description: groupModel.Description,
+		Roles:       []string(groupModel.Roles),
+		CreatedAt:   groupModel.CreatedAt,
+	}
+}
+
+func groupMemberCreateToGroupMemberModel(groupID string, groupMemberCreate *GroupMemberCreate) *entity.GroupMember {
+	return &entity.GroupMember{
+		GroupID:    uuid.FromStringOrNil(groupID),
+		MemberID:   uuid.FromStringOrNil(groupMemberCreate.MemberId),
+		MemberType: entity.GroupMemberType(groupMemberCreate.MemberType),
+	}
+}
+
+func groupMemberModelToGroupMemberInfo(groupMemberModel *entity.GroupMember) *GroupMemberInfo {
+	return &GroupMemberInfo{
+		GroupId:    groupMemberModel.GroupID.String(),
+		MemberId:   groupMemberModel.MemberID.String(),
+		MemberType: string(groupMemberModel.MemberType),
+		CreatedAt:  groupMemberModel.CreatedAt,
+	}
+}
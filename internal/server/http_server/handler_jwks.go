@@ -0,0 +1,29 @@
+package http_server
+
+import (
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/rs/zerolog/log"
+
+	authtoken "github.com/ssup2ket/service-auth/pkg/auth/token"
+)
+
+func getJWKSHandler() func(w http.ResponseWriter, r *http.Request) {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		// Get public keys
+		jwks, err := authtoken.GetJWKS()
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to get JWKS")
+			_ = render.Render(w, r, getErrRendererServerError())
+			return
+		}
+
+		// Let verifiers cache keys for a while
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		render.JSON(w, r, jwks)
+	}
+	return fn
+}
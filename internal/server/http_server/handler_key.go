@@ -0,0 +1,70 @@
+package http_server
+
+import (
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/rs/zerolog/log"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/service"
+)
+
+// List token keys
+func (s *ServerHTTP) GetKeys(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// List token keys
+	tokenKeys, err := s.domain.Key.ListTokenKeys(ctx)
+	if err != nil {
+		if err == service.ErrTokenKeyRotationDisabled {
+			log.Ctx(ctx).Error().Err(err).Msg("Token keys aren't managed by keyring")
+			render.Render(w, r, getErrRendererTokenKeyRotationDisabled())
+			return
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to list token keys")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, TokenKeyInfoList{
+		Keys: TokenKeyModelListToTokenKeyInfoList(tokenKeys),
+	})
+}
+
+// Rotate token keys
+func (s *ServerHTTP) PostKeysRotate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Rotate token keys
+	if err := s.domain.Key.RotateTokenKeys(ctx); err != nil {
+		if err == service.ErrTokenKeyRotationDisabled {
+			log.Ctx(ctx).Error().Err(err).Msg("Token keys aren't managed by keyring")
+			render.Render(w, r, getErrRendererTokenKeyRotationDisabled())
+			return
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to rotate token keys")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, nil)
+}
+
+// DTO <-> Model
+func TokenKeyModelListToTokenKeyInfoList(tokenKeyModelList []entity.TokenKey) []TokenKeyInfo {
+	tokenKeyInfos := []TokenKeyInfo{}
+	for _, tokenKeyModel := range tokenKeyModelList {
+		tmp := TokenKeyInfo{
+			Id:        tokenKeyModel.KeyID,
+			Type:      TokenKeyType(tokenKeyModel.Type),
+			Alg:       tokenKeyModel.Alg,
+			Status:    TokenKeyStatus(tokenKeyModel.Status),
+			CreatedAt: tokenKeyModel.CreatedAt,
+			RetiredAt: tokenKeyModel.RetiredAt,
+			ExpiresAt: tokenKeyModel.ExpiresAt,
+		}
+		tokenKeyInfos = append(tokenKeyInfos, tmp)
+	}
+	return tokenKeyInfos
+}
@@ -0,0 +1,119 @@
+package http_server
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/rs/zerolog/log"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/service"
+	"github.com/ssup2ket/service-auth/internal/server/errors"
+	"github.com/ssup2ket/service-auth/internal/server/request"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+// List login locks
+func (s *ServerHTTP) GetLoginLocks(w http.ResponseWriter, r *http.Request, params GetLoginLocksParams) {
+	ctx := r.Context()
+
+	// Set offset, limit
+	offset := 0
+	if params.Offset != nil {
+		offset = int(*params.Offset)
+	}
+	limit := 0
+	if params.Limit != nil {
+		limit = int(*params.Limit)
+	}
+
+	// List login locks
+	loginLockModels, err := s.domain.LoginLock.ListLoginLock(ctx, offset, limit)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to list login locks")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	now := time.Now()
+	loginLocks := []LoginLockInfo{}
+	for i := range loginLockModels {
+		loginLocks = append(loginLocks, *loginLockModelToLoginLockInfo(&loginLockModels[i], now))
+	}
+	render.JSON(w, r, LoginLockInfoList{
+		LoginLocks: loginLocks,
+	})
+}
+
+// Get a login lock
+func (s *ServerHTTP) GetLoginLocksLoginLockID(w http.ResponseWriter, r *http.Request, loginLockID LoginLockID) {
+	ctx := r.Context()
+
+	// Validate request
+	if err := loginLockID.Validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong login lock ID")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+
+	// Get login lock
+	loginLock, err := s.domain.LoginLock.GetLoginLock(ctx, uuid.FromStringOrNil(string(loginLockID)))
+	if err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("Login lock doesn't exist")
+			render.Render(w, r, getErrRendererNotFound(errors.ErrResouceLoginLock))
+			return
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get login lock")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, loginLockModelToLoginLockInfo(loginLock, time.Now()))
+}
+
+// Delete a login lock to unlock its login ID or IP
+func (s *ServerHTTP) DeleteLoginLocksLoginLockID(w http.ResponseWriter, r *http.Request, loginLockID LoginLockID) {
+	ctx := r.Context()
+
+	// Validate request
+	if err := loginLockID.Validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong login lock ID")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+
+	// Delete login lock
+	if err := s.domain.LoginLock.DeleteLoginLock(ctx, uuid.FromStringOrNil(string(loginLockID))); err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("Login lock doesn't exist")
+			render.Render(w, r, getErrRendererNotFound(errors.ErrResouceLoginLock))
+			return
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete login lock")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, nil)
+}
+
+// Validate & Bind
+func (l *LoginLockID) Validate() error {
+	return request.ValidateLoginLockUUID(string(*l))
+}
+
+// DTO <-> Model
+func loginLockModelToLoginLockInfo(loginLockModel *entity.LoginLock, now time.Time) *LoginLockInfo {
+	return &LoginLockInfo{
+		Id:           loginLockModel.ID.String(),
+		Type:         LoginLockType(loginLockModel.Type),
+		TenantId:     loginLockModel.TenantID,
+		Subject:      loginLockModel.Subject,
+		Failures:     loginLockModel.Failures,
+		LastFailedAt: loginLockModel.LastFailedAt,
+		LockedUntil:  loginLockModel.LockedUntil,
+		Locked:       loginLockModel.IsLocked(now),
+	}
+}
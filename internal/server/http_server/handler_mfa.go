@@ -0,0 +1,275 @@
+package http_server
+
+import (
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/rs/zerolog/log"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/service"
+	"github.com/ssup2ket/service-auth/internal/server/errors"
+	"github.com/ssup2ket/service-auth/internal/server/middleware"
+	"github.com/ssup2ket/service-auth/internal/server/request"
+)
+
+// Get my MFA status
+func (s *ServerHTTP) GetUsersMeMfa(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Get subject
+	subject, ok := getUserMeSubject(w, r)
+	if !ok {
+		return
+	}
+
+	// Get MFA
+	mfaInfo, err := s.domain.MFA.GetMFA(ctx, subject)
+	if err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("User doesn't exist")
+			render.Render(w, r, getErrRendererNotFound(errors.ErrResouceUser))
+			return
+		} else if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Subject doesn't have MFA")
+			render.Render(w, r, getErrRendererUnauthorized())
+			return
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get MFA")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, mfaInfoModelToMFAInfo(mfaInfo))
+}
+
+// Enroll TOTP. The TOTP secret is returned only once, and TOTP is enabled after it's confirmed.
+func (s *ServerHTTP) PostUsersMeMfaTotp(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Get subject
+	subject, ok := getUserMeSubject(w, r)
+	if !ok {
+		return
+	}
+
+	// Enroll TOTP
+	enrollment, err := s.domain.MFA.EnrollTOTP(ctx, subject)
+	if err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("User doesn't exist")
+			render.Render(w, r, getErrRendererNotFound(errors.ErrResouceUser))
+			return
+		} else if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Subject doesn't have MFA")
+			render.Render(w, r, getErrRendererUnauthorized())
+			return
+		} else if err == service.ErrMFADisabled {
+			log.Ctx(ctx).Error().Err(err).Msg("MFA is disabled")
+			render.Render(w, r, getErrRendererMFADisabled())
+			return
+		} else if err == service.ErrMFAAlreadyEnabled {
+			log.Ctx(ctx).Error().Err(err).Msg("TOTP is already enabled")
+			render.Render(w, r, getErrRendererMFAAlreadyEnabled())
+			return
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to enroll TOTP")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, MFATOTPEnrollment{
+		Secret: enrollment.Secret,
+		Uri:    enrollment.URI,
+	})
+}
+
+// Confirm the enrolled TOTP with a first code to enable it. Recovery codes are returned only once.
+func (s *ServerHTTP) PostUsersMeMfaTotpConfirm(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	mfaCode := mfaTOTPCode{}
+
+	// Unmarshal request
+	if err := render.Bind(r, &mfaCode); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong confirm TOTP request")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+
+	// Get subject
+	subject, ok := getUserMeSubject(w, r)
+	if !ok {
+		return
+	}
+
+	// Confirm TOTP
+	recoveryCodes, err := s.domain.MFA.ConfirmTOTP(ctx, subject, mfaCode.Code)
+	if err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("User doesn't exist")
+			render.Render(w, r, getErrRendererNotFound(errors.ErrResouceUser))
+			return
+		} else if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Subject doesn't have MFA")
+			render.Render(w, r, getErrRendererUnauthorized())
+			return
+		} else if err == service.ErrMFADisabled {
+			log.Ctx(ctx).Error().Err(err).Msg("MFA is disabled")
+			render.Render(w, r, getErrRendererMFADisabled())
+			return
+		} else if err == service.ErrMFAAlreadyEnabled {
+			log.Ctx(ctx).Error().Err(err).Msg("TOTP is already enabled")
+			render.Render(w, r, getErrRendererMFAAlreadyEnabled())
+			return
+		} else if err == service.ErrMFANotEnabled {
+			log.Ctx(ctx).Error().Err(err).Msg("TOTP isn't enrolled")
+			render.Render(w, r, getErrRendererMFANotEnabled())
+			return
+		} else if err == service.ErrMFACodeWrong {
+			log.Ctx(ctx).Error().Err(err).Msg("Wrong TOTP code")
+			render.Render(w, r, getErrRendererMFACodeWrong())
+			return
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to confirm TOTP")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, MFARecoveryCodes{RecoveryCodes: recoveryCodes})
+}
+
+// Disable TOTP with a TOTP code or a recovery code
+func (s *ServerHTTP) PostUsersMeMfaTotpDisable(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	mfaCode := MFACode{}
+
+	// Unmarshal request
+	if err := render.Bind(r, &mfaCode); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong disable TOTP request")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+
+	// Get subject
+	subject, ok := getUserMeSubject(w, r)
+	if !ok {
+		return
+	}
+
+	// Disable TOTP
+	if err := s.domain.MFA.DisableTOTP(ctx, subject, mfaCode.Code, getClientIP(r)); err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("User doesn't exist")
+			render.Render(w, r, getErrRendererNotFound(errors.ErrResouceUser))
+			return
+		} else if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Subject doesn't have MFA")
+			render.Render(w, r, getErrRendererUnauthorized())
+			return
+		} else if err == service.ErrMFANotEnabled {
+			log.Ctx(ctx).Error().Err(err).Msg("TOTP isn't enabled")
+			render.Render(w, r, getErrRendererMFANotEnabled())
+			return
+		} else if err == service.ErrMFACodeWrong {
+			log.Ctx(ctx).Error().Err(err).Msg("Wrong MFA code")
+			render.Render(w, r, getErrRendererMFACodeWrong())
+			return
+		} else if err == service.ErrLoginLocked {
+			log.Ctx(ctx).Error().Err(err).Msg("Login is locked")
+			render.Render(w, r, getErrRendererLoginLocked())
+			return
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to disable TOTP")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, nil)
+}
+
+// Regenerate my recovery codes with a TOTP code or a recovery code. Recovery codes are returned only once.
+func (s *ServerHTTP) PostUsersMeMfaRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	mfaCode := MFACode{}
+
+	// Unmarshal request
+	if err := render.Bind(r, &mfaCode); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong regenerate recovery codes request")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+
+	// Get subject
+	subject, ok := getUserMeSubject(w, r)
+	if !ok {
+		return
+	}
+
+	// Regenerate recovery codes
+	recoveryCodes, err := s.domain.MFA.RegenerateMFARecoveryCodes(ctx, subject, mfaCode.Code, getClientIP(r))
+	if err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("User doesn't exist")
+			render.Render(w, r, getErrRendererNotFound(errors.ErrResouceUser))
+			return
+		} else if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Subject doesn't have MFA")
+			render.Render(w, r, getErrRendererUnauthorized())
+			return
+		} else if err == service.ErrMFANotEnabled {
+			log.Ctx(ctx).Error().Err(err).Msg("TOTP isn't enabled")
+			render.Render(w, r, getErrRendererMFANotEnabled())
+			return
+		} else if err == service.ErrMFACodeWrong {
+			log.Ctx(ctx).Error().Err(err).Msg("Wrong MFA code")
+			render.Render(w, r, getErrRendererMFACodeWrong())
+			return
+		} else if err == service.ErrLoginLocked {
+			log.Ctx(ctx).Error().Err(err).Msg("Login is locked")
+			render.Render(w, r, getErrRendererLoginLocked())
+			return
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to regenerate recovery codes")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, MFARecoveryCodes{RecoveryCodes: recoveryCodes})
+}
+
+// Get the subject of the access token having a user
+func getUserMeSubject(w http.ResponseWriter, r *http.Request) (*entity.Subject, bool) {
+	ctx := r.Context()
+
+	if _, ok := getUserMeID(w, r); !ok {
+		return nil, false
+	}
+	subject, err := middleware.GetSubjectFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No subject in context")
+		render.Render(w, r, getErrRendererServerError())
+		return nil, false
+	}
+	return subject, true
+}
+
+// Validate & Bind
+func (m *MFACode) Bind(r *http.Request) error {
+	return request.ValidateMFACode(m.Code)
+}
+
+// TOTP confirmation only allows a TOTP code
+type mfaTOTPCode MFACode
+
+func (m *mfaTOTPCode) Bind(r *http.Request) error {
+	return request.ValidateTOTPCode(m.Code)
+}
+
+// DTO <-> Model
+func mfaInfoModelToMFAInfo(mfaInfo *entity.MFAInfo) *MFAInfo {
+	return &MFAInfo{
+		TotpEnabled:   mfaInfo.TOTPEnabled,
+		TotpEnabledAt: mfaInfo.TOTPEnabledAt,
+		RecoveryCodes: mfaInfo.RecoveryCodes,
+	}
+}
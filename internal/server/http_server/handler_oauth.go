@@ -0,0 +1,546 @@
+package http_server
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/rs/zerolog/log"
+
+	"github.com/ssup2ket/service-auth/internal/config"
+	"github.com/ssup2ket/service-auth/internal/domain"
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/service"
+	"github.com/ssup2ket/service-auth/internal/server/middleware"
+	authtoken "github.com/ssup2ket/service-auth/pkg/auth/token"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+// OAuth2 error codes (RFC 6749)
+const (
+	oauthErrInvalidRequest          = "invalid_request"
+	oauthErrInvalidClient           = "invalid_client"
+	oauthErrInvalidGrant            = "invalid_grant"
+	oauthErrInvalidScope            = "invalid_scope"
+	oauthErrUnauthorizedClient      = "unauthorized_client"
+	oauthErrUnsupportedGrantType    = "unsupported_grant_type"
+	oauthErrUnsupportedResponseType = "unsupported_response_type"
+	oauthErrAccessDenied            = "access_denied"
+	oauthErrServerError             = "server_error"
+)
+
+// User's decision of a consent
+const (
+	oauthConsentAllow = "allow"
+	oauthConsentDeny  = "deny"
+)
+
+// OpenID Connect discovery document
+type oidcConfiguration struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	UserinfoEndpoint                  string   `json:"userinfo_endpoint"`
+	RevocationEndpoint                string   `json:"revocation_endpoint"`
+	JwksURI                           string   `json:"jwks_uri"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+	ClaimsSupported                   []string `json:"claims_supported"`
+}
+
+// OAuth2 token response
+type oauthTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	IDToken      string `json:"id_token,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+}
+
+// OAuth2 consent of an authorization request, which the consent page shows to the user
+type oauthConsentResponse struct {
+	ClientID    string   `json:"client_id"`
+	ClientName  string   `json:"client_name"`
+	RedirectURI string   `json:"redirect_uri"`
+	Scopes      []string `json:"scopes"`
+}
+
+// OAuth2 authorization response. The consent page redirects the browser to the URL.
+type oauthAuthorizeResponse struct {
+	RedirectTo string `json:"redirect_to"`
+}
+
+// OpenID Connect userinfo response
+type oidcUserInfoResponse struct {
+	Subject           string `json:"sub"`
+	PreferredUsername string `json:"preferred_username,omitempty"`
+	Email             string `json:"email,omitempty"`
+	PhoneNumber       string `json:"phone_number,omitempty"`
+}
+
+// OAuth2 error response
+type oauthErrResponse struct {
+	HTTPStatusCode   int    `json:"-"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+func (e *oauthErrResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	render.Status(r, e.HTTPStatusCode)
+	return nil
+}
+
+func getOIDCConfigHandler(c *config.Configs) func(w http.ResponseWriter, r *http.Request) {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		serverURL := strings.TrimSuffix(c.ServerURL, "/")
+		render.JSON(w, r, oidcConfiguration{
+			Issuer:                 c.GetOIDCIssuer(),
+			AuthorizationEndpoint:  serverURL + "/oauth2/authorize",
+			TokenEndpoint:          serverURL + "/oauth2/token",
+			UserinfoEndpoint:       serverURL + "/oauth2/userinfo",
+			RevocationEndpoint:     serverURL + "/oauth2/revoke",
+			JwksURI:                serverURL + "/.well-known/jwks.json",
+			ResponseTypesSupported: []string{service.OAuthResponseTypeCode},
+			GrantTypesSupported: []string{string(entity.OAuthGrantTypeAuthorizationCode),
+				string(entity.OAuthGrantTypeClientCredentials), string(entity.OAuthGrantTypeRefreshToken)},
+			SubjectTypesSupported:            []string{"public"},
+			IDTokenSigningAlgValuesSupported: []string{c.TokenAccessAlg},
+			ScopesSupported: []string{service.OAuthScopeOpenID, service.OAuthScopeProfile,
+				service.OAuthScopeEmail, service.OAuthScopePhone},
+			TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
+			CodeChallengeMethodsSupported:     []string{service.OAuthCodeChallengeMethodS256},
+			ClaimsSupported: []string{"iss", "sub", "aud", "exp", "iat", "auth_time", "nonce",
+				"preferred_username", "email", "phone_number"},
+		})
+	}
+	return fn
+}
+
+// Authorization endpoint for browsers. The request is only validated and redirected to the consent page of the
+// OAUTH_CONSENT_URL env, or the consent is returned if the consent page isn't set. Users aren't authenticated here,
+// and codes are issued only by POST after the user allows the client.
+func getOAuthAuthorizeHandler(d *domain.Domain) func(w http.ResponseWriter, r *http.Request) {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		// Validate request. Errors of client and redirect URI aren't redirected not to be an open redirector.
+		req := getOAuthAuthorizeRequest(r)
+		client, err := d.OAuth.ValidateAuthorizeRequest(ctx, &req)
+		if err == service.ErrOAuthInvalidClient || err == service.ErrOAuthInvalidRedirectURI {
+			log.Ctx(ctx).Error().Err(err).Msg("Wrong OAuth client or redirect URI")
+			render.Render(w, r, getOAuthErrRenderer(service.ErrOAuthInvalidRequest))
+			return
+		} else if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Wrong OAuth authorization request")
+			redirectOAuthAuthorize(w, r, req.RedirectURI, url.Values{
+				"error": {getOAuthErrCode(err)},
+				"state": {req.State},
+			})
+			return
+		}
+
+		// Redirect to the consent page with the validated request
+		if d.Configs.OAuthConsentURL != "" {
+			redirectOAuthAuthorize(w, r, d.Configs.OAuthConsentURL, getOAuthAuthorizeParams(&req))
+			return
+		}
+		render.JSON(w, r, getOAuthConsentResponse(client, &req))
+	}
+	return fn
+}
+
+// Authorization endpoint for the consent page. The user is authenticated by a first-party login token in the
+// Authorization header, so other sites can't post the consent of the user. A code is issued only when the consent
+// is allowed. The redirect URL is returned instead of redirecting, because the consent page posts the request.
+func getOAuthAuthorizeConsentHandler(d *domain.Domain) func(w http.ResponseWriter, r *http.Request) {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		// Validate request. Errors of client and redirect URI aren't redirected not to be an open redirector.
+		req := getOAuthAuthorizeRequest(r)
+		_, err := d.OAuth.ValidateAuthorizeRequest(ctx, &req)
+		if err == service.ErrOAuthInvalidClient || err == service.ErrOAuthInvalidRedirectURI {
+			log.Ctx(ctx).Error().Err(err).Msg("Wrong OAuth client or redirect URI")
+			render.Render(w, r, getOAuthErrRenderer(service.ErrOAuthInvalidRequest))
+			return
+		} else if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Wrong OAuth authorization request")
+			renderOAuthAuthorize(w, r, req.RedirectURI, url.Values{
+				"error": {getOAuthErrCode(err)},
+				"state": {req.State},
+			})
+			return
+		}
+
+		// Authenticate user
+		userInfo, err := authenticateOAuthUser(d, r)
+		if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to authenticate user")
+			render.Render(w, r, getErrRendererUnauthorized())
+			return
+		} else if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to authenticate user")
+			render.Render(w, r, getErrRendererServerError())
+			return
+		}
+
+		// Check consent
+		switch r.FormValue("consent") {
+		case oauthConsentAllow:
+		case oauthConsentDeny:
+			log.Ctx(ctx).Info().Str("client_id", req.ClientID).Msg("User denied OAuth client")
+			renderOAuthAuthorize(w, r, req.RedirectURI, url.Values{
+				"error": {oauthErrAccessDenied},
+				"state": {req.State},
+			})
+			return
+		default:
+			log.Ctx(ctx).Error().Msg("OAuth authorization request doesn't have consent")
+			render.Render(w, r, getOAuthErrRenderer(service.ErrOAuthInvalidRequest))
+			return
+		}
+
+		// Create authorization code
+		code, err := d.OAuth.CreateAuthCode(ctx, &req, userInfo)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to create OAuth authorization code")
+			renderOAuthAuthorize(w, r, req.RedirectURI, url.Values{
+				"error": {oauthErrServerError},
+				"state": {req.State},
+			})
+			return
+		}
+		renderOAuthAuthorize(w, r, req.RedirectURI, url.Values{
+			"code":  {code},
+			"state": {req.State},
+		})
+	}
+	return fn
+}
+
+// Token endpoint. Clients are authenticated by basic auth or form parameters, and public clients only by client ID.
+func getOAuthTokenHandler(d *domain.Domain) func(w http.ResponseWriter, r *http.Request) {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		// Authenticate client
+		if err := r.ParseForm(); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to parse OAuth token request")
+			render.Render(w, r, getOAuthErrRenderer(service.ErrOAuthInvalidRequest))
+			return
+		}
+		client, err := authenticateOAuthClient(d, r)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to authenticate OAuth client")
+			render.Render(w, r, getOAuthErrRenderer(err))
+			return
+		}
+
+		// Grant tokens
+		var tokens *service.OAuthTokens
+		switch entity.OAuthGrantType(r.PostForm.Get("grant_type")) {
+		case entity.OAuthGrantTypeAuthorizationCode:
+			session := entity.Session{
+				DeviceName: client.Name,
+				UserAgent:  r.UserAgent(),
+				IP:         getClientIP(r),
+			}
+			tokens, err = d.OAuth.ExchangeAuthCode(ctx, client, r.PostForm.Get("code"), r.PostForm.Get("redirect_uri"),
+				r.PostForm.Get("code_verifier"), &session)
+		case entity.OAuthGrantTypeRefreshToken:
+			tokens, err = d.OAuth.RefreshToken(ctx, client, r.PostForm.Get("refresh_token"))
+		case entity.OAuthGrantTypeClientCredentials:
+			tokens, err = d.OAuth.CreateClientToken(ctx, client, r.PostForm.Get("scope"))
+		default:
+			err = service.ErrOAuthUnsupportedGrantType
+		}
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to grant OAuth tokens")
+			render.Render(w, r, getOAuthErrRenderer(err))
+			return
+		}
+
+		// Tokens must not be cached
+		resp := oauthTokenResponse{
+			AccessToken: tokens.AccessToken.Token,
+			TokenType:   "Bearer",
+			ExpiresIn:   int64(time.Until(tokens.AccessToken.ExpiresAt).Seconds()),
+			Scope:       tokens.Scope,
+		}
+		if tokens.RefreshToken != nil {
+			resp.RefreshToken = tokens.RefreshToken.Token
+		}
+		if tokens.IDToken != nil {
+			resp.IDToken = tokens.IDToken.Token
+		}
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set("Pragma", "no-cache")
+		render.JSON(w, r, resp)
+	}
+	return fn
+}
+
+// Userinfo endpoint. Access token is validated by middleware, and only access tokens granted the openid scope
+// are allowed. Claims are returned only for the granted scopes like the ID token.
+func getOAuthUserInfoHandler(d *domain.Domain) func(w http.ResponseWriter, r *http.Request) {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		// Get subject from context. Access tokens of clients don't have user.
+		subject, err := middleware.GetSubjectFromCtx(ctx)
+		if err != nil || subject.IsClient() {
+			log.Ctx(ctx).Error().Msg("No user in access token")
+			render.Render(w, r, getErrRendererUnauthorized())
+			return
+		}
+
+		// Check openid scope
+		scopes, _ := middleware.GetScopesFromCtx(ctx)
+		if !containsOAuthScope(scopes, service.OAuthScopeOpenID) {
+			log.Ctx(ctx).Error().Msg("Access token isn't granted openid scope")
+			render.Render(w, r, getErrRendererUnauthorized())
+			return
+		}
+
+		// Get user info of the access token
+		userInfo, err := d.User.GetUser(ctx, subject, uuid.FromStringOrNil(subject.UserID))
+		if err != nil {
+			if err == service.ErrRepoNotFound {
+				log.Ctx(ctx).Error().Err(err).Msg("User doesn't exist")
+				render.Render(w, r, getErrRendererUnauthorized())
+				return
+			}
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to get user")
+			render.Render(w, r, getErrRendererServerError())
+			return
+		}
+
+		resp := oidcUserInfoResponse{Subject: userInfo.ID.String()}
+		if containsOAuthScope(scopes, service.OAuthScopeProfile) {
+			resp.PreferredUsername = userInfo.LoginID
+		}
+		if containsOAuthScope(scopes, service.OAuthScopeEmail) {
+			resp.Email = userInfo.Email
+		}
+		if containsOAuthScope(scopes, service.OAuthScopePhone) {
+			resp.PhoneNumber = userInfo.Phone
+		}
+		render.JSON(w, r, resp)
+	}
+	return fn
+}
+
+// Revocation endpoint (RFC 7009). Revoking a refresh token revokes its session, and revoking an access token
+// revokes only the access token. Tokens of other clients and invalid tokens are ignored.
+func getOAuthRevokeHandler(d *domain.Domain) func(w http.ResponseWriter, r *http.Request) {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		// Authenticate client
+		if err := r.ParseForm(); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to parse OAuth revocation request")
+			render.Render(w, r, getOAuthErrRenderer(service.ErrOAuthInvalidRequest))
+			return
+		}
+		client, err := authenticateOAuthClient(d, r)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to authenticate OAuth client")
+			render.Render(w, r, getOAuthErrRenderer(err))
+			return
+		}
+
+		// Revoke token
+		token := r.PostForm.Get("token")
+		if claims, err := authtoken.ValidateRefreshToken(token); err == nil && claims.ClientID == client.ID.String() {
+			err = d.Session.RevokeSession(ctx, uuid.FromStringOrNil(claims.UserID), uuid.FromStringOrNil(claims.SessionID))
+			if err != nil && err != service.ErrRepoNotFound {
+				log.Ctx(ctx).Error().Err(err).Msg("Failed to revoke session")
+				render.Render(w, r, getOAuthErrRenderer(err))
+				return
+			}
+		} else if claims, err := authtoken.ValidateAccessToken(token); err == nil && claims.ClientID == client.ID.String() {
+			if err = d.TokenRevocation.RevokeToken(ctx, claims.Id); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Failed to revoke access token")
+				render.Render(w, r, getOAuthErrRenderer(err))
+				return
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+	return fn
+}
+
+// Authenticate user of authorization request by a login access token
+func authenticateOAuthUser(d *domain.Domain, r *http.Request) (*entity.UserInfo, error) {
+	ctx := r.Context()
+
+	accToken := strings.TrimSpace(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer"))
+	claims, err := authtoken.ValidateAccessToken(accToken)
+	if err != nil || claims.UserID == "" {
+		return nil, service.ErrUnauthorized
+	}
+	if claims.SessionID == "" {
+		// Limited tokens like tokens for expired passwords can only do their own operations
+		return nil, service.ErrUnauthorized
+	}
+	if claims.ClientID != "" || len(claims.Scopes) > 0 {
+		// Only first-party login tokens can authorize clients. Tokens of clients or tokens with limited scopes
+		// could get codes for wider scopes of other clients.
+		return nil, service.ErrUnauthorized
+	}
+	revoked, err := d.TokenRevocation.IsTokenRevoked(ctx, claims)
+	if err != nil {
+		return nil, err
+	} else if revoked {
+		return nil, service.ErrUnauthorized
+	}
+	subject := &entity.Subject{UserID: claims.UserID, UserRoles: claims.GetRoles(), TenantID: entity.GetTenantIDOrDefault(claims.TenantID),
+		SessionID: claims.SessionID}
+	userInfo, err := d.User.GetUser(ctx, subject, uuid.FromStringOrNil(claims.UserID))
+	if err == service.ErrRepoNotFound {
+		return nil, service.ErrUnauthorized
+	}
+	return userInfo, err
+}
+
+// Authenticate client by client_secret_basic, client_secret_post or none for public clients
+func authenticateOAuthClient(d *domain.Domain, r *http.Request) (*entity.OAuthClient, error) {
+	clientID, clientSecret, ok := r.BasicAuth()
+	if ok {
+		// Client ID and secret are form encoded in basic auth
+		clientID, _ = url.QueryUnescape(clientID)
+		clientSecret, _ = url.QueryUnescape(clientSecret)
+	} else {
+		clientID = r.PostForm.Get("client_id")
+		clientSecret = r.PostForm.Get("client_secret")
+	}
+	return d.OAuth.AuthenticateClient(r.Context(), clientID, clientSecret)
+}
+
+// Get authorization request from query or form parameters
+func getOAuthAuthorizeRequest(r *http.Request) service.OAuthAuthorizeRequest {
+	return service.OAuthAuthorizeRequest{
+		ResponseType:        r.FormValue("response_type"),
+		ClientID:            r.FormValue("client_id"),
+		RedirectURI:         r.FormValue("redirect_uri"),
+		Scope:               r.FormValue("scope"),
+		State:               r.FormValue("state"),
+		Nonce:               r.FormValue("nonce"),
+		CodeChallenge:       r.FormValue("code_challenge"),
+		CodeChallengeMethod: r.FormValue("code_challenge_method"),
+	}
+}
+
+// Get parameters of a validated authorization request to pass it to the consent page
+func getOAuthAuthorizeParams(req *service.OAuthAuthorizeRequest) url.Values {
+	return url.Values{
+		"response_type":         {req.ResponseType},
+		"client_id":             {req.ClientID},
+		"redirect_uri":          {req.RedirectURI},
+		"scope":                 {req.Scope},
+		"state":                 {req.State},
+		"nonce":                 {req.Nonce},
+		"code_challenge":        {req.CodeChallenge},
+		"code_challenge_method": {req.CodeChallengeMethod},
+	}
+}
+
+func getOAuthConsentResponse(client *entity.OAuthClient, req *service.OAuthAuthorizeRequest) *oauthConsentResponse {
+	return &oauthConsentResponse{
+		ClientID:    client.ID.String(),
+		ClientName:  client.Name,
+		RedirectURI: req.RedirectURI,
+		Scopes:      strings.Fields(req.Scope),
+	}
+}
+
+// Get the URL with parameters. Empty parameters are omitted.
+func getOAuthRedirectURL(redirectURI string, params url.Values) (string, error) {
+	redirectURL, err := url.Parse(redirectURI)
+	if err != nil {
+		return "", err
+	}
+	query := redirectURL.Query()
+	for key, values := range params {
+		if len(values) > 0 && values[0] != "" {
+			query.Set(key, values[0])
+		}
+	}
+	redirectURL.RawQuery = query.Encode()
+	return redirectURL.String(), nil
+}
+
+// Redirect to the URL with parameters
+func redirectOAuthAuthorize(w http.ResponseWriter, r *http.Request, redirectURI string, params url.Values) {
+	redirectURL, err := getOAuthRedirectURL(redirectURI, params)
+	if err != nil {
+		log.Ctx(r.Context()).Error().Err(err).Msg("Wrong OAuth redirect URI")
+		render.Render(w, r, getOAuthErrRenderer(service.ErrOAuthInvalidRequest))
+		return
+	}
+	http.Redirect(w, r, redirectURL, http.StatusFound)
+}
+
+// Return the URL with parameters for the consent page to redirect the browser
+func renderOAuthAuthorize(w http.ResponseWriter, r *http.Request, redirectURI string, params url.Values) {
+	redirectURL, err := getOAuthRedirectURL(redirectURI, params)
+	if err != nil {
+		log.Ctx(r.Context()).Error().Err(err).Msg("Wrong OAuth redirect URI")
+		render.Render(w, r, getOAuthErrRenderer(service.ErrOAuthInvalidRequest))
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	render.JSON(w, r, oauthAuthorizeResponse{RedirectTo: redirectURL})
+}
+
+func containsOAuthScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func getOAuthErrCode(err error) string {
+	switch err {
+	case service.ErrOAuthInvalidRequest, service.ErrOAuthInvalidRedirectURI:
+		return oauthErrInvalidRequest
+	case service.ErrOAuthInvalidClient:
+		return oauthErrInvalidClient
+	case service.ErrOAuthInvalidGrant:
+		return oauthErrInvalidGrant
+	case service.ErrOAuthInvalidScope:
+		return oauthErrInvalidScope
+	case service.ErrOAuthUnauthorizedClient:
+		return oauthErrUnauthorizedClient
+	case service.ErrOAuthUnsupportedGrantType:
+		return oauthErrUnsupportedGrantType
+	case service.ErrOAuthUnsupportedResponseType:
+		return oauthErrUnsupportedResponseType
+	}
+	return oauthErrServerError
+}
+
+func getOAuthErrRenderer(err error) render.Renderer {
+	errCode := getOAuthErrCode(err)
+	httpStatusCode := http.StatusBadRequest // 400
+	if errCode == oauthErrInvalidClient {
+		httpStatusCode = http.StatusUnauthorized // 401
+	} else if errCode == oauthErrServerError {
+		httpStatusCode = http.StatusInternalServerError // 500
+	}
+
+	return &oauthErrResponse{
+		HTTPStatusCode: httpStatusCode,
+		Error:          errCode,
+	}
+}
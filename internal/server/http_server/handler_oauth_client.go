@@ -0,0 +1,224 @@
+package http_server
+
+import (
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/rs/zerolog/log"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/service"
+	"github.com/ssup2ket/service-auth/internal/server/errors"
+	"github.com/ssup2ket/service-auth/internal/server/request"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+// List OAuth clients
+func (s *ServerHTTP) GetOauthClients(w http.ResponseWriter, r *http.Request, params GetOauthClientsParams) {
+	ctx := r.Context()
+
+	// Set offset, limit
+	offset := 0
+	if params.Offset != nil {
+		offset = int(*params.Offset)
+	}
+	limit := 0
+	if params.Limit != nil {
+		limit = int(*params.Limit)
+	}
+
+	// List OAuth clients
+	clientModels, err := s.domain.OAuthClient.ListOAuthClient(ctx, offset, limit)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to list OAuth clients")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	clients := []OAuthClientInfo{}
+	for i := range clientModels {
+		clients = append(clients, *oauthClientModelToOAuthClientInfo(&clientModels[i], ""))
+	}
+	render.JSON(w, r, OAuthClientInfoList{
+		Clients: clients,
+	})
+}
+
+// Create an OAuth client
+func (s *ServerHTTP) PostOauthClients(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	clientCreate := OAuthClientCreate{}
+
+	// Unmarshal request
+	if err := render.Bind(r, &clientCreate); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong create OAuth client request")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+
+	// Create OAuth client
+	client, secret, err := s.domain.OAuthClient.CreateOAuthClient(ctx, oauthClientCreateToOAuthClientModel(&clientCreate))
+	if err != nil {
+		if err == service.ErrOAuthClientInvalidGrantType {
+			log.Ctx(ctx).Error().Err(err).Msg("Grant type isn't allowed for the OAuth client")
+			render.Render(w, r, getErrRendererOAuthClientGrantTypeNotAllowed())
+			return
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to create OAuth client")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, oauthClientModelToOAuthClientInfo(client, secret))
+}
+
+// Get an OAuth client
+func (s *ServerHTTP) GetOauthClientsOAuthClientID(w http.ResponseWriter, r *http.Request, clientID OAuthClientID) {
+	ctx := r.Context()
+
+	// Validate request
+	if err := clientID.Validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong OAuth client ID")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+
+	// Get OAuth client
+	client, err := s.domain.OAuthClient.GetOAuthClient(ctx, uuid.FromStringOrNil(string(clientID)))
+	if err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("OAuth client doesn't exist")
+			render.Render(w, r, getErrRendererNotFound(errors.ErrResouceOAuthClient))
+			return
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get OAuth client")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, oauthClientModelToOAuthClientInfo(client, ""))
+}
+
+// Update an OAuth client
+func (s *ServerHTTP) PutOauthClientsOAuthClientID(w http.ResponseWriter, r *http.Request, clientID OAuthClientID) {
+	ctx := r.Context()
+	clientUpdate := OAuthClientUpdate{}
+
+	// Unmarshal request
+	if err := render.Bind(r, &clientUpdate); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong update OAuth client request")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+
+	// Validate request
+	if err := clientID.Validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong OAuth client ID")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+
+	// Update OAuth client
+	if err := s.domain.OAuthClient.UpdateOAuthClient(ctx, oauthClientUpdateToOAuthClientModel(string(clientID), &clientUpdate)); err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("OAuth client doesn't exist")
+			render.Render(w, r, getErrRendererNotFound(errors.ErrResouceOAuthClient))
+			return
+		} else if err == service.ErrOAuthClientInvalidGrantType {
+			log.Ctx(ctx).Error().Err(err).Msg("Grant type isn't allowed for the OAuth client")
+			render.Render(w, r, getErrRendererOAuthClientGrantTypeNotAllowed())
+			return
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to update OAuth client")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, nil)
+}
+
+// Delete an OAuth client
+func (s *ServerHTTP) DeleteOauthClientsOAuthClientID(w http.ResponseWriter, r *http.Request, clientID OAuthClientID) {
+	ctx := r.Context()
+
+	// Validate request
+	if err := clientID.Validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong OAuth client ID")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+
+	// Delete OAuth client
+	if err := s.domain.OAuthClient.DeleteOAuthClient(ctx, uuid.FromStringOrNil(string(clientID))); err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("OAuth client doesn't exist")
+			render.Render(w, r, getErrRendererNotFound(errors.ErrResouceOAuthClient))
+			return
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete OAuth client")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, nil)
+}
+
+// Validate & Bind
+func (o *OAuthClientID) Validate() error {
+	return request.ValidateOAuthClientUUID(string(*o))
+}
+
+func (o *OAuthClientCreate) Bind(r *http.Request) error {
+	return request.ValidateOAuthClientCreate(o.Name, o.RedirectUris, oauthGrantTypesToStrs(o.GrantTypes), o.Scopes)
+}
+
+func (o *OAuthClientUpdate) Bind(r *http.Request) error {
+	return request.ValidateOAuthClientUpdate("", o.Name, o.RedirectUris, oauthGrantTypesToStrs(o.GrantTypes), o.Scopes)
+}
+
+// DTO <-> Model
+func oauthClientCreateToOAuthClientModel(clientCreate *OAuthClientCreate) *entity.OAuthClient {
+	return &entity.OAuthClient{
+		Name:         clientCreate.Name,
+		RedirectURIs: clientCreate.RedirectUris,
+		GrantTypes:   oauthGrantTypesToStrs(clientCreate.GrantTypes),
+		Scopes:       clientCreate.Scopes,
+		Public:       clientCreate.Public,
+	}
+}
+
+func oauthClientUpdateToOAuthClientModel(clientID string, clientUpdate *OAuthClientUpdate) *entity.OAuthClient {
+	return &entity.OAuthClient{
+		ID:           uuid.FromStringOrNil(clientID),
+		Name:         clientUpdate.Name,
+		RedirectURIs: clientUpdate.RedirectUris,
+		GrantTypes:   oauthGrantTypesToStrs(clientUpdate.GrantTypes),
+		Scopes:       clientUpdate.Scopes,
+	}
+}
+
+func oauthClientModelToOAuthClientInfo(clientModel *entity.OAuthClient, secret string) *OAuthClientInfo {
+	clientInfo := OAuthClientInfo{
+		Id:           clientModel.ID.String(),
+		Name:         clientModel.Name,
+		RedirectUris: []string(clientModel.RedirectURIs),
+		Scopes:       []string(clientModel.Scopes),
+		Public:       clientModel.Public,
+		CreatedAt:    clientModel.CreatedAt,
+	}
+	for _, grantType := range clientModel.GrantTypes {
+		clientInfo.GrantTypes = append(clientInfo.GrantTypes, OAuthGrantType(grantType))
+	}
+	if secret != "" {
+		clientInfo.Secret = &secret
+	}
+	return &clientInfo
+}
+
+func oauthGrantTypesToStrs(grantTypes []OAuthGrantType) []string {
+	strs := []string{}
+	for _, grantType := range grantTypes {
+		strs = append(strs, string(grantType))
+	}
+	return strs
+}
@@ -0,0 +1,263 @@
+package http_server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ssup2ket/service-auth/internal/config"
+	"github.com/ssup2ket/service-auth/internal/domain"
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/service"
+	"github.com/ssup2ket/service-auth/internal/domain/service/mocks"
+	"github.com/ssup2ket/service-auth/internal/server/middleware"
+	"github.com/ssup2ket/service-auth/internal/test"
+	authtoken "github.com/ssup2ket/service-auth/pkg/auth/token"
+)
+
+type oauthSuite struct {
+	suite.Suite
+
+	userService            mocks.UserService
+	oauthService           mocks.OAuthService
+	tokenRevocationService mocks.TokenRevocationService
+
+	userInfo *entity.UserInfo
+	domain   *domain.Domain
+}
+
+func TestOAuth(t *testing.T) {
+	suite.Run(t, new(oauthSuite))
+}
+
+func (o *oauthSuite) SetupSuite() {
+	keyProvider, err := authtoken.NewRandomKeyProvider(authtoken.AlgHS256)
+	require.NoError(o.T(), err)
+	authtoken.SetKeyProvider(keyProvider)
+}
+
+func (o *oauthSuite) SetupTest() {
+	o.userService = mocks.UserService{}
+	o.oauthService = mocks.OAuthService{}
+	o.tokenRevocationService = mocks.TokenRevocationService{}
+
+	o.userInfo = &entity.UserInfo{
+		ID:       test.UserIDCorrect,
+		TenantID: test.TenantIDCorrect,
+		LoginID:  test.UserLoginIDCorrect,
+		Phone:    test.UserPhoneCorrect,
+		Email:    test.UserEmailCorrect,
+	}
+	o.domain = &domain.Domain{
+		Configs:         &config.Configs{},
+		User:            &o.userService,
+		OAuth:           &o.oauthService,
+		TokenRevocation: &o.tokenRevocationService,
+	}
+}
+
+// Authorization request parameters of the client
+func (o *oauthSuite) getAuthorizeParams() url.Values {
+	return url.Values{
+		"response_type":         {service.OAuthResponseTypeCode},
+		"client_id":             {test.OAuthClientIDCorrect.String()},
+		"redirect_uri":          {test.OAuthClientRedirectURICorrect},
+		"scope":                 {test.OAuthScopeCorrect},
+		"state":                 {"test-state"},
+		"code_challenge":        {test.OAuthCodeChallengeCorrect},
+		"code_challenge_method": {service.OAuthCodeChallengeMethodS256},
+	}
+}
+
+// Post consent of the user with the login access token
+func (o *oauthSuite) postAuthorize(consent string) *httptest.ResponseRecorder {
+	tokenInfo, err := authtoken.CreateAccessToken(&authtoken.AuthClaims{UserID: test.UserIDCorrect.String(), TenantID: test.TenantIDCorrect,
+		SessionID: test.SessionIDCorrect.String()}, "")
+	require.NoError(o.T(), err)
+	params := o.getAuthorizeParams()
+	params.Set("consent", consent)
+	req := httptest.NewRequest(http.MethodPost, "/oauth2/authorize", strings.NewReader(params.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Authorization", "Bearer "+tokenInfo.Token)
+
+	recorder := httptest.NewRecorder()
+	getOAuthAuthorizeConsentHandler(o.domain)(recorder, req)
+	return recorder
+}
+
+func (o *oauthSuite) mockAuthorizeUser() {
+	o.oauthService.On("ValidateAuthorizeRequest", mock.Anything, mock.Anything).Return(&test.OAuthClientCorrect, nil)
+	o.tokenRevocationService.On("IsTokenRevoked", mock.Anything, mock.Anything).Return(false, nil)
+	o.userService.On("GetUser", mock.Anything, mock.Anything, test.UserIDCorrect).Return(o.userInfo, nil)
+}
+
+// Request userinfo with the context set by the access token validator
+func (o *oauthSuite) getUserInfo(scopes []string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/oauth2/userinfo", nil)
+	ctx := middleware.SetUserIDToCtx(req.Context(), test.UserIDCorrect.String())
+	ctx = middleware.SetUserRolesToCtx(ctx, []entity.UserRole{entity.UserRoleUser})
+	ctx = middleware.SetTenantIDToCtx(ctx, test.TenantIDCorrect)
+	ctx = middleware.SetScopesToCtx(ctx, scopes)
+	req = req.WithContext(ctx)
+
+	recorder := httptest.NewRecorder()
+	getOAuthUserInfoHandler(o.domain)(recorder, req)
+	return recorder
+}
+
+// Request authorization with the access token
+func (o *oauthSuite) authenticateUser(claims *authtoken.AuthClaims) (*entity.UserInfo, error) {
+	tokenInfo, err := authtoken.CreateAccessToken(claims, "")
+	require.NoError(o.T(), err)
+	req := httptest.NewRequest(http.MethodGet, "/oauth2/authorize", nil)
+	req.Header.Set("Authorization", "Bearer "+tokenInfo.Token)
+	return authenticateOAuthUser(o.domain, req)
+}
+
+func (o *oauthSuite) TestUserInfoScopes() {
+	o.userService.On("GetUser", mock.Anything, mock.Anything, test.UserIDCorrect).Return(o.userInfo, nil)
+
+	recorder := o.getUserInfo([]string{service.OAuthScopeOpenID, service.OAuthScopeEmail})
+	require.Equal(o.T(), http.StatusOK, recorder.Code)
+	resp := oidcUserInfoResponse{}
+	require.NoError(o.T(), json.Unmarshal(recorder.Body.Bytes(), &resp))
+	require.Equal(o.T(), oidcUserInfoResponse{Subject: test.UserIDCorrect.String(), Email: test.UserEmailCorrect}, resp)
+}
+
+func (o *oauthSuite) TestUserInfoTenant() {
+	tokenInfo, err := authtoken.CreateAccessToken(&authtoken.AuthClaims{UserID: test.UserIDCorrect.String(), UserRole: entity.UserRoleUser,
+		TenantID: test.TenantIDCorrect, Scopes: []string{service.OAuthScopeOpenID}}, "")
+	require.NoError(o.T(), err)
+
+	// Userinfo gets the user in the access token's tenant
+	o.tokenRevocationService.On("IsTokenRevoked", mock.Anything, mock.Anything).Return(false, nil)
+	o.userService.On("GetUser", mock.Anything, mock.MatchedBy(func(subject *entity.Subject) bool {
+		return subject.UserID == test.UserIDCorrect.String() && subject.TenantID == test.TenantIDCorrect
+	}), test.UserIDCorrect).Return(o.userInfo, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/oauth2/userinfo", nil)
+	req.Header.Set("Authorization", "Bearer "+tokenInfo.Token)
+	recorder := httptest.NewRecorder()
+	handler := mwTenantIDSetter("")(mwAccessTokenValidatorAndSetter(&o.tokenRevocationService)(http.HandlerFunc(getOAuthUserInfoHandler(o.domain))))
+	handler.ServeHTTP(recorder, req)
+	require.Equal(o.T(), http.StatusOK, recorder.Code)
+	resp := oidcUserInfoResponse{}
+	require.NoError(o.T(), json.Unmarshal(recorder.Body.Bytes(), &resp))
+	require.Equal(o.T(), test.UserIDCorrect.String(), resp.Subject)
+}
+
+func (o *oauthSuite) TestUserInfoNoOpenID() {
+	recorder := o.getUserInfo([]string{service.OAuthScopeProfile, service.OAuthScopeEmail})
+	require.Equal(o.T(), http.StatusUnauthorized, recorder.Code)
+	o.userService.AssertNotCalled(o.T(), "GetUser", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (o *oauthSuite) TestAuthenticateUserLoginToken() {
+	o.tokenRevocationService.On("IsTokenRevoked", mock.Anything, mock.Anything).Return(false, nil)
+	o.userService.On("GetUser", mock.Anything, mock.Anything, test.UserIDCorrect).Return(o.userInfo, nil)
+
+	userInfo, err := o.authenticateUser(&authtoken.AuthClaims{UserID: test.UserIDCorrect.String(), TenantID: test.TenantIDCorrect,
+		SessionID: test.SessionIDCorrect.String()})
+	require.NoError(o.T(), err)
+	require.Equal(o.T(), o.userInfo, userInfo)
+}
+
+func (o *oauthSuite) TestAuthenticateUserClientToken() {
+	_, err := o.authenticateUser(&authtoken.AuthClaims{UserID: test.UserIDCorrect.String(), TenantID: test.TenantIDCorrect,
+		SessionID: test.SessionIDCorrect.String(), ClientID: test.OAuthClientIDCorrect.String()})
+	require.Equal(o.T(), service.ErrUnauthorized, err)
+}
+
+func (o *oauthSuite) TestAuthenticateUserScopedToken() {
+	_, err := o.authenticateUser(&authtoken.AuthClaims{UserID: test.UserIDCorrect.String(), TenantID: test.TenantIDCorrect,
+		SessionID: test.SessionIDCorrect.String(), Scopes: []string{entity.ScopeUsersMeRead}})
+	require.Equal(o.T(), service.ErrUnauthorized, err)
+}
+
+func (o *oauthSuite) TestAuthorizeConsent() {
+	o.oauthService.On("ValidateAuthorizeRequest", mock.Anything, mock.Anything).Return(&test.OAuthClientCorrect, nil)
+
+	// GET only returns the consent without authenticating the user
+	req := httptest.NewRequest(http.MethodGet, "/oauth2/authorize?"+o.getAuthorizeParams().Encode(), nil)
+	req.SetBasicAuth(test.UserLoginIDCorrect, test.UserPasswdCorrect)
+	recorder := httptest.NewRecorder()
+	getOAuthAuthorizeHandler(o.domain)(recorder, req)
+	require.Equal(o.T(), http.StatusOK, recorder.Code)
+	resp := oauthConsentResponse{}
+	require.NoError(o.T(), json.Unmarshal(recorder.Body.Bytes(), &resp))
+	require.Equal(o.T(), oauthConsentResponse{ClientID: test.OAuthClientIDCorrect.String(), ClientName: test.OAuthClientNameCorrect,
+		RedirectURI: test.OAuthClientRedirectURICorrect, Scopes: strings.Fields(test.OAuthScopeCorrect)}, resp)
+	o.oauthService.AssertNotCalled(o.T(), "CreateAuthCode", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (o *oauthSuite) TestAuthorizeConsentPage() {
+	o.domain.Configs.OAuthConsentURL = "https://ssup2ket.com/consent"
+	o.oauthService.On("ValidateAuthorizeRequest", mock.Anything, mock.Anything).Return(&test.OAuthClientCorrect, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/oauth2/authorize?"+o.getAuthorizeParams().Encode(), nil)
+	recorder := httptest.NewRecorder()
+	getOAuthAuthorizeHandler(o.domain)(recorder, req)
+	require.Equal(o.T(), http.StatusFound, recorder.Code)
+	location, err := url.Parse(recorder.Header().Get("Location"))
+	require.NoError(o.T(), err)
+	require.Equal(o.T(), "ssup2ket.com", location.Host)
+	require.Equal(o.T(), "/consent", location.Path)
+	require.Equal(o.T(), o.getAuthorizeParams(), location.Query())
+}
+
+func (o *oauthSuite) TestAuthorizeAllow() {
+	o.mockAuthorizeUser()
+	o.oauthService.On("CreateAuthCode", mock.Anything, mock.Anything, o.userInfo).Return("test-code", nil)
+
+	recorder := o.postAuthorize(oauthConsentAllow)
+	require.Equal(o.T(), http.StatusOK, recorder.Code)
+	resp := oauthAuthorizeResponse{}
+	require.NoError(o.T(), json.Unmarshal(recorder.Body.Bytes(), &resp))
+	redirectURL, err := url.Parse(resp.RedirectTo)
+	require.NoError(o.T(), err)
+	require.Equal(o.T(), "test-code", redirectURL.Query().Get("code"))
+	require.Equal(o.T(), "test-state", redirectURL.Query().Get("state"))
+}
+
+func (o *oauthSuite) TestAuthorizeDeny() {
+	o.mockAuthorizeUser()
+
+	recorder := o.postAuthorize(oauthConsentDeny)
+	require.Equal(o.T(), http.StatusOK, recorder.Code)
+	resp := oauthAuthorizeResponse{}
+	require.NoError(o.T(), json.Unmarshal(recorder.Body.Bytes(), &resp))
+	redirectURL, err := url.Parse(resp.RedirectTo)
+	require.NoError(o.T(), err)
+	require.Equal(o.T(), oauthErrAccessDenied, redirectURL.Query().Get("error"))
+	o.oauthService.AssertNotCalled(o.T(), "CreateAuthCode", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (o *oauthSuite) TestAuthorizeWithoutConsent() {
+	o.mockAuthorizeUser()
+
+	recorder := o.postAuthorize("")
+	require.Equal(o.T(), http.StatusBadRequest, recorder.Code)
+	o.oauthService.AssertNotCalled(o.T(), "CreateAuthCode", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (o *oauthSuite) TestAuthorizeBasicAuth() {
+	o.oauthService.On("ValidateAuthorizeRequest", mock.Anything, mock.Anything).Return(&test.OAuthClientCorrect, nil)
+
+	// ID/password isn't accepted
+	params := o.getAuthorizeParams()
+	params.Set("consent", oauthConsentAllow)
+	req := httptest.NewRequest(http.MethodPost, "/oauth2/authorize", strings.NewReader(params.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(test.UserLoginIDCorrect, test.UserPasswdCorrect)
+	recorder := httptest.NewRecorder()
+	getOAuthAuthorizeConsentHandler(o.domain)(recorder, req)
+	require.Equal(o.T(), http.StatusUnauthorized, recorder.Code)
+	o.oauthService.AssertNotCalled(o.T(), "CreateAuthCode", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,282 @@
+package http_server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/rs/zerolog/log"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/service"
+	"github.com/ssup2ket/service-auth/internal/server/errors"
+	"github.com/ssup2ket/service-auth/internal/server/request"
+	"github.com/ssup2ket/service-auth/pkg/auth/webauthn"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+// List my passkeys
+func (s *ServerHTTP) GetUsersMePasskeys(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Get subject
+	subject, ok := getUserMeSubject(w, r)
+	if !ok {
+		return
+	}
+
+	// List passkeys
+	passkeys, err := s.domain.Passkey.ListPasskey(ctx, subject)
+	if err != nil {
+		if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Subject doesn't have passkeys")
+			render.Render(w, r, getErrRendererUnauthorized())
+			return
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to list passkeys")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, PasskeyInfoList{Passkeys: passkeyModelListToPasskeyInfoList(passkeys)})
+}
+
+// Begin a passkey registration. The options are passed to navigator.credentials.create().
+func (s *ServerHTTP) PostUsersMePasskeysBegin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Get subject
+	subject, ok := getUserMeSubject(w, r)
+	if !ok {
+		return
+	}
+
+	// Begin registration
+	registration, err := s.domain.Passkey.BeginPasskeyRegistration(ctx, subject)
+	if err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("User doesn't exist")
+			render.Render(w, r, getErrRendererNotFound(errors.ErrResouceUser))
+			return
+		} else if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Subject doesn't have passkeys")
+			render.Render(w, r, getErrRendererUnauthorized())
+			return
+		} else if err == service.ErrPasskeyDisabled {
+			log.Ctx(ctx).Error().Err(err).Msg("Passkey is disabled")
+			render.Render(w, r, getErrRendererPasskeyDisabled())
+			return
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to begin passkey registration")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	passkeyOptions, err := getPasskeyOptions(registration.ChallengeID, registration.Options)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to encode passkey registration options")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+	render.JSON(w, r, passkeyOptions)
+}
+
+// Finish a passkey registration with the credential of navigator.credentials.create()
+func (s *ServerHTTP) PostUsersMePasskeysFinish(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	passkeyRegistrationFinish := PasskeyRegistrationFinish{}
+
+	// Unmarshal request
+	if err := render.Bind(r, &passkeyRegistrationFinish); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong finish passkey registration request")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+	attestation, err := passkeyAttestationCredentialToPasskeyAttestationModel(&passkeyRegistrationFinish.Credential)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong passkey attestation")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+	name := ""
+	if passkeyRegistrationFinish.Name != nil {
+		name = *passkeyRegistrationFinish.Name
+	}
+
+	// Get subject
+	subject, ok := getUserMeSubject(w, r)
+	if !ok {
+		return
+	}
+
+	// Finish registration
+	passkey, err := s.domain.Passkey.FinishPasskeyRegistration(ctx, subject, uuid.FromStringOrNil(passkeyRegistrationFinish.ChallengeId),
+		name, attestation)
+	if err != nil {
+		if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Subject doesn't have passkeys")
+			render.Render(w, r, getErrRendererUnauthorized())
+			return
+		} else if err == service.ErrPasskeyDisabled {
+			log.Ctx(ctx).Error().Err(err).Msg("Passkey is disabled")
+			render.Render(w, r, getErrRendererPasskeyDisabled())
+			return
+		} else if err == service.ErrPasskeyVerificationFailed {
+			log.Ctx(ctx).Error().Err(err).Msg("Passkey verification failed")
+			render.Render(w, r, getErrRendererPasskeyVerificationFailed())
+			return
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to finish passkey registration")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, passkeyModelToPasskeyInfo(passkey))
+}
+
+// Delete my passkey
+func (s *ServerHTTP) DeleteUsersMePasskeysPasskeyID(w http.ResponseWriter, r *http.Request, passkeyID PasskeyID) {
+	ctx := r.Context()
+
+	// Validate request
+	if err := passkeyID.Validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong passkey ID")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+
+	// Get subject
+	subject, ok := getUserMeSubject(w, r)
+	if !ok {
+		return
+	}
+
+	// Delete passkey
+	if err := s.domain.Passkey.DeletePasskey(ctx, subject, uuid.FromStringOrNil(string(passkeyID))); err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("Passkey doesn't exist")
+			render.Render(w, r, getErrRendererNotFound(errors.ErrResoucePasskey))
+			return
+		} else if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Subject doesn't have passkeys")
+			render.Render(w, r, getErrRendererUnauthorized())
+			return
+		} else if err == service.ErrPasskeyRequired {
+			log.Ctx(ctx).Error().Err(err).Msg("Last passkey of user without password")
+			render.Render(w, r, getErrRendererPasskeyRequired())
+			return
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete passkey")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, nil)
+}
+
+// Validate & Bind
+func (p *PasskeyID) Validate() error {
+	return request.ValidatePasskeyUUID(string(*p))
+}
+
+func (p *PasskeyRegistrationFinish) Bind(r *http.Request) error {
+	name := ""
+	if p.Name != nil {
+		name = *p.Name
+	}
+	transports := []string{}
+	if p.Credential.Response.Transports != nil {
+		transports = *p.Credential.Response.Transports
+	}
+	return request.ValidatePasskeyRegistrationFinish(p.ChallengeId, name, p.Credential.Type, p.Credential.Id,
+		p.Credential.Response.ClientDataJSON, p.Credential.Response.AttestationObject, transports)
+}
+
+func (p *PasskeyLoginFinish) Bind(r *http.Request) error {
+	userHandle := ""
+	if p.Credential.Response.UserHandle != nil {
+		userHandle = *p.Credential.Response.UserHandle
+	}
+	return request.ValidatePasskeyLoginFinish(p.ChallengeId, p.Credential.Type, p.Credential.Id,
+		p.Credential.Response.ClientDataJSON, p.Credential.Response.AuthenticatorData, p.Credential.Response.Signature, userHandle)
+}
+
+// DTO <-> Model
+func getPasskeyOptions(challengeID uuid.EntityUUID, options interface{}) (*PasskeyOptions, error) {
+	// Options are encoded as the JSON of WebAuthn
+	data, err := json.Marshal(options)
+	if err != nil {
+		return nil, err
+	}
+	publicKey := map[string]interface{}{}
+	if err := json.Unmarshal(data, &publicKey); err != nil {
+		return nil, err
+	}
+	return &PasskeyOptions{
+		ChallengeId: challengeID.String(),
+		PublicKey:   publicKey,
+	}, nil
+}
+
+func passkeyAttestationCredentialToPasskeyAttestationModel(credential *PasskeyAttestationCredential) (*entity.PasskeyAttestation, error) {
+	var err error
+	attestation := entity.PasskeyAttestation{Transports: []string{}}
+	if attestation.CredentialID, err = webauthn.DecodeBase64URL(credential.Id); err != nil {
+		return nil, err
+	}
+	if attestation.ClientDataJSON, err = webauthn.DecodeBase64URL(credential.Response.ClientDataJSON); err != nil {
+		return nil, err
+	}
+	if attestation.AttestationObject, err = webauthn.DecodeBase64URL(credential.Response.AttestationObject); err != nil {
+		return nil, err
+	}
+	if credential.Response.Transports != nil {
+		attestation.Transports = *credential.Response.Transports
+	}
+	return &attestation, nil
+}
+
+func passkeyAssertionCredentialToPasskeyAssertionModel(credential *PasskeyAssertionCredential) (*entity.PasskeyAssertion, error) {
+	var err error
+	assertion := entity.PasskeyAssertion{}
+	if assertion.CredentialID, err = webauthn.DecodeBase64URL(credential.Id); err != nil {
+		return nil, err
+	}
+	if assertion.ClientDataJSON, err = webauthn.DecodeBase64URL(credential.Response.ClientDataJSON); err != nil {
+		return nil, err
+	}
+	if assertion.AuthenticatorData, err = webauthn.DecodeBase64URL(credential.Response.AuthenticatorData); err != nil {
+		return nil, err
+	}
+	if assertion.Signature, err = webauthn.DecodeBase64URL(credential.Response.Signature); err != nil {
+		return nil, err
+	}
+	if credential.Response.UserHandle != nil {
+		if assertion.UserHandle, err = webauthn.DecodeBase64URL(*credential.Response.UserHandle); err != nil {
+			return nil, err
+		}
+	}
+	return &assertion, nil
+}
+
+func passkeyModelToPasskeyInfo(passkeyModel *entity.WebAuthnCredential) *PasskeyInfo {
+	transports := []string(passkeyModel.Transports)
+	if transports == nil {
+		transports = []string{}
+	}
+	return &PasskeyInfo{
+		Id:         passkeyModel.ID.String(),
+		Name:       passkeyModel.Name,
+		Transports: transports,
+		CreatedAt:  passkeyModel.CreatedAt,
+		LastUsedAt: passkeyModel.LastUsedAt,
+	}
+}
+
+func passkeyModelListToPasskeyInfoList(passkeyModelList []entity.WebAuthnCredential) []PasskeyInfo {
+	passkeyInfoList := []PasskeyInfo{}
+	for i := range passkeyModelList {
+		passkeyInfoList = append(passkeyInfoList, *passkeyModelToPasskeyInfo(&passkeyModelList[i]))
+	}
+	return passkeyInfoList
+}
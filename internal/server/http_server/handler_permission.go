@@ -0,0 +1,214 @@
+package http_server
+
+import (
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/rs/zerolog/log"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/service"
+	"github.com/ssup2ket/service-auth/internal/server/errors"
+	"github.com/ssup2ket/service-auth/internal/server/request"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+// List permissions
+func (s *ServerHTTP) GetPermissions(w http.ResponseWriter, r *http.Request, params GetPermissionsParams) {
+	ctx := r.Context()
+
+	// Set offset, limit
+	offset := 0
+	if params.Offset != nil {
+		offset = int(*params.Offset)
+	}
+	limit := 0
+	if params.Limit != nil {
+		limit = int(*params.Limit)
+	}
+
+	// List permissions
+	permissionModels, err := s.domain.Permission.ListPermission(ctx, offset, limit)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to list permissions")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	permissions := []PermissionInfo{}
+	for i := range permissionModels {
+		permissions = append(permissions, *permissionModelToPermissionInfo(&permissionModels[i]))
+	}
+	render.JSON(w, r, PermissionInfoList{
+		Permissions: permissions,
+	})
+}
+
+// Create a permission
+func (s *ServerHTTP) PostPermissions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	permissionCreate := PermissionCreate{}
+
+	// Unmarshal request
+	if err := render.Bind(r, &permissionCreate); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong create permission request")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+
+	// Create permission
+	permission, err := s.domain.Permission.CreatePermission(ctx, permissionCreateToPermissionModel(&permissionCreate))
+	if err != nil {
+		if err == service.ErrRoleNotExist {
+			log.Ctx(ctx).Error().Err(err).Msg("Role of subject doesn't exist")
+			render.Render(w, r, getErrRendererRoleNotExist())
+			return
+		} else if err == service.ErrRepoConflict {
+			log.Ctx(ctx).Error().Err(err).Msg("Permission already exists")
+			render.Render(w, r, getErrRendererConflict(errors.ErrResoucePermission))
+			return
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to create permission")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, permissionModelToPermissionInfo(permission))
+}
+
+// Get a permission
+func (s *ServerHTTP) GetPermissionsPermissionID(w http.ResponseWriter, r *http.Request, permissionID PermissionID) {
+	ctx := r.Context()
+
+	// Validate request
+	if err := permissionID.Validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong permission ID")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+
+	// Get permission
+	permission, err := s.domain.Permission.GetPermission(ctx, uuid.FromStringOrNil(string(permissionID)))
+	if err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("Permission doesn't exist")
+			render.Render(w, r, getErrRendererNotFound(errors.ErrResoucePermission))
+			return
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get permission")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, permissionModelToPermissionInfo(permission))
+}
+
+// Update a permission
+func (s *ServerHTTP) PutPermissionsPermissionID(w http.ResponseWriter, r *http.Request, permissionID PermissionID) {
+	ctx := r.Context()
+	permissionUpdate := PermissionUpdate{}
+
+	// Unmarshal request
+	if err := render.Bind(r, &permissionUpdate); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong update permission request")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+
+	// Validate request
+	if err := permissionID.Validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong permission ID")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+
+	// Update permission
+	err := s.domain.Permission.UpdatePermission(ctx, permissionUpdateToPermissionModel(string(permissionID), &permissionUpdate))
+	if err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("Permission doesn't exist")
+			render.Render(w, r, getErrRendererNotFound(errors.ErrResoucePermission))
+			return
+		} else if err == service.ErrRoleNotExist {
+			log.Ctx(ctx).Error().Err(err).Msg("Role of subject doesn't exist")
+			render.Render(w, r, getErrRendererRoleNotExist())
+			return
+		} else if err == service.ErrRepoConflict {
+			log.Ctx(ctx).Error().Err(err).Msg("Permission already exists")
+			render.Render(w, r, getErrRendererConflict(errors.ErrResoucePermission))
+			return
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to update permission")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, nil)
+}
+
+// Delete a permission
+func (s *ServerHTTP) DeletePermissionsPermissionID(w http.ResponseWriter, r *http.Request, permissionID PermissionID) {
+	ctx := r.Context()
+
+	// Validate request
+	if err := permissionID.Validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong permission ID")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+
+	// Delete permission
+	if err := s.domain.Permission.DeletePermission(ctx, uuid.FromStringOrNil(string(permissionID))); err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("Permission doesn't exist")
+			render.Render(w, r, getErrRendererNotFound(errors.ErrResoucePermission))
+			return
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete permission")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, nil)
+}
+
+// Validate & Bind
+func (p *PermissionID) Validate() error {
+	return request.ValidatePermissionUUID(string(*p))
+}
+
+func (p *PermissionCreate) Bind(r *http.Request) error {
+	return request.ValidatePermissionCreate(p.Subject, p.Object, p.Action)
+}
+
+func (p *PermissionUpdate) Bind(r *http.Request) error {
+	return request.ValidatePermissionUpdate("", p.Subject, p.Object, p.Action)
+}
+
+// DTO <-> Model
+func permissionCreateToPermissionModel(permissionCreate *PermissionCreate) *entity.Permission {
+	return &entity.Permission{
+		Subject: permissionCreate.Subject,
+		Object:  permissionCreate.Object,
+		Action:  permissionCreate.Action,
+	}
+}
+
+func permissionUpdateToPermissionModel(permissionID string, permissionUpdate *PermissionUpdate) *entity.Permission {
+	return &entity.Permission{
+		ID:      uuid.FromStringOrNil(permissionID),
+		Subject: permissionUpdate.Subject,
+		Object:  permissionUpdate.Object,
+		Action:  permissionUpdate.Action,
+	}
+}
+
+func permissionModelToPermissionInfo(permissionModel *entity.Permission) *PermissionInfo {
+	return &PermissionInfo{
+		Id:        permissionModel.ID.String(),
+		Subject:   permissionModel.Subject,
+		Object:    permissionModel.Object,
+		Action:    permissionModel.Action,
+		CreatedAt: permissionModel.CreatedAt,
+	}
+}
@@ -0,0 +1,216 @@
+package http_server
+
+import (
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/rs/zerolog/log"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/service"
+	"github.com/ssup2ket/service-auth/internal/server/errors"
+	"github.com/ssup2ket/service-auth/internal/server/request"
+)
+
+// List roles
+func (s *ServerHTTP) GetRoles(w http.ResponseWriter, r *http.Request, params GetRolesParams) {
+	ctx := r.Context()
+
+	// Set offset, limit
+	offset := 0
+	if params.Offset != nil {
+		offset = int(*params.Offset)
+	}
+	limit := 0
+	if params.Limit != nil {
+		limit = int(*params.Limit)
+	}
+
+	// List roles
+	roleModels, err := s.domain.Role.ListRole(ctx, offset, limit)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to list roles")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	roles := []RoleInfo{}
+	for i := range roleModels {
+		roles = append(roles, *roleModelToRoleInfo(&roleModels[i]))
+	}
+	render.JSON(w, r, RoleInfoList{
+		Roles: roles,
+	})
+}
+
+// Create a role
+func (s *ServerHTTP) PostRoles(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	roleCreate := RoleCreate{}
+
+	// Unmarshal request
+	if err := render.Bind(r, &roleCreate); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong create role request")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+
+	// Create role
+	role, err := s.domain.Role.CreateRole(ctx, roleCreateToRoleModel(&roleCreate))
+	if err != nil {
+		if err == service.ErrRepoConflict {
+			log.Ctx(ctx).Error().Err(err).Msg("Role already exists")
+			render.Render(w, r, getErrRendererConflict(errors.ErrResouceRole))
+			return
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to create role")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, roleModelToRoleInfo(role))
+}
+
+// Get a role
+func (s *ServerHTTP) GetRolesRoleName(w http.ResponseWriter, r *http.Request, roleName RoleName) {
+	ctx := r.Context()
+
+	// Validate request
+	if err := roleName.Validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong role name")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+
+	// Get role
+	role, err := s.domain.Role.GetRole(ctx, string(roleName))
+	if err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("Role doesn't exist")
+			render.Render(w, r, getErrRendererNotFound(errors.ErrResouceRole))
+			return
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get role")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, roleModelToRoleInfo(role))
+}
+
+// Update a role
+func (s *ServerHTTP) PutRolesRoleName(w http.ResponseWriter, r *http.Request, roleName RoleName) {
+	ctx := r.Context()
+	roleUpdate := RoleUpdate{}
+
+	// Unmarshal request
+	if err := render.Bind(r, &roleUpdate); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong update role request")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+
+	// Validate request
+	if err := roleName.Validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong role name")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+
+	// Update role
+	if err := s.domain.Role.UpdateRole(ctx, roleUpdateToRoleModel(string(roleName), &roleUpdate)); err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("Role doesn't exist")
+			render.Render(w, r, getErrRendererNotFound(errors.ErrResouceRole))
+			return
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to update role")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, nil)
+}
+
+// Delete a role with its permissions
+func (s *ServerHTTP) DeleteRolesRoleName(w http.ResponseWriter, r *http.Request, roleName RoleName) {
+	ctx := r.Context()
+
+	// Validate request
+	if err := roleName.Validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong role name")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+
+	// Delete role
+	if err := s.domain.Role.DeleteRole(ctx, string(roleName)); err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("Role doesn't exist")
+			render.Render(w, r, getErrRendererNotFound(errors.ErrResouceRole))
+			return
+		} else if err == service.ErrRoleInUse {
+			log.Ctx(ctx).Error().Err(err).Msg("Role is in use")
+			render.Render(w, r, getErrRendererRoleInUse())
+			return
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete role")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, nil)
+}
+
+// Validate & Bind
+func (n *RoleName) Validate() error {
+	return request.ValidateRoleName(string(*n))
+}
+
+func (r *RoleCreate) Bind(req *http.Request) error {
+	return request.ValidateRoleCreate(r.Name, r.Description, r.Scopes, getIntOrZero(r.PasswordMaxAgeDays))
+}
+
+func (r *RoleUpdate) Bind(req *http.Request) error {
+	return request.ValidateRoleUpdate("", r.Description, r.Scopes, getIntOrZero(r.PasswordMaxAgeDays))
+}
+
+// DTO <-> Model
+func roleCreateToRoleModel(roleCreate *RoleCreate) *entity.Role {
+	return &entity.Role{
+		Name:        roleCreate.Name,
+		Description: roleCreate.Description,
+		Scopes:      roleCreate.Scopes,
+
+		PasswdMaxAgeDays: getIntOrZero(roleCreate.PasswordMaxAgeDays),
+	}
+}
+
+func roleUpdateToRoleModel(roleName string, roleUpdate *RoleUpdate) *entity.Role {
+	return &entity.Role{
+		Name:        roleName,
+		Description: roleUpdate.Description,
+		Scopes:      roleUpdate.Scopes,
+
+		PasswdMaxAgeDays: getIntOrZero(roleUpdate.PasswordMaxAgeDays),
+	}
+}
+
+func roleModelToRoleInfo(roleModel *entity.Role) *RoleInfo {
+	return &RoleInfo{
+		Name:        roleModel.Name,
+		Description: roleModel.Description,
+		Scopes:      []string(roleModel.Scopes),
+		CreatedAt:   roleModel.CreatedAt,
+
+		PasswordMaxAgeDays: roleModel.PasswdMaxAgeDays,
+	}
+}
+
+// Get the value of an optional integer. No value means 0.
+func getIntOrZero(i *int) int {
+	if i == nil {
+		return 0
+	}
+	return *i
+}
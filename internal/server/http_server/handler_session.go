@@ -0,0 +1,100 @@
+package http_server
+
+import (
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/rs/zerolog/log"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/server/middleware"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+// List my sessions
+func (s *ServerHTTP) GetUsersMeSessions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Get user ID, session ID
+	userID, err := middleware.GetUserIDFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No user ID in context")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+	sessionID, _ := middleware.GetSessionIDFromCtx(ctx)
+
+	// List sessions
+	sessions, err := s.domain.Session.ListSession(ctx, uuid.FromStringOrNil(userID))
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to list sessions")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, SessionInfoList{
+		Sessions: SessionModelListToSessionInfoList(sessions, sessionID),
+	})
+}
+
+// Logout from all devices
+func (s *ServerHTTP) DeleteUsersMeSessions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Get user ID
+	userID, err := middleware.GetUserIDFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No user ID in context")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	// Revoke sessions
+	if err := s.domain.Session.RevokeUserSessions(ctx, uuid.FromStringOrNil(userID)); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to revoke sessions")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, nil)
+}
+
+// Revoke all sessions of a user
+func (s *ServerHTTP) DeleteUsersUserIDSessions(w http.ResponseWriter, r *http.Request, userID UserID) {
+	ctx := r.Context()
+
+	// Validate request
+	if err := userID.Validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong user ID")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+
+	// Revoke sessions
+	if err := s.domain.Session.RevokeUserSessions(ctx, uuid.FromStringOrNil(string(userID))); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to revoke sessions")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, nil)
+}
+
+// DTO <-> Model
+func SessionModelListToSessionInfoList(sessionModelList []entity.Session, currentSessionID string) []SessionInfo {
+	sessionInfos := []SessionInfo{}
+	for _, sessionModel := range sessionModelList {
+		tmp := SessionInfo{
+			Id:         sessionModel.ID.String(),
+			DeviceName: sessionModel.DeviceName,
+			UserAgent:  sessionModel.UserAgent,
+			Ip:         sessionModel.IP,
+			CreatedAt:  sessionModel.CreatedAt,
+			LastUsedAt: sessionModel.LastUsedAt,
+			ExpiresAt:  sessionModel.ExpiresAt,
+			Current:    sessionModel.ID.String() == currentSessionID,
+		}
+		sessionInfos = append(sessionInfos, tmp)
+	}
+	return sessionInfos
+}
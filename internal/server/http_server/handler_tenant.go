@@ -0,0 +1,199 @@
+package http_server
+
+import (
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/rs/zerolog/log"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/service"
+	"github.com/ssup2ket/service-auth/internal/server/errors"
+	"github.com/ssup2ket/service-auth/internal/server/request"
+)
+
+// List tenants
+func (s *ServerHTTP) GetTenants(w http.ResponseWriter, r *http.Request, params GetTenantsParams) {
+	ctx := r.Context()
+
+	// Set offset, limit
+	offset := 0
+	if params.Offset != nil {
+		offset = int(*params.Offset)
+	}
+	limit := 0
+	if params.Limit != nil {
+		limit = int(*params.Limit)
+	}
+
+	// List tenants
+	tenantModels, err := s.domain.Tenant.ListTenant(ctx, offset, limit)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to list tenants")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	tenants := []TenantInfo{}
+	for i := range tenantModels {
+		tenants = append(tenants, *tenantModelToTenantInfo(&tenantModels[i]))
+	}
+	render.JSON(w, r, TenantInfoList{
+		Tenants: tenants,
+	})
+}
+
+// Create a tenant
+func (s *ServerHTTP) PostTenants(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tenantCreate := TenantCreate{}
+
+	// Unmarshal request
+	if err := render.Bind(r, &tenantCreate); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong create tenant request")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+
+	// Create tenant
+	tenant, err := s.domain.Tenant.CreateTenant(ctx, tenantCreateToTenantModel(&tenantCreate))
+	if err != nil {
+		if err == service.ErrRepoConflict {
+			log.Ctx(ctx).Error().Err(err).Msg("Tenant already exists")
+			render.Render(w, r, getErrRendererConflict(errors.ErrResouceTenant))
+			return
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to create tenant")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, tenantModelToTenantInfo(tenant))
+}
+
+// Get a tenant
+func (s *ServerHTTP) GetTenantsTenantID(w http.ResponseWriter, r *http.Request, tenantID TenantID) {
+	ctx := r.Context()
+
+	// Validate request
+	if err := tenantID.Validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong tenant ID")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+
+	// Get tenant
+	tenant, err := s.domain.Tenant.GetTenant(ctx, string(tenantID))
+	if err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("Tenant doesn't exist")
+			render.Render(w, r, getErrRendererNotFound(errors.ErrResouceTenant))
+			return
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to get tenant")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, tenantModelToTenantInfo(tenant))
+}
+
+// Update a tenant
+func (s *ServerHTTP) PutTenantsTenantID(w http.ResponseWriter, r *http.Request, tenantID TenantID) {
+	ctx := r.Context()
+	tenantUpdate := TenantUpdate{}
+
+	// Unmarshal request
+	if err := render.Bind(r, &tenantUpdate); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong update tenant request")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+
+	// Validate request
+	if err := tenantID.Validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong tenant ID")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+
+	// Update tenant
+	if err := s.domain.Tenant.UpdateTenant(ctx, tenantUpdateToTenantModel(string(tenantID), &tenantUpdate)); err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("Tenant doesn't exist")
+			render.Render(w, r, getErrRendererNotFound(errors.ErrResouceTenant))
+			return
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to update tenant")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, nil)
+}
+
+// Delete a tenant without users
+func (s *ServerHTTP) DeleteTenantsTenantID(w http.ResponseWriter, r *http.Request, tenantID TenantID) {
+	ctx := r.Context()
+
+	// Validate request
+	if err := tenantID.Validate(); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong tenant ID")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+
+	// Delete tenant
+	if err := s.domain.Tenant.DeleteTenant(ctx, string(tenantID)); err != nil {
+		if err == service.ErrRepoNotFound {
+			log.Ctx(ctx).Error().Err(err).Msg("Tenant doesn't exist")
+			render.Render(w, r, getErrRendererNotFound(errors.ErrResouceTenant))
+			return
+		} else if err == service.ErrTenantInUse {
+			log.Ctx(ctx).Error().Err(err).Msg("Tenant is in use")
+			render.Render(w, r, getErrRendererTenantInUse())
+			return
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete tenant")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, nil)
+}
+
+// Validate & Bind
+func (i *TenantID) Validate() error {
+	return request.ValidateTenantID(string(*i))
+}
+
+func (t *TenantCreate) Bind(req *http.Request) error {
+	return request.ValidateTenantCreate(t.Id, t.Description)
+}
+
+func (t *TenantUpdate) Bind(req *http.Request) error {
+	return request.ValidateTenantUpdate("", t.Description)
+}
+
+// DTO <-> Model
+func tenantCreateToTenantModel(tenantCreate *TenantCreate) *entity.Tenant {
+	return &entity.Tenant{
+		ID:          tenantCreate.Id,
+		Description: tenantCreate.Description,
+	}
+}
+
+func tenantUpdateToTenantModel(tenantID string, tenantUpdate *TenantUpdate) *entity.Tenant {
+	return &entity.Tenant{
+		ID:          tenantID,
+		Description: tenantUpdate.Description,
+	}
+}
+
+func tenantModelToTenantInfo(tenantModel *entity.Tenant) *TenantInfo {
+	return &TenantInfo{
+		Id:          tenantModel.ID,
+		Description: tenantModel.Description,
+		CreatedAt:   tenantModel.CreatedAt,
+	}
+}
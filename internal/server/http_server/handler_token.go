@@ -1,16 +1,25 @@
 package http_server
 
 import (
+	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/go-chi/render"
 	"github.com/rs/zerolog/log"
 
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
 	"github.com/ssup2ket/service-auth/internal/domain/service"
+	"github.com/ssup2ket/service-auth/internal/server/errors"
+	"github.com/ssup2ket/service-auth/internal/server/middleware"
+	"github.com/ssup2ket/service-auth/internal/server/request"
+	authtoken "github.com/ssup2ket/service-auth/pkg/auth/token"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
 )
 
 // Login
-func (s *ServerHTTP) PostTokensLogin(w http.ResponseWriter, r *http.Request) {
+func (s *ServerHTTP) PostTokensLogin(w http.ResponseWriter, r *http.Request, params PostTokensLoginParams) {
 	ctx := r.Context()
 
 	// Get login ID and password
@@ -21,10 +30,44 @@ func (s *ServerHTTP) PostTokensLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Get session info
+	session := entity.Session{
+		UserAgent: r.UserAgent(),
+		IP:        getClientIP(r),
+	}
+	if params.XDeviceName != nil {
+		session.DeviceName = string(*params.XDeviceName)
+	}
+	if params.Scope != nil {
+		session.Scopes = strings.Fields(string(*params.Scope))
+	}
+
+	// Get audience
+	audience := ""
+	if params.Audience != nil {
+		audience = string(*params.Audience)
+	}
+
+	// Get tenant ID
+	tenantID, err := middleware.GetTenantIDFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No tenant ID in context")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
 	// Create token
-	accTokenInfo, refTokenInfo, err := s.domain.Token.CreateTokens(ctx, loginID, password)
+	accTokenInfo, refTokenInfo, err := s.domain.Token.CreateTokens(ctx, tenantID, loginID, password, &session, audience)
 	if err != nil {
-		if err == service.ErrRepoNotFound {
+		if err == service.ErrTokenAudienceNotAllowed {
+			log.Ctx(ctx).Error().Err(err).Msg("Wrong audience")
+			render.Render(w, r, getErrRendererTokenAudienceNotAllowed())
+			return
+		} else if err == service.ErrTokenScopeNotAllowed {
+			log.Ctx(ctx).Error().Err(err).Msg("Wrong scope")
+			render.Render(w, r, getErrRendererTokenScopeNotAllowed())
+			return
+		} else if err == service.ErrRepoNotFound {
 			log.Ctx(ctx).Error().Err(err).Msg("ID doesn't exists")
 			render.Render(w, r, getErrRendererUnauthorized())
 			return
@@ -32,6 +75,49 @@ func (s *ServerHTTP) PostTokensLogin(w http.ResponseWriter, r *http.Request) {
 			log.Ctx(ctx).Error().Err(err).Msg("Wrong ID/password")
 			render.Render(w, r, getErrRendererUnauthorized())
 			return
+		} else if err == service.ErrLoginLocked {
+			log.Ctx(ctx).Error().Err(err).Msg("Login is locked")
+			render.Render(w, r, getErrRendererLoginLocked())
+			return
+		} else if err == service.ErrPasswdExpired {
+			log.Ctx(ctx).Error().Err(err).Msg("Password is expired")
+			render.Status(r, http.StatusForbidden)
+			render.JSON(w, r, TokenPasswdExpired{
+				Code:    errors.CodePasswdExpired,
+				Message: errors.MsgPasswdExpired,
+				AccessToken: TokenInfo{
+					Token:     accTokenInfo.Token,
+					IssuedAt:  accTokenInfo.IssuedAt,
+					ExpiresAt: accTokenInfo.ExpiresAt,
+				},
+			})
+			return
+		} else if err == service.ErrEmailNotVerified {
+			log.Ctx(ctx).Error().Err(err).Msg("Email isn't verified")
+			render.Status(r, http.StatusForbidden)
+			render.JSON(w, r, TokenEmailNotVerified{
+				Code:    errors.CodeEmailNotVerified,
+				Message: errors.MsgEmailNotVerified,
+				AccessToken: TokenInfo{
+					Token:     accTokenInfo.Token,
+					IssuedAt:  accTokenInfo.IssuedAt,
+					ExpiresAt: accTokenInfo.ExpiresAt,
+				},
+			})
+			return
+		} else if err == service.ErrMFARequired {
+			log.Ctx(ctx).Info().Msg("MFA is required")
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, TokenMFARequired{
+				Code:    errors.CodeMFARequired,
+				Message: errors.MsgMFARequired,
+				MfaToken: TokenInfo{
+					Token:     accTokenInfo.Token,
+					IssuedAt:  accTokenInfo.IssuedAt,
+					ExpiresAt: accTokenInfo.ExpiresAt,
+				},
+			})
+			return
 		}
 		log.Ctx(ctx).Error().Err(err).Msg("Failed to create access, refresh tokens")
 		render.Render(w, r, getErrRendererServerError())
@@ -52,6 +138,227 @@ func (s *ServerHTTP) PostTokensLogin(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// Exchange a MFA challenge token of the login and a MFA code for tokens
+func (s *ServerHTTP) PostTokensMfa(w http.ResponseWriter, r *http.Request, params PostTokensMfaParams) {
+	ctx := r.Context()
+	tokenMFA := TokenMFA{}
+
+	// Unmarshal request
+	if err := render.Bind(r, &tokenMFA); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong MFA token request")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+
+	// Get session info
+	session := entity.Session{
+		UserAgent: r.UserAgent(),
+		IP:        getClientIP(r),
+	}
+	if params.XDeviceName != nil {
+		session.DeviceName = string(*params.XDeviceName)
+	}
+
+	// Create token
+	accTokenInfo, refTokenInfo, err := s.domain.Token.CreateMFATokens(ctx, tokenMFA.MfaToken, tokenMFA.Code, &session)
+	if err != nil {
+		if err == service.ErrTokenScopeNotAllowed {
+			log.Ctx(ctx).Error().Err(err).Msg("Wrong scope")
+			render.Render(w, r, getErrRendererTokenScopeNotAllowed())
+			return
+		} else if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Wrong MFA token")
+			render.Render(w, r, getErrRendererUnauthorized())
+			return
+		} else if err == service.ErrMFACodeWrong {
+			log.Ctx(ctx).Error().Err(err).Msg("Wrong MFA code")
+			render.Render(w, r, getErrRendererMFACodeWrong())
+			return
+		} else if err == service.ErrLoginLocked {
+			log.Ctx(ctx).Error().Err(err).Msg("Login is locked")
+			render.Render(w, r, getErrRendererLoginLocked())
+			return
+		} else if err == service.ErrPasswdExpired {
+			log.Ctx(ctx).Error().Err(err).Msg("Password is expired")
+			render.Status(r, http.StatusForbidden)
+			render.JSON(w, r, TokenPasswdExpired{
+				Code:    errors.CodePasswdExpired,
+				Message: errors.MsgPasswdExpired,
+				AccessToken: TokenInfo{
+					Token:     accTokenInfo.Token,
+					IssuedAt:  accTokenInfo.IssuedAt,
+					ExpiresAt: accTokenInfo.ExpiresAt,
+				},
+			})
+			return
+		} else if err == service.ErrEmailNotVerified {
+			log.Ctx(ctx).Error().Err(err).Msg("Email isn't verified")
+			render.Status(r, http.StatusForbidden)
+			render.JSON(w, r, TokenEmailNotVerified{
+				Code:    errors.CodeEmailNotVerified,
+				Message: errors.MsgEmailNotVerified,
+				AccessToken: TokenInfo{
+					Token:     accTokenInfo.Token,
+					IssuedAt:  accTokenInfo.IssuedAt,
+					ExpiresAt: accTokenInfo.ExpiresAt,
+				},
+			})
+			return
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to create access, refresh tokens with MFA")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, TokenInfos{
+		AccessToken: TokenInfo{
+			Token:     accTokenInfo.Token,
+			IssuedAt:  accTokenInfo.IssuedAt,
+			ExpiresAt: accTokenInfo.ExpiresAt,
+		},
+		RefreshToken: TokenInfo{
+			Token:     refTokenInfo.Token,
+			IssuedAt:  refTokenInfo.IssuedAt,
+			ExpiresAt: refTokenInfo.ExpiresAt,
+		},
+	})
+}
+
+// Begin a passkey login. The options are passed to navigator.credentials.get().
+func (s *ServerHTTP) PostTokensPasskeyBegin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Get tenant ID
+	tenantID, err := middleware.GetTenantIDFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No tenant ID in context")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	// Begin login
+	login, err := s.domain.Token.BeginPasskeyLogin(ctx, tenantID)
+	if err != nil {
+		if err == service.ErrPasskeyDisabled {
+			log.Ctx(ctx).Error().Err(err).Msg("Passkey is disabled")
+			render.Render(w, r, getErrRendererPasskeyDisabled())
+			return
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to begin passkey login")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	passkeyOptions, err := getPasskeyOptions(login.ChallengeID, login.Options)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to encode passkey login options")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+	render.JSON(w, r, passkeyOptions)
+}
+
+// Finish a passkey login with the credential of navigator.credentials.get() and create tokens like login
+func (s *ServerHTTP) PostTokensPasskeyFinish(w http.ResponseWriter, r *http.Request, params PostTokensPasskeyFinishParams) {
+	ctx := r.Context()
+	passkeyLoginFinish := PasskeyLoginFinish{}
+
+	// Unmarshal request
+	if err := render.Bind(r, &passkeyLoginFinish); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong finish passkey login request")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+	assertion, err := passkeyAssertionCredentialToPasskeyAssertionModel(&passkeyLoginFinish.Credential)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong passkey assertion")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+
+	// Get session info
+	session := entity.Session{
+		UserAgent: r.UserAgent(),
+		IP:        getClientIP(r),
+	}
+	if params.XDeviceName != nil {
+		session.DeviceName = string(*params.XDeviceName)
+	}
+	if params.Scope != nil {
+		session.Scopes = strings.Fields(string(*params.Scope))
+	}
+
+	// Get audience
+	audience := ""
+	if params.Audience != nil {
+		audience = string(*params.Audience)
+	}
+
+	// Get tenant ID
+	tenantID, err := middleware.GetTenantIDFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No tenant ID in context")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	// Create token
+	accTokenInfo, refTokenInfo, err := s.domain.Token.CreatePasskeyTokens(ctx, tenantID,
+		uuid.FromStringOrNil(passkeyLoginFinish.ChallengeId), assertion, &session, audience)
+	if err != nil {
+		if err == service.ErrTokenAudienceNotAllowed {
+			log.Ctx(ctx).Error().Err(err).Msg("Wrong audience")
+			render.Render(w, r, getErrRendererTokenAudienceNotAllowed())
+			return
+		} else if err == service.ErrTokenScopeNotAllowed {
+			log.Ctx(ctx).Error().Err(err).Msg("Wrong scope")
+			render.Render(w, r, getErrRendererTokenScopeNotAllowed())
+			return
+		} else if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Wrong passkey or challenge")
+			render.Render(w, r, getErrRendererUnauthorized())
+			return
+		} else if err == service.ErrLoginLocked {
+			log.Ctx(ctx).Error().Err(err).Msg("Login is locked")
+			render.Render(w, r, getErrRendererLoginLocked())
+			return
+		} else if err == service.ErrPasskeyDisabled {
+			log.Ctx(ctx).Error().Err(err).Msg("Passkey is disabled")
+			render.Render(w, r, getErrRendererPasskeyDisabled())
+			return
+		} else if err == service.ErrEmailNotVerified {
+			log.Ctx(ctx).Error().Err(err).Msg("Email isn't verified")
+			render.Status(r, http.StatusForbidden)
+			render.JSON(w, r, TokenEmailNotVerified{
+				Code:    errors.CodeEmailNotVerified,
+				Message: errors.MsgEmailNotVerified,
+				AccessToken: TokenInfo{
+					Token:     accTokenInfo.Token,
+					IssuedAt:  accTokenInfo.IssuedAt,
+					ExpiresAt: accTokenInfo.ExpiresAt,
+				},
+			})
+			return
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to create access, refresh tokens with passkey")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, TokenInfos{
+		AccessToken: TokenInfo{
+			Token:     accTokenInfo.Token,
+			IssuedAt:  accTokenInfo.IssuedAt,
+			ExpiresAt: accTokenInfo.ExpiresAt,
+		},
+		RefreshToken: TokenInfo{
+			Token:     refTokenInfo.Token,
+			IssuedAt:  refTokenInfo.IssuedAt,
+			ExpiresAt: refTokenInfo.ExpiresAt,
+		},
+	})
+}
+
 func (s *ServerHTTP) PostTokensRefresh(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	tokenRefresh := TokenRefresh{}
@@ -64,25 +371,167 @@ func (s *ServerHTTP) PostTokensRefresh(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Refresh token
-	refTokenInfo, err := s.domain.Token.RefreshToken(ctx, tokenRefresh.RefreshToken)
+	accTokenInfo, refTokenInfo, err := s.domain.Token.RefreshToken(ctx, tokenRefresh.RefreshToken)
 	if err != nil {
 		if err == service.ErrUnauthorized {
 			log.Ctx(ctx).Error().Err(err).Msg("Wrong refresh token")
 			render.Render(w, r, getErrRendererUnauthorized())
 			return
+		} else if err == service.ErrPasswdExpired {
+			log.Ctx(ctx).Error().Err(err).Msg("Password is expired")
+			render.Status(r, http.StatusForbidden)
+			render.JSON(w, r, TokenPasswdExpired{
+				Code:    errors.CodePasswdExpired,
+				Message: errors.MsgPasswdExpired,
+				AccessToken: TokenInfo{
+					Token:     accTokenInfo.Token,
+					IssuedAt:  accTokenInfo.IssuedAt,
+					ExpiresAt: accTokenInfo.ExpiresAt,
+				},
+			})
+			return
+		} else if err == service.ErrEmailNotVerified {
+			log.Ctx(ctx).Error().Err(err).Msg("Email isn't verified")
+			render.Status(r, http.StatusForbidden)
+			render.JSON(w, r, TokenEmailNotVerified{
+				Code:    errors.CodeEmailNotVerified,
+				Message: errors.MsgEmailNotVerified,
+				AccessToken: TokenInfo{
+					Token:     accTokenInfo.Token,
+					IssuedAt:  accTokenInfo.IssuedAt,
+					ExpiresAt: accTokenInfo.ExpiresAt,
+				},
+			})
+			return
 		}
 		render.Render(w, r, getErrRendererServerError())
 		log.Ctx(ctx).Error().Err(err).Msg("Failed to refresh token")
 		return
 	}
 
-	render.JSON(w, r, TokenInfo{
-		Token:     refTokenInfo.Token,
-		IssuedAt:  refTokenInfo.IssuedAt,
-		ExpiresAt: refTokenInfo.ExpiresAt,
+	render.JSON(w, r, TokenInfos{
+		AccessToken: TokenInfo{
+			Token:     accTokenInfo.Token,
+			IssuedAt:  accTokenInfo.IssuedAt,
+			ExpiresAt: accTokenInfo.ExpiresAt,
+		},
+		RefreshToken: TokenInfo{
+			Token:     refTokenInfo.Token,
+			IssuedAt:  refTokenInfo.IssuedAt,
+			ExpiresAt: refTokenInfo.ExpiresAt,
+		},
 	})
 }
 
+// Logout by revoking the session of the access token
+func (s *ServerHTTP) PostTokensLogout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Get user ID, session ID, token ID
+	userID, err := middleware.GetUserIDFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No user ID in context")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+	sessionID, err := middleware.GetSessionIDFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No session ID in context")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+	tokenID, err := middleware.GetTokenIDFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No token ID in context")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	// Revoke access token
+	if err := s.domain.TokenRevocation.RevokeToken(ctx, tokenID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to revoke access token")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	// Revoke session. Session may be revoked already.
+	err = s.domain.Session.RevokeSession(ctx, uuid.FromStringOrNil(userID), uuid.FromStringOrNil(sessionID))
+	if err != nil && err != service.ErrRepoNotFound {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to revoke session")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, nil)
+}
+
+// Introspect an access token for other services
+func (s *ServerHTTP) PostTokensIntrospect(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tokenIntrospect := TokenIntrospect{}
+
+	// Unmarshal request
+	if err := render.Bind(r, &tokenIntrospect); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong introspect token request")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+
+	// Introspect token
+	claims, active, err := s.domain.TokenRevocation.IntrospectToken(ctx, tokenIntrospect.Token)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to introspect token")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	render.JSON(w, r, tokenClaimsToTokenIntrospection(claims, active))
+}
+
+// Validate & Bind
 func (u *TokenRefresh) Bind(r *http.Request) error {
 	return nil
 }
+
+func (t *TokenMFA) Bind(r *http.Request) error {
+	return request.ValidateTokenMFA(t.MfaToken, t.Code)
+}
+
+func (t *TokenIntrospect) Bind(r *http.Request) error {
+	if t.Token == "" {
+		return fmt.Errorf("no token")
+	}
+	return nil
+}
+
+// DTO <-> Model
+func tokenClaimsToTokenIntrospection(claims *authtoken.TokenClaims, active bool) *TokenIntrospection {
+	if !active {
+		return &TokenIntrospection{Active: false}
+	}
+
+	role := string(claims.UserRole)
+	issuedAt := time.Unix(claims.IssuedAt, 0)
+	expiresAt := time.Unix(claims.ExpiresAt, 0)
+	tokenIntrospection := TokenIntrospection{
+		Active:    true,
+		TokenId:   &claims.Id,
+		Subject:   &claims.Subject,
+		Issuer:    &claims.Issuer,
+		Audience:  &claims.Audience,
+		IssuedAt:  &issuedAt,
+		ExpiresAt: &expiresAt,
+	}
+	if claims.UserID != "" {
+		tokenIntrospection.UserId = &claims.UserID
+		tokenIntrospection.UserLoginId = &claims.UserLoginID
+		tokenIntrospection.Role = &role
+	}
+	if claims.ClientID != "" {
+		tokenIntrospection.ClientId = &claims.ClientID
+	}
+	if len(claims.Scopes) > 0 {
+		tokenIntrospection.Scopes = &claims.Scopes
+	}
+	return &tokenIntrospection
+}
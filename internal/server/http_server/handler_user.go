@@ -28,8 +28,16 @@ func (s *ServerHTTP) GetUsers(w http.ResponseWriter, r *http.Request, params Get
 		limit = int(*params.Limit)
 	}
 
+	// Get tenant ID
+	tenantID, err := middleware.GetTenantIDFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No tenant ID in context")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
 	// List user
-	userModels, err := s.domain.User.ListUser(ctx, offset, limit)
+	userModels, err := s.domain.User.ListUser(ctx, tenantID, offset, limit)
 	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Msg("Failed to list user")
 		render.Render(w, r, getErrRendererServerError())
@@ -51,13 +59,40 @@ func (s *ServerHTTP) PostUsers(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Get tenant ID
+	tenantID, err := middleware.GetTenantIDFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No tenant ID in context")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
+	// Get subject. A request without access token has no subject
+	subject, _ := middleware.GetSubjectFromCtx(ctx)
+
 	// Create user
-	user, err := s.domain.User.CreateUser(ctx, userCreateToUserInfoModel(&userCreate), userCreate.Password)
+	user, err := s.domain.User.CreateUser(ctx, subject, userCreateToUserInfoModel(tenantID, &userCreate), userCreate.Password)
 	if err != nil {
 		if err == service.ErrRepoConflict {
 			log.Ctx(ctx).Error().Err(err).Msg("Failed to create user becase of duplication")
 			render.Render(w, r, getErrRendererConflict(errors.ErrResouceUser))
 			return
+		} else if err == service.ErrRoleNotExist {
+			log.Ctx(ctx).Error().Err(err).Msg("Role doesn't exist")
+			render.Render(w, r, getErrRendererRoleNotExist())
+			return
+		} else if err == service.ErrTenantNotExist {
+			log.Ctx(ctx).Error().Err(err).Msg("Tenant doesn't exist")
+			render.Render(w, r, getErrRendererTenantNotExist())
+			return
+		} else if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Role or tenant isn't allowed to be granted")
+			render.Render(w, r, getErrRendererUnauthorized())
+			return
+		} else if service.IsPasswdPolicyErr(err) {
+			log.Ctx(ctx).Error().Err(err).Msg("Password violates password policy")
+			render.Render(w, r, getErrRendererPasswdPolicy(err))
+			return
 		}
 		log.Ctx(ctx).Error().Err(err).Msg("Failed to create user")
 		render.Render(w, r, getErrRendererServerError())
@@ -78,13 +113,25 @@ func (s *ServerHTTP) GetUsersUserID(w http.ResponseWriter, r *http.Request, user
 		return
 	}
 
+	// Get subject
+	subject, err := middleware.GetSubjectFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No subject in context")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
 	// Get user
-	userInfo, err := s.domain.User.GetUser(ctx, uuid.FromStringOrNil(string(userID)))
+	userInfo, err := s.domain.User.GetUser(ctx, subject, uuid.FromStringOrNil(string(userID)))
 	if err != nil {
 		if err == service.ErrRepoNotFound {
 			log.Ctx(ctx).Error().Err(err).Msg("User doesn't exist")
 			render.Render(w, r, getErrRendererNotFound(errors.ErrResouceUser))
 			return
+		} else if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("User isn't allowed to be accessed")
+			render.Render(w, r, getErrRendererUnauthorized())
+			return
 		}
 		log.Ctx(ctx).Error().Err(err).Msg("Failed to get user")
 		render.Render(w, r, getErrRendererServerError())
@@ -113,12 +160,32 @@ func (s *ServerHTTP) PutUsersUserID(w http.ResponseWriter, r *http.Request, user
 		return
 	}
 
+	// Get subject
+	subject, err := middleware.GetSubjectFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No subject in context")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
 	// Update user
-	if err := s.domain.User.UpdateUser(ctx, userUpdateToUserInfoModel(string(userID), &userUpdate), userUpdate.Password); err != nil {
+	if err := s.domain.User.UpdateUser(ctx, subject, userUpdateToUserInfoModel(string(userID), &userUpdate), userUpdate.getPasswd()); err != nil {
 		if err == service.ErrRepoNotFound {
 			log.Ctx(ctx).Error().Err(err).Msg("User doesn't exist")
 			render.Render(w, r, getErrRendererNotFound(errors.ErrResouceUser))
 			return
+		} else if err == service.ErrRoleNotExist {
+			log.Ctx(ctx).Error().Err(err).Msg("Role doesn't exist")
+			render.Render(w, r, getErrRendererRoleNotExist())
+			return
+		} else if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("User isn't allowed to be accessed")
+			render.Render(w, r, getErrRendererUnauthorized())
+			return
+		} else if service.IsPasswdPolicyErr(err) {
+			log.Ctx(ctx).Error().Err(err).Msg("Password violates password policy")
+			render.Render(w, r, getErrRendererPasswdPolicy(err))
+			return
 		}
 		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete user")
 		render.Render(w, r, getErrRendererServerError())
@@ -139,12 +206,24 @@ func (s *ServerHTTP) DeleteUsersUserID(w http.ResponseWriter, r *http.Request, u
 		return
 	}
 
+	// Get subject
+	subject, err := middleware.GetSubjectFromCtx(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("No subject in context")
+		render.Render(w, r, getErrRendererServerError())
+		return
+	}
+
 	// Delete user
-	if err := s.domain.User.DeleteUser(ctx, uuid.FromStringOrNil(string(userID))); err != nil {
+	if err := s.domain.User.DeleteUser(ctx, subject, uuid.FromStringOrNil(string(userID))); err != nil {
 		if err == service.ErrRepoNotFound {
 			log.Ctx(ctx).Error().Err(err).Msg("User doesn't exist")
 			render.Render(w, r, getErrRendererNotFound(errors.ErrResouceUser))
 			return
+		} else if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("User isn't allowed to be accessed")
+			render.Render(w, r, getErrRendererUnauthorized())
+			return
 		}
 		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete user")
 		render.Render(w, r, getErrRendererServerError())
@@ -154,62 +233,115 @@ func (s *ServerHTTP) DeleteUsersUserID(w http.ResponseWriter, r *http.Request, u
 	render.JSON(w, r, nil)
 }
 
-// Get me
+// Get me. Alias of getting the user of the access token.
 func (s *ServerHTTP) GetUsersMe(w http.ResponseWriter, r *http.Request) {
+	if userID, ok := getUserMeID(w, r); ok {
+		s.GetUsersUserID(w, r, userID)
+	}
+}
+
+// Update me. Alias of updating the user of the access token.
+func (s *ServerHTTP) PutUsersMe(w http.ResponseWriter, r *http.Request) {
+	if userID, ok := getUserMeID(w, r); ok {
+		s.PutUsersUserID(w, r, userID)
+	}
+}
+
+// Delete me. Alias of deleting the user of the access token.
+func (s *ServerHTTP) DeleteUsersMe(w http.ResponseWriter, r *http.Request) {
+	if userID, ok := getUserMeID(w, r); ok {
+		s.DeleteUsersUserID(w, r, userID)
+	}
+}
+
+// Change my password with the current password. Tokens issued for expired passwords can call only this API.
+func (s *ServerHTTP) PutUsersMePassword(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	userPasswdUpdate := UserPasswdUpdate{}
 
-	// Get user ID
-	userID, err := middleware.GetUserIDFromCtx(ctx)
+	// Unmarshal request
+	if err := render.Bind(r, &userPasswdUpdate); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong update user password request")
+		render.Render(w, r, getErrRendererBadRequest())
+		return
+	}
+
+	// Get user ID and subject
+	userID, ok := getUserMeID(w, r)
+	if !ok {
+		return
+	}
+	subject, err := middleware.GetSubjectFromCtx(ctx)
 	if err != nil {
-		log.Ctx(ctx).Error().Err(err).Msg("No user ID in context")
+		log.Ctx(ctx).Error().Err(err).Msg("No subject in context")
 		render.Render(w, r, getErrRendererServerError())
 		return
 	}
 
-	// Get user
-	userInfo, err := s.domain.User.GetUser(ctx, uuid.FromStringOrNil(string(userID)))
-	if err != nil {
+	// Update password
+	if err := s.domain.User.UpdateUserPasswd(ctx, subject, uuid.FromStringOrNil(string(userID)), userPasswdUpdate.Password,
+		userPasswdUpdate.NewPassword); err != nil {
 		if err == service.ErrRepoNotFound {
-			log.Ctx(ctx).Error().Msg("User doesn't exist")
+			log.Ctx(ctx).Error().Err(err).Msg("User doesn't exist")
 			render.Render(w, r, getErrRendererNotFound(errors.ErrResouceUser))
 			return
+		} else if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Wrong password or user isn't allowed to be accessed")
+			render.Render(w, r, getErrRendererUnauthorized())
+			return
+		} else if service.IsPasswdPolicyErr(err) {
+			log.Ctx(ctx).Error().Err(err).Msg("Password violates password policy")
+			render.Render(w, r, getErrRendererPasswdPolicy(err))
+			return
 		}
-		log.Ctx(ctx).Error().Err(err).Msg("Failed to get user")
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to update user password")
 		render.Render(w, r, getErrRendererServerError())
 		return
 	}
 
-	render.JSON(w, r, UserModelToUserInfo(userInfo))
+	render.JSON(w, r, nil)
 }
 
-// Update me
-func (s *ServerHTTP) PutUsersMe(w http.ResponseWriter, r *http.Request) {
+// Remove my password to login only by passkeys
+func (s *ServerHTTP) PostUsersMePasswordRemove(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	userUpdate := UserUpdate{}
+	userPasswdRemove := UserPasswdRemove{}
 
 	// Unmarshal request
-	if err := render.Bind(r, &userUpdate); err != nil {
-		log.Ctx(ctx).Error().Err(err).Msg("Wrong update user request")
+	if err := render.Bind(r, &userPasswdRemove); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Wrong remove user password request")
 		render.Render(w, r, getErrRendererBadRequest())
 		return
 	}
 
-	// Get user ID
-	userID, err := middleware.GetUserIDFromCtx(ctx)
+	// Get user ID and subject
+	userID, ok := getUserMeID(w, r)
+	if !ok {
+		return
+	}
+	subject, err := middleware.GetSubjectFromCtx(ctx)
 	if err != nil {
-		log.Ctx(ctx).Error().Err(err).Msg("No user ID in context")
+		log.Ctx(ctx).Error().Err(err).Msg("No subject in context")
 		render.Render(w, r, getErrRendererServerError())
 		return
 	}
 
-	// Update user
-	if err := s.domain.User.UpdateUser(ctx, userUpdateToUserInfoModel(string(userID), &userUpdate), userUpdate.Password); err != nil {
+	// Remove password
+	if err := s.domain.User.RemoveUserPasswd(ctx, subject, uuid.FromStringOrNil(string(userID)), userPasswdRemove.Password); err != nil {
 		if err == service.ErrRepoNotFound {
 			log.Ctx(ctx).Error().Err(err).Msg("User doesn't exist")
 			render.Render(w, r, getErrRendererNotFound(errors.ErrResouceUser))
 			return
+		} else if err == service.ErrUnauthorized {
+			log.Ctx(ctx).Error().Err(err).Msg("Wrong password or user isn't allowed to be accessed")
+			render.Render(w, r, getErrRendererUnauthorized())
+			return
+		} else if err == service.ErrPasskeyRequired {
+			log.Ctx(ctx).Error().Err(err).Msg("User doesn't have passkey")
+			render.Render(w, r, getErrRendererPasskeyRequired())
+			return
 		}
-		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete user")
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to remove user password")
 		render.Render(w, r, getErrRendererServerError())
 		return
 	}
@@ -217,31 +349,21 @@ func (s *ServerHTTP) PutUsersMe(w http.ResponseWriter, r *http.Request) {
 	render.JSON(w, r, nil)
 }
 
-// Delete me
-func (s *ServerHTTP) DeleteUsersMe(w http.ResponseWriter, r *http.Request) {
+// Get the user ID of the access token. Access tokens of clients don't have user.
+func getUserMeID(w http.ResponseWriter, r *http.Request) (UserID, bool) {
 	ctx := r.Context()
 
-	// Get user ID
 	userID, err := middleware.GetUserIDFromCtx(ctx)
 	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Msg("No user ID in context")
 		render.Render(w, r, getErrRendererServerError())
-		return
-	}
-
-	// Delete user
-	if err := s.domain.User.DeleteUser(ctx, uuid.FromStringOrNil(string(userID))); err != nil {
-		if err == service.ErrRepoNotFound {
-			log.Ctx(ctx).Error().Err(err).Msg("User doesn't exist")
-			render.Render(w, r, getErrRendererNotFound(errors.ErrResouceUser))
-			return
-		}
-		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete user")
-		render.Render(w, r, getErrRendererServerError())
-		return
+		return "", false
+	} else if userID == "" {
+		log.Ctx(ctx).Error().Msg("No user in access token")
+		render.Render(w, r, getErrRendererUnauthorized())
+		return "", false
 	}
-
-	render.JSON(w, r, nil)
+	return UserID(userID), true
 }
 
 // Validate & Bind
@@ -254,16 +376,33 @@ func (u *UserCreate) Bind(r *http.Request) error {
 }
 
 func (u *UserUpdate) Bind(r *http.Request) error {
-	return request.ValidateUserUpdate("", u.Password, string(u.Role), u.Phone, u.Email)
+	return request.ValidateUserUpdate("", u.getPasswd(), string(u.Role), u.Phone, u.Email)
+}
+
+// Get the new password. Empty password means the password isn't changed.
+func (u *UserUpdate) getPasswd() string {
+	if u.Password == nil {
+		return ""
+	}
+	return *u.Password
+}
+
+func (u *UserPasswdUpdate) Bind(r *http.Request) error {
+	return request.ValidateUserPasswdUpdate(u.Password, u.NewPassword)
+}
+
+func (u *UserPasswdRemove) Bind(r *http.Request) error {
+	return request.ValidateUserPasswdRemove(u.Password)
 }
 
 // DTO <-> Model
-func userCreateToUserInfoModel(userCreate *UserCreate) *entity.UserInfo {
+func userCreateToUserInfoModel(tenantID string, userCreate *UserCreate) *entity.UserInfo {
 	return &entity.UserInfo{
-		LoginID: userCreate.LoginId,
-		Role:    entity.UserRole(userCreate.Role),
-		Phone:   userCreate.Phone,
-		Email:   userCreate.Email,
+		TenantID: tenantID,
+		LoginID:  userCreate.LoginId,
+		Role:     entity.UserRole(userCreate.Role),
+		Phone:    userCreate.Phone,
+		Email:    userCreate.Email,
 	}
 }
 
@@ -278,11 +417,15 @@ func userUpdateToUserInfoModel(userID string, userUpdate *UserUpdate) *entity.Us
 
 func UserModelToUserInfo(userModel *entity.UserInfo) *UserInfo {
 	return &UserInfo{
-		Id:      userModel.ID.String(),
-		LoginId: userModel.LoginID,
-		Role:    UserRole(userModel.Role),
-		Phone:   userModel.Phone,
-		Email:   userModel.Email,
+		Id:       userModel.ID.String(),
+		LoginId:  userModel.LoginID,
+		Role:     UserRole(userModel.Role),
+		Phone:    userModel.Phone,
+		Email:    userModel.Email,
+		TenantId: userModel.TenantID,
+
+		EmailVerified:   userModel.EmailVerified,
+		EmailVerifiedAt: userModel.EmailVerifiedAt,
 	}
 }
 
@@ -290,11 +433,15 @@ func UserModelListToUserInfoList(userModelList []entity.UserInfo) []UserInfo {
 	userInfos := []UserInfo{}
 	for _, userModel := range userModelList {
 		tmp := UserInfo{
-			Id:      userModel.ID.String(),
-			LoginId: userModel.LoginID,
-			Role:    UserRole(userModel.Role),
-			Phone:   userModel.Phone,
-			Email:   userModel.Email,
+			Id:       userModel.ID.String(),
+			LoginId:  userModel.LoginID,
+			Role:     UserRole(userModel.Role),
+			Phone:    userModel.Phone,
+			Email:    userModel.Email,
+			TenantId: userModel.TenantID,
+
+			EmailVerified:   userModel.EmailVerified,
+			EmailVerifiedAt: userModel.EmailVerifiedAt,
 		}
 		userInfos = append(userInfos, tmp)
 	}
@@ -23,12 +23,73 @@ const (
 	LoginScopes       = "Login.Scopes"
 )
 
+// EmailVerifyConfirm defines model for EmailVerifyConfirm.
+type EmailVerifyConfirm struct {
+
+	// Email verification token sent to the email
+	Token string `json:"token"`
+}
+
 // ErrorInfo defines model for ErrorInfo.
 type ErrorInfo struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
 }
 
+// GroupCreate defines model for GroupCreate.
+type GroupCreate struct {
+	Description string `json:"description"`
+	Name        string `json:"name"`
+
+	// Roles granted to all direct and nested members of the group
+	Roles []string `json:"roles"`
+}
+
+// GroupInfo defines model for GroupInfo.
+type GroupInfo struct {
+	CreatedAt   time.Time `json:"createdAt"`
+	Description string    `json:"description"`
+	Id          string    `json:"id"`
+	Name        string    `json:"name"`
+	Roles       []string  `json:"roles"`
+	TenantId    string    `json:"tenantId"`
+}
+
+// GroupInfoList defines model for GroupInfoList.
+type GroupInfoList struct {
+	Groups []GroupInfo `json:"groups"`
+}
+
+// GroupMemberCreate defines model for GroupMemberCreate.
+type GroupMemberCreate struct {
+
+	// ID of a user or a group in the same tenant
+	MemberId   string `json:"memberId"`
+	MemberType string `json:"memberType"`
+}
+
+// GroupMemberInfo defines model for GroupMemberInfo.
+type GroupMemberInfo struct {
+	CreatedAt  time.Time `json:"createdAt"`
+	GroupId    string    `json:"groupId"`
+	MemberId   string    `json:"memberId"`
+	MemberType string    `json:"memberType"`
+}
+
+// GroupMemberInfoList defines model for GroupMemberInfoList.
+type GroupMemberInfoList struct {
+	Members []GroupMemberInfo `json:"members"`
+}
+
+// GroupUpdate defines model for GroupUpdate.
+type GroupUpdate struct {
+	Description string `json:"description"`
+	Name        string `json:"name"`
+
+	// Roles granted to all direct and nested members of the group
+	Roles []string `json:"roles"`
+}
+
 // ListMeta defines model for ListMeta.
 type ListMeta struct {
 	Limit  int `json:"limit"`
@@ -36,6 +97,309 @@ type ListMeta struct {
 	Total  int `json:"total"`
 }
 
+// LoginLockInfo defines model for LoginLockInfo.
+type LoginLockInfo struct {
+	Failures     int           `json:"failures"`
+	Id           string        `json:"id"`
+	LastFailedAt time.Time     `json:"lastFailedAt"`
+	Locked       bool          `json:"locked"`
+	LockedUntil  time.Time     `json:"lockedUntil"`
+	Subject      string        `json:"subject"`
+	TenantId     string        `json:"tenantId"`
+	Type         LoginLockType `json:"type"`
+}
+
+// LoginLockInfoList defines model for LoginLockInfoList.
+type LoginLockInfoList struct {
+	LoginLocks []LoginLockInfo `json:"loginLocks"`
+}
+
+// LoginLockType defines model for LoginLockType.
+type LoginLockType string
+
+// List of LoginLockType
+const (
+	LoginLockType_ip      LoginLockType = "ip"
+	LoginLockType_loginId LoginLockType = "loginId"
+)
+
+// MFACode defines model for MFACode.
+type MFACode struct {
+
+	// TOTP code, or recovery code except for the TOTP confirmation
+	Code string `json:"code"`
+}
+
+// MFAInfo defines model for MFAInfo.
+type MFAInfo struct {
+
+	// Number of unused recovery codes
+	RecoveryCodes int        `json:"recoveryCodes"`
+	TotpEnabled   bool       `json:"totpEnabled"`
+	TotpEnabledAt *time.Time `json:"totpEnabledAt,omitempty"`
+}
+
+// MFARecoveryCodes defines model for MFARecoveryCodes.
+type MFARecoveryCodes struct {
+
+	// One-time recovery codes. They are shown only once.
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+// MFATOTPEnrollment defines model for MFATOTPEnrollment.
+type MFATOTPEnrollment struct {
+
+	// Base32 encoded TOTP secret
+	Secret string `json:"secret"`
+
+	// otpauth URI for QR codes of authenticator apps
+	Uri string `json:"uri"`
+}
+
+// OAuthClientCreate defines model for OAuthClientCreate.
+type OAuthClientCreate struct {
+	GrantTypes   []OAuthGrantType `json:"grantTypes"`
+	Name         string           `json:"name"`
+	Public       bool             `json:"public"`
+	RedirectUris []string         `json:"redirectUris"`
+	Scopes       []string         `json:"scopes"`
+}
+
+// OAuthClientInfo defines model for OAuthClientInfo.
+type OAuthClientInfo struct {
+	CreatedAt    time.Time        `json:"createdAt"`
+	GrantTypes   []OAuthGrantType `json:"grantTypes"`
+	Id           string           `json:"id"`
+	Name         string           `json:"name"`
+	Public       bool             `json:"public"`
+	RedirectUris []string         `json:"redirectUris"`
+	Scopes       []string         `json:"scopes"`
+
+	// Secret of a confidential client. It is returned only when the client is created.
+	Secret *string `json:"secret,omitempty"`
+}
+
+// OAuthClientInfoList defines model for OAuthClientInfoList.
+type OAuthClientInfoList struct {
+	Clients []OAuthClientInfo `json:"clients"`
+}
+
+// OAuthClientUpdate defines model for OAuthClientUpdate.
+type OAuthClientUpdate struct {
+	GrantTypes   []OAuthGrantType `json:"grantTypes"`
+	Name         string           `json:"name"`
+	RedirectUris []string         `json:"redirectUris"`
+	Scopes       []string         `json:"scopes"`
+}
+
+// OAuthGrantType defines model for OAuthGrantType.
+type OAuthGrantType string
+
+// List of OAuthGrantType
+const (
+	OAuthGrantType_authorization_code OAuthGrantType = "authorization_code"
+	OAuthGrantType_client_credentials OAuthGrantType = "client_credentials"
+	OAuthGrantType_refresh_token      OAuthGrantType = "refresh_token"
+)
+
+// Credential of navigator.credentials.get(). Binary values are base64url encoded.
+type PasskeyAssertionCredential struct {
+	Id       string                   `json:"id"`
+	Response PasskeyAssertionResponse `json:"response"`
+	Type     string                   `json:"type"`
+}
+
+// PasskeyAssertionResponse defines model for PasskeyAssertionResponse.
+type PasskeyAssertionResponse struct {
+	AuthenticatorData string  `json:"authenticatorData"`
+	ClientDataJSON    string  `json:"clientDataJSON"`
+	Signature         string  `json:"signature"`
+	UserHandle        *string `json:"userHandle,omitempty"`
+}
+
+// Credential of navigator.credentials.create(). Binary values are base64url encoded.
+type PasskeyAttestationCredential struct {
+	Id       string                     `json:"id"`
+	Response PasskeyAttestationResponse `json:"response"`
+	Type     string                     `json:"type"`
+}
+
+// PasskeyAttestationResponse defines model for PasskeyAttestationResponse.
+type PasskeyAttestationResponse struct {
+	AttestationObject string    `json:"attestationObject"`
+	ClientDataJSON    string    `json:"clientDataJSON"`
+	Transports        *[]string `json:"transports,omitempty"`
+}
+
+// PasskeyInfo defines model for PasskeyInfo.
+type PasskeyInfo struct {
+	CreatedAt  time.Time  `json:"createdAt"`
+	Id         string     `json:"id"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	Name       string     `json:"name"`
+	Transports []string   `json:"transports"`
+}
+
+// PasskeyInfoList defines model for PasskeyInfoList.
+type PasskeyInfoList struct {
+	Passkeys []PasskeyInfo `json:"passkeys"`
+}
+
+// PasskeyLoginFinish defines model for PasskeyLoginFinish.
+type PasskeyLoginFinish struct {
+	ChallengeId string `json:"challengeId"`
+
+	// Credential of navigator.credentials.get(). Binary values are base64url encoded.
+	Credential PasskeyAssertionCredential `json:"credential"`
+}
+
+// PasskeyOptions defines model for PasskeyOptions.
+type PasskeyOptions struct {
+
+	// ID of the challenge to finish the ceremony
+	ChallengeId string `json:"challengeId"`
+
+	// Options of navigator.credentials.create() for registrations or navigator.credentials.get() for logins. Binary values are base64url encoded.
+	PublicKey map[string]interface{} `json:"publicKey"`
+}
+
+// PasskeyRegistrationFinish defines model for PasskeyRegistrationFinish.
+type PasskeyRegistrationFinish struct {
+	ChallengeId string `json:"challengeId"`
+
+	// Credential of navigator.credentials.create(). Binary values are base64url encoded.
+	Credential PasskeyAttestationCredential `json:"credential"`
+
+	// Name of the passkey. "Passkey" is used by default.
+	Name *string `json:"name,omitempty"`
+}
+
+// PermissionCreate defines model for PermissionCreate.
+type PermissionCreate struct {
+
+	// Regular expression of operation actions like "^(list|get)$"
+	Action string `json:"action"`
+
+	// Regular expression of operation objects like "user"
+	Object string `json:"object"`
+
+	// Role name or scope with the "scope:" prefix
+	Subject string `json:"subject"`
+}
+
+// PermissionInfo defines model for PermissionInfo.
+type PermissionInfo struct {
+	Action    string    `json:"action"`
+	CreatedAt time.Time `json:"createdAt"`
+	Id        string    `json:"id"`
+	Object    string    `json:"object"`
+	Subject   string    `json:"subject"`
+}
+
+// PermissionInfoList defines model for PermissionInfoList.
+type PermissionInfoList struct {
+	Permissions []PermissionInfo `json:"permissions"`
+}
+
+// PermissionUpdate defines model for PermissionUpdate.
+type PermissionUpdate struct {
+
+	// Regular expression of operation actions like "^(list|get)$"
+	Action string `json:"action"`
+
+	// Regular expression of operation objects like "user"
+	Object string `json:"object"`
+
+	// Role name or scope with the "scope:" prefix
+	Subject string `json:"subject"`
+}
+
+// RoleCreate defines model for RoleCreate.
+type RoleCreate struct {
+	Description string `json:"description"`
+	Name        string `json:"name"`
+
+	// Max age of passwords of users having the role in days. 0 or no value means that passwords don't expire.
+	PasswordMaxAgeDays *int `json:"passwordMaxAgeDays,omitempty"`
+
+	// Scopes which can be granted to tokens of the role
+	Scopes []string `json:"scopes"`
+}
+
+// RoleInfo defines model for RoleInfo.
+type RoleInfo struct {
+	CreatedAt          time.Time `json:"createdAt"`
+	Description        string    `json:"description"`
+	Name               string    `json:"name"`
+	PasswordMaxAgeDays int       `json:"passwordMaxAgeDays"`
+	Scopes             []string  `json:"scopes"`
+}
+
+// RoleInfoList defines model for RoleInfoList.
+type RoleInfoList struct {
+	Roles []RoleInfo `json:"roles"`
+}
+
+// RoleUpdate defines model for RoleUpdate.
+type RoleUpdate struct {
+	Description string `json:"description"`
+
+	// Max age of passwords of users having the role in days. 0 or no value means that passwords don't expire.
+	PasswordMaxAgeDays *int `json:"passwordMaxAgeDays,omitempty"`
+
+	// Scopes which can be granted to tokens of the role
+	Scopes []string `json:"scopes"`
+}
+
+// SessionInfo defines model for SessionInfo.
+type SessionInfo struct {
+	CreatedAt  time.Time `json:"createdAt"`
+	Current    bool      `json:"current"`
+	DeviceName string    `json:"deviceName"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+	Id         string    `json:"id"`
+	Ip         string    `json:"ip"`
+	LastUsedAt time.Time `json:"lastUsedAt"`
+	UserAgent  string    `json:"userAgent"`
+}
+
+// SessionInfoList defines model for SessionInfoList.
+type SessionInfoList struct {
+	Sessions []SessionInfo `json:"sessions"`
+}
+
+// TenantCreate defines model for TenantCreate.
+type TenantCreate struct {
+	Description string `json:"description"`
+
+	// Tenant ID, which is also the subdomain of the tenant
+	Id string `json:"id"`
+}
+
+// TenantInfo defines model for TenantInfo.
+type TenantInfo struct {
+	CreatedAt   time.Time `json:"createdAt"`
+	Description string    `json:"description"`
+	Id          string    `json:"id"`
+}
+
+// TenantInfoList defines model for TenantInfoList.
+type TenantInfoList struct {
+	Tenants []TenantInfo `json:"tenants"`
+}
+
+// TenantUpdate defines model for TenantUpdate.
+type TenantUpdate struct {
+	Description string `json:"description"`
+}
+
+// Error of a login with an email not verified having an access token which can only verify the email
+type TokenEmailNotVerified struct {
+	AccessToken TokenInfo `json:"accessToken"`
+	Code        string    `json:"code"`
+	Message     string    `json:"message"`
+}
+
 // TokenInfo defines model for TokenInfo.
 type TokenInfo struct {
 	ExpiresAt time.Time `json:"expiresAt"`
@@ -49,6 +413,90 @@ type TokenInfos struct {
 	RefreshToken TokenInfo `json:"refreshToken"`
 }
 
+// TokenIntrospect defines model for TokenIntrospect.
+type TokenIntrospect struct {
+
+	// Access token to introspect
+	Token string `json:"token"`
+}
+
+// TokenIntrospection defines model for TokenIntrospection.
+type TokenIntrospection struct {
+
+	// Whether the token is valid and isn't revoked. Other properties are set only for an active token.
+	Active      bool       `json:"active"`
+	Audience    *string    `json:"audience,omitempty"`
+	ClientId    *string    `json:"clientId,omitempty"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
+	IssuedAt    *time.Time `json:"issuedAt,omitempty"`
+	Issuer      *string    `json:"issuer,omitempty"`
+	Role        *string    `json:"role,omitempty"`
+	Scopes      *[]string  `json:"scopes,omitempty"`
+	Subject     *string    `json:"subject,omitempty"`
+	TokenId     *string    `json:"tokenId,omitempty"`
+	UserId      *string    `json:"userId,omitempty"`
+	UserLoginId *string    `json:"userLoginId,omitempty"`
+}
+
+// TokenKeyInfo defines model for TokenKeyInfo.
+type TokenKeyInfo struct {
+	Alg       string         `json:"alg"`
+	CreatedAt time.Time      `json:"createdAt"`
+	ExpiresAt *time.Time     `json:"expiresAt,omitempty"`
+	Id        string         `json:"id"`
+	RetiredAt *time.Time     `json:"retiredAt,omitempty"`
+	Status    TokenKeyStatus `json:"status"`
+	Type      TokenKeyType   `json:"type"`
+}
+
+// TokenKeyInfoList defines model for TokenKeyInfoList.
+type TokenKeyInfoList struct {
+	Keys []TokenKeyInfo `json:"keys"`
+}
+
+// TokenKeyStatus defines model for TokenKeyStatus.
+type TokenKeyStatus string
+
+// List of TokenKeyStatus
+const (
+	TokenKeyStatus_pending TokenKeyStatus = "pending"
+	TokenKeyStatus_primary TokenKeyStatus = "primary"
+	TokenKeyStatus_retired TokenKeyStatus = "retired"
+)
+
+// TokenKeyType defines model for TokenKeyType.
+type TokenKeyType string
+
+// List of TokenKeyType
+const (
+	TokenKeyType_access  TokenKeyType = "access"
+	TokenKeyType_refresh TokenKeyType = "refresh"
+)
+
+// TokenMFA defines model for TokenMFA.
+type TokenMFA struct {
+
+	// TOTP code or recovery code
+	Code string `json:"code"`
+
+	// MFA challenge token of the login
+	MfaToken string `json:"mfaToken"`
+}
+
+// Error of a login requiring MFA having a MFA challenge token, which is exchanged for tokens with a MFA code
+type TokenMFARequired struct {
+	Code     string    `json:"code"`
+	Message  string    `json:"message"`
+	MfaToken TokenInfo `json:"mfaToken"`
+}
+
+// Error of an expired password having an access token which can only change the password
+type TokenPasswdExpired struct {
+	AccessToken TokenInfo `json:"accessToken"`
+	Code        string    `json:"code"`
+	Message     string    `json:"message"`
+}
+
 // TokenRefresh defines model for TokenRefresh.
 type TokenRefresh struct {
 	RefreshToken string `json:"refreshToken"`
@@ -56,20 +504,31 @@ type TokenRefresh struct {
 
 // UserCreate defines model for UserCreate.
 type UserCreate struct {
-	Email    string   `json:"email"`
-	LoginId  string   `json:"loginId"`
-	Password string   `json:"password"`
-	Phone    string   `json:"phone"`
-	Role     UserRole `json:"role"`
+	Email    string `json:"email"`
+	LoginId  string `json:"loginId"`
+	Password string `json:"password"`
+	Phone    string `json:"phone"`
+
+	// Role name. admin, tenant-admin and user roles are created by default.
+	Role UserRole `json:"role"`
 }
 
 // UserInfo defines model for UserInfo.
 type UserInfo struct {
-	Email   string   `json:"email"`
-	Id      string   `json:"id"`
-	LoginId string   `json:"loginId"`
-	Phone   string   `json:"phone"`
-	Role    UserRole `json:"role"`
+	Email string `json:"email"`
+
+	// Whether the email is verified. Changing the email resets it.
+	EmailVerified   bool       `json:"emailVerified"`
+	EmailVerifiedAt *time.Time `json:"emailVerifiedAt,omitempty"`
+	Id              string     `json:"id"`
+	LoginId         string     `json:"loginId"`
+	Phone           string     `json:"phone"`
+
+	// Role name. admin, tenant-admin and user roles are created by default.
+	Role UserRole `json:"role"`
+
+	// Tenant of the user, resolved from the X-Tenant-ID header, the subdomain or the access token
+	TenantId string `json:"tenantId"`
 }
 
 // UserInfoList defines model for UserInfoList.
@@ -78,32 +537,189 @@ type UserInfoList struct {
 	Users    []UserInfo `json:"users"`
 }
 
-// UserRole defines model for UserRole.
-type UserRole string
+// UserPasswdRemove defines model for UserPasswdRemove.
+type UserPasswdRemove struct {
 
-// List of UserRole
-const (
-	UserRole_admin UserRole = "admin"
-	UserRole_user  UserRole = "user"
-)
+	// Current password
+	Password string `json:"password"`
+}
+
+// UserPasswdUpdate defines model for UserPasswdUpdate.
+type UserPasswdUpdate struct {
+	NewPassword string `json:"newPassword"`
+
+	// Current password
+	Password string `json:"password"`
+}
+
+// Role name. admin, tenant-admin and user roles are created by default.
+type UserRole string
 
 // UserUpdate defines model for UserUpdate.
 type UserUpdate struct {
-	Email    string   `json:"email"`
-	Password string   `json:"password"`
-	Phone    string   `json:"phone"`
-	Role     UserRole `json:"role"`
+	Email string `json:"email"`
+
+	// New password set by an admin. The password isn't changed if it's empty. Users can't change their own password with it and must use the password API with the current password.
+	Password *string `json:"password,omitempty"`
+	Phone    string  `json:"phone"`
+
+	// Role name. admin, tenant-admin and user roles are created by default.
+	Role UserRole `json:"role"`
 }
 
+// Audience defines model for Audience.
+type Audience string
+
+// DeviceName defines model for DeviceName.
+type DeviceName string
+
+// GroupID defines model for GroupID.
+type GroupID string
+
 // Limit defines model for Limit.
 type Limit int
 
+// LoginLockID defines model for LoginLockID.
+type LoginLockID string
+
+// MemberID defines model for MemberID.
+type MemberID string
+
+// OAuthClientID defines model for OAuthClientID.
+type OAuthClientID string
+
 // Offset defines model for Offset.
 type Offset int
 
+// PasskeyID defines model for PasskeyID.
+type PasskeyID string
+
+// PermissionID defines model for PermissionID.
+type PermissionID string
+
+// RoleName defines model for RoleName.
+type RoleName string
+
+// Scope defines model for Scope.
+type Scope string
+
+// TenantID defines model for TenantID.
+type TenantID string
+
 // UserID defines model for UserID.
 type UserID string
 
+// GetGroupsParams defines parameters for GetGroups.
+type GetGroupsParams struct {
+	Offset *Offset `json:"Offset,omitempty"`
+	Limit  *Limit  `json:"Limit,omitempty"`
+}
+
+// PostGroupsJSONBody defines parameters for PostGroups.
+type PostGroupsJSONBody GroupCreate
+
+// PutGroupsGroupIDJSONBody defines parameters for PutGroupsGroupID.
+type PutGroupsGroupIDJSONBody GroupUpdate
+
+// GetGroupsGroupIDMembersParams defines parameters for GetGroupsGroupIDMembers.
+type GetGroupsGroupIDMembersParams struct {
+	Offset *Offset `json:"Offset,omitempty"`
+	Limit  *Limit  `json:"Limit,omitempty"`
+}
+
+// PostGroupsGroupIDMembersJSONBody defines parameters for PostGroupsGroupIDMembers.
+type PostGroupsGroupIDMembersJSONBody GroupMemberCreate
+
+// GetLoginLocksParams defines parameters for GetLoginLocks.
+type GetLoginLocksParams struct {
+	Offset *Offset `json:"Offset,omitempty"`
+	Limit  *Limit  `json:"Limit,omitempty"`
+}
+
+// GetOauthClientsParams defines parameters for GetOauthClients.
+type GetOauthClientsParams struct {
+	Offset *Offset `json:"Offset,omitempty"`
+	Limit  *Limit  `json:"Limit,omitempty"`
+}
+
+// PostOauthClientsJSONBody defines parameters for PostOauthClients.
+type PostOauthClientsJSONBody OAuthClientCreate
+
+// PutOauthClientsOAuthClientIDJSONBody defines parameters for PutOauthClientsOAuthClientID.
+type PutOauthClientsOAuthClientIDJSONBody OAuthClientUpdate
+
+// GetPermissionsParams defines parameters for GetPermissions.
+type GetPermissionsParams struct {
+	Offset *Offset `json:"Offset,omitempty"`
+	Limit  *Limit  `json:"Limit,omitempty"`
+}
+
+// PostPermissionsJSONBody defines parameters for PostPermissions.
+type PostPermissionsJSONBody PermissionCreate
+
+// PutPermissionsPermissionIDJSONBody defines parameters for PutPermissionsPermissionID.
+type PutPermissionsPermissionIDJSONBody PermissionUpdate
+
+// GetRolesParams defines parameters for GetRoles.
+type GetRolesParams struct {
+	Offset *Offset `json:"Offset,omitempty"`
+	Limit  *Limit  `json:"Limit,omitempty"`
+}
+
+// PostRolesJSONBody defines parameters for PostRoles.
+type PostRolesJSONBody RoleCreate
+
+// PutRolesRoleNameJSONBody defines parameters for PutRolesRoleName.
+type PutRolesRoleNameJSONBody RoleUpdate
+
+// GetTenantsParams defines parameters for GetTenants.
+type GetTenantsParams struct {
+	Offset *Offset `json:"Offset,omitempty"`
+	Limit  *Limit  `json:"Limit,omitempty"`
+}
+
+// PostTenantsJSONBody defines parameters for PostTenants.
+type PostTenantsJSONBody TenantCreate
+
+// PutTenantsTenantIDJSONBody defines parameters for PutTenantsTenantID.
+type PutTenantsTenantIDJSONBody TenantUpdate
+
+// PostTokensIntrospectJSONBody defines parameters for PostTokensIntrospect.
+type PostTokensIntrospectJSONBody TokenIntrospect
+
+// PostTokensLoginParams defines parameters for PostTokensLogin.
+type PostTokensLoginParams struct {
+
+	// Audience of tokens. Default audience is used if not set.
+	Audience *Audience `json:"audience,omitempty"`
+
+	// Space separated scopes of tokens. Tokens have all permissions of the role if not set.
+	Scope       *Scope      `json:"scope,omitempty"`
+	XDeviceName *DeviceName `json:"X-Device-Name,omitempty"`
+}
+
+// PostTokensMfaJSONBody defines parameters for PostTokensMfa.
+type PostTokensMfaJSONBody TokenMFA
+
+// PostTokensMfaParams defines parameters for PostTokensMfa.
+type PostTokensMfaParams struct {
+	XDeviceName *DeviceName `json:"X-Device-Name,omitempty"`
+}
+
+// PostTokensPasskeyFinishJSONBody defines parameters for PostTokensPasskeyFinish.
+type PostTokensPasskeyFinishJSONBody PasskeyLoginFinish
+
+// PostTokensPasskeyFinishParams defines parameters for PostTokensPasskeyFinish.
+type PostTokensPasskeyFinishParams struct {
+
+	// Audience of tokens. Default audience is used if not set.
+	Audience *Audience `json:"audience,omitempty"`
+
+	// Space separated scopes of tokens. Tokens have all permissions of the role if not set.
+	Scope       *Scope      `json:"scope,omitempty"`
+	XDeviceName *DeviceName `json:"X-Device-Name,omitempty"`
+}
+
 // PostTokensRefreshJSONBody defines parameters for PostTokensRefresh.
 type PostTokensRefreshJSONBody TokenRefresh
 
@@ -119,71 +735,1739 @@ type PostUsersJSONBody UserCreate
 // PutUsersMeJSONBody defines parameters for PutUsersMe.
 type PutUsersMeJSONBody UserUpdate
 
+// PostUsersMeEmailVerifyConfirmJSONBody defines parameters for PostUsersMeEmailVerifyConfirm.
+type PostUsersMeEmailVerifyConfirmJSONBody EmailVerifyConfirm
+
+// PostUsersMeMfaRecoveryCodesJSONBody defines parameters for PostUsersMeMfaRecoveryCodes.
+type PostUsersMeMfaRecoveryCodesJSONBody MFACode
+
+// PostUsersMeMfaTotpConfirmJSONBody defines parameters for PostUsersMeMfaTotpConfirm.
+type PostUsersMeMfaTotpConfirmJSONBody MFACode
+
+// PostUsersMeMfaTotpDisableJSONBody defines parameters for PostUsersMeMfaTotpDisable.
+type PostUsersMeMfaTotpDisableJSONBody MFACode
+
+// PostUsersMePasskeysFinishJSONBody defines parameters for PostUsersMePasskeysFinish.
+type PostUsersMePasskeysFinishJSONBody PasskeyRegistrationFinish
+
+// PutUsersMePasswordJSONBody defines parameters for PutUsersMePassword.
+type PutUsersMePasswordJSONBody UserPasswdUpdate
+
+// PostUsersMePasswordRemoveJSONBody defines parameters for PostUsersMePasswordRemove.
+type PostUsersMePasswordRemoveJSONBody UserPasswdRemove
+
 // PutUsersUserIDJSONBody defines parameters for PutUsersUserID.
 type PutUsersUserIDJSONBody UserUpdate
 
+// PostGroupsJSONRequestBody defines body for PostGroups for application/json ContentType.
+type PostGroupsJSONRequestBody PostGroupsJSONBody
+
+// PutGroupsGroupIDJSONRequestBody defines body for PutGroupsGroupID for application/json ContentType.
+type PutGroupsGroupIDJSONRequestBody PutGroupsGroupIDJSONBody
+
+// PostGroupsGroupIDMembersJSONRequestBody defines body for PostGroupsGroupIDMembers for application/json ContentType.
+type PostGroupsGroupIDMembersJSONRequestBody PostGroupsGroupIDMembersJSONBody
+
+// PostOauthClientsJSONRequestBody defines body for PostOauthClients for application/json ContentType.
+type PostOauthClientsJSONRequestBody PostOauthClientsJSONBody
+
+// PutOauthClientsOAuthClientIDJSONRequestBody defines body for PutOauthClientsOAuthClientID for application/json ContentType.
+type PutOauthClientsOAuthClientIDJSONRequestBody PutOauthClientsOAuthClientIDJSONBody
+
+// PostPermissionsJSONRequestBody defines body for PostPermissions for application/json ContentType.
+type PostPermissionsJSONRequestBody PostPermissionsJSONBody
+
+// PutPermissionsPermissionIDJSONRequestBody defines body for PutPermissionsPermissionID for application/json ContentType.
+type PutPermissionsPermissionIDJSONRequestBody PutPermissionsPermissionIDJSONBody
+
+// PostRolesJSONRequestBody defines body for PostRoles for application/json ContentType.
+type PostRolesJSONRequestBody PostRolesJSONBody
+
+// PutRolesRoleNameJSONRequestBody defines body for PutRolesRoleName for application/json ContentType.
+type PutRolesRoleNameJSONRequestBody PutRolesRoleNameJSONBody
+
+// PostTenantsJSONRequestBody defines body for PostTenants for application/json ContentType.
+type PostTenantsJSONRequestBody PostTenantsJSONBody
+
+// PutTenantsTenantIDJSONRequestBody defines body for PutTenantsTenantID for application/json ContentType.
+type PutTenantsTenantIDJSONRequestBody PutTenantsTenantIDJSONBody
+
+// PostTokensIntrospectJSONRequestBody defines body for PostTokensIntrospect for application/json ContentType.
+type PostTokensIntrospectJSONRequestBody PostTokensIntrospectJSONBody
+
+// PostTokensMfaJSONRequestBody defines body for PostTokensMfa for application/json ContentType.
+type PostTokensMfaJSONRequestBody PostTokensMfaJSONBody
+
+// PostTokensPasskeyFinishJSONRequestBody defines body for PostTokensPasskeyFinish for application/json ContentType.
+type PostTokensPasskeyFinishJSONRequestBody PostTokensPasskeyFinishJSONBody
+
 // PostTokensRefreshJSONRequestBody defines body for PostTokensRefresh for application/json ContentType.
 type PostTokensRefreshJSONRequestBody PostTokensRefreshJSONBody
 
-// PostUsersJSONRequestBody defines body for PostUsers for application/json ContentType.
-type PostUsersJSONRequestBody PostUsersJSONBody
+// PostUsersJSONRequestBody defines body for PostUsers for application/json ContentType.
+type PostUsersJSONRequestBody PostUsersJSONBody
+
+// PutUsersMeJSONRequestBody defines body for PutUsersMe for application/json ContentType.
+type PutUsersMeJSONRequestBody PutUsersMeJSONBody
+
+// PostUsersMeEmailVerifyConfirmJSONRequestBody defines body for PostUsersMeEmailVerifyConfirm for application/json ContentType.
+type PostUsersMeEmailVerifyConfirmJSONRequestBody PostUsersMeEmailVerifyConfirmJSONBody
+
+// PostUsersMeMfaRecoveryCodesJSONRequestBody defines body for PostUsersMeMfaRecoveryCodes for application/json ContentType.
+type PostUsersMeMfaRecoveryCodesJSONRequestBody PostUsersMeMfaRecoveryCodesJSONBody
+
+// PostUsersMeMfaTotpConfirmJSONRequestBody defines body for PostUsersMeMfaTotpConfirm for application/json ContentType.
+type PostUsersMeMfaTotpConfirmJSONRequestBody PostUsersMeMfaTotpConfirmJSONBody
+
+// PostUsersMeMfaTotpDisableJSONRequestBody defines body for PostUsersMeMfaTotpDisable for application/json ContentType.
+type PostUsersMeMfaTotpDisableJSONRequestBody PostUsersMeMfaTotpDisableJSONBody
+
+// PostUsersMePasskeysFinishJSONRequestBody defines body for PostUsersMePasskeysFinish for application/json ContentType.
+type PostUsersMePasskeysFinishJSONRequestBody PostUsersMePasskeysFinishJSONBody
+
+// PutUsersMePasswordJSONRequestBody defines body for PutUsersMePassword for application/json ContentType.
+type PutUsersMePasswordJSONRequestBody PutUsersMePasswordJSONBody
+
+// PostUsersMePasswordRemoveJSONRequestBody defines body for PostUsersMePasswordRemove for application/json ContentType.
+type PostUsersMePasswordRemoveJSONRequestBody PostUsersMePasswordRemoveJSONBody
+
+// PutUsersUserIDJSONRequestBody defines body for PutUsersUserID for application/json ContentType.
+type PutUsersUserIDJSONRequestBody PutUsersUserIDJSONBody
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+
+	// (GET /groups)
+	GetGroups(w http.ResponseWriter, r *http.Request, params GetGroupsParams)
+
+	// (POST /groups)
+	PostGroups(w http.ResponseWriter, r *http.Request)
+
+	// (DELETE /groups/{GroupID})
+	DeleteGroupsGroupID(w http.ResponseWriter, r *http.Request, groupID GroupID)
+
+	// (GET /groups/{GroupID})
+	GetGroupsGroupID(w http.ResponseWriter, r *http.Request, groupID GroupID)
+
+	// (PUT /groups/{GroupID})
+	PutGroupsGroupID(w http.ResponseWriter, r *http.Request, groupID GroupID)
+
+	// (GET /groups/{GroupID}/members)
+	GetGroupsGroupIDMembers(w http.ResponseWriter, r *http.Request, groupID GroupID, params GetGroupsGroupIDMembersParams)
+
+	// (POST /groups/{GroupID}/members)
+	PostGroupsGroupIDMembers(w http.ResponseWriter, r *http.Request, groupID GroupID)
+
+	// (DELETE /groups/{GroupID}/members/{MemberID})
+	DeleteGroupsGroupIDMembersMemberID(w http.ResponseWriter, r *http.Request, groupID GroupID, memberID MemberID)
+
+	// (GET /keys)
+	GetKeys(w http.ResponseWriter, r *http.Request)
+
+	// (POST /keys/rotate)
+	PostKeysRotate(w http.ResponseWriter, r *http.Request)
+
+	// (GET /login-locks)
+	GetLoginLocks(w http.ResponseWriter, r *http.Request, params GetLoginLocksParams)
+
+	// (DELETE /login-locks/{LoginLockID})
+	DeleteLoginLocksLoginLockID(w http.ResponseWriter, r *http.Request, loginLockID LoginLockID)
+
+	// (GET /login-locks/{LoginLockID})
+	GetLoginLocksLoginLockID(w http.ResponseWriter, r *http.Request, loginLockID LoginLockID)
+
+	// (GET /oauth/clients)
+	GetOauthClients(w http.ResponseWriter, r *http.Request, params GetOauthClientsParams)
+
+	// (POST /oauth/clients)
+	PostOauthClients(w http.ResponseWriter, r *http.Request)
+
+	// (DELETE /oauth/clients/{OAuthClientID})
+	DeleteOauthClientsOAuthClientID(w http.ResponseWriter, r *http.Request, oAuthClientID OAuthClientID)
+
+	// (GET /oauth/clients/{OAuthClientID})
+	GetOauthClientsOAuthClientID(w http.ResponseWriter, r *http.Request, oAuthClientID OAuthClientID)
+
+	// (PUT /oauth/clients/{OAuthClientID})
+	PutOauthClientsOAuthClientID(w http.ResponseWriter, r *http.Request, oAuthClientID OAuthClientID)
+
+	// (GET /permissions)
+	GetPermissions(w http.ResponseWriter, r *http.Request, params GetPermissionsParams)
+
+	// (POST /permissions)
+	PostPermissions(w http.ResponseWriter, r *http.Request)
+
+	// (DELETE /permissions/{PermissionID})
+	DeletePermissionsPermissionID(w http.ResponseWriter, r *http.Request, permissionID PermissionID)
+
+	// (GET /permissions/{PermissionID})
+	GetPermissionsPermissionID(w http.ResponseWriter, r *http.Request, permissionID PermissionID)
+
+	// (PUT /permissions/{PermissionID})
+	PutPermissionsPermissionID(w http.ResponseWriter, r *http.Request, permissionID PermissionID)
+
+	// (GET /roles)
+	GetRoles(w http.ResponseWriter, r *http.Request, params GetRolesParams)
+
+	// (POST /roles)
+	PostRoles(w http.ResponseWriter, r *http.Request)
+
+	// (DELETE /roles/{RoleName})
+	DeleteRolesRoleName(w http.ResponseWriter, r *http.Request, roleName RoleName)
+
+	// (GET /roles/{RoleName})
+	GetRolesRoleName(w http.ResponseWriter, r *http.Request, roleName RoleName)
+
+	// (PUT /roles/{RoleName})
+	PutRolesRoleName(w http.ResponseWriter, r *http.Request, roleName RoleName)
+
+	// (GET /tenants)
+	GetTenants(w http.ResponseWriter, r *http.Request, params GetTenantsParams)
+
+	// (POST /tenants)
+	PostTenants(w http.ResponseWriter, r *http.Request)
+
+	// (DELETE /tenants/{TenantID})
+	DeleteTenantsTenantID(w http.ResponseWriter, r *http.Request, tenantID TenantID)
+
+	// (GET /tenants/{TenantID})
+	GetTenantsTenantID(w http.ResponseWriter, r *http.Request, tenantID TenantID)
+
+	// (PUT /tenants/{TenantID})
+	PutTenantsTenantID(w http.ResponseWriter, r *http.Request, tenantID TenantID)
+
+	// (POST /tokens/introspect)
+	PostTokensIntrospect(w http.ResponseWriter, r *http.Request)
+
+	// (POST /tokens/login)
+	PostTokensLogin(w http.ResponseWriter, r *http.Request, params PostTokensLoginParams)
+
+	// (POST /tokens/logout)
+	PostTokensLogout(w http.ResponseWriter, r *http.Request)
+
+	// (POST /tokens/mfa)
+	PostTokensMfa(w http.ResponseWriter, r *http.Request, params PostTokensMfaParams)
+
+	// (POST /tokens/passkey/begin)
+	PostTokensPasskeyBegin(w http.ResponseWriter, r *http.Request)
+
+	// (POST /tokens/passkey/finish)
+	PostTokensPasskeyFinish(w http.ResponseWriter, r *http.Request, params PostTokensPasskeyFinishParams)
+
+	// (POST /tokens/refresh)
+	PostTokensRefresh(w http.ResponseWriter, r *http.Request)
+
+	// (GET /users)
+	GetUsers(w http.ResponseWriter, r *http.Request, params GetUsersParams)
+
+	// (POST /users)
+	PostUsers(w http.ResponseWriter, r *http.Request)
+
+	// (DELETE /users/me)
+	DeleteUsersMe(w http.ResponseWriter, r *http.Request)
+
+	// (GET /users/me)
+	GetUsersMe(w http.ResponseWriter, r *http.Request)
+
+	// (PUT /users/me)
+	PutUsersMe(w http.ResponseWriter, r *http.Request)
+
+	// (POST /users/me/email/verify)
+	PostUsersMeEmailVerify(w http.ResponseWriter, r *http.Request)
+
+	// (POST /users/me/email/verify/confirm)
+	PostUsersMeEmailVerifyConfirm(w http.ResponseWriter, r *http.Request)
+
+	// (GET /users/me/mfa)
+	GetUsersMeMfa(w http.ResponseWriter, r *http.Request)
+
+	// (POST /users/me/mfa/recovery-codes)
+	PostUsersMeMfaRecoveryCodes(w http.ResponseWriter, r *http.Request)
+
+	// (POST /users/me/mfa/totp)
+	PostUsersMeMfaTotp(w http.ResponseWriter, r *http.Request)
+
+	// (POST /users/me/mfa/totp/confirm)
+	PostUsersMeMfaTotpConfirm(w http.ResponseWriter, r *http.Request)
+
+	// (POST /users/me/mfa/totp/disable)
+	PostUsersMeMfaTotpDisable(w http.ResponseWriter, r *http.Request)
+
+	// (GET /users/me/passkeys)
+	GetUsersMePasskeys(w http.ResponseWriter, r *http.Request)
+
+	// (POST /users/me/passkeys/begin)
+	PostUsersMePasskeysBegin(w http.ResponseWriter, r *http.Request)
+
+	// (POST /users/me/passkeys/finish)
+	PostUsersMePasskeysFinish(w http.ResponseWriter, r *http.Request)
+
+	// (DELETE /users/me/passkeys/{PasskeyID})
+	DeleteUsersMePasskeysPasskeyID(w http.ResponseWriter, r *http.Request, passkeyID PasskeyID)
+
+	// (PUT /users/me/password)
+	PutUsersMePassword(w http.ResponseWriter, r *http.Request)
+
+	// (POST /users/me/password/remove)
+	PostUsersMePasswordRemove(w http.ResponseWriter, r *http.Request)
+
+	// (DELETE /users/me/sessions)
+	DeleteUsersMeSessions(w http.ResponseWriter, r *http.Request)
+
+	// (GET /users/me/sessions)
+	GetUsersMeSessions(w http.ResponseWriter, r *http.Request)
+
+	// (DELETE /users/{UserID})
+	DeleteUsersUserID(w http.ResponseWriter, r *http.Request, userID UserID)
+
+	// (GET /users/{UserID})
+	GetUsersUserID(w http.ResponseWriter, r *http.Request, userID UserID)
+
+	// (PUT /users/{UserID})
+	PutUsersUserID(w http.ResponseWriter, r *http.Request, userID UserID)
+
+	// (DELETE /users/{UserID}/sessions)
+	DeleteUsersUserIDSessions(w http.ResponseWriter, r *http.Request, userID UserID)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+}
+
+type MiddlewareFunc func(http.HandlerFunc) http.HandlerFunc
+
+// GetGroups operation middleware
+func (siw *ServerInterfaceWrapper) GetGroups(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetGroupsParams
+
+	// ------------- Optional query parameter "Offset" -------------
+	if paramValue := r.URL.Query().Get("Offset"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "Offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter Offset: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "Limit" -------------
+	if paramValue := r.URL.Query().Get("Limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "Limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter Limit: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetGroups(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostGroups operation middleware
+func (siw *ServerInterfaceWrapper) PostGroups(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostGroups(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeleteGroupsGroupID operation middleware
+func (siw *ServerInterfaceWrapper) DeleteGroupsGroupID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "GroupID" -------------
+	var groupID GroupID
+
+	err = runtime.BindStyledParameter("simple", false, "GroupID", chi.URLParam(r, "GroupID"), &groupID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter GroupID: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteGroupsGroupID(w, r, groupID)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetGroupsGroupID operation middleware
+func (siw *ServerInterfaceWrapper) GetGroupsGroupID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "GroupID" -------------
+	var groupID GroupID
+
+	err = runtime.BindStyledParameter("simple", false, "GroupID", chi.URLParam(r, "GroupID"), &groupID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter GroupID: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetGroupsGroupID(w, r, groupID)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PutGroupsGroupID operation middleware
+func (siw *ServerInterfaceWrapper) PutGroupsGroupID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "GroupID" -------------
+	var groupID GroupID
+
+	err = runtime.BindStyledParameter("simple", false, "GroupID", chi.URLParam(r, "GroupID"), &groupID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter GroupID: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutGroupsGroupID(w, r, groupID)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetGroupsGroupIDMembers operation middleware
+func (siw *ServerInterfaceWrapper) GetGroupsGroupIDMembers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "GroupID" -------------
+	var groupID GroupID
+
+	err = runtime.BindStyledParameter("simple", false, "GroupID", chi.URLParam(r, "GroupID"), &groupID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter GroupID: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetGroupsGroupIDMembersParams
+
+	// ------------- Optional query parameter "Offset" -------------
+	if paramValue := r.URL.Query().Get("Offset"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "Offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter Offset: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "Limit" -------------
+	if paramValue := r.URL.Query().Get("Limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "Limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter Limit: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetGroupsGroupIDMembers(w, r, groupID, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostGroupsGroupIDMembers operation middleware
+func (siw *ServerInterfaceWrapper) PostGroupsGroupIDMembers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "GroupID" -------------
+	var groupID GroupID
+
+	err = runtime.BindStyledParameter("simple", false, "GroupID", chi.URLParam(r, "GroupID"), &groupID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter GroupID: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostGroupsGroupIDMembers(w, r, groupID)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeleteGroupsGroupIDMembersMemberID operation middleware
+func (siw *ServerInterfaceWrapper) DeleteGroupsGroupIDMembersMemberID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "GroupID" -------------
+	var groupID GroupID
+
+	err = runtime.BindStyledParameter("simple", false, "GroupID", chi.URLParam(r, "GroupID"), &groupID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter GroupID: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "MemberID" -------------
+	var memberID MemberID
+
+	err = runtime.BindStyledParameter("simple", false, "MemberID", chi.URLParam(r, "MemberID"), &memberID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter MemberID: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteGroupsGroupIDMembersMemberID(w, r, groupID, memberID)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetKeys operation middleware
+func (siw *ServerInterfaceWrapper) GetKeys(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetKeys(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostKeysRotate operation middleware
+func (siw *ServerInterfaceWrapper) PostKeysRotate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostKeysRotate(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetLoginLocks operation middleware
+func (siw *ServerInterfaceWrapper) GetLoginLocks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetLoginLocksParams
+
+	// ------------- Optional query parameter "Offset" -------------
+	if paramValue := r.URL.Query().Get("Offset"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "Offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter Offset: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "Limit" -------------
+	if paramValue := r.URL.Query().Get("Limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "Limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter Limit: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetLoginLocks(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeleteLoginLocksLoginLockID operation middleware
+func (siw *ServerInterfaceWrapper) DeleteLoginLocksLoginLockID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "LoginLockID" -------------
+	var loginLockID LoginLockID
+
+	err = runtime.BindStyledParameter("simple", false, "LoginLockID", chi.URLParam(r, "LoginLockID"), &loginLockID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter LoginLockID: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteLoginLocksLoginLockID(w, r, loginLockID)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetLoginLocksLoginLockID operation middleware
+func (siw *ServerInterfaceWrapper) GetLoginLocksLoginLockID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "LoginLockID" -------------
+	var loginLockID LoginLockID
+
+	err = runtime.BindStyledParameter("simple", false, "LoginLockID", chi.URLParam(r, "LoginLockID"), &loginLockID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter LoginLockID: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetLoginLocksLoginLockID(w, r, loginLockID)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetOauthClients operation middleware
+func (siw *ServerInterfaceWrapper) GetOauthClients(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetOauthClientsParams
+
+	// ------------- Optional query parameter "Offset" -------------
+	if paramValue := r.URL.Query().Get("Offset"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "Offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter Offset: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "Limit" -------------
+	if paramValue := r.URL.Query().Get("Limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "Limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter Limit: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetOauthClients(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostOauthClients operation middleware
+func (siw *ServerInterfaceWrapper) PostOauthClients(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostOauthClients(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeleteOauthClientsOAuthClientID operation middleware
+func (siw *ServerInterfaceWrapper) DeleteOauthClientsOAuthClientID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "OAuthClientID" -------------
+	var oAuthClientID OAuthClientID
+
+	err = runtime.BindStyledParameter("simple", false, "OAuthClientID", chi.URLParam(r, "OAuthClientID"), &oAuthClientID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter OAuthClientID: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteOauthClientsOAuthClientID(w, r, oAuthClientID)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetOauthClientsOAuthClientID operation middleware
+func (siw *ServerInterfaceWrapper) GetOauthClientsOAuthClientID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "OAuthClientID" -------------
+	var oAuthClientID OAuthClientID
+
+	err = runtime.BindStyledParameter("simple", false, "OAuthClientID", chi.URLParam(r, "OAuthClientID"), &oAuthClientID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter OAuthClientID: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetOauthClientsOAuthClientID(w, r, oAuthClientID)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PutOauthClientsOAuthClientID operation middleware
+func (siw *ServerInterfaceWrapper) PutOauthClientsOAuthClientID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "OAuthClientID" -------------
+	var oAuthClientID OAuthClientID
+
+	err = runtime.BindStyledParameter("simple", false, "OAuthClientID", chi.URLParam(r, "OAuthClientID"), &oAuthClientID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter OAuthClientID: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutOauthClientsOAuthClientID(w, r, oAuthClientID)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetPermissions operation middleware
+func (siw *ServerInterfaceWrapper) GetPermissions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPermissionsParams
+
+	// ------------- Optional query parameter "Offset" -------------
+	if paramValue := r.URL.Query().Get("Offset"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "Offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter Offset: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "Limit" -------------
+	if paramValue := r.URL.Query().Get("Limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "Limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter Limit: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPermissions(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostPermissions operation middleware
+func (siw *ServerInterfaceWrapper) PostPermissions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostPermissions(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeletePermissionsPermissionID operation middleware
+func (siw *ServerInterfaceWrapper) DeletePermissionsPermissionID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "PermissionID" -------------
+	var permissionID PermissionID
+
+	err = runtime.BindStyledParameter("simple", false, "PermissionID", chi.URLParam(r, "PermissionID"), &permissionID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter PermissionID: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeletePermissionsPermissionID(w, r, permissionID)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetPermissionsPermissionID operation middleware
+func (siw *ServerInterfaceWrapper) GetPermissionsPermissionID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "PermissionID" -------------
+	var permissionID PermissionID
+
+	err = runtime.BindStyledParameter("simple", false, "PermissionID", chi.URLParam(r, "PermissionID"), &permissionID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter PermissionID: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPermissionsPermissionID(w, r, permissionID)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PutPermissionsPermissionID operation middleware
+func (siw *ServerInterfaceWrapper) PutPermissionsPermissionID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "PermissionID" -------------
+	var permissionID PermissionID
+
+	err = runtime.BindStyledParameter("simple", false, "PermissionID", chi.URLParam(r, "PermissionID"), &permissionID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter PermissionID: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutPermissionsPermissionID(w, r, permissionID)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetRoles operation middleware
+func (siw *ServerInterfaceWrapper) GetRoles(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRolesParams
+
+	// ------------- Optional query parameter "Offset" -------------
+	if paramValue := r.URL.Query().Get("Offset"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "Offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter Offset: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "Limit" -------------
+	if paramValue := r.URL.Query().Get("Limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "Limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter Limit: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRoles(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostRoles operation middleware
+func (siw *ServerInterfaceWrapper) PostRoles(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostRoles(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeleteRolesRoleName operation middleware
+func (siw *ServerInterfaceWrapper) DeleteRolesRoleName(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "RoleName" -------------
+	var roleName RoleName
+
+	err = runtime.BindStyledParameter("simple", false, "RoleName", chi.URLParam(r, "RoleName"), &roleName)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter RoleName: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteRolesRoleName(w, r, roleName)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetRolesRoleName operation middleware
+func (siw *ServerInterfaceWrapper) GetRolesRoleName(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "RoleName" -------------
+	var roleName RoleName
+
+	err = runtime.BindStyledParameter("simple", false, "RoleName", chi.URLParam(r, "RoleName"), &roleName)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter RoleName: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRolesRoleName(w, r, roleName)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PutRolesRoleName operation middleware
+func (siw *ServerInterfaceWrapper) PutRolesRoleName(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "RoleName" -------------
+	var roleName RoleName
+
+	err = runtime.BindStyledParameter("simple", false, "RoleName", chi.URLParam(r, "RoleName"), &roleName)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter RoleName: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutRolesRoleName(w, r, roleName)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetTenants operation middleware
+func (siw *ServerInterfaceWrapper) GetTenants(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTenantsParams
+
+	// ------------- Optional query parameter "Offset" -------------
+	if paramValue := r.URL.Query().Get("Offset"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "Offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter Offset: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "Limit" -------------
+	if paramValue := r.URL.Query().Get("Limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "Limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter Limit: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTenants(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostTenants operation middleware
+func (siw *ServerInterfaceWrapper) PostTenants(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostTenants(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeleteTenantsTenantID operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTenantsTenantID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "TenantID" -------------
+	var tenantID TenantID
+
+	err = runtime.BindStyledParameter("simple", false, "TenantID", chi.URLParam(r, "TenantID"), &tenantID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter TenantID: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteTenantsTenantID(w, r, tenantID)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetTenantsTenantID operation middleware
+func (siw *ServerInterfaceWrapper) GetTenantsTenantID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "TenantID" -------------
+	var tenantID TenantID
+
+	err = runtime.BindStyledParameter("simple", false, "TenantID", chi.URLParam(r, "TenantID"), &tenantID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter TenantID: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTenantsTenantID(w, r, tenantID)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PutTenantsTenantID operation middleware
+func (siw *ServerInterfaceWrapper) PutTenantsTenantID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "TenantID" -------------
+	var tenantID TenantID
+
+	err = runtime.BindStyledParameter("simple", false, "TenantID", chi.URLParam(r, "TenantID"), &tenantID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter TenantID: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutTenantsTenantID(w, r, tenantID)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostTokensIntrospect operation middleware
+func (siw *ServerInterfaceWrapper) PostTokensIntrospect(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostTokensIntrospect(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostTokensLogin operation middleware
+func (siw *ServerInterfaceWrapper) PostTokensLogin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, LoginScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostTokensLoginParams
+
+	// ------------- Optional query parameter "audience" -------------
+	if paramValue := r.URL.Query().Get("audience"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "audience", r.URL.Query(), &params.Audience)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter audience: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "scope" -------------
+	if paramValue := r.URL.Query().Get("scope"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "scope", r.URL.Query(), &params.Scope)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter scope: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Device-Name" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Device-Name")]; found {
+		var XDeviceName DeviceName
+		n := len(valueList)
+		if n != 1 {
+			http.Error(w, fmt.Sprintf("Expected one value for X-Device-Name, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameter("simple", false, "X-Device-Name", valueList[0], &XDeviceName)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Invalid format for parameter X-Device-Name: %s", err), http.StatusBadRequest)
+			return
+		}
+
+		params.XDeviceName = &XDeviceName
+
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostTokensLogin(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostTokensLogout operation middleware
+func (siw *ServerInterfaceWrapper) PostTokensLogout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostTokensLogout(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostTokensMfa operation middleware
+func (siw *ServerInterfaceWrapper) PostTokensMfa(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostTokensMfaParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Device-Name" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Device-Name")]; found {
+		var XDeviceName DeviceName
+		n := len(valueList)
+		if n != 1 {
+			http.Error(w, fmt.Sprintf("Expected one value for X-Device-Name, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameter("simple", false, "X-Device-Name", valueList[0], &XDeviceName)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Invalid format for parameter X-Device-Name: %s", err), http.StatusBadRequest)
+			return
+		}
+
+		params.XDeviceName = &XDeviceName
+
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostTokensMfa(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostTokensPasskeyBegin operation middleware
+func (siw *ServerInterfaceWrapper) PostTokensPasskeyBegin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostTokensPasskeyBegin(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostTokensPasskeyFinish operation middleware
+func (siw *ServerInterfaceWrapper) PostTokensPasskeyFinish(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostTokensPasskeyFinishParams
+
+	// ------------- Optional query parameter "audience" -------------
+	if paramValue := r.URL.Query().Get("audience"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "audience", r.URL.Query(), &params.Audience)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter audience: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "scope" -------------
+	if paramValue := r.URL.Query().Get("scope"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "scope", r.URL.Query(), &params.Scope)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter scope: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Device-Name" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Device-Name")]; found {
+		var XDeviceName DeviceName
+		n := len(valueList)
+		if n != 1 {
+			http.Error(w, fmt.Sprintf("Expected one value for X-Device-Name, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameter("simple", false, "X-Device-Name", valueList[0], &XDeviceName)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Invalid format for parameter X-Device-Name: %s", err), http.StatusBadRequest)
+			return
+		}
+
+		params.XDeviceName = &XDeviceName
+
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostTokensPasskeyFinish(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostTokensRefresh operation middleware
+func (siw *ServerInterfaceWrapper) PostTokensRefresh(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostTokensRefresh(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetUsers operation middleware
+func (siw *ServerInterfaceWrapper) GetUsers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersParams
+
+	// ------------- Optional query parameter "Offset" -------------
+	if paramValue := r.URL.Query().Get("Offset"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "Offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter Offset: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "Limit" -------------
+	if paramValue := r.URL.Query().Get("Limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "Limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter Limit: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUsers(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostUsers operation middleware
+func (siw *ServerInterfaceWrapper) PostUsers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostUsers(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeleteUsersMe operation middleware
+func (siw *ServerInterfaceWrapper) DeleteUsersMe(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteUsersMe(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetUsersMe operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersMe(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUsersMe(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PutUsersMe operation middleware
+func (siw *ServerInterfaceWrapper) PutUsersMe(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutUsersMe(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostUsersMeEmailVerify operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersMeEmailVerify(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostUsersMeEmailVerify(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostUsersMeEmailVerifyConfirm operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersMeEmailVerifyConfirm(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostUsersMeEmailVerifyConfirm(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetUsersMeMfa operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersMeMfa(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUsersMeMfa(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostUsersMeMfaRecoveryCodes operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersMeMfaRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-// PutUsersMeJSONRequestBody defines body for PutUsersMe for application/json ContentType.
-type PutUsersMeJSONRequestBody PutUsersMeJSONBody
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
 
-// PutUsersUserIDJSONRequestBody defines body for PutUsersUserID for application/json ContentType.
-type PutUsersUserIDJSONRequestBody PutUsersUserIDJSONBody
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostUsersMeMfaRecoveryCodes(w, r)
+	}
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	// (POST /tokens/login)
-	PostTokensLogin(w http.ResponseWriter, r *http.Request)
+	handler(w, r.WithContext(ctx))
+}
 
-	// (POST /tokens/refresh)
-	PostTokensRefresh(w http.ResponseWriter, r *http.Request)
+// PostUsersMeMfaTotp operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersMeMfaTotp(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-	// (GET /users)
-	GetUsers(w http.ResponseWriter, r *http.Request, params GetUsersParams)
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
 
-	// (POST /users)
-	PostUsers(w http.ResponseWriter, r *http.Request)
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostUsersMeMfaTotp(w, r)
+	}
 
-	// (DELETE /users/me)
-	DeleteUsersMe(w http.ResponseWriter, r *http.Request)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	// (GET /users/me)
-	GetUsersMe(w http.ResponseWriter, r *http.Request)
+	handler(w, r.WithContext(ctx))
+}
 
-	// (PUT /users/me)
-	PutUsersMe(w http.ResponseWriter, r *http.Request)
+// PostUsersMeMfaTotpConfirm operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersMeMfaTotpConfirm(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-	// (DELETE /users/{UserID})
-	DeleteUsersUserID(w http.ResponseWriter, r *http.Request, userID UserID)
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
 
-	// (GET /users/{UserID})
-	GetUsersUserID(w http.ResponseWriter, r *http.Request, userID UserID)
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostUsersMeMfaTotpConfirm(w, r)
+	}
 
-	// (PUT /users/{UserID})
-	PutUsersUserID(w http.ResponseWriter, r *http.Request, userID UserID)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
+	handler(w, r.WithContext(ctx))
 }
 
-type MiddlewareFunc func(http.HandlerFunc) http.HandlerFunc
-
-// PostTokensLogin operation middleware
-func (siw *ServerInterfaceWrapper) PostTokensLogin(w http.ResponseWriter, r *http.Request) {
+// PostUsersMeMfaTotpDisable operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersMeMfaTotpDisable(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	ctx = context.WithValue(ctx, LoginScopes, []string{""})
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
 
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostTokensLogin(w, r)
+		siw.Handler.PostUsersMeMfaTotpDisable(w, r)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -193,12 +2477,14 @@ func (siw *ServerInterfaceWrapper) PostTokensLogin(w http.ResponseWriter, r *htt
 	handler(w, r.WithContext(ctx))
 }
 
-// PostTokensRefresh operation middleware
-func (siw *ServerInterfaceWrapper) PostTokensRefresh(w http.ResponseWriter, r *http.Request) {
+// GetUsersMePasskeys operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersMePasskeys(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostTokensRefresh(w, r)
+		siw.Handler.GetUsersMePasskeys(w, r)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -208,41 +2494,59 @@ func (siw *ServerInterfaceWrapper) PostTokensRefresh(w http.ResponseWriter, r *h
 	handler(w, r.WithContext(ctx))
 }
 
-// GetUsers operation middleware
-func (siw *ServerInterfaceWrapper) GetUsers(w http.ResponseWriter, r *http.Request) {
+// PostUsersMePasskeysBegin operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersMePasskeysBegin(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	var err error
-
 	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetUsersParams
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostUsersMePasskeysBegin(w, r)
+	}
 
-	// ------------- Optional query parameter "Offset" -------------
-	if paramValue := r.URL.Query().Get("Offset"); paramValue != "" {
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostUsersMePasskeysFinish operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersMePasskeysFinish(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostUsersMePasskeysFinish(w, r)
 	}
 
-	err = runtime.BindQueryParameter("form", true, false, "Offset", r.URL.Query(), &params.Offset)
-	if err != nil {
-		http.Error(w, fmt.Sprintf("Invalid format for parameter Offset: %s", err), http.StatusBadRequest)
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
-	// ------------- Optional query parameter "Limit" -------------
-	if paramValue := r.URL.Query().Get("Limit"); paramValue != "" {
+	handler(w, r.WithContext(ctx))
+}
 
-	}
+// DeleteUsersMePasskeysPasskeyID operation middleware
+func (siw *ServerInterfaceWrapper) DeleteUsersMePasskeysPasskeyID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-	err = runtime.BindQueryParameter("form", true, false, "Limit", r.URL.Query(), &params.Limit)
+	var err error
+
+	// ------------- Path parameter "PasskeyID" -------------
+	var passkeyID PasskeyID
+
+	err = runtime.BindStyledParameter("simple", false, "PasskeyID", chi.URLParam(r, "PasskeyID"), &passkeyID)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Invalid format for parameter Limit: %s", err), http.StatusBadRequest)
+		http.Error(w, fmt.Sprintf("Invalid format for parameter PasskeyID: %s", err), http.StatusBadRequest)
 		return
 	}
 
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetUsers(w, r, params)
+		siw.Handler.DeleteUsersMePasskeysPasskeyID(w, r, passkeyID)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -252,14 +2556,14 @@ func (siw *ServerInterfaceWrapper) GetUsers(w http.ResponseWriter, r *http.Reque
 	handler(w, r.WithContext(ctx))
 }
 
-// PostUsers operation middleware
-func (siw *ServerInterfaceWrapper) PostUsers(w http.ResponseWriter, r *http.Request) {
+// PutUsersMePassword operation middleware
+func (siw *ServerInterfaceWrapper) PutUsersMePassword(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
 
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostUsers(w, r)
+		siw.Handler.PutUsersMePassword(w, r)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -269,14 +2573,14 @@ func (siw *ServerInterfaceWrapper) PostUsers(w http.ResponseWriter, r *http.Requ
 	handler(w, r.WithContext(ctx))
 }
 
-// DeleteUsersMe operation middleware
-func (siw *ServerInterfaceWrapper) DeleteUsersMe(w http.ResponseWriter, r *http.Request) {
+// PostUsersMePasswordRemove operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersMePasswordRemove(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
 
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteUsersMe(w, r)
+		siw.Handler.PostUsersMePasswordRemove(w, r)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -286,14 +2590,14 @@ func (siw *ServerInterfaceWrapper) DeleteUsersMe(w http.ResponseWriter, r *http.
 	handler(w, r.WithContext(ctx))
 }
 
-// GetUsersMe operation middleware
-func (siw *ServerInterfaceWrapper) GetUsersMe(w http.ResponseWriter, r *http.Request) {
+// DeleteUsersMeSessions operation middleware
+func (siw *ServerInterfaceWrapper) DeleteUsersMeSessions(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
 
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetUsersMe(w, r)
+		siw.Handler.DeleteUsersMeSessions(w, r)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -303,14 +2607,14 @@ func (siw *ServerInterfaceWrapper) GetUsersMe(w http.ResponseWriter, r *http.Req
 	handler(w, r.WithContext(ctx))
 }
 
-// PutUsersMe operation middleware
-func (siw *ServerInterfaceWrapper) PutUsersMe(w http.ResponseWriter, r *http.Request) {
+// GetUsersMeSessions operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersMeSessions(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
 
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PutUsersMe(w, r)
+		siw.Handler.GetUsersMeSessions(w, r)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -404,6 +2708,34 @@ func (siw *ServerInterfaceWrapper) PutUsersUserID(w http.ResponseWriter, r *http
 	handler(w, r.WithContext(ctx))
 }
 
+// DeleteUsersUserIDSessions operation middleware
+func (siw *ServerInterfaceWrapper) DeleteUsersUserIDSessions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "UserID" -------------
+	var userID UserID
+
+	err = runtime.BindStyledParameter("simple", false, "UserID", chi.URLParam(r, "UserID"), &userID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter UserID: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx = context.WithValue(ctx, AccessTokenScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteUsersUserIDSessions(w, r, userID)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
 // Handler creates http.Handler with routing matching OpenAPI spec.
 func Handler(si ServerInterface) http.Handler {
 	return HandlerWithOptions(si, ChiServerOptions{})
@@ -441,9 +2773,123 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		HandlerMiddlewares: options.Middlewares,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/groups", wrapper.GetGroups)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/groups", wrapper.PostGroups)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/groups/{GroupID}", wrapper.DeleteGroupsGroupID)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/groups/{GroupID}", wrapper.GetGroupsGroupID)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/groups/{GroupID}", wrapper.PutGroupsGroupID)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/groups/{GroupID}/members", wrapper.GetGroupsGroupIDMembers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/groups/{GroupID}/members", wrapper.PostGroupsGroupIDMembers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/groups/{GroupID}/members/{MemberID}", wrapper.DeleteGroupsGroupIDMembersMemberID)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/keys", wrapper.GetKeys)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/keys/rotate", wrapper.PostKeysRotate)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/login-locks", wrapper.GetLoginLocks)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/login-locks/{LoginLockID}", wrapper.DeleteLoginLocksLoginLockID)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/login-locks/{LoginLockID}", wrapper.GetLoginLocksLoginLockID)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/oauth/clients", wrapper.GetOauthClients)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/oauth/clients", wrapper.PostOauthClients)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/oauth/clients/{OAuthClientID}", wrapper.DeleteOauthClientsOAuthClientID)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/oauth/clients/{OAuthClientID}", wrapper.GetOauthClientsOAuthClientID)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/oauth/clients/{OAuthClientID}", wrapper.PutOauthClientsOAuthClientID)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/permissions", wrapper.GetPermissions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/permissions", wrapper.PostPermissions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/permissions/{PermissionID}", wrapper.DeletePermissionsPermissionID)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/permissions/{PermissionID}", wrapper.GetPermissionsPermissionID)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/permissions/{PermissionID}", wrapper.PutPermissionsPermissionID)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/roles", wrapper.GetRoles)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/roles", wrapper.PostRoles)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/roles/{RoleName}", wrapper.DeleteRolesRoleName)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/roles/{RoleName}", wrapper.GetRolesRoleName)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/roles/{RoleName}", wrapper.PutRolesRoleName)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/tenants", wrapper.GetTenants)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/tenants", wrapper.PostTenants)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/tenants/{TenantID}", wrapper.DeleteTenantsTenantID)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/tenants/{TenantID}", wrapper.GetTenantsTenantID)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/tenants/{TenantID}", wrapper.PutTenantsTenantID)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/tokens/introspect", wrapper.PostTokensIntrospect)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/tokens/login", wrapper.PostTokensLogin)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/tokens/logout", wrapper.PostTokensLogout)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/tokens/mfa", wrapper.PostTokensMfa)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/tokens/passkey/begin", wrapper.PostTokensPasskeyBegin)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/tokens/passkey/finish", wrapper.PostTokensPasskeyFinish)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/tokens/refresh", wrapper.PostTokensRefresh)
 	})
@@ -462,6 +2908,51 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/users/me", wrapper.PutUsersMe)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/me/email/verify", wrapper.PostUsersMeEmailVerify)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/me/email/verify/confirm", wrapper.PostUsersMeEmailVerifyConfirm)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/me/mfa", wrapper.GetUsersMeMfa)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/me/mfa/recovery-codes", wrapper.PostUsersMeMfaRecoveryCodes)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/me/mfa/totp", wrapper.PostUsersMeMfaTotp)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/me/mfa/totp/confirm", wrapper.PostUsersMeMfaTotpConfirm)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/me/mfa/totp/disable", wrapper.PostUsersMeMfaTotpDisable)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/me/passkeys", wrapper.GetUsersMePasskeys)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/me/passkeys/begin", wrapper.PostUsersMePasskeysBegin)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/me/passkeys/finish", wrapper.PostUsersMePasskeysFinish)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/users/me/passkeys/{PasskeyID}", wrapper.DeleteUsersMePasskeysPasskeyID)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/users/me/password", wrapper.PutUsersMePassword)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/me/password/remove", wrapper.PostUsersMePasswordRemove)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/users/me/sessions", wrapper.DeleteUsersMeSessions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/me/sessions", wrapper.GetUsersMeSessions)
+	})
 	r.Group(func(r chi.Router) {
 		r.Delete(options.BaseURL+"/users/{UserID}", wrapper.DeleteUsersUserID)
 	})
@@ -471,6 +2962,9 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/users/{UserID}", wrapper.PutUsersUserID)
 	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/users/{UserID}/sessions", wrapper.DeleteUsersUserIDSessions)
+	})
 
 	return r
 }
@@ -478,24 +2972,93 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xZX2/bNhD/KgW3R9VyvWTA9LSszYYM6TakCfZg5IGVzjZbUWR5VDIj0HcfjqQs2Zbs",
-	"GIjTbtFb4jveP/7ufifpgaVKalVAYZElD0xzwyVYMO6/SyGFpT9EwRL2pQSzZBEruASWBGHEMF2A5KSV",
-	"wYyXuWXJ6ThidqlJSxQW5mBYVUXsz9kModdekLYNSlEIWUqWdNu7QTAX71b2NLeLxlwQRszAl1IYyFhi",
-	"TQlt88EkWiOKOavIpBe65M+NUeaimClXF6M0GCvAiVKVQYeBiElA5PMuWdUOZOotNPq3q/zUx0+QWrJ1",
-	"KdC+B8u33ef1tWzWJGJqVeJtmVWW512ijdjycLGqvhB/sCvGa/UZiu4awT9aGMAzF8xMGcktS1jGLby2",
-	"QlLyW9UTiCVkh5yw5H9/tb1ay0HUCm9nXridGE9TQLyuPX9vYMYS9l3cNFIcUBQ35XEBzQzg4tCDG6m0",
-	"vW/Y7E3kymttp7IZ0u4q7nVGLffWALfQAQfJRd7ZM7mai+Ii65RpjnivTI9woYruNjQqh30lpmCvSG8L",
-	"/iGelvdgsXYZhWz6atDTEL0VENnhhTlO7oJybQpwcNY0s7Yzl2B5xv0k2xXWauJVESsxcJCwIPExCdV9",
-	"FoLjxvDlVoLebNSE1JfNVSgjFMQ/U8YzKajjyEDrUFN3OnSjswPB/zUAfjisiRghLY2wyw9k3Wd1tj4I",
-	"PwI3YH6t5/bvf1/XXE6mvLSZ4QtrtWM5AhsdbzQ5inRTkUIQoa1SVVie2lZhGZYaS33648nk5zn9NEqV",
-	"JOsZYGqEtkLRgoBY6slnsK94aRevEMydSCntXKRQoCtoWB7ONE8X8GoyGtOVmzzEkcTx/f39iDvpSJl5",
-	"HI5ifHnx9vyPD+evJ6PxaGFl7pAobA47/N6BQR/Zm9F4NHb8raHgWrCE/eB+itxW48odOxbDOK8rppVv",
-	"NkIapxRpVrC/FFp3J+hLS3ePWlGQpDwZj+saQuGOc61zkToD8SdUzWXwR7MU+gtarzalc/KE3pp9rNfZ",
-	"m+dzdvp8mbW6jyXTh7plprcVdSqfY7Pi3JJyDRTTov09UKk3BD8oAO0vKls+LU5qF1Xl59GxMTlA8riQ",
-	"7ALeirTn0AG338DeBPptP2ZOuyNpVOLwZFhFezX9Myn1xdEAtrbqvDiMnYxPXsSMXdtt1iet3wBpPesd",
-	"qjXMjzFMWw9ZRx6lzVL9TaD8pwF4DnirQRtL8G/dcvAPHetAfOd+d1B8Dz1r4DBNvplpspMyey/w/9jo",
-	"AwC/Dp2VXWxWrgHwOHQWXpv009kAyZdNdA/+Y071SLprffoZKO+/Snk7L3GgvQGET0V7B72KCKgkSzv5",
-	"cu3r88CZA56fizPdEXNXw/lxH0DWvnDUSv4bym31bwAAAP//RHpfQSMiAAA=",
+	"H4sIAAAAAAAC/+xde3PcuJH/KiheqpJU0TOOd5Oq6K/TWvaespalk+UkVWvfFjTsmUHEIRgAlDyn03e/",
+	"woMkSIIv7VCaWeEvW0OgATR+/QIawH2woJuUJpAIHhzdBylmeAMCmPrrOIsIJAuQ/4+ALxhJBaFJcFR8",
+	"QXSJBL2BhM/QCSxxFguE82+Eo4xDhMgSJVQgDmIWhAGR9f+dAdsGYZDgDQRHQV4lCAO+WMMGyxbFNpXf",
+	"uGAkWQUPD2FwArdkAR9VnXtNaA04AlZS+ucrXeiVKtVN7kdGs/T0pKCVYrEuKeVfw4DBvzPCIAqOBMug",
+	"m+YHsiGioFgbpv5oE4g0z4KjP78Oc2okEbACpsnRFUk+0MVNazftEuO6egaba2CthIvP46ieH2di/TYm",
+	"kIhW0tUyI+kvlxxaOWy+2iQ2JCGbbBMcuTl8gTm/gW1rX8vv4/p5AWxDOCc0aSdtFxlH/ZLGVTmoUi4+",
+	"j6P6aUFTh7B/SvECEAepHAREiMti3Bb9K/UvWuNbQDiOUVqMTBdbA2I0hgGaQNHukdsrSHAHuorP4wb/",
+	"mXcIg/k4huJD/lGp0ncbTOK/AyPL7VuaLAnbyF9TRlNggoAqo7jZZL+qim5lXbLA8kfNd8QhEUhQxV6Q",
+	"hYLQMa6yxz+bFr4Wxej1v2AhgocweMcYZafJkja7taAROAYYBhvgHK/AzU67WUWhLO9qX2nbtwywgGYP",
+	"KvxwdCQxktD4IEHXpKDEg6MVw4mEs6AKsxFhsBAIJxFKgMsPG6UBCwSvZBclbAVsuLM58wNmDG8bTEi0",
+	"PNo9yTvYypCWCVFsio6VFlxStsEiOAoiLOCVIKqRRs/6OEiiRzJ2KDPCQGixjPrhQqLAKh52cS60mNHJ",
+	"xQ+EiyYn1ZRWx/E7BsvgKPiPeekYzY0cz8s56ZtsQ7i1S9q4tuFdA0+zqorc0xOJRiz9KoYoQ1ijEpFE",
+	"QZTjDSDNORcKNN2rrVbykEir+HMgaQWh7nLwtVGtNrKibxVyPQPdGY5VJ0+jFnVUcq1n6N1DzBsJWwY7",
+	"CHTlwN3Q0/RGYq8k2ovAnH5rBz+nkVe2hiFyis5A4CY34tyfr7uOYUALT7T5TVCBY9enWj9jExHQ3G/V",
+	"FZ19LBx9pzAtMYkzBtzdnxb9HmMu3mMSjxPCmC5uwCZ4TWkMOCm/fU4EiYcT5JkepXOa281GTqlbcAq+",
+	"Kdl12xot1ZbJyXsUlnytcas61Pyv/plza4M4LzJcIVSo9gqE1UBnF+vGQdVTLCEu4xAGZ++P3xoH0e02",
+	"VrXB1fnVBZKfQmm+GCzoLbCt+gXBtwWkAi0pUyrAFFXOMjYiPMDPdA3v7P2xW2ryDsghOHTXx0wqJamT",
+	"skQtZVQ6zIPQLfrpuwRfx20iYhUYLnUNR75sJKyNooUBl/WhjuLEeaJ7VuPADF2tYYswA8TX9C5BNIm3",
+	"iCYLmD1eeQ8ajkTHu4TRON5A4pAoDgsGojmQHzCH794gSGT/Iw0yU9aB7oyRJgkqUpyJNfp8earA+t+X",
+	"mhnKNcvEGhIhAzXpnqUp753LonXZmGu01nJJm8eozKuU3eH6Q1H9Ma/n8thbLX2aXcdk4UY3A23bPzMy",
+	"MkDQywpj6riNfqUHoc2aoo1iCD3s3qHfOsH8jA3a9mXawlbh/KR+1yGO0vyRlCUco4Wajhk6FXJVmYHI",
+	"WAKR1jZ3a9DRjy4kC5gZmvXKHrHiyzG46QsBahhyG33d35GIKIn2ykNOv6eDbYHA0+qUg9AbrZwsx2z5",
+	"TtIWUEb+Vzkvv5hFMD0pvywYGGxz1fSSAV//Ul+fKwdglqGPOZcTRJO3Rf2mFJXfpCQl+JaspDWaWW3O",
+	"ViD+8McZ+oEkmG3RLY4z4MqMX2MOf/k+Y3FuIqUQVYHRoncY8JQmvNchrw/lMq9nOfRDFoi001606pqb",
+	"1qYaYK/Y7RMssHOIevLk5799Ov/oLMLJKsEiY26Uy7WW/8JJFA8YZa2x0NFHu7mu8QsBXOBd4EarveeG",
+	"TjmeJwCPo7EmfMpC5+2x7AD4CIYTnlImfo1CayKn0b2OAe/M6elYdPjMx5FqNRs74ZftBVgE+4y8xS+3",
+	"gU91geH2056Bvk4XxDu6psL69yQhfO2Y0TWOY0hW0LK4sqioijEa3VIyDWhajVaa6BjFuVJQvHcErqVy",
+	"5RfmxeTq41JxQ/8ODDY02boAp/28n2DrCIXVf3i/jlTBIYMV4YJhU4d12WNVQa248MH6tca0TnaXg+rg",
+	"9qXV4aeDjtNCWYJfW5eR+xxmdo0czNCXfARfgiL15HqLTIpFfywwDprFDndbSI4X+RJ6bT0cVlmMGYJv",
+	"KQNFQY5E1tR7u7oeRzG5AfQl+J8/xISL/1uB+OPvvgQuqNLC4IxrSNcrGpKuibsBnrW1IPf0EzUXTGcF",
+	"oDsitHB90T7z0ZcApQyW5Fsv/8s1V5r/x/CwewLc9qpkvwudOzJltN3Wt69pu4xP+9h7jVCFDy12qCgz",
+	"whRV+dtrjawmurvZFm56eZlUXmTbO8+vkLr3jrLoDH87XsEJ3jbJBWf4G8Irpa7z4sp2Sv6plCGSrKwM",
+	"oQRFeMtn6LWylVTbP7QBnHAk1lhYRCKa/F7I6SJMLTd3ZXrZywS1NSf1O7pbk8UaLXCCrsHerdQpTnYW",
+	"0453JTuWFuScPVkKyMhJ7mLwBOxxdqNPN+b8c2vFZg5Llz4s5qJvFO27zJLEY3fdvaSNgtJgEfsEHT7E",
+	"I6RskTFm9qOai+xRJX+5UVfzl+/ALyHpziJvCZ7jVXVIXZ6MNUa7suqTLa+V3thjL3nYM19uqeYw0tGx",
+	"KPbCqiDu6prOO32sjSWOEFZTRKcnoREawhGOuc745Nl1RDeYJLnEtOV9uWepbKd9LM+bftjX7z7tX47B",
+	"jRTNr+FAKen14iQn3d6tx9mBDi3nbEpqVJU+/JEKlXxMwAEzlfurN93U4oN2SnGik4pVxvatqZxbESz9",
+	"7gVwblKRS52utuRU8W0lMbnu7MvKV3nCcyfbZaGc6zvPRQ4rXWllolsSHqOwOc/GyU6RFT4ktdtqwNap",
+	"nePirljscdNj9rHGVqwNxW69RrNjIIJRnpqwa1Be/bENYEERKUk8Po++1hkjxs1I99axqvWPNYg16OQn",
+	"3SvCpVdGIpUoSbh0whjc0huIZuhclS0p6yQcEFoC5Uoi1sHxraFmrYBZPgm2zni1bFi0LPI9CfpVDdaa",
+	"b+r88KiMhK4URDWnUeuGXsenDyZ9rld8DSZaIfVT274Mjle7WuTanf/JQMiBjaHEBRYZH6Q0foLtJ116",
+	"YApoXqs3A1Qys+hKr39hzYvbwxi191OZ5j4Ho3Xjp8YgKxshhSSSvJammGywOnRl5smZcVBhmkVHa+dS",
+	"MbdXPnt/PDottJEV6jzHsMRXbp1+9v64st1zA4V7rFybXs1ekA7bs0nzwV0W9Xo9Kt2E9JxkD3MnCjm6",
+	"a7n68G2xxskKIp0Sq8Nj7Zvpmpo9v/q0VpWhj7PZDbeqINnKQblbcxe9+5b28TAxKw9RsSIx0A3V7Ct2",
+	"iWTNg/dEL43UORJ4q75Xd+O9XpU88dgWy2qf3rnC0GrvyiUs98c1TaDTzHfNjOysXF1zp73rvc8SAGZp",
+	"STcZmtG08aDF82/lABQHPZ2xlu3jqaLKxzPFZ+itRGy+TKe/M+AgOCLC7b9V2ttFdkbXDO5okqqnOpxr",
+	"HkZpSx8qlCyg8a3Ug4xu1O//fKXLvTo9QfoKgLC+KKJ5bKuHYUsj1imQEj1OyNQnuwtCbYfABI5Mslnn",
+	"WY/8fJJxK4d7FQWE+zwKTTYsu9Q2Gq22L2FDb8Gd8pILeS27TK/q2Zq4ezaKgt09aVtJSeDuolPjTNDT",
+	"sNJqW78vjbC0bA7OEI42JAnNgt4r9ZeKANWpT7W/oII94552JznoJtuY1K7H2tnzEe5KQywDzuutMsSy",
+	"m+o8SPlVh6y5F0OWiIjfcwSbVGxn6LPalljgsoiUWMKQPEdSkFD+DtFHBTcZF5IJFYuOji9Oy23URW3u",
+	"nByZyNgMtCs6/z1jRGw/SZJ6Jo6rfsg1YAbsfa7J//aPq/xSBKX/1ddyaGsh0uIYl6xelsScLOoFZReI",
+	"sWsLmgisA18DhoBnKc/SP//l+zf/uZI/zRZ001jFDTjP0jc3INSRF8SByXV/qTTJAkyOpLk84TjFizWg",
+	"N7PX6pBLbPpxNJ/f3d3NsPo6o2w1N1X5/MPp23cfP7179Wb2erYWG5UJJIiIoaPdW2Bc9+xPs9ez17IK",
+	"TSHBKQmOgu/UT6G61UGxe14e/V7pkwjFRr+0SsGPIH7UJcLKpTg/u7FRFpmbK0gewt6S+jqYh69lHqrq",
+	"z5vXr/OJMdsuOE1jc/XD/F+cljOMB59YV/bn4aExi5JN3++wwfIeidbG/vR0jf356UZmSbVCSUWef/4q",
+	"Z1ngFS/OlwdfpSKi3IG9C8pL8EkFA1z8QKPtblFhnPuHh4eHpwDgywPf96//6pGukf4Q5gp3fm/u1XrQ",
+	"jkUMApoScKJ+VyW5fQ9XE6QvC1Dfe0DlqrPbaneDxms2D8Td2fBR3mEOTEkrzVzGP3NAeCIXwESF7S6A",
+	"B7X3DZ7DN5hbtyMNUvRnpvzhB2u1e6O8bfG25VG2pSeybMjNZEamctXdU0Sb9iVpXnq8EXtuIza/z69S",
+	"Hhvz6nq8chOzd9I85H6ddej3dQrAPchm5nkaU5sn9hNs+ZSRdiPRaj+Q+J3XtE8I+xt5av0hh+OcUZFv",
+	"aLZ6OhKXl7rccM3pIfQCIKQSO17F+X2bbYrtQ3lp5kEHls0LSP1O4F4gsriVtYnL+b31sscAx7Eozesv",
+	"gniP0aOsQFk4QNn1A2j3WsmH6i8emOMMrA1SHaZQmRA0ty7VbEP6OS7uvjxww+66ZtSb9r3AtkJjT5JP",
+	"DYhTLME2746eeAm2cUmth+M+wbGhKOf3ldfYBniaNmqbL7l5b9MjzSi+IfZ3CIC8XvLQ3LVNHufzVUDa",
+	"lbrTDe1JzbtP5vFgb7P4tQsg2xTzhVXsoOMix6WY3g/dC1SWSOyJjapYnEJ3Nq7wnTgyqt+o6s9DvGz0",
+	"1xTz/N5+D3pAHFYW542XpL3190iz9ewAiz8AQ14NenBO4QSMcjQrOO2KxbqwPaU34QMxn5C6Px5Gcct1",
+	"mwlQ7zMfdrhXuefbB3p7AUSJu54QL0feFOrYeu5g4rCuvBjeB3QvFeWFop3fSzjI288HBHCyKM/L+7DN",
+	"+wrPpaI7XYMegHod6qPBXXkKozzQApddMWATwdO4Gj7m85hu8QusZyba9OyVKXLQQVjtuQ0fhu0FEDX6",
+	"egKxEn9T6MfKuzgTB2P2Gy0+HHu5aLcU7/zegGLInpouyvMaPijzQdnzKewed6EHpF6fejd2l97DKM+0",
+	"wGZXcObC8VTuhw/QPLLbPQVZgs9J9Q2xdndZFbdeHJsIuLV3zaZ2nZsvl/kYbj+wqn6sQDXOL3rvQ6m+",
+	"EX6s+j4pH3EdsLhwnD8hN6CsegN44oWI8mHDQwQwTeB82TpJjmbDAeywX6t6+Oro6T8YTVbo9GSeP5wQ",
+	"IsrUK1OEo/yRA/26g/yxeJ5wyBtVs8dcdjKMDY6HpAbxo/EoqpMpF+U7FsUTVJQV7/bIhyvKp3uuaq/N",
+	"dD5DJcnUH0nVbHrzRBGQUgxyYPK2Cf2IyBKTGCL9YhmvPJuGTk/yB3X0sUF0eqEAYuyefo+EYQEoliuN",
+	"EM2eWZ1+0ApyiCKlmRioSWXJvbxJ6GDs1maJh/D6bIl/lc36OqFHJl81fBpXbB8sWK7Dpaa4kzZitvfX",
+	"ZHnL4S3HOIXWobAk229gO7+GgQ73ha7wA2i/e7o0et3OuRoIf/YFVdMdCYuIcHwdQ/S0qLw8LDwtSUL4",
+	"egSg3usK+xnJTZBOr0etdI0Z+ssxuk9qYnPJpcx6irhm7b/bLZebNrTZrXcjTaXbJr4Y9feijDKz3kDu",
+	"0Z75c8kTBgR5E14/+RDgeUOA5xfQ4nHitr1r9ejrYSe6VZ509rviL29dS4K8J6Muh/kURsd6mX9ik1M+",
+	"Hr4XKP+rBx4wW9HON9Cf0qageLa3V+B7bZJrk06TeTbp+Zs9E3QPwOcxZy15WjYApzFnPjvLQ7LH0M1V",
+	"mDPXcU935G/wqsK0v+vyQ1ClipqRlwkOHBKBBLUiLRWspQxuCc14nvIgF0xIcotjEuF8vcTnaf/qxvJV",
+	"QIRjBjjaliHz4YJ3vqDJkrDNWBC/NdWmUcOOhrw6vsq1gFoMD+11nIyDzpEqFIMslydD4aUA/Ukrkjus",
+	"NYnfE3NJh8kK6XGAdVLIZD7w2ftj7wLstxbdLPGcwYLeAtu+WtAI+CAderbEl6bWW1VpGg169v5Ykp96",
+	"UUalctqj8SlCv1Hn5+r86sLsFEBS7ikelrwKKtKhUnoly04rOpKn7xJG43gjyfkTlhODt3DcDYLttPLK",
+	"TvnhoXqUH2/QPa0P/1ItkMKaN0FPJ8VSiBMqzZLUpL8tqTb9HyPVJ6bKc0m1d/6887d3AmUyXfmAyP4i",
+	"Lzp9vvRevSF/eJM5JA++Nql7kwnvVdJESa4HCOMh6fc1HBcJ+BOmu1/CinCh+/E0We+WWnx2N94sV1vr",
+	"7ZQhM2Vm40c3alKbZ7/dK7Z+Q6J2n0NsyP1bNZkrqo7OGC1rtuWC+pSCQxeSD5iLQj+Y8w0Sf+r+AZqJ",
+	"ImH6AEVHdluZp+6cnDzJfMLcHNVE5DN0/PbcUNzOGWzoLQz2rmSdS11lahibZjyM7dMp9rrNb9FOyOlH",
+	"a8xRQnNzcWAWgUP5eOsgB+oTWE9n+utKdpqI3cPbnYzKtOFXq8aLy71KZh8cbOjS/nTEQQtl5yT6ExIe",
+	"hLs6ITFqDcKgsusK3Dp6/fEKj+dntJljHU1d7RHOpr9A9pD0l26L3eb1ql3hPEvf3IBAOBNrJMuRBQRh",
+	"kLE4OArWQqRH83leaLagG0nx/wcAyp1u0Xn6AAA=",
 }
 
 // GetSwagger returns the Swagger specification corresponding to the generated code
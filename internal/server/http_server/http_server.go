@@ -7,11 +7,12 @@ import (
 
 	"github.com/casbin/casbin"
 	"github.com/go-chi/chi/v5"
-	"github.com/go-chi/chi/v5/middleware"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
 	"github.com/rs/zerolog/hlog"
 	"github.com/rs/zerolog/log"
 
 	"github.com/ssup2ket/service-auth/internal/domain"
+	"github.com/ssup2ket/service-auth/internal/server/middleware"
 )
 
 // ServerHTTP
@@ -22,7 +23,8 @@ type ServerHTTP struct {
 	domain *domain.Domain
 }
 
-func New(d *domain.Domain, url string, e *casbin.Enforcer) (*ServerHTTP, error) {
+func New(d *domain.Domain, url, tenantDomain string, e *casbin.SyncedEnforcer, limiter *middleware.RateLimiter,
+	trustedProxies middleware.TrustedProxies) (*ServerHTTP, error) {
 	server := ServerHTTP{}
 	serverWrapper := ServerInterfaceWrapper{
 		Handler: &server,
@@ -30,21 +32,45 @@ func New(d *domain.Domain, url string, e *casbin.Enforcer) (*ServerHTTP, error)
 
 	// Set middlewares
 	r := chi.NewRouter()
-	r.Use(middleware.Heartbeat("/healthz"))
-	r.Use(middleware.Recoverer)
-	r.Use(middleware.RealIP)
+	r.Use(chimiddleware.Heartbeat("/healthz"))
+	r.Use(chimiddleware.Recoverer)
+	r.Use(mwRealIPSetter(trustedProxies))
 
 	r.Use(hlog.NewHandler(log.Logger))
 	r.Use(mwRequestIDSetter())
 	r.Use(mwOpenTracingSetter())
 	r.Use(hlog.AccessHandler(mwAccessLogger))
+	r.Use(mwTenantIDSetter(tenantDomain))
 
 	// Set handlers
+	r.Group(func(r chi.Router) {
+		r.Use(mwRateLimiter(limiter))
+
+		r.Get("/.well-known/jwks.json", getJWKSHandler())
+		r.Get("/.well-known/openid-configuration", getOIDCConfigHandler(d.Configs))
+	})
+	r.Route("/oauth2", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(mwRateLimiter(limiter))
+
+			r.Get("/authorize", getOAuthAuthorizeHandler(d))
+			r.Post("/authorize", getOAuthAuthorizeConsentHandler(d))
+			r.Post("/token", getOAuthTokenHandler(d))
+			r.Post("/revoke", getOAuthRevokeHandler(d))
+		})
+		r.Group(func(r chi.Router) {
+			r.Use(mwAccessTokenValidatorAndSetter(d.TokenRevocation))
+			r.Use(mwRateLimiter(limiter))
+
+			r.Get("/userinfo", getOAuthUserInfoHandler(d))
+		})
+	})
 	r.Route("/v1", func(r chi.Router) {
 		// Auth
 		r.Group(func(r chi.Router) {
 			// Set Auth middlewares
-			r.Use(mwAccessTokenValidatorAndSetter())
+			r.Use(mwAccessTokenValidatorAndSetter(d.TokenRevocation))
+			r.Use(mwRateLimiter(limiter))
 			r.Use(mwAuthorizer(e))
 
 			// User
@@ -58,17 +84,98 @@ func New(d *domain.Domain, url string, e *casbin.Enforcer) (*ServerHTTP, error)
 				r.Get("/users/me", serverWrapper.GetUsersMe)
 				r.Put("/users/me", serverWrapper.PutUsersMe)
 				r.Delete("/users/me", serverWrapper.DeleteUsersMe)
+				r.Put("/users/me/password", serverWrapper.PutUsersMePassword)
+				r.Post("/users/me/password/remove", serverWrapper.PostUsersMePasswordRemove)
+				r.Get("/users/me/sessions", serverWrapper.GetUsersMeSessions)
+				r.Delete("/users/me/sessions", serverWrapper.DeleteUsersMeSessions)
+				r.Get("/users/me/mfa", serverWrapper.GetUsersMeMfa)
+				r.Post("/users/me/mfa/totp", serverWrapper.PostUsersMeMfaTotp)
+				r.Post("/users/me/mfa/totp/confirm", serverWrapper.PostUsersMeMfaTotpConfirm)
+				r.Post("/users/me/mfa/totp/disable", serverWrapper.PostUsersMeMfaTotpDisable)
+				r.Post("/users/me/mfa/recovery-codes", serverWrapper.PostUsersMeMfaRecoveryCodes)
+				r.Get("/users/me/passkeys", serverWrapper.GetUsersMePasskeys)
+				r.Post("/users/me/passkeys/begin", serverWrapper.PostUsersMePasskeysBegin)
+				r.Post("/users/me/passkeys/finish", serverWrapper.PostUsersMePasskeysFinish)
+				r.Delete("/users/me/passkeys/{PasskeyID}", serverWrapper.DeleteUsersMePasskeysPasskeyID)
+				r.Post("/users/me/email/verify", serverWrapper.PostUsersMeEmailVerify)
+				r.Delete("/users/{UserID}/sessions", serverWrapper.DeleteUsersUserIDSessions)
 			})
+
+			// Token
+			r.Post("/tokens/logout", serverWrapper.PostTokensLogout)
+			r.Post("/tokens/introspect", serverWrapper.PostTokensIntrospect)
+
+			// Key
+			r.Get("/keys", serverWrapper.GetKeys)
+			r.Post("/keys/rotate", serverWrapper.PostKeysRotate)
+
+			// OAuth client
+			r.Get("/oauth/clients", serverWrapper.GetOauthClients)
+			r.Post("/oauth/clients", serverWrapper.PostOauthClients)
+			r.Get("/oauth/clients/{OAuthClientID}", serverWrapper.GetOauthClientsOAuthClientID)
+			r.Put("/oauth/clients/{OAuthClientID}", serverWrapper.PutOauthClientsOAuthClientID)
+			r.Delete("/oauth/clients/{OAuthClientID}", serverWrapper.DeleteOauthClientsOAuthClientID)
+
+			// Role
+			r.Get("/roles", serverWrapper.GetRoles)
+			r.Post("/roles", serverWrapper.PostRoles)
+			r.Get("/roles/{RoleName}", serverWrapper.GetRolesRoleName)
+			r.Put("/roles/{RoleName}", serverWrapper.PutRolesRoleName)
+			r.Delete("/roles/{RoleName}", serverWrapper.DeleteRolesRoleName)
+
+			// Permission
+			r.Get("/permissions", serverWrapper.GetPermissions)
+			r.Post("/permissions", serverWrapper.PostPermissions)
+			r.Get("/permissions/{PermissionID}", serverWrapper.GetPermissionsPermissionID)
+			r.Put("/permissions/{PermissionID}", serverWrapper.PutPermissionsPermissionID)
+			r.Delete("/permissions/{PermissionID}", serverWrapper.DeletePermissionsPermissionID)
+
+			// Tenant
+			r.Get("/tenants", serverWrapper.GetTenants)
+			r.Post("/tenants", serverWrapper.PostTenants)
+			r.Get("/tenants/{TenantID}", serverWrapper.GetTenantsTenantID)
+			r.Put("/tenants/{TenantID}", serverWrapper.PutTenantsTenantID)
+			r.Delete("/tenants/{TenantID}", serverWrapper.DeleteTenantsTenantID)
+
+			// Group
+			r.Get("/groups", serverWrapper.GetGroups)
+			r.Post("/groups", serverWrapper.PostGroups)
+			r.Get("/groups/{GroupID}", serverWrapper.GetGroupsGroupID)
+			r.Put("/groups/{GroupID}", serverWrapper.PutGroupsGroupID)
+			r.Delete("/groups/{GroupID}", serverWrapper.DeleteGroupsGroupID)
+			r.Get("/groups/{GroupID}/members", serverWrapper.GetGroupsGroupIDMembers)
+			r.Post("/groups/{GroupID}/members", serverWrapper.PostGroupsGroupIDMembers)
+			r.Delete("/groups/{GroupID}/members/{MemberID}", serverWrapper.DeleteGroupsGroupIDMembersMemberID)
+
+			// Login lock
+			r.Get("/login-locks", serverWrapper.GetLoginLocks)
+			r.Get("/login-locks/{LoginLockID}", serverWrapper.GetLoginLocksLoginLockID)
+			r.Delete("/login-locks/{LoginLockID}", serverWrapper.DeleteLoginLocksLoginLockID)
+		})
+
+		// Optional auth. A user created without access token has the default role
+		r.Group(func(r chi.Router) {
+			r.Use(mwAccessTokenOptional(mwAccessTokenValidatorAndSetter(d.TokenRevocation)))
+			r.Use(mwRateLimiter(limiter))
+			r.Use(mwAccessTokenOptional(mwAuthorizer(e)))
+
+			// User
+			r.Post("/users", serverWrapper.PostUsers)
 		})
 
 		// Noauth
 		r.Group(func(r chi.Router) {
+			r.Use(mwRateLimiter(limiter))
+
 			// Token
 			r.Post("/tokens/login", serverWrapper.PostTokensLogin)
+			r.Post("/tokens/mfa", serverWrapper.PostTokensMfa)
+			r.Post("/tokens/passkey/begin", serverWrapper.PostTokensPasskeyBegin)
+			r.Post("/tokens/passkey/finish", serverWrapper.PostTokensPasskeyFinish)
 			r.Post("/tokens/refresh", serverWrapper.PostTokensRefresh)
 
 			// User
-			r.Post("/users", serverWrapper.PostUsers)
+			r.Post("/users/me/email/verify/confirm", serverWrapper.PostUsersMeEmailVerifyConfirm)
 
 			// Swagger
 			r.Get("/", getSwaggerUIHandler(url))
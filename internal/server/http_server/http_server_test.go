@@ -0,0 +1,193 @@
+package http_server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/casbin/casbin"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ssup2ket/service-auth/internal/domain"
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/server/middleware"
+	"github.com/ssup2ket/service-auth/internal/test"
+)
+
+// Routes not authorized by the permission catalogue. OAuth2 and OpenID Connect routes are authorized
+// by their own grants, and swagger routes are public.
+var routesNotInCatalogue = map[string]struct{}{
+	"GET /.well-known/openid-configuration": {},
+	"GET /oauth2/authorize":                 {},
+	"POST /oauth2/authorize":                {},
+	"POST /oauth2/token":                    {},
+	"POST /oauth2/revoke":                   {},
+	"GET /oauth2/userinfo":                  {},
+	"GET /v1/":                              {},
+	"GET /v1/swagger/ui":                    {},
+	"GET /v1/swagger/spec":                  {},
+}
+
+type routeSuite struct {
+	suite.Suite
+
+	server *ServerHTTP
+}
+
+func TestRoute(t *testing.T) {
+	suite.Run(t, new(routeSuite))
+}
+
+func (r *routeSuite) SetupTest() {
+	var err error
+	r.server, err = New(&domain.Domain{}, "http://localhost", "", nil, nil, nil)
+	require.NoError(r.T(), err)
+}
+
+func (r *routeSuite) TestRoutesInCatalogue() {
+	err := chi.Walk(r.server.router, func(method string, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) error {
+		if _, ok := routesNotInCatalogue[method+" "+route]; ok {
+			return nil
+		}
+		_, ok := middleware.GetHTTPOperation(method, route)
+		require.True(r.T(), ok, "%s %s isn't in the permission catalogue", method, route)
+		return nil
+	})
+	require.NoError(r.T(), err)
+}
+
+func (r *routeSuite) TestCatalogueInRoutes() {
+	routes := map[string]struct{}{}
+	err := chi.Walk(r.server.router, func(method string, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) error {
+		routes[method+" "+route] = struct{}{}
+		return nil
+	})
+	require.NoError(r.T(), err)
+
+	for _, route := range middleware.GetHTTPRoutes() {
+		_, ok := routes[route]
+		require.True(r.T(), ok, "%s of the permission catalogue isn't registered", route)
+	}
+}
+
+func (r *routeSuite) TestAuthorizerRoutePattern() {
+	e := casbin.NewSyncedEnforcer("../../../configs/rbac_model.conf", "../../../configs/rbac_policy.csv")
+	ok := func(w http.ResponseWriter, r *http.Request) {}
+
+	router := chi.NewRouter()
+	router.Route("/v1", func(router chi.Router) {
+		router.Group(func(router chi.Router) {
+			router.Use(func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					ctx := middleware.SetUserRolesToCtx(r.Context(), []entity.UserRole{entity.UserRoleUser})
+					ctx = middleware.SetScopesToCtx(ctx, nil)
+					ctx = middleware.SetClientIDToCtx(ctx, "")
+					next.ServeHTTP(w, r.WithContext(ctx))
+				})
+			})
+			router.Use(mwAuthorizer(e))
+
+			router.Get("/users/me", ok)
+			router.Get("/users/{UserID}", ok)
+			router.Get("/unknown", ok)
+		})
+	})
+
+	codes := map[string]int{
+		"/v1/users/me": http.StatusOK,
+		"/v1/users/" + test.UserIDCorrect.String(): http.StatusUnauthorized,
+		"/v1/unknown": http.StatusUnauthorized,
+	}
+	for path, code := range codes {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(r.T(), code, recorder.Code, path)
+	}
+}
+
+func (r *routeSuite) TestAccessTokenOptional() {
+	e := casbin.NewSyncedEnforcer("../../../configs/rbac_model.conf", "../../../configs/rbac_policy.csv")
+	ok := func(w http.ResponseWriter, r *http.Request) {}
+
+	router := chi.NewRouter()
+	router.Route("/v1", func(router chi.Router) {
+		router.Group(func(router chi.Router) {
+			router.Use(mwAccessTokenOptional(func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					ctx := middleware.SetUserRolesToCtx(r.Context(), []entity.UserRole{entity.UserRoleUser})
+					ctx = middleware.SetScopesToCtx(ctx, nil)
+					ctx = middleware.SetClientIDToCtx(ctx, "")
+					next.ServeHTTP(w, r.WithContext(ctx))
+				})
+			}))
+			router.Use(mwAccessTokenOptional(mwAuthorizer(e)))
+
+			router.Post("/users", ok)
+		})
+	})
+
+	// Requests without access token are public, and requests with access token are authorized by the role
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/v1/users", nil))
+	require.Equal(r.T(), http.StatusOK, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/v1/users", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	router.ServeHTTP(recorder, req)
+	require.Equal(r.T(), http.StatusUnauthorized, recorder.Code)
+}
+
+func (r *routeSuite) TestRealIPSetter() {
+	trustedProxies, err := middleware.ParseTrustedProxies([]string{"10.0.0.0/8"})
+	require.NoError(r.T(), err)
+	clientIP := ""
+	handler := mwRealIPSetter(trustedProxies)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientIP = getClientIP(r)
+	}))
+
+	// Forwarded headers are used only from trusted proxies
+	for remoteAddr, ip := range map[string]string{"10.0.0.1:1234": "2.2.2.2", "1.1.1.1:1234": "1.1.1.1"} {
+		req := httptest.NewRequest(http.MethodGet, "/v1/users", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set(middleware.HeaderForwardedFor, "2.2.2.2")
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+		require.Equal(r.T(), ip, clientIP, remoteAddr)
+	}
+}
+
+func (r *routeSuite) TestRateLimiterRoutePattern() {
+	limits, err := middleware.ParseRateLimits([]string{"user:get=1/1m/ip"})
+	require.NoError(r.T(), err)
+	ok := func(w http.ResponseWriter, r *http.Request) {}
+
+	router := chi.NewRouter()
+	router.Route("/v1", func(router chi.Router) {
+		router.Group(func(router chi.Router) {
+			router.Use(mwRateLimiter(middleware.NewRateLimiter(limits)))
+
+			router.Get("/users/{UserID}", ok)
+			router.Get("/users/me", ok)
+		})
+	})
+
+	// Users are limited by the operation of the route pattern, and other routes aren't limited
+	path := "/v1/users/" + test.UserIDCorrect.String()
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+	require.Equal(r.T(), http.StatusOK, recorder.Code)
+	require.Equal(r.T(), "1", recorder.Header().Get(middleware.HeaderRateLimitLimit))
+	require.Equal(r.T(), "0", recorder.Header().Get(middleware.HeaderRateLimitRemaining))
+
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/v1/users/"+test.UserIDCorrect2.String(), nil))
+	require.Equal(r.T(), http.StatusTooManyRequests, recorder.Code)
+	require.Equal(r.T(), "60", recorder.Header().Get(middleware.HeaderRetryAfter))
+
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/v1/users/me", nil))
+	require.Equal(r.T(), http.StatusOK, recorder.Code)
+	require.Empty(r.T(), recorder.Header().Get(middleware.HeaderRateLimitLimit))
+}
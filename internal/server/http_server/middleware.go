@@ -1,6 +1,7 @@
 package http_server
 
 import (
+	"net"
 	"net/http"
 	"strings"
 	"time"
@@ -15,6 +16,8 @@ import (
 	uuid "github.com/satori/go.uuid"
 	"github.com/uber/jaeger-client-go"
 
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/domain/service"
 	"github.com/ssup2ket/service-auth/internal/server/middleware"
 	authtoken "github.com/ssup2ket/service-auth/pkg/auth/token"
 )
@@ -117,7 +120,57 @@ func mwAccessLogger(r *http.Request, status, size int, duration time.Duration) {
 		Send()
 }
 
-func mwAccessTokenValidatorAndSetter() func(next http.Handler) http.Handler {
+// Set RemoteAddr to the client IP. X-Forwarded-For and X-Real-IP headers are used only for requests from
+// trusted proxies, so clients can't spoof their IP.
+func mwRealIPSetter(trustedProxies middleware.TrustedProxies) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			if len(trustedProxies) > 0 {
+				remoteIP := getClientIP(r)
+				clientIP := trustedProxies.GetClientIP(remoteIP, r.Header.Get(middleware.HeaderForwardedFor), r.Header.Get(middleware.HeaderRealIP))
+				if clientIP != remoteIP {
+					r.RemoteAddr = clientIP
+				}
+			}
+
+			// Call next handler
+			next.ServeHTTP(w, r)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// Get client IP without port. RealIP setter middleware sets RemoteAddr from forwarded headers of trusted proxies.
+func getClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func mwTenantIDSetter(tenantDomain string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			// Resolve requested tenant ID from header or subdomain
+			requestTenantID := middleware.ResolveRequestTenantID(r.Header.Get(middleware.HeaderTenantID), r.Host, tenantDomain)
+
+			// Set tenant ID to new context. The tenant ID is fixed by access token later
+			newCtx := middleware.SetRequestTenantIDToCtx(ctx, requestTenantID)
+			newCtx = middleware.SetTenantIDToCtx(newCtx, entity.GetTenantIDOrDefault(requestTenantID))
+
+			// Call next handler
+			next.ServeHTTP(w, r.WithContext(newCtx))
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+func mwAccessTokenValidatorAndSetter(tokenRevocation service.TokenRevocationService) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		fn := func(w http.ResponseWriter, r *http.Request) {
 			ctx := r.Context()
@@ -133,14 +186,40 @@ func mwAccessTokenValidatorAndSetter() func(next http.Handler) http.Handler {
 				return
 			}
 
+			// Check revocation of access token
+			revoked, err := tokenRevocation.IsTokenRevoked(ctx, authInfo)
+			if err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Failed to check access token revocation")
+				render.Render(w, r, getErrRendererServerError())
+				return
+			} else if revoked {
+				log.Ctx(ctx).Error().Msg("Access token is revoked")
+				render.Render(w, r, getErrRendererUnauthorized())
+				return
+			}
+
+			// Select tenant ID
+			requestTenantID, _ := middleware.GetRequestTenantIDFromCtx(ctx)
+			tenantID, ok := middleware.SelectTenantID(requestTenantID, authInfo.TenantID, authInfo.GetRoles())
+			if !ok {
+				log.Ctx(ctx).Error().Str("tenant_id", requestTenantID).Msg("Access token isn't allowed for the tenant")
+				render.Render(w, r, getErrRendererUnauthorized())
+				return
+			}
+
 			// Set auth context to context
 			newCtx := middleware.SetUserIDToCtx(ctx, authInfo.UserID)
 			newCtx = middleware.SetUserLoginIDToCtx(newCtx, authInfo.UserLoginID)
-			newCtx = middleware.SetUserRoleToCtx(newCtx, authInfo.UserRole)
+			newCtx = middleware.SetUserRolesToCtx(newCtx, authInfo.GetRoles())
+			newCtx = middleware.SetSessionIDToCtx(newCtx, authInfo.SessionID)
+			newCtx = middleware.SetTokenIDToCtx(newCtx, authInfo.Id)
+			newCtx = middleware.SetScopesToCtx(newCtx, authInfo.Scopes)
+			newCtx = middleware.SetClientIDToCtx(newCtx, authInfo.ClientID)
+			newCtx = middleware.SetTenantIDToCtx(newCtx, tenantID)
 
 			// Set auth info to logger
 			zerolog.Ctx(newCtx).UpdateContext(func(c zerolog.Context) zerolog.Context {
-				return c.Str("token_user_id", authInfo.UserID).Str("token_user_loginid", authInfo.UserLoginID)
+				return c.Str("token_user_id", authInfo.UserID).Str("token_user_loginid", authInfo.UserLoginID).Str("tenant_id", tenantID)
 			})
 
 			// Call next handler
@@ -151,23 +230,59 @@ func mwAccessTokenValidatorAndSetter() func(next http.Handler) http.Handler {
 	}
 }
 
-func mwAuthorizer(e *casbin.Enforcer) func(next http.Handler) http.Handler {
+// Apply the middleware only to requests having an access token. It's for public routes which authenticate
+// and authorize the request by the access token if it's given.
+func mwAccessTokenOptional(mw func(next http.Handler) http.Handler) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		mwNext := mw(next)
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			mwNext.ServeHTTP(w, r)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+func mwAuthorizer(e *casbin.SyncedEnforcer) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		fn := func(w http.ResponseWriter, r *http.Request) {
 			ctx := r.Context()
 
-			// Get role, method, path from request
-			role, err := middleware.GetUserRoleFromCtx(ctx)
+			// Get roles, scopes from request
+			roles, err := middleware.GetUserRolesFromCtx(ctx)
+			if err != nil {
+				log.Ctx(ctx).Error().Msg("No user roles in context")
+				render.Render(w, r, getErrRendererServerError())
+				return
+			}
+			scopes, err := middleware.GetScopesFromCtx(ctx)
+			if err != nil {
+				log.Ctx(ctx).Error().Msg("No scopes in context")
+				render.Render(w, r, getErrRendererServerError())
+				return
+			}
+			clientID, err := middleware.GetClientIDFromCtx(ctx)
 			if err != nil {
-				log.Ctx(ctx).Error().Msg("No user role in context")
+				log.Ctx(ctx).Error().Msg("No client ID in context")
 				render.Render(w, r, getErrRendererServerError())
 				return
 			}
-			method := strings.ToLower(r.Method)
-			path := r.URL.Path
+
+			// Get operation from route. A route not in the catalogue isn't allowed
+			route := chi.RouteContext(ctx).RoutePattern()
+			operation, ok := middleware.GetHTTPOperation(r.Method, route)
+			if !ok {
+				log.Ctx(ctx).Error().Str("route", route).Msg("No operation of the route in the catalogue")
+				render.Render(w, r, getErrRendererUnauthorized())
+				return
+			}
 
 			// Check authority
-			if !e.Enforce(string(role), path, method) {
+			if !middleware.Authorize(e, clientID, roles, scopes, operation) {
 				log.Ctx(ctx).Error().Msg("This request isn't allowed")
 				render.Render(w, r, getErrRendererUnauthorized())
 				return
@@ -181,6 +296,47 @@ func mwAuthorizer(e *casbin.Enforcer) func(next http.Handler) http.Handler {
 	}
 }
 
+// Limit requests by the rate limit of the route. It's set in route groups, because the route pattern is known
+// only after routing, and after the access token validator to limit requests by user.
+func mwRateLimiter(limiter *middleware.RateLimiter) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			// Get names of the route. The operation's rate limit takes precedence over the route's rate limit
+			route := chi.RouteContext(ctx).RoutePattern()
+			names := []string{}
+			if operation, ok := middleware.GetHTTPOperation(r.Method, route); ok {
+				names = append(names, operation.String())
+			}
+			names = append(names, r.Method+" "+route)
+
+			// Take a token. User ID is empty without access token
+			userID, _ := middleware.GetUserIDFromCtx(ctx)
+			result, ok := limiter.Take(names, getClientIP(r), userID)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// Set rate limit headers and check rate limit
+			for header, value := range result.GetHeaders() {
+				w.Header().Set(header, value)
+			}
+			if !result.Allowed {
+				log.Ctx(ctx).Warn().Str("route", route).Msg("Request is rate limited")
+				render.Render(w, r, getErrRendererRateLimited())
+				return
+			}
+
+			// Call next handler
+			next.ServeHTTP(w, r)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
 func mwUserIDLoggerSetter() func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		fn := func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"github.com/casbin/casbin"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+)
+
+// Check whether the operation is allowed. A token with roles must be allowed by one of the roles,
+// and a token with scopes must also be allowed by one of the scopes. A token without role like
+// a client credentials token is allowed only by its scopes. A token of an OAuth2 client must have scopes,
+// because it would have all permissions of the user's roles without scopes.
+func Authorize(e *casbin.SyncedEnforcer, clientID string, roles []entity.UserRole, scopes []string, operation Operation) bool {
+	if len(roles) == 0 && len(scopes) == 0 {
+		return false
+	}
+	if clientID != "" && len(scopes) == 0 {
+		return false
+	}
+
+	// Check roles
+	if len(roles) > 0 && !isAllowedByRoles(e, roles, operation) {
+		return false
+	}
+
+	// Check scopes
+	if len(scopes) == 0 {
+		return true
+	}
+	for _, scope := range scopes {
+		if e.Enforce(entity.PermissionScopePrefix+scope, operation.Object, operation.Action) {
+			return true
+		}
+	}
+	return false
+}
+
+func isAllowedByRoles(e *casbin.SyncedEnforcer, roles []entity.UserRole, operation Operation) bool {
+	for _, role := range roles {
+		if e.Enforce(string(role), operation.Object, operation.Action) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"testing"
+
+	"github.com/casbin/casbin"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+)
+
+type authorizerSuite struct {
+	suite.Suite
+
+	enforcer *casbin.SyncedEnforcer
+}
+
+func TestAuthorizer(t *testing.T) {
+	suite.Run(t, new(authorizerSuite))
+}
+
+func (a *authorizerSuite) SetupTest() {
+	a.enforcer = casbin.NewSyncedEnforcer("../../../configs/rbac_model.conf", "../../../configs/rbac_policy.csv")
+}
+
+func (a *authorizerSuite) TestAuthorizeRole() {
+	userList, _ := GetHTTPOperation("GET", "/v1/users")
+	userMeUpdate, _ := GetGRPCOperation("/UserMe/UpdateUserMe")
+
+	require.True(a.T(), Authorize(a.enforcer, "", []entity.UserRole{entity.UserRoleAdmin}, nil, userList))
+	require.True(a.T(), Authorize(a.enforcer, "", []entity.UserRole{entity.UserRoleUser}, nil, userMeUpdate))
+	require.False(a.T(), Authorize(a.enforcer, "", []entity.UserRole{entity.UserRoleUser}, nil, userList))
+}
+
+func (a *authorizerSuite) TestAuthorizeTenantAdmin() {
+	userList, _ := GetHTTPOperation("GET", "/v1/users")
+	tenantList, _ := GetHTTPOperation("GET", "/v1/tenants")
+	tokenRevokeUser, _ := GetGRPCOperation("/Token/RevokeUserToken")
+
+	require.True(a.T(), Authorize(a.enforcer, "", []entity.UserRole{entity.UserRoleTenantAdmin}, nil, userList))
+	require.False(a.T(), Authorize(a.enforcer, "", []entity.UserRole{entity.UserRoleTenantAdmin}, nil, tenantList))
+	require.False(a.T(), Authorize(a.enforcer, "", []entity.UserRole{entity.UserRoleTenantAdmin}, nil, tokenRevokeUser))
+	require.True(a.T(), Authorize(a.enforcer, "", []entity.UserRole{entity.UserRoleAdmin}, nil, tenantList))
+}
+
+func (a *authorizerSuite) TestAuthorizeGroupRole() {
+	userList, _ := GetHTTPOperation("GET", "/v1/users")
+	groupMemberAdd, _ := GetGRPCOperation("/Group/AddGroupMember")
+
+	roles := []entity.UserRole{entity.UserRoleUser, entity.UserRoleTenantAdmin}
+	require.True(a.T(), Authorize(a.enforcer, "", roles, nil, userList))
+	require.True(a.T(), Authorize(a.enforcer, "", roles, nil, groupMemberAdd))
+	require.False(a.T(), Authorize(a.enforcer, "", []entity.UserRole{entity.UserRoleUser}, nil, groupMemberAdd))
+}
+
+func (a *authorizerSuite) TestAuthorizeScope() {
+	userMeGet, _ := GetHTTPOperation("GET", "/v1/users/me")
+	userMeUpdate, _ := GetGRPCOperation("/UserMe/UpdateUserMe")
+	keyList, _ := GetGRPCOperation("/Key/ListKey")
+
+	scopes := []string{entity.ScopeUsersMeRead}
+	require.True(a.T(), Authorize(a.enforcer, "", []entity.UserRole{entity.UserRoleUser}, scopes, userMeGet))
+	require.False(a.T(), Authorize(a.enforcer, "", []entity.UserRole{entity.UserRoleUser}, scopes, userMeUpdate))
+	require.True(a.T(), Authorize(a.enforcer, "", nil, []string{entity.ScopeKeysRead}, keyList))
+	require.False(a.T(), Authorize(a.enforcer, "", nil, nil, keyList))
+	require.False(a.T(), Authorize(a.enforcer, "", []entity.UserRole{entity.UserRoleAdmin}, []string{entity.ScopeUsersRead}, userMeGet))
+}
+
+func (a *authorizerSuite) TestAuthorizeClientWithoutScopes() {
+	userMeGet, _ := GetHTTPOperation("GET", "/v1/users/me")
+
+	// Tokens of clients don't have all permissions of the user's roles without scopes
+	clientID := "cccccccc-cccc-cccc-cccc-cccccccccccc"
+	require.False(a.T(), Authorize(a.enforcer, clientID, []entity.UserRole{entity.UserRoleAdmin}, nil, userMeGet))
+	require.True(a.T(), Authorize(a.enforcer, clientID, []entity.UserRole{entity.UserRoleAdmin}, []string{entity.ScopeUsersMeRead}, userMeGet))
+}
+
+func (a *authorizerSuite) TestHTTPAndGRPCOperationSame() {
+	httpOperation, okHTTP := GetHTTPOperation("DELETE", "/v1/users/{UserID}/sessions")
+	grpcOperation, okGRPC := GetGRPCOperation("/Token/RevokeUserToken")
+	require.True(a.T(), okHTTP)
+	require.True(a.T(), okGRPC)
+	require.Equal(a.T(), httpOperation, grpcOperation)
+	require.Equal(a.T(), "token:revokeuser", httpOperation.String())
+}
@@ -13,6 +13,16 @@ const (
 	HeaderRequestID = "X-Request-ID"
 	HeaderTraceID   = "X-B3-TraceId"
 	HeaderSpanID    = "X-B3-SpanId"
+
+	HeaderDeviceName = "X-Device-Name"
+	HeaderTenantID   = "X-Tenant-ID"
+
+	// Access token which can only change the expired password. It's set to the GRPC login response header.
+	HeaderPasswdChangeToken = "X-Passwd-Change-Token"
+	// MFA challenge token exchanged for tokens with a MFA code. It's set to the GRPC login response header.
+	HeaderMFAToken = "X-MFA-Token"
+	// Access token which can only verify the email. It's set to the GRPC login response header.
+	HeaderEmailVerifyToken = "X-Email-Verify-Token"
 )
 
 func SetRequestIDToCtx(ctx context.Context, requestID string) context.Context {
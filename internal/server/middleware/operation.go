@@ -0,0 +1,185 @@
+package middleware
+
+import (
+	"net/http"
+)
+
+// Operation is an object and an action of the permission catalogue like "user:list". Both HTTP routes
+// and GRPC methods are mapped to operations, so one policy governs both of them. A public operation is
+// allowed without an access token.
+type Operation struct {
+	Object string
+	Action string
+	Public bool
+}
+
+func (o Operation) String() string {
+	return o.Object + ":" + o.Action
+}
+
+// Public operations which are authenticated and authorized by the access token if it's given, like creating
+// a user with a role other than the default role
+var authOptionalOperations = map[string]struct{}{
+	"user:create": {},
+}
+
+func (o Operation) IsAuthOptional() bool {
+	_, ok := authOptionalOperations[o.String()]
+	return o.Public && ok
+}
+
+// Binding of an operation to a HTTP route and a GRPC method. An empty route or method means the operation
+// isn't served by the transport.
+type operationBinding struct {
+	operation  Operation
+	httpMethod string
+	httpRoute  string
+	grpcMethod string
+}
+
+// Permission catalogue. HTTP routes are chi route patterns and GRPC methods are full method names.
+var operationCatalogue = []operationBinding{
+	// User
+	{Operation{"user", "list", false}, http.MethodGet, "/v1/users", "/User/ListUser"},
+	{Operation{"user", "create", true}, http.MethodPost, "/v1/users", "/User/CreateUser"},
+	{Operation{"user", "get", false}, http.MethodGet, "/v1/users/{UserID}", "/User/GetUser"},
+	{Operation{"user", "update", false}, http.MethodPut, "/v1/users/{UserID}", "/User/UpdateUser"},
+	{Operation{"user", "delete", false}, http.MethodDelete, "/v1/users/{UserID}", "/User/DeleteUser"},
+
+	// User me
+	{Operation{"userme", "get", false}, http.MethodGet, "/v1/users/me", "/UserMe/GetUserMe"},
+	{Operation{"userme", "update", false}, http.MethodPut, "/v1/users/me", "/UserMe/UpdateUserMe"},
+	{Operation{"userme", "delete", false}, http.MethodDelete, "/v1/users/me", "/UserMe/DeleteUserMe"},
+	{Operation{"userme", "listsession", false}, http.MethodGet, "/v1/users/me/sessions", "/UserMe/ListSessionUserMe"},
+	{Operation{"userme", "updatepasswd", false}, http.MethodPut, "/v1/users/me/password", "/UserMe/UpdatePasswdUserMe"},
+	{Operation{"userme", "getmfa", false}, http.MethodGet, "/v1/users/me/mfa", "/UserMe/GetMFAUserMe"},
+	{Operation{"userme", "enrolltotp", false}, http.MethodPost, "/v1/users/me/mfa/totp", "/UserMe/EnrollTOTPUserMe"},
+	{Operation{"userme", "confirmtotp", false}, http.MethodPost, "/v1/users/me/mfa/totp/confirm", "/UserMe/ConfirmTOTPUserMe"},
+	{Operation{"userme", "disabletotp", false}, http.MethodPost, "/v1/users/me/mfa/totp/disable", "/UserMe/DisableTOTPUserMe"},
+	{Operation{"userme", "regeneraterecoverycodes", false}, http.MethodPost, "/v1/users/me/mfa/recovery-codes", "/UserMe/RegenerateRecoveryCodesUserMe"},
+	{Operation{"userme", "removepasswd", false}, http.MethodPost, "/v1/users/me/password/remove", "/UserMe/RemovePasswdUserMe"},
+	{Operation{"userme", "listpasskey", false}, http.MethodGet, "/v1/users/me/passkeys", "/UserMe/ListPasskeyUserMe"},
+	{Operation{"userme", "beginpasskey", false}, http.MethodPost, "/v1/users/me/passkeys/begin", "/UserMe/BeginPasskeyUserMe"},
+	{Operation{"userme", "finishpasskey", false}, http.MethodPost, "/v1/users/me/passkeys/finish", "/UserMe/FinishPasskeyUserMe"},
+	{Operation{"userme", "deletepasskey", false}, http.MethodDelete, "/v1/users/me/passkeys/{PasskeyID}", "/UserMe/DeletePasskeyUserMe"},
+	{Operation{"userme", "sendemailverification", false}, http.MethodPost, "/v1/users/me/email/verify", "/UserMe/SendEmailVerificationUserMe"},
+	{Operation{"userme", "verifyemail", true}, http.MethodPost, "/v1/users/me/email/verify/confirm", "/UserMe/VerifyEmailUserMe"},
+
+	// Token
+	{Operation{"token", "login", true}, http.MethodPost, "/v1/tokens/login", "/Token/LoginToken"},
+	{Operation{"token", "mfa", true}, http.MethodPost, "/v1/tokens/mfa", "/Token/MFAToken"},
+	{Operation{"token", "beginpasskey", true}, http.MethodPost, "/v1/tokens/passkey/begin", "/Token/BeginPasskeyToken"},
+	{Operation{"token", "finishpasskey", true}, http.MethodPost, "/v1/tokens/passkey/finish", "/Token/FinishPasskeyToken"},
+	{Operation{"token", "refresh", true}, http.MethodPost, "/v1/tokens/refresh", "/Token/RefreshToken"},
+	{Operation{"token", "getjwks", true}, http.MethodGet, "/.well-known/jwks.json", "/Token/GetJWKS"},
+	{Operation{"token", "logout", false}, http.MethodPost, "/v1/tokens/logout", "/Token/LogoutToken"},
+	{Operation{"token", "logoutall", false}, http.MethodDelete, "/v1/users/me/sessions", "/Token/LogoutAllToken"},
+	{Operation{"token", "revokeuser", false}, http.MethodDelete, "/v1/users/{UserID}/sessions", "/Token/RevokeUserToken"},
+	{Operation{"token", "introspect", false}, http.MethodPost, "/v1/tokens/introspect", "/Token/IntrospectToken"},
+
+	// Key
+	{Operation{"key", "list", false}, http.MethodGet, "/v1/keys", "/Key/ListKey"},
+	{Operation{"key", "rotate", false}, http.MethodPost, "/v1/keys/rotate", "/Key/RotateKey"},
+
+	// OAuth client
+	{Operation{"oauthclient", "list", false}, http.MethodGet, "/v1/oauth/clients", "/OAuthClient/ListOAuthClient"},
+	{Operation{"oauthclient", "create", false}, http.MethodPost, "/v1/oauth/clients", "/OAuthClient/CreateOAuthClient"},
+	{Operation{"oauthclient", "get", false}, http.MethodGet, "/v1/oauth/clients/{OAuthClientID}", "/OAuthClient/GetOAuthClient"},
+	{Operation{"oauthclient", "update", false}, http.MethodPut, "/v1/oauth/clients/{OAuthClientID}", "/OAuthClient/UpdateOAuthClient"},
+	{Operation{"oauthclient", "delete", false}, http.MethodDelete, "/v1/oauth/clients/{OAuthClientID}", "/OAuthClient/DeleteOAuthClient"},
+
+	// Role
+	{Operation{"role", "list", false}, http.MethodGet, "/v1/roles", "/Role/ListRole"},
+	{Operation{"role", "create", false}, http.MethodPost, "/v1/roles", "/Role/CreateRole"},
+	{Operation{"role", "get", false}, http.MethodGet, "/v1/roles/{RoleName}", "/Role/GetRole"},
+	{Operation{"role", "update", false}, http.MethodPut, "/v1/roles/{RoleName}", "/Role/UpdateRole"},
+	{Operation{"role", "delete", false}, http.MethodDelete, "/v1/roles/{RoleName}", "/Role/DeleteRole"},
+
+	// Permission
+	{Operation{"permission", "list", false}, http.MethodGet, "/v1/permissions", "/Permission/ListPermission"},
+	{Operation{"permission", "create", false}, http.MethodPost, "/v1/permissions", "/Permission/CreatePermission"},
+	{Operation{"permission", "get", false}, http.MethodGet, "/v1/permissions/{PermissionID}", "/Permission/GetPermission"},
+	{Operation{"permission", "update", false}, http.MethodPut, "/v1/permissions/{PermissionID}", "/Permission/UpdatePermission"},
+	{Operation{"permission", "delete", false}, http.MethodDelete, "/v1/permissions/{PermissionID}", "/Permission/DeletePermission"},
+
+	// Tenant
+	{Operation{"tenant", "list", false}, http.MethodGet, "/v1/tenants", "/Tenant/ListTenant"},
+	{Operation{"tenant", "create", false}, http.MethodPost, "/v1/tenants", "/Tenant/CreateTenant"},
+	{Operation{"tenant", "get", false}, http.MethodGet, "/v1/tenants/{TenantID}", "/Tenant/GetTenant"},
+	{Operation{"tenant", "update", false}, http.MethodPut, "/v1/tenants/{TenantID}", "/Tenant/UpdateTenant"},
+	{Operation{"tenant", "delete", false}, http.MethodDelete, "/v1/tenants/{TenantID}", "/Tenant/DeleteTenant"},
+
+	// Login lock
+	{Operation{"loginlock", "list", false}, http.MethodGet, "/v1/login-locks", "/LoginLock/ListLoginLock"},
+	{Operation{"loginlock", "get", false}, http.MethodGet, "/v1/login-locks/{LoginLockID}", "/LoginLock/GetLoginLock"},
+	{Operation{"loginlock", "delete", false}, http.MethodDelete, "/v1/login-locks/{LoginLockID}", "/LoginLock/DeleteLoginLock"},
+
+	// Group
+	{Operation{"group", "list", false}, http.MethodGet, "/v1/groups", "/Group/ListGroup"},
+	{Operation{"group", "create", false}, http.MethodPost, "/v1/groups", "/Group/CreateGroup"},
+	{Operation{"group", "get", false}, http.MethodGet, "/v1/groups/{GroupID}", "/Group/GetGroup"},
+	{Operation{"group", "update", false}, http.MethodPut, "/v1/groups/{GroupID}", "/Group/UpdateGroup"},
+	{Operation{"group", "delete", false}, http.MethodDelete, "/v1/groups/{GroupID}", "/Group/DeleteGroup"},
+	{Operation{"group", "listmember", false}, http.MethodGet, "/v1/groups/{GroupID}/members", "/Group/ListGroupMember"},
+	{Operation{"group", "addmember", false}, http.MethodPost, "/v1/groups/{GroupID}/members", "/Group/AddGroupMember"},
+	{Operation{"group", "removemember", false}, http.MethodDelete, "/v1/groups/{GroupID}/members/{MemberID}", "/Group/RemoveGroupMember"},
+}
+
+var (
+	httpOperations = getHTTPOperations()
+	grpcOperations = getGRPCOperations()
+)
+
+// Get the operation of the HTTP method and the chi route pattern
+func GetHTTPOperation(method, route string) (Operation, bool) {
+	operation, ok := httpOperations[method+" "+route]
+	return operation, ok
+}
+
+// Get the operation of the GRPC full method
+func GetGRPCOperation(fullMethod string) (Operation, bool) {
+	operation, ok := grpcOperations[fullMethod]
+	return operation, ok
+}
+
+// Get the HTTP methods and the chi route patterns of all operations served by HTTP like "GET /v1/users"
+func GetHTTPRoutes() []string {
+	routes := []string{}
+	for _, binding := range operationCatalogue {
+		if binding.httpRoute != "" {
+			routes = append(routes, binding.httpMethod+" "+binding.httpRoute)
+		}
+	}
+	return routes
+}
+
+// Get the GRPC full methods of all operations served by GRPC
+func GetGRPCMethods() []string {
+	methods := []string{}
+	for _, binding := range operationCatalogue {
+		if binding.grpcMethod != "" {
+			methods = append(methods, binding.grpcMethod)
+		}
+	}
+	return methods
+}
+
+func getHTTPOperations() map[string]Operation {
+	operations := map[string]Operation{}
+	for _, binding := range operationCatalogue {
+		if binding.httpRoute != "" {
+			operations[binding.httpMethod+" "+binding.httpRoute] = binding.operation
+		}
+	}
+	return operations
+}
+
+func getGRPCOperations() map[string]Operation {
+	operations := map[string]Operation{}
+	for _, binding := range operationCatalogue {
+		if binding.grpcMethod != "" {
+			operations[binding.grpcMethod] = binding.operation
+		}
+	}
+	return operations
+}
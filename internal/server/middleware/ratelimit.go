@@ -0,0 +1,205 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Rate limit key. Requests having the same key share a token bucket.
+type RateLimitKey string
+
+const (
+	RateLimitKeyIP    RateLimitKey = "ip"    // Bucket per client IP
+	RateLimitKeyUser  RateLimitKey = "user"  // Bucket per user of the access token, or per client IP without access token
+	RateLimitKeyRoute RateLimitKey = "route" // Bucket per route or method shared by all clients
+)
+
+const (
+	// Name of the default rate limit applied to routes and methods without their own rate limit
+	RateLimitNameDefault = "*"
+
+	HeaderRateLimitLimit     = "RateLimit-Limit"
+	HeaderRateLimitRemaining = "RateLimit-Remaining"
+	HeaderRateLimitReset     = "RateLimit-Reset"
+	HeaderRetryAfter         = "Retry-After"
+)
+
+// RateLimit allows requests per period with bursts up to the requests. Zero requests means no limit.
+type RateLimit struct {
+	Requests int
+	Period   time.Duration
+	Key      RateLimitKey
+}
+
+// Parse rate limit rules like "token:login=10/1m/ip". The name of a rule is an operation of the permission
+// catalogue, a HTTP route like "POST /oauth2/token" or a GRPC full method which isn't in the catalogue,
+// or "*" for the default rate limit.
+func ParseRateLimits(rules []string) (map[string]RateLimit, error) {
+	limits := map[string]RateLimit{}
+	for _, rule := range rules {
+		sep := strings.LastIndex(rule, "=")
+		if sep <= 0 {
+			return nil, fmt.Errorf("wrong rate limit rule %q", rule)
+		}
+		name := strings.TrimSpace(rule[:sep])
+		values := strings.Split(rule[sep+1:], "/")
+		if len(values) != 3 {
+			return nil, fmt.Errorf("wrong rate limit rule %q", rule)
+		}
+
+		requests, err := strconv.Atoi(values[0])
+		if err != nil || requests < 0 {
+			return nil, fmt.Errorf("wrong rate limit requests %q", rule)
+		}
+		period, err := time.ParseDuration(values[1])
+		if err != nil || period <= 0 {
+			return nil, fmt.Errorf("wrong rate limit period %q", rule)
+		}
+		key := RateLimitKey(values[2])
+		if key != RateLimitKeyIP && key != RateLimitKeyUser && key != RateLimitKeyRoute {
+			return nil, fmt.Errorf("wrong rate limit key %q", rule)
+		}
+		limits[name] = RateLimit{Requests: requests, Period: period, Key: key}
+	}
+	return limits, nil
+}
+
+// Result of taking a token from a bucket
+type RateLimitResult struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration // Until the bucket is full
+	RetryAfter time.Duration // Until a request is allowed. Zero if the request is allowed.
+}
+
+// Get rate limit headers. Durations are rounded up to seconds, and Retry-After is set only for rejected requests.
+func (r *RateLimitResult) GetHeaders() map[string]string {
+	headers := map[string]string{
+		HeaderRateLimitLimit:     strconv.Itoa(r.Limit),
+		HeaderRateLimitRemaining: strconv.Itoa(r.Remaining),
+		HeaderRateLimitReset:     strconv.Itoa(int(math.Ceil(r.Reset.Seconds()))),
+	}
+	if !r.Allowed {
+		headers[HeaderRetryAfter] = strconv.Itoa(int(math.Ceil(r.RetryAfter.Seconds())))
+	}
+	return headers
+}
+
+// RateLimiter limits requests with token buckets in memory, so each replica limits requests by itself.
+// HTTP and GRPC servers share a rate limiter.
+type RateLimiter struct {
+	lock sync.Mutex
+
+	limits  map[string]RateLimit
+	buckets map[string]*rateLimitBucket
+	now     func() time.Time
+}
+
+type rateLimitBucket struct {
+	limit     RateLimit
+	tokens    float64
+	updatedAt time.Time
+}
+
+func NewRateLimiter(limits map[string]RateLimit) *RateLimiter {
+	return &RateLimiter{
+		limits:  limits,
+		buckets: map[string]*rateLimitBucket{},
+		now:     time.Now,
+	}
+}
+
+// Take a token for a request from the bucket of its key. Names of the request like its operation and its route are
+// checked in order, and the default rate limit is used if no name has a rate limit. The default rate limit has a
+// bucket per client IP or user shared by all routes, or a bucket per route with the route key. It returns false if
+// no rate limit is applied.
+func (l *RateLimiter) Take(names []string, clientIP, userID string) (RateLimitResult, bool) {
+	if l == nil || len(names) == 0 {
+		return RateLimitResult{}, false
+	}
+
+	// Get rate limit
+	name := ""
+	limit, ok := RateLimit{}, false
+	for _, name = range names {
+		if limit, ok = l.limits[name]; ok {
+			break
+		}
+	}
+	if !ok {
+		if limit, ok = l.limits[RateLimitNameDefault]; !ok {
+			return RateLimitResult{}, false
+		}
+		name = RateLimitNameDefault
+		if limit.Key == RateLimitKeyRoute {
+			name = names[len(names)-1]
+		}
+	}
+	if limit.Requests == 0 {
+		return RateLimitResult{}, false
+	}
+
+	// Get bucket key
+	bucketKey := name + "|" + string(limit.Key)
+	if limit.Key == RateLimitKeyIP || (limit.Key == RateLimitKeyUser && userID == "") {
+		bucketKey += "|ip:" + clientIP
+	} else if limit.Key == RateLimitKeyUser {
+		bucketKey += "|user:" + userID
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	// Refill bucket
+	now := l.now()
+	bucket, ok := l.buckets[bucketKey]
+	if !ok || bucket.limit != limit {
+		bucket = &rateLimitBucket{limit: limit, tokens: float64(limit.Requests), updatedAt: now}
+		l.buckets[bucketKey] = bucket
+	}
+	bucket.refill(now)
+
+	// Take a token
+	result := RateLimitResult{Limit: limit.Requests}
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = bucket.getDuration(1 - bucket.tokens)
+	}
+	result.Remaining = int(bucket.tokens)
+	result.Reset = bucket.getDuration(float64(limit.Requests) - bucket.tokens)
+	return result, true
+}
+
+// Delete buckets which are full, because they are same as new buckets
+func (l *RateLimiter) DeleteFullBuckets() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.now()
+	for key, bucket := range l.buckets {
+		if bucket.refill(now); bucket.tokens >= float64(bucket.limit.Requests) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// Add tokens of the elapsed time up to the requests
+func (b *rateLimitBucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.updatedAt); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * float64(b.limit.Requests) / b.limit.Period.Seconds()
+		b.tokens = math.Min(b.tokens, float64(b.limit.Requests))
+		b.updatedAt = now
+	}
+}
+
+// Get the duration to refill the tokens
+func (b *rateLimitBucket) getDuration(tokens float64) time.Duration {
+	return time.Duration(tokens * float64(b.limit.Period) / float64(b.limit.Requests))
+}
@@ -0,0 +1,132 @@
+package middleware
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ssup2ket/service-auth/internal/test"
+)
+
+type rateLimitSuite struct {
+	suite.Suite
+
+	now     time.Time
+	limiter *RateLimiter
+}
+
+func TestRateLimit(t *testing.T) {
+	suite.Run(t, new(rateLimitSuite))
+}
+
+func (r *rateLimitSuite) SetupTest() {
+	limits, err := ParseRateLimits([]string{
+		"*=2/1s/ip",
+		"token:login=2/1m/ip",
+		"user:list=1/1s/user",
+		"POST /oauth2/token=1/1s/route",
+		"key:list=0/1s/ip",
+	})
+	require.NoError(r.T(), err)
+
+	r.now = time.Now()
+	r.limiter = NewRateLimiter(limits)
+	r.limiter.now = func() time.Time { return r.now }
+}
+
+func (r *rateLimitSuite) TestParseRateLimits() {
+	limits, err := ParseRateLimits([]string{"token:login=10/1m/ip", "GET /oauth2/userinfo=5/1s/user"})
+	require.NoError(r.T(), err)
+	require.Equal(r.T(), RateLimit{Requests: 10, Period: time.Minute, Key: RateLimitKeyIP}, limits["token:login"])
+	require.Equal(r.T(), RateLimit{Requests: 5, Period: time.Second, Key: RateLimitKeyUser}, limits["GET /oauth2/userinfo"])
+
+	for _, rule := range []string{"token:login", "=1/1s/ip", "token:login=1/1s", "token:login=-1/1s/ip",
+		"token:login=1/0s/ip", "token:login=1/1s/tenant"} {
+		_, err := ParseRateLimits([]string{rule})
+		require.Error(r.T(), err, rule)
+	}
+}
+
+func (r *rateLimitSuite) TestTakeBurstAndRefill() {
+	names := []string{"token:login", "POST /v1/tokens/login"}
+	for i := 0; i < 2; i++ {
+		result, ok := r.limiter.Take(names, test.RateLimitIPCorrect, "")
+		require.True(r.T(), ok)
+		require.True(r.T(), result.Allowed)
+		require.Equal(r.T(), 1-i, result.Remaining)
+	}
+
+	// Bucket is empty, and a token is refilled every 30 seconds
+	result, _ := r.limiter.Take(names, test.RateLimitIPCorrect, "")
+	require.False(r.T(), result.Allowed)
+	require.Equal(r.T(), 30*time.Second, result.RetryAfter)
+	require.Equal(r.T(), time.Minute, result.Reset)
+	require.Equal(r.T(), map[string]string{
+		HeaderRateLimitLimit:     "2",
+		HeaderRateLimitRemaining: "0",
+		HeaderRateLimitReset:     "60",
+		HeaderRetryAfter:         "30",
+	}, result.GetHeaders())
+
+	// Other IPs have their own bucket
+	result, _ = r.limiter.Take(names, test.RateLimitIPCorrect2, "")
+	require.True(r.T(), result.Allowed)
+
+	r.now = r.now.Add(30 * time.Second)
+	result, _ = r.limiter.Take(names, test.RateLimitIPCorrect, "")
+	require.True(r.T(), result.Allowed)
+}
+
+func (r *rateLimitSuite) TestTakeDefault() {
+	// Routes without their own rate limit share the default bucket of the IP
+	result, ok := r.limiter.Take([]string{"user:get", "GET /v1/users/{UserID}"}, test.RateLimitIPCorrect, "")
+	require.True(r.T(), ok)
+	require.True(r.T(), result.Allowed)
+	result, _ = r.limiter.Take([]string{"role:list", "GET /v1/roles"}, test.RateLimitIPCorrect, "")
+	require.True(r.T(), result.Allowed)
+	result, _ = r.limiter.Take([]string{"/Role/ListRole"}, test.RateLimitIPCorrect, "")
+	require.False(r.T(), result.Allowed)
+}
+
+func (r *rateLimitSuite) TestTakeUserAndRoute() {
+	// User key falls back to IP without user
+	names := []string{"user:list", "GET /v1/users"}
+	result, _ := r.limiter.Take(names, test.RateLimitIPCorrect, test.UserIDCorrect.String())
+	require.True(r.T(), result.Allowed)
+	result, _ = r.limiter.Take(names, test.RateLimitIPCorrect, "")
+	require.True(r.T(), result.Allowed)
+	result, _ = r.limiter.Take(names, test.RateLimitIPCorrect2, test.UserIDCorrect.String())
+	require.False(r.T(), result.Allowed)
+
+	// Route key is shared by all clients
+	names = []string{"POST /oauth2/token"}
+	result, _ = r.limiter.Take(names, test.RateLimitIPCorrect, "")
+	require.True(r.T(), result.Allowed)
+	result, _ = r.limiter.Take(names, test.RateLimitIPCorrect2, "")
+	require.False(r.T(), result.Allowed)
+}
+
+func (r *rateLimitSuite) TestTakeNoLimit() {
+	_, ok := r.limiter.Take([]string{"key:list", "GET /v1/keys"}, test.RateLimitIPCorrect, "")
+	require.False(r.T(), ok)
+
+	_, ok = NewRateLimiter(map[string]RateLimit{}).Take([]string{"key:list"}, test.RateLimitIPCorrect, "")
+	require.False(r.T(), ok)
+
+	var limiter *RateLimiter
+	_, ok = limiter.Take([]string{"key:list"}, test.RateLimitIPCorrect, "")
+	require.False(r.T(), ok)
+}
+
+func (r *rateLimitSuite) TestDeleteFullBuckets() {
+	r.limiter.Take([]string{"token:login"}, test.RateLimitIPCorrect, "")
+	r.limiter.Take([]string{"user:get"}, test.RateLimitIPCorrect, "")
+	require.Len(r.T(), r.limiter.buckets, 2)
+
+	// Default bucket is refilled in a second, but login bucket isn't
+	r.now = r.now.Add(time.Second)
+	r.limiter.DeleteFullBuckets()
+	require.Len(r.T(), r.limiter.buckets, 1)
+}
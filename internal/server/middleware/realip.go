@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+const (
+	HeaderForwardedFor = "X-Forwarded-For"
+	HeaderRealIP       = "X-Real-IP"
+)
+
+// TrustedProxies are reverse proxies whose forwarded headers are trusted. Forwarded headers of other clients are
+// ignored, so clients can't spoof their IP used by rate limits and login locks.
+type TrustedProxies []*net.IPNet
+
+// Parse trusted proxies of CIDRs like "10.0.0.0/8" or IPs like "10.0.0.1"
+func ParseTrustedProxies(proxies []string) (TrustedProxies, error) {
+	trustedProxies := TrustedProxies{}
+	for _, proxy := range proxies {
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, fmt.Errorf("wrong trusted proxy %q", proxy)
+			}
+			if ip.To4() != nil {
+				proxy += "/32"
+			} else {
+				proxy += "/128"
+			}
+		}
+		_, ipNet, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("wrong trusted proxy %q", proxy)
+		}
+		trustedProxies = append(trustedProxies, ipNet)
+	}
+	return trustedProxies, nil
+}
+
+// Check whether the IP is a trusted proxy
+func (t TrustedProxies) IsTrusted(ip string) bool {
+	parsedIP := net.ParseIP(ip)
+	if parsedIP == nil {
+		return false
+	}
+	for _, ipNet := range t {
+		if ipNet.Contains(parsedIP) {
+			return true
+		}
+	}
+	return false
+}
+
+// Get the client IP of a request from the remote IP. Only if the remote IP is a trusted proxy, the client IP is
+// the last IP of the X-Forwarded-For header which isn't a trusted proxy, because the leading IPs can be set
+// by the client. X-Real-IP header is used only without X-Forwarded-For header.
+func (t TrustedProxies) GetClientIP(remoteIP, forwardedFor, realIP string) string {
+	if !t.IsTrusted(remoteIP) {
+		return remoteIP
+	}
+
+	// Get from X-Forwarded-For
+	if forwardedFor != "" {
+		ips := strings.Split(forwardedFor, ",")
+		for i := len(ips) - 1; i >= 0; i-- {
+			ip := strings.TrimSpace(ips[i])
+			if net.ParseIP(ip) == nil {
+				break
+			}
+			if i == 0 || !t.IsTrusted(ip) {
+				return ip
+			}
+		}
+		return remoteIP
+	}
+
+	// Get from X-Real-IP
+	if ip := strings.TrimSpace(realIP); net.ParseIP(ip) != nil {
+		return ip
+	}
+	return remoteIP
+}
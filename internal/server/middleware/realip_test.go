@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type realIPSuite struct {
+	suite.Suite
+
+	proxies TrustedProxies
+}
+
+func TestRealIP(t *testing.T) {
+	suite.Run(t, new(realIPSuite))
+}
+
+func (r *realIPSuite) SetupTest() {
+	var err error
+	r.proxies, err = ParseTrustedProxies([]string{"10.0.0.0/8", "192.168.0.1"})
+	require.NoError(r.T(), err)
+}
+
+func (r *realIPSuite) TestParseTrustedProxies() {
+	require.True(r.T(), r.proxies.IsTrusted("10.1.2.3"))
+	require.True(r.T(), r.proxies.IsTrusted("192.168.0.1"))
+	require.False(r.T(), r.proxies.IsTrusted("192.168.0.2"))
+
+	_, err := ParseTrustedProxies([]string{"10.0.0.0/33"})
+	require.Error(r.T(), err)
+	_, err = ParseTrustedProxies([]string{"proxy"})
+	require.Error(r.T(), err)
+}
+
+func (r *realIPSuite) TestGetClientIPUntrusted() {
+	// Forwarded headers of clients which aren't trusted proxies are ignored
+	require.Equal(r.T(), "1.1.1.1", r.proxies.GetClientIP("1.1.1.1", "2.2.2.2", "3.3.3.3"))
+	require.Equal(r.T(), "1.1.1.1", TrustedProxies{}.GetClientIP("1.1.1.1", "2.2.2.2", ""))
+}
+
+func (r *realIPSuite) TestGetClientIPTrusted() {
+	// The last IP which isn't a trusted proxy is the client, and leading IPs set by the client are ignored
+	require.Equal(r.T(), "2.2.2.2", r.proxies.GetClientIP("10.0.0.1", "9.9.9.9, 2.2.2.2, 10.0.0.2", ""))
+	require.Equal(r.T(), "10.0.0.3", r.proxies.GetClientIP("10.0.0.1", "10.0.0.3, 10.0.0.2", ""))
+	require.Equal(r.T(), "10.0.0.1", r.proxies.GetClientIP("10.0.0.1", "wrong", ""))
+	require.Equal(r.T(), "3.3.3.3", r.proxies.GetClientIP("10.0.0.1", "", "3.3.3.3"))
+	require.Equal(r.T(), "10.0.0.1", r.proxies.GetClientIP("10.0.0.1", "", ""))
+}
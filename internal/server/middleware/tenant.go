@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+)
+
+type ctxKeyTenantID int
+type ctxKeyRequestTenantID int
+
+const (
+	CtxKeyTenantID        ctxKeyTenantID        = 0
+	CtxKeyRequestTenantID ctxKeyRequestTenantID = 0
+)
+
+// Set the tenant of the request. It's the requested tenant or the default tenant before the access token is
+// validated, and the tenant selected by SelectTenantID after the access token is validated.
+func SetTenantIDToCtx(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, CtxKeyTenantID, tenantID)
+}
+
+// Set the tenant requested by the tenant ID header or the subdomain. Empty means no tenant is requested.
+func SetRequestTenantIDToCtx(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, CtxKeyRequestTenantID, tenantID)
+}
+
+func GetTenantIDFromCtx(ctx context.Context) (string, error) {
+	tenantID, ok := ctx.Value(CtxKeyTenantID).(string)
+	if !ok {
+		return "", fmt.Errorf("no tenant ID in context")
+	}
+	return tenantID, nil
+}
+
+func GetRequestTenantIDFromCtx(ctx context.Context) (string, error) {
+	tenantID, ok := ctx.Value(CtxKeyRequestTenantID).(string)
+	if !ok {
+		return "", fmt.Errorf("no request tenant ID in context")
+	}
+	return tenantID, nil
+}
+
+// Resolve the tenant requested by the tenant ID header, or by the subdomain of the tenant domain if the header
+// isn't set. For example, "acme.auth.example.com" requests "acme" tenant when the tenant domain is "auth.example.com".
+// Empty means no tenant is requested.
+func ResolveRequestTenantID(headerTenantID, host, tenantDomain string) string {
+	if headerTenantID != "" {
+		return headerTenantID
+	}
+	if tenantDomain == "" {
+		return ""
+	}
+
+	// Remove port from host
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+	host = strings.ToLower(host)
+	suffix := "." + strings.ToLower(tenantDomain)
+	if !strings.HasSuffix(host, suffix) {
+		return ""
+	}
+	subdomain := strings.TrimSuffix(host, suffix)
+	if strings.Contains(subdomain, ".") {
+		return ""
+	}
+	return subdomain
+}
+
+// Select the tenant of the request authenticated by the access token. No requested tenant means the token's tenant.
+// The requested tenant must be the token's tenant, except that admins can select any tenant to manage it.
+// Tokens without tenant are issued before tenants were introduced, so they are in the default tenant.
+func SelectTenantID(requestTenantID, tokenTenantID string, roles []entity.UserRole) (string, bool) {
+	tokenTenantID = entity.GetTenantIDOrDefault(tokenTenantID)
+	if requestTenantID == "" || requestTenantID == tokenTenantID {
+		return tokenTenantID, true
+	}
+	for _, role := range roles {
+		if role == entity.UserRoleAdmin {
+			return requestTenantID, true
+		}
+	}
+	return "", false
+}
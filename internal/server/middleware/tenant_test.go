@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/test"
+)
+
+type tenantSuite struct {
+	suite.Suite
+}
+
+func TestTenant(t *testing.T) {
+	suite.Run(t, new(tenantSuite))
+}
+
+func (t *tenantSuite) TestResolveRequestTenantID() {
+	host := test.TenantIDCorrect + "." + test.TenantDomainCorrect
+
+	require.Equal(t.T(), test.TenantIDCorrect2, ResolveRequestTenantID(test.TenantIDCorrect2, host, test.TenantDomainCorrect))
+	require.Equal(t.T(), test.TenantIDCorrect, ResolveRequestTenantID("", host, test.TenantDomainCorrect))
+	require.Equal(t.T(), test.TenantIDCorrect, ResolveRequestTenantID("", host+":8080", test.TenantDomainCorrect))
+	require.Equal(t.T(), "", ResolveRequestTenantID("", test.TenantDomainCorrect, test.TenantDomainCorrect))
+	require.Equal(t.T(), "", ResolveRequestTenantID("", "a."+host, test.TenantDomainCorrect))
+	require.Equal(t.T(), "", ResolveRequestTenantID("", host, ""))
+}
+
+func (t *tenantSuite) TestSelectTenantID() {
+	tenantID, ok := SelectTenantID("", test.TenantIDCorrect, []entity.UserRole{entity.UserRoleUser})
+	require.True(t.T(), ok)
+	require.Equal(t.T(), test.TenantIDCorrect, tenantID)
+
+	tenantID, ok = SelectTenantID("", "", []entity.UserRole{entity.UserRoleUser})
+	require.True(t.T(), ok)
+	require.Equal(t.T(), entity.TenantIDDefault, tenantID)
+
+	tenantID, ok = SelectTenantID(test.TenantIDCorrect, test.TenantIDCorrect, []entity.UserRole{entity.UserRoleTenantAdmin})
+	require.True(t.T(), ok)
+	require.Equal(t.T(), test.TenantIDCorrect, tenantID)
+
+	_, ok = SelectTenantID(test.TenantIDCorrect2, test.TenantIDCorrect, []entity.UserRole{entity.UserRoleTenantAdmin})
+	require.False(t.T(), ok)
+
+	tenantID, ok = SelectTenantID(test.TenantIDCorrect2, entity.TenantIDDefault, []entity.UserRole{entity.UserRoleAdmin})
+	require.True(t.T(), ok)
+	require.Equal(t.T(), test.TenantIDCorrect2, tenantID)
+
+	tenantID, ok = SelectTenantID(test.TenantIDCorrect2, entity.TenantIDDefault, []entity.UserRole{entity.UserRoleUser, entity.UserRoleAdmin})
+	require.True(t.T(), ok)
+	require.Equal(t.T(), test.TenantIDCorrect2, tenantID)
+}
@@ -9,12 +9,20 @@ import (
 
 type ctxKeyUserID int
 type ctxKeyUserLoginID int
-type ctxKeyUserRole int
+type ctxKeyUserRoles int
+type ctxKeySessionID int
+type ctxKeyTokenID int
+type ctxKeyScopes int
+type ctxKeyClientID int
 
 const (
 	CtxKeyUserID      ctxKeyUserID      = 0
 	CtxKeyUserLoginID ctxKeyUserLoginID = 0
-	CtxKeyUserRole    ctxKeyUserRole    = 0
+	CtxKeyUserRoles   ctxKeyUserRoles   = 0
+	CtxKeySessionID   ctxKeySessionID   = 0
+	CtxKeyTokenID     ctxKeyTokenID     = 0
+	CtxKeyScopes      ctxKeyScopes      = 0
+	CtxKeyClientID    ctxKeyClientID    = 0
 )
 
 func SetUserIDToCtx(ctx context.Context, userID string) context.Context {
@@ -25,8 +33,24 @@ func SetUserLoginIDToCtx(ctx context.Context, userID string) context.Context {
 	return context.WithValue(ctx, CtxKeyUserLoginID, userID)
 }
 
-func SetUserRoleToCtx(ctx context.Context, userRole entity.UserRole) context.Context {
-	return context.WithValue(ctx, CtxKeyUserRole, userRole)
+func SetUserRolesToCtx(ctx context.Context, userRoles []entity.UserRole) context.Context {
+	return context.WithValue(ctx, CtxKeyUserRoles, userRoles)
+}
+
+func SetSessionIDToCtx(ctx context.Context, sessionID string) context.Context {
+	return context.WithValue(ctx, CtxKeySessionID, sessionID)
+}
+
+func SetTokenIDToCtx(ctx context.Context, tokenID string) context.Context {
+	return context.WithValue(ctx, CtxKeyTokenID, tokenID)
+}
+
+func SetScopesToCtx(ctx context.Context, scopes []string) context.Context {
+	return context.WithValue(ctx, CtxKeyScopes, scopes)
+}
+
+func SetClientIDToCtx(ctx context.Context, clientID string) context.Context {
+	return context.WithValue(ctx, CtxKeyClientID, clientID)
 }
 
 func GetUserIDFromCtx(ctx context.Context) (string, error) {
@@ -45,10 +69,65 @@ func GetUserLoginIDFromCtx(ctx context.Context) (string, error) {
 	return userLoginID, nil
 }
 
-func GetUserRoleFromCtx(ctx context.Context) (entity.UserRole, error) {
-	userRole, ok := ctx.Value(CtxKeyUserRole).(entity.UserRole)
+func GetUserRolesFromCtx(ctx context.Context) ([]entity.UserRole, error) {
+	userRoles, ok := ctx.Value(CtxKeyUserRoles).([]entity.UserRole)
 	if !ok {
-		return "", fmt.Errorf("no user role in context")
+		return nil, fmt.Errorf("no user roles in context")
+	}
+	return userRoles, nil
+}
+
+func GetSessionIDFromCtx(ctx context.Context) (string, error) {
+	sessionID, ok := ctx.Value(CtxKeySessionID).(string)
+	if !ok {
+		return "", fmt.Errorf("no session ID in context")
+	}
+	return sessionID, nil
+}
+
+func GetTokenIDFromCtx(ctx context.Context) (string, error) {
+	tokenID, ok := ctx.Value(CtxKeyTokenID).(string)
+	if !ok {
+		return "", fmt.Errorf("no token ID in context")
+	}
+	return tokenID, nil
+}
+
+func GetScopesFromCtx(ctx context.Context) ([]string, error) {
+	scopes, ok := ctx.Value(CtxKeyScopes).([]string)
+	if !ok {
+		return nil, fmt.Errorf("no scopes in context")
+	}
+	return scopes, nil
+}
+
+func GetClientIDFromCtx(ctx context.Context) (string, error) {
+	clientID, ok := ctx.Value(CtxKeyClientID).(string)
+	if !ok {
+		return "", fmt.Errorf("no client ID in context")
+	}
+	return clientID, nil
+}
+
+// Get the subject of the access token and the tenant of the request for attribute based access checks
+func GetSubjectFromCtx(ctx context.Context) (*entity.Subject, error) {
+	userID, err := GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userRoles, err := GetUserRolesFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := GetTenantIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
 	}
-	return userRole, nil
+	sessionID, _ := GetSessionIDFromCtx(ctx)
+	return &entity.Subject{
+		UserID:    userID,
+		UserRoles: userRoles,
+		TenantID:  tenantID,
+		SessionID: sessionID,
+	}, nil
 }
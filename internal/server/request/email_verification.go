@@ -0,0 +1,16 @@
+package request
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Email verification tokens are base64url without padding
+var emailVerifyTokenRegex = regexp.MustCompile("^[a-zA-Z0-9_-]{1,128}$")
+
+func ValidateEmailVerifyToken(token string) error {
+	if !emailVerifyTokenRegex.MatchString(token) {
+		return fmt.Errorf("wrong email verification token format")
+	}
+	return nil
+}
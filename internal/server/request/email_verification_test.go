@@ -0,0 +1,31 @@
+package request
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ssup2ket/service-auth/internal/test"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type emailVerificationSuite struct {
+	suite.Suite
+}
+
+func TestEmailVerification(t *testing.T) {
+	suite.Run(t, new(emailVerificationSuite))
+}
+
+// EmailVerifyToken
+func (e *emailVerificationSuite) TestBindEmailVerifyTokenCorrect() {
+	err := ValidateEmailVerifyToken(test.EmailVerificationTokenCorrect)
+	require.NoError(e.T(), err)
+}
+
+func (e *emailVerificationSuite) TestBindEmailVerifyTokenWrong() {
+	for _, token := range []string{test.EmailVerificationTokenWrongFormat, strings.Repeat("a", 129), ""} {
+		err := ValidateEmailVerifyToken(token)
+		require.Error(e.T(), err, token)
+	}
+}
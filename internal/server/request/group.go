@@ -0,0 +1,75 @@
+package request
+
+import (
+	"fmt"
+	"regexp"
+
+	gouuid "github.com/satori/go.uuid"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+)
+
+func ValidateGroupUUID(uuid string) error {
+	if _, err := gouuid.FromString(uuid); err != nil {
+		return fmt.Errorf("wrong uuid format")
+	}
+	return nil
+}
+
+func ValidateGroupCreate(name, description string, roles []string) error {
+	return validateGroup(name, description, roles)
+}
+
+func ValidateGroupUpdate(uuid, name, description string, roles []string) error {
+	// UUID
+	if uuid != "" {
+		if err := ValidateGroupUUID(uuid); err != nil {
+			return err
+		}
+	}
+
+	return validateGroup(name, description, roles)
+}
+
+func ValidateGroupMemberCreate(groupUUID, memberUUID, memberType string) error {
+	// UUIDs
+	if groupUUID != "" {
+		if err := ValidateGroupUUID(groupUUID); err != nil {
+			return err
+		}
+	}
+	if _, err := gouuid.FromString(memberUUID); err != nil {
+		return fmt.Errorf("wrong member uuid format")
+	}
+
+	// Member type
+	if memberType != string(entity.GroupMemberTypeUser) && memberType != string(entity.GroupMemberTypeGroup) {
+		return fmt.Errorf("wrong member type")
+	}
+	return nil
+}
+
+func validateGroup(name, description string, roles []string) error {
+	// Name
+	nameMatched, err := regexp.MatchString("^[a-zA-Z0-9][a-zA-Z0-9_.-]{0,49}$", name)
+	if err != nil {
+		return fmt.Errorf("wrong group name regex")
+	}
+	if !nameMatched {
+		return fmt.Errorf("wrong group name")
+	}
+
+	// Description
+	if len(description) > 255 {
+		return fmt.Errorf("wrong description length")
+	}
+
+	// Roles
+	for _, role := range roles {
+		if err := validateRoleName(role); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
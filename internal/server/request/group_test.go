@@ -0,0 +1,87 @@
+package request
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/test"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type groupSuite struct {
+	suite.Suite
+}
+
+func TestGroup(t *testing.T) {
+	suite.Run(t, new(groupSuite))
+}
+
+var (
+	groupRolesCorrect = []string{test.RoleNameCorrect}
+)
+
+// GroupUUID
+func (g *groupSuite) TestBindGroupUUIDCorrect() {
+	err := ValidateGroupUUID(test.GroupIDCorrect.String())
+	require.NoError(g.T(), err)
+}
+
+func (g *groupSuite) TestBindGroupUUIDWrong() {
+	err := ValidateGroupUUID(test.GroupIDWrongFormat)
+	require.Error(g.T(), err)
+}
+
+// GroupCreate
+func (g *groupSuite) TestBindGroupCreateCorrect() {
+	err := ValidateGroupCreate(test.GroupNameCorrect, test.GroupDescriptionCorrect, groupRolesCorrect)
+	require.NoError(g.T(), err)
+}
+
+func (g *groupSuite) TestBindGroupCreateNameWrong() {
+	wrongNames := []string{"", test.GroupNameWrongFormat, test.GroupNameLong}
+	for _, wrongName := range wrongNames {
+		err := ValidateGroupCreate(wrongName, test.GroupDescriptionCorrect, groupRolesCorrect)
+		require.Error(g.T(), err)
+	}
+}
+
+func (g *groupSuite) TestBindGroupCreateDescriptionWrong() {
+	err := ValidateGroupCreate(test.GroupNameCorrect, strings.Repeat("a", 256), groupRolesCorrect)
+	require.Error(g.T(), err)
+}
+
+func (g *groupSuite) TestBindGroupCreateRoleWrong() {
+	err := ValidateGroupCreate(test.GroupNameCorrect, test.GroupDescriptionCorrect, []string{test.GroupRoleWrongFormat})
+	require.Error(g.T(), err)
+}
+
+// GroupUpdate
+func (g *groupSuite) TestBindGroupUpdateCorrect() {
+	err := ValidateGroupUpdate(test.GroupIDCorrect.String(), test.GroupNameCorrect, test.GroupDescriptionCorrect, groupRolesCorrect)
+	require.NoError(g.T(), err)
+}
+
+func (g *groupSuite) TestBindGroupUpdateUUIDWrong() {
+	err := ValidateGroupUpdate(test.GroupIDWrongFormat, test.GroupNameCorrect, test.GroupDescriptionCorrect, groupRolesCorrect)
+	require.Error(g.T(), err)
+}
+
+// GroupMemberCreate
+func (g *groupSuite) TestBindGroupMemberCreateCorrect() {
+	err := ValidateGroupMemberCreate(test.GroupIDCorrect.String(), test.UserIDCorrect.String(), string(entity.GroupMemberTypeUser))
+	require.NoError(g.T(), err)
+	err = ValidateGroupMemberCreate(test.GroupIDCorrect2.String(), test.GroupIDCorrect.String(), string(entity.GroupMemberTypeGroup))
+	require.NoError(g.T(), err)
+}
+
+func (g *groupSuite) TestBindGroupMemberCreateMemberIDWrong() {
+	err := ValidateGroupMemberCreate(test.GroupIDCorrect.String(), test.GroupMemberIDWrongFormat, string(entity.GroupMemberTypeUser))
+	require.Error(g.T(), err)
+}
+
+func (g *groupSuite) TestBindGroupMemberCreateMemberTypeWrong() {
+	err := ValidateGroupMemberCreate(test.GroupIDCorrect.String(), test.UserIDCorrect.String(), test.GroupMemberTypeWrong)
+	require.Error(g.T(), err)
+}
@@ -0,0 +1,15 @@
+package request
+
+import (
+	"fmt"
+
+	gouuid "github.com/satori/go.uuid"
+)
+
+func ValidateLoginLockUUID(uuid string) error {
+	if _, err := gouuid.FromString(uuid); err != nil {
+		return fmt.Errorf("wrong uuid format")
+	}
+
+	return nil
+}
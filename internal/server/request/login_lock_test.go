@@ -0,0 +1,28 @@
+package request
+
+import (
+	"testing"
+
+	"github.com/ssup2ket/service-auth/internal/test"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type loginLockSuite struct {
+	suite.Suite
+}
+
+func TestLoginLock(t *testing.T) {
+	suite.Run(t, new(loginLockSuite))
+}
+
+// LoginLockUUID
+func (l *loginLockSuite) TestBindLoginLockUUIDCorrect() {
+	err := ValidateLoginLockUUID(test.LoginLockIDCorrect.String())
+	require.NoError(l.T(), err)
+}
+
+func (l *loginLockSuite) TestBindLoginLockUUIDWrong() {
+	err := ValidateLoginLockUUID(test.LoginLockIDWrongFormat)
+	require.Error(l.T(), err)
+}
@@ -0,0 +1,35 @@
+package request
+
+import (
+	"fmt"
+	"regexp"
+)
+
+var (
+	totpCodeRegex = regexp.MustCompile("^[0-9]{6}$")
+	// Recovery codes are base32 like "abcde-fghij", and the separator is optional
+	mfaRecoveryCodeRegex = regexp.MustCompile("^[a-zA-Z2-7]{5}-?[a-zA-Z2-7]{5}$")
+)
+
+// Validate a TOTP code
+func ValidateTOTPCode(code string) error {
+	if !totpCodeRegex.MatchString(code) {
+		return fmt.Errorf("wrong TOTP code format")
+	}
+	return nil
+}
+
+// Validate a TOTP code or a recovery code
+func ValidateMFACode(code string) error {
+	if !totpCodeRegex.MatchString(code) && !mfaRecoveryCodeRegex.MatchString(code) {
+		return fmt.Errorf("wrong MFA code format")
+	}
+	return nil
+}
+
+func ValidateTokenMFA(mfaToken, code string) error {
+	if mfaToken == "" {
+		return fmt.Errorf("no MFA token")
+	}
+	return ValidateMFACode(code)
+}
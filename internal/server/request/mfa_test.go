@@ -0,0 +1,51 @@
+package request
+
+import (
+	"testing"
+
+	"github.com/ssup2ket/service-auth/internal/test"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type mfaSuite struct {
+	suite.Suite
+}
+
+func TestMFA(t *testing.T) {
+	suite.Run(t, new(mfaSuite))
+}
+
+// TOTPCode
+func (m *mfaSuite) TestBindTOTPCodeCorrect() {
+	err := ValidateTOTPCode("123456")
+	require.NoError(m.T(), err)
+}
+
+func (m *mfaSuite) TestBindTOTPCodeWrong() {
+	for _, code := range []string{test.MFATOTPCodeWrongFormat, test.MFARecoveryCodeCorrect, ""} {
+		err := ValidateTOTPCode(code)
+		require.Error(m.T(), err, code)
+	}
+}
+
+// MFACode
+func (m *mfaSuite) TestBindMFACodeCorrect() {
+	for _, code := range []string{"123456", test.MFARecoveryCodeCorrect, "ABCDEFGHIJ"} {
+		err := ValidateMFACode(code)
+		require.NoError(m.T(), err, code)
+	}
+}
+
+func (m *mfaSuite) TestBindMFACodeWrong() {
+	for _, code := range []string{test.MFATOTPCodeWrongFormat, test.MFARecoveryCodeWrongFormat, ""} {
+		err := ValidateMFACode(code)
+		require.Error(m.T(), err, code)
+	}
+}
+
+// TokenMFA
+func (m *mfaSuite) TestBindTokenMFAEmptyToken() {
+	err := ValidateTokenMFA("", "123456")
+	require.Error(m.T(), err)
+}
@@ -0,0 +1,74 @@
+package request
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+
+	gouuid "github.com/satori/go.uuid"
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+)
+
+func ValidateOAuthClientUUID(uuid string) error {
+	if _, err := gouuid.FromString(uuid); err != nil {
+		return fmt.Errorf("wrong uuid format")
+	}
+
+	return nil
+}
+
+func ValidateOAuthClientCreate(name string, redirectURIs, grantTypes, scopes []string) error {
+	return validateOAuthClient(name, redirectURIs, grantTypes, scopes)
+}
+
+func ValidateOAuthClientUpdate(uuid, name string, redirectURIs, grantTypes, scopes []string) error {
+	// UUID
+	if uuid != "" {
+		if _, err := gouuid.FromString(uuid); err != nil {
+			return fmt.Errorf("wrong uuid format")
+		}
+	}
+
+	return validateOAuthClient(name, redirectURIs, grantTypes, scopes)
+}
+
+func validateOAuthClient(name string, redirectURIs, grantTypes, scopes []string) error {
+	// Name
+	if len(name) == 0 || len(name) > 100 {
+		return fmt.Errorf("wrong name length")
+	}
+
+	// Redirect URIs must be absolute URIs without fragment
+	for _, redirectURI := range redirectURIs {
+		u, err := url.Parse(redirectURI)
+		if err != nil || !u.IsAbs() || u.Host == "" || u.Fragment != "" {
+			return fmt.Errorf("wrong redirect URI format")
+		}
+	}
+
+	// Grant types
+	if len(grantTypes) == 0 {
+		return fmt.Errorf("no grant type")
+	}
+	for _, grantType := range grantTypes {
+		if !entity.IsValidOAuthGrantType(grantType) {
+			return fmt.Errorf("wrong grant type")
+		}
+		if entity.OAuthGrantType(grantType) == entity.OAuthGrantTypeAuthorizationCode && len(redirectURIs) == 0 {
+			return fmt.Errorf("no redirect URI for authorization code grant")
+		}
+	}
+
+	// Scopes
+	for _, scope := range scopes {
+		scopeMatched, err := regexp.MatchString("^[a-zA-Z0-9_.:-]{1,64}$", scope)
+		if err != nil {
+			return fmt.Errorf("wrong scope regex")
+		}
+		if !scopeMatched {
+			return fmt.Errorf("wrong scope format")
+		}
+	}
+
+	return nil
+}
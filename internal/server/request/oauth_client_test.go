@@ -0,0 +1,85 @@
+package request
+
+import (
+	"testing"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/test"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type oauthClientSuite struct {
+	suite.Suite
+}
+
+func TestOAuthClient(t *testing.T) {
+	suite.Run(t, new(oauthClientSuite))
+}
+
+var (
+	oauthClientRedirectURIsCorrect = []string{test.OAuthClientRedirectURICorrect}
+	oauthClientGrantTypesCorrect   = []string{string(entity.OAuthGrantTypeAuthorizationCode), string(entity.OAuthGrantTypeRefreshToken)}
+	oauthClientScopesCorrect       = []string{"openid", "profile"}
+)
+
+// OAuthClientUUID
+func (o *oauthClientSuite) TestBindOAuthClientUUIDCorrect() {
+	err := ValidateOAuthClientUUID(test.OAuthClientIDCorrect.String())
+	require.NoError(o.T(), err)
+}
+
+func (o *oauthClientSuite) TestBindOAuthClientUUIDWrong() {
+	err := ValidateOAuthClientUUID(test.OAuthClientIDWrongFormat)
+	require.Error(o.T(), err)
+}
+
+// OAuthClientCreate
+func (o *oauthClientSuite) TestBindOAuthClientCreateCorrect() {
+	err := ValidateOAuthClientCreate(test.OAuthClientNameCorrect, oauthClientRedirectURIsCorrect, oauthClientGrantTypesCorrect,
+		oauthClientScopesCorrect)
+	require.NoError(o.T(), err)
+}
+
+func (o *oauthClientSuite) TestBindOAuthClientCreateNameWrong() {
+	wrongNames := []string{"", test.OAuthClientNameLong}
+	for _, wrongName := range wrongNames {
+		err := ValidateOAuthClientCreate(wrongName, oauthClientRedirectURIsCorrect, oauthClientGrantTypesCorrect, oauthClientScopesCorrect)
+		require.Error(o.T(), err)
+	}
+}
+
+func (o *oauthClientSuite) TestBindOAuthClientCreateRedirectURIWrong() {
+	wrongRedirectURIs := [][]string{{test.OAuthClientRedirectURIWrongFormat}, {test.OAuthClientRedirectURIWithFragment}, {}}
+	for _, wrongRedirectURI := range wrongRedirectURIs {
+		err := ValidateOAuthClientCreate(test.OAuthClientNameCorrect, wrongRedirectURI, oauthClientGrantTypesCorrect, oauthClientScopesCorrect)
+		require.Error(o.T(), err)
+	}
+}
+
+func (o *oauthClientSuite) TestBindOAuthClientCreateGrantTypeWrong() {
+	wrongGrantTypes := [][]string{{test.OAuthClientGrantTypeWrong}, {}}
+	for _, wrongGrantType := range wrongGrantTypes {
+		err := ValidateOAuthClientCreate(test.OAuthClientNameCorrect, oauthClientRedirectURIsCorrect, wrongGrantType, oauthClientScopesCorrect)
+		require.Error(o.T(), err)
+	}
+}
+
+func (o *oauthClientSuite) TestBindOAuthClientCreateScopeWrong() {
+	err := ValidateOAuthClientCreate(test.OAuthClientNameCorrect, oauthClientRedirectURIsCorrect, oauthClientGrantTypesCorrect,
+		[]string{test.OAuthClientScopeWrongFormat})
+	require.Error(o.T(), err)
+}
+
+// OAuthClientUpdate
+func (o *oauthClientSuite) TestBindOAuthClientUpdateCorrect() {
+	err := ValidateOAuthClientUpdate(test.OAuthClientIDCorrect.String(), test.OAuthClientNameCorrect, nil,
+		[]string{string(entity.OAuthGrantTypeClientCredentials)}, nil)
+	require.NoError(o.T(), err)
+}
+
+func (o *oauthClientSuite) TestBindOAuthClientUpdateUUIDWrong() {
+	err := ValidateOAuthClientUpdate(test.OAuthClientIDWrongFormat, test.OAuthClientNameCorrect, oauthClientRedirectURIsCorrect,
+		oauthClientGrantTypesCorrect, oauthClientScopesCorrect)
+	require.Error(o.T(), err)
+}
@@ -0,0 +1,82 @@
+package request
+
+import (
+	"fmt"
+	"regexp"
+
+	gouuid "github.com/satori/go.uuid"
+
+	"github.com/ssup2ket/service-auth/pkg/auth/webauthn"
+)
+
+var (
+	// Binary values of WebAuthn JSON are base64url encoded without padding, but padding is allowed
+	passkeyDataRegex = regexp.MustCompile("^[A-Za-z0-9_-]+={0,2}$")
+	// Authenticator transports of WebAuthn Level 3
+	passkeyTransports = map[string]bool{"usb": true, "nfc": true, "ble": true, "smart-card": true, "hybrid": true, "internal": true}
+)
+
+func ValidatePasskeyUUID(uuid string) error {
+	if _, err := gouuid.FromString(uuid); err != nil {
+		return fmt.Errorf("wrong uuid format")
+	}
+
+	return nil
+}
+
+func ValidatePasskeyRegistrationFinish(challengeUUID, name, credentialType, credentialID, clientDataJSON, attestationObject string,
+	transports []string) error {
+	// Challenge UUID
+	if _, err := gouuid.FromString(challengeUUID); err != nil {
+		return fmt.Errorf("wrong challenge uuid format")
+	}
+
+	// Name
+	if len(name) > 100 {
+		return fmt.Errorf("too long name")
+	}
+
+	// Credential
+	if err := validatePasskeyCredential(credentialType, credentialID, clientDataJSON, attestationObject); err != nil {
+		return err
+	}
+
+	// Transports
+	for _, transport := range transports {
+		if !passkeyTransports[transport] {
+			return fmt.Errorf("wrong transport")
+		}
+	}
+
+	return nil
+}
+
+func ValidatePasskeyLoginFinish(challengeUUID, credentialType, credentialID, clientDataJSON, authenticatorData, signature,
+	userHandle string) error {
+	// Challenge UUID
+	if _, err := gouuid.FromString(challengeUUID); err != nil {
+		return fmt.Errorf("wrong challenge uuid format")
+	}
+
+	// Credential. User handle is optional.
+	if err := validatePasskeyCredential(credentialType, credentialID, clientDataJSON, authenticatorData, signature); err != nil {
+		return err
+	}
+	if userHandle != "" && !passkeyDataRegex.MatchString(userHandle) {
+		return fmt.Errorf("wrong user handle format")
+	}
+
+	return nil
+}
+
+func validatePasskeyCredential(credentialType string, data ...string) error {
+	if credentialType != webauthn.CredentialType {
+		return fmt.Errorf("wrong credential type")
+	}
+	for _, d := range data {
+		if !passkeyDataRegex.MatchString(d) {
+			return fmt.Errorf("wrong credential data format")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package request
+
+import (
+	"testing"
+
+	"github.com/ssup2ket/service-auth/internal/test"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type passkeySuite struct {
+	suite.Suite
+}
+
+func TestPasskey(t *testing.T) {
+	suite.Run(t, new(passkeySuite))
+}
+
+// PasskeyUUID
+func (p *passkeySuite) TestBindPasskeyUUIDCorrect() {
+	err := ValidatePasskeyUUID(test.PasskeyIDCorrect.String())
+	require.NoError(p.T(), err)
+}
+
+func (p *passkeySuite) TestBindPasskeyUUIDWrong() {
+	err := ValidatePasskeyUUID(test.PasskeyIDWrongFormat)
+	require.Error(p.T(), err)
+}
+
+// PasskeyRegistrationFinish
+func (p *passkeySuite) TestBindPasskeyRegistrationFinishCorrect() {
+	err := ValidatePasskeyRegistrationFinish(test.PasskeyChallengeIDCorrect.String(), test.PasskeyNameCorrect, "public-key",
+		"dGVzdA", "dGVzdA==", "dGVzdA", []string{test.PasskeyTransportCorrect})
+	require.NoError(p.T(), err)
+}
+
+func (p *passkeySuite) TestBindPasskeyRegistrationFinishWrong() {
+	tests := map[string]error{
+		"challenge uuid": ValidatePasskeyRegistrationFinish(test.PasskeyIDWrongFormat, test.PasskeyNameCorrect, "public-key",
+			"dGVzdA", "dGVzdA", "dGVzdA", nil),
+		"credential type": ValidatePasskeyRegistrationFinish(test.PasskeyChallengeIDCorrect.String(), test.PasskeyNameCorrect, "password",
+			"dGVzdA", "dGVzdA", "dGVzdA", nil),
+		"credential id": ValidatePasskeyRegistrationFinish(test.PasskeyChallengeIDCorrect.String(), test.PasskeyNameCorrect, "public-key",
+			test.PasskeyCredentialIDWrongFormat, "dGVzdA", "dGVzdA", nil),
+		"attestation object": ValidatePasskeyRegistrationFinish(test.PasskeyChallengeIDCorrect.String(), test.PasskeyNameCorrect,
+			"public-key", "dGVzdA", "dGVzdA", "", nil),
+		"transport": ValidatePasskeyRegistrationFinish(test.PasskeyChallengeIDCorrect.String(), test.PasskeyNameCorrect, "public-key",
+			"dGVzdA", "dGVzdA", "dGVzdA", []string{test.PasskeyTransportWrong}),
+	}
+	for name, err := range tests {
+		require.Error(p.T(), err, name)
+	}
+}
+
+// PasskeyLoginFinish
+func (p *passkeySuite) TestBindPasskeyLoginFinishCorrect() {
+	for _, userHandle := range []string{"", "dGVzdA"} {
+		err := ValidatePasskeyLoginFinish(test.PasskeyChallengeIDCorrect.String(), "public-key", "dGVzdA", "dGVzdA", "dGVzdA",
+			"dGVzdA", userHandle)
+		require.NoError(p.T(), err, userHandle)
+	}
+}
+
+func (p *passkeySuite) TestBindPasskeyLoginFinishWrong() {
+	tests := map[string]error{
+		"challenge uuid": ValidatePasskeyLoginFinish("", "public-key", "dGVzdA", "dGVzdA", "dGVzdA", "dGVzdA", ""),
+		"signature":      ValidatePasskeyLoginFinish(test.PasskeyChallengeIDCorrect.String(), "public-key", "dGVzdA", "dGVzdA", "dGVzdA", "", ""),
+		"user handle": ValidatePasskeyLoginFinish(test.PasskeyChallengeIDCorrect.String(), "public-key", "dGVzdA", "dGVzdA", "dGVzdA",
+			"dGVzdA", test.PasskeyCredentialIDWrongFormat),
+	}
+	for name, err := range tests {
+		require.Error(p.T(), err, name)
+	}
+}
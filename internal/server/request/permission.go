@@ -0,0 +1,62 @@
+package request
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	gouuid "github.com/satori/go.uuid"
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+)
+
+func ValidatePermissionUUID(uuid string) error {
+	if _, err := gouuid.FromString(uuid); err != nil {
+		return fmt.Errorf("wrong uuid format")
+	}
+
+	return nil
+}
+
+func ValidatePermissionCreate(subject, object, action string) error {
+	return validatePermission(subject, object, action)
+}
+
+func ValidatePermissionUpdate(uuid, subject, object, action string) error {
+	// UUID
+	if uuid != "" {
+		if _, err := gouuid.FromString(uuid); err != nil {
+			return fmt.Errorf("wrong uuid format")
+		}
+	}
+
+	return validatePermission(subject, object, action)
+}
+
+func validatePermission(subject, object, action string) error {
+	// Subject is a role or a scope with the scope prefix
+	if strings.HasPrefix(subject, entity.PermissionScopePrefix) {
+		if !entity.IsValidScope(strings.TrimPrefix(subject, entity.PermissionScopePrefix)) {
+			return fmt.Errorf("wrong subject scope")
+		}
+	} else if err := validateRoleName(subject); err != nil {
+		return fmt.Errorf("wrong subject role")
+	}
+
+	// Object is a regex of operation objects
+	if len(object) == 0 || len(object) > 255 || strings.ContainsAny(object, " \t\n,") {
+		return fmt.Errorf("wrong object format")
+	}
+	if _, err := regexp.Compile(object); err != nil {
+		return fmt.Errorf("wrong object regex")
+	}
+
+	// Action is a regex of operation actions
+	if len(action) == 0 || len(action) > 255 || strings.ContainsAny(action, " \t\n,") {
+		return fmt.Errorf("wrong action format")
+	}
+	if _, err := regexp.Compile(action); err != nil {
+		return fmt.Errorf("wrong action regex")
+	}
+
+	return nil
+}
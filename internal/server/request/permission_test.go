@@ -0,0 +1,76 @@
+package request
+
+import (
+	"testing"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/test"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type permissionSuite struct {
+	suite.Suite
+}
+
+func TestPermission(t *testing.T) {
+	suite.Run(t, new(permissionSuite))
+}
+
+// PermissionUUID
+func (p *permissionSuite) TestBindPermissionUUIDCorrect() {
+	err := ValidatePermissionUUID(test.PermissionIDCorrect.String())
+	require.NoError(p.T(), err)
+}
+
+func (p *permissionSuite) TestBindPermissionUUIDWrong() {
+	err := ValidatePermissionUUID(test.PermissionIDWrongFormat)
+	require.Error(p.T(), err)
+}
+
+// PermissionCreate
+func (p *permissionSuite) TestBindPermissionCreateCorrect() {
+	validSubjects := []string{test.RoleNameCorrect, entity.PermissionScopePrefix + entity.ScopeUsersMeRead}
+	for _, validSubject := range validSubjects {
+		err := ValidatePermissionCreate(validSubject, test.PermissionObjectCorrect,
+			test.PermissionActionCorrect)
+		require.NoError(p.T(), err)
+	}
+}
+
+func (p *permissionSuite) TestBindPermissionCreateSubjectWrong() {
+	wrongSubjects := []string{"", test.PermissionSubjectWrongFormat, test.PermissionSubjectScopeWrong}
+	for _, wrongSubject := range wrongSubjects {
+		err := ValidatePermissionCreate(wrongSubject, test.PermissionObjectCorrect,
+			test.PermissionActionCorrect)
+		require.Error(p.T(), err)
+	}
+}
+
+func (p *permissionSuite) TestBindPermissionCreateObjectWrong() {
+	wrongObjects := []string{"", test.PermissionObjectWrongFormat}
+	for _, wrongObject := range wrongObjects {
+		err := ValidatePermissionCreate(test.RoleNameCorrect, wrongObject, test.PermissionActionCorrect)
+		require.Error(p.T(), err)
+	}
+}
+
+func (p *permissionSuite) TestBindPermissionCreateActionWrong() {
+	wrongActions := []string{"", test.PermissionActionWrongRegex}
+	for _, wrongAction := range wrongActions {
+		err := ValidatePermissionCreate(test.RoleNameCorrect, test.PermissionObjectCorrect, wrongAction)
+		require.Error(p.T(), err)
+	}
+}
+
+// PermissionUpdate
+func (p *permissionSuite) TestBindPermissionUpdateCorrect() {
+	err := ValidatePermissionUpdate("", test.RoleNameCorrect, test.PermissionObjectCorrect, test.PermissionActionCorrect)
+	require.NoError(p.T(), err)
+}
+
+func (p *permissionSuite) TestBindPermissionUpdateUUIDWrong() {
+	err := ValidatePermissionUpdate(test.PermissionIDWrongFormat, test.RoleNameCorrect, test.PermissionObjectCorrect,
+		test.PermissionActionCorrect)
+	require.Error(p.T(), err)
+}
@@ -0,0 +1,64 @@
+package request
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+)
+
+func ValidateRoleName(name string) error {
+	return validateRoleName(name)
+}
+
+func ValidateRoleCreate(name, description string, scopes []string, passwdMaxAgeDays int) error {
+	// Name
+	if err := validateRoleName(name); err != nil {
+		return err
+	}
+
+	return validateRole(description, scopes, passwdMaxAgeDays)
+}
+
+func ValidateRoleUpdate(name, description string, scopes []string, passwdMaxAgeDays int) error {
+	// Name
+	if name != "" {
+		if err := validateRoleName(name); err != nil {
+			return err
+		}
+	}
+
+	return validateRole(description, scopes, passwdMaxAgeDays)
+}
+
+func validateRoleName(name string) error {
+	nameMatched, err := regexp.MatchString("^[a-z][a-z0-9_-]{0,19}$", name)
+	if err != nil {
+		return fmt.Errorf("wrong role regex")
+	}
+	if !nameMatched {
+		return fmt.Errorf("wrong role")
+	}
+	return nil
+}
+
+func validateRole(description string, scopes []string, passwdMaxAgeDays int) error {
+	// Description
+	if len(description) > 255 {
+		return fmt.Errorf("wrong description length")
+	}
+
+	// Scopes
+	for _, scope := range scopes {
+		if !entity.IsValidScope(scope) {
+			return fmt.Errorf("wrong scope")
+		}
+	}
+
+	// Password max age
+	if passwdMaxAgeDays < 0 {
+		return fmt.Errorf("wrong password max age")
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package request
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/internal/test"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type roleSuite struct {
+	suite.Suite
+}
+
+func TestRole(t *testing.T) {
+	suite.Run(t, new(roleSuite))
+}
+
+var (
+	roleScopesCorrect = []string{entity.ScopeUsersMeRead, entity.ScopeUsersMeWrite}
+)
+
+// RoleName
+func (r *roleSuite) TestBindRoleNameCorrect() {
+	err := ValidateRoleName(test.RoleNameCorrect)
+	require.NoError(r.T(), err)
+}
+
+func (r *roleSuite) TestBindRoleNameWrong() {
+	wrongNames := []string{"", test.RoleNameWrongFormat, test.RoleNameLong}
+	for _, wrongName := range wrongNames {
+		err := ValidateRoleName(wrongName)
+		require.Error(r.T(), err)
+	}
+}
+
+// RoleCreate
+func (r *roleSuite) TestBindRoleCreateCorrect() {
+	err := ValidateRoleCreate(test.RoleNameCorrect, test.RoleDescriptionCorrect, roleScopesCorrect, test.RolePasswdMaxAgeDaysCorrect)
+	require.NoError(r.T(), err)
+}
+
+func (r *roleSuite) TestBindRoleCreateNameWrong() {
+	err := ValidateRoleCreate(test.RoleNameWrongFormat, test.RoleDescriptionCorrect, roleScopesCorrect, test.RolePasswdMaxAgeDaysCorrect)
+	require.Error(r.T(), err)
+}
+
+func (r *roleSuite) TestBindRoleCreateDescriptionWrong() {
+	err := ValidateRoleCreate(test.RoleNameCorrect, strings.Repeat("a", 256), roleScopesCorrect, test.RolePasswdMaxAgeDaysCorrect)
+	require.Error(r.T(), err)
+}
+
+func (r *roleSuite) TestBindRoleCreateScopeWrong() {
+	err := ValidateRoleCreate(test.RoleNameCorrect, test.RoleDescriptionCorrect, []string{test.RoleScopeWrong}, test.RolePasswdMaxAgeDaysCorrect)
+	require.Error(r.T(), err)
+}
+
+func (r *roleSuite) TestBindRoleCreatePasswdMaxAgeWrong() {
+	err := ValidateRoleCreate(test.RoleNameCorrect, test.RoleDescriptionCorrect, roleScopesCorrect, test.RolePasswdMaxAgeDaysWrong)
+	require.Error(r.T(), err)
+}
+
+// RoleUpdate
+func (r *roleSuite) TestBindRoleUpdateCorrect() {
+	err := ValidateRoleUpdate("", test.RoleDescriptionCorrect, roleScopesCorrect, test.RolePasswdMaxAgeDaysCorrect)
+	require.NoError(r.T(), err)
+}
+
+func (r *roleSuite) TestBindRoleUpdateScopeWrong() {
+	err := ValidateRoleUpdate(test.RoleNameCorrect, test.RoleDescriptionCorrect, []string{test.RoleScopeWrong}, test.RolePasswdMaxAgeDaysCorrect)
+	require.Error(r.T(), err)
+}
@@ -0,0 +1,51 @@
+package request
+
+import (
+	"fmt"
+	"regexp"
+)
+
+func ValidateTenantID(id string) error {
+	return validateTenantID(id)
+}
+
+func ValidateTenantCreate(id, description string) error {
+	// ID
+	if err := validateTenantID(id); err != nil {
+		return err
+	}
+
+	return validateTenant(description)
+}
+
+func ValidateTenantUpdate(id, description string) error {
+	// ID
+	if id != "" {
+		if err := validateTenantID(id); err != nil {
+			return err
+		}
+	}
+
+	return validateTenant(description)
+}
+
+// Tenant ID is a DNS label to be used as a subdomain
+func validateTenantID(id string) error {
+	idMatched, err := regexp.MatchString("^[a-z0-9]([a-z0-9-]{0,28}[a-z0-9])?$", id)
+	if err != nil {
+		return fmt.Errorf("wrong tenant ID regex")
+	}
+	if !idMatched {
+		return fmt.Errorf("wrong tenant ID")
+	}
+	return nil
+}
+
+func validateTenant(description string) error {
+	// Description
+	if len(description) > 255 {
+		return fmt.Errorf("wrong description length")
+	}
+
+	return nil
+}
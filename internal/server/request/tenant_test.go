@@ -0,0 +1,59 @@
+package request
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ssup2ket/service-auth/internal/test"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type tenantSuite struct {
+	suite.Suite
+}
+
+func TestTenant(t *testing.T) {
+	suite.Run(t, new(tenantSuite))
+}
+
+// TenantID
+func (t *tenantSuite) TestBindTenantIDCorrect() {
+	err := ValidateTenantID(test.TenantIDCorrect)
+	require.NoError(t.T(), err)
+}
+
+func (t *tenantSuite) TestBindTenantIDWrong() {
+	wrongIDs := []string{"", test.TenantIDWrongFormat, test.TenantIDLong, "-acme", "acme-"}
+	for _, wrongID := range wrongIDs {
+		err := ValidateTenantID(wrongID)
+		require.Error(t.T(), err)
+	}
+}
+
+// TenantCreate
+func (t *tenantSuite) TestBindTenantCreateCorrect() {
+	err := ValidateTenantCreate(test.TenantIDCorrect, test.TenantDescriptionCorrect)
+	require.NoError(t.T(), err)
+}
+
+func (t *tenantSuite) TestBindTenantCreateIDWrong() {
+	err := ValidateTenantCreate(test.TenantIDWrongFormat, test.TenantDescriptionCorrect)
+	require.Error(t.T(), err)
+}
+
+func (t *tenantSuite) TestBindTenantCreateDescriptionWrong() {
+	err := ValidateTenantCreate(test.TenantIDCorrect, strings.Repeat("a", 256))
+	require.Error(t.T(), err)
+}
+
+// TenantUpdate
+func (t *tenantSuite) TestBindTenantUpdateCorrect() {
+	err := ValidateTenantUpdate("", test.TenantDescriptionCorrect)
+	require.NoError(t.T(), err)
+}
+
+func (t *tenantSuite) TestBindTenantUpdateIDWrong() {
+	err := ValidateTenantUpdate(test.TenantIDWrongFormat, test.TenantDescriptionCorrect)
+	require.Error(t.T(), err)
+}
@@ -6,7 +6,6 @@ import (
 	"regexp"
 
 	gouuid "github.com/satori/go.uuid"
-	"github.com/ssup2ket/service-auth/internal/domain/entity"
 )
 
 func ValidateUserUUID(uuid string) error {
@@ -27,18 +26,14 @@ func ValidateUserCreate(id, passwd, role, phone, email string) error {
 		return fmt.Errorf("wrong id format")
 	}
 
-	// Password
-	passwdMatched, err := regexp.MatchString("^[a-zA-Z0-9]{8,20}$", passwd)
-	if err != nil {
-		return fmt.Errorf("wrong password regex")
-	}
-	if !passwdMatched {
-		return fmt.Errorf("wrong password format")
+	// Password is checked by the password policy of the user service
+	if passwd == "" {
+		return fmt.Errorf("no password")
 	}
 
 	// Role
-	if !entity.IsValidUserRole(role) {
-		return fmt.Errorf("wrong role")
+	if err := validateRoleName(role); err != nil {
+		return err
 	}
 
 	// Phone
@@ -51,8 +46,8 @@ func ValidateUserCreate(id, passwd, role, phone, email string) error {
 	}
 
 	// Email
-	if _, err := mail.ParseAddress(email); err != nil {
-		return fmt.Errorf("wrong email format")
+	if err := validateEmail(email); err != nil {
+		return err
 	}
 
 	return nil
@@ -66,21 +61,10 @@ func ValidateUserUpdate(uuid, passwd, role, phone, email string) error {
 		}
 	}
 
-	// Password
-	if passwd != "" {
-		passwdMatched, err := regexp.MatchString("^[a-zA-Z0-9]{8,20}$", passwd)
-		if err != nil {
-			return fmt.Errorf("wrong password regex")
-		}
-		if !passwdMatched {
-			return fmt.Errorf("wrong password format")
-		}
-	}
-
 	// Role
 	if role != "" {
-		if !entity.IsValidUserRole(role) {
-			return fmt.Errorf("wrong role")
+		if err := validateRoleName(role); err != nil {
+			return err
 		}
 	}
 
@@ -97,10 +81,36 @@ func ValidateUserUpdate(uuid, passwd, role, phone, email string) error {
 
 	// Email
 	if email != "" {
-		if _, err := mail.ParseAddress(email); err != nil {
-			return fmt.Errorf("wrong email format")
+		if err := validateEmail(email); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
+
+// Email has to be only an address without a display name, because verification mails are sent to it
+func validateEmail(email string) error {
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return fmt.Errorf("wrong email format")
+	}
+	return nil
+}
+
+func ValidateUserPasswdUpdate(passwd, newPasswd string) error {
+	// New password is checked by the password policy of the user service
+	if passwd == "" || newPasswd == "" {
+		return fmt.Errorf("no password")
+	}
+
+	return nil
+}
+
+func ValidateUserPasswdRemove(passwd string) error {
+	if passwd == "" {
+		return fmt.Errorf("no password")
+	}
+
+	return nil
+}
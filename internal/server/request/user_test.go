@@ -42,11 +42,13 @@ func (u *userSuite) TestBindUserCreateLoginIDWrong() {
 }
 
 func (u *userSuite) TestBindUserCreatePasswdWrong() {
-	wrongPasswds := []string{test.UserPasswdShort, test.UserPasswdLong}
-	for _, wrongPasswd := range wrongPasswds {
-		err := ValidateUserCreate(test.UserLoginIDCorrect, wrongPasswd, string(test.UserRoleCorrect), test.UserPhoneCorrect, test.UserEmailCorrect)
-		require.Error(u.T(), err)
-	}
+	err := ValidateUserCreate(test.UserLoginIDCorrect, "", string(test.UserRoleCorrect), test.UserPhoneCorrect, test.UserEmailCorrect)
+	require.Error(u.T(), err)
+}
+
+func (u *userSuite) TestBindUserCreatePasswdPassphrase() {
+	err := ValidateUserCreate(test.UserLoginIDCorrect, test.UserPasswdPassphrase, string(test.UserRoleCorrect), test.UserPhoneCorrect, test.UserEmailCorrect)
+	require.NoError(u.T(), err)
 }
 
 func (u *userSuite) TestBindUserCreateRoleWrong() {
@@ -64,6 +66,11 @@ func (u *userSuite) TestBindUserCreateEmailWrong() {
 	require.Error(u.T(), err)
 }
 
+func (u *userSuite) TestBindUserCreateEmailDisplayName() {
+	err := ValidateUserCreate(test.UserLoginIDCorrect, test.UserPasswdCorrect, string(test.UserRoleCorrect), test.UserPhoneCorrect, test.UserEmailWithDisplayName)
+	require.Error(u.T(), err)
+}
+
 // UserUpdate
 func (u *userSuite) TestBindUserUpdateCorrect() {
 	err := ValidateUserUpdate(test.UserIDCorrect.String(), test.UserPasswdCorrect, string(test.UserRoleCorrect), test.UserPhoneCorrect, test.UserEmailCorrect)
@@ -75,12 +82,9 @@ func (u *userSuite) TestBindUserUpdateUUIDWrong() {
 	require.Error(u.T(), err)
 }
 
-func (u *userSuite) TestBindUserUpdatePasswdWrong() {
-	wrongPasswds := []string{test.UserPasswdShort, test.UserPasswdLong}
-	for _, wrongPasswd := range wrongPasswds {
-		err := ValidateUserUpdate(test.UserIDCorrect.String(), wrongPasswd, string(test.UserRoleCorrect), test.UserPhoneCorrect, test.UserEmailCorrect)
-		require.Error(u.T(), err)
-	}
+func (u *userSuite) TestBindUserUpdatePasswdPassphrase() {
+	err := ValidateUserUpdate(test.UserIDCorrect.String(), test.UserPasswdPassphrase, string(test.UserRoleCorrect), test.UserPhoneCorrect, test.UserEmailCorrect)
+	require.NoError(u.T(), err)
 }
 
 func (u *userSuite) TestBindUserUpdateRoleWrong() {
@@ -97,3 +101,30 @@ func (u *userSuite) TestBindUserUpdateEmailWrong() {
 	err := ValidateUserUpdate(test.UserIDCorrect.String(), test.UserPasswdCorrect, string(test.UserRoleCorrect), test.UserPhoneCorrect, test.UserEmailWrongFormat)
 	require.Error(u.T(), err)
 }
+
+func (u *userSuite) TestBindUserUpdateEmailDisplayName() {
+	err := ValidateUserUpdate(test.UserIDCorrect.String(), test.UserPasswdCorrect, string(test.UserRoleCorrect), test.UserPhoneCorrect, test.UserEmailWithDisplayName)
+	require.Error(u.T(), err)
+}
+
+// UserPasswdUpdate
+func (u *userSuite) TestBindUserPasswdUpdateCorrect() {
+	err := ValidateUserPasswdUpdate(test.UserPasswdCorrect, test.UserPasswdCorrect2)
+	require.NoError(u.T(), err)
+}
+
+func (u *userSuite) TestBindUserPasswdUpdateNewPasswdEmpty() {
+	err := ValidateUserPasswdUpdate(test.UserPasswdCorrect, "")
+	require.Error(u.T(), err)
+}
+
+// UserPasswdRemove
+func (u *userSuite) TestBindUserPasswdRemoveCorrect() {
+	err := ValidateUserPasswdRemove(test.UserPasswdCorrect)
+	require.NoError(u.T(), err)
+}
+
+func (u *userSuite) TestBindUserPasswdRemoveEmpty() {
+	err := ValidateUserPasswdRemove("")
+	require.Error(u.T(), err)
+}
@@ -0,0 +1,11 @@
+package test
+
+const (
+	EmailVerificationTokenCorrect     = "dGVzdC1lbWFpbC12ZXJpZmljYXRpb24tdG9rZW4tMDE"
+	EmailVerificationTokenHashCorrect = "020ffe969944a41d27ada217b308171e4880ba010a5737c9514e74c10ee89bb9"
+	EmailVerificationTokenWrongFormat = "test token"
+	EmailVerificationURLCorrect       = "https://ssup2ket.com/verify-email"
+
+	UserEmailCorrect2        = "test2@test.com"
+	UserEmailWithDisplayName = "Test <test@test.com>"
+)
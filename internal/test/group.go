@@ -0,0 +1,42 @@
+package test
+
+import (
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+const (
+	GroupNameCorrect        = "developers"
+	GroupDescriptionCorrect = "Developers"
+
+	GroupIDWrongFormat       = "cccc-cccc"
+	GroupNameWrongFormat     = "developers!"
+	GroupNameLong            = "developersdevelopersdevelopersdevelopersdevelopers0"
+	GroupMemberTypeWrong     = "role"
+	GroupRoleWrongFormat     = "test role"
+	GroupMemberIDWrongFormat = "dddd-dddd"
+)
+
+var (
+	GroupIDCorrect  = uuid.FromStringOrNil("cccccccc-cccc-cccc-cccc-cccccccccccc")
+	GroupIDCorrect2 = uuid.FromStringOrNil("dddddddd-dddd-dddd-dddd-dddddddddddd")
+
+	GroupCorrect = entity.Group{
+		ID:          GroupIDCorrect,
+		TenantID:    TenantIDCorrect,
+		Name:        GroupNameCorrect,
+		Description: GroupDescriptionCorrect,
+		Roles:       entity.StrList{RoleNameCorrect},
+	}
+
+	GroupMemberUserCorrect = entity.GroupMember{
+		GroupID:    GroupIDCorrect,
+		MemberID:   UserIDCorrect,
+		MemberType: entity.GroupMemberTypeUser,
+	}
+	GroupMemberGroupCorrect = entity.GroupMember{
+		GroupID:    GroupIDCorrect2,
+		MemberID:   GroupIDCorrect,
+		MemberType: entity.GroupMemberTypeGroup,
+	}
+)
@@ -0,0 +1,19 @@
+package test
+
+import (
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+const (
+	LoginLockTypeCorrect    = entity.LoginLockTypeLoginID
+	LoginLockSubjectCorrect = UserLoginIDCorrect
+	LoginLockIPCorrect      = SessionIPCorrect
+
+	LoginLockIDWrongFormat = "eeee-eeee"
+)
+
+var (
+	LoginLockIDCorrect = uuid.FromStringOrNil("eeeeeeee-eeee-eeee-eeee-eeeeeeeeeeee")
+	LoginLockIDWrong   = uuid.FromStringOrNil("ffffffff-ffff-ffff-ffff-ffffffffffff")
+)
@@ -0,0 +1,20 @@
+package test
+
+import (
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+const (
+	MFASecretCorrect       = "test-mfa-secret"
+	MFATOTPIssuerCorrect   = "ssup2ket"
+	MFARecoveryCodeCorrect = "abcde-fghij"
+
+	MFATOTPCodeWrongFormat     = "12345"
+	MFARecoveryCodeWrongFormat = "abcde-fghi1"
+)
+
+var (
+	MFATOTPSecretCorrect = []byte("12345678901234567890")
+
+	MFARecoveryCodeIDCorrect = uuid.FromStringOrNil("abababab-abab-abab-abab-abababababab")
+)
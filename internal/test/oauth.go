@@ -0,0 +1,47 @@
+package test
+
+import (
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/pkg/auth/hashing"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+const (
+	OAuthClientNameCorrect        = "test-client"
+	OAuthClientSecretCorrect      = "test-client-secret"
+	OAuthClientSecretWrong        = "test-client-secret-wrong"
+	OAuthClientRedirectURICorrect = "https://client.ssup2ket.com/callback"
+	OAuthClientRedirectURIWrong   = "https://attacker.com/callback"
+
+	OAuthClientIDWrongFormat           = "cccc-cccc"
+	OAuthClientNameLong                = "testtesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttesttest"
+	OAuthClientRedirectURIWrongFormat  = "/callback"
+	OAuthClientRedirectURIWithFragment = "https://client.ssup2ket.com/callback#fragment"
+	OAuthClientGrantTypeWrong          = "password"
+	OAuthClientScopeWrongFormat        = "open id"
+
+	OAuthAuthCodeHashCorrect  = "a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2"
+	OAuthCodeVerifierCorrect  = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	OAuthCodeVerifierWrong    = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXX"
+	OAuthCodeChallengeCorrect = "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+	OAuthNonceCorrect         = "test-nonce"
+	OAuthScopeCorrect         = "openid profile"
+	OAuthScopeWrong           = "openid admin"
+)
+
+var (
+	OAuthClientIDCorrect = uuid.FromStringOrNil("cccccccc-cccc-cccc-cccc-cccccccccccc")
+
+	OAuthClientSecretSaltCorrect = []byte("aaaaaaaaaaaaaaaaaaaa")
+
+	OAuthClientCorrect = entity.OAuthClient{
+		ID:           OAuthClientIDCorrect,
+		Name:         OAuthClientNameCorrect,
+		SecretHash:   hashing.GetStrHash(OAuthClientSecretCorrect, OAuthClientSecretSaltCorrect),
+		SecretSalt:   OAuthClientSecretSaltCorrect,
+		RedirectURIs: []string{OAuthClientRedirectURICorrect},
+		GrantTypes: []string{string(entity.OAuthGrantTypeAuthorizationCode),
+			string(entity.OAuthGrantTypeClientCredentials), string(entity.OAuthGrantTypeRefreshToken)},
+		Scopes: []string{"openid", "profile", "email", "phone"},
+	}
+)
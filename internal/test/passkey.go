@@ -0,0 +1,24 @@
+package test
+
+import (
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+const (
+	PasskeyNameCorrect      = "test-passkey"
+	PasskeyRPIDCorrect      = "ssup2ket.com"
+	PasskeyOriginCorrect    = "https://ssup2ket.com"
+	PasskeyTransportCorrect = "internal"
+
+	PasskeyIDWrongFormat           = "cdcd-cdcd"
+	PasskeyCredentialIDWrongFormat = "test+passkey/credential"
+	PasskeyTransportWrong          = "carrier-pigeon"
+)
+
+var (
+	PasskeyIDCorrect          = uuid.FromStringOrNil("cdcdcdcd-cdcd-cdcd-cdcd-cdcdcdcdcdcd")
+	PasskeyChallengeIDCorrect = uuid.FromStringOrNil("dcdcdcdc-dcdc-dcdc-dcdc-dcdcdcdcdcdc")
+
+	PasskeyCredentialIDCorrect = []byte("test-passkey-credential")
+	PasskeyChallengeCorrect    = []byte("0123456789abcdef0123456789abcdef")
+)
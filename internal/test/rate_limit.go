@@ -0,0 +1,6 @@
+package test
+
+const (
+	RateLimitIPCorrect  = SessionIPCorrect
+	RateLimitIPCorrect2 = "127.0.0.2"
+)
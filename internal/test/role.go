@@ -0,0 +1,63 @@
+package test
+
+import (
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+const (
+	RoleNameCorrect             = "tester"
+	RoleNameWideCorrect         = "operator"
+	RoleDescriptionCorrect      = "Tester"
+	RolePasswdMaxAgeDaysCorrect = 90
+
+	RoleNameWrongFormat       = "Tester!"
+	RoleNameLong              = "testtesttesttesttesttest"
+	RoleScopeWrong            = "unknown"
+	RolePasswdMaxAgeDaysWrong = -1
+
+	PermissionObjectCorrect = "^userme$"
+	PermissionActionCorrect = "^(get|update)$"
+
+	PermissionIDWrongFormat      = "eeee-eeee"
+	PermissionSubjectWrongFormat = "tester, admin"
+	PermissionSubjectScopeWrong  = "scope:unknown"
+	PermissionObjectWrongFormat  = "user me"
+	PermissionActionWrongRegex   = "^(get|update$"
+)
+
+var (
+	PermissionIDCorrect = uuid.FromStringOrNil("eeeeeeee-eeee-eeee-eeee-eeeeeeeeeeee")
+
+	RoleAdminCorrect = entity.Role{
+		Name:        string(entity.UserRoleAdmin),
+		Description: "Administrator",
+		Scopes:      entity.GetAllScopes(),
+	}
+
+	RoleCorrect = entity.Role{
+		Name:        RoleNameCorrect,
+		Description: RoleDescriptionCorrect,
+		Scopes:      entity.StrList{entity.ScopeUsersMeRead},
+
+		PasswdMaxAgeDays: RolePasswdMaxAgeDaysCorrect,
+	}
+
+	PermissionCorrect = entity.Permission{
+		ID:      PermissionIDCorrect,
+		Subject: RoleNameCorrect,
+		Object:  PermissionObjectCorrect,
+		Action:  PermissionActionCorrect,
+	}
+
+	// Permissions of the tenant admin role and custom roles. The permissions of the tester role are covered by
+	// the tenant admin role, and the permissions of the operator role aren't.
+	PermissionsCorrect = []entity.Permission{
+		{Subject: string(entity.UserRoleTenantAdmin), Object: "^user$", Action: ".*"},
+		{Subject: string(entity.UserRoleTenantAdmin), Object: "^userme$", Action: ".*"},
+		{Subject: string(entity.UserRoleTenantAdmin), Object: "^token$", Action: "^(logout|logoutall|introspect)$"},
+		{Subject: string(entity.UserRoleTenantAdmin), Object: "^group$", Action: ".*"},
+		PermissionCorrect,
+		{Subject: RoleNameWideCorrect, Object: ".*", Action: ".*"},
+	}
+)
@@ -0,0 +1,22 @@
+package test
+
+import (
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+const (
+	SessionDeviceNameCorrect = "test-phone"
+	SessionUserAgentCorrect  = "test-agent"
+	SessionIPCorrect         = "127.0.0.1"
+)
+
+var (
+	SessionScopesCorrect = []string{entity.ScopeUsersMeRead, entity.ScopeUsersRead, entity.ScopeUsersMeRead}
+	SessionScopesWrong   = []string{entity.ScopeUsersMeRead, "unknown"}
+)
+
+var (
+	SessionIDCorrect  = uuid.FromStringOrNil("dddddddd-dddd-dddd-dddd-dddddddddddd")
+	SessionIDCorrect2 = uuid.FromStringOrNil("eeeeeeee-eeee-eeee-eeee-eeeeeeeeeeee")
+)
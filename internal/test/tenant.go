@@ -0,0 +1,22 @@
+package test
+
+import (
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+)
+
+const (
+	TenantIDCorrect          = "acme"
+	TenantIDCorrect2         = "globex"
+	TenantDescriptionCorrect = "Acme"
+	TenantDomainCorrect      = "auth.example.com"
+
+	TenantIDWrongFormat = "Acme!"
+	TenantIDLong        = "acmeacmeacmeacmeacmeacmeacmeacme"
+)
+
+var (
+	TenantCorrect = entity.Tenant{
+		ID:          TenantIDCorrect,
+		Description: TenantDescriptionCorrect,
+	}
+)
@@ -0,0 +1,18 @@
+package test
+
+import (
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+const (
+	TokenKeyKeyIDCorrect  = "kid0000"
+	TokenKeyTypeCorrect   = entity.TokenKeyTypeAccess
+	TokenKeyAlgCorrect    = "HS256"
+	TokenKeyStatusCorrect = entity.TokenKeyStatusPrimary
+	TokenKeyKeyCorrect    = "key"
+)
+
+var (
+	TokenKeyIDCorrect = uuid.FromStringOrNil("cccccccc-cccc-cccc-cccc-cccccccccccc")
+)
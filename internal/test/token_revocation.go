@@ -0,0 +1,16 @@
+package test
+
+import (
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+const (
+	TokenRevocationTypeCorrect    = entity.TokenRevocationTypeUser
+	TokenRevocationSubjectCorrect = "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	TokenRevocationTokenIDCorrect = "ffffffff-ffff-ffff-ffff-ffffffffffff"
+)
+
+var (
+	TokenRevocationIDCorrect = uuid.FromStringOrNil("eeeeeeee-eeee-eeee-eeee-eeeeeeeeeeee")
+)
@@ -1,6 +1,8 @@
 package test
 
 import (
+	"strings"
+
 	"github.com/ssup2ket/service-auth/internal/domain/entity"
 	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
 )
@@ -9,7 +11,8 @@ const (
 	UserLoginIDCorrect  = "test0000"
 	UserLoginIDCorrect2 = "test1111"
 	UserRoleCorrect     = entity.UserRoleAdmin
-	UserPasswdCorrect   = "test0000"
+	UserPasswdCorrect   = "secret-0000"
+	UserPasswdCorrect2  = "secret-1111"
 	UserPhoneCorrect    = "000-0000-0000"
 	UserEmailCorrect    = "test@test.com"
 
@@ -17,13 +20,37 @@ const (
 	UserLoginIDShort     = "test0"
 	UserLoginIDLong      = "testtesttesttesttesttest"
 	UserPasswdShort      = "test0"
-	UserPasswdLong       = "testtesttesttesttesttest"
-	UserRoleWrong        = "tester"
+	UserPasswdPassphrase = "Correct horse battery staple, 2 times!"
+	UserPasswdBreached   = "Password123"
+	UserRoleWrong        = "test role"
 	UserPhoneWrongFormat = "00-000-00000"
 	UserEmailWrongFormat = "testtest.com"
 )
 
 var (
+	UserPasswdLong = strings.Repeat("secret-0", 17)
+
 	UserIDCorrect  = uuid.FromStringOrNil("aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa")
 	UserIDCorrect2 = uuid.FromStringOrNil("bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb")
+
+	SubjectUserCorrect = entity.Subject{
+		UserID:    UserIDCorrect.String(),
+		UserRoles: []entity.UserRole{entity.UserRoleUser},
+		TenantID:  TenantIDCorrect,
+	}
+	SubjectOtherUserCorrect = entity.Subject{
+		UserID:    UserIDCorrect2.String(),
+		UserRoles: []entity.UserRole{entity.UserRoleUser},
+		TenantID:  TenantIDCorrect,
+	}
+	SubjectAdminCorrect = entity.Subject{
+		UserID:    UserIDCorrect2.String(),
+		UserRoles: []entity.UserRole{entity.UserRoleAdmin},
+		TenantID:  TenantIDCorrect,
+	}
+	SubjectTenantAdminCorrect = entity.Subject{
+		UserID:    UserIDCorrect2.String(),
+		UserRoles: []entity.UserRole{entity.UserRoleTenantAdmin},
+		TenantID:  TenantIDCorrect,
+	}
 )
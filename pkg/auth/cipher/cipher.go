@@ -0,0 +1,55 @@
+package cipher
+
+import (
+	"crypto/aes"
+	gocipher "crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+)
+
+// Error
+var (
+	ErrWrongCiphertext error = fmt.Errorf("wrong ciphertext")
+)
+
+// Encrypt with AES-256-GCM. The AES key is derived from the secret.
+// The nonce is prepended to the ciphertext.
+func Encrypt(secret, plaintext []byte) ([]byte, error) {
+	gcm, err := getGCM(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func Decrypt(secret, ciphertext []byte) ([]byte, error) {
+	gcm, err := getGCM(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, ErrWrongCiphertext
+	}
+	nonce, sealed := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return nil, ErrWrongCiphertext
+	}
+	return plaintext, nil
+}
+
+func getGCM(secret []byte) (gocipher.AEAD, error) {
+	key := sha256.Sum256(secret)
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	return gocipher.NewGCM(block)
+}
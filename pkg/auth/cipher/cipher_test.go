@@ -0,0 +1,28 @@
+package cipher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	secret := []byte("secret")
+	plaintext := []byte("plaintext")
+
+	ciphertext, err := Encrypt(secret, plaintext)
+	require.NoError(t, err, "Failed to encrypt")
+	require.NotEqual(t, plaintext, ciphertext)
+
+	decrypted, err := Decrypt(secret, ciphertext)
+	require.NoError(t, err, "Failed to decrypt")
+	require.Equal(t, plaintext, decrypted)
+}
+
+func TestDecryptWrongSecret(t *testing.T) {
+	ciphertext, err := Encrypt([]byte("secret"), []byte("plaintext"))
+	require.NoError(t, err, "Failed to encrypt")
+
+	_, err = Decrypt([]byte("wrong"), ciphertext)
+	require.Equal(t, ErrWrongCiphertext, err)
+}
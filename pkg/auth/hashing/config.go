@@ -0,0 +1,32 @@
+package hashing
+
+// Config has the algorithm and parameters of new password hashes
+type Config struct {
+	Alg Alg
+
+	// Argon2id parameters. Memory is in KiB.
+	Argon2Memory  uint32
+	Argon2Time    uint32
+	Argon2Threads uint8
+
+	// Bcrypt parameters
+	BcryptCost int
+}
+
+var config = GetDefaultConfig()
+
+func GetDefaultConfig() Config {
+	return Config{
+		Alg: AlgArgon2id,
+
+		Argon2Memory:  64 * 1024, // 64 MiB
+		Argon2Time:    3,
+		Argon2Threads: 2,
+
+		BcryptCost: 12,
+	}
+}
+
+func SetConfig(c Config) {
+	config = c
+}
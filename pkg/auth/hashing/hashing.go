@@ -1,9 +1,9 @@
 package hashing
 
 import (
-	"bytes"
 	"crypto/rand"
 	"crypto/sha256"
+	"crypto/subtle"
 
 	"golang.org/x/crypto/pbkdf2"
 )
@@ -26,10 +26,13 @@ func GetSalt(size int) ([]byte, error) {
 	return b, nil
 }
 
-func GetStrHash(passwd string, salt []byte) []byte {
-	return pbkdf2.Key([]byte(passwd), salt, 4096, sha256.Size, sha256.New)
+const strHashIter = 4096
+
+// Get the PBKDF2 hash of a random string like a refresh token. Use GetPasswdHash for passwords.
+func GetStrHash(str string, salt []byte) []byte {
+	return pbkdf2.Key([]byte(str), salt, strHashIter, sha256.Size, sha256.New)
 }
 
-func ValidateStr(passwd string, hash, salt []byte) bool {
-	return bytes.Equal(GetStrHash(passwd, salt), hash)
+func ValidateStr(str string, hash, salt []byte) bool {
+	return subtle.ConstantTimeCompare(GetStrHash(str, salt), hash) == 1
 }
@@ -0,0 +1,153 @@
+package hashing
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// Password hashes are self-describing strings having the algorithm and parameters. Argon2id and PBKDF2 hashes
+// are PHC strings like "$argon2id$v=19$m=65536,t=3,p=2$<salt>$<hash>" with unpadded base64 salts and hashes,
+// and bcrypt hashes are in the bcrypt format like "$2a$12$<salt and hash>".
+type Alg string
+
+const (
+	AlgArgon2id Alg = "argon2id"
+	AlgBcrypt   Alg = "bcrypt"
+	// Only for validating legacy hashes
+	AlgPBKDF2SHA256 Alg = "pbkdf2-sha256"
+)
+
+const (
+	passwdSaltSize = 16
+	passwdHashSize = 32
+)
+
+var ErrPasswdHashFormat = errors.New("wrong password hash format")
+
+// Get the hash of the password with the algorithm and parameters of the config
+func GetPasswdHash(passwd string) (string, error) {
+	switch config.Alg {
+	case AlgArgon2id:
+		salt, err := GetSalt(passwdSaltSize)
+		if err != nil {
+			return "", err
+		}
+		hash := argon2.IDKey([]byte(passwd), salt, config.Argon2Time, config.Argon2Memory, config.Argon2Threads, passwdHashSize)
+		return encodeArgon2idHash(config.Argon2Memory, config.Argon2Time, config.Argon2Threads, salt, hash), nil
+	case AlgBcrypt:
+		hash, err := bcrypt.GenerateFromPassword([]byte(passwd), config.BcryptCost)
+		if err != nil {
+			return "", err
+		}
+		return string(hash), nil
+	}
+	return "", fmt.Errorf("unsupported password hash algorithm %s", config.Alg)
+}
+
+// Get the PHC string of a legacy PBKDF2 hash and salt
+func GetLegacyPasswdHash(hash, salt []byte) string {
+	return fmt.Sprintf("$%s$i=%d$%s$%s", AlgPBKDF2SHA256, strHashIter,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(hash))
+}
+
+// Validate the password with the hash. Hashes are compared in constant time.
+func ValidatePasswd(passwd, passwdHash string) (bool, error) {
+	alg, params, salt, hash, err := decodePasswdHash(passwdHash)
+	if err != nil {
+		return false, err
+	}
+
+	var passwdKey []byte
+	switch alg {
+	case AlgArgon2id:
+		var memory, time uint32
+		var threads uint8
+		if _, err := fmt.Sscanf(params, "m=%d,t=%d,p=%d", &memory, &time, &threads); err != nil || time == 0 || threads == 0 {
+			return false, ErrPasswdHashFormat
+		}
+		passwdKey = argon2.IDKey([]byte(passwd), salt, time, memory, threads, uint32(len(hash)))
+	case AlgBcrypt:
+		// Bcrypt compares hashes in constant time
+		if err := bcrypt.CompareHashAndPassword([]byte(passwdHash), []byte(passwd)); err != nil {
+			if err == bcrypt.ErrMismatchedHashAndPassword {
+				return false, nil
+			}
+			return false, ErrPasswdHashFormat
+		}
+		return true, nil
+	case AlgPBKDF2SHA256:
+		var iter int
+		if _, err := fmt.Sscanf(params, "i=%d", &iter); err != nil || iter <= 0 {
+			return false, ErrPasswdHashFormat
+		}
+		passwdKey = pbkdf2.Key([]byte(passwd), salt, iter, len(hash), sha256.New)
+	}
+	return subtle.ConstantTimeCompare(passwdKey, hash) == 1, nil
+}
+
+// Check whether the hash needs to be rehashed, because its algorithm or parameters are different from the config
+func IsPasswdHashOutdated(passwdHash string) bool {
+	alg, params, _, _, err := decodePasswdHash(passwdHash)
+	if err != nil || alg != config.Alg {
+		return true
+	}
+
+	switch alg {
+	case AlgArgon2id:
+		return params != fmt.Sprintf("m=%d,t=%d,p=%d", config.Argon2Memory, config.Argon2Time, config.Argon2Threads)
+	case AlgBcrypt:
+		cost, err := bcrypt.Cost([]byte(passwdHash))
+		return err != nil || cost != config.BcryptCost
+	}
+	return true
+}
+
+func encodeArgon2idHash(memory, time uint32, threads uint8, salt, hash []byte) string {
+	return fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s", AlgArgon2id, argon2.Version, memory, time, threads,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(hash))
+}
+
+// Decode the hash to its algorithm, parameters, salt and hash. Bcrypt hashes are only checked their algorithm.
+func decodePasswdHash(passwdHash string) (alg Alg, params string, salt, hash []byte, err error) {
+	fields := strings.Split(passwdHash, "$")
+	if len(fields) < 2 || fields[0] != "" {
+		return "", "", nil, nil, ErrPasswdHashFormat
+	}
+
+	switch fields[1] {
+	case "2a", "2b", "2y":
+		return AlgBcrypt, "", nil, nil, nil
+	case string(AlgArgon2id):
+		// $argon2id$v=<version>$<params>$<salt>$<hash>
+		if len(fields) != 6 || fields[2] != fmt.Sprintf("v=%d", argon2.Version) {
+			return "", "", nil, nil, ErrPasswdHashFormat
+		}
+		alg, params = AlgArgon2id, fields[3]
+		fields = fields[4:]
+	case string(AlgPBKDF2SHA256):
+		// $pbkdf2-sha256$<params>$<salt>$<hash>
+		if len(fields) != 5 {
+			return "", "", nil, nil, ErrPasswdHashFormat
+		}
+		alg, params = AlgPBKDF2SHA256, fields[2]
+		fields = fields[3:]
+	default:
+		return "", "", nil, nil, ErrPasswdHashFormat
+	}
+
+	if salt, err = base64.RawStdEncoding.DecodeString(fields[0]); err != nil {
+		return "", "", nil, nil, ErrPasswdHashFormat
+	}
+	if hash, err = base64.RawStdEncoding.DecodeString(fields[1]); err != nil || len(hash) == 0 {
+		return "", "", nil, nil, ErrPasswdHashFormat
+	}
+	return alg, params, salt, hash, nil
+}
@@ -0,0 +1,83 @@
+package hashing
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePasswdArgon2id(t *testing.T) {
+	passwd := "passwd"
+	passwdHash, err := GetPasswdHash(passwd)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(passwdHash, "$argon2id$v=19$m=65536,t=3,p=2$"))
+
+	valid, err := ValidatePasswd(passwd, passwdHash)
+	require.NoError(t, err)
+	require.True(t, valid)
+	valid, err = ValidatePasswd("wrong", passwdHash)
+	require.NoError(t, err)
+	require.False(t, valid)
+	require.False(t, IsPasswdHashOutdated(passwdHash))
+}
+
+func TestValidatePasswdBcrypt(t *testing.T) {
+	defer SetConfig(GetDefaultConfig())
+	cfg := GetDefaultConfig()
+	cfg.Alg = AlgBcrypt
+	cfg.BcryptCost = 4
+	SetConfig(cfg)
+
+	passwd := "passwd"
+	passwdHash, err := GetPasswdHash(passwd)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(passwdHash, "$2a$04$"))
+
+	valid, err := ValidatePasswd(passwd, passwdHash)
+	require.NoError(t, err)
+	require.True(t, valid)
+	valid, err = ValidatePasswd("wrong", passwdHash)
+	require.NoError(t, err)
+	require.False(t, valid)
+	require.False(t, IsPasswdHashOutdated(passwdHash))
+}
+
+func TestValidatePasswdLegacy(t *testing.T) {
+	passwd := "passwd"
+	hash, salt, err := GetStrHashAndSalt(passwd)
+	require.NoError(t, err)
+	passwdHash := GetLegacyPasswdHash(hash, salt)
+
+	valid, err := ValidatePasswd(passwd, passwdHash)
+	require.NoError(t, err)
+	require.True(t, valid)
+	valid, err = ValidatePasswd("wrong", passwdHash)
+	require.NoError(t, err)
+	require.False(t, valid)
+	require.True(t, IsPasswdHashOutdated(passwdHash))
+}
+
+func TestValidatePasswdWrongFormat(t *testing.T) {
+	for _, passwdHash := range []string{"", "passwd", "$md5$abc", "$argon2id$v=19$m=65536,t=3,p=0$c2FsdA$aGFzaA",
+		"$argon2id$v=16$m=65536,t=3,p=2$c2FsdA$aGFzaA", "$pbkdf2-sha256$i=4096$!!$aGFzaA"} {
+		_, err := ValidatePasswd("passwd", passwdHash)
+		require.Equal(t, ErrPasswdHashFormat, err, passwdHash)
+	}
+}
+
+func TestIsPasswdHashOutdatedParams(t *testing.T) {
+	passwdHash, err := GetPasswdHash("passwd")
+	require.NoError(t, err)
+
+	defer SetConfig(GetDefaultConfig())
+	cfg := GetDefaultConfig()
+	cfg.Argon2Time = 4
+	SetConfig(cfg)
+	require.True(t, IsPasswdHashOutdated(passwdHash))
+
+	cfg = GetDefaultConfig()
+	cfg.Alg = AlgBcrypt
+	SetConfig(cfg)
+	require.True(t, IsPasswdHashOutdated(passwdHash))
+}
@@ -0,0 +1,137 @@
+package passwd
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Minimum length of user inputs like login IDs and emails to check whether passwords contain them
+const minUserInputLength = 4
+
+var (
+	ErrTooShort    = errors.New("password is too short")
+	ErrTooLong     = errors.New("password is too long")
+	ErrCharClasses = errors.New("password doesn't have enough character classes")
+	ErrBreached    = errors.New("password is in the breached password list")
+	ErrUserInput   = errors.New("password contains user information")
+)
+
+// Check whether the error is a violation of password policies
+func IsPolicyErr(err error) bool {
+	return err == ErrTooShort || err == ErrTooLong || err == ErrCharClasses || err == ErrBreached || err == ErrUserInput
+}
+
+// Policy validates new passwords. User inputs are user information like login IDs and emails
+// which passwords can't contain.
+type Policy interface {
+	Validate(passwd string, userInputs ...string) error
+}
+
+// PolicyConfig has the rules of the default policy. Lengths are counted in characters, and character classes
+// are lowercase letters, uppercase letters, digits and others.
+type PolicyConfig struct {
+	MinLength      int
+	MaxLength      int
+	MinCharClasses int
+
+	// Lowercase breached passwords
+	BreachedPasswds map[string]bool
+}
+
+func GetDefaultPolicyConfig() PolicyConfig {
+	return PolicyConfig{
+		MinLength:       8,
+		MaxLength:       128,
+		MinCharClasses:  2,
+		BreachedPasswds: map[string]bool{},
+	}
+}
+
+type DefaultPolicy struct {
+	config PolicyConfig
+}
+
+func NewDefaultPolicy(c PolicyConfig) *DefaultPolicy {
+	return &DefaultPolicy{
+		config: c,
+	}
+}
+
+func (d *DefaultPolicy) Validate(passwd string, userInputs ...string) error {
+	// Length
+	length := utf8.RuneCountInString(passwd)
+	if length < d.config.MinLength {
+		return ErrTooShort
+	} else if length > d.config.MaxLength {
+		return ErrTooLong
+	}
+
+	// Character classes
+	if getCharClasses(passwd) < d.config.MinCharClasses {
+		return ErrCharClasses
+	}
+
+	// Breached passwords
+	lowerPasswd := strings.ToLower(passwd)
+	if d.config.BreachedPasswds[lowerPasswd] {
+		return ErrBreached
+	}
+
+	// User inputs. The local part of emails is also checked.
+	for _, userInput := range userInputs {
+		inputs := []string{userInput}
+		if at := strings.LastIndex(userInput, "@"); at > 0 {
+			inputs = append(inputs, userInput[:at])
+		}
+		for _, input := range inputs {
+			if utf8.RuneCountInString(input) >= minUserInputLength && strings.Contains(lowerPasswd, strings.ToLower(input)) {
+				return ErrUserInput
+			}
+		}
+	}
+	return nil
+}
+
+// Load breached passwords from the file having a password per line. Empty lines and lines starting with "#" are skipped.
+func LoadBreachedPasswds(path string) (map[string]bool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	passwds := map[string]bool{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		passwds[strings.ToLower(line)] = true
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return passwds, nil
+}
+
+func getCharClasses(passwd string) int {
+	var lower, upper, digit, other int
+	for _, r := range passwd {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			other = 1
+		}
+	}
+	return lower + upper + digit + other
+}
@@ -0,0 +1,65 @@
+package passwd
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSuccess(t *testing.T) {
+	policy := NewDefaultPolicy(GetDefaultPolicyConfig())
+	require.NoError(t, policy.Validate("correct horse battery staple", "test0000", "test@test.com"))
+	require.NoError(t, policy.Validate("Passwd!@#$%^"))
+}
+
+func TestValidateLength(t *testing.T) {
+	policy := NewDefaultPolicy(GetDefaultPolicyConfig())
+	require.Equal(t, ErrTooShort, policy.Validate("abc123"))
+	require.Equal(t, ErrTooLong, policy.Validate(string(make([]byte, 129))))
+
+	// Length is counted in characters
+	require.NoError(t, policy.Validate("비밀번호는1234"))
+}
+
+func TestValidateCharClasses(t *testing.T) {
+	policy := NewDefaultPolicy(GetDefaultPolicyConfig())
+	require.Equal(t, ErrCharClasses, policy.Validate("abcdefghij"))
+	require.Equal(t, ErrCharClasses, policy.Validate("1234567890"))
+}
+
+func TestValidateBreached(t *testing.T) {
+	cfg := GetDefaultPolicyConfig()
+	cfg.BreachedPasswds = map[string]bool{"password1": true}
+	policy := NewDefaultPolicy(cfg)
+	require.Equal(t, ErrBreached, policy.Validate("Password1"))
+}
+
+func TestValidateUserInput(t *testing.T) {
+	policy := NewDefaultPolicy(GetDefaultPolicyConfig())
+	require.Equal(t, ErrUserInput, policy.Validate("Test0000!", "test0000"))
+	require.Equal(t, ErrUserInput, policy.Validate("alice-1234", "test0000", "Alice@test.com"))
+
+	// Short user inputs aren't checked
+	require.NoError(t, policy.Validate("bob-12345", "bob@test.com"))
+}
+
+func TestLoadBreachedPasswds(t *testing.T) {
+	file, err := ioutil.TempFile("", "breached")
+	require.NoError(t, err)
+	defer os.Remove(file.Name())
+	_, err = file.WriteString("# comment\n\nPassword1\nqwerty123\n")
+	require.NoError(t, err)
+	file.Close()
+
+	passwds, err := LoadBreachedPasswds(file.Name())
+	require.NoError(t, err)
+	require.Equal(t, map[string]bool{"password1": true, "qwerty123": true}, passwds)
+}
+
+func TestLoadBundledBreachedPasswds(t *testing.T) {
+	passwds, err := LoadBreachedPasswds("../../../configs/breached_passwds.txt")
+	require.NoError(t, err)
+	require.True(t, passwds["password123"])
+}
@@ -0,0 +1,45 @@
+package token
+
+import (
+	"time"
+)
+
+// Config has claims and lifetimes of tokens
+type Config struct {
+	AccessTokenLifetime  time.Duration
+	RefreshTokenLifetime time.Duration
+
+	// Issuer and audience aren't set and checked if they are empty
+	Issuer    string
+	Audience  string   // Default audience and audience of service-auth APIs
+	Audiences []string // Additional audiences which login can request
+
+	// Allowed clock skew between token issuer and validators
+	Leeway time.Duration
+}
+
+var config = GetDefaultConfig()
+
+func GetDefaultConfig() Config {
+	return Config{
+		AccessTokenLifetime:  time.Hour,           // 1 hour
+		RefreshTokenLifetime: time.Hour * 24 * 14, // 2 weeks
+	}
+}
+
+func SetConfig(c Config) {
+	config = c
+}
+
+// Check whether login can request the audience. Empty audience means the default audience.
+func IsAudienceAllowed(audience string) bool {
+	if audience == "" || audience == config.Audience {
+		return true
+	}
+	for _, allowed := range config.Audiences {
+		if audience == allowed {
+			return true
+		}
+	}
+	return false
+}
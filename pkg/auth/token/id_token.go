@@ -0,0 +1,60 @@
+package token
+
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt"
+
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
+)
+
+// OpenID Connect ID token claims
+type IDTokenClaims struct {
+	jwt.StandardClaims
+	AuthTime          int64  `json:"auth_time,omitempty"`
+	Nonce             string `json:"nonce,omitempty"`
+	PreferredUsername string `json:"preferred_username,omitempty"`
+	Email             string `json:"email,omitempty"`
+	PhoneNumber       string `json:"phone_number,omitempty"`
+}
+
+// Create an OpenID Connect ID token for the client. ID tokens are signed with the access token key,
+// so clients verify ID tokens with public keys of JWKS.
+func CreateIDToken(idInfo *IDTokenClaims, issuer, clientID string) (*TokenInfo, error) {
+	if keyProvider == nil {
+		return nil, ErrNoKeyProvider
+	}
+	tokenKey := keyProvider.GetAccessTokenKey()
+	if tokenKey == nil {
+		return nil, ErrNoSigningKey
+	}
+
+	// Calculate issuance and expiration time
+	issuedAt := time.Now()
+	expiresAt := issuedAt.Add(config.AccessTokenLifetime)
+
+	// Set ID token
+	claims := *idInfo
+	claims.StandardClaims = jwt.StandardClaims{
+		Id:        uuid.NewV4().String(),
+		Issuer:    issuer,
+		Subject:   idInfo.Subject,
+		Audience:  clientID,
+		IssuedAt:  issuedAt.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+	}
+	token := jwt.NewWithClaims(tokenKey.Method, &claims)
+	token.Header["kid"] = tokenKey.ID
+
+	// Signing ID token
+	tokenSigned, err := token.SignedString(tokenKey.SignKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenInfo{
+		Token:     tokenSigned,
+		IssuedAt:  issuedAt,
+		ExpiresAt: expiresAt,
+	}, nil
+}
@@ -0,0 +1,36 @@
+package token
+
+import (
+	"testing"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	clientIDCorrect = "client0000"
+)
+
+func TestCreateIDToken(t *testing.T) {
+	tokenInfo, err := CreateIDToken(&IDTokenClaims{
+		StandardClaims:    jwt.StandardClaims{Subject: userIDCorrect},
+		Nonce:             "nonce",
+		PreferredUsername: userLoginIDCorrect,
+	}, "issuer", clientIDCorrect)
+	require.NoError(t, err, "Failed to create ID token")
+
+	claims := IDTokenClaims{}
+	_, err = jwt.ParseWithClaims(tokenInfo.Token, &claims, func(token *jwt.Token) (interface{}, error) {
+		return keyProvider.GetAccessTokenVerifyKey(token.Header["kid"].(string)).VerifyKey, nil
+	})
+	require.NoError(t, err, "Failed to parse ID token")
+	require.Equal(t, "issuer", claims.Issuer)
+	require.Equal(t, clientIDCorrect, claims.Audience)
+	require.Equal(t, userIDCorrect, claims.Subject)
+	require.Equal(t, "nonce", claims.Nonce)
+	require.Equal(t, userLoginIDCorrect, claims.PreferredUsername)
+
+	// ID token isn't an access token for service-auth
+	_, err = ValidateAccessToken(tokenInfo.Token)
+	require.Error(t, err, "ID token is validated as access token")
+}
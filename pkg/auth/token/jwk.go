@@ -0,0 +1,126 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+)
+
+// JWK (RFC 7517)
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use,omitempty"`
+	Kid string `json:"kid,omitempty"`
+	Alg string `json:"alg,omitempty"`
+
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+
+	// EC, OKP
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// Get public keys to verify access tokens. HMAC keys are never published.
+func GetJWKS() (*JWKS, error) {
+	if keyProvider == nil {
+		return nil, ErrNoKeyProvider
+	}
+
+	jwks := JWKS{Keys: []JWK{}}
+	for _, key := range keyProvider.GetAccessTokenVerifyKeys() {
+		if !key.IsAsymmetric() {
+			continue
+		}
+
+		jwk, err := newJWK(key.Method.Alg(), key.ID, key.VerifyKey)
+		if err != nil {
+			return nil, err
+		}
+		jwks.Keys = append(jwks.Keys, *jwk)
+	}
+	return &jwks, nil
+}
+
+func newJWK(alg, kid string, pubKey crypto.PublicKey) (*JWK, error) {
+	jwk := JWK{
+		Use: "sig",
+		Kid: kid,
+		Alg: alg,
+	}
+
+	switch k := pubKey.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = encodeJWKBytes(k.N.Bytes())
+		jwk.E = encodeJWKBytes(big.NewInt(int64(k.E)).Bytes())
+	case *ecdsa.PublicKey:
+		size := (k.Curve.Params().BitSize + 7) / 8
+		jwk.Kty = "EC"
+		jwk.Crv = k.Curve.Params().Name
+		jwk.X = encodeJWKBytes(padJWKBytes(k.X.Bytes(), size))
+		jwk.Y = encodeJWKBytes(padJWKBytes(k.Y.Bytes(), size))
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = encodeJWKBytes(k)
+	default:
+		return nil, ErrKeyWrongFormat
+	}
+	return &jwk, nil
+}
+
+// JWK thumbprint (RFC 7638)
+func (j *JWK) thumbprint() string {
+	// Only required members in lexicographic order
+	var members interface{}
+	switch j.Kty {
+	case "RSA":
+		members = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{j.E, j.Kty, j.N}
+	case "EC":
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		}{j.Crv, j.Kty, j.X, j.Y}
+	default:
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{j.Crv, j.Kty, j.X}
+	}
+
+	membersJSON, _ := json.Marshal(members)
+	hash := sha256.Sum256(membersJSON)
+	return encodeJWKBytes(hash[:])
+}
+
+func encodeJWKBytes(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func padJWKBytes(b []byte, size int) []byte {
+	if len(b) >= size {
+		return b
+	}
+	padded := make([]byte, size)
+	copy(padded[size-len(b):], b)
+	return padded
+}
@@ -0,0 +1,276 @@
+package token
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/golang-jwt/jwt"
+)
+
+const (
+	keyMinSize    = 32
+	keyRandomSize = 64
+	keyRSABits    = 2048
+)
+
+// Signing algorithm
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+	AlgES256 = "ES256"
+	AlgEdDSA = "EdDSA"
+)
+
+// Error
+var (
+	ErrNoKeyProvider   error = fmt.Errorf("no token key provider")
+	ErrNoSigningKey    error = fmt.Errorf("no token signing key")
+	ErrKeyTooShort     error = fmt.Errorf("token key is too short")
+	ErrKeySame         error = fmt.Errorf("access and refresh token keys are same")
+	ErrKeyWrongFormat  error = fmt.Errorf("wrong token key format")
+	ErrUnsupportedAlg  error = fmt.Errorf("unsupported token signing algorithm")
+	ErrKeyNotMatched   error = fmt.Errorf("token key isn't matched")
+	ErrWrongSignMethod error = fmt.Errorf("wrong token signing method")
+
+	ErrExpired            error = fmt.Errorf("token is expired")
+	ErrNotValidYet        error = fmt.Errorf("token isn't valid yet")
+	ErrWrongIssuer        error = fmt.Errorf("wrong token issuer")
+	ErrWrongAudience      error = fmt.Errorf("wrong token audience")
+	ErrAudienceNotAllowed error = fmt.Errorf("token audience isn't allowed")
+	ErrWrongTokenType     error = fmt.Errorf("wrong token type")
+)
+
+// Signing key
+type SigningKey struct {
+	ID        string
+	Method    jwt.SigningMethod
+	SignKey   interface{}
+	VerifyKey interface{}
+}
+
+func NewHMACSigningKey(key []byte) (*SigningKey, error) {
+	if len(key) < keyMinSize {
+		return nil, ErrKeyTooShort
+	}
+
+	// Key ID is derived from the key's hash not to expose the key
+	hash := sha256.Sum256(key)
+	return &SigningKey{
+		ID:        base64.RawURLEncoding.EncodeToString(hash[:])[:16],
+		Method:    jwt.SigningMethodHS256,
+		SignKey:   key,
+		VerifyKey: key,
+	}, nil
+}
+
+// Get a signing key from a PEM encoded private key. HS256 uses the raw key instead of PEM.
+func NewSigningKeyFromPEM(alg string, key []byte) (*SigningKey, error) {
+	var privKey crypto.Signer
+	var err error
+
+	switch alg {
+	case AlgHS256:
+		return NewHMACSigningKey(key)
+	case AlgRS256:
+		privKey, err = jwt.ParseRSAPrivateKeyFromPEM(key)
+	case AlgES256:
+		var ecKey *ecdsa.PrivateKey
+		ecKey, err = jwt.ParseECPrivateKeyFromPEM(key)
+		if err == nil && ecKey.Curve != elliptic.P256() {
+			return nil, ErrKeyWrongFormat
+		}
+		privKey = ecKey
+	case AlgEdDSA:
+		var edKey crypto.PrivateKey
+		edKey, err = jwt.ParseEdPrivateKeyFromPEM(key)
+		if err == nil {
+			privKey = edKey.(ed25519.PrivateKey)
+		}
+	default:
+		return nil, ErrUnsupportedAlg
+	}
+	if err != nil {
+		return nil, ErrKeyWrongFormat
+	}
+	return newAsymmetricSigningKey(alg, privKey)
+}
+
+func NewRandomSigningKey(alg string) (*SigningKey, error) {
+	var privKey crypto.Signer
+	var err error
+
+	switch alg {
+	case AlgHS256:
+		key := make([]byte, keyRandomSize)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+		return NewHMACSigningKey(key)
+	case AlgRS256:
+		privKey, err = rsa.GenerateKey(rand.Reader, keyRSABits)
+	case AlgES256:
+		privKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case AlgEdDSA:
+		_, privKey, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, ErrUnsupportedAlg
+	}
+	if err != nil {
+		return nil, err
+	}
+	return newAsymmetricSigningKey(alg, privKey)
+}
+
+func newAsymmetricSigningKey(alg string, privKey crypto.Signer) (*SigningKey, error) {
+	pubKey := privKey.Public()
+
+	// Key ID is the JWK thumbprint of the public key
+	jwk, err := newJWK(alg, "", pubKey)
+	if err != nil {
+		return nil, err
+	}
+	return &SigningKey{
+		ID:        jwk.thumbprint(),
+		Method:    jwt.GetSigningMethod(alg),
+		SignKey:   privKey,
+		VerifyKey: pubKey,
+	}, nil
+}
+
+func (s *SigningKey) IsAsymmetric() bool {
+	_, ok := s.Method.(*jwt.SigningMethodHMAC)
+	return !ok
+}
+
+// Get the key in the format which NewSigningKeyFromPEM() reads
+func (s *SigningKey) MarshalSignKey() ([]byte, error) {
+	if !s.IsAsymmetric() {
+		return s.SignKey.([]byte), nil
+	}
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(s.SignKey)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), nil
+}
+
+// Key provider
+type KeyProvider interface {
+	// Keys to sign new tokens
+	GetAccessTokenKey() *SigningKey
+	GetRefreshTokenKey() *SigningKey
+
+	// Keys to verify tokens by key ID
+	GetAccessTokenVerifyKey(kid string) *SigningKey
+	GetRefreshTokenVerifyKey(kid string) *SigningKey
+	GetAccessTokenVerifyKeys() []*SigningKey
+}
+
+var keyProvider KeyProvider
+
+func SetKeyProvider(k KeyProvider) {
+	keyProvider = k
+}
+
+// Static key provider
+type StaticKeyProvider struct {
+	accTokenKey *SigningKey
+	refTokenKey *SigningKey
+}
+
+func NewStaticKeyProvider(accKey, refKey *SigningKey) *StaticKeyProvider {
+	return &StaticKeyProvider{
+		accTokenKey: accKey,
+		refTokenKey: refKey,
+	}
+}
+
+// Access token key follows the given algorithm. Refresh token key is always HMAC
+// because refresh tokens are validated only by this service. Keys must be different not to share key IDs.
+func NewBytesKeyProvider(accAlg string, accKey, refKey []byte) (*StaticKeyProvider, error) {
+	if bytes.Equal(accKey, refKey) {
+		return nil, ErrKeySame
+	}
+	accSigningKey, err := NewSigningKeyFromPEM(accAlg, accKey)
+	if err != nil {
+		return nil, err
+	}
+	refSigningKey, err := NewHMACSigningKey(refKey)
+	if err != nil {
+		return nil, err
+	}
+	return NewStaticKeyProvider(accSigningKey, refSigningKey), nil
+}
+
+// Random keys are only for local environment. Issued tokens are invalid after restart.
+func NewRandomKeyProvider(accAlg string) (*StaticKeyProvider, error) {
+	accSigningKey, err := NewRandomSigningKey(accAlg)
+	if err != nil {
+		return nil, err
+	}
+	refSigningKey, err := NewRandomSigningKey(AlgHS256)
+	if err != nil {
+		return nil, err
+	}
+	return NewStaticKeyProvider(accSigningKey, refSigningKey), nil
+}
+
+// Read keys from mounted files such as K8s secret volumes
+func NewFileKeyProvider(accAlg string, accKeyPath, refKeyPath string) (*StaticKeyProvider, error) {
+	accKey, err := readKeyFile(accKeyPath)
+	if err != nil {
+		return nil, err
+	}
+	refKey, err := readKeyFile(refKeyPath)
+	if err != nil {
+		return nil, err
+	}
+	return NewBytesKeyProvider(accAlg, accKey, refKey)
+}
+
+func (s *StaticKeyProvider) GetAccessTokenKey() *SigningKey {
+	return s.accTokenKey
+}
+
+func (s *StaticKeyProvider) GetRefreshTokenKey() *SigningKey {
+	return s.refTokenKey
+}
+
+func (s *StaticKeyProvider) GetAccessTokenVerifyKey(kid string) *SigningKey {
+	if kid != s.accTokenKey.ID {
+		return nil
+	}
+	return s.accTokenKey
+}
+
+func (s *StaticKeyProvider) GetRefreshTokenVerifyKey(kid string) *SigningKey {
+	if kid != s.refTokenKey.ID {
+		return nil
+	}
+	return s.refTokenKey
+}
+
+func (s *StaticKeyProvider) GetAccessTokenVerifyKeys() []*SigningKey {
+	return []*SigningKey{s.accTokenKey}
+}
+
+func readKeyFile(path string) ([]byte, error) {
+	key, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(strings.TrimSpace(string(key))), nil
+}
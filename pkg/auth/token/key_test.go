@@ -0,0 +1,79 @@
+package token
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHMACSigningKeyShortKey(t *testing.T) {
+	_, err := NewHMACSigningKey([]byte("short"))
+	require.Equal(t, ErrKeyTooShort, err)
+}
+
+func TestNewSigningKeyFromPEMUnsupportedAlg(t *testing.T) {
+	_, err := NewSigningKeyFromPEM("none", []byte("key"))
+	require.Equal(t, ErrUnsupportedAlg, err)
+}
+
+func TestNewSigningKeyFromPEMWrongFormat(t *testing.T) {
+	_, err := NewSigningKeyFromPEM(AlgRS256, []byte("key"))
+	require.Equal(t, ErrKeyWrongFormat, err)
+}
+
+func TestNewFileKeyProvider(t *testing.T) {
+	dir, err := ioutil.TempDir("", "token-key")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	accKeyPath := filepath.Join(dir, "access")
+	refKeyPath := filepath.Join(dir, "refresh")
+	require.NoError(t, ioutil.WriteFile(accKeyPath, []byte("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa\n"), 0600))
+	require.NoError(t, ioutil.WriteFile(refKeyPath, []byte("bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb\n"), 0600))
+
+	fileKeyProvider, err := NewFileKeyProvider(AlgHS256, accKeyPath, refKeyPath)
+	require.NoError(t, err)
+	require.Equal(t, []byte("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"), fileKeyProvider.GetAccessTokenKey().SignKey)
+	require.Equal(t, []byte("bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"), fileKeyProvider.GetRefreshTokenKey().SignKey)
+}
+
+func TestNewBytesKeyProviderSameKey(t *testing.T) {
+	key := []byte("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	_, err := NewBytesKeyProvider(AlgHS256, key, key)
+	require.Equal(t, ErrKeySame, err)
+}
+
+func TestNewSigningKeyFromPEM(t *testing.T) {
+	privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	privKeyDER, err := x509.MarshalPKCS8PrivateKey(privKey)
+	require.NoError(t, err)
+	privKeyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privKeyDER})
+
+	signingKey, err := NewSigningKeyFromPEM(AlgES256, privKeyPEM)
+	require.NoError(t, err)
+	require.True(t, signingKey.IsAsymmetric())
+	require.Equal(t, AlgES256, signingKey.Method.Alg())
+	require.Equal(t, &privKey.PublicKey, signingKey.VerifyKey)
+}
+
+func TestMarshalSignKey(t *testing.T) {
+	for _, alg := range []string{AlgHS256, AlgRS256, AlgES256, AlgEdDSA} {
+		signingKey, err := NewRandomSigningKey(alg)
+		require.NoError(t, err)
+
+		signKey, err := signingKey.MarshalSignKey()
+		require.NoError(t, err)
+		parsedSigningKey, err := NewSigningKeyFromPEM(alg, signKey)
+		require.NoError(t, err)
+		require.Equal(t, signingKey.ID, parsedSigningKey.ID)
+	}
+}
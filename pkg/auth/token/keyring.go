@@ -0,0 +1,76 @@
+package token
+
+import (
+	"sync"
+)
+
+// Keyring has a primary key to sign new tokens and all active keys to verify tokens
+type Keyring struct {
+	lock sync.RWMutex
+
+	accTokenKey  *SigningKey
+	refTokenKey  *SigningKey
+	accTokenKeys map[string]*SigningKey
+	refTokenKeys map[string]*SigningKey
+}
+
+func NewKeyring() *Keyring {
+	return &Keyring{
+		accTokenKeys: map[string]*SigningKey{},
+		refTokenKeys: map[string]*SigningKey{},
+	}
+}
+
+// Replace all keys. Primary keys are also used as verification keys.
+func (k *Keyring) SetKeys(accPrimaryKey, refPrimaryKey *SigningKey, accKeys, refKeys []*SigningKey) {
+	accTokenKeys := map[string]*SigningKey{accPrimaryKey.ID: accPrimaryKey}
+	for _, key := range accKeys {
+		accTokenKeys[key.ID] = key
+	}
+	refTokenKeys := map[string]*SigningKey{refPrimaryKey.ID: refPrimaryKey}
+	for _, key := range refKeys {
+		refTokenKeys[key.ID] = key
+	}
+
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	k.accTokenKey = accPrimaryKey
+	k.refTokenKey = refPrimaryKey
+	k.accTokenKeys = accTokenKeys
+	k.refTokenKeys = refTokenKeys
+}
+
+func (k *Keyring) GetAccessTokenKey() *SigningKey {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+	return k.accTokenKey
+}
+
+func (k *Keyring) GetRefreshTokenKey() *SigningKey {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+	return k.refTokenKey
+}
+
+func (k *Keyring) GetAccessTokenVerifyKey(kid string) *SigningKey {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+	return k.accTokenKeys[kid]
+}
+
+func (k *Keyring) GetRefreshTokenVerifyKey(kid string) *SigningKey {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+	return k.refTokenKeys[kid]
+}
+
+func (k *Keyring) GetAccessTokenVerifyKeys() []*SigningKey {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+
+	keys := []*SigningKey{}
+	for _, key := range k.accTokenKeys {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,54 @@
+package token
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyringRotation(t *testing.T) {
+	defer SetKeyProvider(keyProvider)
+
+	// Set first keys
+	accKey, err := NewRandomSigningKey(AlgES256)
+	require.NoError(t, err)
+	refKey, err := NewRandomSigningKey(AlgHS256)
+	require.NoError(t, err)
+	keyring := NewKeyring()
+	keyring.SetKeys(accKey, refKey, nil, nil)
+	SetKeyProvider(keyring)
+
+	oldAccTokenInfo, err := CreateAccessToken(&AuthClaims{UserID: userIDCorrect, UserLoginID: userLoginIDCorrect}, "")
+	require.NoError(t, err, "Failed to create access token")
+	oldRefTokenInfo, err := CreateRefreshToken(&AuthClaims{UserID: userIDCorrect, UserLoginID: userLoginIDCorrect}, "")
+	require.NoError(t, err, "Failed to create refresh token")
+
+	// Rotate keys and keep old keys for verification
+	newAccKey, err := NewRandomSigningKey(AlgES256)
+	require.NoError(t, err)
+	newRefKey, err := NewRandomSigningKey(AlgHS256)
+	require.NoError(t, err)
+	keyring.SetKeys(newAccKey, newRefKey, []*SigningKey{accKey}, []*SigningKey{refKey})
+
+	_, err = ValidateAccessToken(oldAccTokenInfo.Token)
+	require.NoError(t, err, "Failed to validate access token signed by retired key")
+	_, err = ValidateRefreshToken(oldRefTokenInfo.Token)
+	require.NoError(t, err, "Failed to validate refresh token signed by retired key")
+
+	newAccTokenInfo, err := CreateAccessToken(&AuthClaims{UserID: userIDCorrect, UserLoginID: userLoginIDCorrect}, "")
+	require.NoError(t, err, "Failed to create access token")
+	_, err = ValidateAccessToken(newAccTokenInfo.Token)
+	require.NoError(t, err, "Failed to validate access token signed by new key")
+
+	jwks, err := GetJWKS()
+	require.NoError(t, err, "Failed to get JWKS")
+	require.Len(t, jwks.Keys, 2)
+
+	// Remove old keys
+	keyring.SetKeys(newAccKey, newRefKey, nil, nil)
+
+	_, err = ValidateAccessToken(oldAccTokenInfo.Token)
+	require.Error(t, err, "Access token signed by removed key is validated")
+	_, err = ValidateRefreshToken(oldRefTokenInfo.Token)
+	require.Error(t, err, "Refresh token signed by removed key is validated")
+}
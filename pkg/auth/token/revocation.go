@@ -0,0 +1,81 @@
+package token
+
+import (
+	"sync"
+	"time"
+)
+
+// RevocationList has revoked subjects in memory. A subject is a token ID, a session ID or a user ID,
+// and tokens of a subject issued until the revocation time are revoked.
+type RevocationList struct {
+	lock sync.RWMutex
+
+	revocations map[string]revocation
+}
+
+type revocation struct {
+	revokedAt time.Time
+	expiresAt time.Time
+}
+
+func NewRevocationList() *RevocationList {
+	return &RevocationList{
+		revocations: map[string]revocation{},
+	}
+}
+
+// Add a revoked subject. The latest revocation time is kept if the subject is already revoked.
+func (r *RevocationList) Add(subject string, revokedAt, expiresAt time.Time) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	old, ok := r.revocations[subject]
+	if ok {
+		if old.revokedAt.After(revokedAt) {
+			revokedAt = old.revokedAt
+		}
+		if old.expiresAt.After(expiresAt) {
+			expiresAt = old.expiresAt
+		}
+	}
+	r.revocations[subject] = revocation{revokedAt: revokedAt, expiresAt: expiresAt}
+}
+
+// Delete revocations which don't revoke any valid token anymore
+func (r *RevocationList) DeleteExpired(now time.Time) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for subject, revocation := range r.revocations {
+		if revocation.expiresAt.Before(now) {
+			delete(r.revocations, subject)
+		}
+	}
+}
+
+// Check token with its token ID, session ID and user ID. Tokens are compared in milliseconds, so tokens
+// issued just after the revocation like tokens of the next login aren't revoked.
+func (r *RevocationList) IsRevoked(claims *TokenClaims) bool {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	issuedAt := claims.GetIssuedAt()
+	for _, subject := range GetRevocationSubjects(claims) {
+		revocation, ok := r.revocations[subject]
+		if ok && !issuedAt.After(revocation.revokedAt) {
+			return true
+		}
+	}
+	return false
+}
+
+// Get subjects to check revocations of the token
+func GetRevocationSubjects(claims *TokenClaims) []string {
+	subjects := []string{}
+	for _, subject := range []string{claims.Id, claims.SessionID, claims.UserID} {
+		if subject != "" {
+			subjects = append(subjects, subject)
+		}
+	}
+	return subjects
+}
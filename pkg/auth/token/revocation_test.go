@@ -0,0 +1,70 @@
+package token
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	tokenIDCorrect   = "cccccccc-cccc-cccc-cccc-cccccccccccc"
+	sessionIDCorrect = "dddddddd-dddd-dddd-dddd-dddddddddddd"
+)
+
+func getRevocationTestClaims(issuedAt time.Time) *TokenClaims {
+	return &TokenClaims{
+		StandardClaims: jwt.StandardClaims{Id: tokenIDCorrect, IssuedAt: issuedAt.Unix()},
+		AuthClaims:     AuthClaims{UserID: userIDCorrect, SessionID: sessionIDCorrect},
+	}
+}
+
+func TestRevocationListRevoked(t *testing.T) {
+	now := time.Now()
+
+	for _, subject := range []string{tokenIDCorrect, sessionIDCorrect, userIDCorrect} {
+		revocationList := NewRevocationList()
+		revocationList.Add(subject, now, now.Add(time.Hour))
+		require.True(t, revocationList.IsRevoked(getRevocationTestClaims(now.Add(-time.Minute))))
+	}
+}
+
+func TestRevocationListIssuedAfterRevocation(t *testing.T) {
+	now := time.Now()
+	revocationList := NewRevocationList()
+	revocationList.Add(userIDCorrect, now, now.Add(time.Hour))
+
+	require.False(t, revocationList.IsRevoked(getRevocationTestClaims(now.Add(time.Minute))))
+}
+
+func TestRevocationListIssuedInSameSecond(t *testing.T) {
+	now := time.Unix(1000, 0)
+	revocationList := NewRevocationList()
+	revocationList.Add(userIDCorrect, now.Add(100*time.Millisecond), now.Add(time.Hour))
+
+	// Tokens issued before the revocation in the same second are revoked, and tokens issued after it aren't
+	claims := getRevocationTestClaims(now)
+	claims.IssuedAtMs = 1000100
+	require.True(t, revocationList.IsRevoked(claims))
+	claims.IssuedAtMs = 1000101
+	require.False(t, revocationList.IsRevoked(claims))
+}
+
+func TestRevocationListKeepLatest(t *testing.T) {
+	now := time.Now()
+	revocationList := NewRevocationList()
+	revocationList.Add(userIDCorrect, now, now.Add(time.Hour))
+	revocationList.Add(userIDCorrect, now.Add(-time.Hour), now)
+
+	require.True(t, revocationList.IsRevoked(getRevocationTestClaims(now.Add(-time.Minute))))
+}
+
+func TestRevocationListDeleteExpired(t *testing.T) {
+	now := time.Now()
+	revocationList := NewRevocationList()
+	revocationList.Add(userIDCorrect, now.Add(-time.Hour), now.Add(-time.Minute))
+	revocationList.DeleteExpired(now)
+
+	require.False(t, revocationList.IsRevoked(getRevocationTestClaims(now.Add(-2*time.Hour))))
+}
@@ -7,61 +7,139 @@ import (
 	"github.com/golang-jwt/jwt"
 
 	"github.com/ssup2ket/service-auth/internal/domain/entity"
+	"github.com/ssup2ket/service-auth/pkg/entity/uuid"
 )
 
+// Token types. Tokens are validated only as their type even if they are signed with the same key.
 const (
-	accTokenKey        = "cyQsIQ6RSE1CqTARl8pWeM7br9qp1Don57Pd18uDCwoBaiUPEXWe15pYMP4D9WKc"
-	accTokenTimeoutMin = 60 // 1 hour
-	refTokenKey        = "lEAWYT9pcR5r9B5fq3ED2V5dQyhZlOACZD0lJJwzMmzxScOAX1k1ZuXHZ9hLAOG9"
-	refTokenTimeoutMin = 60 * 24 * 14 // 2 weeks
+	TypeAccess       = "access"
+	TypeRefresh      = "refresh"
+	TypeMFAChallenge = "mfa_challenge"
 )
 
 // Structs
 type TokenClaims struct {
 	jwt.StandardClaims
 	AuthClaims
+	Type       string `json:"typ"`
+	IssuedAtMs int64  `json:"iat_ms,omitempty"` // Issuance time in milliseconds to compare with revocation time
+}
+
+// Get the issuance time in milliseconds. Tokens without the milliseconds claim have the time in seconds.
+func (t *TokenClaims) GetIssuedAt() time.Time {
+	if t.IssuedAtMs != 0 {
+		return time.Unix(0, t.IssuedAtMs*int64(time.Millisecond))
+	}
+	return time.Unix(t.IssuedAt, 0)
 }
 
 type AuthClaims struct {
 	UserID      string
 	UserLoginID string
 	UserRole    entity.UserRole
+	Roles       []entity.UserRole `json:",omitempty"` // Effective roles of the user including roles of the user's groups
+	TenantID    string            `json:",omitempty"`
+	SessionID   string
+	ClientID    string   `json:",omitempty"`
+	Scopes      []string `json:",omitempty"` // OAuth2 scopes granted to the client
+}
+
+// Get the effective roles. Tokens issued before groups were introduced have only the user's role.
+func (a *AuthClaims) GetRoles() []entity.UserRole {
+	if len(a.Roles) > 0 {
+		return a.Roles
+	}
+	if a.UserRole != "" {
+		return []entity.UserRole{a.UserRole}
+	}
+	return nil
 }
 
+// Lifetime of MFA challenge tokens
+const MFAChallengeTokenLifetime = 5 * time.Minute
+
 type TokenInfo struct {
 	Token     string
 	IssuedAt  time.Time
 	ExpiresAt time.Time
 }
 
-func CreateAccessToken(authInfo *AuthClaims) (*TokenInfo, error) {
-	return createToken(accTokenKey, accTokenTimeoutMin, authInfo)
+// Create an access token for the audience. Empty audience means the default audience.
+func CreateAccessToken(authInfo *AuthClaims, audience string) (*TokenInfo, error) {
+	if keyProvider == nil {
+		return nil, ErrNoKeyProvider
+	}
+	return createToken(keyProvider.GetAccessTokenKey(), TypeAccess, config.AccessTokenLifetime, authInfo, audience)
 }
 
-func CreateRefreshToken(authInfo *AuthClaims) (*TokenInfo, error) {
-	return createToken(refTokenKey, refTokenTimeoutMin, authInfo)
+// Create a refresh token for the audience. Access tokens refreshed by the refresh token have the same audience.
+func CreateRefreshToken(authInfo *AuthClaims, audience string) (*TokenInfo, error) {
+	if keyProvider == nil {
+		return nil, ErrNoKeyProvider
+	}
+	return createToken(keyProvider.GetRefreshTokenKey(), TypeRefresh, config.RefreshTokenLifetime, authInfo, audience)
 }
 
-func createToken(tokenKey string, tokenTimeoutMin int, authInfo *AuthClaims) (*TokenInfo, error) {
+// Create a MFA challenge token for the audience of tokens issued after MFA. It's signed with the refresh token key,
+// because only service-auth validates it. MFA challenge tokens are only exchanged for tokens with a MFA code.
+func CreateMFAChallengeToken(authInfo *AuthClaims, audience string) (*TokenInfo, error) {
+	if keyProvider == nil {
+		return nil, ErrNoKeyProvider
+	}
+	return createToken(keyProvider.GetRefreshTokenKey(), TypeMFAChallenge, MFAChallengeTokenLifetime, authInfo, audience)
+}
+
+func createToken(tokenKey *SigningKey, tokenType string, lifetime time.Duration, authInfo *AuthClaims, audience string) (*TokenInfo, error) {
+	if tokenKey == nil {
+		return nil, ErrNoSigningKey
+	}
+	if !IsAudienceAllowed(audience) {
+		return nil, ErrAudienceNotAllowed
+	}
+	if audience == "" {
+		audience = config.Audience
+	}
+
 	// Calculate issuance and expiration time
 	issuedAt := time.Now()
-	expiresAt := issuedAt.Add(time.Minute * accTokenTimeoutMin)
+	expiresAt := issuedAt.Add(lifetime)
+
+	// Tokens of OAuth2 clients without user have the client as subject
+	subject := authInfo.UserID
+	if subject == "" {
+		subject = authInfo.ClientID
+	}
 
 	// Set access token
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &TokenClaims{
+	token := jwt.NewWithClaims(tokenKey.Method, &TokenClaims{
 		StandardClaims: jwt.StandardClaims{
+			Id:        uuid.NewV4().String(), // Make every token unique
+			Issuer:    config.Issuer,
+			Subject:   subject,
+			Audience:  audience,
 			IssuedAt:  issuedAt.Unix(),
+			NotBefore: issuedAt.Unix(),
 			ExpiresAt: expiresAt.Unix(),
 		},
 		AuthClaims: AuthClaims{
 			UserID:      authInfo.UserID,
 			UserLoginID: authInfo.UserLoginID,
 			UserRole:    authInfo.UserRole,
+			Roles:       authInfo.Roles,
+			TenantID:    authInfo.TenantID,
+			SessionID:   authInfo.SessionID,
+			ClientID:    authInfo.ClientID,
+			Scopes:      authInfo.Scopes,
 		},
+		Type:       tokenType,
+		IssuedAtMs: issuedAt.UnixNano() / int64(time.Millisecond),
 	})
 
+	// Set key ID to select verification key
+	token.Header["kid"] = tokenKey.ID
+
 	// Signing access token
-	tokenSigned, err := token.SignedString([]byte(tokenKey))
+	tokenSigned, err := token.SignedString(tokenKey.SignKey)
 	if err != nil {
 		return nil, err
 	}
@@ -73,19 +151,94 @@ func createToken(tokenKey string, tokenTimeoutMin int, authInfo *AuthClaims) (*T
 	}, nil
 }
 
-func ValidateAccessToken(token string) (*AuthClaims, error) {
-	return validateToken(accTokenKey, token)
+// Get the longest token lifetime. Retired keys must be kept for verification during this time.
+func GetMaxTokenLifetime() time.Duration {
+	if config.AccessTokenLifetime > config.RefreshTokenLifetime {
+		return config.AccessTokenLifetime + config.Leeway
+	}
+	return config.RefreshTokenLifetime + config.Leeway
 }
 
-func ValidateRefreshToken(token string) (*AuthClaims, error) {
-	return validateToken(refTokenKey, token)
+// Get access token lifetime including leeway. Revoked access tokens must be kept revoked during this time.
+func GetAccessTokenLifetime() time.Duration {
+	return config.AccessTokenLifetime + config.Leeway
 }
 
-func validateToken(tokenKey, tokenSigned string) (*AuthClaims, error) {
-	// Prase token
+// Validate an access token for service-auth APIs. Access tokens for other audiences are rejected.
+func ValidateAccessToken(token string) (*TokenClaims, error) {
+	if keyProvider == nil {
+		return nil, ErrNoKeyProvider
+	}
+	claims, err := validateToken(keyProvider.GetAccessTokenVerifyKey, token, TypeAccess)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Audience != config.Audience {
+		return nil, ErrWrongAudience
+	}
+	return claims, nil
+}
+
+// Validate an access token for any allowed audience. Other services introspect their access tokens by this.
+func ValidateAccessTokenAnyAudience(token string) (*TokenClaims, error) {
+	if keyProvider == nil {
+		return nil, ErrNoKeyProvider
+	}
+	claims, err := validateToken(keyProvider.GetAccessTokenVerifyKey, token, TypeAccess)
+	if err != nil {
+		return nil, err
+	}
+	if !IsAudienceAllowed(claims.Audience) {
+		return nil, ErrWrongAudience
+	}
+	return claims, nil
+}
+
+// Validate a refresh token for any allowed audience
+func ValidateRefreshToken(token string) (*TokenClaims, error) {
+	if keyProvider == nil {
+		return nil, ErrNoKeyProvider
+	}
+	claims, err := validateToken(keyProvider.GetRefreshTokenVerifyKey, token, TypeRefresh)
+	if err != nil {
+		return nil, err
+	}
+	if !IsAudienceAllowed(claims.Audience) {
+		return nil, ErrWrongAudience
+	}
+	return claims, nil
+}
+
+// Validate a MFA challenge token for any allowed audience
+func ValidateMFAChallengeToken(token string) (*TokenClaims, error) {
+	if keyProvider == nil {
+		return nil, ErrNoKeyProvider
+	}
+	claims, err := validateToken(keyProvider.GetRefreshTokenVerifyKey, token, TypeMFAChallenge)
+	if err != nil {
+		return nil, err
+	}
+	if !IsAudienceAllowed(claims.Audience) {
+		return nil, ErrWrongAudience
+	}
+	return claims, nil
+}
+
+func validateToken(getTokenKey func(kid string) *SigningKey, tokenSigned string, tokenType string) (*TokenClaims, error) {
+	// Prase token. Claims are validated below with leeway.
 	claims := TokenClaims{}
-	token, err := jwt.ParseWithClaims(tokenSigned, &claims, func(token *jwt.Token) (interface{}, error) {
-		return []byte(tokenKey), nil
+	parser := jwt.Parser{SkipClaimsValidation: true}
+	token, err := parser.ParseWithClaims(tokenSigned, &claims, func(token *jwt.Token) (interface{}, error) {
+		// Select verification key by key ID
+		kid, _ := token.Header["kid"].(string)
+		tokenKey := getTokenKey(kid)
+		if tokenKey == nil {
+			return nil, ErrKeyNotMatched
+		}
+		if token.Method.Alg() != tokenKey.Method.Alg() {
+			return nil, ErrWrongSignMethod
+		}
+		return tokenKey.VerifyKey, nil
 	})
 	if err != nil {
 		return nil, err
@@ -95,7 +248,21 @@ func validateToken(tokenKey, tokenSigned string) (*AuthClaims, error) {
 	if !token.Valid {
 		return nil, fmt.Errorf("token is not valid")
 	}
+	now := time.Now()
+	if !claims.VerifyExpiresAt(now.Add(-config.Leeway).Unix(), true) {
+		return nil, ErrExpired
+	}
+	if !claims.VerifyIssuedAt(now.Add(config.Leeway).Unix(), false) ||
+		!claims.VerifyNotBefore(now.Add(config.Leeway).Unix(), false) {
+		return nil, ErrNotValidYet
+	}
+	if claims.Issuer != config.Issuer {
+		return nil, ErrWrongIssuer
+	}
+	if claims.Type != tokenType {
+		return nil, ErrWrongTokenType
+	}
 
-	// Return auth infos
-	return &claims.AuthClaims, nil
+	// Return claims with token ID and issuance time
+	return &claims, nil
 }
@@ -1,28 +1,60 @@
 package token
 
 import (
+	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/ssup2ket/service-auth/internal/domain/entity"
 )
 
 const (
 	userIDCorrect      = "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
 	userLoginIDCorrect = "test0000"
+	tenantIDCorrect    = "acme"
 )
 
+func TestMain(m *testing.M) {
+	keyProvider, err := NewRandomKeyProvider(AlgHS256)
+	if err != nil {
+		panic(err)
+	}
+	SetKeyProvider(keyProvider)
+	os.Exit(m.Run())
+}
+
 func TestCreateAccessToken(t *testing.T) {
-	tokenInfo, err := CreateAccessToken(&AuthClaims{UserID: userIDCorrect, UserLoginID: userLoginIDCorrect})
+	tokenInfo, err := CreateAccessToken(&AuthClaims{UserID: userIDCorrect, UserLoginID: userLoginIDCorrect, TenantID: tenantIDCorrect}, "")
 	require.NoError(t, err, "Failed to create access token")
 
 	validatedAccessToken, err := ValidateAccessToken(tokenInfo.Token)
 	require.NoError(t, err, "Failed to validate access token")
 	require.Equal(t, validatedAccessToken.UserID, userIDCorrect)
 	require.Equal(t, validatedAccessToken.UserLoginID, userLoginIDCorrect)
+	require.Equal(t, validatedAccessToken.TenantID, tenantIDCorrect)
+	require.Equal(t, tokenInfo.IssuedAt.Truncate(time.Millisecond).UnixNano(), validatedAccessToken.GetIssuedAt().UnixNano())
+}
+
+func TestCreateAccessTokenRoles(t *testing.T) {
+	tokenInfo, err := CreateAccessToken(&AuthClaims{UserID: userIDCorrect, UserRole: entity.UserRoleUser,
+		Roles: []entity.UserRole{entity.UserRoleUser, entity.UserRoleTenantAdmin}}, "")
+	require.NoError(t, err, "Failed to create access token")
+
+	validatedAccessToken, err := ValidateAccessToken(tokenInfo.Token)
+	require.NoError(t, err, "Failed to validate access token")
+	require.Equal(t, []entity.UserRole{entity.UserRoleUser, entity.UserRoleTenantAdmin}, validatedAccessToken.GetRoles())
+}
+
+func TestGetRolesWithoutRoles(t *testing.T) {
+	authClaims := AuthClaims{UserID: userIDCorrect, UserRole: entity.UserRoleAdmin}
+	require.Equal(t, []entity.UserRole{entity.UserRoleAdmin}, authClaims.GetRoles())
+	require.Nil(t, (&AuthClaims{ClientID: clientIDCorrect}).GetRoles())
 }
 
 func TestCreateRefreshToken(t *testing.T) {
-	tokenInfo, err := CreateRefreshToken(&AuthClaims{UserID: userIDCorrect, UserLoginID: userLoginIDCorrect})
+	tokenInfo, err := CreateRefreshToken(&AuthClaims{UserID: userIDCorrect, UserLoginID: userLoginIDCorrect}, "")
 	require.NoError(t, err, "Failed to create refresh token")
 
 	validatedAccessToken, err := ValidateRefreshToken(tokenInfo.Token)
@@ -30,3 +62,188 @@ func TestCreateRefreshToken(t *testing.T) {
 	require.Equal(t, validatedAccessToken.UserID, userIDCorrect)
 	require.Equal(t, validatedAccessToken.UserLoginID, userLoginIDCorrect)
 }
+
+func TestValidateAccessTokenWithRefreshToken(t *testing.T) {
+	tokenInfo, err := CreateRefreshToken(&AuthClaims{UserID: userIDCorrect, UserLoginID: userLoginIDCorrect}, "")
+	require.NoError(t, err, "Failed to create refresh token")
+
+	_, err = ValidateAccessToken(tokenInfo.Token)
+	require.Error(t, err, "Refresh token is validated as access token")
+}
+
+func TestValidateTokenTypeSameKey(t *testing.T) {
+	defer SetKeyProvider(keyProvider)
+
+	// Tokens of other types aren't validated even if the access and refresh token keys are same
+	signingKey, err := NewRandomSigningKey(AlgHS256)
+	require.NoError(t, err)
+	SetKeyProvider(NewStaticKeyProvider(signingKey, signingKey))
+
+	refTokenInfo, err := CreateRefreshToken(&AuthClaims{UserID: userIDCorrect, UserLoginID: userLoginIDCorrect}, "")
+	require.NoError(t, err, "Failed to create refresh token")
+	_, err = ValidateAccessToken(refTokenInfo.Token)
+	require.Equal(t, ErrWrongTokenType, err)
+	challengeTokenInfo, err := CreateMFAChallengeToken(&AuthClaims{UserID: userIDCorrect, UserLoginID: userLoginIDCorrect}, "")
+	require.NoError(t, err, "Failed to create MFA challenge token")
+	_, err = ValidateAccessToken(challengeTokenInfo.Token)
+	require.Equal(t, ErrWrongTokenType, err)
+	accTokenInfo, err := CreateAccessToken(&AuthClaims{UserID: userIDCorrect, UserLoginID: userLoginIDCorrect}, "")
+	require.NoError(t, err, "Failed to create access token")
+	_, err = ValidateRefreshToken(accTokenInfo.Token)
+	require.Equal(t, ErrWrongTokenType, err)
+}
+
+func TestCreateMFAChallengeToken(t *testing.T) {
+	tokenInfo, err := CreateMFAChallengeToken(&AuthClaims{UserID: userIDCorrect, UserLoginID: userLoginIDCorrect,
+		TenantID: tenantIDCorrect, Scopes: []string{"openid"}}, "")
+	require.NoError(t, err, "Failed to create MFA challenge token")
+	require.Equal(t, MFAChallengeTokenLifetime, tokenInfo.ExpiresAt.Sub(tokenInfo.IssuedAt))
+
+	claims, err := ValidateMFAChallengeToken(tokenInfo.Token)
+	require.NoError(t, err, "Failed to validate MFA challenge token")
+	require.Equal(t, TypeMFAChallenge, claims.Type)
+	require.Equal(t, userIDCorrect, claims.UserID)
+	require.Equal(t, []string{"openid"}, claims.Scopes)
+
+	// MFA challenge tokens aren't refresh tokens, and refresh tokens aren't MFA challenge tokens
+	_, err = ValidateRefreshToken(tokenInfo.Token)
+	require.Equal(t, ErrWrongTokenType, err)
+	refTokenInfo, err := CreateRefreshToken(&AuthClaims{UserID: userIDCorrect, UserLoginID: userLoginIDCorrect}, "")
+	require.NoError(t, err, "Failed to create refresh token")
+	_, err = ValidateMFAChallengeToken(refTokenInfo.Token)
+	require.Equal(t, ErrWrongTokenType, err)
+}
+
+func TestCreateAccessTokenAsymmetric(t *testing.T) {
+	defer SetKeyProvider(keyProvider)
+
+	for _, alg := range []string{AlgRS256, AlgES256, AlgEdDSA} {
+		randKeyProvider, err := NewRandomKeyProvider(alg)
+		require.NoError(t, err, "Failed to create key provider")
+		SetKeyProvider(randKeyProvider)
+
+		tokenInfo, err := CreateAccessToken(&AuthClaims{UserID: userIDCorrect, UserLoginID: userLoginIDCorrect}, "")
+		require.NoError(t, err, "Failed to create access token")
+
+		validatedAccessToken, err := ValidateAccessToken(tokenInfo.Token)
+		require.NoError(t, err, "Failed to validate access token")
+		require.Equal(t, validatedAccessToken.UserID, userIDCorrect)
+
+		jwks, err := GetJWKS()
+		require.NoError(t, err, "Failed to get JWKS")
+		require.Len(t, jwks.Keys, 1)
+		require.Equal(t, alg, jwks.Keys[0].Alg)
+		require.Equal(t, randKeyProvider.GetAccessTokenKey().ID, jwks.Keys[0].Kid)
+	}
+}
+
+func TestGetJWKSHMAC(t *testing.T) {
+	jwks, err := GetJWKS()
+	require.NoError(t, err, "Failed to get JWKS")
+	require.Len(t, jwks.Keys, 0)
+}
+
+func TestCreateAccessTokenClaims(t *testing.T) {
+	defer SetConfig(GetDefaultConfig())
+	SetConfig(Config{
+		AccessTokenLifetime:  time.Hour,
+		RefreshTokenLifetime: time.Hour,
+		Issuer:               "issuer",
+		Audience:             "service-auth",
+		Audiences:            []string{"service-a"},
+	})
+
+	tokenInfo, err := CreateAccessToken(&AuthClaims{UserID: userIDCorrect, UserLoginID: userLoginIDCorrect}, "")
+	require.NoError(t, err, "Failed to create access token")
+	claims, err := ValidateAccessToken(tokenInfo.Token)
+	require.NoError(t, err, "Failed to validate access token")
+	require.Equal(t, "issuer", claims.Issuer)
+	require.Equal(t, "service-auth", claims.Audience)
+	require.Equal(t, userIDCorrect, claims.Subject)
+	require.Equal(t, claims.IssuedAt, claims.NotBefore)
+}
+
+func TestValidateAccessTokenWrongAudience(t *testing.T) {
+	defer SetConfig(GetDefaultConfig())
+	SetConfig(Config{
+		AccessTokenLifetime:  time.Hour,
+		RefreshTokenLifetime: time.Hour,
+		Audience:             "service-auth",
+		Audiences:            []string{"service-a"},
+	})
+
+	// Access token for other service isn't valid for service-auth, but refresh token is valid
+	accTokenInfo, err := CreateAccessToken(&AuthClaims{UserID: userIDCorrect}, "service-a")
+	require.NoError(t, err, "Failed to create access token")
+	_, err = ValidateAccessToken(accTokenInfo.Token)
+	require.Equal(t, ErrWrongAudience, err)
+
+	refTokenInfo, err := CreateRefreshToken(&AuthClaims{UserID: userIDCorrect}, "service-a")
+	require.NoError(t, err, "Failed to create refresh token")
+	claims, err := ValidateRefreshToken(refTokenInfo.Token)
+	require.NoError(t, err, "Failed to validate refresh token")
+	require.Equal(t, "service-a", claims.Audience)
+
+	_, err = CreateAccessToken(&AuthClaims{UserID: userIDCorrect}, "service-b")
+	require.Equal(t, ErrAudienceNotAllowed, err)
+}
+
+func TestValidateAccessTokenWrongIssuer(t *testing.T) {
+	defer SetConfig(GetDefaultConfig())
+	SetConfig(Config{AccessTokenLifetime: time.Hour, RefreshTokenLifetime: time.Hour, Issuer: "issuer-a"})
+	tokenInfo, err := CreateAccessToken(&AuthClaims{UserID: userIDCorrect}, "")
+	require.NoError(t, err, "Failed to create access token")
+
+	SetConfig(Config{AccessTokenLifetime: time.Hour, RefreshTokenLifetime: time.Hour, Issuer: "issuer-b"})
+	_, err = ValidateAccessToken(tokenInfo.Token)
+	require.Equal(t, ErrWrongIssuer, err)
+}
+
+func TestValidateAccessTokenLeeway(t *testing.T) {
+	defer SetConfig(GetDefaultConfig())
+
+	// Token is already expired by 1 second
+	SetConfig(Config{AccessTokenLifetime: -time.Second, RefreshTokenLifetime: time.Hour})
+	tokenInfo, err := CreateAccessToken(&AuthClaims{UserID: userIDCorrect}, "")
+	require.NoError(t, err, "Failed to create access token")
+	_, err = ValidateAccessToken(tokenInfo.Token)
+	require.Equal(t, ErrExpired, err)
+
+	SetConfig(Config{AccessTokenLifetime: -time.Second, RefreshTokenLifetime: time.Hour, Leeway: time.Minute})
+	_, err = ValidateAccessToken(tokenInfo.Token)
+	require.NoError(t, err, "Failed to validate access token with leeway")
+}
+
+func TestCreateAccessTokenClient(t *testing.T) {
+	tokenInfo, err := CreateAccessToken(&AuthClaims{ClientID: clientIDCorrect}, "")
+	require.NoError(t, err, "Failed to create access token")
+
+	claims, err := ValidateAccessToken(tokenInfo.Token)
+	require.NoError(t, err, "Failed to validate access token")
+	require.Equal(t, clientIDCorrect, claims.Subject)
+	require.Equal(t, clientIDCorrect, claims.ClientID)
+	require.Empty(t, claims.UserID)
+}
+
+func TestValidateAccessTokenAnyAudience(t *testing.T) {
+	defer SetConfig(GetDefaultConfig())
+	SetConfig(Config{
+		AccessTokenLifetime:  time.Hour,
+		RefreshTokenLifetime: time.Hour,
+		Audience:             "service-auth",
+		Audiences:            []string{"service-a"},
+	})
+
+	tokenInfo, err := CreateAccessToken(&AuthClaims{ClientID: clientIDCorrect, Scopes: []string{"profile"}}, "service-a")
+	require.NoError(t, err, "Failed to create access token")
+	claims, err := ValidateAccessTokenAnyAudience(tokenInfo.Token)
+	require.NoError(t, err, "Failed to validate access token")
+	require.Equal(t, "service-a", claims.Audience)
+	require.Equal(t, []string{"profile"}, claims.Scopes)
+
+	// Refresh token isn't an access token
+	refTokenInfo, err := CreateRefreshToken(&AuthClaims{UserID: userIDCorrect}, "service-a")
+	require.NoError(t, err, "Failed to create refresh token")
+	_, err = ValidateAccessTokenAnyAudience(refTokenInfo.Token)
+	require.Error(t, err)
+}
@@ -0,0 +1,99 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// TOTP of RFC 6238 with HMAC-SHA1, 6 digits and 30 seconds period, which authenticator apps support by default
+const (
+	Digits = 6
+	Period = 30 * time.Second
+
+	// Codes of the previous and the next time steps are also valid for clock skew
+	skewSteps = 1
+
+	secretSize = 20
+)
+
+var secretEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// Generate a random secret
+func GenerateSecret() ([]byte, error) {
+	secret := make([]byte, secretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// Encode the secret by base32 without padding to enter it to authenticator apps
+func EncodeSecret(secret []byte) string {
+	return secretEncoding.EncodeToString(secret)
+}
+
+// Get the otpauth URI of the secret for QR codes of authenticator apps
+func GetURI(issuer, accountName string, secret []byte) string {
+	query := url.Values{}
+	query.Set("secret", EncodeSecret(secret))
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(Digits))
+	query.Set("period", fmt.Sprint(int(Period.Seconds())))
+
+	uri := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + accountName,
+		RawQuery: query.Encode(),
+	}
+	return uri.String()
+}
+
+// Get the time step of the time
+func GetStep(t time.Time) int64 {
+	return t.Unix() / int64(Period.Seconds())
+}
+
+// Get the code of the time
+func GetCode(secret []byte, t time.Time) string {
+	return getCode(secret, GetStep(t))
+}
+
+// Validate the code at the time and get the time step of the code. Callers need to keep the last used time step
+// and reject codes of the time step or before not to reuse codes.
+func ValidateCode(secret []byte, code string, t time.Time) (int64, bool) {
+	step := GetStep(t)
+	for i := -skewSteps; i <= skewSteps; i++ {
+		if subtle.ConstantTimeCompare([]byte(getCode(secret, step+int64(i))), []byte(code)) == 1 {
+			return step + int64(i), true
+		}
+	}
+	return 0, false
+}
+
+// Get the HOTP code of RFC 4226 for the counter
+func getCode(secret []byte, counter int64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(counter))
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	// Dynamic truncation
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < Digits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", Digits, value%mod)
+}
@@ -0,0 +1,59 @@
+package totp
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Secret of RFC 6238 test vectors
+var rfcSecret = []byte("12345678901234567890")
+
+func TestGetCodeRFCVectors(t *testing.T) {
+	// Last 6 digits of 8 digits codes in RFC 6238
+	vectors := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1111111111: "050471",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+	for unix, code := range vectors {
+		require.Equal(t, code, GetCode(rfcSecret, time.Unix(unix, 0)), unix)
+	}
+}
+
+func TestValidateCode(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	code := GetCode(rfcSecret, now)
+
+	step, ok := ValidateCode(rfcSecret, code, now)
+	require.True(t, ok)
+	require.Equal(t, GetStep(now), step)
+
+	// Clock skew of a step is allowed
+	step, ok = ValidateCode(rfcSecret, code, now.Add(Period))
+	require.True(t, ok)
+	require.Equal(t, GetStep(now), step)
+
+	_, ok = ValidateCode(rfcSecret, code, now.Add(2*Period))
+	require.False(t, ok)
+	_, ok = ValidateCode([]byte("wrong-secret"), code, now)
+	require.False(t, ok)
+}
+
+func TestGetURI(t *testing.T) {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+
+	uri, err := url.Parse(GetURI("ssup2ket", "user@test", secret))
+	require.NoError(t, err)
+	require.Equal(t, "otpauth", uri.Scheme)
+	require.Equal(t, "totp", uri.Host)
+	require.Equal(t, "/ssup2ket:user@test", uri.Path)
+	require.Equal(t, EncodeSecret(secret), uri.Query().Get("secret"))
+	require.Equal(t, "ssup2ket", uri.Query().Get("issuer"))
+	require.Equal(t, "6", uri.Query().Get("digits"))
+}
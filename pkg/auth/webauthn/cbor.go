@@ -0,0 +1,133 @@
+package webauthn
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+// CBOR major types
+const (
+	cborUint   = 0
+	cborNegInt = 1
+	cborBytes  = 2
+	cborText   = 3
+	cborArray  = 4
+	cborMap    = 5
+	cborSimple = 7
+
+	cborMaxDepth = 16
+)
+
+var errCBOR = errors.New("CBOR isn't valid or supported")
+
+// Decode a CBOR item of RFC 8949 and get the number of bytes of the item. Authenticators encode attestation objects
+// and COSE keys by the CTAP2 canonical CBOR, so indefinite lengths, tags and floats aren't supported. Integers are
+// decoded to int64, byte strings to []byte, text strings to string, arrays to []interface{} and maps to
+// map[interface{}]interface{} having int64 or string keys.
+func decodeCBOR(data []byte) (interface{}, int, error) {
+	return decodeCBORItem(data, 0)
+}
+
+func decodeCBORItem(data []byte, depth int) (interface{}, int, error) {
+	if depth > cborMaxDepth || len(data) == 0 {
+		return nil, 0, errCBOR
+	}
+	major := data[0] >> 5
+	arg, n, err := decodeCBORArg(data)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	switch major {
+	case cborUint:
+		if arg > math.MaxInt64 {
+			return nil, 0, errCBOR
+		}
+		return int64(arg), n, nil
+	case cborNegInt:
+		if arg > math.MaxInt64 {
+			return nil, 0, errCBOR
+		}
+		return -1 - int64(arg), n, nil
+	case cborBytes, cborText:
+		if arg > uint64(len(data)-n) {
+			return nil, 0, errCBOR
+		}
+		value := data[n : n+int(arg)]
+		if major == cborText {
+			return string(value), n + int(arg), nil
+		}
+		return append([]byte{}, value...), n + int(arg), nil
+	case cborArray:
+		// Every item has one byte at least
+		if arg > uint64(len(data)-n) {
+			return nil, 0, errCBOR
+		}
+		items := make([]interface{}, 0, arg)
+		for i := uint64(0); i < arg; i++ {
+			item, itemLen, err := decodeCBORItem(data[n:], depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			items = append(items, item)
+			n += itemLen
+		}
+		return items, n, nil
+	case cborMap:
+		if arg > uint64(len(data)-n)/2 {
+			return nil, 0, errCBOR
+		}
+		items := make(map[interface{}]interface{}, arg)
+		for i := uint64(0); i < arg; i++ {
+			key, keyLen, err := decodeCBORItem(data[n:], depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			n += keyLen
+			switch key.(type) {
+			case int64, string:
+			default:
+				return nil, 0, errCBOR
+			}
+			if _, ok := items[key]; ok {
+				return nil, 0, errCBOR
+			}
+			value, valueLen, err := decodeCBORItem(data[n:], depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			n += valueLen
+			items[key] = value
+		}
+		return items, n, nil
+	case cborSimple:
+		switch data[0] & 0x1f {
+		case 20:
+			return false, 1, nil
+		case 21:
+			return true, 1, nil
+		case 22:
+			return nil, 1, nil
+		}
+	}
+	return nil, 0, errCBOR
+}
+
+// Decode the argument of the item's head and get the length of the head
+func decodeCBORArg(data []byte) (uint64, int, error) {
+	info := data[0] & 0x1f
+	switch {
+	case info < 24:
+		return uint64(info), 1, nil
+	case info == 24 && len(data) >= 2:
+		return uint64(data[1]), 2, nil
+	case info == 25 && len(data) >= 3:
+		return uint64(binary.BigEndian.Uint16(data[1:])), 3, nil
+	case info == 26 && len(data) >= 5:
+		return uint64(binary.BigEndian.Uint32(data[1:])), 5, nil
+	case info == 27 && len(data) >= 9:
+		return binary.BigEndian.Uint64(data[1:]), 9, nil
+	}
+	return 0, 0, errCBOR
+}
@@ -0,0 +1,99 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"math/big"
+)
+
+// COSE algorithms of RFC 9053 supported for credentials
+const (
+	AlgES256 = -7
+	AlgEdDSA = -8
+	AlgRS256 = -257
+)
+
+// COSE key parameters
+const (
+	coseKeyKty = 1
+	coseKeyAlg = 3
+	coseKeyCrv = -1 // n of RSA keys
+	coseKeyX   = -2 // e of RSA keys
+	coseKeyY   = -3
+
+	coseKtyOKP = 1
+	coseKtyEC2 = 2
+	coseKtyRSA = 3
+
+	coseCrvP256    = 1
+	coseCrvEd25519 = 6
+
+	rsaMinBits = 2048
+)
+
+// Algorithms in the order of preference for new credentials
+var supportedAlgs = []int64{AlgES256, AlgEdDSA, AlgRS256}
+
+type publicKey struct {
+	alg int64
+	key crypto.PublicKey
+}
+
+// Parse a public key encoded as a COSE key. The key's algorithm must be one of the supported algorithms.
+func parsePublicKey(data []byte) (*publicKey, error) {
+	item, n, err := decodeCBOR(data)
+	if err != nil || n != len(data) {
+		return nil, ErrPublicKey
+	}
+	params, ok := item.(map[interface{}]interface{})
+	if !ok {
+		return nil, ErrPublicKey
+	}
+	kty, _ := params[int64(coseKeyKty)].(int64)
+	alg, _ := params[int64(coseKeyAlg)].(int64)
+	crv, _ := params[int64(coseKeyCrv)].(int64)
+	x, _ := params[int64(coseKeyX)].([]byte)
+	y, _ := params[int64(coseKeyY)].([]byte)
+
+	switch {
+	case kty == coseKtyEC2 && alg == AlgES256 && crv == coseCrvP256 && len(x) == 32 && len(y) == 32:
+		key := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !key.Curve.IsOnCurve(key.X, key.Y) {
+			return nil, ErrPublicKey
+		}
+		return &publicKey{alg: alg, key: key}, nil
+	case kty == coseKtyOKP && alg == AlgEdDSA && crv == coseCrvEd25519 && len(x) == ed25519.PublicKeySize:
+		return &publicKey{alg: alg, key: ed25519.PublicKey(x)}, nil
+	case kty == coseKtyRSA && alg == AlgRS256:
+		n, _ := params[int64(coseKeyCrv)].([]byte)
+		e, _ := params[int64(coseKeyX)].([]byte)
+		if len(e) == 0 || len(e) > 4 {
+			return nil, ErrPublicKey
+		}
+		key := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+		if key.N.BitLen() < rsaMinBits || key.E < 3 || key.E%2 == 0 {
+			return nil, ErrPublicKey
+		}
+		return &publicKey{alg: alg, key: key}, nil
+	}
+	return nil, ErrPublicKey
+}
+
+// Verify the signature of the data
+func (p *publicKey) verify(data, signature []byte) bool {
+	switch key := p.key.(type) {
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(data)
+		return ecdsa.VerifyASN1(key, digest[:], signature)
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, data, signature)
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(data)
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature) == nil
+	}
+	return false
+}
@@ -0,0 +1,324 @@
+package webauthn
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// WebAuthn Level 2 ceremonies for passkeys. Credentials must be discoverable and verify users, so a passkey is enough
+// for login without a password or another factor. Attestation statements aren't verified, because credentials aren't
+// restricted by authenticator models.
+const (
+	ChallengeSize = 32
+	Timeout       = 5 * time.Minute
+
+	CredentialType = "public-key"
+
+	clientDataTypeCreate = "webauthn.create"
+	clientDataTypeGet    = "webauthn.get"
+
+	// Authenticator data flags
+	flagUserPresent  = 0x01
+	flagUserVerified = 0x04
+	flagAttested     = 0x40
+
+	authDataMinSize     = 37
+	aaguidSize          = 16
+	credentialIDMaxSize = 1023
+)
+
+var (
+	ErrClientData    = errors.New("client data isn't valid")
+	ErrAuthData      = errors.New("authenticator data isn't valid")
+	ErrAttestation   = errors.New("attestation object isn't valid")
+	ErrPublicKey     = errors.New("public key isn't valid or supported")
+	ErrSignature     = errors.New("signature isn't valid")
+	ErrSignCount     = errors.New("sign count isn't increased")
+	ErrBase64URLData = errors.New("base64url data isn't valid")
+)
+
+// Relying party of credentials. ID is the domain of origins, and credentials are bound to it.
+type RelyingParty struct {
+	ID      string
+	Name    string
+	Origins []string
+}
+
+// Credential created by the registration
+type Credential struct {
+	ID        []byte
+	PublicKey []byte // COSE key
+	SignCount uint32
+}
+
+// Options of navigator.credentials.create() encoded by JSON
+type CreationOptions struct {
+	Challenge              string                 `json:"challenge"`
+	RP                     RelyingPartyEntity     `json:"rp"`
+	User                   UserEntity             `json:"user"`
+	PubKeyCredParams       []CredentialParameter  `json:"pubKeyCredParams"`
+	Timeout                int64                  `json:"timeout"`
+	ExcludeCredentials     []CredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection AuthenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                 `json:"attestation"`
+}
+
+// Options of navigator.credentials.get() encoded by JSON
+type RequestOptions struct {
+	Challenge        string                 `json:"challenge"`
+	Timeout          int64                  `json:"timeout"`
+	RPID             string                 `json:"rpId"`
+	AllowCredentials []CredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                 `json:"userVerification"`
+}
+
+type RelyingPartyEntity struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type UserEntity struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+type CredentialParameter struct {
+	Type string `json:"type"`
+	Alg  int64  `json:"alg"`
+}
+
+type CredentialDescriptor struct {
+	Type       string   `json:"type"`
+	ID         string   `json:"id"`
+	Transports []string `json:"transports,omitempty"`
+}
+
+type AuthenticatorSelection struct {
+	ResidentKey        string `json:"residentKey"`
+	RequireResidentKey bool   `json:"requireResidentKey"`
+	UserVerification   string `json:"userVerification"`
+}
+
+type clientData struct {
+	Type        string `json:"type"`
+	Challenge   string `json:"challenge"`
+	Origin      string `json:"origin"`
+	CrossOrigin bool   `json:"crossOrigin"`
+}
+
+type authData struct {
+	flags     byte
+	signCount uint32
+
+	credentialID []byte
+	publicKey    []byte
+}
+
+// Generate a random challenge
+func GenerateChallenge() ([]byte, error) {
+	challenge := make([]byte, ChallengeSize)
+	if _, err := rand.Read(challenge); err != nil {
+		return nil, err
+	}
+	return challenge, nil
+}
+
+// Encode data by base64url without padding like WebAuthn JSON
+func EncodeBase64URL(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Decode base64url data with or without padding
+func DecodeBase64URL(data string) ([]byte, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(data, "="))
+	if err != nil {
+		return nil, ErrBase64URLData
+	}
+	return decoded, nil
+}
+
+// Create a credential descriptor to exclude or allow a credential
+func NewCredentialDescriptor(credentialID []byte, transports []string) CredentialDescriptor {
+	return CredentialDescriptor{
+		Type:       CredentialType,
+		ID:         EncodeBase64URL(credentialID),
+		Transports: transports,
+	}
+}
+
+// Get options to register a discoverable credential of the user. User ID must not be personal info, because
+// authenticators store it. Excluded credentials are already registered, so they aren't registered again.
+func (r *RelyingParty) GetCreationOptions(challenge, userID []byte, userName, userDisplayName string,
+	excludeCredentials []CredentialDescriptor) *CreationOptions {
+	params := []CredentialParameter{}
+	for _, alg := range supportedAlgs {
+		params = append(params, CredentialParameter{Type: CredentialType, Alg: alg})
+	}
+	return &CreationOptions{
+		Challenge:          EncodeBase64URL(challenge),
+		RP:                 RelyingPartyEntity{ID: r.ID, Name: r.Name},
+		User:               UserEntity{ID: EncodeBase64URL(userID), Name: userName, DisplayName: userDisplayName},
+		PubKeyCredParams:   params,
+		Timeout:            Timeout.Milliseconds(),
+		ExcludeCredentials: excludeCredentials,
+		AuthenticatorSelection: AuthenticatorSelection{
+			ResidentKey:        "required",
+			RequireResidentKey: true,
+			UserVerification:   "required",
+		},
+		Attestation: "none",
+	}
+}
+
+// Get options to authenticate by a discoverable credential. Empty allowed credentials mean any credential of
+// the relying party.
+func (r *RelyingParty) GetRequestOptions(challenge []byte, allowCredentials []CredentialDescriptor) *RequestOptions {
+	return &RequestOptions{
+		Challenge:        EncodeBase64URL(challenge),
+		Timeout:          Timeout.Milliseconds(),
+		RPID:             r.ID,
+		AllowCredentials: allowCredentials,
+		UserVerification: "required",
+	}
+}
+
+// Verify the response of navigator.credentials.create() and get the created credential
+func (r *RelyingParty) VerifyRegistration(challenge, clientDataJSON, attestationObject []byte) (*Credential, error) {
+	if err := r.verifyClientData(clientDataJSON, clientDataTypeCreate, challenge); err != nil {
+		return nil, err
+	}
+
+	// Get authenticator data from attestation object
+	item, n, err := decodeCBOR(attestationObject)
+	if err != nil || n != len(attestationObject) {
+		return nil, ErrAttestation
+	}
+	attestation, ok := item.(map[interface{}]interface{})
+	if !ok {
+		return nil, ErrAttestation
+	}
+	if _, ok := attestation["fmt"].(string); !ok {
+		return nil, ErrAttestation
+	}
+	rawAuthData, ok := attestation["authData"].([]byte)
+	if !ok {
+		return nil, ErrAttestation
+	}
+
+	authData, err := r.verifyAuthData(rawAuthData)
+	if err != nil {
+		return nil, err
+	}
+	if authData.flags&flagAttested == 0 {
+		return nil, ErrAuthData
+	}
+	if _, err := parsePublicKey(authData.publicKey); err != nil {
+		return nil, err
+	}
+	return &Credential{
+		ID:        authData.credentialID,
+		PublicKey: authData.publicKey,
+		SignCount: authData.signCount,
+	}, nil
+}
+
+// Verify the response of navigator.credentials.get() by the credential's public key and get the new sign count.
+// Sign counts have to be increased unless authenticators don't support them, so cloned authenticators are detected.
+func (r *RelyingParty) VerifyAssertion(challenge, publicKey []byte, signCount uint32, clientDataJSON, rawAuthData,
+	signature []byte) (uint32, error) {
+	if err := r.verifyClientData(clientDataJSON, clientDataTypeGet, challenge); err != nil {
+		return 0, err
+	}
+	authData, err := r.verifyAuthData(rawAuthData)
+	if err != nil {
+		return 0, err
+	}
+
+	// Verify signature of authenticator data and client data hash
+	key, err := parsePublicKey(publicKey)
+	if err != nil {
+		return 0, err
+	}
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signedData := append(append([]byte{}, rawAuthData...), clientDataHash[:]...)
+	if !key.verify(signedData, signature) {
+		return 0, ErrSignature
+	}
+
+	// Check sign count
+	if (authData.signCount != 0 || signCount != 0) && authData.signCount <= signCount {
+		return 0, ErrSignCount
+	}
+	return authData.signCount, nil
+}
+
+// Verify type, challenge and origin of client data
+func (r *RelyingParty) verifyClientData(clientDataJSON []byte, clientDataType string, challenge []byte) error {
+	data := clientData{}
+	if err := json.Unmarshal(clientDataJSON, &data); err != nil {
+		return ErrClientData
+	}
+	if data.Type != clientDataType || data.CrossOrigin {
+		return ErrClientData
+	}
+	dataChallenge, err := DecodeBase64URL(data.Challenge)
+	if err != nil || subtle.ConstantTimeCompare(dataChallenge, challenge) != 1 {
+		return ErrClientData
+	}
+	for _, origin := range r.Origins {
+		if data.Origin == origin {
+			return nil
+		}
+	}
+	return ErrClientData
+}
+
+// Parse authenticator data and verify the relying party ID hash and flags. Attested credential data is parsed
+// if it's included, and extensions are ignored.
+func (r *RelyingParty) verifyAuthData(data []byte) (*authData, error) {
+	if len(data) < authDataMinSize {
+		return nil, ErrAuthData
+	}
+	rpIDHash := sha256.Sum256([]byte(r.ID))
+	if !bytes.Equal(data[:32], rpIDHash[:]) {
+		return nil, ErrAuthData
+	}
+	parsed := authData{
+		flags:     data[32],
+		signCount: binary.BigEndian.Uint32(data[33:37]),
+	}
+	if parsed.flags&flagUserPresent == 0 || parsed.flags&flagUserVerified == 0 {
+		return nil, ErrAuthData
+	}
+	if parsed.flags&flagAttested == 0 {
+		return &parsed, nil
+	}
+
+	// Attested credential data
+	data = data[authDataMinSize:]
+	if len(data) < aaguidSize+2 {
+		return nil, ErrAuthData
+	}
+	credentialIDLen := int(binary.BigEndian.Uint16(data[aaguidSize:]))
+	data = data[aaguidSize+2:]
+	if credentialIDLen == 0 || credentialIDLen > credentialIDMaxSize || len(data) < credentialIDLen {
+		return nil, ErrAuthData
+	}
+	parsed.credentialID = append([]byte{}, data[:credentialIDLen]...)
+	data = data[credentialIDLen:]
+	_, keyLen, err := decodeCBOR(data)
+	if err != nil {
+		return nil, ErrAuthData
+	}
+	parsed.publicKey = append([]byte{}, data[:keyLen]...)
+	return &parsed, nil
+}
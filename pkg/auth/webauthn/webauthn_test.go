@@ -0,0 +1,212 @@
+package webauthn
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testRP = &RelyingParty{ID: "ssup2ket.com", Name: "ssup2ket", Origins: []string{"https://ssup2ket.com"}}
+
+// Authenticator signing ceremonies like a browser
+type testAuthenticator struct {
+	credentialID []byte
+	publicKey    []byte
+	sign         func(data []byte) []byte
+}
+
+func newES256Authenticator(t *testing.T) *testAuthenticator {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	x, y := make([]byte, 32), make([]byte, 32)
+	key.X.FillBytes(x)
+	key.Y.FillBytes(y)
+	return &testAuthenticator{
+		credentialID: []byte("es256-credential"),
+		publicKey:    encodeCBOR(map[interface{}]interface{}{1: 2, 3: AlgES256, -1: 1, -2: x, -3: y}),
+		sign: func(data []byte) []byte {
+			digest := sha256.Sum256(data)
+			signature, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+			require.NoError(t, err)
+			return signature
+		},
+	}
+}
+
+func newEdDSAAuthenticator(t *testing.T) *testAuthenticator {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return &testAuthenticator{
+		credentialID: []byte("eddsa-credential"),
+		publicKey:    encodeCBOR(map[interface{}]interface{}{1: 1, 3: AlgEdDSA, -1: 6, -2: []byte(publicKey)}),
+		sign: func(data []byte) []byte {
+			return ed25519.Sign(privateKey, data)
+		},
+	}
+}
+
+func (a *testAuthenticator) getAuthData(rpID string, flags byte, signCount uint32, attested bool) []byte {
+	rpIDHash := sha256.Sum256([]byte(rpID))
+	data := append(rpIDHash[:], flags, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(data[33:], signCount)
+	if attested {
+		data = append(data, make([]byte, aaguidSize)...)
+		data = append(data, byte(len(a.credentialID)>>8), byte(len(a.credentialID)))
+		data = append(data, a.credentialID...)
+		data = append(data, a.publicKey...)
+	}
+	return data
+}
+
+func getClientDataJSON(t *testing.T, clientDataType string, challenge []byte, origin string) []byte {
+	data, err := json.Marshal(clientData{Type: clientDataType, Challenge: EncodeBase64URL(challenge), Origin: origin})
+	require.NoError(t, err)
+	return data
+}
+
+func TestRegistrationAndAssertion(t *testing.T) {
+	for _, authenticator := range []*testAuthenticator{newES256Authenticator(t), newEdDSAAuthenticator(t)} {
+		challenge, err := GenerateChallenge()
+		require.NoError(t, err)
+
+		// Register
+		authData := authenticator.getAuthData(testRP.ID, flagUserPresent|flagUserVerified|flagAttested, 0, true)
+		attestationObject := encodeCBOR(map[interface{}]interface{}{
+			"fmt":      "none",
+			"attStmt":  map[interface{}]interface{}{},
+			"authData": authData,
+		})
+		credential, err := testRP.VerifyRegistration(challenge,
+			getClientDataJSON(t, clientDataTypeCreate, challenge, "https://ssup2ket.com"), attestationObject)
+		require.NoError(t, err)
+		require.Equal(t, authenticator.credentialID, credential.ID)
+		require.Equal(t, authenticator.publicKey, credential.PublicKey)
+
+		// Assert
+		clientDataJSON := getClientDataJSON(t, clientDataTypeGet, challenge, "https://ssup2ket.com")
+		clientDataHash := sha256.Sum256(clientDataJSON)
+		authData = authenticator.getAuthData(testRP.ID, flagUserPresent|flagUserVerified, 1, false)
+		signature := authenticator.sign(append(append([]byte{}, authData...), clientDataHash[:]...))
+
+		signCount, err := testRP.VerifyAssertion(challenge, credential.PublicKey, credential.SignCount, clientDataJSON,
+			authData, signature)
+		require.NoError(t, err)
+		require.Equal(t, uint32(1), signCount)
+
+		// Cloned authenticator doesn't increase the sign count
+		_, err = testRP.VerifyAssertion(challenge, credential.PublicKey, signCount, clientDataJSON, authData, signature)
+		require.Equal(t, ErrSignCount, err)
+
+		// Signature of other data
+		_, err = testRP.VerifyAssertion(challenge, credential.PublicKey, 0, clientDataJSON,
+			authenticator.getAuthData(testRP.ID, flagUserPresent|flagUserVerified, 2, false), signature)
+		require.Equal(t, ErrSignature, err)
+	}
+}
+
+func TestVerifyAssertionWrong(t *testing.T) {
+	authenticator := newES256Authenticator(t)
+	challenge, err := GenerateChallenge()
+	require.NoError(t, err)
+	authData := authenticator.getAuthData(testRP.ID, flagUserPresent|flagUserVerified, 0, false)
+
+	tests := map[string]struct {
+		clientDataJSON []byte
+		authData       []byte
+		err            error
+	}{
+		"wrong type": {getClientDataJSON(t, clientDataTypeCreate, challenge, "https://ssup2ket.com"), authData, ErrClientData},
+		"wrong challenge": {getClientDataJSON(t, clientDataTypeGet, []byte("wrong-challenge"), "https://ssup2ket.com"),
+			authData, ErrClientData},
+		"wrong origin": {getClientDataJSON(t, clientDataTypeGet, challenge, "https://phishing.com"), authData, ErrClientData},
+		"wrong RP ID": {getClientDataJSON(t, clientDataTypeGet, challenge, "https://ssup2ket.com"),
+			authenticator.getAuthData("phishing.com", flagUserPresent|flagUserVerified, 0, false), ErrAuthData},
+		"user not verified": {getClientDataJSON(t, clientDataTypeGet, challenge, "https://ssup2ket.com"),
+			authenticator.getAuthData(testRP.ID, flagUserPresent, 0, false), ErrAuthData},
+	}
+	for name, test := range tests {
+		clientDataHash := sha256.Sum256(test.clientDataJSON)
+		signature := authenticator.sign(append(append([]byte{}, test.authData...), clientDataHash[:]...))
+		_, err := testRP.VerifyAssertion(challenge, authenticator.publicKey, 0, test.clientDataJSON, test.authData, signature)
+		require.Equal(t, test.err, err, name)
+	}
+}
+
+func TestParsePublicKeyWrong(t *testing.T) {
+	authenticator := newES256Authenticator(t)
+	for _, data := range [][]byte{
+		nil,
+		append(append([]byte{}, authenticator.publicKey...), 0),
+		encodeCBOR(map[interface{}]interface{}{1: 2, 3: AlgRS256, -1: 1, -2: make([]byte, 32), -3: make([]byte, 32)}),
+		encodeCBOR(map[interface{}]interface{}{1: 2, 3: AlgES256, -1: 1, -2: make([]byte, 32), -3: make([]byte, 32)}),
+	} {
+		_, err := parsePublicKey(data)
+		require.Equal(t, ErrPublicKey, err)
+	}
+}
+
+func TestDecodeCBORWrong(t *testing.T) {
+	for _, data := range [][]byte{
+		{},
+		{0x5f},                   // Indefinite byte string
+		{0x43, 0x01},             // Short byte string
+		{0x9a, 0xff, 0xff, 0xff}, // Huge array
+		{0xa2, 0x01, 0x01, 0x01, 0x01},
+		{0xf9, 0x00, 0x00}, // Float
+	} {
+		_, _, err := decodeCBOR(data)
+		require.Error(t, err, data)
+	}
+}
+
+// Encode CBOR with canonical map key order for tests
+func encodeCBOR(item interface{}) []byte {
+	head := func(major byte, arg uint64) []byte {
+		switch {
+		case arg < 24:
+			return []byte{major<<5 | byte(arg)}
+		case arg < 1<<8:
+			return []byte{major<<5 | 24, byte(arg)}
+		default:
+			return []byte{major<<5 | 25, byte(arg >> 8), byte(arg)}
+		}
+	}
+
+	switch v := item.(type) {
+	case int:
+		if v < 0 {
+			return head(cborNegInt, uint64(-1-v))
+		}
+		return head(cborUint, uint64(v))
+	case []byte:
+		return append(head(cborBytes, uint64(len(v))), v...)
+	case string:
+		return append(head(cborText, uint64(len(v))), v...)
+	case map[interface{}]interface{}:
+		keys := [][]byte{}
+		for key := range v {
+			keys = append(keys, encodeCBOR(key))
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			return len(keys[i]) < len(keys[j]) || (len(keys[i]) == len(keys[j]) && string(keys[i]) < string(keys[j]))
+		})
+		data := head(cborMap, uint64(len(v)))
+		for _, key := range keys {
+			decoded, _, _ := decodeCBOR(key)
+			if i, ok := decoded.(int64); ok {
+				decoded = int(i)
+			}
+			data = append(append(data, key...), encodeCBOR(v[decoded])...)
+		}
+		return data
+	}
+	panic("unsupported CBOR item")
+}
@@ -26,3 +26,7 @@ func NewV4() EntityUUID {
 func FromStringOrNil(input string) EntityUUID {
 	return EntityUUID{UUID: gouuid.FromStringOrNil(input)}
 }
+
+func FromBytesOrNil(input []byte) EntityUUID {
+	return EntityUUID{UUID: gouuid.FromBytesOrNil(input)}
+}
@@ -0,0 +1,219 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Message is a plain text mail
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Sender sends mails from its sender address
+type Sender interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// Timeout of sending a mail by SMTP if the context doesn't have an earlier deadline
+const SMTPTimeout = 30 * time.Second
+
+// SMTP sender. STARTTLS is used if the server supports it, and PLAIN auth is used only if the user is set.
+type SMTPSender struct {
+	addr string
+	host string
+	auth smtp.Auth
+	from string
+}
+
+func NewSMTPSender(addr, user, passwd, from string) (*SMTPSender, error) {
+	host := addr
+	if i := strings.LastIndex(addr, ":"); i >= 0 {
+		host = addr[:i]
+	}
+	if host == "" {
+		return nil, fmt.Errorf("no SMTP host")
+	}
+	if _, err := mail.ParseAddress(from); err != nil {
+		return nil, fmt.Errorf("wrong from address")
+	}
+
+	sender := SMTPSender{
+		addr: addr,
+		host: host,
+		from: from,
+	}
+	if user != "" {
+		sender.auth = smtp.PlainAuth("", user, passwd, host)
+	}
+	return &sender, nil
+}
+
+// Send a mail like smtp.SendMail, but the connection has the deadline of the context or the SMTP timeout,
+// so a slow or stuck SMTP server can't block the sender forever.
+func (s *SMTPSender) Send(ctx context.Context, msg *Message) error {
+	data, err := getMessageData(s.from, msg, time.Now())
+	if err != nil {
+		return err
+	}
+
+	// Connect with the deadline
+	deadline := time.Now().Add(SMTPTimeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	dialer := net.Dialer{Deadline: deadline}
+	conn, err := dialer.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	// Close the connection if the context is canceled before the deadline
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	return s.sendData(client, msg.To, data)
+}
+
+func (s *SMTPSender) sendData(client *smtp.Client, to string, data []byte) error {
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return err
+		}
+	}
+	if s.auth != nil {
+		if ok, _ := client.Extension("AUTH"); !ok {
+			return fmt.Errorf("SMTP server doesn't support AUTH")
+		}
+		if err := client.Auth(s.auth); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(s.from); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write(data); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// Log sender logs mails instead of sending them for local use. Mails are logged with their bodies,
+// so it shouldn't be used in production.
+type LogSender struct {
+	from string
+}
+
+func NewLogSender(from string) *LogSender {
+	return &LogSender{
+		from: from,
+	}
+}
+
+func (l *LogSender) Send(ctx context.Context, msg *Message) error {
+	if _, err := getMessageData(l.from, msg, time.Now()); err != nil {
+		return err
+	}
+	log.Ctx(ctx).Info().Str("from", l.from).Str("to", msg.To).Str("subject", msg.Subject).Str("body", msg.Body).Msg("Mail is logged")
+	return nil
+}
+
+// File sender appends mails to a file instead of sending them for local use. Mails are separated by empty lines.
+type FileSender struct {
+	path string
+	from string
+	lock sync.Mutex
+}
+
+func NewFileSender(path, from string) *FileSender {
+	return &FileSender{
+		path: path,
+		from: from,
+	}
+}
+
+func (f *FileSender) Send(ctx context.Context, msg *Message) error {
+	data, err := getMessageData(f.from, msg, time.Now())
+	if err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if _, err := file.Write(append(data, "\r\n"...)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Get the RFC 5322 message data of the mail. Headers can't have line breaks not to inject headers.
+func getMessageData(from string, msg *Message, now time.Time) ([]byte, error) {
+	if _, err := mail.ParseAddress(msg.To); err != nil {
+		return nil, fmt.Errorf("wrong to address")
+	}
+	if strings.ContainsAny(from, "\r\n") || strings.ContainsAny(msg.To, "\r\n") || strings.ContainsAny(msg.Subject, "\r\n") {
+		return nil, fmt.Errorf("line break in mail header")
+	}
+
+	var data bytes.Buffer
+	data.WriteString("From: " + from + "\r\n")
+	data.WriteString("To: " + msg.To + "\r\n")
+	data.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", msg.Subject) + "\r\n")
+	data.WriteString("Date: " + now.Format(time.RFC1123Z) + "\r\n")
+	data.WriteString("MIME-Version: 1.0\r\n")
+	data.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	data.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	data.WriteString("\r\n")
+	body := strings.ReplaceAll(msg.Body, "\r\n", "\n")
+	data.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	if !strings.HasSuffix(body, "\n") {
+		data.WriteString("\r\n")
+	}
+	return data.Bytes(), nil
+}
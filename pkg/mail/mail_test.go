@@ -0,0 +1,163 @@
+package mail
+
+import (
+	"bufio"
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testFrom = "no-reply@ssup2ket.com"
+	testTo   = "test@test.com"
+)
+
+func TestGetMessageData(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	data, err := getMessageData(testFrom, &Message{To: testTo, Subject: "Verify your email", Body: "line1\nline2"}, now)
+	require.NoError(t, err)
+	require.Equal(t, "From: no-reply@ssup2ket.com\r\n"+
+		"To: test@test.com\r\n"+
+		"Subject: Verify your email\r\n"+
+		"Date: Fri, 02 Jan 2026 03:04:05 +0000\r\n"+
+		"MIME-Version: 1.0\r\n"+
+		"Content-Type: text/plain; charset=utf-8\r\n"+
+		"Content-Transfer-Encoding: 8bit\r\n"+
+		"\r\n"+
+		"line1\r\nline2\r\n", string(data))
+}
+
+func TestGetMessageDataEncodedSubject(t *testing.T) {
+	data, err := getMessageData(testFrom, &Message{To: testTo, Subject: "이메일 인증", Body: "body"}, time.Now())
+	require.NoError(t, err)
+	require.Contains(t, string(data), "Subject: =?utf-8?q?")
+}
+
+func TestGetMessageDataHeaderInjection(t *testing.T) {
+	_, err := getMessageData(testFrom, &Message{To: testTo, Subject: "subject\r\nBcc: other@test.com", Body: "body"}, time.Now())
+	require.Error(t, err)
+}
+
+func TestGetMessageDataWrongTo(t *testing.T) {
+	_, err := getMessageData(testFrom, &Message{To: "test", Subject: "subject", Body: "body"}, time.Now())
+	require.Error(t, err)
+}
+
+func TestNewSMTPSender(t *testing.T) {
+	_, err := NewSMTPSender("smtp.ssup2ket.com:587", "user", "passwd", testFrom)
+	require.NoError(t, err)
+	_, err = NewSMTPSender(":587", "", "", testFrom)
+	require.Error(t, err)
+	_, err = NewSMTPSender("smtp.ssup2ket.com:587", "", "", "no-reply")
+	require.Error(t, err)
+}
+
+func TestSMTPSenderSend(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	// Fake SMTP server without STARTTLS and AUTH
+	received := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		reader := bufio.NewReader(conn)
+		conn.Write([]byte("220 localhost ESMTP\r\n"))
+		var data strings.Builder
+		inData := false
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			if inData {
+				if line == ".\r\n" {
+					inData = false
+					received <- data.String()
+					conn.Write([]byte("250 OK\r\n"))
+				} else {
+					data.WriteString(line)
+				}
+				continue
+			}
+			switch {
+			case strings.HasPrefix(line, "EHLO"):
+				conn.Write([]byte("250 localhost\r\n"))
+			case strings.HasPrefix(line, "DATA"):
+				inData = true
+				conn.Write([]byte("354 Start mail input\r\n"))
+			case strings.HasPrefix(line, "QUIT"):
+				conn.Write([]byte("221 Bye\r\n"))
+				return
+			default:
+				conn.Write([]byte("250 OK\r\n"))
+			}
+		}
+	}()
+
+	sender, err := NewSMTPSender(listener.Addr().String(), "", "", testFrom)
+	require.NoError(t, err)
+	err = sender.Send(context.Background(), &Message{To: testTo, Subject: "subject", Body: "body"})
+	require.NoError(t, err)
+	require.Contains(t, <-received, "Subject: subject")
+}
+
+func TestSMTPSenderSendTimeout(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	// SMTP server which never responds
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	sender, err := NewSMTPSender(listener.Addr().String(), "", "", testFrom)
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err = sender.Send(ctx, &Message{To: testTo, Subject: "subject", Body: "body"})
+	require.Error(t, err)
+	require.Less(t, int64(time.Since(start)), int64(5*time.Second))
+}
+
+func TestLogSender(t *testing.T) {
+	sender := NewLogSender(testFrom)
+	require.NoError(t, sender.Send(context.Background(), &Message{To: testTo, Subject: "subject", Body: "body"}))
+}
+
+func TestFileSender(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mail")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "mails.txt")
+	sender := NewFileSender(path, testFrom)
+	require.NoError(t, sender.Send(context.Background(), &Message{To: testTo, Subject: "subject1", Body: "body1"}))
+	require.NoError(t, sender.Send(context.Background(), &Message{To: testTo, Subject: "subject2", Body: "body2"}))
+
+	// Mails are appended
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, 2, strings.Count(string(data), "From: "+testFrom))
+	require.Contains(t, string(data), "Subject: subject1")
+	require.Contains(t, string(data), "Subject: subject2")
+}